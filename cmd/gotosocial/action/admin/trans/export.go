@@ -26,10 +26,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/trans"
 )
 
-// Export exports info from the database into a file
+// Export exports info from the database (and optionally storage) into a file
 var Export action.GTSAction = func(ctx context.Context) error {
 	var state state.State
 
@@ -41,14 +42,24 @@ var Export action.GTSAction = func(ctx context.Context) error {
 	// Set the state DB connection
 	state.DB = dbConn
 
-	exporter := trans.NewExporter(dbConn)
+	media := config.GetAdminTransIncludeMedia()
+	if media {
+		// Only need storage
+		// when exporting media.
+		state.Storage, err = gtsstorage.AutoConfig()
+		if err != nil {
+			return fmt.Errorf("error creating storage backend: %w", err)
+		}
+	}
+
+	exporter := trans.NewExporter(dbConn, state.Storage)
 
 	path := config.GetAdminTransPath()
 	if path == "" {
 		return errors.New("no path set")
 	}
 
-	if err := exporter.ExportMinimal(ctx, path); err != nil {
+	if err := exporter.Export(ctx, path, media); err != nil {
 		return err
 	}
 
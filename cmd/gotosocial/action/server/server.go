@@ -65,6 +65,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/web"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 // Start creates and starts a gotosocial server
@@ -298,6 +299,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		mediaManager,
 		state,
 		emailSender,
+		webpush.NewSender(client, state),
 		visFilter,
 		intFilter,
 	)
@@ -308,17 +310,39 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	state.Workers.Delivery.Init(client)
 	state.Workers.Client.Process = process.Workers().ProcessFromClientAPI
 	state.Workers.Federator.Process = process.Workers().ProcessFromFediAPI
+	state.Workers.Delivery.Health.OnChange = process.Admin().StoreDeliveryHealth
 
 	// Now start workers!
 	state.Workers.Start()
 
+	// Generate the VAPID key pair used to
+	// send Web Push notifications, if needed.
+	if _, err := webpush.GetOrCreateVAPIDKeyPair(ctx, state.DB); err != nil {
+		return fmt.Errorf("error getting vapid key pair: %w", err)
+	}
+
 	// Schedule notif tasks for all existing poll expiries.
 	if err := process.Polls().ScheduleAll(ctx); err != nil {
 		return fmt.Errorf("error scheduling poll expiries: %w", err)
 	}
 
+	// Schedule publishing of all pending scheduled statuses.
+	if err := process.Status().ScheduleAll(ctx); err != nil {
+		return fmt.Errorf("error scheduling statuses: %w", err)
+	}
+
+	// Schedule fetching of domain permission subscriptions.
+	if err := process.Admin().ScheduleDomainPermissionSubscriptions(); err != nil {
+		return fmt.Errorf("error scheduling domain permission subscriptions: %w", err)
+	}
+
+	// Schedule recalculation of trends.
+	if err := process.Trends().ScheduleRefresh(); err != nil {
+		return fmt.Errorf("error scheduling trends refresh: %w", err)
+	}
+
 	// Initialize metrics.
-	if err := metrics.Initialize(state.DB); err != nil {
+	if err := metrics.Initialize(state); err != nil {
 		return fmt.Errorf("error initializing metrics: %w", err)
 	}
 
@@ -473,6 +497,22 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error filling worker queues: %w", err)
 	}
 
+	// Load delivery health of instances we've been failing to deliver to.
+	if err := process.Admin().FillDeliveryHealth(ctx); err != nil {
+		return fmt.Errorf("error filling delivery health: %w", err)
+	}
+
+	// Resume any account data imports interrupted by last shutdown.
+	if err := process.Account().ResumeImports(ctx); err != nil {
+		return fmt.Errorf("error resuming imports: %w", err)
+	}
+
+	// Rebuild interrupted account archives,
+	// and schedule removal of expired ones.
+	if err := process.Account().ScheduleArchives(ctx); err != nil {
+		return fmt.Errorf("error scheduling account archives: %w", err)
+	}
+
 	// catch shutdown signals from the operating system
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
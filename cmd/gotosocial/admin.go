@@ -146,6 +146,19 @@ func adminCommands() *cobra.Command {
 	config.AddAdminAccountPassword(adminAccountPasswordCmd)
 	adminAccountCmd.AddCommand(adminAccountPasswordCmd)
 
+	adminAccountDisable2FACmd := &cobra.Command{
+		Use:   "disable-2fa",
+		Short: "disable two factor authentication for the given local account",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.Disable2FA)
+		},
+	}
+	config.AddAdminAccount(adminAccountDisable2FACmd)
+	adminAccountCmd.AddCommand(adminAccountDisable2FACmd)
+
 	adminCmd.AddCommand(adminAccountCmd)
 
 	/*
@@ -162,7 +175,7 @@ func adminCommands() *cobra.Command {
 			return run(cmd.Context(), trans.Export)
 		},
 	}
-	config.AddAdminTrans(adminExportCmd)
+	config.AddAdminExport(adminExportCmd)
 	adminCmd.AddCommand(adminExportCmd)
 
 	adminImportCmd := &cobra.Command{
@@ -251,6 +264,19 @@ func adminCommands() *cobra.Command {
 	config.AddAdminMediaPrune(adminMediaPruneRemoteCmd)
 	adminMediaPruneCmd.AddCommand(adminMediaPruneRemoteCmd)
 
+	adminMediaPruneDuplicatesCmd := &cobra.Command{
+		Use:   "duplicates",
+		Short: "prune duplicate media files from storage, sharing one file between identical media",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), prune.Duplicates)
+		},
+	}
+	config.AddAdminMediaPrune(adminMediaPruneDuplicatesCmd)
+	adminMediaPruneCmd.AddCommand(adminMediaPruneDuplicatesCmd)
+
 	adminMediaPruneAllCmd := &cobra.Command{
 		Use:   "all",
 		Short: "perform all media and emoji prune / cleaning commands",
@@ -33,7 +33,9 @@
 //	    scopes:
 //	      read: grants read access to everything
 //	      read:accounts: grants read access to accounts
+//	      read:applications: grants read access to applications and tokens
 //	      read:blocks: grant read access to blocks
+//	      read:bookmarks: grant read access to bookmarks
 //	      read:custom_emojis: grant read access to custom_emojis
 //	      read:favourites: grant read access to favourites
 //	      read:filters: grant read access to filters
@@ -41,6 +43,7 @@
 //	      read:lists: grant read access to lists
 //	      read:media: grant read access to media
 //	      read:mutes: grant read access to mutes
+//	      read:reports: grant read access to reports
 //	      read:search: grant read access to searches
 //	      read:statuses: grants read access to statuses
 //	      read:streaming: grants read access to streaming api
@@ -48,16 +51,33 @@
 //	      read:notifications: grants read access to notifications
 //	      write: grants write access to everything
 //	      write:accounts: grants write access to accounts
+//	      write:applications: grants write access to applications and tokens
 //	      write:blocks: grants write access to blocks
+//	      write:bookmarks: grants write access to bookmarks
+//	      write:conversations: grants write access to conversations
+//	      write:favourites: grants write access to favourites
 //	      write:filters: grants write access to filters
 //	      write:follows: grants write access to follows
 //	      write:lists: grants write access to lists
 //	      write:media: grants write access to media
 //	      write:mutes: grants write access to mutes
+//	      write:notifications: grants write access to notifications
+//	      write:reports: grants write access to reports
 //	      write:statuses: grants write access to statuses
 //	      write:user: grants write access to user-level info
+//	      follow: grants read and write access to blocks, follows, and mutes
 //	      admin: grants admin access to everything
-//	      admin:accounts: grants admin access to accounts
+//	      admin:read: grants admin read access to everything
+//	      admin:read:accounts: grants admin read access to accounts
+//	      admin:read:reports: grants admin read access to reports
+//	      admin:read:domain_allows: grants admin read access to domain allows
+//	      admin:read:domain_blocks: grants admin read access to domain blocks
+//	      admin:write: grants admin write access to everything
+//	      admin:write:accounts: grants admin write access to accounts
+//	      admin:write:reports: grants admin write access to reports
+//	      admin:write:domain_allows: grants admin write access to domain allows
+//	      admin:write:domain_blocks: grants admin write access to domain blocks
+//	      push: grants access to Web Push notifications
 //	  OAuth2 Application:
 //	    type: oauth2
 //	    flow: application
@@ -77,6 +77,13 @@ const (
 	// See https://www.w3.org/TR/activitystreams-vocabulary/#microsyntaxes
 	// and https://www.w3.org/TR/activitystreams-vocabulary/#dfn-tag
 	TagHashtag = "Hashtag"
+
+	// EmojiReact is not in the AS spec, but is used by Pleroma,
+	// Akkoma and others to federate emoji reactions. Incoming
+	// EmojiReacts are normalized to Likes with emoji content.
+	//
+	// See https://docs.pleroma.social/backend/development/ap_extensions/#emojireacts
+	ActivityEmojiReact = "EmojiReact"
 )
 
 // isActivity returns whether AS type name is of an Activity (NOT IntransitiveActivity).
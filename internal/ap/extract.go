@@ -246,6 +246,86 @@ func ExtractInReplyToURI(i WithInReplyTo) *url.URL {
 	return nil
 }
 
+// ExtractQuoteURI extracts the URI of the status quoted by
+// the given Statusable, if any. It checks the various quote
+// properties in use across the fediverse, before falling
+// back to FEP-e232 object links in the tag property.
+// Will return nil if no valid URI can be found.
+func ExtractQuoteURI(statusable Statusable) *url.URL {
+	unknown := statusable.GetUnknownProperties()
+	for _, key := range []string{
+		"quote",          // FEP-044f
+		"quoteUrl",       // Pleroma, Akkoma
+		"quoteUri",       // Fedibird
+		"_misskey_quote", // Misskey
+	} {
+		if iri := rawToIRI(unknown[key]); iri != nil {
+			// Found one we can use.
+			return iri
+		}
+	}
+
+	tagsProp := statusable.GetActivityStreamsTag()
+	if tagsProp == nil {
+		return nil
+	}
+
+	for iter := tagsProp.Begin(); iter != tagsProp.End(); iter = iter.Next() {
+		if !iter.IsActivityStreamsLink() {
+			continue
+		}
+
+		link := iter.GetActivityStreamsLink()
+		if link == nil {
+			continue
+		}
+
+		// Only links to ActivityPub objects are
+		// of interest, see FEP-e232 object links.
+		mediaTypeProp := link.GetActivityStreamsMediaType()
+		if mediaTypeProp == nil || !isObjectLinkMediaType(mediaTypeProp.Get()) {
+			continue
+		}
+
+		hrefProp := link.GetActivityStreamsHref()
+		if hrefProp != nil && hrefProp.IsIRI() {
+			return hrefProp.GetIRI()
+		}
+	}
+
+	return nil
+}
+
+// isObjectLinkMediaType returns whether the given
+// Link mediaType indicates a link to an ActivityPub
+// object, as described in FEP-e232.
+func isObjectLinkMediaType(mediaType string) bool {
+	mediaType = strings.ReplaceAll(mediaType, " ", "")
+	return mediaType == "application/activity+json" ||
+		mediaType == `application/ld+json;profile="https://www.w3.org/ns/activitystreams"`
+}
+
+// rawToIRI parses an IRI from the given raw JSON
+// value, which may be either a string, or an object
+// with an "id" property. Only http(s) IRIs are valid.
+func rawToIRI(raw any) *url.URL {
+	if obj, ok := raw.(map[string]any); ok {
+		raw = obj["id"]
+	}
+
+	str, ok := raw.(string)
+	if !ok || str == "" {
+		return nil
+	}
+
+	iri, err := url.Parse(str)
+	if err != nil || (iri.Scheme != "http" && iri.Scheme != "https") {
+		return nil
+	}
+
+	return iri
+}
+
 // ExtractItemsURIs extracts each URI it can
 // find for an item from the provided WithItems.
 func ExtractItemsURIs(i WithItems) []*url.URL {
@@ -604,6 +684,22 @@ func ExtractPubKeyFromKey(pkey vocab.W3IDSecurityV1PublicKey) (
 	return pubKey, pubKeyID, pubKeyOwner, nil
 }
 
+// ExtractReaction returns the emoji reaction of the given
+// Like, which may be either a unicode emoji, or a custom
+// emoji shortcode wrapped in colons, eg., ":blobcat:".
+// Misskey sets this as "_misskey_reaction", others (and
+// normalized EmojiReacts) as content. An empty string
+// means the Like is just a plain old fave.
+func ExtractReaction(i Reactable) string {
+	if reaction, ok := i.GetUnknownProperties()["_misskey_reaction"].(string); ok {
+		if reaction = strings.TrimSpace(reaction); reaction != "" {
+			return reaction
+		}
+	}
+
+	return strings.TrimSpace(ExtractContent(i).Content)
+}
+
 // ExtractContent returns an intermediary representation of
 // the given interface's Content and/or ContentMap property.
 func ExtractContent(i WithContent) gtsmodel.Content {
@@ -1081,6 +1177,8 @@ func ExtractInteractionPolicy(
 		CanLike:     extractCanLike(policy.GetGoToSocialCanLike(), owner),
 		CanReply:    extractCanReply(policy.GetGoToSocialCanReply(), owner),
 		CanAnnounce: extractCanAnnounce(policy.GetGoToSocialCanAnnounce(), owner),
+		CanQuote:    extractCanQuote(policy, owner),
+		CanReact:    extractCanReact(policy, owner),
 	}
 }
 
@@ -1156,11 +1254,75 @@ func extractCanAnnounce(
 	}
 }
 
+// extractCanQuote extracts canQuote rules from the given
+// policy. Since canQuote is not part of our vocabulary,
+// the rules are parsed from the raw unknown properties.
+func extractCanQuote(
+	policy vocab.GoToSocialInteractionPolicy,
+	owner *gtsmodel.Account,
+) gtsmodel.PolicyRules {
+	withRules, ok := policy.GetUnknownProperties()["canQuote"].(map[string]any)
+	if !ok {
+		return gtsmodel.PolicyRules{}
+	}
+
+	return gtsmodel.PolicyRules{
+		Always:       rawToPolicyValues(withRules["always"], owner),
+		WithApproval: rawToPolicyValues(withRules["approvalRequired"], owner),
+	}
+}
+
+// extractCanReact extracts canReact rules from the given
+// policy. Like canQuote, canReact is not part of our
+// vocabulary, so it's parsed from the unknown properties.
+func extractCanReact(
+	policy vocab.GoToSocialInteractionPolicy,
+	owner *gtsmodel.Account,
+) gtsmodel.PolicyRules {
+	withRules, ok := policy.GetUnknownProperties()["canReact"].(map[string]any)
+	if !ok {
+		return gtsmodel.PolicyRules{}
+	}
+
+	return gtsmodel.PolicyRules{
+		Always:       rawToPolicyValues(withRules["always"], owner),
+		WithApproval: rawToPolicyValues(withRules["approvalRequired"], owner),
+	}
+}
+
+// rawToPolicyValues converts the given raw JSON value,
+// which may be a single IRI or an array of IRIs, into
+// PolicyValues, in the same way as extractPolicyValues.
+func rawToPolicyValues(
+	raw any,
+	owner *gtsmodel.Account,
+) gtsmodel.PolicyValues {
+	rawArr, ok := raw.([]any)
+	if !ok {
+		rawArr = []any{raw}
+	}
+
+	iris := make([]*url.URL, 0, len(rawArr))
+	for _, rawIRI := range rawArr {
+		if iri := rawToIRI(rawIRI); iri != nil {
+			iris = append(iris, iri)
+		}
+	}
+
+	return irisToPolicyValues(iris, owner)
+}
+
 func extractPolicyValues[T WithIRI](
 	prop Property[T],
 	owner *gtsmodel.Account,
 ) gtsmodel.PolicyValues {
-	iris := getIRIs(prop)
+	return irisToPolicyValues(getIRIs(prop), owner)
+}
+
+func irisToPolicyValues(
+	iris []*url.URL,
+	owner *gtsmodel.Account,
+) gtsmodel.PolicyValues {
 	PolicyValues := make(gtsmodel.PolicyValues, 0, len(iris))
 
 	for _, iri := range iris {
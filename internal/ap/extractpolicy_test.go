@@ -132,6 +132,107 @@ func (suite *ExtractPolicyTestSuite) TestExtractPolicy() {
 	suite.EqualValues(expectedPolicy, policy)
 }
 
+func (suite *ExtractPolicyTestSuite) TestExtractPolicyCanQuote() {
+	rawNote := `{
+  "@context": [
+    "https://gotosocial.org/ns",
+    "https://www.w3.org/ns/activitystreams"
+  ],
+  "content": "quote me if you like",
+  "interactionPolicy": {
+    "canLike": {
+      "always": "https://www.w3.org/ns/activitystreams#Public"
+    },
+    "canReply": {
+      "always": "https://www.w3.org/ns/activitystreams#Public"
+    },
+    "canAnnounce": {
+      "always": "https://www.w3.org/ns/activitystreams#Public"
+    },
+    "canQuote": {
+      "always": [
+        "http://localhost:8080/users/the_mighty_zork",
+        "http://localhost:8080/users/the_mighty_zork/following"
+      ],
+      "approvalRequired": "https://www.w3.org/ns/activitystreams#Public"
+    }
+  },
+  "type": "Note"
+}`
+
+	statusable, err := ap.ResolveStatusable(
+		context.Background(),
+		io.NopCloser(
+			bytes.NewBufferString(rawNote),
+		),
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	policy := ap.ExtractInteractionPolicy(
+		statusable,
+		suite.testAccounts["local_account_1"],
+	)
+
+	expectedCanQuote := gtsmodel.PolicyRules{
+		Always: gtsmodel.PolicyValues{
+			gtsmodel.PolicyValueAuthor,
+			gtsmodel.PolicyValueFollowing,
+		},
+		WithApproval: gtsmodel.PolicyValues{
+			gtsmodel.PolicyValuePublic,
+		},
+	}
+	suite.EqualValues(expectedCanQuote, policy.CanQuote)
+}
+
+func (suite *ExtractPolicyTestSuite) TestExtractPolicyCanReact() {
+	rawNote := `{
+  "@context": [
+    "https://gotosocial.org/ns",
+    "https://www.w3.org/ns/activitystreams"
+  ],
+  "content": "react to me if you like",
+  "interactionPolicy": {
+    "canLike": {
+      "always": "https://www.w3.org/ns/activitystreams#Public"
+    },
+    "canReact": {
+      "always": [
+        "http://localhost:8080/users/the_mighty_zork",
+        "http://localhost:8080/users/the_mighty_zork/followers"
+      ]
+    }
+  },
+  "type": "Note"
+}`
+
+	statusable, err := ap.ResolveStatusable(
+		context.Background(),
+		io.NopCloser(
+			bytes.NewBufferString(rawNote),
+		),
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	policy := ap.ExtractInteractionPolicy(
+		statusable,
+		suite.testAccounts["local_account_1"],
+	)
+
+	expectedCanReact := gtsmodel.PolicyRules{
+		Always: gtsmodel.PolicyValues{
+			gtsmodel.PolicyValueAuthor,
+			gtsmodel.PolicyValueFollowers,
+		},
+		WithApproval: gtsmodel.PolicyValues{},
+	}
+	suite.EqualValues(expectedCanReact, policy.CanReact)
+}
+
 func TestExtractPolicyTestSuite(t *testing.T) {
 	suite.Run(t, &ExtractPolicyTestSuite{})
 }
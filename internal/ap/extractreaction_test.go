@@ -0,0 +1,105 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+)
+
+type ExtractReactionTestSuite struct {
+	APTestSuite
+}
+
+func (suite *ExtractReactionTestSuite) resolveLike(rawActivity string) ap.Reactable {
+	request := httptest.NewRequest(
+		http.MethodPost,
+		"http://localhost:8080/users/the_mighty_zork/inbox",
+		bytes.NewBufferString(rawActivity),
+	)
+
+	activity, ok, errWithCode := ap.ResolveIncomingActivity(request)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.True(ok)
+
+	like, ok := activity.(ap.Reactable)
+	if !ok {
+		suite.FailNow("", "%T was not Reactable", activity)
+	}
+
+	return like
+}
+
+func (suite *ExtractReactionTestSuite) TestExtractEmojiReact() {
+	like := suite.resolveLike(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://pleroma.example.org/activities/1",
+  "type": "EmojiReact",
+  "actor": "https://pleroma.example.org/users/someone",
+  "object": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+  "content": "🐸"
+}`)
+	suite.Equal("🐸", ap.ExtractReaction(like))
+}
+
+func (suite *ExtractReactionTestSuite) TestExtractMisskeyReaction() {
+	like := suite.resolveLike(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://misskey.example.org/likes/1",
+  "type": "Like",
+  "actor": "https://misskey.example.org/users/someone",
+  "object": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+  "content": ":blobcat:",
+  "_misskey_reaction": ":blobcat:",
+  "tag": [
+    {
+      "id": "https://misskey.example.org/emojis/blobcat",
+      "type": "Emoji",
+      "name": ":blobcat:",
+      "icon": {
+        "type": "Image",
+        "mediaType": "image/png",
+        "url": "https://misskey.example.org/files/blobcat.png"
+      }
+    }
+  ]
+}`)
+	suite.Equal(":blobcat:", ap.ExtractReaction(like))
+}
+
+func (suite *ExtractReactionTestSuite) TestExtractPlainLike() {
+	like := suite.resolveLike(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/likes/1",
+  "type": "Like",
+  "actor": "https://example.org/users/someone",
+  "object": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY"
+}`)
+	suite.Empty(ap.ExtractReaction(like))
+}
+
+func TestExtractReactionTestSuite(t *testing.T) {
+	suite.Run(t, &ExtractReactionTestSuite{})
+}
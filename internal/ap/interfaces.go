@@ -196,6 +196,7 @@ type Statusable interface {
 	WithName
 	WithInReplyTo
 	WithPublished
+	WithUpdated
 	WithURL
 	WithAttributedTo
 	WithTo
@@ -208,6 +209,7 @@ type Statusable interface {
 	WithReplies
 	WithInteractionPolicy
 	WithApprovedBy
+	WithUnknownProperties
 }
 
 // Pollable represents the minimum activitypub interface for representing a 'poll' (it's a subset of a status).
@@ -294,6 +296,16 @@ type Likeable interface {
 	WithObject
 }
 
+// Reactable represents the minimum interface for an activitystreams
+// 'like' activity which may carry an emoji reaction as its content.
+type Reactable interface {
+	Likeable
+	WithContent
+	WithTag
+
+	GetUnknownProperties() map[string]interface{}
+}
+
 // Blockable represents the minimum interface for an activitystreams 'block' activity.
 type Blockable interface {
 	WithJSONLDId
@@ -696,6 +708,12 @@ type WithPolicyRules interface {
 	GetGoToSocialApprovalRequired() vocab.GoToSocialApprovalRequiredProperty
 }
 
+// WithUnknownProperties represents an object with properties
+// not covered by our vocabulary, such as quote properties.
+type WithUnknownProperties interface {
+	GetUnknownProperties() map[string]interface{}
+}
+
 // WithApprovedBy represents a Statusable with the approvedBy property.
 type WithApprovedBy interface {
 	GetGoToSocialApprovedBy() vocab.GoToSocialApprovedByProperty
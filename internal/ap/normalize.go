@@ -638,20 +638,22 @@ func NormalizeOutgoingInteractionPolicyProp(item WithInteractionPolicy, rawJSON
 		"canLike",
 		"canReply",
 		"canAnnounce",
+		"canQuote",
+		"canReact",
 	} {
-		// Either "canAnnounce",
-		// "canLike", or "canApprove"
+		// Either "canAnnounce", "canLike",
+		// "canReply", "canQuote" or "canReact".
 		rulesVal, ok := policyMap[rulesKey]
 		if !ok {
 			// Not set.
-			return
+			continue
 		}
 
 		rulesValMap, ok := rulesVal.(map[string]interface{})
 		if !ok {
 			// Malformed or not
 			// present skip.
-			return
+			continue
 		}
 
 		for _, PolicyValuesKey := range []string{
@@ -676,6 +678,28 @@ func NormalizeOutgoingInteractionPolicyProp(item WithInteractionPolicy, rawJSON
 	}
 }
 
+// normalizeEmojiReact rewrites the type of the given raw
+// JSON from EmojiReact to Like, as EmojiReact is not part
+// of our vocabulary. The emoji itself is kept as content,
+// so it will be picked up by ExtractReaction. The object
+// of an Undo is rewritten in the same way, if embedded.
+func normalizeEmojiReact(rawJSON map[string]interface{}) {
+	if rawJSON["type"] == ActivityEmojiReact {
+		rawJSON["type"] = ActivityLike
+		return
+	}
+
+	if rawJSON["type"] != ActivityUndo {
+		// Nothing to change.
+		return
+	}
+
+	object, ok := rawJSON["object"].(map[string]interface{})
+	if ok && object["type"] == ActivityEmojiReact {
+		object["type"] = ActivityLike
+	}
+}
+
 // NormalizeOutgoingObjectProp normalizes each Object entry in the rawJSON of the given
 // item by calling custom serialization / normalization functions on them in turn.
 //
@@ -276,6 +276,10 @@ func decodeType(
 	// Done with body.
 	_ = body.Close()
 
+	// Rewrite any non-standard
+	// EmojiReact types to Like.
+	normalizeEmojiReact(raw)
+
 	// Resolve an ActivityStreams type.
 	t, err := streams.ToType(ctx, raw)
 	if err != nil {
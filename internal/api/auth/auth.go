@@ -43,6 +43,8 @@ const (
 	AuthAccountDisabledPath = "/account_disabled"
 	// AuthCallbackPath is the API path for receiving callback tokens from external OIDC providers
 	AuthCallbackPath = "/callback"
+	// AuthTwoFactorPath is the API path for users to enter a 2FA code after signing in with their password
+	AuthTwoFactorPath = "/2fa"
 
 	/*
 		paths prefixed with 'oauth'
@@ -73,6 +75,8 @@ const (
 	sessionClientState   = "client_state"
 	sessionClaims        = "claims"
 	sessionAppID         = "app_id"
+	sessionTwoFactorID   = "2fa_userid"
+	sessionTwoFactorTry  = "2fa_attempts"
 )
 
 type Module struct {
@@ -97,6 +101,8 @@ func (m *Module) RouteAuth(attachHandler func(method string, path string, f ...g
 	attachHandler(http.MethodGet, AuthSignInPath, m.SignInGETHandler)
 	attachHandler(http.MethodPost, AuthSignInPath, m.SignInPOSTHandler)
 	attachHandler(http.MethodGet, AuthCallbackPath, m.CallbackGETHandler)
+	attachHandler(http.MethodGet, AuthTwoFactorPath, m.TwoFactorGETHandler)
+	attachHandler(http.MethodPost, AuthTwoFactorPath, m.TwoFactorPOSTHandler)
 }
 
 // RouteOauth routes all paths that should have an 'oauth' prefix
@@ -64,8 +64,12 @@ type AuthStandardTestSuite struct {
 }
 
 const (
-	sessionUserID   = "userid"
-	sessionClientID = "client_id"
+	sessionUserID       = "userid"
+	sessionClientID     = "client_id"
+	sessionRedirectURI  = "redirect_uri"
+	sessionScope        = "scope"
+	sessionTwoFactorID  = "2fa_userid"
+	sessionTwoFactorTry = "2fa_attempts"
 )
 
 func (suite *AuthStandardTestSuite) SetupSuite() {
@@ -138,6 +138,12 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := checkAppScopes(app, scope); errWithCode != nil {
+		m.clearSession(s)
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
@@ -216,6 +222,26 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	app, err := m.db.GetApplicationByClientID(c.Request.Context(), clientID)
+	if err != nil {
+		m.clearSession(s)
+		safe := fmt.Sprintf("application for %s %s could not be retrieved", sessionClientID, clientID)
+		var errWithCode gtserror.WithCode
+		if err == db.ErrNoEntries {
+			errWithCode = gtserror.NewErrorBadRequest(err, safe, oauth.HelpfulAdvice)
+		} else {
+			errWithCode = gtserror.NewErrorInternalError(err, safe, oauth.HelpfulAdvice)
+		}
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if errWithCode := checkAppScopes(app, scope); errWithCode != nil {
+		m.clearSession(s)
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	user, err := m.db.GetUserByID(c.Request.Context(), userID)
 	if err != nil {
 		m.clearSession(s)
@@ -318,6 +344,21 @@ func saveAuthFormToSession(s sessions.Session, form *apimodel.OAuthAuthorize) gt
 	return nil
 }
 
+// checkAppScopes checks that each of the requested
+// scopes is permitted by the scopes that the given
+// application registered with, so that an app can't
+// request more access than it was created with.
+func checkAppScopes(app *gtsmodel.Application, scope string) gtserror.WithCode {
+	appScopes := apiutil.ParseScopes(app.Scopes)
+	for _, wanted := range apiutil.ParseScopes(scope) {
+		if !appScopes.Permits(wanted) {
+			err := fmt.Errorf("requested scope %s is not permitted by scopes %q registered for application", wanted, app.Scopes)
+			return gtserror.NewErrorBadRequest(err, err.Error(), oauth.HelpfulAdvice)
+		}
+	}
+	return nil
+}
+
 func ensureUserIsAuthorizedOrRedirect(ctx *gin.Context, user *gtsmodel.User, account *gtsmodel.Account) (redirected bool) {
 	if user.ConfirmedAt.IsZero() {
 		ctx.Redirect(http.StatusSeeOther, "/auth"+AuthCheckYourEmailPath)
@@ -113,6 +113,34 @@ func (suite *AuthAuthorizeTestSuite) TestAccountAuthorizeHandler() {
 	}
 }
 
+func (suite *AuthAuthorizeTestSuite) TestAuthorizeScopes() {
+	for _, test := range []struct {
+		scope              string
+		expectedStatusCode int
+	}{
+		// Within the app's "read write follow push".
+		{scope: "read:statuses write", expectedStatusCode: http.StatusOK},
+		{scope: "follow", expectedStatusCode: http.StatusOK},
+		// Not registered by the app.
+		{scope: "read admin", expectedStatusCode: http.StatusBadRequest},
+		{scope: "admin:read:accounts", expectedStatusCode: http.StatusBadRequest},
+	} {
+		ctx, recorder := suite.newContext(http.MethodGet, auth.OauthAuthorizePath, nil, "")
+
+		testSession := sessions.Default(ctx)
+		testSession.Set(sessionUserID, suite.testUsers["local_account_1"].ID)
+		testSession.Set(sessionClientID, suite.testApplications["application_1"].ClientID)
+		testSession.Set(sessionRedirectURI, suite.testApplications["application_1"].RedirectURI)
+		testSession.Set(sessionScope, test.scope)
+		if err := testSession.Save(); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		suite.authModule.AuthorizeGETHandler(ctx)
+		suite.Equal(test.expectedStatusCode, recorder.Code, "failed on scope: %s", test.scope)
+	}
+}
+
 func TestAccountUpdateTestSuite(t *testing.T) {
 	suite.Run(t, new(AuthAuthorizeTestSuite))
 }
@@ -100,6 +100,31 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	user, err := m.db.GetUserByID(c.Request.Context(), userid)
+	if err != nil {
+		m.clearSession(s)
+		err := fmt.Errorf("error getting user %s: %w", userid, err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	if user.TwoFactorEnabled() {
+		// The user has 2FA enabled, so don't treat
+		// them as signed in yet: park their user ID
+		// on the session and ask them for a code first.
+		s.Delete(sessionUserID)
+		s.Set(sessionTwoFactorID, userid)
+		s.Set(sessionTwoFactorTry, 0)
+		if err := s.Save(); err != nil {
+			err := fmt.Errorf("error saving user id onto session: %s", err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+			return
+		}
+
+		c.Redirect(http.StatusFound, "/auth"+AuthTwoFactorPath)
+		return
+	}
+
 	s.Set(sessionUserID, userid)
 	if err := s.Save(); err != nil {
 		err := fmt.Errorf("error saving user id onto session: %s", err)
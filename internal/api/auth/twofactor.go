@@ -0,0 +1,138 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// maxTwoFactorAttempts is the number of wrong codes a user
+// may enter before their pending sign in is thrown away,
+// and they need to start again with their email + password.
+const maxTwoFactorAttempts = 5
+
+// twoFactor wraps a form-submitted 2FA code.
+type twoFactor struct {
+	Code string `form:"code"`
+}
+
+// TwoFactorGETHandler should be served at https://example.org/auth/2fa.
+// Users with 2FA enabled are redirected here by SignInPOSTHandler after
+// entering a correct email + password, to present them with a form to
+// enter a code from their authenticator app (or a recovery code).
+func (m *Module) TwoFactorGETHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.HTMLAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	s := sessions.Default(c)
+	if _, ok := s.Get(sessionTwoFactorID).(string); !ok {
+		// Nothing pending, start at the beginning.
+		c.Redirect(http.StatusFound, "/auth"+AuthSignInPath)
+		return
+	}
+
+	instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	page := apiutil.WebPage{
+		Template: "sign-in-2fa.tmpl",
+		Instance: instance,
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
+
+// TwoFactorPOSTHandler should be served at https://example.org/auth/2fa.
+// It checks the submitted code against the pending user, and if it's
+// correct, signs the user in and redirects to the oauth authorize handler.
+func (m *Module) TwoFactorPOSTHandler(c *gin.Context) {
+	s := sessions.Default(c)
+
+	userID, ok := s.Get(sessionTwoFactorID).(string)
+	if !ok || userID == "" {
+		m.clearSession(s)
+		err := fmt.Errorf("key %s was not found in session", sessionTwoFactorID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &twoFactor{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	user, err := m.db.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		m.clearSession(s)
+		err := fmt.Errorf("error getting user %s: %w", userID, err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	if errWithCode := m.processor.User().TwoFactorCheck(c.Request.Context(), user, form.Code); errWithCode != nil {
+		attempts, _ := s.Get(sessionTwoFactorTry).(int)
+		attempts++
+
+		if attempts >= maxTwoFactorAttempts {
+			// Too many goes, make
+			// them sign in again.
+			m.clearSession(s)
+			err := errors.New("too many incorrect two factor codes")
+			apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error(), "please sign in again"), m.processor.InstanceGetV1)
+			return
+		}
+
+		// Don't clear session here, so the user
+		// can just press back and try again.
+		s.Set(sessionTwoFactorTry, attempts)
+		if err := s.Save(); err != nil {
+			err := fmt.Errorf("error saving two factor attempts onto session: %s", err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+			return
+		}
+
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Code was fine, user is now signed in.
+	s.Delete(sessionTwoFactorID)
+	s.Delete(sessionTwoFactorTry)
+	s.Set(sessionUserID, user.ID)
+	if err := s.Save(); err != nil {
+		err := fmt.Errorf("error saving user id onto session: %s", err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/oauth"+OauthAuthorizePath)
+}
@@ -0,0 +1,153 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/auth"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type AuthTwoFactorTestSuite struct {
+	AuthStandardTestSuite
+}
+
+// enableTwoFactor enables 2FA on the given user,
+// with a single recovery code "abcdefghij".
+func (suite *AuthTwoFactorTestSuite) enableTwoFactor(user *gtsmodel.User) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("abcdefghij"), bcrypt.MinCost)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user.TwoFactorSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	user.TwoFactorBackups = []string{string(hash)}
+	user.TwoFactorEnabledAt = time.Now()
+	if err := suite.db.UpdateUser(context.Background(), user,
+		"two_factor_secret",
+		"two_factor_backups",
+		"two_factor_enabled_at",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *AuthTwoFactorTestSuite) signIn() (sessions.Session, *http.Response) {
+	form := url.Values{
+		"username": {"zork@example.org"},
+		"password": {"password"},
+	}
+
+	ctx, recorder := suite.newContext(http.MethodPost, "auth"+auth.AuthSignInPath, []byte(form.Encode()), "application/x-www-form-urlencoded")
+	suite.authModule.SignInPOSTHandler(ctx)
+	ctx.Writer.WriteHeaderNow()
+	return sessions.Default(ctx), recorder.Result()
+}
+
+func (suite *AuthTwoFactorTestSuite) TestSignInNoTwoFactor() {
+	s, resp := suite.signIn()
+	defer resp.Body.Close()
+
+	suite.Equal(http.StatusFound, resp.StatusCode)
+	suite.Equal("/oauth"+auth.OauthAuthorizePath, resp.Header.Get("Location"))
+	suite.Equal(suite.testUsers["local_account_1"].ID, s.Get(sessionUserID))
+	suite.Nil(s.Get(sessionTwoFactorID))
+}
+
+func (suite *AuthTwoFactorTestSuite) TestSignInTwoFactor() {
+	user := suite.testUsers["local_account_1"]
+	suite.enableTwoFactor(user)
+
+	s, resp := suite.signIn()
+	defer resp.Body.Close()
+
+	// User should be sent to enter a code,
+	// and not yet be signed in.
+	suite.Equal(http.StatusFound, resp.StatusCode)
+	suite.Equal("/auth"+auth.AuthTwoFactorPath, resp.Header.Get("Location"))
+	suite.Nil(s.Get(sessionUserID))
+	suite.Equal(user.ID, s.Get(sessionTwoFactorID))
+}
+
+func (suite *AuthTwoFactorTestSuite) twoFactor(userID string, attempts int, code string) (sessions.Session, *http.Response) {
+	form := url.Values{"code": {code}}
+	ctx, recorder := suite.newContext(http.MethodPost, "auth"+auth.AuthTwoFactorPath, []byte(form.Encode()), "application/x-www-form-urlencoded")
+
+	s := sessions.Default(ctx)
+	s.Set(sessionTwoFactorID, userID)
+	s.Set(sessionTwoFactorTry, attempts)
+	if err := s.Save(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.authModule.TwoFactorPOSTHandler(ctx)
+	ctx.Writer.WriteHeaderNow()
+	return s, recorder.Result()
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorOK() {
+	user := suite.testUsers["local_account_1"]
+	suite.enableTwoFactor(user)
+
+	s, resp := suite.twoFactor(user.ID, 0, "abcde-fghij")
+	defer resp.Body.Close()
+
+	suite.Equal(http.StatusFound, resp.StatusCode)
+	suite.Equal("/oauth"+auth.OauthAuthorizePath, resp.Header.Get("Location"))
+	suite.Equal(user.ID, s.Get(sessionUserID))
+	suite.Nil(s.Get(sessionTwoFactorID))
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorWrongCode() {
+	user := suite.testUsers["local_account_1"]
+	suite.enableTwoFactor(user)
+
+	s, resp := suite.twoFactor(user.ID, 0, "000000")
+	defer resp.Body.Close()
+
+	// Wrong code, but can try again.
+	suite.Equal(http.StatusForbidden, resp.StatusCode)
+	suite.Nil(s.Get(sessionUserID))
+	suite.Equal(user.ID, s.Get(sessionTwoFactorID))
+	suite.Equal(1, s.Get(sessionTwoFactorTry))
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorTooManyAttempts() {
+	user := suite.testUsers["local_account_1"]
+	suite.enableTwoFactor(user)
+
+	s, resp := suite.twoFactor(user.ID, 4, "000000")
+	defer resp.Body.Close()
+
+	// Out of attempts, session cleared.
+	suite.Equal(http.StatusForbidden, resp.StatusCode)
+	suite.Nil(s.Get(sessionUserID))
+	suite.Nil(s.Get(sessionTwoFactorID))
+}
+
+func TestAuthTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, &AuthTwoFactorTestSuite{})
+}
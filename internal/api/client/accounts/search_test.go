@@ -378,7 +378,9 @@ func (suite *AccountSearchTestSuite) TestSearchAFollowing() {
 		usernames = append(usernames, account.Username)
 	}
 
-	suite.EqualValues([]string{"her_fuckin_maj", "foss_satan", "1happyturtle", "the_mighty_zork", "admin"}, usernames)
+	// Names with a word starting with "a" rank
+	// above names just containing "a" somewhere.
+	suite.EqualValues([]string{"admin", "her_fuckin_maj", "foss_satan", "1happyturtle", "the_mighty_zork"}, usernames)
 }
 
 func (suite *AccountSearchTestSuite) TestSearchANotFollowing() {
@@ -421,7 +423,8 @@ func (suite *AccountSearchTestSuite) TestSearchANotFollowing() {
 		usernames = append(usernames, account.Username)
 	}
 
-	suite.EqualValues([]string{"1happyturtle", "admin"}, usernames)
+	// Username matches rank above note matches.
+	suite.EqualValues([]string{"admin", "1happyturtle"}, usernames)
 }
 
 func TestAccountSearchTestSuite(t *testing.T) {
@@ -28,37 +28,67 @@ import (
 )
 
 const (
-	BasePath                = "/v1/admin"
-	EmojiPath               = BasePath + "/custom_emojis"
-	EmojiPathWithID         = EmojiPath + "/:" + apiutil.IDKey
-	EmojiCategoriesPath     = EmojiPath + "/categories"
-	DomainBlocksPath        = BasePath + "/domain_blocks"
-	DomainBlocksPathWithID  = DomainBlocksPath + "/:" + apiutil.IDKey
-	DomainAllowsPath        = BasePath + "/domain_allows"
-	DomainAllowsPathWithID  = DomainAllowsPath + "/:" + apiutil.IDKey
-	DomainKeysExpirePath    = BasePath + "/domain_keys_expire"
-	HeaderAllowsPath        = BasePath + "/header_allows"
-	HeaderAllowsPathWithID  = HeaderAllowsPath + "/:" + apiutil.IDKey
-	HeaderBlocksPath        = BasePath + "/header_blocks"
-	HeaderBlocksPathWithID  = HeaderBlocksPath + "/:" + apiutil.IDKey
-	AccountsV1Path          = BasePath + "/accounts"
-	AccountsV2Path          = "/v2/admin/accounts"
-	AccountsPathWithID      = AccountsV1Path + "/:" + apiutil.IDKey
-	AccountsActionPath      = AccountsPathWithID + "/action"
-	AccountsApprovePath     = AccountsPathWithID + "/approve"
-	AccountsRejectPath      = AccountsPathWithID + "/reject"
-	MediaCleanupPath        = BasePath + "/media_cleanup"
-	MediaRefetchPath        = BasePath + "/media_refetch"
-	ReportsPath             = BasePath + "/reports"
-	ReportsPathWithID       = ReportsPath + "/:" + apiutil.IDKey
-	ReportsResolvePath      = ReportsPathWithID + "/resolve"
-	EmailPath               = BasePath + "/email"
-	EmailTestPath           = EmailPath + "/test"
-	InstanceRulesPath       = BasePath + "/instance/rules"
-	InstanceRulesPathWithID = InstanceRulesPath + "/:" + apiutil.IDKey
-	DebugPath               = BasePath + "/debug"
-	DebugAPUrlPath          = DebugPath + "/apurl"
-	DebugClearCachesPath    = DebugPath + "/caches/clear"
+	BasePath                                = "/v1/admin"
+	AnnouncementsPath                       = BasePath + "/announcements"
+	AnnouncementsPathWithID                 = AnnouncementsPath + "/:" + apiutil.IDKey
+	EmojiPath                               = BasePath + "/custom_emojis"
+	EmojiPathWithID                         = EmojiPath + "/:" + apiutil.IDKey
+	EmojiCategoriesPath                     = EmojiPath + "/categories"
+	ContentLimitsPath                       = BasePath + "/content_limits"
+	ContentLimitsPathWithID                 = ContentLimitsPath + "/:" + apiutil.IDKey
+	DeliveryHealthPath                      = BasePath + "/delivery_health"
+	DeliveryQueuePath                       = BasePath + "/delivery_queue"
+	DeliveryQueuePurgePath                  = DeliveryQueuePath + "/purge"
+	DeliveryQueueRetryPath                  = DeliveryQueuePath + "/retry"
+	DomainBlocksPath                        = BasePath + "/domain_blocks"
+	DomainBlocksPathWithID                  = DomainBlocksPath + "/:" + apiutil.IDKey
+	DomainAllowsPath                        = BasePath + "/domain_allows"
+	DomainAllowsPathWithID                  = DomainAllowsPath + "/:" + apiutil.IDKey
+	DomainKeysExpirePath                    = BasePath + "/domain_keys_expire"
+	DomainPermissionDraftsPath              = BasePath + "/domain_permission_drafts"
+	DomainPermissionDraftsPathWithID        = DomainPermissionDraftsPath + "/:" + apiutil.IDKey
+	DomainPermissionDraftAcceptPath         = DomainPermissionDraftsPathWithID + "/accept"
+	DomainPermissionDraftRemovePath         = DomainPermissionDraftsPathWithID + "/remove"
+	DomainPermissionSubscriptionsPath       = BasePath + "/domain_permission_subscriptions"
+	DomainPermissionSubscriptionsPathWithID = DomainPermissionSubscriptionsPath + "/:" + apiutil.IDKey
+	DomainPermissionSubscriptionTestPath    = DomainPermissionSubscriptionsPathWithID + "/test"
+	DomainPermissionSubscriptionRemovePath  = DomainPermissionSubscriptionsPathWithID + "/remove"
+	HeaderAllowsPath                        = BasePath + "/header_allows"
+	HeaderAllowsPathWithID                  = HeaderAllowsPath + "/:" + apiutil.IDKey
+	HeaderBlocksPath                        = BasePath + "/header_blocks"
+	HeaderBlocksPathWithID                  = HeaderBlocksPath + "/:" + apiutil.IDKey
+	AccountsV1Path                          = BasePath + "/accounts"
+	AccountsV2Path                          = "/v2/admin/accounts"
+	AccountsPathWithID                      = AccountsV1Path + "/:" + apiutil.IDKey
+	AccountsActionPath                      = AccountsPathWithID + "/action"
+	AccountsApprovePath                     = AccountsPathWithID + "/approve"
+	AccountsRejectPath                      = AccountsPathWithID + "/reject"
+	MediaCleanupPath                        = BasePath + "/media_cleanup"
+	MediaRefetchPath                        = BasePath + "/media_refetch"
+	RelaysPath                              = BasePath + "/relays"
+	RelaysPathWithID                        = RelaysPath + "/:" + apiutil.IDKey
+	ReportsPath                             = BasePath + "/reports"
+	ReportsPathWithID                       = ReportsPath + "/:" + apiutil.IDKey
+	ReportsResolvePath                      = ReportsPathWithID + "/resolve"
+	SuggestionsPath                         = BasePath + "/suggestions"
+	SuggestionsPathWithID                   = SuggestionsPath + "/:" + apiutil.IDKey
+	TrendsPath                              = BasePath + "/trends"
+	TrendsTagsPath                          = TrendsPath + "/tags"
+	TrendsTagApprovePath                    = TrendsTagsPath + "/:" + apiutil.IDKey + "/approve"
+	TrendsTagRejectPath                     = TrendsTagsPath + "/:" + apiutil.IDKey + "/reject"
+	TrendsStatusesPath                      = TrendsPath + "/statuses"
+	TrendsStatusApprovePath                 = TrendsStatusesPath + "/:" + apiutil.IDKey + "/approve"
+	TrendsStatusRejectPath                  = TrendsStatusesPath + "/:" + apiutil.IDKey + "/reject"
+	TrendsLinksPath                         = TrendsPath + "/links"
+	TrendsLinkApprovePath                   = TrendsLinksPath + "/:" + apiutil.IDKey + "/approve"
+	TrendsLinkRejectPath                    = TrendsLinksPath + "/:" + apiutil.IDKey + "/reject"
+	EmailPath                               = BasePath + "/email"
+	EmailTestPath                           = EmailPath + "/test"
+	InstanceRulesPath                       = BasePath + "/instance/rules"
+	InstanceRulesPathWithID                 = InstanceRulesPath + "/:" + apiutil.IDKey
+	DebugPath                               = BasePath + "/debug"
+	DebugAPUrlPath                          = DebugPath + "/apurl"
+	DebugClearCachesPath                    = DebugPath + "/caches/clear"
 
 	FilterQueryKey        = "filter"
 	MaxShortcodeDomainKey = "max_shortcode_domain"
@@ -99,6 +129,21 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, DomainAllowsPathWithID, m.DomainAllowGETHandler)
 	attachHandler(http.MethodDelete, DomainAllowsPathWithID, m.DomainAllowDELETEHandler)
 
+	// domain permission draft stuff
+	attachHandler(http.MethodPost, DomainPermissionDraftsPath, m.DomainPermissionDraftsPOSTHandler)
+	attachHandler(http.MethodGet, DomainPermissionDraftsPath, m.DomainPermissionDraftsGETHandler)
+	attachHandler(http.MethodGet, DomainPermissionDraftsPathWithID, m.DomainPermissionDraftGETHandler)
+	attachHandler(http.MethodPost, DomainPermissionDraftAcceptPath, m.DomainPermissionDraftAcceptPOSTHandler)
+	attachHandler(http.MethodPost, DomainPermissionDraftRemovePath, m.DomainPermissionDraftRemovePOSTHandler)
+
+	// domain permission subscription stuff
+	attachHandler(http.MethodPost, DomainPermissionSubscriptionsPath, m.DomainPermissionSubscriptionPOSTHandler)
+	attachHandler(http.MethodGet, DomainPermissionSubscriptionsPath, m.DomainPermissionSubscriptionsGETHandler)
+	attachHandler(http.MethodGet, DomainPermissionSubscriptionsPathWithID, m.DomainPermissionSubscriptionGETHandler)
+	attachHandler(http.MethodPatch, DomainPermissionSubscriptionsPathWithID, m.DomainPermissionSubscriptionPATCHHandler)
+	attachHandler(http.MethodPost, DomainPermissionSubscriptionTestPath, m.DomainPermissionSubscriptionTestPOSTHandler)
+	attachHandler(http.MethodPost, DomainPermissionSubscriptionRemovePath, m.DomainPermissionSubscriptionRemovePOSTHandler)
+
 	// header filtering administration routes
 	attachHandler(http.MethodGet, HeaderAllowsPathWithID, m.HeaderFilterAllowGET)
 	attachHandler(http.MethodGet, HeaderBlocksPathWithID, m.HeaderFilterBlockGET)
@@ -109,6 +154,22 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodDelete, HeaderAllowsPathWithID, m.HeaderFilterAllowDELETE)
 	attachHandler(http.MethodDelete, HeaderBlocksPathWithID, m.HeaderFilterBlockDELETE)
 
+	// content limit stuff
+	attachHandler(http.MethodPost, ContentLimitsPath, m.ContentLimitsPOSTHandler)
+	attachHandler(http.MethodGet, ContentLimitsPath, m.ContentLimitsGETHandler)
+	attachHandler(http.MethodGet, ContentLimitsPathWithID, m.ContentLimitGETHandler)
+	attachHandler(http.MethodPatch, ContentLimitsPathWithID, m.ContentLimitPATCHHandler)
+	attachHandler(http.MethodDelete, ContentLimitsPathWithID, m.ContentLimitDELETEHandler)
+
+	// delivery health stuff
+	attachHandler(http.MethodGet, DeliveryHealthPath, m.DeliveryHealthGETHandler)
+	attachHandler(http.MethodDelete, DeliveryHealthPath, m.DeliveryHealthDELETEHandler)
+
+	// delivery queue stuff
+	attachHandler(http.MethodGet, DeliveryQueuePath, m.DeliveryQueueGETHandler)
+	attachHandler(http.MethodPost, DeliveryQueuePurgePath, m.DeliveryQueuePurgePOSTHandler)
+	attachHandler(http.MethodPost, DeliveryQueueRetryPath, m.DeliveryQueueRetryPOSTHandler)
+
 	// domain maintenance stuff
 	attachHandler(http.MethodPost, DomainKeysExpirePath, m.DomainKeysExpirePOSTHandler)
 
@@ -129,9 +190,38 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, ReportsPathWithID, m.ReportGETHandler)
 	attachHandler(http.MethodPost, ReportsResolvePath, m.ReportResolvePOSTHandler)
 
+	// relays stuff
+	attachHandler(http.MethodGet, RelaysPath, m.RelaysGETHandler)
+	attachHandler(http.MethodPost, RelaysPath, m.RelayPOSTHandler)
+	attachHandler(http.MethodGet, RelaysPathWithID, m.RelayGETHandler)
+	attachHandler(http.MethodDelete, RelaysPathWithID, m.RelayDELETEHandler)
+
+	// trends stuff
+	attachHandler(http.MethodGet, TrendsTagsPath, m.TrendsTagsGETHandler)
+	attachHandler(http.MethodPost, TrendsTagApprovePath, m.TrendsTagApprovePOSTHandler)
+	attachHandler(http.MethodPost, TrendsTagRejectPath, m.TrendsTagRejectPOSTHandler)
+	attachHandler(http.MethodGet, TrendsStatusesPath, m.TrendsStatusesGETHandler)
+	attachHandler(http.MethodPost, TrendsStatusApprovePath, m.TrendsStatusApprovePOSTHandler)
+	attachHandler(http.MethodPost, TrendsStatusRejectPath, m.TrendsStatusRejectPOSTHandler)
+	attachHandler(http.MethodGet, TrendsLinksPath, m.TrendsLinksGETHandler)
+	attachHandler(http.MethodPost, TrendsLinkApprovePath, m.TrendsLinkApprovePOSTHandler)
+	attachHandler(http.MethodPost, TrendsLinkRejectPath, m.TrendsLinkRejectPOSTHandler)
+
+	// suggestions stuff
+	attachHandler(http.MethodGet, SuggestionsPath, m.FeaturedSuggestionsGETHandler)
+	attachHandler(http.MethodPost, SuggestionsPath, m.FeaturedSuggestionPOSTHandler)
+	attachHandler(http.MethodDelete, SuggestionsPathWithID, m.FeaturedSuggestionDELETEHandler)
+
 	// email stuff
 	attachHandler(http.MethodPost, EmailTestPath, m.EmailTestPOSTHandler)
 
+	// announcements stuff
+	attachHandler(http.MethodGet, AnnouncementsPath, m.AnnouncementsGETHandler)
+	attachHandler(http.MethodPost, AnnouncementsPath, m.AnnouncementPOSTHandler)
+	attachHandler(http.MethodGet, AnnouncementsPathWithID, m.AnnouncementGETHandler)
+	attachHandler(http.MethodPatch, AnnouncementsPathWithID, m.AnnouncementPATCHHandler)
+	attachHandler(http.MethodDelete, AnnouncementsPathWithID, m.AnnouncementDELETEHandler)
+
 	// instance rules stuff
 	attachHandler(http.MethodGet, InstanceRulesPath, m.RulesGETHandler)
 	attachHandler(http.MethodGet, InstanceRulesPathWithID, m.RuleGETHandler)
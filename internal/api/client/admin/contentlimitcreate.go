@@ -0,0 +1,155 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// ContentLimitsPOSTHandler swagger:operation POST /api/v1/admin/content_limits contentLimitCreate
+//
+// Create a content limit on an account or domain.
+//
+// A content limit forces a content warning and/or sensitive flag onto all statuses
+// from the target account or domain (including subdomains), and/or rejects media
+// attached to those statuses. Exactly one of `account_id` or `domain` must be set,
+// as well as at least one of `force_sensitive`, `content_warning`, or `reject_media`.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- multipart/form-data
+//	- application/json
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: account_id
+//		in: formData
+//		description: ID of the account to limit.
+//		type: string
+//	-
+//		name: domain
+//		in: formData
+//		description: Domain to limit.
+//		type: string
+//	-
+//		name: force_sensitive
+//		in: formData
+//		description: Mark all statuses from the target as sensitive.
+//		type: boolean
+//	-
+//		name: content_warning
+//		in: formData
+//		description: >-
+//			Content warning to add to all statuses from the target.
+//			If a status already has a content warning, this will be prepended to it.
+//		type: string
+//	-
+//		name: reject_media
+//		in: formData
+//		description: >-
+//			Don't download media attached to statuses from the target,
+//			and don't show already-downloaded media. Links to the remote
+//			media will be shown instead.
+//		type: boolean
+//	-
+//		name: private_comment
+//		in: formData
+//		description: >-
+//			Private comment about this content limit. Will only be shown to other admins, so this
+//			is a useful way of internally keeping track of why a certain account or domain ended up limited.
+//		type: string
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin:write
+//
+//	responses:
+//		'200':
+//			description: The newly created content limit.
+//			schema:
+//				"$ref": "#/definitions/contentLimit"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: conflict, a content limit already exists for this account or domain
+//		'500':
+//			description: internal server error
+func (m *Module) ContentLimitsPOSTHandler(c *gin.Context) {
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		apiutil.ScopeAdminWrite,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := new(apimodel.ContentLimitRequest)
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	limit, errWithCode := m.processor.Admin().ContentLimitCreate(
+		c.Request.Context(),
+		authed.Account,
+		form,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, limit)
+}
@@ -29,7 +29,6 @@ import (
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
-	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
 type singleDomainPermCreate func(
@@ -63,9 +62,12 @@ func (m *Module) createDomainPermissions(
 	single singleDomainPermCreate,
 	multi multiDomainPermCreate,
 ) {
-	authed, err := oauth.Authed(c, true, true, true, true)
-	if err != nil {
-		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		domainPermissionScope(permType, true),
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
@@ -98,6 +100,7 @@ func (m *Module) createDomainPermissions(
 		return
 	}
 
+	var err error
 	if importing && form.Domains.Size == 0 {
 		err = errors.New("import was specified but list of domains is empty")
 	} else if !importing && form.Domain == "" {
@@ -171,9 +174,12 @@ func (m *Module) deleteDomainPermission(
 	c *gin.Context,
 	permType gtsmodel.DomainPermissionType, // block/allow
 ) {
-	authed, err := oauth.Authed(c, true, true, true, true)
-	if err != nil {
-		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		domainPermissionScope(permType, true),
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
@@ -218,9 +224,12 @@ func (m *Module) getDomainPermission(
 	c *gin.Context,
 	permType gtsmodel.DomainPermissionType,
 ) {
-	authed, err := oauth.Authed(c, true, true, true, true)
-	if err != nil {
-		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		domainPermissionScope(permType, false),
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
@@ -266,9 +275,12 @@ func (m *Module) getDomainPermissions(
 	c *gin.Context,
 	permType gtsmodel.DomainPermissionType,
 ) {
-	authed, err := oauth.Authed(c, true, true, true, true)
-	if err != nil {
-		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		domainPermissionScope(permType, false),
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
@@ -302,3 +314,37 @@ func (m *Module) getDomainPermissions(
 
 	apiutil.JSON(c, http.StatusOK, domainPerm)
 }
+
+// parseDomainPermissionType parses an optional
+// permission_type query value into a domain
+// permission type. An empty value returns
+// DomainPermissionUnknown, meaning "any type".
+func parseDomainPermissionType(value string) (gtsmodel.DomainPermissionType, gtserror.WithCode) {
+	if value == "" {
+		return gtsmodel.DomainPermissionUnknown, nil
+	}
+
+	permType := gtsmodel.NewDomainPermissionType(value)
+	if permType == gtsmodel.DomainPermissionUnknown {
+		text := fmt.Sprintf("invalid %s %s, expected block or allow", apiutil.DomainPermissionPermTypeKey, value)
+		return permType, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return permType, nil
+}
+
+// domainPermissionScope returns the granular admin
+// scope required to read or write domain permissions
+// of the given type.
+func domainPermissionScope(permType gtsmodel.DomainPermissionType, write bool) apiutil.Scope {
+	switch {
+	case permType == gtsmodel.DomainPermissionAllow && write:
+		return apiutil.ScopeAdminWriteDomainAllows
+	case permType == gtsmodel.DomainPermissionAllow:
+		return apiutil.ScopeAdminReadDomainAllows
+	case write:
+		return apiutil.ScopeAdminWriteDomainBlocks
+	default:
+		return apiutil.ScopeAdminReadDomainBlocks
+	}
+}
@@ -0,0 +1,87 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+)
+
+type DomainPermissionDraftsGetTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DomainPermissionDraftsGetTestSuite) TestDomainPermissionDraftsGet() {
+	recorder := httptest.NewRecorder()
+
+	path := admin.DomainPermissionDraftsPath
+	ctx := suite.newContext(recorder, http.MethodGet, nil, path, "application/json")
+
+	suite.adminModule.DomainPermissionDraftsGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	suite.NoError(err)
+	dst := new(bytes.Buffer)
+	err = json.Indent(dst, b, "", "  ")
+	suite.NoError(err)
+	suite.Equal(`[
+  {
+    "domain": "infosec.example.org",
+    "id": "01JDW7QK3CC6Q3S3PPEGGX9S0K",
+    "private_comment": "these people are nice",
+    "created_by": "01F8MH17FWEB39HZJ76B6VXSKF",
+    "created_at": "2024-11-01T09:00:00.000Z",
+    "permission_type": "allow"
+  },
+  {
+    "domain": "fossbros-anonymous.io",
+    "public_comment": "this is a public comment",
+    "id": "01JCZN614XG85GCGAMSV9ZZAEJ",
+    "private_comment": "this is a draft domain permission",
+    "created_by": "01F8MH17FWEB39HZJ76B6VXSKF",
+    "created_at": "2024-11-01T09:00:00.000Z",
+    "permission_type": "block"
+  }
+]`, dst.String())
+}
+
+func (suite *DomainPermissionDraftsGetTestSuite) TestDomainPermissionDraftsGetInvalidType() {
+	recorder := httptest.NewRecorder()
+
+	path := admin.DomainPermissionDraftsPath + "?permission_type=nope"
+	ctx := suite.newContext(recorder, http.MethodGet, nil, path, "application/json")
+
+	suite.adminModule.DomainPermissionDraftsGETHandler(ctx)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	suite.NoError(err)
+	suite.Equal(`{"error":"Bad Request: invalid permission_type nope, expected block or allow"}`, string(b))
+}
+
+func TestDomainPermissionDraftsGetTestSuite(t *testing.T) {
+	suite.Run(t, &DomainPermissionDraftsGetTestSuite{})
+}
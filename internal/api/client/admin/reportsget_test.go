@@ -576,6 +576,20 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
               "me"
             ],
             "with_approval": []
+          },
+          "can_quote": {
+            "always": [
+              "public",
+              "me"
+            ],
+            "with_approval": []
+          },
+          "can_react": {
+            "always": [
+              "public",
+              "me"
+            ],
+            "with_approval": []
           }
         }
       }
@@ -826,6 +840,20 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
               "me"
             ],
             "with_approval": []
+          },
+          "can_quote": {
+            "always": [
+              "public",
+              "me"
+            ],
+            "with_approval": []
+          },
+          "can_react": {
+            "always": [
+              "public",
+              "me"
+            ],
+            "with_approval": []
           }
         }
       }
@@ -1076,6 +1104,20 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
               "me"
             ],
             "with_approval": []
+          },
+          "can_quote": {
+            "always": [
+              "public",
+              "me"
+            ],
+            "with_approval": []
+          },
+          "can_react": {
+            "always": [
+              "public",
+              "me"
+            ],
+            "with_approval": []
           }
         }
       }
@@ -1117,10 +1159,28 @@ func (suite *ReportsGetTestSuite) TestReportsGetResolvedTargetAccount() {
 
 func (suite *ReportsGetTestSuite) TestReportsGetNotAdmin() {
 	testAccount := suite.testAccounts["local_account_1"]
-	testToken := suite.testTokens["local_account_1"]
 	testUser := suite.testUsers["local_account_1"]
 
-	reports, _, err := suite.getReports(testAccount, testToken, testUser, http.StatusForbidden, `{"error":"Forbidden: user 01F8MGVGPHQ2D3P3X0454H54Z5 not an admin"}`, nil, "", "", "", "", "", 20)
+	// Give the token admin scope, so that
+	// it's the user that's not an admin.
+	testToken := *suite.testTokens["local_account_1"]
+	testToken.Scope = "read write admin"
+
+	reports, _, err := suite.getReports(testAccount, &testToken, testUser, http.StatusForbidden, `{"error":"Forbidden: user 01F8MGVGPHQ2D3P3X0454H54Z5 not an admin"}`, nil, "", "", "", "", "", 20)
+	suite.NoError(err)
+	suite.Empty(reports)
+}
+
+func (suite *ReportsGetTestSuite) TestReportsGetNoAdminScope() {
+	testAccount := suite.testAccounts["admin_account"]
+	testUser := suite.testUsers["admin_account"]
+
+	// User is an admin, but
+	// token has no admin scope.
+	testToken := *suite.testTokens["admin_account"]
+	testToken.Scope = "read write"
+
+	reports, _, err := suite.getReports(testAccount, &testToken, testUser, http.StatusForbidden, `{"error":"Forbidden: token is missing scope admin:read:reports, which is required to perform this action"}`, nil, "", "", "", "", "", 20)
 	suite.NoError(err)
 	suite.Empty(reports)
 }
@@ -0,0 +1,196 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// TrendsTagsGETHandler swagger:operation GET /api/v1/admin/trends/tags adminTrendsTagsGet
+//
+// View trending tags, including those not (yet) approved, most trending first.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: limit
+//		type: integer
+//		description: Number of items to return.
+//		default: 20
+//		maximum: 50
+//		minimum: 1
+//		in: query
+//		required: false
+//	-
+//		name: offset
+//		type: integer
+//		description: Skip the first n results.
+//		default: 0
+//		minimum: 0
+//		in: query
+//		required: false
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin:read
+//
+//	responses:
+//		'200':
+//			description: Array of trending tags.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/adminTrendsTag"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) TrendsTagsGETHandler(c *gin.Context) {
+	getTrends(m, c, func(ctx context.Context, _ *gtsmodel.Account, limit int, offset int) ([]*apimodel.AdminTrendsTag, gtserror.WithCode) {
+		return m.processor.Trends().AdminTagsGet(ctx, limit, offset)
+	})
+}
+
+// TrendsStatusesGETHandler swagger:operation GET /api/v1/admin/trends/statuses adminTrendsStatusesGet
+//
+// View trending statuses, including those not (yet) approved, most trending first.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: limit
+//		type: integer
+//		description: Number of items to return.
+//		default: 20
+//		maximum: 50
+//		minimum: 1
+//		in: query
+//		required: false
+//	-
+//		name: offset
+//		type: integer
+//		description: Skip the first n results.
+//		default: 0
+//		minimum: 0
+//		in: query
+//		required: false
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin:read
+//
+//	responses:
+//		'200':
+//			description: Array of trending statuses.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/adminTrendsStatus"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) TrendsStatusesGETHandler(c *gin.Context) {
+	getTrends(m, c, m.processor.Trends().AdminStatusesGet)
+}
+
+// TrendsLinksGETHandler swagger:operation GET /api/v1/admin/trends/links adminTrendsLinksGet
+//
+// View trending links, including those not (yet) approved, most trending first.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: limit
+//		type: integer
+//		description: Number of items to return.
+//		default: 20
+//		maximum: 50
+//		minimum: 1
+//		in: query
+//		required: false
+//	-
+//		name: offset
+//		type: integer
+//		description: Skip the first n results.
+//		default: 0
+//		minimum: 0
+//		in: query
+//		required: false
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin:read
+//
+//	responses:
+//		'200':
+//			description: Array of trending links.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/adminTrendsLink"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) TrendsLinksGETHandler(c *gin.Context) {
+	getTrends(m, c, func(ctx context.Context, _ *gtsmodel.Account, limit int, offset int) ([]*apimodel.AdminTrendsLink, gtserror.WithCode) {
+		return m.processor.Trends().AdminLinksGet(ctx, limit, offset)
+	})
+}
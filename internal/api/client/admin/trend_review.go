@@ -0,0 +1,296 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import "github.com/gin-gonic/gin"
+
+// TrendsTagApprovePOSTHandler swagger:operation POST /api/v1/admin/trends/tags/{id}/approve adminTrendsTagApprove
+//
+// Approve the given tag to be shown as trending. This may be done before the tag starts trending.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: ID of the tag.
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin:write
+//
+//	responses:
+//		'200':
+//			description: The reviewed trending item.
+//			schema:
+//				"$ref": "#/definitions/adminTrendsTag"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable entity
+//		'500':
+//			description: internal server error
+func (m *Module) TrendsTagApprovePOSTHandler(c *gin.Context) {
+	reviewTrend(m, c, m.processor.Trends().AdminTagReview, true)
+}
+
+// TrendsTagRejectPOSTHandler swagger:operation POST /api/v1/admin/trends/tags/{id}/reject adminTrendsTagReject
+//
+// Reject the given tag from being shown as trending.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: ID of the tag.
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin:write
+//
+//	responses:
+//		'200':
+//			description: The reviewed trending item.
+//			schema:
+//				"$ref": "#/definitions/adminTrendsTag"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable entity
+//		'500':
+//			description: internal server error
+func (m *Module) TrendsTagRejectPOSTHandler(c *gin.Context) {
+	reviewTrend(m, c, m.processor.Trends().AdminTagReview, false)
+}
+
+// TrendsStatusApprovePOSTHandler swagger:operation POST /api/v1/admin/trends/statuses/{id}/approve adminTrendsStatusApprove
+//
+// Approve the given public status to be shown as trending. This may be done before the status starts trending.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: ID of the status.
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin:write
+//
+//	responses:
+//		'200':
+//			description: The reviewed trending item.
+//			schema:
+//				"$ref": "#/definitions/adminTrendsStatus"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable entity
+//		'500':
+//			description: internal server error
+func (m *Module) TrendsStatusApprovePOSTHandler(c *gin.Context) {
+	reviewTrend(m, c, m.processor.Trends().AdminStatusReview, true)
+}
+
+// TrendsStatusRejectPOSTHandler swagger:operation POST /api/v1/admin/trends/statuses/{id}/reject adminTrendsStatusReject
+//
+// Reject the given status from being shown as trending.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: ID of the status.
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin:write
+//
+//	responses:
+//		'200':
+//			description: The reviewed trending item.
+//			schema:
+//				"$ref": "#/definitions/adminTrendsStatus"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable entity
+//		'500':
+//			description: internal server error
+func (m *Module) TrendsStatusRejectPOSTHandler(c *gin.Context) {
+	reviewTrend(m, c, m.processor.Trends().AdminStatusReview, false)
+}
+
+// TrendsLinkApprovePOSTHandler swagger:operation POST /api/v1/admin/trends/links/{id}/approve adminTrendsLinkApprove
+//
+// Approve the given trending link to be shown as trending.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: ID of the trending link, as returned by the admin trending links endpoint.
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin:write
+//
+//	responses:
+//		'200':
+//			description: The reviewed trending item.
+//			schema:
+//				"$ref": "#/definitions/adminTrendsLink"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable entity
+//		'500':
+//			description: internal server error
+func (m *Module) TrendsLinkApprovePOSTHandler(c *gin.Context) {
+	reviewTrend(m, c, m.processor.Trends().AdminLinkReview, true)
+}
+
+// TrendsLinkRejectPOSTHandler swagger:operation POST /api/v1/admin/trends/links/{id}/reject adminTrendsLinkReject
+//
+// Reject the given trending link from being shown as trending.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: ID of the trending link, as returned by the admin trending links endpoint.
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin:write
+//
+//	responses:
+//		'200':
+//			description: The reviewed trending item.
+//			schema:
+//				"$ref": "#/definitions/adminTrendsLink"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable entity
+//		'500':
+//			description: internal server error
+func (m *Module) TrendsLinkRejectPOSTHandler(c *gin.Context) {
+	reviewTrend(m, c, m.processor.Trends().AdminLinkReview, false)
+}
@@ -0,0 +1,250 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package exports
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// ArchivesGETHandler swagger:operation GET /api/v1/exports/archives exportArchives
+//
+// List archives of all of your account's data, newest first.
+//
+//	---
+//	tags:
+//	- import-export
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:accounts
+//
+//	responses:
+//		'200':
+//			description: Archives of your account's data.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/accountArchive"
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) ArchivesGETHandler(c *gin.Context) {
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		apiutil.ScopeReadAccounts,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	archives, errWithCode := m.processor.Account().ArchivesGet(
+		c.Request.Context(),
+		authed.Account,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, archives)
+}
+
+// ArchivePOSTHandler swagger:operation POST /api/v1/exports/archives exportArchiveCreate
+//
+// Request a new archive of all of your account's data.
+//
+// The archive is a zip file in Mastodon-compatible format, containing your profile (`actor.json`),
+// all of your statuses (`outbox.json`), liked and bookmarked statuses (`likes.json`, `bookmarks.json`),
+// and all of your media. It is built in the background, and can be downloaded once its state is `ready`,
+// until it expires after 7 days.
+//
+// A new archive can only be requested once every 24 hours. Requesting a new archive removes any previous ones.
+//
+//	---
+//	tags:
+//	- import-export
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:accounts
+//
+//	responses:
+//		'202':
+//			description: The newly requested archive.
+//			schema:
+//				"$ref": "#/definitions/accountArchive"
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: conflict (an archive is already being built)
+//		'422':
+//			description: unprocessable entity (archive requested too recently)
+//		'500':
+//			description: internal server error
+func (m *Module) ArchivePOSTHandler(c *gin.Context) {
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		apiutil.ScopeWriteAccounts,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	archive, errWithCode := m.processor.Account().ArchiveCreate(
+		c.Request.Context(),
+		authed.Account,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusAccepted, archive)
+}
+
+// ArchiveDownloadGETHandler swagger:operation GET /api/v1/exports/archives/{id}/download exportArchiveDownload
+//
+// Download the zip file of an archive of your account's data.
+//
+//	---
+//	tags:
+//	- import-export
+//
+//	produces:
+//	- application/zip
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: ID of the archive.
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:accounts
+//
+//	responses:
+//		'200':
+//			description: Zip file of the archive.
+//		'302':
+//			description: Redirect to the zip file in storage.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found, or archive not ready
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) ArchiveDownloadGETHandler(c *gin.Context) {
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		apiutil.ScopeReadAccounts,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, "application/zip"); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	archiveID, errWithCode := apiutil.ParseID(c.Param(apiutil.IDKey))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	content, errWithCode := m.processor.Account().ArchiveFileGet(
+		ctx,
+		authed.Account,
+		archiveID,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if content.URL != nil {
+		// Storage can serve the file directly.
+		c.Redirect(http.StatusFound, content.URL.String())
+		return
+	}
+
+	defer func() {
+		// Close content when we're done, catch errors.
+		if err := content.Content.Close(); err != nil {
+			log.Errorf(ctx, "error closing readcloser: %v", err)
+		}
+	}()
+
+	c.DataFromReader(
+		http.StatusOK,
+		content.ContentLength,
+		content.ContentType,
+		content.Content,
+		map[string]string{
+			"Content-Disposition": "attachment; filename=" +
+				strconv.Quote("archive-"+archiveID+".zip"),
+		},
+	)
+}
@@ -28,7 +28,6 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
-	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -39,6 +38,11 @@ const (
 var types = []string{
 	"following",
 	"blocks",
+	"mutes",
+	"domain_blocks",
+	"bookmarks",
+	"lists",
+	"archive",
 }
 
 var modes = []string{
@@ -83,7 +87,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //	-
 //		name: data
 //		in: formData
-//		description: The CSV data file to upload.
+//		description: The CSV data file (or zip archive) to upload.
 //		type: file
 //		required: true
 //	-
@@ -94,6 +98,11 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //
 //			- `following` - accounts to follow.
 //			- `blocks` - accounts to block.
+//			- `mutes` - accounts to mute.
+//			- `domain_blocks` - domains to block (not yet supported).
+//			- `bookmarks` - statuses to bookmark.
+//			- `lists` - lists and the (already followed) accounts in them.
+//			- `archive` - statuses from a Mastodon-compatible account archive (zip), merge mode only.
 //		type: string
 //		required: true
 //	-
@@ -120,12 +129,17 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //			description: unauthorized
 //		'406':
 //			description: not acceptable
+//		'422':
+//			description: unprocessable entity
 //		'500':
 //			description: internal server error
 func (m *Module) ImportPOSTHandler(c *gin.Context) {
-	authed, err := oauth.Authed(c, true, true, true, true)
-	if err != nil {
-		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		apiutil.ScopeWriteAccounts,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
@@ -179,7 +193,7 @@ func (m *Module) ImportPOSTHandler(c *gin.Context) {
 	overwrite := form.Mode == "overwrite"
 
 	// Trigger the import.
-	errWithCode := m.processor.Account().ImportData(
+	errWithCode = m.processor.Account().ImportData(
 		c.Request.Context(),
 		authed.Account,
 		form.Data,
@@ -46,6 +46,8 @@ type ImportTestSuite struct {
 	testApplications map[string]*gtsmodel.Application
 	testUsers        map[string]*gtsmodel.User
 	testAccounts     map[string]*gtsmodel.Account
+	testStatuses     map[string]*gtsmodel.Status
+	testLists        map[string]*gtsmodel.List
 
 	// module being tested
 	importModule *importdata.Module
@@ -57,6 +59,8 @@ func (suite *ImportTestSuite) SetupSuite() {
 	suite.testApplications = testrig.NewTestApplications()
 	suite.testUsers = testrig.NewTestUsers()
 	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testStatuses = testrig.NewTestStatuses()
+	suite.testLists = testrig.NewTestLists()
 }
 
 func (suite *ImportTestSuite) SetupTest() {
@@ -205,6 +209,125 @@ admin@localhost:8080,true
 	}
 }
 
+func (suite *ImportTestSuite) TestImportMutes() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		targetAcct  = suite.testAccounts["local_account_2"]
+	)
+
+	// Have zork mute turtle,
+	// without hiding notifs.
+	data := `Account address,Hide notifications
+1happyturtle@localhost:8080,false
+`
+
+	// Trigger the import handler.
+	suite.TriggerHandler(data, "mutes", "merge")
+
+	// Wait for zork to
+	// be muting turtle.
+	var mute *gtsmodel.UserMute
+	if !testrig.WaitFor(func() bool {
+		var err error
+		mute, err = suite.state.DB.GetMute(ctx, testAccount.ID, targetAcct.ID)
+		return err == nil
+	}) {
+		suite.FailNow("timed out waiting for zork to mute turtle")
+	}
+
+	suite.False(*mute.Notifications)
+}
+
+func (suite *ImportTestSuite) TestImportBookmarksOverwrite() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		prevStatus  = suite.testStatuses["admin_account_status_1"]
+		newStatus   = suite.testStatuses["local_account_2_status_1"]
+	)
+
+	// Replace zork's bookmark of
+	// admin's status with turtle's.
+	data := newStatus.URI + "\n"
+
+	// Trigger the import handler.
+	suite.TriggerHandler(data, "bookmarks", "overwrite")
+
+	// Wait for the import to finish.
+	if !testrig.WaitFor(func() bool {
+		tasks, err := suite.state.DB.GetWorkerTasks(ctx)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		return len(tasks) == 0
+	}) {
+		suite.FailNow("timed out waiting for import to finish")
+	}
+
+	bookmarked, err := suite.state.DB.IsStatusBookmarkedBy(ctx, testAccount.ID, newStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(bookmarked)
+
+	bookmarked, err = suite.state.DB.IsStatusBookmarkedBy(ctx, testAccount.ID, prevStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(bookmarked)
+}
+
+func (suite *ImportTestSuite) TestImportListsOverwrite() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		testList    = suite.testLists["local_account_1_list_1"]
+	)
+
+	// Keep only admin in zork's existing
+	// list, and put turtle in a new list.
+	data := `Cool Ass Posters From This Instance,admin@localhost:8080
+Turtles,1happyturtle@localhost:8080
+`
+
+	// Trigger the import handler.
+	suite.TriggerHandler(data, "lists", "overwrite")
+
+	// Wait for the import to finish.
+	if !testrig.WaitFor(func() bool {
+		tasks, err := suite.state.DB.GetWorkerTasks(ctx)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		return len(tasks) == 0
+	}) {
+		suite.FailNow("timed out waiting for import to finish")
+	}
+
+	lists, err := suite.state.DB.GetListsByAccountID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	listAccounts := make(map[string][]string, len(lists))
+	for _, list := range lists {
+		follows, err := suite.state.DB.GetFollowsInList(ctx, list.ID, nil)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		for _, follow := range follows {
+			listAccounts[list.Title] = append(listAccounts[list.Title], follow.TargetAccount.Username)
+		}
+	}
+
+	suite.Equal(map[string][]string{
+		testList.Title: {"admin"},
+		"Turtles":      {"1happyturtle"},
+	}, listAccounts)
+}
+
 func TestImportTestSuite(t *testing.T) {
 	suite.Run(t, new(ImportTestSuite))
 }
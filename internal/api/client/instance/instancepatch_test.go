@@ -517,8 +517,13 @@ func (suite *InstancePatchTestSuite) TestInstancePatch5() {
 	recorder := httptest.NewRecorder()
 	ctx := suite.newContext(recorder, http.MethodPatch, instance.InstanceInformationPathV1, bodyBytes, w.FormDataContentType(), true)
 
+	// Give the token admin scope, so that
+	// it's the user that's not an admin.
+	token := *suite.testTokens["local_account_1"]
+	token.Scope = "read write admin"
+
 	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
-	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(&token))
 	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
 	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
 
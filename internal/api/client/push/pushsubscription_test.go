@@ -0,0 +1,233 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push_test
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/push"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+// pushRequest calls the given handler with the given method, content
+// type, and body, as local_account_1 using its token, returning the
+// response code and the subscription from the response, if any.
+func (suite *PushTestSuite) pushRequest(
+	method string,
+	contentType string,
+	body string,
+	handler func(*gin.Context),
+) (int, *apimodel.PushSubscription) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	ctx.Request = httptest.NewRequest(method, config.GetProtocol()+"://"+config.GetHost()+"/api/"+push.BasePath, strings.NewReader(body))
+	ctx.Request.Header.Set("accept", "application/json")
+	if contentType != "" {
+		ctx.Request.Header.Set("content-type", contentType)
+	}
+
+	handler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if recorder.Code != http.StatusOK || method == http.MethodDelete {
+		return recorder.Code, nil
+	}
+
+	subscription := &apimodel.PushSubscription{}
+	if err := json.Unmarshal(b, subscription); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder.Code, subscription
+}
+
+// newKeys returns new base64-encoded
+// p256dh and auth user agent keys.
+func (suite *PushTestSuite) newKeys() (string, string) {
+	uaPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	authSecret := make([]byte, 16)
+	if _, err := rand.Read(authSecret); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return base64.RawURLEncoding.EncodeToString(uaPrivate.PublicKey().Bytes()),
+		base64.RawURLEncoding.EncodeToString(authSecret)
+}
+
+func (suite *PushTestSuite) TestSubscriptionLifecycle() {
+	// No subscription yet.
+	code, _ := suite.pushRequest(http.MethodGet, "", "", suite.pushModule.PushSubscriptionGETHandler)
+	suite.Equal(http.StatusNotFound, code)
+
+	// Create one using form data.
+	p256dh, auth := suite.newKeys()
+	form := url.Values{
+		"subscription[endpoint]":      {"https://push.example.org/send/123"},
+		"subscription[keys][p256dh]":  {p256dh},
+		"subscription[keys][auth]":    {auth},
+		"data[alerts][mention]":       {"true"},
+		"data[alerts][follow]":        {"true"},
+		"data[alerts][admin.sign_up]": {"true"},
+		"data[policy]":                {"followed"},
+	}
+	code, created := suite.pushRequest(
+		http.MethodPost,
+		"application/x-www-form-urlencoded",
+		form.Encode(),
+		suite.pushModule.PushSubscriptionPOSTHandler,
+	)
+	if !suite.Equal(http.StatusOK, code) {
+		suite.FailNow("")
+	}
+	suite.Equal("https://push.example.org/send/123", created.Endpoint)
+	suite.NotEmpty(created.ServerKey)
+	suite.Equal("followed", created.Policy)
+	suite.True(created.Alerts.Mention)
+	suite.True(created.Alerts.Follow)
+	suite.True(created.Alerts.AdminSignup)
+	suite.False(created.Alerts.Favourite)
+
+	// Get it back.
+	code, got := suite.pushRequest(http.MethodGet, "", "", suite.pushModule.PushSubscriptionGETHandler)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(created, got)
+
+	// Update it using JSON.
+	code, updated := suite.pushRequest(
+		http.MethodPut,
+		"application/json",
+		`{"data":{"alerts":{"favourite":true},"policy":"all"}}`,
+		suite.pushModule.PushSubscriptionPUTHandler,
+	)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(created.ID, updated.ID)
+	suite.Equal("all", updated.Policy)
+	suite.True(updated.Alerts.Favourite)
+	suite.False(updated.Alerts.Mention)
+
+	// Delete it.
+	code, _ = suite.pushRequest(http.MethodDelete, "", "", suite.pushModule.PushSubscriptionDELETEHandler)
+	suite.Equal(http.StatusOK, code)
+
+	code, _ = suite.pushRequest(http.MethodGet, "", "", suite.pushModule.PushSubscriptionGETHandler)
+	suite.Equal(http.StatusNotFound, code)
+}
+
+func (suite *PushTestSuite) TestCreateReplacesExisting() {
+	var ids []string
+	for i := 0; i < 2; i++ {
+		p256dh, auth := suite.newKeys()
+		code, created := suite.pushRequest(
+			http.MethodPost,
+			"application/json",
+			`{"subscription":{"endpoint":"https://push.example.org/send/123","keys":{"p256dh":"`+p256dh+`","auth":"`+auth+`"}}}`,
+			suite.pushModule.PushSubscriptionPOSTHandler,
+		)
+		if !suite.Equal(http.StatusOK, code) {
+			suite.FailNow("")
+		}
+		suite.Equal("all", created.Policy)
+		ids = append(ids, created.ID)
+	}
+
+	code, got := suite.pushRequest(http.MethodGet, "", "", suite.pushModule.PushSubscriptionGETHandler)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(ids[1], got.ID)
+}
+
+func (suite *PushTestSuite) TestCreateInvalid() {
+	p256dh, auth := suite.newKeys()
+
+	for _, test := range []struct {
+		form url.Values
+		code int
+	}{
+		{
+			// Missing endpoint.
+			form: url.Values{
+				"subscription[keys][p256dh]": {p256dh},
+				"subscription[keys][auth]":   {auth},
+			},
+			code: http.StatusBadRequest,
+		},
+		{
+			// Not https.
+			form: url.Values{
+				"subscription[endpoint]":     {"http://push.example.org/send/123"},
+				"subscription[keys][p256dh]": {p256dh},
+				"subscription[keys][auth]":   {auth},
+			},
+			code: http.StatusUnprocessableEntity,
+		},
+		{
+			// Invalid key.
+			form: url.Values{
+				"subscription[endpoint]":     {"https://push.example.org/send/123"},
+				"subscription[keys][p256dh]": {"bm90IGEga2V5"},
+				"subscription[keys][auth]":   {auth},
+			},
+			code: http.StatusUnprocessableEntity,
+		},
+		{
+			// Invalid policy.
+			form: url.Values{
+				"subscription[endpoint]":     {"https://push.example.org/send/123"},
+				"subscription[keys][p256dh]": {p256dh},
+				"subscription[keys][auth]":   {auth},
+				"data[policy]":               {"everyone"},
+			},
+			code: http.StatusUnprocessableEntity,
+		},
+	} {
+		code, _ := suite.pushRequest(
+			http.MethodPost,
+			"application/x-www-form-urlencoded",
+			test.form.Encode(),
+			suite.pushModule.PushSubscriptionPOSTHandler,
+		)
+		suite.Equal(test.code, code, test.form.Encode())
+	}
+}
@@ -0,0 +1,209 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// PushSubscriptionPOSTHandler swagger:operation POST /api/v1/push/subscription pushSubscriptionPost
+//
+// Create a Web Push subscription for the current access token.
+//
+// Each access token can have only one subscription;
+// creating a new one replaces any existing subscription.
+//
+//	---
+//	tags:
+//	- push
+//
+//	consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: subscription[endpoint]
+//		type: string
+//		description: The https URL of the Web Push endpoint to deliver notifications to.
+//		in: formData
+//		required: true
+//	-
+//		name: subscription[keys][auth]
+//		type: string
+//		description: Base64-encoded 16 byte auth secret, as per RFC 8291.
+//		in: formData
+//		required: true
+//	-
+//		name: subscription[keys][p256dh]
+//		type: string
+//		description: Base64-encoded uncompressed P-256 ECDH public key, as per RFC 8291.
+//		in: formData
+//		required: true
+//	-
+//		name: data[alerts][follow]
+//		type: boolean
+//		description: Receive a push notification for follow notifications.
+//		in: formData
+//		default: false
+//	-
+//		name: data[alerts][follow_request]
+//		type: boolean
+//		description: Receive a push notification for follow_request notifications.
+//		in: formData
+//		default: false
+//	-
+//		name: data[alerts][favourite]
+//		type: boolean
+//		description: Receive a push notification for favourite notifications.
+//		in: formData
+//		default: false
+//	-
+//		name: data[alerts][mention]
+//		type: boolean
+//		description: Receive a push notification for mention notifications.
+//		in: formData
+//		default: false
+//	-
+//		name: data[alerts][reblog]
+//		type: boolean
+//		description: Receive a push notification for reblog notifications.
+//		in: formData
+//		default: false
+//	-
+//		name: data[alerts][poll]
+//		type: boolean
+//		description: Receive a push notification for poll notifications.
+//		in: formData
+//		default: false
+//	-
+//		name: data[alerts][status]
+//		type: boolean
+//		description: Receive a push notification for status notifications.
+//		in: formData
+//		default: false
+//	-
+//		name: data[alerts][admin.sign_up]
+//		type: boolean
+//		description: Receive a push notification for admin.sign_up notifications.
+//		in: formData
+//		default: false
+//	-
+//		name: data[alerts][pending.favourite]
+//		type: boolean
+//		description: Receive a push notification for pending.favourite notifications.
+//		in: formData
+//		default: false
+//	-
+//		name: data[alerts][pending.reply]
+//		type: boolean
+//		description: Receive a push notification for pending.reply notifications.
+//		in: formData
+//		default: false
+//	-
+//		name: data[alerts][pending.reblog]
+//		type: boolean
+//		description: Receive a push notification for pending.reblog notifications.
+//		in: formData
+//		default: false
+//	-
+//		name: data[alerts][pending.quote]
+//		type: boolean
+//		description: Receive a push notification for pending.quote notifications.
+//		in: formData
+//		default: false
+//	-
+//		name: data[alerts][reaction]
+//		type: boolean
+//		description: Receive a push notification for reaction notifications.
+//		in: formData
+//		default: false
+//	-
+//		name: data[policy]
+//		type: string
+//		description: Which accounts should generate notifications.
+//		in: formData
+//		default: all
+//		enum:
+//			- all
+//			- followed
+//			- follower
+//			- none
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- push
+//
+//	responses:
+//		'200':
+//			description: The newly created Web Push subscription.
+//			schema:
+//				"$ref": "#/definitions/webPushSubscription"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable content
+//		'500':
+//			description: internal server error
+func (m *Module) PushSubscriptionPOSTHandler(c *gin.Context) {
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		apiutil.ScopePush,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.PushSubscriptionCreateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+	form.Normalize()
+
+	subscription, errWithCode := m.processor.Push().CreateOrReplace(
+		c.Request.Context(),
+		authed.Account,
+		authed.Token.GetAccess(),
+		form,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, subscription)
+}
@@ -24,7 +24,6 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
-	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
 // SearchGETHandler swagger:operation GET /api/{api_version}/search searchGet
@@ -81,11 +80,12 @@ import (
 //		name: offset
 //		type: integer
 //		description: >-
-//			Page number of results to return (starts at 0).
-//			This parameter is currently not used, page by selecting
-//			a specific query type and using maxID and minID instead.
+//			Number of results to skip, for paging through results of arbitrary
+//			string queries, which are ordered by relevance. Not supported for
+//			other query forms, or together with max_id / min_id; page by selecting
+//			a specific query type and using max_id and min_id instead.
 //		default: 0
-//		maximum: 10
+//		maximum: 1000
 //		minimum: 0
 //		in: query
 //		required: false
@@ -100,8 +100,11 @@ import (
 //			- `#[hashtag_name]` -- search for a hashtag with the given hashtag name, or starting with the given hashtag name. Case insensitive. Can return multiple results.
 //			- any arbitrary string -- search for accounts or statuses containing the given string. Can return multiple results.
 //
-//			Arbitrary string queries may include the following operators:
+//			Arbitrary string queries are matched against whole words, and results are ordered by relevance.
+//			They may include "quoted phrases", to match words appearing together in that order, and the following operators:
 //			- `from:localuser`, `from:remoteuser@instance.tld`: restrict results to statuses created by the specified account.
+//			- `has:media`: restrict results to statuses with media attachments.
+//			- `before:YYYY-MM-DD`, `after:YYYY-MM-DD`: restrict results to statuses created before or after the given day.
 //		in: query
 //		required: true
 //	-
@@ -178,9 +181,12 @@ func (m *Module) SearchGETHandler(c *gin.Context) {
 		return
 	}
 
-	authed, err := oauth.Authed(c, true, true, true, true)
-	if err != nil {
-		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		apiutil.ScopeReadSearch,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
@@ -207,7 +213,7 @@ func (m *Module) SearchGETHandler(c *gin.Context) {
 		return
 	}
 
-	offset, errWithCode := apiutil.ParseSearchOffset(c.Query(apiutil.SearchOffsetKey), 0, 10, 0)
+	offset, errWithCode := apiutil.ParseSearchOffset(c.Query(apiutil.SearchOffsetKey), 0, 1000, 0)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
@@ -915,8 +915,11 @@ func (suite *SearchGetTestSuite) TestSearchAAny() {
 		suite.FailNow(err.Error())
 	}
 
+	// Accounts with "a" in username or
+	// display name, and statuses
+	// containing the word "a".
 	suite.Len(searchResult.Accounts, 5)
-	suite.Len(searchResult.Statuses, 7)
+	suite.Len(searchResult.Statuses, 5)
 	suite.Len(searchResult.Hashtags, 0)
 }
 
@@ -959,7 +962,7 @@ func (suite *SearchGetTestSuite) TestSearchAAnyFollowingOnly() {
 	}
 
 	suite.Len(searchResult.Accounts, 2)
-	suite.Len(searchResult.Statuses, 7)
+	suite.Len(searchResult.Statuses, 5)
 	suite.Len(searchResult.Hashtags, 0)
 }
 
@@ -1002,7 +1005,52 @@ func (suite *SearchGetTestSuite) TestSearchAStatuses() {
 	}
 
 	suite.Len(searchResult.Accounts, 0)
-	suite.Len(searchResult.Statuses, 7)
+	suite.Len(searchResult.Statuses, 5)
+	suite.Len(searchResult.Hashtags, 0)
+}
+
+func (suite *SearchGetTestSuite) TestSearchAStatusesOffset() {
+	var (
+		requestingAccount          = suite.testAccounts["local_account_1"]
+		token                      = suite.testTokens["local_account_1"]
+		user                       = suite.testUsers["local_account_1"]
+		maxID              *string = nil
+		minID              *string = nil
+		limit              *int    = nil
+		offset             *int    = func() *int { i := 2; return &i }()
+		resolve            *bool   = func() *bool { i := true; return &i }()
+		query                      = "a"
+		queryType          *string = func() *string { i := "statuses"; return &i }() // Only statuses.
+		following          *bool   = nil
+		fromAccountID      *string = nil
+		expectedHTTPStatus         = http.StatusOK
+		expectedBody               = ""
+	)
+
+	searchResult, err := suite.getSearch(
+		requestingAccount,
+		token,
+		apiutil.APIv2,
+		user,
+		maxID,
+		minID,
+		limit,
+		offset,
+		query,
+		queryType,
+		resolve,
+		following,
+		fromAccountID,
+		expectedHTTPStatus,
+		expectedBody)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(searchResult.Accounts, 0)
+	// Skip the 2 most relevant
+	// of the 5 matching statuses.
+	suite.Len(searchResult.Statuses, 3)
 	suite.Len(searchResult.Hashtags, 0)
 }
 
@@ -27,6 +27,8 @@ import (
 const (
 	// IDKey is for status UUIDs
 	IDKey = "id"
+	// ReactionEmojiKey is for the emoji of a status reaction
+	ReactionEmojiKey = "emoji"
 	// BasePath is the base path for serving the statuses API, minus the 'api' prefix
 	BasePath = "/v1/statuses"
 	// BasePathWithID is just the base path with the ID key in it.
@@ -70,6 +72,12 @@ const (
 
 	// SourcePath is used for fetching source of a post.
 	SourcePath = BasePathWithID + "/source"
+
+	// PleromaBasePathWithID is the Pleroma-compatible statuses
+	// base path with the ID key in it, minus the 'api' prefix.
+	PleromaBasePathWithID = "/v1/pleroma/statuses/:" + IDKey
+	// ReactionPath is for adding or removing an emoji reaction on a status
+	ReactionPath = PleromaBasePathWithID + "/reactions/:" + ReactionEmojiKey
 )
 
 type Module struct {
@@ -83,9 +91,10 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	// create / get / delete status
+	// create / get / edit / delete status
 	attachHandler(http.MethodPost, BasePath, m.StatusCreatePOSTHandler)
 	attachHandler(http.MethodGet, BasePathWithID, m.StatusGETHandler)
+	attachHandler(http.MethodPut, BasePathWithID, m.StatusEditPUTHandler)
 	attachHandler(http.MethodDelete, BasePathWithID, m.StatusDELETEHandler)
 
 	// fave stuff
@@ -93,6 +102,10 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, UnfavouritePath, m.StatusUnfavePOSTHandler)
 	attachHandler(http.MethodGet, FavouritedPath, m.StatusFavedByGETHandler)
 
+	// reaction stuff
+	attachHandler(http.MethodPut, ReactionPath, m.StatusReactionPUTHandler)
+	attachHandler(http.MethodDelete, ReactionPath, m.StatusReactionDELETEHandler)
+
 	// pin stuff
 	attachHandler(http.MethodPost, PinPath, m.StatusPinPOSTHandler)
 	attachHandler(http.MethodPost, UnpinPath, m.StatusUnpinPOSTHandler)
@@ -114,6 +114,20 @@ func (suite *StatusBoostTestSuite) TestPostBoost() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -167,6 +181,20 @@ func (suite *StatusBoostTestSuite) TestPostBoost() {
         ],
         "with_approval": []
       },
+      "can_quote": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
+      },
+      "can_react": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
+      },
       "can_reblog": {
         "always": [
           "public",
@@ -296,6 +324,22 @@ func (suite *StatusBoostTestSuite) TestPostBoostOwnFollowersOnly() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "author",
+        "followers",
+        "mentioned",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "author",
@@ -345,6 +389,22 @@ func (suite *StatusBoostTestSuite) TestPostBoostOwnFollowersOnly() {
         ],
         "with_approval": []
       },
+      "can_quote": {
+        "always": [
+          "author",
+          "me"
+        ],
+        "with_approval": []
+      },
+      "can_react": {
+        "always": [
+          "author",
+          "followers",
+          "mentioned",
+          "me"
+        ],
+        "with_approval": []
+      },
       "can_reblog": {
         "always": [
           "author",
@@ -508,6 +568,20 @@ func (suite *StatusBoostTestSuite) TestPostBoostImplicitAccept() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -553,6 +627,20 @@ func (suite *StatusBoostTestSuite) TestPostBoostImplicitAccept() {
         ],
         "with_approval": []
       },
+      "can_quote": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
+      },
+      "can_react": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
+      },
       "can_reblog": {
         "always": [
           "public",
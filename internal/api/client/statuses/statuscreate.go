@@ -30,7 +30,6 @@ import (
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
-	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
@@ -130,6 +129,12 @@ import (
 //		type: string
 //		in: formData
 //	-
+//		name: quoted_status_id
+//		x-go-name: QuotedStatusID
+//		description: ID of the status being quoted, if status is a quote.
+//		type: string
+//		in: formData
+//	-
 //		name: sensitive
 //		x-go-name: Sensitive
 //		description: Status and attached media should be marked as sensitive.
@@ -180,8 +185,6 @@ import (
 //			ISO 8601 Datetime at which to schedule a status.
 //			Providing this parameter will cause ScheduledStatus to be returned instead of Status.
 //			Must be at least 5 minutes in the future.
-//
-//			This feature isn't implemented yet.
 //		type: string
 //		in: formData
 //	-
@@ -239,7 +242,9 @@ import (
 //
 //	responses:
 //		'200':
-//			description: "The newly created status."
+//			description: >-
+//				The newly created status.
+//				If scheduled_at was set, a scheduledStatus will be returned instead.
 //			schema:
 //				"$ref": "#/definitions/status"
 //		'400':
@@ -252,12 +257,17 @@ import (
 //			description: not found
 //		'406':
 //			description: not acceptable
+//		'422':
+//			description: unprocessable content
 //		'500':
 //			description: internal server error
 func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
-	authed, err := oauth.Authed(c, true, true, true, true)
-	if err != nil {
-		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		apiutil.ScopeWriteStatuses,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
@@ -291,6 +301,24 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if form.ScheduledAt != "" {
+		// Status should be published later,
+		// return the scheduled status instead.
+		scheduled, errWithCode := m.processor.Status().ScheduledStatusCreate(
+			c.Request.Context(),
+			authed.Account,
+			authed.Application,
+			form,
+		)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		c.JSON(http.StatusOK, scheduled)
+		return
+	}
+
 	apiStatus, errWithCode := m.processor.Status().Create(
 		c.Request.Context(),
 		authed.Account,
@@ -403,7 +431,7 @@ func validateNormalizeCreateStatus(form *apimodel.StatusCreateRequest) error {
 	}
 
 	if form.Poll != nil {
-		if err := validateNormalizeCreatePoll(form); err != nil {
+		if err := validateNormalizePoll(form.Poll); err != nil {
 			return err
 		}
 	}
@@ -425,17 +453,20 @@ func validateNormalizeCreateStatus(form *apimodel.StatusCreateRequest) error {
 	return nil
 }
 
-func validateNormalizeCreatePoll(form *apimodel.StatusCreateRequest) error {
+// validateNormalizePoll checks the given poll
+// form for missing or overlength options, and
+// normalizes the poll expiry if necessary.
+func validateNormalizePoll(poll *apimodel.PollRequest) error {
 	maxPollOptions := config.GetStatusesPollMaxOptions()
 	maxPollChars := config.GetStatusesPollOptionMaxChars()
 
 	// Normalize poll expiry if necessary.
 	// If we parsed this as JSON, expires_in
 	// may be either a float64 or a string.
-	if ei := form.Poll.ExpiresInI; ei != nil {
+	if ei := poll.ExpiresInI; ei != nil {
 		switch e := ei.(type) {
 		case float64:
-			form.Poll.ExpiresIn = int(e)
+			poll.ExpiresIn = int(e)
 
 		case string:
 			expiresIn, err := strconv.Atoi(e)
@@ -443,22 +474,22 @@ func validateNormalizeCreatePoll(form *apimodel.StatusCreateRequest) error {
 				return fmt.Errorf("could not parse expires_in value %s as integer: %w", e, err)
 			}
 
-			form.Poll.ExpiresIn = expiresIn
+			poll.ExpiresIn = expiresIn
 
 		default:
 			return fmt.Errorf("could not parse expires_in type %T as integer", ei)
 		}
 	}
 
-	if len(form.Poll.Options) == 0 {
+	if len(poll.Options) == 0 {
 		return errors.New("poll with no options")
 	}
 
-	if len(form.Poll.Options) > maxPollOptions {
-		return fmt.Errorf("too many poll options provided, %d provided but limit is %d", len(form.Poll.Options), maxPollOptions)
+	if len(poll.Options) > maxPollOptions {
+		return fmt.Errorf("too many poll options provided, %d provided but limit is %d", len(poll.Options), maxPollOptions)
 	}
 
-	for _, p := range form.Poll.Options {
+	for _, p := range poll.Options {
 		if length := len([]rune(p)); length > maxPollChars {
 			return fmt.Errorf("poll option too long, %d characters provided but limit is %d", length, maxPollChars)
 		}
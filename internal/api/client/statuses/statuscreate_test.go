@@ -118,6 +118,22 @@ func (suite *StatusCreateTestSuite) TestPostNewStatus() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "author",
+        "followers",
+        "mentioned",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "author",
@@ -201,6 +217,20 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusIntPolicy() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "author",
@@ -296,6 +326,20 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusIntPolicyJSON() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "author",
@@ -402,6 +446,20 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusMarkdown() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -485,6 +543,20 @@ func (suite *StatusCreateTestSuite) TestMentionUnknownAccount() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -562,6 +634,20 @@ func (suite *StatusCreateTestSuite) TestPostStatusWithLinksAndTags() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -653,6 +739,20 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithEmoji() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -742,6 +842,20 @@ func (suite *StatusCreateTestSuite) TestReplyToLocalStatus() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -824,6 +938,20 @@ func (suite *StatusCreateTestSuite) TestAttachNewMediaSuccess() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -928,6 +1056,20 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithNoncanonicalLanguageTag
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -1002,6 +1144,20 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithPollForm() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -1098,6 +1254,20 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithPollJSON() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -1153,6 +1323,38 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithPollJSON() {
 }`, out)
 }
 
+// Try to post a status using a token
+// that only has read permissions.
+func (suite *StatusCreateTestSuite) TestPostNewStatusReadOnlyToken() {
+	token := *suite.testTokens["local_account_1"]
+	token.Scope = "read write:media"
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(&token))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(
+		http.MethodPost,
+		"http://localhost:8080"+statuses.BasePath,
+		bytes.NewReader([]byte(`{"status":"this should not be posted"}`)),
+	)
+	ctx.Request.Header.Set("content-type", "application/json")
+	ctx.Request.Header.Set("accept", "application/json")
+
+	// Trigger handler.
+	suite.statusModule.StatusCreatePOSTHandler(ctx)
+	out, recorder := suite.parseStatusResponse(recorder)
+
+	// We should have 403 from
+	// our call to the function.
+	suite.Equal(http.StatusForbidden, recorder.Code)
+	suite.Equal(`{
+  "error": "Forbidden: token is missing scope write:statuses, which is required to perform this action"
+}`, out)
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
@@ -0,0 +1,149 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package statuses_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusEditTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusEditTestSuite) editStatus(
+	accountName string,
+	targetStatusID string,
+	jsonData string,
+) (*apimodel.Status, *httptest.ResponseRecorder) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountName]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountName])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountName])
+
+	ctx.Request = httptest.NewRequest(
+		http.MethodPut,
+		"http://localhost:8080"+strings.ReplaceAll(statuses.BasePathWithID, ":id", targetStatusID),
+		bytes.NewReader([]byte(jsonData)),
+	)
+	ctx.Request.Header.Set("content-type", "application/json")
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   statuses.IDKey,
+			Value: targetStatusID,
+		},
+	}
+
+	// Trigger handler.
+	suite.statusModule.StatusEditPUTHandler(ctx)
+
+	if recorder.Code != http.StatusOK {
+		return nil, recorder
+	}
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiStatus := new(apimodel.Status)
+	if err := json.Unmarshal(b, apiStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return apiStatus, recorder
+}
+
+func (suite *StatusEditTestSuite) TestEditStatus() {
+	var (
+		ctx            = context.Background()
+		testAccount    = suite.testAccounts["local_account_1"]
+		targetStatusID = suite.testStatuses["local_account_1_status_1"].ID
+	)
+
+	apiStatus, recorder := suite.editStatus("local_account_1", targetStatusID, `{
+  "status": "hello everyone! (edited)",
+  "spoiler_text": "introduction post",
+  "sensitive": true
+}`)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("<p>hello everyone! (edited)</p>", apiStatus.Content)
+	suite.Equal("introduction post", apiStatus.SpoilerText)
+	suite.NotNil(apiStatus.EditedAt)
+
+	// The status in the database should be updated.
+	dbStatus, err := suite.db.GetStatusByID(ctx, targetStatusID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("hello everyone! (edited)", dbStatus.Text)
+	suite.False(dbStatus.EditedAt.IsZero())
+	suite.Len(dbStatus.EditIDs, 1)
+
+	// History should now contain the
+	// original and the edited version.
+	history, errWithCode := suite.processor.Status().HistoryGet(ctx, testAccount, targetStatusID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(history, 2)
+	suite.Equal("hello everyone!", history[0].Content)
+	suite.Equal("<p>hello everyone! (edited)</p>", history[1].Content)
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusNotOwned() {
+	targetStatusID := suite.testStatuses["local_account_2_status_1"].ID
+
+	_, recorder := suite.editStatus("local_account_1", targetStatusID, `{
+  "status": "this isn't my status!"
+}`)
+	suite.Equal(http.StatusForbidden, recorder.Code)
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusEmpty() {
+	targetStatusID := suite.testStatuses["local_account_1_status_1"].ID
+
+	_, recorder := suite.editStatus("local_account_1", targetStatusID, `{
+  "status": ""
+}`)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+	suite.Equal(`{"error":"Bad Request: no status, media, or poll provided"}`, recorder.Body.String())
+}
+
+func TestStatusEditTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusEditTestSuite))
+}
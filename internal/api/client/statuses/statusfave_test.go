@@ -119,6 +119,20 @@ func (suite *StatusFaveTestSuite) TestPostFave() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -242,6 +256,20 @@ func (suite *StatusFaveTestSuite) TestPostFaveImplicitAccept() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -166,6 +166,20 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, muted)
@@ -251,6 +265,20 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, unmuted)
@@ -28,7 +28,6 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
-	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	streampkg "github.com/superseriousbusiness/gotosocial/internal/stream"
 
 	"github.com/gin-gonic/gin"
@@ -77,6 +76,7 @@ import (
 //			`user`: receive updates for the account's home timeline.
 //			`public`: receive updates for the public timeline.
 //			`public:local`: receive updates for the local timeline.
+//			`public:remote`: receive updates for the public timeline, excluding local statuses.
 //			`hashtag`: receive updates for a given hashtag.
 //			`hashtag:local`: receive local updates for a given hashtag.
 //			`list`: receive updates for a certain list of accounts.
@@ -115,6 +115,7 @@ import (
 //							- user
 //							- public
 //							- public:local
+//							- public:remote
 //							- hashtag
 //							- hashtag:local
 //							- list
@@ -185,9 +186,11 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 
 		// No explicit token was provided:
 		// try regular oauth as a last resort.
-		authed, err := oauth.Authed(c, true, true, true, true)
-		if err != nil {
-			errWithCode := gtserror.NewErrorUnauthorized(err, err.Error())
+		authed, errWithCode := apiutil.TokenAuth(c,
+			true, true, true, true,
+			apiutil.ScopeReadStreaming,
+		)
+		if errWithCode != nil {
 			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 			return
 		}
@@ -210,10 +213,21 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 	// can allow streaming for specific list IDs or hashtags.
 	// The streamType in this case will end up looking like
 	// `hashtag:example` or `list:01H3YF48G8B7KTPQFS8D2QBVG8`.
-	if list := c.Query(StreamListKey); list != "" {
-		streamType += ":" + list
-	} else if tag := c.Query(StreamTagKey); tag != "" {
-		streamType += ":" + tag
+	switch streamType {
+	case streampkg.TimelineHashtag, streampkg.TimelineHashtagLocal:
+		streamType, errWithCode = m.processor.Stream().HashtagStreamType(
+			streamType,
+			c.Query(StreamTagKey),
+		)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+	default:
+		if list := c.Query(StreamListKey); list != "" {
+			streamType += ":" + list
+		}
 	}
 
 	// Open a stream with the processor; this lets processor
@@ -323,6 +337,7 @@ func (m *Module) readFromWSConn(
 			Type   string `json:"type"`
 			Stream string `json:"stream"`
 			List   string `json:"list,omitempty"`
+			Tag    string `json:"tag,omitempty"`
 		}
 
 		// Read JSON objects from the client and act on them.
@@ -353,11 +368,24 @@ func (m *Module) readFromWSConn(
 			continue
 		}
 
-		if msg.List != "" {
-			// If a list is given, add this to
-			// the stream name as this is how we
-			// we track stream types internally.
-			msg.Stream += ":" + msg.List
+		switch msg.Stream {
+		case streampkg.TimelineHashtag, streampkg.TimelineHashtagLocal:
+			// Hashtag streams are tracked
+			// internally keyed by tag name.
+			streamType, errWithCode := m.processor.Stream().HashtagStreamType(msg.Stream, msg.Tag)
+			if errWithCode != nil {
+				l.Warnf("invalid 'tag' field: %v", msg)
+				continue
+			}
+			msg.Stream = streamType
+
+		default:
+			if msg.List != "" {
+				// If a list is given, add this to
+				// the stream name as this is how we
+				// we track stream types internally.
+				msg.Stream += ":" + msg.List
+			}
 		}
 
 		switch msg.Type {
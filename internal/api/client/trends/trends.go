@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+)
+
+const (
+	BasePath     = "/v1/trends"
+	TagsPath     = BasePath + "/tags"
+	StatusesPath = BasePath + "/statuses"
+	LinksPath    = BasePath + "/links"
+)
+
+type Module struct {
+	processor *processing.Processor
+}
+
+func New(processor *processing.Processor) *Module {
+	return &Module{
+		processor: processor,
+	}
+}
+
+func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	// Mastodon's deprecated alias for /api/v1/trends/tags.
+	attachHandler(http.MethodGet, BasePath, m.TrendsTagsGETHandler)
+	attachHandler(http.MethodGet, TagsPath, m.TrendsTagsGETHandler)
+	attachHandler(http.MethodGet, StatusesPath, m.TrendsStatusesGETHandler)
+	attachHandler(http.MethodGet, LinksPath, m.TrendsLinksGETHandler)
+}
@@ -0,0 +1,101 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// OIDCTwoFactorHelp is returned when trying to
+// manage 2FA on an instance using OIDC sign-in.
+const OIDCTwoFactorHelp = "two factor authentication cannot be managed by GoToSocial as this instance is running with OIDC enabled; you must set up two factor authentication with your OIDC provider"
+
+// TwoFactorQRCodeURIPOSTHandler swagger:operation POST /api/v1/user/2fa/qruri twoFactorQRCodeURIPost
+//
+// Start enabling two factor authentication, by generating a new two factor secret
+// for the authorized user and returning a QR code uri for it in the `otpauth` scheme.
+//
+// Each call generates a new secret, replacing any secret from an earlier, unfinished attempt.
+// The uri can be entered into an authenticator app, or scanned as the QR code returned by `/api/v1/user/2fa/qr.png`.
+// Two factor authentication isn't enabled until a code from the app is confirmed by calling `/api/v1/user/2fa/enable`.
+//
+//	---
+//	tags:
+//	- user
+//
+//	produces:
+//	- text/plain
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:user
+//
+//	responses:
+//		'200':
+//			description: QR code uri.
+//			schema:
+//				type: string
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: conflict, 2fa is already enabled for this user
+//		'422':
+//			description: unprocessable request because instance is running with OIDC backend
+//		'500':
+//			description: internal error
+func (m *Module) TwoFactorQRCodeURIPOSTHandler(c *gin.Context) {
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		apiutil.ScopeWriteUser,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.TextPlainHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if config.GetOIDCEnabled() {
+		err := errors.New("instance running with OIDC")
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnprocessableEntity(err, OIDCTwoFactorHelp), m.processor.InstanceGetV1)
+		return
+	}
+
+	uri, errWithCode := m.processor.User().TwoFactorQRCodeURICreate(c.Request.Context(), authed.User)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.Data(c, http.StatusOK, apiutil.TextPlain, []byte(uri.String()))
+}
@@ -31,6 +31,16 @@ const (
 	PasswordChangePath = BasePath + "/password_change"
 	// EmailChangePath is the path for POSTing an email address change request.
 	EmailChangePath = BasePath + "/email_change"
+	// TwoFactorPath is the base path for managing two factor authentication.
+	TwoFactorPath = BasePath + "/2fa"
+	// TwoFactorQRCodeURIPath is the path for POSTing a request for a new 2FA QR code uri.
+	TwoFactorQRCodeURIPath = TwoFactorPath + "/qruri"
+	// TwoFactorQRCodePngPath is the path for GETting a 2FA QR code png.
+	TwoFactorQRCodePngPath = TwoFactorPath + "/qr.png"
+	// TwoFactorEnablePath is the path for POSTing a 2FA enable request.
+	TwoFactorEnablePath = TwoFactorPath + "/enable"
+	// TwoFactorDisablePath is the path for POSTing a 2FA disable request.
+	TwoFactorDisablePath = TwoFactorPath + "/disable"
 )
 
 type Module struct {
@@ -47,4 +57,8 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, BasePath, m.UserGETHandler)
 	attachHandler(http.MethodPost, PasswordChangePath, m.PasswordChangePOSTHandler)
 	attachHandler(http.MethodPost, EmailChangePath, m.EmailChangePOSTHandler)
+	attachHandler(http.MethodPost, TwoFactorQRCodeURIPath, m.TwoFactorQRCodeURIPOSTHandler)
+	attachHandler(http.MethodGet, TwoFactorQRCodePngPath, m.TwoFactorQRCodePngGETHandler)
+	attachHandler(http.MethodPost, TwoFactorEnablePath, m.TwoFactorEnablePOSTHandler)
+	attachHandler(http.MethodPost, TwoFactorDisablePath, m.TwoFactorDisablePOSTHandler)
 }
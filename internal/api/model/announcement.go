@@ -19,7 +19,7 @@ package model
 
 // Announcement models an admin announcement for the instance.
 //
-// TODO: swagger:model announcement once announcement API is supported
+// swagger:model announcement
 type Announcement struct {
 	// The ID of the announcement.
 	// example: 01FC30T7X4TNCZK0TH90QYF3M4
@@ -31,16 +31,17 @@ type Announcement struct {
 	// When the announcement should begin to be displayed (ISO 8601 Datetime).
 	// If the announcement has no start time, this will be omitted or empty.
 	// example: 2021-07-30T09:20:25+00:00
-	StartsAt string `json:"starts_at"`
+	StartsAt string `json:"starts_at,omitempty"`
 	// When the announcement should stop being displayed (ISO 8601 Datetime).
 	// If the announcement has no end time, this will be omitted or empty.
 	// example: 2021-07-30T09:20:25+00:00
-	EndsAt string `json:"ends_at"`
+	EndsAt string `json:"ends_at,omitempty"`
 	// Announcement doesn't have begin time and end time, but begin day and end day.
 	AllDay bool `json:"all_day"`
 	// When the announcement was first published (ISO 8601 Datetime).
+	// If the announcement has not been published, this will be omitted or empty.
 	// example: 2021-07-30T09:20:25+00:00
-	PublishedAt string `json:"published_at"`
+	PublishedAt string `json:"published_at,omitempty"`
 	// When the announcement was last updated (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	UpdatedAt string `json:"updated_at"`
@@ -56,7 +57,25 @@ type Announcement struct {
 	// Tags used in this announcement.
 	Tags []Tag `json:"tags"`
 	// Emojis used in this announcement.
-	Emojis []Emoji `json:"emoji"`
+	Emojis []Emoji `json:"emojis"`
 	// Reactions to this announcement.
 	Reactions []AnnouncementReaction `json:"reactions"`
 }
+
+// AnnouncementRequest is the form submitted as a POST or PATCH
+// to create or update an instance announcement via the admin API.
+//
+// swagger:ignore
+type AnnouncementRequest struct {
+	// Text of the announcement, as markdown.
+	Text *string `form:"text" json:"text" xml:"text"`
+	// When the announced event starts (ISO 8601 Datetime). Empty string to unset.
+	StartsAt *string `form:"starts_at" json:"starts_at" xml:"starts_at"`
+	// When the announced event ends (ISO 8601 Datetime). Empty string to unset.
+	// Announcements are hidden from users once they have ended.
+	EndsAt *string `form:"ends_at" json:"ends_at" xml:"ends_at"`
+	// StartsAt and EndsAt should be treated as dates rather than times.
+	AllDay *bool `form:"all_day" json:"all_day" xml:"all_day"`
+	// Announcement should be visible to users.
+	Published *bool `form:"published" json:"published" xml:"published"`
+}
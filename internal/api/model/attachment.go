@@ -111,6 +111,12 @@ type WebAttachment struct {
 	// MIME type of
 	// the thumbnail.
 	PreviewMIMEType string
+
+	// Thumbnail variants with the same
+	// aspect ratio as the preview, for use
+	// alongside it in a srcset. Variants
+	// cropped around focus are excluded.
+	PreviewVariants []MediaThumbnail
 }
 
 // MediaMeta models media metadata.
@@ -124,6 +130,11 @@ type MediaMeta struct {
 	Small MediaDimensions `json:"small,omitempty"`
 	// Focus data for the media.
 	Focus *MediaFocus `json:"focus,omitempty"`
+	// Additional scaled-down versions of the media
+	// for responsive display, smallest first. Media
+	// that is very wide or very tall is cropped around
+	// its focus for these. Not set for audio.
+	Thumbnails []MediaThumbnail `json:"thumbnails,omitempty"`
 }
 
 // MediaFocus models the focal point of a piece of media.
@@ -138,6 +149,21 @@ type MediaFocus struct {
 	Y float32 `json:"y"`
 }
 
+// MediaThumbnail models a scaled-down version of a piece of media.
+//
+// swagger:model mediaThumbnail
+type MediaThumbnail struct {
+	// The location of the thumbnail.
+	// example: https://example.org/fileserver/some_id/attachments/some_id/w640/attachment.jpeg
+	URL string `json:"url"`
+	// Width of the thumbnail in pixels.
+	// example: 640
+	Width int `json:"width"`
+	// Height of the thumbnail in pixels.
+	// example: 360
+	Height int `json:"height"`
+}
+
 // MediaDimensions models detailed properties of a piece of media.
 //
 // swagger:model mediaDimensions
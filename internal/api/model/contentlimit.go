@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// ContentLimit represents a limit placed by an admin on the
+// content of statuses from either a single account, or from
+// all accounts on a domain (and its subdomains).
+//
+// swagger:model contentLimit
+type ContentLimit struct {
+	// The ID of the content limit.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// ID of the account this limit applies to, if it's an account-level limit.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	AccountID string `json:"account_id,omitempty"`
+	// Domain this limit applies to, if it's a domain-level limit.
+	// example: art.example.org
+	Domain string `json:"domain,omitempty"`
+	// Mark all statuses from the target as sensitive.
+	// example: true
+	ForceSensitive bool `json:"force_sensitive"`
+	// Content warning to add to all statuses from the target.
+	// example: nsfw art
+	ContentWarning string `json:"content_warning"`
+	// Don't fetch or show media attached to statuses from the target.
+	// example: false
+	RejectMedia bool `json:"reject_media"`
+	// Private comment on this limit, viewable to admins.
+	// example: lots of unmarked nsfw art on this instance
+	PrivateComment string `json:"private_comment"`
+	// ID of the account that created this limit.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	CreatedBy string `json:"created_by"`
+	// Time at which the limit was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+	// Time at which the limit was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	UpdatedAt string `json:"updated_at"`
+}
+
+// ContentLimitRequest is the form submitted
+// as a POST to /api/v1/admin/content_limits
+// to create a limit, or as a PATCH to
+// /api/v1/admin/content_limits/{id} to
+// update an existing limit.
+//
+// swagger:ignore
+type ContentLimitRequest struct {
+	// ID of the account to limit. Only used on creation.
+	AccountID string `form:"account_id" json:"account_id" xml:"account_id"`
+	// Domain to limit. Only used on creation.
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+	// Mark all statuses from the target as sensitive.
+	ForceSensitive *bool `form:"force_sensitive" json:"force_sensitive" xml:"force_sensitive"`
+	// Content warning to add to all statuses from the target.
+	ContentWarning *string `form:"content_warning" json:"content_warning" xml:"content_warning"`
+	// Don't fetch or show media attached to statuses from the target.
+	RejectMedia *bool `form:"reject_media" json:"reject_media" xml:"reject_media"`
+	// Private comment on this limit, viewable to admins.
+	PrivateComment *string `form:"private_comment" json:"private_comment" xml:"private_comment"`
+}
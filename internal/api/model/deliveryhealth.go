@@ -0,0 +1,54 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// DeliveryHealth represents the health of outgoing
+// deliveries to a single domain, that deliveries
+// have been failing to since their last success.
+//
+// swagger:model deliveryHealth
+type DeliveryHealth struct {
+	// Domain that deliveries are failing to.
+	// example: dead.example.org
+	Domain string `json:"domain"`
+	// Domain is marked as unavailable, so deliveries to it are skipped,
+	// except for an occasional probe to check whether it has recovered.
+	// example: true
+	Unavailable bool `json:"unavailable"`
+	// Number of failed delivery attempts since the last successful delivery, or since startup.
+	// example: 420
+	Failures int `json:"failures"`
+	// Number of deliveries dropped without an attempt while the domain was unavailable, since startup.
+	// example: 69
+	Skipped int `json:"skipped"`
+	// Error returned by the last failed delivery attempt.
+	// example: dial tcp: lookup dead.example.org: i/o timeout
+	LastError string `json:"last_error"`
+	// Time of the last failed delivery attempt since startup, if any (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	LastFailureAt *string `json:"last_failure_at"`
+	// Time of the first failed delivery attempt since the last successful delivery (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	FailingSince string `json:"failing_since"`
+	// Time at which the domain was marked unavailable, if it was (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	UnavailableAt *string `json:"unavailable_at"`
+	// Time of the last delivery attempt permitted while the domain was unavailable (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	LastProbeAt *string `json:"last_probe_at"`
+}
@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// DeliveryQueue represents the outgoing deliveries
+// currently pending to a single domain, both those
+// queued awaiting a first attempt, and those that
+// have failed and are awaiting retry.
+//
+// swagger:model deliveryQueue
+type DeliveryQueue struct {
+	// Domain that deliveries are pending to.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Number of deliveries queued awaiting a first attempt.
+	// example: 12
+	Queued int `json:"queued"`
+	// Number of deliveries that have failed at least once, awaiting retry.
+	// example: 3
+	Backlogged int `json:"backlogged"`
+	// Total number of delivery attempts made for pending deliveries.
+	// example: 9
+	Attempts int `json:"attempts"`
+	// Highest number of delivery attempts made for a single pending delivery.
+	// example: 4
+	MaxAttempts int `json:"max_attempts"`
+	// Time at which the oldest pending delivery was created, if known (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	OldestCreatedAt *string `json:"oldest_created_at"`
+	// Time of the next scheduled retry of a backlogged delivery, if any (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	NextRetryAt *string `json:"next_retry_at"`
+	// Error returned by the last failed delivery attempt to the domain, if any.
+	// example: http response: 503 Service Unavailable
+	LastError string `json:"last_error"`
+	// Time of the last failed delivery attempt to the domain, if any (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	LastErrorAt *string `json:"last_error_at"`
+	// Domain is marked as unavailable, so pending deliveries to it will be skipped.
+	// example: false
+	Unavailable bool `json:"unavailable"`
+}
+
+// DeliveryQueueRequest is the form submitted as a POST to
+// /api/v1/admin/delivery_queue/purge or /api/v1/admin/delivery_queue/retry.
+//
+// swagger:ignore
+type DeliveryQueueRequest struct {
+	// Domain to purge or retry pending deliveries to.
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+}
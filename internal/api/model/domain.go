@@ -61,6 +61,10 @@ type DomainPermission struct {
 	// Time at which the permission entry was created (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	CreatedAt string `json:"created_at,omitempty"`
+	// Permission type of this entry (block, allow).
+	// Only set for domain permission drafts.
+	// example: block
+	PermissionType string `json:"permission_type,omitempty"`
 }
 
 // DomainPermissionRequest is the form submitted as a POST to create a new domain permission entry (allow/block).
@@ -87,6 +91,107 @@ type DomainPermissionRequest struct {
 	PublicComment string `form:"public_comment" json:"public_comment" xml:"public_comment"`
 }
 
+// DomainPermissionDraftRequest is the form submitted as a POST to create a new domain permission draft.
+//
+// swagger:ignore
+type DomainPermissionDraftRequest struct {
+	// Domain for which this draft should apply.
+	// example: example.org
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+	// Type of the permission to create (block, allow).
+	// example: block
+	PermissionType string `form:"permission_type" json:"permission_type" xml:"permission_type"`
+	// Obfuscate the domain name when displaying this permission entry publicly.
+	// example: false
+	Obfuscate bool `form:"obfuscate" json:"obfuscate" xml:"obfuscate"`
+	// Private comment for other admins on why this permission entry was created.
+	// example: don't like 'em!!!!
+	PrivateComment string `form:"private_comment" json:"private_comment" xml:"private_comment"`
+	// Public comment on why this permission entry was created.
+	// example: foss dorks 😫
+	PublicComment string `form:"public_comment" json:"public_comment" xml:"public_comment"`
+}
+
+// DomainPermissionSubscription represents an auto-refreshing
+// subscription to a list of domain permissions (blocks, allows).
+//
+// swagger:model domainPermissionSubscription
+type DomainPermissionSubscription struct {
+	// The ID of the domain permission subscription.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// Priority of this subscription compared to others of the same permission type. 0-255 (higher = higher priority).
+	// example: 100
+	Priority uint8 `json:"priority"`
+	// Title of this subscription, as set by admin who created or updated it.
+	// example: really cool list of neato pals
+	Title string `json:"title"`
+	// The type of domain permission subscription (allow, block).
+	// example: block
+	PermissionType string `json:"permission_type"`
+	// If true, domain permissions arising from this subscription will be created as drafts that must be approved by a moderator to take effect.
+	// If false, domain permissions from this subscription will come into force immediately.
+	// example: true
+	AsDraft bool `json:"as_draft"`
+	// ID of the account that created this subscription.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	CreatedBy string `json:"created_by"`
+	// Time at which the subscription was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+	// URI to call in order to fetch the permissions list.
+	// example: https://www.example.org/blocklists/list1.csv
+	URI string `json:"uri"`
+	// MIME content type to use when parsing the permissions list.
+	// example: text/csv
+	ContentType string `json:"content_type"`
+	// (Optional) username to set for basic auth when doing a fetch of URI.
+	// example: admin123
+	FetchUsername string `json:"fetch_username,omitempty"`
+	// (Optional) password to set for basic auth when doing a fetch of URI.
+	// example: admin123
+	FetchPassword string `json:"fetch_password,omitempty"`
+	// Time of the most recent fetch attempt (successful or otherwise) (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	FetchedAt string `json:"fetched_at,omitempty"`
+	// Time of the most recent successful fetch (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	SuccessfullyFetchedAt string `json:"successfully_fetched_at,omitempty"`
+	// If most recent fetch attempt failed, this field will contain an error message related to the fetch attempt.
+	// example: Oopsie doopsie, we made a fucky wucky.
+	// readonly: true
+	Error string `json:"error,omitempty"`
+}
+
+// DomainPermissionSubscriptionRequest is the form submitted as a POST or PATCH
+// to create or update a domain permission subscription. Pointer fields which
+// are not set will be left unchanged on update, or defaulted on create.
+//
+// swagger:ignore
+type DomainPermissionSubscriptionRequest struct {
+	// Priority of this subscription compared to others of the same permission type. 0-255 (higher = higher priority).
+	Priority *int `form:"priority" json:"priority" xml:"priority"`
+	// Title of this subscription.
+	Title *string `form:"title" json:"title" xml:"title"`
+	// Type of permissions to create by parsing the targeted file/list (allow, block).
+	PermissionType *string `form:"permission_type" json:"permission_type" xml:"permission_type"`
+	// If true, domain permissions arising from this subscription will be created as drafts.
+	AsDraft *bool `form:"as_draft" json:"as_draft" xml:"as_draft"`
+	// URI to call in order to fetch the permissions list.
+	URI *string `form:"uri" json:"uri" xml:"uri"`
+	// MIME content type to use when parsing the permissions list (text/csv, application/json, text/plain).
+	ContentType *string `form:"content_type" json:"content_type" xml:"content_type"`
+	// Optional basic auth username to use when fetching the list.
+	FetchUsername *string `form:"fetch_username" json:"fetch_username" xml:"fetch_username"`
+	// Optional basic auth password to use when fetching the list.
+	FetchPassword *string `form:"fetch_password" json:"fetch_password" xml:"fetch_password"`
+}
+
 // DomainKeysExpireRequest is the form submitted as a POST to /api/v1/admin/domain_keys_expire to expire a domain's public keys.
 //
 // swagger:parameters domainKeysExpire
@@ -61,11 +61,49 @@ type AccountExportStats struct {
 	MutesCount int `json:"mutes_count"`
 }
 
+// AccountArchive models a downloadable archive of all of
+// an account's data, in Mastodon-compatible archive format.
+//
+// swagger:model accountArchive
+type AccountArchive struct {
+	// The ID of the archive.
+	//
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+
+	// When the archive was requested (ISO 8601 Datetime).
+	//
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+
+	// State of the archive, one of `processing`, `ready` or `failed`.
+	//
+	// example: ready
+	State string `json:"state"`
+
+	// Size of the archive zip file in bytes, once ready.
+	//
+	// example: 1048576
+	Size int64 `json:"size"`
+
+	// When the archive expires and will be removed (ISO 8601 Datetime),
+	// or null if the archive is still processing.
+	//
+	// example: 2021-08-06T09:20:25+00:00
+	ExpiresAt *string `json:"expires_at"`
+
+	// URL at which the archive zip file can be downloaded,
+	// or null if the archive is not ready for download.
+	//
+	// example: https://example.org/api/v1/exports/archives/01FBVD42CQ3ZEEVMW180SBX03B/download
+	URL *string `json:"url"`
+}
+
 // AttachmentRequest models media attachment creation parameters.
 //
 // swagger: ignore
 type ImportRequest struct {
-	// The CSV data to upload.
+	// The CSV data file (or zip archive) to upload.
 	Data *multipart.FileHeader `form:"data" binding:"required"`
 	// Type of entries contained in the data file.
 	//
@@ -74,6 +112,7 @@ type ImportRequest struct {
 	//	- `blocks` - accounts to block.
 	//	- `mutes` - accounts to mute.
 	//	- `bookmarks` - statuses to bookmark.
+	//	- `archive` - statuses from a Mastodon-compatible account archive.
 	Type string `form:"type" binding:"required"`
 	// Mode to use when creating entries from the data file:
 	//	- `merge` to merge entries in file with existing entries.
@@ -18,6 +18,8 @@
 package model
 
 // History represents daily usage history of a hashtag.
+//
+// swagger:model history
 type History struct {
 	// UNIX timestamp on midnight of the given day (string cast from integer).
 	Day string `json:"day"`
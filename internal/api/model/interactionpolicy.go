@@ -64,6 +64,10 @@ type InteractionPolicy struct {
 	CanReply PolicyRules `form:"can_reply" json:"can_reply"`
 	// Rules for who can reblog this status.
 	CanReblog PolicyRules `form:"can_reblog" json:"can_reblog"`
+	// Rules for who can quote this status.
+	CanQuote PolicyRules `form:"can_quote" json:"can_quote"`
+	// Rules for who can emoji react to this status.
+	CanReact PolicyRules `form:"can_react" json:"can_react"`
 }
 
 // Default interaction policies to use for new statuses by requesting account.
@@ -34,6 +34,7 @@ type Notification struct {
 	// 	poll = A poll you have voted in or created has ended. `status` will be set. `account` will be set.
 	// 	status = Someone you enabled notifications for has posted a status. `status` will be set. `account` will be set.
 	// 	admin.sign_up = Someone has signed up for a new account on the instance. `account` will be set.
+	// 	reaction = Someone emoji reacted to one of your statuses. `status` will be set. `account` will be set. `emoji` will be set.
 	Type string `json:"type"`
 	// The timestamp of the notification (ISO 8601 Datetime)
 	CreatedAt string `json:"created_at"`
@@ -44,6 +45,15 @@ type Notification struct {
 
 	// Status that was the object of the notification, e.g. in mentions, reblogs, favourites, or polls.
 	Status *Status `json:"status,omitempty"`
+
+	// The emoji used in a reaction notification. Either a unicode emoji, or a custom emoji's shortcode.
+	// example: blobcat_uwu
+	Emoji string `json:"emoji,omitempty"`
+
+	// Web link to the image of the custom emoji used in a reaction notification.
+	// Empty for unicode emojis.
+	// example: https://example.org/custom_emojis/original/blobcat_uwu.png
+	EmojiURL string `json:"emoji_url,omitempty"`
 }
 
 /*
@@ -17,22 +17,32 @@
 
 package model
 
-// PushSubscription represents a subscription to the push streaming server.
+// PushSubscription represents a subscription to a Web Push server.
+//
+// swagger:model webPushSubscription
 type PushSubscription struct {
 	// The id of the push subscription in the database.
 	ID string `json:"id"`
 	// Where push alerts will be sent to.
 	Endpoint string `json:"endpoint"`
-	// The streaming server's VAPID key.
+	// The instance's VAPID public key, used by the
+	// push server to authenticate deliveries from us.
 	ServerKey string `json:"server_key"`
 	// Which alerts should be delivered to the endpoint.
 	Alerts *PushSubscriptionAlerts `json:"alerts"`
+	// Which accounts should generate notifications.
+	// example: all
+	Policy string `json:"policy"`
 }
 
 // PushSubscriptionAlerts represents the specific alerts that this push subscription will give.
+//
+// swagger:model webPushSubscriptionAlerts
 type PushSubscriptionAlerts struct {
 	// Receive a push notification when someone has followed you?
 	Follow bool `json:"follow"`
+	// Receive a push notification when someone has requested to follow you?
+	FollowRequest bool `json:"follow_request"`
 	// Receive a push notification when a status you created has been favourited by someone else?
 	Favourite bool `json:"favourite"`
 	// Receive a push notification when someone else has mentioned you in a status?
@@ -41,4 +51,176 @@ type PushSubscriptionAlerts struct {
 	Reblog bool `json:"reblog"`
 	// Receive a push notification when a poll you voted in or created has ended?
 	Poll bool `json:"poll"`
+	// Receive a push notification when a subscribed account posts a status?
+	Status bool `json:"status"`
+	// Receive a push notification when a new user has signed up?
+	AdminSignup bool `json:"admin.sign_up"`
+	// Receive a push notification when someone has requested to favourite a status you created?
+	PendingFavourite bool `json:"pending.favourite"`
+	// Receive a push notification when someone has requested to reply to a status you created?
+	PendingReply bool `json:"pending.reply"`
+	// Receive a push notification when someone has requested to boost a status you created?
+	PendingReblog bool `json:"pending.reblog"`
+	// Receive a push notification when someone has requested to quote a status you created?
+	PendingQuote bool `json:"pending.quote"`
+	// Receive a push notification when a status you created has been emoji reacted to by someone else?
+	Reaction bool `json:"reaction"`
+}
+
+// PushSubscriptionCreateRequest models a request to
+// create a Web Push subscription for the current token.
+//
+// Mastodon clients may send this either as JSON with
+// nested objects, or as form data with bracketed keys,
+// so both sets of fields are present; use Normalize to
+// fold the form fields into the nested JSON fields.
+//
+// swagger:ignore
+type PushSubscriptionCreateRequest struct {
+	Subscription *PushSubscriptionRequestSubscription `form:"-" json:"subscription"`
+	Data         *PushSubscriptionRequestData         `form:"-" json:"data"`
+
+	SubscriptionEndpoint   *string `form:"subscription[endpoint]" json:"-"`
+	SubscriptionKeysAuth   *string `form:"subscription[keys][auth]" json:"-"`
+	SubscriptionKeysP256dh *string `form:"subscription[keys][p256dh]" json:"-"`
+
+	PushSubscriptionRequestDataForm
+}
+
+// Normalize folds form fields
+// into the nested JSON fields.
+func (r *PushSubscriptionCreateRequest) Normalize() {
+	if r.Subscription == nil {
+		r.Subscription = new(PushSubscriptionRequestSubscription)
+	}
+
+	if r.SubscriptionEndpoint != nil {
+		r.Subscription.Endpoint = *r.SubscriptionEndpoint
+	}
+
+	if r.SubscriptionKeysAuth != nil {
+		r.Subscription.Keys.Auth = *r.SubscriptionKeysAuth
+	}
+
+	if r.SubscriptionKeysP256dh != nil {
+		r.Subscription.Keys.P256dh = *r.SubscriptionKeysP256dh
+	}
+
+	r.Data = r.PushSubscriptionRequestDataForm.normalize(r.Data)
+}
+
+// PushSubscriptionUpdateRequest models a request to update
+// the alerts and policy of the current token's subscription.
+//
+// As with PushSubscriptionCreateRequest, use Normalize
+// to fold form fields into the nested JSON fields.
+//
+// swagger:ignore
+type PushSubscriptionUpdateRequest struct {
+	Data *PushSubscriptionRequestData `form:"-" json:"data"`
+
+	PushSubscriptionRequestDataForm
+}
+
+// Normalize folds form fields
+// into the nested JSON fields.
+func (r *PushSubscriptionUpdateRequest) Normalize() {
+	r.Data = r.PushSubscriptionRequestDataForm.normalize(r.Data)
+}
+
+// PushSubscriptionRequestSubscription contains the
+// endpoint and keys for a new Web Push subscription.
+//
+// swagger:ignore
+type PushSubscriptionRequestSubscription struct {
+	// Where push alerts will be sent to.
+	Endpoint string `json:"endpoint"`
+	// Keys used to encrypt push alerts.
+	Keys PushSubscriptionRequestKeys `json:"keys"`
+}
+
+// PushSubscriptionRequestKeys contains the user agent's
+// keys used to encrypt push alerts, as per RFC 8291.
+//
+// swagger:ignore
+type PushSubscriptionRequestKeys struct {
+	// Base64-encoded auth secret.
+	Auth string `json:"auth"`
+	// Base64-encoded P-256 ECDH public key.
+	P256dh string `json:"p256dh"`
+}
+
+// PushSubscriptionRequestData contains the alerts
+// and policy settings for a Web Push subscription.
+//
+// swagger:ignore
+type PushSubscriptionRequestData struct {
+	// Which alerts should be delivered to the endpoint.
+	Alerts *PushSubscriptionAlerts `json:"alerts"`
+	// Which accounts should generate notifications.
+	Policy *string `json:"policy"`
+}
+
+// PushSubscriptionRequestDataForm contains
+// the form data variant of alerts and policy.
+//
+// swagger:ignore
+type PushSubscriptionRequestDataForm struct {
+	DataAlertsFollow           *bool   `form:"data[alerts][follow]" json:"-"`
+	DataAlertsFollowRequest    *bool   `form:"data[alerts][follow_request]" json:"-"`
+	DataAlertsFavourite        *bool   `form:"data[alerts][favourite]" json:"-"`
+	DataAlertsMention          *bool   `form:"data[alerts][mention]" json:"-"`
+	DataAlertsReblog           *bool   `form:"data[alerts][reblog]" json:"-"`
+	DataAlertsPoll             *bool   `form:"data[alerts][poll]" json:"-"`
+	DataAlertsStatus           *bool   `form:"data[alerts][status]" json:"-"`
+	DataAlertsAdminSignup      *bool   `form:"data[alerts][admin.sign_up]" json:"-"`
+	DataAlertsPendingFavourite *bool   `form:"data[alerts][pending.favourite]" json:"-"`
+	DataAlertsPendingReply     *bool   `form:"data[alerts][pending.reply]" json:"-"`
+	DataAlertsPendingReblog    *bool   `form:"data[alerts][pending.reblog]" json:"-"`
+	DataAlertsPendingQuote     *bool   `form:"data[alerts][pending.quote]" json:"-"`
+	DataAlertsReaction         *bool   `form:"data[alerts][reaction]" json:"-"`
+	DataPolicy                 *string `form:"data[policy]" json:"-"`
+}
+
+// normalize folds form fields into the given
+// nested data, allocating it if necessary.
+func (f *PushSubscriptionRequestDataForm) normalize(data *PushSubscriptionRequestData) *PushSubscriptionRequestData {
+	if data == nil {
+		data = new(PushSubscriptionRequestData)
+	}
+
+	for _, alert := range []struct {
+		form *bool
+		dst  func(*PushSubscriptionAlerts) *bool
+	}{
+		{f.DataAlertsFollow, func(a *PushSubscriptionAlerts) *bool { return &a.Follow }},
+		{f.DataAlertsFollowRequest, func(a *PushSubscriptionAlerts) *bool { return &a.FollowRequest }},
+		{f.DataAlertsFavourite, func(a *PushSubscriptionAlerts) *bool { return &a.Favourite }},
+		{f.DataAlertsMention, func(a *PushSubscriptionAlerts) *bool { return &a.Mention }},
+		{f.DataAlertsReblog, func(a *PushSubscriptionAlerts) *bool { return &a.Reblog }},
+		{f.DataAlertsPoll, func(a *PushSubscriptionAlerts) *bool { return &a.Poll }},
+		{f.DataAlertsStatus, func(a *PushSubscriptionAlerts) *bool { return &a.Status }},
+		{f.DataAlertsAdminSignup, func(a *PushSubscriptionAlerts) *bool { return &a.AdminSignup }},
+		{f.DataAlertsPendingFavourite, func(a *PushSubscriptionAlerts) *bool { return &a.PendingFavourite }},
+		{f.DataAlertsPendingReply, func(a *PushSubscriptionAlerts) *bool { return &a.PendingReply }},
+		{f.DataAlertsPendingReblog, func(a *PushSubscriptionAlerts) *bool { return &a.PendingReblog }},
+		{f.DataAlertsPendingQuote, func(a *PushSubscriptionAlerts) *bool { return &a.PendingQuote }},
+		{f.DataAlertsReaction, func(a *PushSubscriptionAlerts) *bool { return &a.Reaction }},
+	} {
+		if alert.form == nil {
+			continue
+		}
+
+		if data.Alerts == nil {
+			data.Alerts = new(PushSubscriptionAlerts)
+		}
+
+		*alert.dst(data.Alerts) = *alert.form
+	}
+
+	if f.DataPolicy != nil {
+		data.Policy = f.DataPolicy
+	}
+
+	return data
 }
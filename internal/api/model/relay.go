@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Relay represents a fediverse relay that this instance is subscribed to.
+//
+// swagger:model relay
+type Relay struct {
+	// The ID of the relay.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// Inbox URL of the relay, to which the Follow and forwarded posts are delivered.
+	// example: https://relay.example.org/inbox
+	InboxURL string `json:"inbox_url"`
+	// Actor URL of the relay. Only set for LitePub-style relays.
+	// example: https://relay.example.org/relay
+	ActorURL string `json:"actor_url,omitempty"`
+	// Domain of the relay.
+	// example: relay.example.org
+	Domain string `json:"domain"`
+	// State of the subscription to the relay: pending, accepted, or rejected.
+	// example: accepted
+	State string `json:"state"`
+	// Public posts created on this instance are forwarded to the relay.
+	Forward bool `json:"forward"`
+	// ID of the account that added this relay.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	CreatedBy string `json:"created_by"`
+	// Time at which the relay was added (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+}
+
+// RelayCreateRequest is the form submitted as a POST
+// to /api/v1/admin/relays to subscribe to a relay.
+//
+// swagger:ignore
+type RelayCreateRequest struct {
+	// Inbox URL of a Mastodon-style relay.
+	InboxURL string `form:"inbox_url" json:"inbox_url" xml:"inbox_url"`
+	// Actor URL of a LitePub-style relay.
+	ActorURL string `form:"actor_url" json:"actor_url" xml:"actor_url"`
+	// Forward public posts created on this instance to the relay. Defaults to true.
+	Forward *bool `form:"forward" json:"forward" xml:"forward"`
+}
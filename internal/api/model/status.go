@@ -29,6 +29,10 @@ type Status struct {
 	// The date when this status was created (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	CreatedAt string `json:"created_at"`
+	// The date when this status was last edited (ISO 8601 Datetime).
+	// Omitted if the status has never been edited.
+	// example: 2021-07-30T09:20:25+00:00
+	EditedAt *string `json:"edited_at,omitempty"`
 	// ID of the status being replied to.
 	// example: 01FBVD42CQ3ZEEVMW180SBX03B
 	// nullable: true
@@ -64,6 +68,9 @@ type Status struct {
 	ReblogsCount int `json:"reblogs_count"`
 	// Number of favourites/likes this status has received, according to our instance.
 	FavouritesCount int `json:"favourites_count"`
+	// Emoji reactions to this status, according to our instance, grouped by emoji.
+	// Omitted if this status has no reactions.
+	EmojiReactions []StatusReaction `json:"emoji_reactions,omitempty"`
 	// This status has been favourited by the account viewing it.
 	Favourited bool `json:"favourited"`
 	// This status has been boosted/reblogged by the account viewing it.
@@ -80,6 +87,9 @@ type Status struct {
 	// The status that this status reblogs/boosts.
 	// nullable: true
 	Reblog *StatusReblogged `json:"reblog"`
+	// The status that this status quotes, and the state of the quote.
+	// Omitted if this status does not quote another status.
+	Quote *StatusQuote `json:"quote,omitempty"`
 	// The application used to post this status, if visible.
 	Application *Application `json:"application,omitempty"`
 	// The account that authored this status.
@@ -189,6 +199,32 @@ type StatusReblogged struct {
 	*Status
 }
 
+// StatusQuote represents the quote of one status by another.
+//
+// swagger:model statusQuote
+type StatusQuote struct {
+	// State of the quote, from the perspective of the account viewing it.
+	//
+	//	`pending` - The quote is awaiting approval by the author of the quoted status.
+	//	`accepted` - The quote was approved, or did not require approval.
+	//	`deleted` - The quoted status has been deleted, or is not known to this instance (yet).
+	//	`unauthorized` - The quoted status is not visible to the account viewing the quote.
+	// example: accepted
+	State string `json:"state"`
+	// The quoted status. Only set if state is `accepted` and
+	// the quote is not itself nested inside another quote.
+	// nullable: true
+	QuotedStatus *Status `json:"quoted_status"`
+}
+
+// Possible states of a StatusQuote.
+const (
+	StatusQuoteStatePending      = "pending"
+	StatusQuoteStateAccepted     = "accepted"
+	StatusQuoteStateDeleted      = "deleted"
+	StatusQuoteStateUnauthorized = "unauthorized"
+)
+
 // StatusCreateRequest models status creation parameters.
 //
 // swagger:ignore
@@ -204,6 +240,8 @@ type StatusCreateRequest struct {
 	Poll *PollRequest `form:"poll" json:"poll"`
 	// ID of the status being replied to, if status is a reply.
 	InReplyToID string `form:"in_reply_to_id" json:"in_reply_to_id"`
+	// ID of the status being quoted, if status is a quote.
+	QuotedStatusID string `form:"quoted_status_id" json:"quoted_status_id"`
 	// Status and attached media should be marked as sensitive.
 	Sensitive bool `form:"sensitive" json:"sensitive"`
 	// Text to be shown as a warning or subject before the actual content.
@@ -227,6 +265,53 @@ type StatusCreateRequest struct {
 	InteractionPolicy *InteractionPolicy `form:"-" json:"interaction_policy"`
 }
 
+// StatusEditRequest models status edit parameters.
+//
+// swagger:ignore
+type StatusEditRequest struct {
+	// Text content of the status.
+	// If media_ids is provided, this becomes optional.
+	// Attaching a poll is optional while status is provided.
+	Status string `form:"status" json:"status"`
+	// Text to be shown as a warning or subject before the actual content.
+	// Statuses are generally collapsed behind this field.
+	SpoilerText string `form:"spoiler_text" json:"spoiler_text"`
+	// Status and attached media should be marked as sensitive.
+	Sensitive bool `form:"sensitive" json:"sensitive"`
+	// ISO 639 language code for this status.
+	Language string `form:"language" json:"language"`
+	// Content type to use when parsing this status.
+	ContentType StatusContentType `form:"content_type" json:"content_type"`
+	// Array of Attachment ids to be attached as media.
+	// If provided, status becomes optional, and poll cannot be used.
+	MediaIDs []string `form:"media_ids[]" json:"media_ids"`
+	// Array of Attachment attributes to be updated in attached media.
+	MediaAttributes []AttachmentAttributesRequest `form:"-" json:"media_attributes"`
+	// Poll to include with this status.
+	Poll *PollRequest `form:"poll" json:"poll"`
+}
+
+// Separate form for parsing media
+// attributes on status edit requests.
+//
+// swagger:ignore
+type StatusEditMediaAttributesForm struct {
+	// Array of Attachment attributes to be updated in attached media.
+	MediaAttributes []AttachmentAttributesRequest `form:"media_attributes" json:"-"`
+}
+
+// AttachmentAttributesRequest models an edit request for attachment attributes.
+//
+// swagger:ignore
+type AttachmentAttributesRequest struct {
+	// ID of the attachment to update.
+	ID string `form:"id" json:"id"`
+	// Image or media description to use as alt-text on the attachment.
+	Description string `form:"description" json:"description"`
+	// Focus of the media file, in the form 'x,y'. Both values should be between -1 and 1.
+	Focus string `form:"focus" json:"focus"`
+}
+
 // Separate form for parsing interaction
 // policy on status create requests.
 //
@@ -0,0 +1,53 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Suggestion represents an account suggested
+// to the requester as one worth following.
+//
+// swagger:model suggestion
+type Suggestion struct {
+	// The reason this account is being suggested.
+	// Deprecated in favor of sources.
+	// enum:
+	//	- staff
+	//	- past_interactions
+	//	- global
+	// example: staff
+	Source string `json:"source"`
+	// All reasons this account is being suggested,
+	// most significant first.
+	//
+	// - featured: featured by an admin of this instance.
+	// - most_interactions: the requester often interacts with statuses by this account.
+	// - friends_of_friends: followed by accounts that the requester follows.
+	// - most_followed: one of the most followed accounts on this instance.
+	// example: ["featured","friends_of_friends"]
+	Sources []string `json:"sources"`
+	// The suggested account.
+	Account *Account `json:"account"`
+}
+
+// FeaturedSuggestionCreateRequest is the form submitted as
+// a POST to /api/v1/admin/suggestions to feature an account.
+//
+// swagger:ignore
+type FeaturedSuggestionCreateRequest struct {
+	// ID of the local account to feature.
+	AccountID string `form:"account_id" json:"account_id"`
+}
@@ -0,0 +1,67 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// TrendsLink represents a link which
+// is being shared a lot on this instance.
+//
+// swagger:model trendsLink
+type TrendsLink struct {
+	Card
+	// Usage history of this link, most recent day first.
+	History []History `json:"history"`
+}
+
+// AdminTrendsTag represents a trending tag, as shown to admins.
+//
+// swagger:model adminTrendsTag
+type AdminTrendsTag struct {
+	Tag
+	// The ID of the tag in the database.
+	// example: 01F8MHA1A2NF9MJ3WCCQ3K8BSZ
+	ID string `json:"id"`
+	// Whether this tag has been approved to be shown as trending.
+	Trendable bool `json:"trendable"`
+	// Whether this tag may be used in statuses.
+	Usable bool `json:"usable"`
+	// Whether this tag has not yet been reviewed by an admin.
+	RequiresReview bool `json:"requires_review"`
+}
+
+// AdminTrendsStatus represents a trending status, as shown to admins.
+//
+// swagger:model adminTrendsStatus
+type AdminTrendsStatus struct {
+	*Status
+	// Whether this status has not yet been reviewed by an admin.
+	RequiresReview bool `json:"requires_review"`
+}
+
+// AdminTrendsLink represents a trending link, as shown to admins.
+//
+// swagger:model adminTrendsLink
+type AdminTrendsLink struct {
+	TrendsLink
+	// The ID of the trend review entry for this link.
+	// example: 01JAQ7X4M2D8W5S3KCV0FRTN6B
+	ID string `json:"id"`
+	// Whether this link has been approved to be shown as trending.
+	Trendable bool `json:"trendable"`
+	// Whether this link has not yet been reviewed by an admin.
+	RequiresReview bool `json:"requires_review"`
+}
@@ -60,6 +60,9 @@ type User struct {
 	// Time when the last "please reset your password" email was sent, if at all. (ISO 8601 Datetime)
 	// example: 2021-07-30T09:20:25+00:00
 	ResetPasswordSentAt string `json:"reset_password_sent_at,omitempty"`
+	// Time at which the user enabled two factor authentication, if at all. (ISO 8601 Datetime)
+	// example: 2021-07-30T09:20:25+00:00
+	TwoFactorEnabledAt string `json:"two_factor_enabled_at,omitempty"`
 }
 
 // PasswordChangeRequest models user password change parameters.
@@ -95,3 +98,24 @@ type EmailChangeRequest struct {
 	// required: true
 	NewEmail string `form:"new_email" json:"new_email" xml:"new_email" validation:"required"`
 }
+
+// TwoFactorEnableRequest models a request
+// to enable two factor authentication.
+//
+// swagger:ignore
+type TwoFactorEnableRequest struct {
+	// User's current password, for verification.
+	Password string `form:"password" json:"password" xml:"password"`
+	// Current TOTP code from the
+	// user's authenticator app.
+	Code string `form:"code" json:"code" xml:"code"`
+}
+
+// TwoFactorDisableRequest models a request
+// to disable two factor authentication.
+//
+// swagger:ignore
+type TwoFactorDisableRequest struct {
+	// User's current password, for verification.
+	Password string `form:"password" json:"password" xml:"password"`
+}
@@ -0,0 +1,74 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// TokenAuth is a convenience function for extracting
+// an *oauth.Auth from the given gin context, and then
+// checking that the authed token has been granted
+// *all* of the given scopes.
+//
+// Returns 401 Unauthorized if any of the required
+// token, app, user, or account are missing, and 403
+// Forbidden if the token is missing a wanted scope.
+//
+// If no token is present (and one isn't required),
+// then scopes aren't checked, since there's nothing
+// to check them against: this is the case for
+// endpoints that can be accessed without auth.
+func TokenAuth(
+	c *gin.Context,
+	requireToken bool,
+	requireApp bool,
+	requireUser bool,
+	requireAccount bool,
+	scopes ...Scope,
+) (*oauth.Auth, gtserror.WithCode) {
+	authed, err := oauth.Authed(c,
+		requireToken,
+		requireApp,
+		requireUser,
+		requireAccount,
+	)
+	if err != nil {
+		return nil, gtserror.NewErrorUnauthorized(err, err.Error())
+	}
+
+	if authed.Token == nil {
+		// No token to check.
+		return authed, nil
+	}
+
+	granted := ParseScopes(authed.Token.GetScope())
+	for _, wanted := range scopes {
+		if !granted.Permits(wanted) {
+			text := "token is missing scope " + string(wanted) +
+				", which is required to perform this action"
+			return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+		}
+	}
+
+	return authed, nil
+}
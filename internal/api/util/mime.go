@@ -36,6 +36,8 @@ const (
 	TextHTML          = `text/html`
 	TextCSS           = `text/css`
 	TextCSV           = `text/csv`
+	TextPlain         = `text/plain`
+	ImagePNG          = `image/png`
 )
 
 // JSONContentType returns whether is application/json(;charset=utf-8)? content-type.
@@ -94,6 +94,18 @@ var CSVHeaders = []string{
 	TextCSV,
 }
 
+// TextPlainHeaders just contains
+// the text/plain MIME type.
+var TextPlainHeaders = []string{
+	TextPlain,
+}
+
+// PNGHeaders just contains
+// the image/png MIME type.
+var PNGHeaders = []string{
+	ImagePNG,
+}
+
 // NegotiateAccept takes the *gin.Context from an incoming request, and a
 // slice of Offers, and performs content negotiation for the given request
 // with the given content-type offers. It will return a string representation
@@ -37,6 +37,7 @@ const (
 	IDKey              = "id"
 	LimitKey           = "limit"
 	LocalKey           = "local"
+	OffsetKey          = "offset"
 	MaxIDKey           = "max_id"
 	SinceIDKey         = "since_id"
 	MinIDKey           = "min_id"
@@ -69,8 +70,13 @@ const (
 
 	/* Domain permission keys */
 
-	DomainPermissionExportKey = "export"
-	DomainPermissionImportKey = "import"
+	DomainPermissionExportKey         = "export"
+	DomainPermissionImportKey         = "import"
+	DomainPermissionPermTypeKey       = "permission_type"
+	DomainPermissionSubscriptionIDKey = "subscription_id"
+	DomainPermissionDomainKey         = "domain"
+	DomainPermissionOverwriteKey      = "overwrite"
+	DomainPermissionRemoveChildrenKey = "remove_children"
 
 	/* Admin query keys */
 
@@ -98,6 +104,21 @@ const (
 	InteractionFavouritesKey = "favourites"
 	InteractionRepliesKey    = "replies"
 	InteractionReblogsKey    = "reblogs"
+	InteractionQuotesKey     = "quotes"
+
+	/* Announcement keys */
+
+	AnnouncementWithDismissedKey = "with_dismissed"
+	AnnouncementReactionNameKey  = "name"
+
+	/* Directory keys */
+
+	DirectoryOrderKey = "order"
+
+	/* Directory order values */
+
+	DirectoryOrderActive = "active"
+	DirectoryOrderNew    = "new"
 )
 
 /*
@@ -141,6 +162,10 @@ func ParseLocal(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, LocalKey)
 }
 
+func ParseOffset(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, OffsetKey)
+}
+
 func ParseResolved(value string, defaultValue *bool) (*bool, gtserror.WithCode) {
 	return parseBoolPtr(value, defaultValue, ResolvedKey)
 }
@@ -169,6 +194,14 @@ func ParseDomainPermissionImport(value string, defaultValue bool) (bool, gtserro
 	return parseBool(value, defaultValue, DomainPermissionImportKey)
 }
 
+func ParseDomainPermissionOverwrite(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, DomainPermissionOverwriteKey)
+}
+
+func ParseDomainPermissionRemoveChildren(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, DomainPermissionRemoveChildrenKey)
+}
+
 func ParseOnlyOtherAccounts(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, OnlyOtherAccountsKey)
 }
@@ -213,6 +246,33 @@ func ParseInteractionReblogs(value string, defaultValue bool) (bool, gtserror.Wi
 	return parseBool(value, defaultValue, InteractionReblogsKey)
 }
 
+func ParseInteractionQuotes(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, InteractionQuotesKey)
+}
+
+func ParseAnnouncementWithDismissed(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, AnnouncementWithDismissedKey)
+}
+
+func ParseDirectoryOrder(value string, defaultValue string) (string, gtserror.WithCode) {
+	key := DirectoryOrderKey
+
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	switch value {
+	case DirectoryOrderActive, DirectoryOrderNew:
+		return value, nil
+	default:
+		err := fmt.Errorf(
+			"error parsing key %s with value %s: must be one of [%s, %s]",
+			key, value, DirectoryOrderActive, DirectoryOrderNew,
+		)
+		return "", gtserror.NewErrorBadRequest(err, err.Error())
+	}
+}
+
 /*
 	Parse functions for *REQUIRED* parameters.
 */
@@ -247,6 +307,16 @@ func ParseID(value string) (string, gtserror.WithCode) {
 	return value, nil
 }
 
+func ParseAnnouncementReactionName(value string) (string, gtserror.WithCode) {
+	key := AnnouncementReactionNameKey
+
+	if value == "" {
+		return "", requiredError(key)
+	}
+
+	return value, nil
+}
+
 func ParseSearchLookup(value string) (string, gtserror.WithCode) {
 	key := SearchLookupKey
 
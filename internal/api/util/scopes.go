@@ -0,0 +1,168 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import "strings"
+
+// Scope represents an OAuth scope, either as granted
+// to a token, or as required to access an endpoint.
+//
+// Scopes are hierarchical, as per Mastodon: a token
+// with eg., scope "read" is permitted to access an
+// endpoint requiring "read:statuses", and a token
+// with scope "admin:read" may access an endpoint
+// requiring "admin:read:accounts". See:
+// https://docs.joinmastodon.org/api/oauth-scopes/
+type Scope string
+
+const (
+	/* Top-level scopes */
+
+	ScopeRead       Scope = "read"
+	ScopeWrite      Scope = "write"
+	ScopeFollow     Scope = "follow" // Deprecated by Mastodon, but still used by some clients.
+	ScopePush       Scope = "push"
+	ScopeUser       Scope = "user"  // Legacy GtS scope, formerly requested by the settings panel; equivalent to read + write.
+	ScopeAdmin      Scope = "admin" // Not in Mastodon, but GtS has always used this for admin:read + admin:write.
+	ScopeAdminRead  Scope = "admin:read"
+	ScopeAdminWrite Scope = "admin:write"
+
+	/* Granular read scopes */
+
+	ScopeReadAccounts      Scope = "read:accounts"
+	ScopeReadApplications  Scope = "read:applications"
+	ScopeReadBlocks        Scope = "read:blocks"
+	ScopeReadBookmarks     Scope = "read:bookmarks"
+	ScopeReadCustomEmojis  Scope = "read:custom_emojis"
+	ScopeReadFavourites    Scope = "read:favourites"
+	ScopeReadFilters       Scope = "read:filters"
+	ScopeReadFollows       Scope = "read:follows"
+	ScopeReadLists         Scope = "read:lists"
+	ScopeReadMedia         Scope = "read:media"
+	ScopeReadMutes         Scope = "read:mutes"
+	ScopeReadNotifications Scope = "read:notifications"
+	ScopeReadReports       Scope = "read:reports"
+	ScopeReadSearch        Scope = "read:search"
+	ScopeReadStatuses      Scope = "read:statuses"
+	ScopeReadStreaming     Scope = "read:streaming"
+	ScopeReadUser          Scope = "read:user"
+
+	/* Granular write scopes */
+
+	ScopeWriteAccounts      Scope = "write:accounts"
+	ScopeWriteApplications  Scope = "write:applications"
+	ScopeWriteBlocks        Scope = "write:blocks"
+	ScopeWriteBookmarks     Scope = "write:bookmarks"
+	ScopeWriteConversations Scope = "write:conversations"
+	ScopeWriteFavourites    Scope = "write:favourites"
+	ScopeWriteFilters       Scope = "write:filters"
+	ScopeWriteFollows       Scope = "write:follows"
+	ScopeWriteLists         Scope = "write:lists"
+	ScopeWriteMedia         Scope = "write:media"
+	ScopeWriteMutes         Scope = "write:mutes"
+	ScopeWriteNotifications Scope = "write:notifications"
+	ScopeWriteReports       Scope = "write:reports"
+	ScopeWriteStatuses      Scope = "write:statuses"
+	ScopeWriteUser          Scope = "write:user"
+
+	/* Granular admin scopes */
+
+	ScopeAdminReadAccounts      Scope = "admin:read:accounts"
+	ScopeAdminReadReports       Scope = "admin:read:reports"
+	ScopeAdminReadDomainAllows  Scope = "admin:read:domain_allows"
+	ScopeAdminReadDomainBlocks  Scope = "admin:read:domain_blocks"
+	ScopeAdminWriteAccounts     Scope = "admin:write:accounts"
+	ScopeAdminWriteReports      Scope = "admin:write:reports"
+	ScopeAdminWriteDomainAllows Scope = "admin:write:domain_allows"
+	ScopeAdminWriteDomainBlocks Scope = "admin:write:domain_blocks"
+)
+
+// followScopes are the granular scopes implied
+// by the deprecated top-level "follow" scope.
+var followScopes = []Scope{
+	ScopeReadBlocks,
+	ScopeWriteBlocks,
+	ScopeReadFollows,
+	ScopeWriteFollows,
+	ScopeReadMutes,
+	ScopeWriteMutes,
+}
+
+// Permits returns true if the
+// granted scope permits
+// access to the wanted scope.
+func (has Scope) Permits(wanted Scope) bool {
+	if has == wanted {
+		// Exact match.
+		return true
+	}
+
+	if has == ScopeUser {
+		// Special case, legacy
+		// "user" means read + write.
+		return ScopeRead.Permits(wanted) ||
+			ScopeWrite.Permits(wanted)
+	}
+
+	if has == ScopeFollow {
+		// Special case, "follow"
+		// doesn't nest like others.
+		for _, scope := range followScopes {
+			if scope == wanted {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Check if we have a parent of the wanted scope,
+	// eg., we have "read", and want "read:statuses".
+	return strings.HasPrefix(string(wanted), string(has)+":")
+}
+
+// Scopes is a set of scopes
+// granted to a single token.
+type Scopes []Scope
+
+// ParseScopes parses the given space-separated
+// string of scopes, as stored on a token. An
+// empty string is treated as "read", which is
+// the default scope for tokens, as per Mastodon.
+func ParseScopes(str string) Scopes {
+	fields := strings.Fields(str)
+	if len(fields) == 0 {
+		return Scopes{ScopeRead}
+	}
+
+	scopes := make(Scopes, len(fields))
+	for i, field := range fields {
+		scopes[i] = Scope(field)
+	}
+	return scopes
+}
+
+// Permits returns true if any of the
+// scopes permits the wanted scope.
+func (scopes Scopes) Permits(wanted Scope) bool {
+	for _, has := range scopes {
+		if has.Permits(wanted) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import "testing"
+
+func TestScopesPermits(t *testing.T) {
+	tests := []struct {
+		granted string
+		wanted  Scope
+		permits bool
+	}{
+		{granted: "read", wanted: ScopeRead, permits: true},
+		{granted: "read", wanted: ScopeReadStatuses, permits: true},
+		{granted: "read", wanted: ScopeWriteStatuses, permits: false},
+		{granted: "", wanted: ScopeReadAccounts, permits: true},
+		{granted: "", wanted: ScopeWriteAccounts, permits: false},
+		{granted: "read:statuses", wanted: ScopeRead, permits: false},
+		{granted: "read:statuses", wanted: ScopeReadStatuses, permits: true},
+		{granted: "read:statuses", wanted: ScopeReadAccounts, permits: false},
+		{granted: "read write", wanted: ScopeWriteMedia, permits: true},
+		{granted: "read write", wanted: ScopePush, permits: false},
+		{granted: "read write", wanted: ScopeAdminRead, permits: false},
+		{granted: "readwrite", wanted: ScopeRead, permits: false},
+		{granted: "follow", wanted: ScopeWriteFollows, permits: true},
+		{granted: "follow", wanted: ScopeReadBlocks, permits: true},
+		{granted: "follow", wanted: ScopeWriteStatuses, permits: false},
+		{granted: "push", wanted: ScopePush, permits: true},
+		{granted: "user", wanted: ScopeReadStatuses, permits: true},
+		{granted: "user", wanted: ScopeWriteAccounts, permits: true},
+		{granted: "user", wanted: ScopePush, permits: false},
+		{granted: "user admin", wanted: ScopeAdminWriteReports, permits: true},
+		{granted: "admin", wanted: ScopeAdminRead, permits: true},
+		{granted: "admin", wanted: ScopeAdminWriteAccounts, permits: true},
+		{granted: "admin", wanted: ScopeReadAccounts, permits: false},
+		{granted: "admin:read", wanted: ScopeAdminReadReports, permits: true},
+		{granted: "admin:read", wanted: ScopeAdminWriteReports, permits: false},
+		{granted: "admin:read:reports", wanted: ScopeAdminReadAccounts, permits: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.granted+" "+string(tt.wanted), func(t *testing.T) {
+			t.Parallel()
+
+			permits := ParseScopes(tt.granted).Permits(tt.wanted)
+			if permits != tt.permits {
+				t.Errorf("expected %q permits %s to be %t, got %t", tt.granted, tt.wanted, tt.permits, permits)
+			}
+		})
+	}
+}
@@ -70,6 +70,7 @@ func (c *Caches) Init() {
 	c.initBlockIDs()
 	c.initBoostOfIDs()
 	c.initClient()
+	c.initContentLimit()
 	c.initConversation()
 	c.initConversationLastStatusIDs()
 	c.initDomainAllow()
@@ -98,13 +99,18 @@ func (c *Caches) Init() {
 	c.initPoll()
 	c.initPollVote()
 	c.initPollVoteIDs()
+	c.initRelay()
 	c.initReport()
+	c.initScheduledStatus()
 	c.initSinBinStatus()
 	c.initStatus()
 	c.initStatusBookmark()
 	c.initStatusBookmarkIDs()
+	c.initStatusEdit()
 	c.initStatusFave()
 	c.initStatusFaveIDs()
+	c.initStatusReaction()
+	c.initStatusReactionIDs()
 	c.initTag()
 	c.initThreadMute()
 	c.initToken()
@@ -113,6 +119,7 @@ func (c *Caches) Init() {
 	c.initUserMute()
 	c.initUserMuteIDs()
 	c.initWebfinger()
+	c.initWebPushSubscription()
 	c.initVisibility()
 	c.initStatusesFilterableFields()
 }
@@ -156,6 +163,7 @@ func (c *Caches) Sweep(threshold float64) {
 	c.DB.BlockIDs.Trim(threshold)
 	c.DB.BoostOfIDs.Trim(threshold)
 	c.DB.Client.Trim(threshold)
+	c.DB.ContentLimit.Trim(threshold)
 	c.DB.Conversation.Trim(threshold)
 	c.DB.ConversationLastStatusIDs.Trim(threshold)
 	c.DB.Emoji.Trim(threshold)
@@ -183,12 +191,16 @@ func (c *Caches) Sweep(threshold float64) {
 	c.DB.PollVote.Trim(threshold)
 	c.DB.PollVoteIDs.Trim(threshold)
 	c.DB.Report.Trim(threshold)
+	c.DB.ScheduledStatus.Trim(threshold)
 	c.DB.SinBinStatus.Trim(threshold)
 	c.DB.Status.Trim(threshold)
 	c.DB.StatusBookmark.Trim(threshold)
 	c.DB.StatusBookmarkIDs.Trim(threshold)
+	c.DB.StatusEdit.Trim(threshold)
 	c.DB.StatusFave.Trim(threshold)
 	c.DB.StatusFaveIDs.Trim(threshold)
+	c.DB.StatusReaction.Trim(threshold)
+	c.DB.StatusReactionIDs.Trim(threshold)
 	c.DB.Tag.Trim(threshold)
 	c.DB.ThreadMute.Trim(threshold)
 	c.DB.Token.Trim(threshold)
@@ -196,6 +208,7 @@ func (c *Caches) Sweep(threshold float64) {
 	c.DB.User.Trim(threshold)
 	c.DB.UserMute.Trim(threshold)
 	c.DB.UserMuteIDs.Trim(threshold)
+	c.DB.WebPushSubscription.Trim(threshold)
 	c.Visibility.Trim(threshold)
 }
 
@@ -55,6 +55,9 @@ type DBCaches struct {
 	// Client provides access to the gtsmodel Client database cache.
 	Client StructCache[*gtsmodel.Client]
 
+	// ContentLimit provides access to the gtsmodel ContentLimit database cache.
+	ContentLimit StructCache[*gtsmodel.ContentLimit]
+
 	// Conversation provides access to the gtsmodel Conversation database cache.
 	Conversation StructCache[*gtsmodel.Conversation]
 
@@ -205,9 +208,15 @@ type DBCaches struct {
 	// PollVoteIDs provides access to the poll vote IDs list database cache.
 	PollVoteIDs SliceCache[string]
 
+	// RelayActorURIs provides access to the accepted relay actor URIs database cache.
+	RelayActorURIs SliceCache[string]
+
 	// Report provides access to the gtsmodel Report database cache.
 	Report StructCache[*gtsmodel.Report]
 
+	// ScheduledStatus provides access to the gtsmodel ScheduledStatus database cache.
+	ScheduledStatus StructCache[*gtsmodel.ScheduledStatus]
+
 	// SinBinStatus provides access to the gtsmodel SinBinStatus database cache.
 	SinBinStatus StructCache[*gtsmodel.SinBinStatus]
 
@@ -220,12 +229,21 @@ type DBCaches struct {
 	// StatusBookmarkIDs provides access to the status bookmark IDs list database cache.
 	StatusBookmarkIDs SliceCache[string]
 
+	// StatusEdit provides access to the gtsmodel StatusEdit database cache.
+	StatusEdit StructCache[*gtsmodel.StatusEdit]
+
 	// StatusFave provides access to the gtsmodel StatusFave database cache.
 	StatusFave StructCache[*gtsmodel.StatusFave]
 
 	// StatusFaveIDs provides access to the status fave IDs list database cache.
 	StatusFaveIDs SliceCache[string]
 
+	// StatusReaction provides access to the gtsmodel StatusReaction database cache.
+	StatusReaction StructCache[*gtsmodel.StatusReaction]
+
+	// StatusReactionIDs provides access to the status reaction IDs list database cache.
+	StatusReactionIDs SliceCache[string]
+
 	// Tag provides access to the gtsmodel Tag database cache.
 	Tag StructCache[*gtsmodel.Tag]
 
@@ -246,6 +264,9 @@ type DBCaches struct {
 
 	// UserMuteIDs provides access to the user mute IDs database cache.
 	UserMuteIDs SliceCache[string]
+
+	// WebPushSubscription provides access to the gtsmodel WebPushSubscription database cache.
+	WebPushSubscription StructCache[*gtsmodel.WebPushSubscription]
 }
 
 // NOTE:
@@ -494,6 +515,40 @@ func (c *Caches) initClient() {
 	})
 }
 
+func (c *Caches) initContentLimit() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofContentLimit(), // model in-mem size.
+		config.GetCacheContentLimitMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(l1 *gtsmodel.ContentLimit) *gtsmodel.ContentLimit {
+		l2 := new(gtsmodel.ContentLimit)
+		*l2 = *l1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/contentlimit.go.
+		l2.Account = nil
+		l2.CreatedByAccount = nil
+
+		return l2
+	}
+
+	c.DB.ContentLimit.Init(structr.CacheConfig[*gtsmodel.ContentLimit]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "AccountID"},
+			{Fields: "Domain"},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
 func (c *Caches) initConversation() {
 	cap := calculateResultCacheMax(
 		sizeofConversation(), // model in-mem size.
@@ -1187,6 +1242,12 @@ func (c *Caches) initPollVoteIDs() {
 	c.DB.PollVoteIDs.Init(0, cap)
 }
 
+func (c *Caches) initRelay() {
+	// There's only ever one
+	// list of actor URIs cached.
+	c.DB.RelayActorURIs.Init(0, 1)
+}
+
 func (c *Caches) initReport() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1222,6 +1283,39 @@ func (c *Caches) initReport() {
 	})
 }
 
+func (c *Caches) initScheduledStatus() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofScheduledStatus(), // model in-mem size.
+		config.GetCacheScheduledStatusMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(s1 *gtsmodel.ScheduledStatus) *gtsmodel.ScheduledStatus {
+		s2 := new(gtsmodel.ScheduledStatus)
+		*s2 = *s1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/scheduledstatus.go.
+		s2.Account = nil
+		s2.MediaAttachments = nil
+		s2.Application = nil
+
+		return s2
+	}
+
+	c.DB.ScheduledStatus.Init(structr.CacheConfig[*gtsmodel.ScheduledStatus]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
 func (c *Caches) initSinBinStatus() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1269,11 +1363,14 @@ func (c *Caches) initStatus() {
 		s2.InReplyToAccount = nil
 		s2.BoostOf = nil
 		s2.BoostOfAccount = nil
+		s2.Quote = nil
+		s2.QuoteAccount = nil
 		s2.Poll = nil
 		s2.Attachments = nil
 		s2.Tags = nil
 		s2.Mentions = nil
 		s2.Emojis = nil
+		s2.Edits = nil
 		s2.CreatedWithApplication = nil
 
 		return s2
@@ -1343,6 +1440,38 @@ func (c *Caches) initStatusBookmarkIDs() {
 	c.DB.StatusBookmarkIDs.Init(0, cap)
 }
 
+func (c *Caches) initStatusEdit() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofStatusEdit(), // model in-mem size.
+		config.GetCacheStatusEditMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(e1 *gtsmodel.StatusEdit) *gtsmodel.StatusEdit {
+		e2 := new(gtsmodel.StatusEdit)
+		*e2 = *e1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/statusedit.go.
+		e2.Attachments = nil
+
+		return e2
+	}
+
+	c.DB.StatusEdit.Init(structr.CacheConfig[*gtsmodel.StatusEdit]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "StatusID", Multiple: true},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
 func (c *Caches) initStatusFave() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1391,6 +1520,55 @@ func (c *Caches) initStatusFaveIDs() {
 	c.DB.StatusFaveIDs.Init(0, cap)
 }
 
+func (c *Caches) initStatusReaction() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofStatusReaction(), // model in-mem size.
+		config.GetCacheStatusReactionMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(r1 *gtsmodel.StatusReaction) *gtsmodel.StatusReaction {
+		r2 := new(gtsmodel.StatusReaction)
+		*r2 = *r1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/statusreaction.go.
+		r2.Account = nil
+		r2.TargetAccount = nil
+		r2.Status = nil
+		r2.Emoji = nil
+
+		return r2
+	}
+
+	c.DB.StatusReaction.Init(structr.CacheConfig[*gtsmodel.StatusReaction]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "URI"},
+			{Fields: "AccountID,StatusID,Name"},
+			{Fields: "StatusID", Multiple: true},
+		},
+		MaxSize:    cap,
+		IgnoreErr:  ignoreErrors,
+		Copy:       copyF,
+		Invalidate: c.OnInvalidateStatusReaction,
+	})
+}
+
+func (c *Caches) initStatusReactionIDs() {
+	// Calculate maximum cache size.
+	cap := calculateSliceCacheMax(
+		config.GetCacheStatusReactionIDsMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	c.DB.StatusReactionIDs.Init(0, cap)
+}
+
 func (c *Caches) initTag() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1579,3 +1757,30 @@ func (c *Caches) initUserMuteIDs() {
 
 	c.DB.UserMuteIDs.Init(0, cap)
 }
+
+func (c *Caches) initWebPushSubscription() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofWebPushSubscription(), // model in-mem size.
+		config.GetCacheWebPushSubscriptionMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(s1 *gtsmodel.WebPushSubscription) *gtsmodel.WebPushSubscription {
+		s2 := new(gtsmodel.WebPushSubscription)
+		*s2 = *s1
+		return s2
+	}
+
+	c.DB.WebPushSubscription.Init(structr.CacheConfig[*gtsmodel.WebPushSubscription]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "TokenID"},
+			{Fields: "AccountID", Multiple: true},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
@@ -181,6 +181,7 @@ func totalOfRatios() float64 {
 		config.GetCacheBlockIDsMemRatio() +
 		config.GetCacheBoostOfIDsMemRatio() +
 		config.GetCacheClientMemRatio() +
+		config.GetCacheContentLimitMemRatio() +
 		config.GetCacheEmojiMemRatio() +
 		config.GetCacheEmojiCategoryMemRatio() +
 		config.GetCacheFilterMemRatio() +
@@ -206,12 +207,16 @@ func totalOfRatios() float64 {
 		config.GetCachePollVoteMemRatio() +
 		config.GetCachePollVoteIDsMemRatio() +
 		config.GetCacheReportMemRatio() +
+		config.GetCacheScheduledStatusMemRatio() +
 		config.GetCacheSinBinStatusMemRatio() +
 		config.GetCacheStatusMemRatio() +
 		config.GetCacheStatusBookmarkMemRatio() +
 		config.GetCacheStatusBookmarkIDsMemRatio() +
+		config.GetCacheStatusEditMemRatio() +
 		config.GetCacheStatusFaveMemRatio() +
 		config.GetCacheStatusFaveIDsMemRatio() +
+		config.GetCacheStatusReactionMemRatio() +
+		config.GetCacheStatusReactionIDsMemRatio() +
 		config.GetCacheTagMemRatio() +
 		config.GetCacheThreadMuteMemRatio() +
 		config.GetCacheTokenMemRatio() +
@@ -220,6 +225,7 @@ func totalOfRatios() float64 {
 		config.GetCacheUserMuteMemRatio() +
 		config.GetCacheUserMuteIDsMemRatio() +
 		config.GetCacheWebfingerMemRatio() +
+		config.GetCacheWebPushSubscriptionMemRatio() +
 		config.GetCacheVisibilityMemRatio()
 }
 
@@ -294,15 +300,16 @@ func sizeofAccountStats() uintptr {
 
 func sizeofApplication() uintptr {
 	return uintptr(size.Of(&gtsmodel.Application{
-		ID:           exampleID,
-		CreatedAt:    exampleTime,
-		UpdatedAt:    exampleTime,
-		Name:         exampleUsername,
-		Website:      exampleURI,
-		RedirectURI:  exampleURI,
-		ClientID:     exampleID,
-		ClientSecret: exampleID,
-		Scopes:       exampleTextSmall,
+		ID:              exampleID,
+		CreatedAt:       exampleTime,
+		UpdatedAt:       exampleTime,
+		Name:            exampleUsername,
+		Website:         exampleURI,
+		RedirectURI:     exampleURI,
+		ClientID:        exampleID,
+		ClientSecret:    exampleID,
+		Scopes:          exampleTextSmall,
+		ManagedByUserID: exampleID,
 	}))
 }
 
@@ -328,6 +335,20 @@ func sizeofClient() uintptr {
 	}))
 }
 
+func sizeofContentLimit() uintptr {
+	return uintptr(size.Of(&gtsmodel.ContentLimit{
+		ID:                 exampleID,
+		CreatedAt:          exampleTime,
+		UpdatedAt:          exampleTime,
+		Domain:             "example.org",
+		ForceSensitive:     util.Ptr(true),
+		ContentWarning:     exampleTextSmall,
+		RejectMedia:        util.Ptr(true),
+		CreatedByAccountID: exampleID,
+		PrivateComment:     exampleTextSmall,
+	}))
+}
+
 func sizeofConversation() uintptr {
 	return uintptr(size.Of(&gtsmodel.Conversation{
 		ID:               exampleID,
@@ -499,6 +520,7 @@ func sizeofMedia() uintptr {
 		File: gtsmodel.File{
 			Path:        exampleURI,
 			ContentType: "image/jpeg",
+			Hash:        "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
 		},
 		Thumbnail: gtsmodel.Thumbnail{
 			Path:        exampleURI,
@@ -506,6 +528,13 @@ func sizeofMedia() uintptr {
 			URL:         exampleURI,
 			RemoteURL:   exampleURI,
 		},
+		ThumbnailVariants: []*gtsmodel.ThumbnailVariant{
+			{
+				Path:        exampleURI,
+				ContentType: "image/jpeg",
+				URL:         exampleURI,
+			},
+		},
 		Avatar: func() *bool { ok := false; return &ok }(),
 		Header: func() *bool { ok := false; return &ok }(),
 		Cached: func() *bool { ok := true; return &ok }(),
@@ -590,6 +619,25 @@ func sizeofReport() uintptr {
 	}))
 }
 
+func sizeofScheduledStatus() uintptr {
+	return uintptr(size.Of(&gtsmodel.ScheduledStatus{
+		ID:          exampleID,
+		CreatedAt:   exampleTime,
+		UpdatedAt:   exampleTime,
+		AccountID:   exampleID,
+		ScheduledAt: exampleTime,
+		Text:        exampleText,
+		MediaIDs:    []string{exampleID, exampleID, exampleID},
+		Sensitive:   func() *bool { ok := false; return &ok }(),
+		SpoilerText: exampleText,
+		Visibility:  gtsmodel.VisibilityPublic,
+		LocalOnly:   func() *bool { ok := false; return &ok }(),
+		InReplyToID: exampleID,
+		Language:    "en",
+		ContentType: "text/markdown",
+	}))
+}
+
 func sizeofSinBinStatus() uintptr {
 	return uintptr(size.Of(&gtsmodel.SinBinStatus{
 		ID:                  exampleID,
@@ -659,6 +707,22 @@ func sizeofStatusBookmark() uintptr {
 	}))
 }
 
+func sizeofStatusEdit() uintptr {
+	return uintptr(size.Of(&gtsmodel.StatusEdit{
+		ID:             exampleID,
+		CreatedAt:      exampleTime,
+		StatusID:       exampleID,
+		Content:        exampleText,
+		ContentWarning: exampleUsername, // similar length
+		Text:           exampleText,
+		Language:       "en",
+		Sensitive:      func() *bool { ok := false; return &ok }(),
+		AttachmentIDs:  []string{exampleID, exampleID, exampleID},
+		PollOptions:    []string{exampleTextSmall, exampleTextSmall, exampleTextSmall, exampleTextSmall},
+		PollVotes:      []int{69, 420, 1337, 1969},
+	}))
+}
+
 func sizeofStatusFave() uintptr {
 	return uintptr(size.Of(&gtsmodel.StatusFave{
 		ID:              exampleID,
@@ -670,6 +734,20 @@ func sizeofStatusFave() uintptr {
 	}))
 }
 
+func sizeofStatusReaction() uintptr {
+	return uintptr(size.Of(&gtsmodel.StatusReaction{
+		ID:              exampleID,
+		CreatedAt:       exampleTime,
+		UpdatedAt:       exampleTime,
+		AccountID:       exampleID,
+		TargetAccountID: exampleID,
+		StatusID:        exampleID,
+		Name:            exampleUsername,
+		EmojiID:         exampleID,
+		URI:             exampleURI,
+	}))
+}
+
 func sizeofTag() uintptr {
 	return uintptr(size.Of(&gtsmodel.Tag{
 		ID:        exampleID,
@@ -711,6 +789,7 @@ func sizeofToken() uintptr {
 		Refresh:             "", // TODO: clients don't really support this very well yet
 		RefreshCreateAt:     exampleTime,
 		RefreshExpiresAt:    exampleTime,
+		LastUsed:            exampleTime,
 	}))
 }
 
@@ -771,3 +850,18 @@ func sizeofUserMute() uintptr {
 		Notifications:   util.Ptr(false),
 	}))
 }
+
+func sizeofWebPushSubscription() uintptr {
+	return uintptr(size.Of(&gtsmodel.WebPushSubscription{
+		ID:                exampleID,
+		CreatedAt:         exampleTime,
+		UpdatedAt:         exampleTime,
+		AccountID:         exampleID,
+		TokenID:           exampleID,
+		Endpoint:          exampleURI,
+		Auth:              exampleTextSmall,
+		P256dh:            exampleText,
+		NotificationFlags: gtsmodel.WebPushSubscriptionNotificationFlags(0),
+		Policy:            gtsmodel.WebPushNotificationPolicyAll,
+	}))
+}
@@ -20,6 +20,8 @@ package cleaner
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -30,6 +32,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
@@ -88,6 +91,16 @@ func (m *Media) LogFixCacheStates(ctx context.Context) {
 	}
 }
 
+// LogDedupe performs Media.Dedupe(...), logging the start and outcome.
+func (m *Media) LogDedupe(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := m.Dedupe(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "deduplicated: %d", n)
+	}
+}
+
 // PruneOrphaned will delete orphaned files from storage (i.e. media missing a database entry).
 // Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
 func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
@@ -117,8 +130,21 @@ func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
 		return 0, gtserror.Newf("error walking storage: %w", err)
 	}
 
+	var (
+		total int
+		errs  gtserror.MultiError
+	)
+
 	// Delete all orphaned files from storage.
-	return m.removeFiles(ctx, files...)
+	for _, path := range files {
+		n, err := m.removeOrphaned(ctx, path)
+		if err != nil {
+			errs.Append(err)
+		}
+		total += n
+	}
+
+	return total, errs.Combine()
 }
 
 // PruneUnused will delete all unused media attachments from the database and storage driver.
@@ -170,6 +196,57 @@ func (m *Media) PruneUnused(ctx context.Context) (int, error) {
 	return total, nil
 }
 
+// Dedupe will change media attachments with identical file contents stored separately to all share
+// the stored file of the most recent of them, deleting the then unused duplicate files from storage.
+// Media stored without a file hash (i.e. before media were hashed on storing) will have their file
+// hash calculated and stored. Context will be checked for `gtscontext.DryRun()` in order to actually
+// perform the action, though calculated file hashes are stored regardless.
+func (m *Media) Dedupe(ctx context.Context) (int, error) {
+	var (
+		total int
+		page  paging.Page
+	)
+
+	// Set page select limit.
+	page.Limit = selectLimit
+
+	for {
+		// Fetch the next batch of media attachments to next maxID.
+		attachments, err := m.state.DB.GetAttachments(ctx, &page)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting attachments: %w", err)
+		}
+
+		// Get current max ID.
+		maxID := page.Max.Value
+
+		// If no attachments or the same group is returned, we reached the end.
+		if len(attachments) == 0 || maxID == attachments[len(attachments)-1].ID {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = attachments[len(attachments)-1].ID
+		page.Max = paging.MaxID(maxID)
+
+		for _, media := range attachments {
+			// Check / dedupe media attachment file.
+			deduped, err := m.dedupe(ctx, media)
+			if err != nil {
+				return total, err
+			}
+
+			if deduped {
+				// Update
+				// count.
+				total++
+			}
+		}
+	}
+
+	return total, nil
+}
+
 // UncacheRemote will uncache all remote media attachments older than given input time.
 // Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
 func (m *Media) UncacheRemote(ctx context.Context, olderThan time.Time) (int, error) {
@@ -264,6 +341,20 @@ func (m *Media) FixCacheStates(ctx context.Context) (int, error) {
 	return total, nil
 }
 
+// removeOrphaned removes the file at path from storage if it is orphaned,
+// checking again under lock, as media files may have been shared since.
+func (m *Media) removeOrphaned(ctx context.Context, path string) (int, error) {
+	unlock := m.state.StorageLocks.Lock(strings.TrimPrefix(path, "/"))
+	defer unlock()
+
+	orphaned, err := m.isOrphaned(ctx, path)
+	if err != nil || !orphaned {
+		return 0, err
+	}
+
+	return m.removeFiles(ctx, path)
+}
+
 func (m *Media) isOrphaned(ctx context.Context, path string) (bool, error) {
 	pathParts := regexes.FilePath.FindStringSubmatch(path)
 	if len(pathParts) != 6 {
@@ -276,8 +367,8 @@ func (m *Media) isOrphaned(ctx context.Context, path string) (bool, error) {
 		// 0th -> whole match
 		// 1st -> account ID
 		mediaType = pathParts[2]
-		// 3rd -> media sub-type (e.g. small, static)
-		mediaID = pathParts[4]
+		mediaSize = media.Size(pathParts[3])
+		mediaID   = pathParts[4]
 		// 5th -> file extension
 	)
 
@@ -287,6 +378,12 @@ func (m *Media) isOrphaned(ctx context.Context, path string) (bool, error) {
 
 	switch media.Type(mediaType) {
 	case media.TypeAttachment:
+		// Check whether path is for a thumbnail variant.
+		variant := (media.ThumbVariantWidth(mediaSize) != 0)
+
+		// Or for an original media file.
+		original := (mediaSize == media.SizeOriginal)
+
 		// Look for media in database stored by ID.
 		media, err := m.state.DB.GetAttachmentByID(
 			gtscontext.SetBarebones(ctx),
@@ -296,11 +393,39 @@ func (m *Media) isOrphaned(ctx context.Context, path string) (bool, error) {
 			return false, gtserror.Newf("error fetching media by id %s: %w", mediaID, err)
 		}
 
+		if media == nil && original {
+			// Media files may be shared with other media
+			// with identical contents, so check whether
+			// file is in use by any other cached media.
+			refs, err := m.state.DB.CountAttachmentsByFilePath(ctx,
+				strings.TrimPrefix(path, "/"),
+				"",
+			)
+			if err != nil {
+				return false, gtserror.Newf("error counting media with file %s: %w", path, err)
+			}
+
+			if refs > 0 {
+				l.Debug("file shared with other media")
+				return false, nil
+			}
+		}
+
 		if media == nil {
 			l.Debug("missing db entry for media")
 			return true, nil
 		}
 
+		// Thumbnail variants may be left over from previous
+		// processing of media, so check this one is in use.
+		if variant && !slices.Contains(
+			mediaFiles(media),
+			strings.TrimPrefix(path, "/"),
+		) {
+			l.Debug("unused thumbnail variant for media")
+			return true, nil
+		}
+
 	case media.TypeEmoji:
 		// Generate static URL for this emoji to lookup.
 		staticURL := uris.URIForAttachment(
@@ -372,6 +497,38 @@ func (m *Media) pruneUnused(ctx context.Context, media *gtsmodel.MediaAttachment
 				return false, nil
 			}
 		}
+
+		// Check whether attached to a previous edit of status.
+		edits, err := m.state.DB.GetStatusEditsByIDs(
+			gtscontext.SetBarebones(ctx),
+			status.EditIDs,
+		)
+		if err != nil {
+			return false, gtserror.Newf("error fetching edits of status %s: %w", status.ID, err)
+		}
+
+		for _, edit := range edits {
+			if slices.Contains(edit.AttachmentIDs, media.ID) {
+				l.Debug("skipping as attached to status edit")
+				return false, nil
+			}
+		}
+	}
+
+	if media.ScheduledStatusID != "" {
+		// Check whether still attached to a status scheduled for publishing.
+		scheduled, err := m.state.DB.GetScheduledStatusByID(
+			gtscontext.SetBarebones(ctx),
+			media.ScheduledStatusID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return false, gtserror.Newf("error fetching scheduled status %s: %w", media.ScheduledStatusID, err)
+		}
+
+		if scheduled != nil && slices.Contains(scheduled.MediaIDs, media.ID) {
+			l.Debug("skipping as attached to scheduled status")
+			return false, nil
+		}
 	}
 
 	// Media totally unused, delete it.
@@ -404,8 +561,7 @@ func (m *Media) fixCacheState(ctx context.Context, media *gtsmodel.MediaAttachme
 
 	// Check whether files exist.
 	exist, err := m.haveFiles(ctx,
-		media.Thumbnail.Path,
-		media.File.Path,
+		mediaFiles(media)...,
 	)
 	if err != nil {
 		return false, err
@@ -420,10 +576,13 @@ func (m *Media) fixCacheState(ctx context.Context, media *gtsmodel.MediaAttachme
 	case !*media.Cached && exist:
 		// Remove files if we don't expect them to exist.
 		l.Debug("cached=false exists=true => deleting")
-		_, err := m.removeFiles(ctx,
-			media.Thumbnail.Path,
-			media.File.Path,
-		)
+		unlock := m.lockFile(media)
+		defer unlock()
+		files, err := m.unsharedFiles(ctx, media)
+		if err != nil {
+			return false, err
+		}
+		_, err = m.removeFiles(ctx, files...)
 		return true, err
 
 	default:
@@ -496,6 +655,71 @@ func (m *Media) uncacheRemote(ctx context.Context, after time.Time, media *gtsmo
 	return true, m.uncache(ctx, media)
 }
 
+func (m *Media) dedupe(ctx context.Context, media *gtsmodel.MediaAttachment) (bool, error) {
+	if !*media.Cached || media.File.Path == "" {
+		// Nothing stored.
+		return false, nil
+	}
+
+	// Start a log entry for media.
+	l := log.WithContext(ctx).
+		WithField("media", media.ID)
+
+	if media.File.Hash == "" {
+		// Calculate hash of stored file contents.
+		hash, err := m.hashFile(ctx, media.File.Path)
+		if storage.IsNotFound(err) {
+			// FixCacheStates will take care of this case.
+			l.Debug("skipping due to missing file")
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+
+		// Set the calculated file hash.
+		media.File.Hash = hash
+
+		// Update attachment with file hash so it can be found by other media. This
+		// is done even on a dry run, as it changes no files, and without it other
+		// duplicates of this media could not be found by hash to be counted.
+		if err := m.state.DB.UpdateAttachment(ctx, media, "file_hash"); err != nil {
+			return false, gtserror.Newf("error updating media: %w", err)
+		}
+	}
+
+	// Look for the most recent cached media with identical
+	// contents, whose stored file all duplicates will share.
+	existing, err := m.state.DB.GetAttachmentByFileHash(ctx,
+		media.File.Hash,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("error getting media by file hash: %w", err)
+	}
+
+	switch {
+	case existing == nil || existing.ID == media.ID:
+		// No duplicates, or this
+		// is the media to share.
+		return false, nil
+
+	case existing.File.Path == media.File.Path:
+		// Already shared.
+		return false, nil
+	}
+
+	// Duplicate of an existing file, share it.
+	l.Debugf("sharing duplicate file %s", existing.File.Path)
+	shared, err := m.share(ctx, media, existing.File.Path)
+	if err != nil {
+		return false, err
+	} else if !shared {
+		// FixCacheStates will take care of this case.
+		l.Debug("skipping due to missing file to share")
+	}
+
+	return shared, nil
+}
+
 func (m *Media) getOwningAccount(ctx context.Context, media *gtsmodel.MediaAttachment) (*gtsmodel.Account, bool, error) {
 	if media.AccountID == "" {
 		// no related account.
@@ -548,11 +772,18 @@ func (m *Media) uncache(ctx context.Context, media *gtsmodel.MediaAttachment) er
 		return nil
 	}
 
-	// Remove media and thumbnail.
-	_, err := m.removeFiles(ctx,
-		media.File.Path,
-		media.Thumbnail.Path,
-	)
+	// Lock media file until uncached in db.
+	unlock := m.lockFile(media)
+	defer unlock()
+
+	// Get media files not shared with other media.
+	files, err := m.unsharedFiles(ctx, media)
+	if err != nil {
+		return err
+	}
+
+	// Remove media and thumbnails.
+	_, err = m.removeFiles(ctx, files...)
 	if err != nil {
 		return gtserror.Newf("error removing media files: %w", err)
 	}
@@ -573,11 +804,18 @@ func (m *Media) delete(ctx context.Context, media *gtsmodel.MediaAttachment) err
 		return nil
 	}
 
-	// Remove media and thumbnail.
-	_, err := m.removeFiles(ctx,
-		media.File.Path,
-		media.Thumbnail.Path,
-	)
+	// Lock media file until deleted from db.
+	unlock := m.lockFile(media)
+	defer unlock()
+
+	// Get media files not shared with other media.
+	files, err := m.unsharedFiles(ctx, media)
+	if err != nil {
+		return err
+	}
+
+	// Remove media and thumbnails.
+	_, err = m.removeFiles(ctx, files...)
 	if err != nil {
 		return gtserror.Newf("error removing media files: %w", err)
 	}
@@ -590,3 +828,125 @@ func (m *Media) delete(ctx context.Context, media *gtsmodel.MediaAttachment) err
 
 	return nil
 }
+
+// mediaFiles returns the storage paths of all files
+// stored for media, i.e. the original file, thumbnail
+// and any additional thumbnail variants.
+func mediaFiles(media *gtsmodel.MediaAttachment) []string {
+	files := make([]string, 0, 2+len(media.ThumbnailVariants))
+	files = append(files, media.File.Path, media.Thumbnail.Path)
+	for _, variant := range media.ThumbnailVariants {
+		files = append(files, variant.Path)
+	}
+	return files
+}
+
+// lockFile locks the storage path of the original file of media, (see
+// state.StorageLocks), returning the unlock function. This must be held
+// from checking unsharedFiles() until media is updated in the database.
+func (m *Media) lockFile(media *gtsmodel.MediaAttachment) func() {
+	if media.File.Path == "" {
+		// No original file.
+		return func() {}
+	}
+	return m.state.StorageLocks.Lock(media.File.Path)
+}
+
+// unsharedFiles returns the storage paths of all files stored for media,
+// (see mediaFiles()), except for an original file shared with other cached
+// media. i.e. those files safe to remove when uncaching or deleting media.
+func (m *Media) unsharedFiles(ctx context.Context, media *gtsmodel.MediaAttachment) ([]string, error) {
+	files := mediaFiles(media)
+
+	if media.File.Path == "" {
+		// No original file.
+		return files, nil
+	}
+
+	// Check whether the original file is shared with other media.
+	refs, err := m.state.DB.CountAttachmentsByFilePath(ctx,
+		media.File.Path,
+		media.ID,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error counting media with file %s: %w", media.File.Path, err)
+	}
+
+	if refs > 0 {
+		// Drop original file,
+		// (always the first).
+		files = files[1:]
+	}
+
+	return files, nil
+}
+
+// share updates media to share the stored file at path
+// with other media, which must have identical contents,
+// removing its own file if now unused. Returns false
+// if the file at path is no longer in storage.
+func (m *Media) share(ctx context.Context, media *gtsmodel.MediaAttachment, path string) (bool, error) {
+	// Get current file path.
+	oldPath := media.File.Path
+
+	// Lock both file paths until updated in db, in
+	// a consistent order so as not to deadlock with
+	// any other caller also locking both paths.
+	paths := []string{path, oldPath}
+	slices.Sort(paths)
+	for _, p := range paths {
+		unlock := m.state.StorageLocks.Lock(p)
+		defer unlock()
+	}
+
+	// Ensure file to share is still in storage.
+	exist, err := m.haveFiles(ctx, path)
+	if err != nil || !exist {
+		return false, err
+	}
+
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return true, nil
+	}
+
+	// Update attachment to share the stored file at path.
+	media.File.Path = path
+	if err := m.state.DB.UpdateAttachment(ctx, media, "file_path"); err != nil {
+		return false, gtserror.Newf("error updating media: %w", err)
+	}
+
+	// Check whether old file is still used by other media.
+	refs, err := m.state.DB.CountAttachmentsByFilePath(ctx,
+		oldPath,
+		media.ID,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error counting media with file %s: %w", oldPath, err)
+	}
+
+	if refs == 0 {
+		// Old file is now unused, remove it.
+		_, err := m.removeFiles(ctx, oldPath)
+		return true, err
+	}
+
+	return true, nil
+}
+
+// hashFile calculates the media file hash of stored file at path.
+func (m *Media) hashFile(ctx context.Context, path string) (string, error) {
+	rc, err := m.state.Storage.GetStream(ctx, path)
+	if err != nil {
+		return "", gtserror.Newf("error opening %s: %w", path, err)
+	}
+	defer rc.Close()
+
+	// Hash all stored data.
+	hash, err := media.Hash(rc)
+	if err != nil {
+		return "", gtserror.Newf("error hashing %s: %w", path, err)
+	}
+
+	return hash, nil
+}
@@ -425,3 +425,234 @@ func (suite *MediaTestSuite) TestUncacheOneNonExistent() {
 	suite.NoError(err)
 	suite.Equal(3, totalUncached)
 }
+
+func (suite *MediaTestSuite) TestUncacheRemoteThumbnailVariants() {
+	ctx := context.Background()
+	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+
+	// Give attachment a stored thumbnail variant.
+	variant := suite.putThumbnailVariant(ctx, testStatusAttachment, media.SizeW320)
+	testStatusAttachment.ThumbnailVariants = []*gtsmodel.ThumbnailVariant{variant}
+	err := suite.db.UpdateAttachment(ctx, testStatusAttachment, "thumbnail_variants")
+	suite.NoError(err)
+
+	after := time.Now().Add(-24 * time.Hour)
+	totalUncached, err := suite.cleaner.Media().UncacheRemote(ctx, after)
+	suite.NoError(err)
+	suite.Equal(3, totalUncached)
+
+	// Thumbnail variant should be removed along with the media.
+	have, err := suite.storage.Has(ctx, variant.Path)
+	suite.NoError(err)
+	suite.False(have)
+}
+
+func (suite *MediaTestSuite) TestPruneOrphanedThumbnailVariants() {
+	ctx := context.Background()
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	// Give attachment a stored thumbnail variant,
+	// and store another variant size left over
+	// from some earlier processing of the media.
+	variant := suite.putThumbnailVariant(ctx, testAttachment, media.SizeW320)
+	leftover := suite.putThumbnailVariant(ctx, testAttachment, media.SizeW640)
+	testAttachment.ThumbnailVariants = []*gtsmodel.ThumbnailVariant{variant}
+	err := suite.db.UpdateAttachment(ctx, testAttachment, "thumbnail_variants")
+	suite.NoError(err)
+
+	_, err = suite.cleaner.Media().PruneOrphaned(ctx)
+	suite.NoError(err)
+
+	// Variant in use should be kept.
+	have, err := suite.storage.Has(ctx, variant.Path)
+	suite.NoError(err)
+	suite.True(have)
+
+	// Leftover variant should be pruned.
+	have, err = suite.storage.Has(ctx, leftover.Path)
+	suite.NoError(err)
+	suite.False(have)
+
+	// Other files for the media should be untouched.
+	have, err = suite.storage.Has(ctx, testAttachment.File.Path)
+	suite.NoError(err)
+	suite.True(have)
+}
+
+func (suite *MediaTestSuite) TestUncacheRemoteSharedFile() {
+	ctx := context.Background()
+	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	// Set local attachment to share the remote attachment's file.
+	suite.shareFile(ctx, testAttachment, testStatusAttachment)
+
+	after := time.Now().Add(-24 * time.Hour)
+	totalUncached, err := suite.cleaner.Media().UncacheRemote(ctx, after)
+	suite.NoError(err)
+	suite.Equal(3, totalUncached)
+
+	// Shared file should be kept for the local attachment.
+	have, err := suite.storage.Has(ctx, testStatusAttachment.File.Path)
+	suite.NoError(err)
+	suite.True(have)
+
+	// Unshared thumbnail should be removed.
+	have, err = suite.storage.Has(ctx, testStatusAttachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.False(have)
+}
+
+func (suite *MediaTestSuite) TestPruneOrphanedSharedFile() {
+	ctx := context.Background()
+	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	// Set local attachment to share the remote attachment's
+	// file, then delete the remote attachment from the database.
+	suite.shareFile(ctx, testAttachment, testStatusAttachment)
+	err := suite.db.DeleteAttachment(ctx, testStatusAttachment.ID)
+	suite.NoError(err)
+
+	_, err = suite.cleaner.Media().PruneOrphaned(ctx)
+	suite.NoError(err)
+
+	// Shared file should be kept for the local attachment.
+	have, err := suite.storage.Has(ctx, testStatusAttachment.File.Path)
+	suite.NoError(err)
+	suite.True(have)
+
+	// Orphaned thumbnail should be pruned.
+	have, err = suite.storage.Has(ctx, testStatusAttachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.False(have)
+}
+
+func (suite *MediaTestSuite) TestDedupe() {
+	ctx := context.Background()
+	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	// Store a duplicate of the remote attachment's
+	// file as the local attachment's own file.
+	b, err := suite.storage.Get(ctx, testStatusAttachment.File.Path)
+	suite.NoError(err)
+	_, err = suite.storage.Put(ctx, testAttachment.File.Path, b)
+	suite.NoError(err)
+
+	// Dry run should change no files. Note the expected
+	// count includes duplicates among the test media.
+	totalDeduped, err := suite.cleaner.Media().Dedupe(gtscontext.SetDryRun(ctx))
+	suite.NoError(err)
+	suite.Equal(3, totalDeduped)
+
+	have, err := suite.storage.Has(ctx, testAttachment.File.Path)
+	suite.NoError(err)
+	suite.True(have)
+
+	totalDeduped, err = suite.cleaner.Media().Dedupe(ctx)
+	suite.NoError(err)
+	suite.Equal(3, totalDeduped)
+
+	// Most recent media should keep its own file, and the other
+	// should now share it, with its duplicate file removed.
+	keep, dupe := testAttachment, testStatusAttachment
+	if dupe.ID > keep.ID {
+		keep, dupe = dupe, keep
+	}
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, dupe.ID)
+	suite.NoError(err)
+	suite.Equal(keep.File.Path, dbAttachment.File.Path)
+	suite.NotEmpty(dbAttachment.File.Hash)
+
+	have, err = suite.storage.Has(ctx, keep.File.Path)
+	suite.NoError(err)
+	suite.True(have)
+
+	have, err = suite.storage.Has(ctx, dupe.File.Path)
+	suite.NoError(err)
+	suite.False(have)
+
+	// Files should now all be deduplicated.
+	totalDeduped, err = suite.cleaner.Media().Dedupe(ctx)
+	suite.NoError(err)
+	suite.Zero(totalDeduped)
+}
+
+func (suite *MediaTestSuite) TestDedupeMissingFile() {
+	ctx := context.Background()
+	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	// Store a duplicate of the remote attachment's
+	// file as the local attachment's own file.
+	b, err := suite.storage.Get(ctx, testStatusAttachment.File.Path)
+	suite.NoError(err)
+	_, err = suite.storage.Put(ctx, testAttachment.File.Path, b)
+	suite.NoError(err)
+
+	// Remove the file of the most recent media,
+	// which the other would otherwise share.
+	keep, dupe := testAttachment, testStatusAttachment
+	if dupe.ID > keep.ID {
+		keep, dupe = dupe, keep
+	}
+	err = suite.storage.Delete(ctx, keep.File.Path)
+	suite.NoError(err)
+
+	_, err = suite.cleaner.Media().Dedupe(ctx)
+	suite.NoError(err)
+
+	// Duplicate should have kept its own file.
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, dupe.ID)
+	suite.NoError(err)
+	suite.Equal(dupe.File.Path, dbAttachment.File.Path)
+
+	have, err := suite.storage.Has(ctx, dupe.File.Path)
+	suite.NoError(err)
+	suite.True(have)
+}
+
+// shareFile updates media to share the stored file of other media,
+// removing its own now unused file from storage, as if deduplicated.
+func (suite *MediaTestSuite) shareFile(
+	ctx context.Context,
+	attachment *gtsmodel.MediaAttachment,
+	other *gtsmodel.MediaAttachment,
+) {
+	if err := suite.storage.Delete(ctx, attachment.File.Path); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	attachment.File = other.File
+	if err := suite.db.UpdateAttachment(ctx, attachment, "file_path", "file_file_size", "file_content_type"); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+// putThumbnailVariant stores a copy of the thumbnail of given
+// media as a thumbnail variant of size, returning the variant.
+func (suite *MediaTestSuite) putThumbnailVariant(
+	ctx context.Context,
+	attachment *gtsmodel.MediaAttachment,
+	size media.Size,
+) *gtsmodel.ThumbnailVariant {
+	b, err := suite.storage.Get(ctx, attachment.Thumbnail.Path)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	variant := &gtsmodel.ThumbnailVariant{
+		Width:       media.ThumbVariantWidth(size),
+		Path:        attachment.AccountID + "/attachment/" + string(size) + "/" + attachment.ID + ".jpeg",
+		ContentType: "image/jpeg",
+		FileSize:    len(b),
+	}
+
+	if _, err := suite.storage.Put(ctx, variant.Path, b); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return variant
+}
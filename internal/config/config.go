@@ -78,21 +78,26 @@ type Configuration struct {
 	WebTemplateBaseDir string `name:"web-template-base-dir" usage:"Basedir for html templating files for rendering pages and composing emails."`
 	WebAssetBaseDir    string `name:"web-asset-base-dir" usage:"Directory to serve static assets from, accessible at example.org/assets/"`
 
-	InstanceFederationMode         string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
-	InstanceFederationSpamFilter   bool               `name:"instance-federation-spam-filter" usage:"Enable basic spam filter heuristics for messages coming from other instances, and drop messages identified as spam"`
-	InstanceExposePeers            bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
-	InstanceExposeSuspended        bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
-	InstanceExposeSuspendedWeb     bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
-	InstanceExposePublicTimeline   bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
-	InstanceDeliverToSharedInboxes bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
-	InstanceInjectMastodonVersion  bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
-	InstanceLanguages              language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
+	InstanceFederationMode            string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
+	InstanceFederationSpamFilter      bool               `name:"instance-federation-spam-filter" usage:"Enable basic spam filter heuristics for messages coming from other instances, and drop messages identified as spam"`
+	InstanceExposePeers               bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
+	InstanceExposeSuspended           bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
+	InstanceExposeSuspendedWeb        bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
+	InstanceExposePublicTimeline      bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
+	InstanceDirectoryEnabled          bool               `name:"instance-directory-enabled" usage:"Show a directory of accounts which have opted in to being discoverable, via /api/v1/directory and the web page at /directory"`
+	InstanceDeliverToSharedInboxes    bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
+	InstanceInjectMastodonVersion     bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
+	InstanceLanguages                 language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
+	InstanceSubscriptionsProcessFrom  string             `name:"instance-subscriptions-process-from" usage:"Time of day from which to start running instance subscriptions processing jobs. Should be in the format 'hh:mm', eg., '15:04'."`
+	InstanceSubscriptionsProcessEvery time.Duration      `name:"instance-subscriptions-process-every" usage:"Period to elapse between instance subscriptions processing jobs, starting from instance-subscriptions-process-from."`
 
 	AccountsRegistrationOpen bool `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
 	AccountsReasonRequired   bool `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
 	AccountsAllowCustomCSS   bool `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
 	AccountsCustomCSSLength  int  `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
 
+	AccountsArchiveImportMaxJSONSize bytesize.Size `name:"accounts-archive-import-max-json-size" usage:"Max size in bytes of the JSON files (eg., outbox.json) read from account archives uploaded for import."`
+
 	MediaDescriptionMinChars int           `name:"media-description-min-chars" usage:"Min required chars for an image description"`
 	MediaDescriptionMaxChars int           `name:"media-description-max-chars" usage:"Max permitted chars for an image description"`
 	MediaRemoteCacheDays     int           `name:"media-remote-cache-days" usage:"Number of days to locally cache media from remote instances. If set to 0, remote media will be kept indefinitely."`
@@ -104,6 +109,11 @@ type Configuration struct {
 	MediaCleanupEvery        time.Duration `name:"media-cleanup-every" usage:"Period to elapse between cleanups, starting from media-cleanup-at."`
 	MediaFfmpegPoolSize      int           `name:"media-ffmpeg-pool-size" usage:"Number of instances of the embedded ffmpeg WASM binary to add to the media processing pool. 0 or less uses GOMAXPROCS."`
 
+	MediaTranscode            bool          `name:"media-transcode" usage:"Transcode media in formats that web browsers can't display (eg., HEIC, TIFF, AVI, MKV, FLAC) to widely supported formats."`
+	MediaTranscodeMaxSize     bytesize.Size `name:"media-transcode-max-size" usage:"Max size in bytes of media files to transcode. Larger files are stored without transcoding, or rejected if their format requires it."`
+	MediaTranscodeMaxDuration time.Duration `name:"media-transcode-max-duration" usage:"Max duration of video and audio files to transcode. Longer files are stored without transcoding, or rejected if their format requires it. 0 or less turns this limit off."`
+	MediaTranscodeAudioFormat string        `name:"media-transcode-audio-format" usage:"Format to transcode audio files to, either 'mp3' or 'opus'."`
+
 	StorageBackend       string `name:"storage-backend" usage:"Storage backend to use for media attachments"`
 	StorageLocalBasePath string `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
 	StorageS3Endpoint    string `name:"storage-s3-endpoint" usage:"S3 Endpoint URL (e.g 'minio.example.org:9000')"`
@@ -168,6 +178,9 @@ type Configuration struct {
 	AdvancedCSPExtraURIs         []string      `name:"advanced-csp-extra-uris" usage:"Additional URIs to allow when building content-security-policy for media + images."`
 	AdvancedHeaderFilterMode     string        `name:"advanced-header-filter-mode" usage:"Set incoming request header filtering mode."`
 
+	AdvancedDeliveryUnavailableAfter time.Duration `name:"advanced-delivery-unavailable-after" usage:"Period of continuously failing deliveries to an instance after which it is marked unavailable, and further deliveries to it are skipped. 0 or less turns this off."`
+	AdvancedDeliveryProbeEvery       time.Duration `name:"advanced-delivery-probe-every" usage:"Period to elapse between delivery attempts to an instance marked unavailable, to check whether it has recovered."`
+
 	// HTTPClient configuration vars.
 	HTTPClient HTTPClientConfiguration `name:"http-client"`
 
@@ -179,6 +192,7 @@ type Configuration struct {
 	AdminAccountEmail        string `name:"email" usage:"the email address of this account"`
 	AdminAccountPassword     string `name:"password" usage:"the password to set for this account"`
 	AdminTransPath           string `name:"path" usage:"the path of the file to import from/export to"`
+	AdminTransIncludeMedia   bool   `name:"include-media" usage:"also export all media files and emojis in storage; needed when moving an instance between hosts"`
 	AdminMediaPruneDryRun    bool   `name:"dry-run" usage:"perform a dry run and only log number of items eligible for pruning"`
 	AdminMediaListLocalOnly  bool   `name:"local-only" usage:"list only local attachments/emojis; if specified then remote-only cannot also be true"`
 	AdminMediaListRemoteOnly bool   `name:"remote-only" usage:"list only remote attachments/emojis; if specified then local-only cannot also be true"`
@@ -204,6 +218,7 @@ type CacheConfiguration struct {
 	BlockIDsMemRatio                  float64       `name:"block-ids-mem-ratio"`
 	BoostOfIDsMemRatio                float64       `name:"boost-of-ids-mem-ratio"`
 	ClientMemRatio                    float64       `name:"client-mem-ratio"`
+	ContentLimitMemRatio              float64       `name:"content-limit-mem-ratio"`
 	ConversationMemRatio              float64       `name:"conversation-mem-ratio"`
 	ConversationLastStatusIDsMemRatio float64       `name:"conversation-last-status-ids-mem-ratio"`
 	EmojiMemRatio                     float64       `name:"emoji-mem-ratio"`
@@ -231,12 +246,16 @@ type CacheConfiguration struct {
 	PollVoteMemRatio                  float64       `name:"poll-vote-mem-ratio"`
 	PollVoteIDsMemRatio               float64       `name:"poll-vote-ids-mem-ratio"`
 	ReportMemRatio                    float64       `name:"report-mem-ratio"`
+	ScheduledStatusMemRatio           float64       `name:"scheduled-status-mem-ratio"`
 	SinBinStatusMemRatio              float64       `name:"sin-bin-status-mem-ratio"`
 	StatusMemRatio                    float64       `name:"status-mem-ratio"`
 	StatusBookmarkMemRatio            float64       `name:"status-bookmark-mem-ratio"`
 	StatusBookmarkIDsMemRatio         float64       `name:"status-bookmark-ids-mem-ratio"`
+	StatusEditMemRatio                float64       `name:"status-edit-mem-ratio"`
 	StatusFaveMemRatio                float64       `name:"status-fave-mem-ratio"`
 	StatusFaveIDsMemRatio             float64       `name:"status-fave-ids-mem-ratio"`
+	StatusReactionMemRatio            float64       `name:"status-reaction-mem-ratio"`
+	StatusReactionIDsMemRatio         float64       `name:"status-reaction-ids-mem-ratio"`
 	TagMemRatio                       float64       `name:"tag-mem-ratio"`
 	ThreadMuteMemRatio                float64       `name:"thread-mute-mem-ratio"`
 	TokenMemRatio                     float64       `name:"token-mem-ratio"`
@@ -245,6 +264,7 @@ type CacheConfiguration struct {
 	UserMuteMemRatio                  float64       `name:"user-mute-mem-ratio"`
 	UserMuteIDsMemRatio               float64       `name:"user-mute-ids-mem-ratio"`
 	WebfingerMemRatio                 float64       `name:"webfinger-mem-ratio"`
+	WebPushSubscriptionMemRatio       float64       `name:"web-push-subscription-mem-ratio"`
 	VisibilityMemRatio                float64       `name:"visibility-mem-ratio"`
 }
 
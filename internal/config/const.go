@@ -29,4 +29,9 @@ const (
 	RequestHeaderFilterModeAllow    = "allow"
 	RequestHeaderFilterModeBlock    = "block"
 	RequestHeaderFilterModeDisabled = ""
+
+	// Media transcode audio format determines
+	// the format audio files are transcoded to.
+	MediaTranscodeAudioFormatMP3  = "mp3"
+	MediaTranscodeAudioFormatOpus = "opus"
 )
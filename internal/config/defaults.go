@@ -58,19 +58,24 @@ var Defaults = Configuration{
 	WebTemplateBaseDir: "./web/template/",
 	WebAssetBaseDir:    "./web/assets/",
 
-	InstanceFederationMode:         InstanceFederationModeDefault,
-	InstanceFederationSpamFilter:   false,
-	InstanceExposePeers:            false,
-	InstanceExposeSuspended:        false,
-	InstanceExposeSuspendedWeb:     false,
-	InstanceDeliverToSharedInboxes: true,
-	InstanceLanguages:              make(language.Languages, 0),
+	InstanceFederationMode:            InstanceFederationModeDefault,
+	InstanceFederationSpamFilter:      false,
+	InstanceExposePeers:               false,
+	InstanceExposeSuspended:           false,
+	InstanceExposeSuspendedWeb:        false,
+	InstanceDirectoryEnabled:          true,
+	InstanceDeliverToSharedInboxes:    true,
+	InstanceLanguages:                 make(language.Languages, 0),
+	InstanceSubscriptionsProcessFrom:  "23:00",        // 11pm.
+	InstanceSubscriptionsProcessEvery: 24 * time.Hour, // 1/day.
 
 	AccountsRegistrationOpen: false,
 	AccountsReasonRequired:   true,
 	AccountsAllowCustomCSS:   false,
 	AccountsCustomCSSLength:  10000,
 
+	AccountsArchiveImportMaxJSONSize: 100 * bytesize.MiB,
+
 	MediaDescriptionMinChars: 0,
 	MediaDescriptionMaxChars: 1500,
 	MediaRemoteCacheDays:     7,
@@ -82,6 +87,11 @@ var Defaults = Configuration{
 	MediaCleanupEvery:        24 * time.Hour, // 1/day.
 	MediaFfmpegPoolSize:      1,
 
+	MediaTranscode:            true,
+	MediaTranscodeMaxSize:     40 * bytesize.MiB,
+	MediaTranscodeMaxDuration: 10 * time.Minute,
+	MediaTranscodeAudioFormat: MediaTranscodeAudioFormatMP3,
+
 	StorageBackend:       "local",
 	StorageLocalBasePath: "/gotosocial/storage",
 	StorageS3UseSSL:      true,
@@ -138,6 +148,9 @@ var Defaults = Configuration{
 	AdvancedCSPExtraURIs:         []string{},
 	AdvancedHeaderFilterMode:     RequestHeaderFilterModeDisabled,
 
+	AdvancedDeliveryUnavailableAfter: 24 * time.Hour,
+	AdvancedDeliveryProbeEvery:       time.Hour,
+
 	Cache: CacheConfiguration{
 		// Rough memory target that the total
 		// size of all State.Caches will attempt
@@ -167,6 +180,7 @@ var Defaults = Configuration{
 		BlockIDsMemRatio:                  3,
 		BoostOfIDsMemRatio:                3,
 		ClientMemRatio:                    0.1,
+		ContentLimitMemRatio:              0.1,
 		ConversationMemRatio:              1,
 		ConversationLastStatusIDsMemRatio: 2,
 		EmojiMemRatio:                     3,
@@ -194,12 +208,16 @@ var Defaults = Configuration{
 		PollVoteMemRatio:                  2,
 		PollVoteIDsMemRatio:               2,
 		ReportMemRatio:                    1,
+		ScheduledStatusMemRatio:           0.5,
 		SinBinStatusMemRatio:              0.5,
 		StatusMemRatio:                    5,
 		StatusBookmarkMemRatio:            0.5,
 		StatusBookmarkIDsMemRatio:         2,
+		StatusEditMemRatio:                2,
 		StatusFaveMemRatio:                2,
 		StatusFaveIDsMemRatio:             3,
+		StatusReactionMemRatio:            1,
+		StatusReactionIDsMemRatio:         2,
 		TagMemRatio:                       2,
 		ThreadMuteMemRatio:                0.2,
 		TokenMemRatio:                     0.75,
@@ -208,6 +226,7 @@ var Defaults = Configuration{
 		UserMuteMemRatio:                  2,
 		UserMuteIDsMemRatio:               3,
 		WebfingerMemRatio:                 0.1,
+		WebPushSubscriptionMemRatio:       1,
 		VisibilityMemRatio:                2,
 	},
 
@@ -88,6 +88,7 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Bool(InstanceExposePeersFlag(), cfg.InstanceExposePeers, fieldtag("InstanceExposePeers", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedFlag(), cfg.InstanceExposeSuspended, fieldtag("InstanceExposeSuspended", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedWebFlag(), cfg.InstanceExposeSuspendedWeb, fieldtag("InstanceExposeSuspendedWeb", "usage"))
+		cmd.Flags().Bool(InstanceDirectoryEnabledFlag(), cfg.InstanceDirectoryEnabled, fieldtag("InstanceDirectoryEnabled", "usage"))
 		cmd.Flags().Bool(InstanceDeliverToSharedInboxesFlag(), cfg.InstanceDeliverToSharedInboxes, fieldtag("InstanceDeliverToSharedInboxes", "usage"))
 		cmd.Flags().StringSlice(InstanceLanguagesFlag(), cfg.InstanceLanguages.TagStrs(), fieldtag("InstanceLanguages", "usage"))
 
@@ -207,6 +208,15 @@ func AddAdminTrans(cmd *cobra.Command) {
 	}
 }
 
+// AddAdminExport attaches flags pertaining to the export command.
+func AddAdminExport(cmd *cobra.Command) {
+	AddAdminTrans(cmd)
+
+	name := AdminTransIncludeMediaFlag()
+	usage := fieldtag("AdminTransIncludeMedia", "usage")
+	cmd.Flags().Bool(name, false, usage)
+}
+
 // AddAdminMediaList attaches flags pertaining to media list commands.
 func AddAdminMediaList(cmd *cobra.Command) {
 	localOnly := AdminMediaListLocalOnlyFlag()
@@ -925,6 +925,31 @@ func GetInstanceExposePublicTimeline() bool { return global.GetInstanceExposePub
 // SetInstanceExposePublicTimeline safely sets the value for global configuration 'InstanceExposePublicTimeline' field
 func SetInstanceExposePublicTimeline(v bool) { global.SetInstanceExposePublicTimeline(v) }
 
+// GetInstanceDirectoryEnabled safely fetches the Configuration value for state's 'InstanceDirectoryEnabled' field
+func (st *ConfigState) GetInstanceDirectoryEnabled() (v bool) {
+	st.mutex.RLock()
+	v = st.config.InstanceDirectoryEnabled
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceDirectoryEnabled safely sets the Configuration value for state's 'InstanceDirectoryEnabled' field
+func (st *ConfigState) SetInstanceDirectoryEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceDirectoryEnabled = v
+	st.reloadToViper()
+}
+
+// InstanceDirectoryEnabledFlag returns the flag name for the 'InstanceDirectoryEnabled' field
+func InstanceDirectoryEnabledFlag() string { return "instance-directory-enabled" }
+
+// GetInstanceDirectoryEnabled safely fetches the value for global configuration 'InstanceDirectoryEnabled' field
+func GetInstanceDirectoryEnabled() bool { return global.GetInstanceDirectoryEnabled() }
+
+// SetInstanceDirectoryEnabled safely sets the value for global configuration 'InstanceDirectoryEnabled' field
+func SetInstanceDirectoryEnabled(v bool) { global.SetInstanceDirectoryEnabled(v) }
+
 // GetInstanceDeliverToSharedInboxes safely fetches the Configuration value for state's 'InstanceDeliverToSharedInboxes' field
 func (st *ConfigState) GetInstanceDeliverToSharedInboxes() (v bool) {
 	st.mutex.RLock()
@@ -1000,6 +1025,62 @@ func GetInstanceLanguages() language.Languages { return global.GetInstanceLangua
 // SetInstanceLanguages safely sets the value for global configuration 'InstanceLanguages' field
 func SetInstanceLanguages(v language.Languages) { global.SetInstanceLanguages(v) }
 
+// GetInstanceSubscriptionsProcessFrom safely fetches the Configuration value for state's 'InstanceSubscriptionsProcessFrom' field
+func (st *ConfigState) GetInstanceSubscriptionsProcessFrom() (v string) {
+	st.mutex.RLock()
+	v = st.config.InstanceSubscriptionsProcessFrom
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceSubscriptionsProcessFrom safely sets the Configuration value for state's 'InstanceSubscriptionsProcessFrom' field
+func (st *ConfigState) SetInstanceSubscriptionsProcessFrom(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceSubscriptionsProcessFrom = v
+	st.reloadToViper()
+}
+
+// InstanceSubscriptionsProcessFromFlag returns the flag name for the 'InstanceSubscriptionsProcessFrom' field
+func InstanceSubscriptionsProcessFromFlag() string { return "instance-subscriptions-process-from" }
+
+// GetInstanceSubscriptionsProcessFrom safely fetches the value for global configuration 'InstanceSubscriptionsProcessFrom' field
+func GetInstanceSubscriptionsProcessFrom() string {
+	return global.GetInstanceSubscriptionsProcessFrom()
+}
+
+// SetInstanceSubscriptionsProcessFrom safely sets the value for global configuration 'InstanceSubscriptionsProcessFrom' field
+func SetInstanceSubscriptionsProcessFrom(v string) { global.SetInstanceSubscriptionsProcessFrom(v) }
+
+// GetInstanceSubscriptionsProcessEvery safely fetches the Configuration value for state's 'InstanceSubscriptionsProcessEvery' field
+func (st *ConfigState) GetInstanceSubscriptionsProcessEvery() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.InstanceSubscriptionsProcessEvery
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceSubscriptionsProcessEvery safely sets the Configuration value for state's 'InstanceSubscriptionsProcessEvery' field
+func (st *ConfigState) SetInstanceSubscriptionsProcessEvery(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceSubscriptionsProcessEvery = v
+	st.reloadToViper()
+}
+
+// InstanceSubscriptionsProcessEveryFlag returns the flag name for the 'InstanceSubscriptionsProcessEvery' field
+func InstanceSubscriptionsProcessEveryFlag() string { return "instance-subscriptions-process-every" }
+
+// GetInstanceSubscriptionsProcessEvery safely fetches the value for global configuration 'InstanceSubscriptionsProcessEvery' field
+func GetInstanceSubscriptionsProcessEvery() time.Duration {
+	return global.GetInstanceSubscriptionsProcessEvery()
+}
+
+// SetInstanceSubscriptionsProcessEvery safely sets the value for global configuration 'InstanceSubscriptionsProcessEvery' field
+func SetInstanceSubscriptionsProcessEvery(v time.Duration) {
+	global.SetInstanceSubscriptionsProcessEvery(v)
+}
+
 // GetAccountsRegistrationOpen safely fetches the Configuration value for state's 'AccountsRegistrationOpen' field
 func (st *ConfigState) GetAccountsRegistrationOpen() (v bool) {
 	st.mutex.RLock()
@@ -1100,6 +1181,35 @@ func GetAccountsCustomCSSLength() int { return global.GetAccountsCustomCSSLength
 // SetAccountsCustomCSSLength safely sets the value for global configuration 'AccountsCustomCSSLength' field
 func SetAccountsCustomCSSLength(v int) { global.SetAccountsCustomCSSLength(v) }
 
+// GetAccountsArchiveImportMaxJSONSize safely fetches the Configuration value for state's 'AccountsArchiveImportMaxJSONSize' field
+func (st *ConfigState) GetAccountsArchiveImportMaxJSONSize() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.AccountsArchiveImportMaxJSONSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsArchiveImportMaxJSONSize safely sets the Configuration value for state's 'AccountsArchiveImportMaxJSONSize' field
+func (st *ConfigState) SetAccountsArchiveImportMaxJSONSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsArchiveImportMaxJSONSize = v
+	st.reloadToViper()
+}
+
+// AccountsArchiveImportMaxJSONSizeFlag returns the flag name for the 'AccountsArchiveImportMaxJSONSize' field
+func AccountsArchiveImportMaxJSONSizeFlag() string { return "accounts-archive-import-max-json-size" }
+
+// GetAccountsArchiveImportMaxJSONSize safely fetches the value for global configuration 'AccountsArchiveImportMaxJSONSize' field
+func GetAccountsArchiveImportMaxJSONSize() bytesize.Size {
+	return global.GetAccountsArchiveImportMaxJSONSize()
+}
+
+// SetAccountsArchiveImportMaxJSONSize safely sets the value for global configuration 'AccountsArchiveImportMaxJSONSize' field
+func SetAccountsArchiveImportMaxJSONSize(v bytesize.Size) {
+	global.SetAccountsArchiveImportMaxJSONSize(v)
+}
+
 // GetMediaDescriptionMinChars safely fetches the Configuration value for state's 'MediaDescriptionMinChars' field
 func (st *ConfigState) GetMediaDescriptionMinChars() (v int) {
 	st.mutex.RLock()
@@ -1350,6 +1460,106 @@ func GetMediaFfmpegPoolSize() int { return global.GetMediaFfmpegPoolSize() }
 // SetMediaFfmpegPoolSize safely sets the value for global configuration 'MediaFfmpegPoolSize' field
 func SetMediaFfmpegPoolSize(v int) { global.SetMediaFfmpegPoolSize(v) }
 
+// GetMediaTranscode safely fetches the Configuration value for state's 'MediaTranscode' field
+func (st *ConfigState) GetMediaTranscode() (v bool) {
+	st.mutex.RLock()
+	v = st.config.MediaTranscode
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaTranscode safely sets the Configuration value for state's 'MediaTranscode' field
+func (st *ConfigState) SetMediaTranscode(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaTranscode = v
+	st.reloadToViper()
+}
+
+// MediaTranscodeFlag returns the flag name for the 'MediaTranscode' field
+func MediaTranscodeFlag() string { return "media-transcode" }
+
+// GetMediaTranscode safely fetches the value for global configuration 'MediaTranscode' field
+func GetMediaTranscode() bool { return global.GetMediaTranscode() }
+
+// SetMediaTranscode safely sets the value for global configuration 'MediaTranscode' field
+func SetMediaTranscode(v bool) { global.SetMediaTranscode(v) }
+
+// GetMediaTranscodeMaxSize safely fetches the Configuration value for state's 'MediaTranscodeMaxSize' field
+func (st *ConfigState) GetMediaTranscodeMaxSize() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.MediaTranscodeMaxSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaTranscodeMaxSize safely sets the Configuration value for state's 'MediaTranscodeMaxSize' field
+func (st *ConfigState) SetMediaTranscodeMaxSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaTranscodeMaxSize = v
+	st.reloadToViper()
+}
+
+// MediaTranscodeMaxSizeFlag returns the flag name for the 'MediaTranscodeMaxSize' field
+func MediaTranscodeMaxSizeFlag() string { return "media-transcode-max-size" }
+
+// GetMediaTranscodeMaxSize safely fetches the value for global configuration 'MediaTranscodeMaxSize' field
+func GetMediaTranscodeMaxSize() bytesize.Size { return global.GetMediaTranscodeMaxSize() }
+
+// SetMediaTranscodeMaxSize safely sets the value for global configuration 'MediaTranscodeMaxSize' field
+func SetMediaTranscodeMaxSize(v bytesize.Size) { global.SetMediaTranscodeMaxSize(v) }
+
+// GetMediaTranscodeMaxDuration safely fetches the Configuration value for state's 'MediaTranscodeMaxDuration' field
+func (st *ConfigState) GetMediaTranscodeMaxDuration() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.MediaTranscodeMaxDuration
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaTranscodeMaxDuration safely sets the Configuration value for state's 'MediaTranscodeMaxDuration' field
+func (st *ConfigState) SetMediaTranscodeMaxDuration(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaTranscodeMaxDuration = v
+	st.reloadToViper()
+}
+
+// MediaTranscodeMaxDurationFlag returns the flag name for the 'MediaTranscodeMaxDuration' field
+func MediaTranscodeMaxDurationFlag() string { return "media-transcode-max-duration" }
+
+// GetMediaTranscodeMaxDuration safely fetches the value for global configuration 'MediaTranscodeMaxDuration' field
+func GetMediaTranscodeMaxDuration() time.Duration { return global.GetMediaTranscodeMaxDuration() }
+
+// SetMediaTranscodeMaxDuration safely sets the value for global configuration 'MediaTranscodeMaxDuration' field
+func SetMediaTranscodeMaxDuration(v time.Duration) { global.SetMediaTranscodeMaxDuration(v) }
+
+// GetMediaTranscodeAudioFormat safely fetches the Configuration value for state's 'MediaTranscodeAudioFormat' field
+func (st *ConfigState) GetMediaTranscodeAudioFormat() (v string) {
+	st.mutex.RLock()
+	v = st.config.MediaTranscodeAudioFormat
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaTranscodeAudioFormat safely sets the Configuration value for state's 'MediaTranscodeAudioFormat' field
+func (st *ConfigState) SetMediaTranscodeAudioFormat(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaTranscodeAudioFormat = v
+	st.reloadToViper()
+}
+
+// MediaTranscodeAudioFormatFlag returns the flag name for the 'MediaTranscodeAudioFormat' field
+func MediaTranscodeAudioFormatFlag() string { return "media-transcode-audio-format" }
+
+// GetMediaTranscodeAudioFormat safely fetches the value for global configuration 'MediaTranscodeAudioFormat' field
+func GetMediaTranscodeAudioFormat() string { return global.GetMediaTranscodeAudioFormat() }
+
+// SetMediaTranscodeAudioFormat safely sets the value for global configuration 'MediaTranscodeAudioFormat' field
+func SetMediaTranscodeAudioFormat(v string) { global.SetMediaTranscodeAudioFormat(v) }
+
 // GetStorageBackend safely fetches the Configuration value for state's 'StorageBackend' field
 func (st *ConfigState) GetStorageBackend() (v string) {
 	st.mutex.RLock()
@@ -2700,6 +2910,60 @@ func GetAdvancedHeaderFilterMode() string { return global.GetAdvancedHeaderFilte
 // SetAdvancedHeaderFilterMode safely sets the value for global configuration 'AdvancedHeaderFilterMode' field
 func SetAdvancedHeaderFilterMode(v string) { global.SetAdvancedHeaderFilterMode(v) }
 
+// GetAdvancedDeliveryUnavailableAfter safely fetches the Configuration value for state's 'AdvancedDeliveryUnavailableAfter' field
+func (st *ConfigState) GetAdvancedDeliveryUnavailableAfter() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.AdvancedDeliveryUnavailableAfter
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedDeliveryUnavailableAfter safely sets the Configuration value for state's 'AdvancedDeliveryUnavailableAfter' field
+func (st *ConfigState) SetAdvancedDeliveryUnavailableAfter(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedDeliveryUnavailableAfter = v
+	st.reloadToViper()
+}
+
+// AdvancedDeliveryUnavailableAfterFlag returns the flag name for the 'AdvancedDeliveryUnavailableAfter' field
+func AdvancedDeliveryUnavailableAfterFlag() string { return "advanced-delivery-unavailable-after" }
+
+// GetAdvancedDeliveryUnavailableAfter safely fetches the value for global configuration 'AdvancedDeliveryUnavailableAfter' field
+func GetAdvancedDeliveryUnavailableAfter() time.Duration {
+	return global.GetAdvancedDeliveryUnavailableAfter()
+}
+
+// SetAdvancedDeliveryUnavailableAfter safely sets the value for global configuration 'AdvancedDeliveryUnavailableAfter' field
+func SetAdvancedDeliveryUnavailableAfter(v time.Duration) {
+	global.SetAdvancedDeliveryUnavailableAfter(v)
+}
+
+// GetAdvancedDeliveryProbeEvery safely fetches the Configuration value for state's 'AdvancedDeliveryProbeEvery' field
+func (st *ConfigState) GetAdvancedDeliveryProbeEvery() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.AdvancedDeliveryProbeEvery
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedDeliveryProbeEvery safely sets the Configuration value for state's 'AdvancedDeliveryProbeEvery' field
+func (st *ConfigState) SetAdvancedDeliveryProbeEvery(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedDeliveryProbeEvery = v
+	st.reloadToViper()
+}
+
+// AdvancedDeliveryProbeEveryFlag returns the flag name for the 'AdvancedDeliveryProbeEvery' field
+func AdvancedDeliveryProbeEveryFlag() string { return "advanced-delivery-probe-every" }
+
+// GetAdvancedDeliveryProbeEvery safely fetches the value for global configuration 'AdvancedDeliveryProbeEvery' field
+func GetAdvancedDeliveryProbeEvery() time.Duration { return global.GetAdvancedDeliveryProbeEvery() }
+
+// SetAdvancedDeliveryProbeEvery safely sets the value for global configuration 'AdvancedDeliveryProbeEvery' field
+func SetAdvancedDeliveryProbeEvery(v time.Duration) { global.SetAdvancedDeliveryProbeEvery(v) }
+
 // GetHTTPClientAllowIPs safely fetches the Configuration value for state's 'HTTPClient.AllowIPs' field
 func (st *ConfigState) GetHTTPClientAllowIPs() (v []string) {
 	st.mutex.RLock()
@@ -3050,6 +3314,31 @@ func GetCacheClientMemRatio() float64 { return global.GetCacheClientMemRatio() }
 // SetCacheClientMemRatio safely sets the value for global configuration 'Cache.ClientMemRatio' field
 func SetCacheClientMemRatio(v float64) { global.SetCacheClientMemRatio(v) }
 
+// GetCacheContentLimitMemRatio safely fetches the Configuration value for state's 'Cache.ContentLimitMemRatio' field
+func (st *ConfigState) GetCacheContentLimitMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.ContentLimitMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheContentLimitMemRatio safely sets the Configuration value for state's 'Cache.ContentLimitMemRatio' field
+func (st *ConfigState) SetCacheContentLimitMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.ContentLimitMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheContentLimitMemRatioFlag returns the flag name for the 'Cache.ContentLimitMemRatio' field
+func CacheContentLimitMemRatioFlag() string { return "cache-content-limit-mem-ratio" }
+
+// GetCacheContentLimitMemRatio safely fetches the value for global configuration 'Cache.ContentLimitMemRatio' field
+func GetCacheContentLimitMemRatio() float64 { return global.GetCacheContentLimitMemRatio() }
+
+// SetCacheContentLimitMemRatio safely sets the value for global configuration 'Cache.ContentLimitMemRatio' field
+func SetCacheContentLimitMemRatio(v float64) { global.SetCacheContentLimitMemRatio(v) }
+
 // GetCacheConversationMemRatio safely fetches the Configuration value for state's 'Cache.ConversationMemRatio' field
 func (st *ConfigState) GetCacheConversationMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3731,6 +4020,31 @@ func GetCacheReportMemRatio() float64 { return global.GetCacheReportMemRatio() }
 // SetCacheReportMemRatio safely sets the value for global configuration 'Cache.ReportMemRatio' field
 func SetCacheReportMemRatio(v float64) { global.SetCacheReportMemRatio(v) }
 
+// GetCacheScheduledStatusMemRatio safely fetches the Configuration value for state's 'Cache.ScheduledStatusMemRatio' field
+func (st *ConfigState) GetCacheScheduledStatusMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.ScheduledStatusMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheScheduledStatusMemRatio safely sets the Configuration value for state's 'Cache.ScheduledStatusMemRatio' field
+func (st *ConfigState) SetCacheScheduledStatusMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.ScheduledStatusMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheScheduledStatusMemRatioFlag returns the flag name for the 'Cache.ScheduledStatusMemRatio' field
+func CacheScheduledStatusMemRatioFlag() string { return "cache-scheduled-status-mem-ratio" }
+
+// GetCacheScheduledStatusMemRatio safely fetches the value for global configuration 'Cache.ScheduledStatusMemRatio' field
+func GetCacheScheduledStatusMemRatio() float64 { return global.GetCacheScheduledStatusMemRatio() }
+
+// SetCacheScheduledStatusMemRatio safely sets the value for global configuration 'Cache.ScheduledStatusMemRatio' field
+func SetCacheScheduledStatusMemRatio(v float64) { global.SetCacheScheduledStatusMemRatio(v) }
+
 // GetCacheSinBinStatusMemRatio safely fetches the Configuration value for state's 'Cache.SinBinStatusMemRatio' field
 func (st *ConfigState) GetCacheSinBinStatusMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3831,6 +4145,31 @@ func GetCacheStatusBookmarkIDsMemRatio() float64 { return global.GetCacheStatusB
 // SetCacheStatusBookmarkIDsMemRatio safely sets the value for global configuration 'Cache.StatusBookmarkIDsMemRatio' field
 func SetCacheStatusBookmarkIDsMemRatio(v float64) { global.SetCacheStatusBookmarkIDsMemRatio(v) }
 
+// GetCacheStatusEditMemRatio safely fetches the Configuration value for state's 'Cache.StatusEditMemRatio' field
+func (st *ConfigState) GetCacheStatusEditMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.StatusEditMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheStatusEditMemRatio safely sets the Configuration value for state's 'Cache.StatusEditMemRatio' field
+func (st *ConfigState) SetCacheStatusEditMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.StatusEditMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheStatusEditMemRatioFlag returns the flag name for the 'Cache.StatusEditMemRatio' field
+func CacheStatusEditMemRatioFlag() string { return "cache-status-edit-mem-ratio" }
+
+// GetCacheStatusEditMemRatio safely fetches the value for global configuration 'Cache.StatusEditMemRatio' field
+func GetCacheStatusEditMemRatio() float64 { return global.GetCacheStatusEditMemRatio() }
+
+// SetCacheStatusEditMemRatio safely sets the value for global configuration 'Cache.StatusEditMemRatio' field
+func SetCacheStatusEditMemRatio(v float64) { global.SetCacheStatusEditMemRatio(v) }
+
 // GetCacheStatusFaveMemRatio safely fetches the Configuration value for state's 'Cache.StatusFaveMemRatio' field
 func (st *ConfigState) GetCacheStatusFaveMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3881,6 +4220,56 @@ func GetCacheStatusFaveIDsMemRatio() float64 { return global.GetCacheStatusFaveI
 // SetCacheStatusFaveIDsMemRatio safely sets the value for global configuration 'Cache.StatusFaveIDsMemRatio' field
 func SetCacheStatusFaveIDsMemRatio(v float64) { global.SetCacheStatusFaveIDsMemRatio(v) }
 
+// GetCacheStatusReactionMemRatio safely fetches the Configuration value for state's 'Cache.StatusReactionMemRatio' field
+func (st *ConfigState) GetCacheStatusReactionMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.StatusReactionMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheStatusReactionMemRatio safely sets the Configuration value for state's 'Cache.StatusReactionMemRatio' field
+func (st *ConfigState) SetCacheStatusReactionMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.StatusReactionMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheStatusReactionMemRatioFlag returns the flag name for the 'Cache.StatusReactionMemRatio' field
+func CacheStatusReactionMemRatioFlag() string { return "cache-status-reaction-mem-ratio" }
+
+// GetCacheStatusReactionMemRatio safely fetches the value for global configuration 'Cache.StatusReactionMemRatio' field
+func GetCacheStatusReactionMemRatio() float64 { return global.GetCacheStatusReactionMemRatio() }
+
+// SetCacheStatusReactionMemRatio safely sets the value for global configuration 'Cache.StatusReactionMemRatio' field
+func SetCacheStatusReactionMemRatio(v float64) { global.SetCacheStatusReactionMemRatio(v) }
+
+// GetCacheStatusReactionIDsMemRatio safely fetches the Configuration value for state's 'Cache.StatusReactionIDsMemRatio' field
+func (st *ConfigState) GetCacheStatusReactionIDsMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.StatusReactionIDsMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheStatusReactionIDsMemRatio safely sets the Configuration value for state's 'Cache.StatusReactionIDsMemRatio' field
+func (st *ConfigState) SetCacheStatusReactionIDsMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.StatusReactionIDsMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheStatusReactionIDsMemRatioFlag returns the flag name for the 'Cache.StatusReactionIDsMemRatio' field
+func CacheStatusReactionIDsMemRatioFlag() string { return "cache-status-reaction-ids-mem-ratio" }
+
+// GetCacheStatusReactionIDsMemRatio safely fetches the value for global configuration 'Cache.StatusReactionIDsMemRatio' field
+func GetCacheStatusReactionIDsMemRatio() float64 { return global.GetCacheStatusReactionIDsMemRatio() }
+
+// SetCacheStatusReactionIDsMemRatio safely sets the value for global configuration 'Cache.StatusReactionIDsMemRatio' field
+func SetCacheStatusReactionIDsMemRatio(v float64) { global.SetCacheStatusReactionIDsMemRatio(v) }
+
 // GetCacheTagMemRatio safely fetches the Configuration value for state's 'Cache.TagMemRatio' field
 func (st *ConfigState) GetCacheTagMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -4081,6 +4470,33 @@ func GetCacheWebfingerMemRatio() float64 { return global.GetCacheWebfingerMemRat
 // SetCacheWebfingerMemRatio safely sets the value for global configuration 'Cache.WebfingerMemRatio' field
 func SetCacheWebfingerMemRatio(v float64) { global.SetCacheWebfingerMemRatio(v) }
 
+// GetCacheWebPushSubscriptionMemRatio safely fetches the Configuration value for state's 'Cache.WebPushSubscriptionMemRatio' field
+func (st *ConfigState) GetCacheWebPushSubscriptionMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.WebPushSubscriptionMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheWebPushSubscriptionMemRatio safely sets the Configuration value for state's 'Cache.WebPushSubscriptionMemRatio' field
+func (st *ConfigState) SetCacheWebPushSubscriptionMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.WebPushSubscriptionMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheWebPushSubscriptionMemRatioFlag returns the flag name for the 'Cache.WebPushSubscriptionMemRatio' field
+func CacheWebPushSubscriptionMemRatioFlag() string { return "cache-web-push-subscription-mem-ratio" }
+
+// GetCacheWebPushSubscriptionMemRatio safely fetches the value for global configuration 'Cache.WebPushSubscriptionMemRatio' field
+func GetCacheWebPushSubscriptionMemRatio() float64 {
+	return global.GetCacheWebPushSubscriptionMemRatio()
+}
+
+// SetCacheWebPushSubscriptionMemRatio safely sets the value for global configuration 'Cache.WebPushSubscriptionMemRatio' field
+func SetCacheWebPushSubscriptionMemRatio(v float64) { global.SetCacheWebPushSubscriptionMemRatio(v) }
+
 // GetCacheVisibilityMemRatio safely fetches the Configuration value for state's 'Cache.VisibilityMemRatio' field
 func (st *ConfigState) GetCacheVisibilityMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -4206,6 +4622,31 @@ func GetAdminTransPath() string { return global.GetAdminTransPath() }
 // SetAdminTransPath safely sets the value for global configuration 'AdminTransPath' field
 func SetAdminTransPath(v string) { global.SetAdminTransPath(v) }
 
+// GetAdminTransIncludeMedia safely fetches the Configuration value for state's 'AdminTransIncludeMedia' field
+func (st *ConfigState) GetAdminTransIncludeMedia() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AdminTransIncludeMedia
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminTransIncludeMedia safely sets the Configuration value for state's 'AdminTransIncludeMedia' field
+func (st *ConfigState) SetAdminTransIncludeMedia(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminTransIncludeMedia = v
+	st.reloadToViper()
+}
+
+// AdminTransIncludeMediaFlag returns the flag name for the 'AdminTransIncludeMedia' field
+func AdminTransIncludeMediaFlag() string { return "include-media" }
+
+// GetAdminTransIncludeMedia safely fetches the value for global configuration 'AdminTransIncludeMedia' field
+func GetAdminTransIncludeMedia() bool { return global.GetAdminTransIncludeMedia() }
+
+// SetAdminTransIncludeMedia safely sets the value for global configuration 'AdminTransIncludeMedia' field
+func SetAdminTransIncludeMedia(v bool) { global.SetAdminTransIncludeMedia(v) }
+
 // GetAdminMediaPruneDryRun safely fetches the Configuration value for state's 'AdminMediaPruneDryRun' field
 func (st *ConfigState) GetAdminMediaPruneDryRun() (v bool) {
 	st.mutex.RLock()
@@ -84,6 +84,21 @@ func Validate() error {
 		)
 	}
 
+	// `media-transcode-audio-format` should
+	// be "mp3" or "opus" if transcoding.
+	switch audioFmt := GetMediaTranscodeAudioFormat(); audioFmt {
+	case MediaTranscodeAudioFormatMP3, MediaTranscodeAudioFormatOpus:
+		// No problem.
+
+	default:
+		if GetMediaTranscode() {
+			errf(
+				"%s must be set to either mp3 or opus, provided value was %s",
+				MediaTranscodeAudioFormatFlag(), audioFmt,
+			)
+		}
+	}
+
 	// `federation-mode` should be
 	// "blocklist" or "allowlist".
 	switch fediMode := GetInstanceFederationMode(); fediMode {
@@ -79,6 +79,14 @@ type Account interface {
 		error,
 	)
 
+	// GetDirectoryAccounts returns accounts which have opted in
+	// to being discoverable, for showing in the profile directory.
+	// If local is true, only local accounts will be returned. If
+	// newest is true, accounts will be returned newest first, else
+	// accounts which most recently posted a status are returned
+	// first, skipping accounts which have not posted any statuses.
+	GetDirectoryAccounts(ctx context.Context, local bool, newest bool, limit int, offset int) ([]*gtsmodel.Account, error)
+
 	// PopulateAccount ensures that all sub-models of an account are populated (e.g. avatar, header etc).
 	PopulateAccount(ctx context.Context, account *gtsmodel.Account) error
 
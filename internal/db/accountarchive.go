@@ -0,0 +1,47 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// AccountArchive handles getting/creation/deletion/updating of account data archives.
+type AccountArchive interface {
+	// GetAccountArchiveByID gets one account archive by its db id.
+	GetAccountArchiveByID(ctx context.Context, id string) (*gtsmodel.AccountArchive, error)
+
+	// GetAccountArchives gets all account archives, oldest first.
+	GetAccountArchives(ctx context.Context) ([]*gtsmodel.AccountArchive, error)
+
+	// GetAccountArchivesByAccountID gets all archives
+	// belonging to the given account, newest first.
+	GetAccountArchivesByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.AccountArchive, error)
+
+	// PutAccountArchive puts the given account archive in the database.
+	PutAccountArchive(ctx context.Context, archive *gtsmodel.AccountArchive) error
+
+	// UpdateAccountArchive updates one account archive by its db id.
+	// If no columns are given, every column will be updated.
+	UpdateAccountArchive(ctx context.Context, archive *gtsmodel.AccountArchive, columns ...string) error
+
+	// DeleteAccountArchiveByID deletes one account archive by its db id.
+	DeleteAccountArchiveByID(ctx context.Context, id string) error
+}
@@ -0,0 +1,71 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Announcement handles getting/creation/deletion/updating of instance
+// announcements, and of per-account read state and reactions to them.
+type Announcement interface {
+	// GetAnnouncementByID gets one announcement by its db id.
+	GetAnnouncementByID(ctx context.Context, id string) (*gtsmodel.Announcement, error)
+
+	// GetAnnouncements gets all announcements, oldest first. If activeOnly
+	// is true, only published announcements that have not ended are returned.
+	GetAnnouncements(ctx context.Context, activeOnly bool) ([]*gtsmodel.Announcement, error)
+
+	// PopulateAnnouncement ensures that all sub-models of the announcement are populated.
+	PopulateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error
+
+	// PutAnnouncement puts the given announcement in the database.
+	PutAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error
+
+	// UpdateAnnouncement updates one announcement by its db id.
+	// If no columns are given, every column will be updated.
+	UpdateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement, columns ...string) error
+
+	// DeleteAnnouncementByID deletes one announcement by its db id,
+	// along with all read markers and reactions belonging to it.
+	DeleteAnnouncementByID(ctx context.Context, id string) error
+
+	// IsAnnouncementRead returns whether the given account has read (dismissed) the given announcement.
+	IsAnnouncementRead(ctx context.Context, announcementID string, accountID string) (bool, error)
+
+	// PutAnnouncementRead marks an announcement as read by an account.
+	// Marking an already-read announcement as read again is a no-op.
+	PutAnnouncementRead(ctx context.Context, read *gtsmodel.AnnouncementRead) error
+
+	// GetAnnouncementReactions gets all reactions to the given announcement, oldest first.
+	GetAnnouncementReactions(ctx context.Context, announcementID string) ([]*gtsmodel.AnnouncementReaction, error)
+
+	// PutAnnouncementReaction puts the given reaction in the database.
+	// Returns ErrAlreadyExists if the account already reacted with this name.
+	PutAnnouncementReaction(ctx context.Context, reaction *gtsmodel.AnnouncementReaction) error
+
+	// DeleteAnnouncementReaction deletes the reaction with the given name by
+	// the given account to the given announcement. Missing reactions are not an error.
+	DeleteAnnouncementReaction(ctx context.Context, announcementID string, accountID string, name string) error
+
+	// DeleteAnnouncementReadsAndReactionsByAccountID deletes all
+	// read markers and reactions belonging to the given account.
+	DeleteAnnouncementReadsAndReactionsByAccountID(ctx context.Context, accountID string) error
+}
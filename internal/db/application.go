@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 type Application interface {
@@ -30,6 +31,9 @@ type Application interface {
 	// GetApplicationByClientID fetches the application from the database with corresponding client_id value.
 	GetApplicationByClientID(ctx context.Context, clientID string) (*gtsmodel.Application, error)
 
+	// GetApplicationsManagedByUserID fetches a page of applications managed by the given userID.
+	GetApplicationsManagedByUserID(ctx context.Context, userID string, page *paging.Page) ([]*gtsmodel.Application, error)
+
 	// PutApplication places the new application in the database, erroring on non-unique ID or client_id.
 	PutApplication(ctx context.Context, app *gtsmodel.Application) error
 
@@ -48,6 +52,9 @@ type Application interface {
 	// GetAllTokens ...
 	GetAllTokens(ctx context.Context) ([]*gtsmodel.Token, error)
 
+	// GetTokenByID ...
+	GetTokenByID(ctx context.Context, id string) (*gtsmodel.Token, error)
+
 	// GetTokenByCode ...
 	GetTokenByCode(ctx context.Context, code string) (*gtsmodel.Token, error)
 
@@ -57,9 +64,15 @@ type Application interface {
 	// GetTokenByRefresh ...
 	GetTokenByRefresh(ctx context.Context, refresh string) (*gtsmodel.Token, error)
 
+	// GetAccessTokens fetches a page of access tokens owned by the given userID.
+	GetAccessTokens(ctx context.Context, userID string, page *paging.Page) ([]*gtsmodel.Token, error)
+
 	// PutToken ...
 	PutToken(ctx context.Context, token *gtsmodel.Token) error
 
+	// UpdateToken updates the given token. Updates all columns if none are specified.
+	UpdateToken(ctx context.Context, token *gtsmodel.Token, columns ...string) error
+
 	// DeleteTokenByID ...
 	DeleteTokenByID(ctx context.Context, id string) error
 
@@ -71,4 +84,7 @@ type Application interface {
 
 	// DeleteTokenByRefresh ...
 	DeleteTokenByRefresh(ctx context.Context, refresh string) error
+
+	// DeleteTokensByClientID deletes all tokens with the given client_id.
+	DeleteTokensByClientID(ctx context.Context, clientID string) error
 }
@@ -53,6 +53,13 @@ type Basic interface {
 	// In case of no entries, a 'no entries' error will be returned
 	GetAll(ctx context.Context, i interface{}) error
 
+	// GetAllPage is like GetAll, but gets at most limit entries of type i, ordered by
+	// primary key (or unique key, for types without one). If after is set, only entries
+	// with a key greater than that of after are returned, so passing the last entry of
+	// one page gets the next, without the db having to skip over all previous entries.
+	// The given interface i should be a pointer to a slice.
+	GetAllPage(ctx context.Context, i interface{}, after interface{}, limit int) error
+
 	// Put simply stores i. It is up to the implementation to figure out how to store it, and using what key.
 	// The given interface i will be set to the result of the query, whatever it is. Use a pointer or a slice.
 	Put(ctx context.Context, i interface{}) error
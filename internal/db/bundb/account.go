@@ -737,8 +737,12 @@ func (a *accountDB) PutAccount(ctx context.Context, account *gtsmodel.Account) e
 			}
 
 			// insert the account
-			_, err := tx.NewInsert().Model(account).Exec(ctx)
-			return err
+			if _, err := tx.NewInsert().Model(account).Exec(ctx); err != nil {
+				return err
+			}
+
+			// add the account to the search index
+			return indexAccount(ctx, tx, account)
 		})
 	})
 }
@@ -778,12 +782,23 @@ func (a *accountDB) UpdateAccount(ctx context.Context, account *gtsmodel.Account
 			}
 
 			// update the account
-			_, err := tx.NewUpdate().
+			if _, err := tx.NewUpdate().
 				Model(account).
 				Where("? = ?", bun.Ident("account.id"), account.ID).
 				Column(columns...).
-				Exec(ctx)
-			return err
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			if !searchIndexColumnsChanged(columns,
+				"username", "display_name", "note",
+			) {
+				// indexed text unchanged
+				return nil
+			}
+
+			// update the account in the search index
+			return indexAccount(ctx, tx, account)
 		})
 	})
 }
@@ -806,6 +821,11 @@ func (a *accountDB) DeleteAccount(ctx context.Context, id string) error {
 			return err
 		}
 
+		// remove the account from the search index
+		if err := unindexAccount(ctx, tx, id); err != nil {
+			return err
+		}
+
 		// delete the account
 		_, err := tx.
 			NewDelete().
@@ -845,6 +865,56 @@ func (a *accountDB) GetAccountCustomCSSByUsername(ctx context.Context, username
 	return account.Settings.CustomCSS, nil
 }
 
+func (a *accountDB) GetDirectoryAccounts(
+	ctx context.Context,
+	local bool,
+	newest bool,
+	limit int,
+	offset int,
+) ([]*gtsmodel.Account, error) {
+	accountIDs := make([]string, 0, limit)
+
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Column("account.id").
+		Where("? = ?", bun.Ident("account.discoverable"), true).
+		Where("? IS NULL", bun.Ident("account.suspended_at")).
+		Where("? IS NULL", bun.Ident("account.moved_to_uri"))
+
+	if local {
+		// Only local accounts.
+		q = q.Where("? IS NULL", bun.Ident("account.domain"))
+	}
+
+	if newest {
+		// Newest accounts first.
+		q = q.Order("account.created_at DESC")
+	} else {
+		// Most recently active accounts first,
+		// according to their last status time.
+		q = q.
+			Join(
+				"JOIN ? AS ? ON ? = ?",
+				bun.Ident("account_stats"), bun.Ident("stats"),
+				bun.Ident("stats.account_id"), bun.Ident("account.id"),
+			).
+			Where("? IS NOT NULL", bun.Ident("stats.last_status_at")).
+			Order("stats.last_status_at DESC")
+	}
+
+	if err := q.
+		Order("account.id DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	// Convert account IDs into account objects.
+	return a.GetAccountsByIDs(ctx, accountIDs)
+}
+
 func (a *accountDB) GetAccountsUsingEmoji(ctx context.Context, emojiID string) ([]*gtsmodel.Account, error) {
 	var accountIDs []string
 
@@ -913,27 +983,8 @@ func (a *accountDB) GetAccountStatuses(ctx context.Context, accountID string, li
 	}
 
 	if mediaOnly {
-		// Attachments are stored as a json object; this
-		// implementation differs between SQLite and Postgres,
-		// so we have to be thorough to cover all eventualities
-		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
-			switch a.db.Dialect().Name() {
-			case dialect.PG:
-				return q.
-					Where("? IS NOT NULL", bun.Ident("status.attachments")).
-					Where("? != '{}'", bun.Ident("status.attachments"))
-			case dialect.SQLite:
-				return q.
-					Where("? IS NOT NULL", bun.Ident("status.attachments")).
-					Where("? != ''", bun.Ident("status.attachments")).
-					Where("? != 'null'", bun.Ident("status.attachments")).
-					Where("? != '{}'", bun.Ident("status.attachments")).
-					Where("? != '[]'", bun.Ident("status.attachments"))
-			default:
-				log.Panic(ctx, "db dialect was neither pg nor sqlite")
-				return q
-			}
-		})
+		// Select only statuses with attachments.
+		q = whereHasAttachments(q)
 	}
 
 	if publicOnly {
@@ -768,6 +768,63 @@ func (suite *AccountTestSuite) TestAccountStatsAll() {
 	}
 }
 
+func (suite *AccountTestSuite) TestGetDirectoryAccountsNewest() {
+	accounts, err := suite.db.GetDirectoryAccounts(context.Background(), false, true, 20, 0)
+	suite.NoError(err)
+	suite.NotEmpty(accounts)
+
+	var prev *gtsmodel.Account
+	for _, account := range accounts {
+		// Only discoverable accounts should be returned.
+		suite.True(*account.Discoverable)
+		suite.True(account.SuspendedAt.IsZero())
+
+		// Newest accounts should be first.
+		if prev != nil {
+			suite.False(account.CreatedAt.After(prev.CreatedAt))
+		}
+		prev = account
+	}
+
+	// local_account_2 isn't discoverable.
+	for _, account := range accounts {
+		suite.NotEqual(suite.testAccounts["local_account_2"].ID, account.ID)
+	}
+}
+
+func (suite *AccountTestSuite) TestGetDirectoryAccountsLocalActive() {
+	ctx := context.Background()
+
+	// Ensure stats are populated for
+	// all accounts, so we know when
+	// they last posted a status.
+	for _, account := range suite.testAccounts {
+		err := suite.db.RegenerateAccountStats(ctx, account)
+		suite.NoError(err)
+	}
+
+	accounts, err := suite.db.GetDirectoryAccounts(ctx, true, false, 20, 0)
+	suite.NoError(err)
+
+	accountIDs := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		accountIDs = append(accountIDs, account.ID)
+	}
+
+	// The instance account is discoverable but has never posted,
+	// so only these local accounts should be shown, with the one
+	// which posted most recently first.
+	suite.Equal([]string{
+		suite.testAccounts["local_account_1"].ID,
+		suite.testAccounts["admin_account"].ID,
+	}, accountIDs)
+
+	// Paging past the end should return nothing.
+	accounts, err = suite.db.GetDirectoryAccounts(ctx, true, false, 20, 2)
+	suite.NoError(err)
+	suite.Empty(accounts)
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
@@ -0,0 +1,246 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type announcementDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (a *announcementDB) GetAnnouncementByID(ctx context.Context, id string) (*gtsmodel.Announcement, error) {
+	var announcement gtsmodel.Announcement
+
+	if err := a.db.
+		NewSelect().
+		Model(&announcement).
+		Where("? = ?", bun.Ident("announcement.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := a.PopulateAnnouncement(ctx, &announcement); err != nil {
+		return nil, err
+	}
+
+	return &announcement, nil
+}
+
+func (a *announcementDB) GetAnnouncements(ctx context.Context, activeOnly bool) ([]*gtsmodel.Announcement, error) {
+	announcements := make([]*gtsmodel.Announcement, 0)
+
+	q := a.db.
+		NewSelect().
+		Model(&announcements).
+		Order("announcement.id ASC")
+
+	if activeOnly {
+		q = q.
+			Where("? = ?", bun.Ident("announcement.published"), true).
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("? IS NULL", bun.Ident("announcement.ends_at")).
+					WhereOr("? > ?", bun.Ident("announcement.ends_at"), time.Now())
+			})
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, announcement := range announcements {
+		if err := a.PopulateAnnouncement(ctx, announcement); err != nil {
+			return nil, err
+		}
+	}
+
+	return announcements, nil
+}
+
+func (a *announcementDB) PopulateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error {
+	if announcement.EmojisPopulated() {
+		return nil
+	}
+
+	// Announcement emojis are out-of-date with IDs, repopulate.
+	emojis, err := a.state.DB.GetEmojisByIDs(ctx, announcement.EmojiIDs)
+	if err != nil {
+		return gtserror.Newf("error populating announcement emojis: %w", err)
+	}
+	announcement.Emojis = emojis
+
+	return nil
+}
+
+func (a *announcementDB) PutAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error {
+	_, err := a.db.
+		NewInsert().
+		Model(announcement).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) UpdateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement, columns ...string) error {
+	announcement.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := a.db.
+		NewUpdate().
+		Model(announcement).
+		Column(columns...).
+		Where("? = ?", bun.Ident("announcement.id"), announcement.ID).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) DeleteAnnouncementByID(ctx context.Context, id string) error {
+	return a.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Delete reactions to the announcement.
+		if _, err := tx.
+			NewDelete().
+			Table("announcement_reactions").
+			Where("? = ?", bun.Ident("announcement_id"), id).
+			Exec(ctx); err != nil {
+			return gtserror.Newf("error deleting announcement reactions: %w", err)
+		}
+
+		// Delete read markers for the announcement.
+		if _, err := tx.
+			NewDelete().
+			Table("announcement_reads").
+			Where("? = ?", bun.Ident("announcement_id"), id).
+			Exec(ctx); err != nil {
+			return gtserror.Newf("error deleting announcement reads: %w", err)
+		}
+
+		// Finally delete the announcement itself.
+		if _, err := tx.
+			NewDelete().
+			Table("announcements").
+			Where("? = ?", bun.Ident("id"), id).
+			Exec(ctx); err != nil {
+			return gtserror.Newf("error deleting announcement: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (a *announcementDB) IsAnnouncementRead(ctx context.Context, announcementID string, accountID string) (bool, error) {
+	return exists(ctx, a.db.
+		NewSelect().
+		Table("announcement_reads").
+		Column("id").
+		Where("? = ?", bun.Ident("announcement_id"), announcementID).
+		Where("? = ?", bun.Ident("account_id"), accountID),
+	)
+}
+
+func (a *announcementDB) PutAnnouncementRead(ctx context.Context, read *gtsmodel.AnnouncementRead) error {
+	_, err := a.db.
+		NewInsert().
+		Model(read).
+		On("CONFLICT (?, ?) DO NOTHING", bun.Ident("announcement_id"), bun.Ident("account_id")).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) GetAnnouncementReactions(ctx context.Context, announcementID string) ([]*gtsmodel.AnnouncementReaction, error) {
+	reactions := make([]*gtsmodel.AnnouncementReaction, 0)
+
+	if err := a.db.
+		NewSelect().
+		Model(&reactions).
+		Where("? = ?", bun.Ident("announcement_reaction.announcement_id"), announcementID).
+		Order("announcement_reaction.id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, reaction := range reactions {
+		if reaction.EmojiID == "" {
+			// Unicode emoji,
+			// nothing to do.
+			continue
+		}
+
+		emoji, err := a.state.DB.GetEmojiByID(ctx, reaction.EmojiID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("error populating reaction emoji: %w", err)
+		}
+		reaction.Emoji = emoji
+	}
+
+	return reactions, nil
+}
+
+func (a *announcementDB) PutAnnouncementReaction(ctx context.Context, reaction *gtsmodel.AnnouncementReaction) error {
+	_, err := a.db.
+		NewInsert().
+		Model(reaction).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) DeleteAnnouncementReaction(ctx context.Context, announcementID string, accountID string, name string) error {
+	_, err := a.db.
+		NewDelete().
+		Table("announcement_reactions").
+		Where("? = ?", bun.Ident("announcement_id"), announcementID).
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Where("? = ?", bun.Ident("name"), name).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) DeleteAnnouncementReadsAndReactionsByAccountID(ctx context.Context, accountID string) error {
+	return a.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.
+			NewDelete().
+			Table("announcement_reactions").
+			Where("? = ?", bun.Ident("account_id"), accountID).
+			Exec(ctx); err != nil {
+			return gtserror.Newf("error deleting announcement reactions: %w", err)
+		}
+
+		if _, err := tx.
+			NewDelete().
+			Table("announcement_reads").
+			Where("? = ?", bun.Ident("account_id"), accountID).
+			Exec(ctx); err != nil {
+			return gtserror.Newf("error deleting announcement reads: %w", err)
+		}
+
+		return nil
+	})
+}
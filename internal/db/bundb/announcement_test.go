@@ -0,0 +1,181 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type AnnouncementTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *AnnouncementTestSuite) TestGetAnnouncementByID() {
+	announcement, err := suite.db.GetAnnouncementByID(context.Background(), "01JAD2W8X2A9ZG3C5NQF1S8V6P")
+	suite.NoError(err)
+	suite.Equal("<p>Welcome to the instance! :rainbow:</p>", announcement.Content)
+	suite.True(announcement.IsPublished())
+	suite.Len(announcement.Emojis, 1)
+	suite.Equal("rainbow", announcement.Emojis[0].Shortcode)
+}
+
+func (suite *AnnouncementTestSuite) TestGetAnnouncements() {
+	ctx := context.Background()
+
+	all, err := suite.db.GetAnnouncements(ctx, false)
+	suite.NoError(err)
+	suite.Len(all, 3)
+
+	// Only the published announcement that
+	// has not yet ended should be active.
+	active, err := suite.db.GetAnnouncements(ctx, true)
+	suite.NoError(err)
+	suite.Len(active, 1)
+	suite.Equal("01JAD2W8X2A9ZG3C5NQF1S8V6P", active[0].ID)
+}
+
+func (suite *AnnouncementTestSuite) TestUpdateAnnouncementEndsAt() {
+	ctx := context.Background()
+
+	announcement, err := suite.db.GetAnnouncementByID(ctx, "01JAD2W8X2A9ZG3C5NQF1S8V6P")
+	suite.NoError(err)
+
+	announcement.EndsAt = time.Now().Add(-time.Minute)
+	err = suite.db.UpdateAnnouncement(ctx, announcement, "ends_at")
+	suite.NoError(err)
+
+	active, err := suite.db.GetAnnouncements(ctx, true)
+	suite.NoError(err)
+	suite.Empty(active)
+}
+
+func (suite *AnnouncementTestSuite) TestAnnouncementRead() {
+	ctx := context.Background()
+	announcementID := "01JAD2W8X2A9ZG3C5NQF1S8V6P"
+	accountID := suite.testAccounts["local_account_1"].ID
+
+	read, err := suite.db.IsAnnouncementRead(ctx, announcementID, accountID)
+	suite.NoError(err)
+	suite.False(read)
+
+	// Marking as read twice should not error.
+	for i := 0; i < 2; i++ {
+		err = suite.db.PutAnnouncementRead(ctx, &gtsmodel.AnnouncementRead{
+			ID:             id.NewULID(),
+			AnnouncementID: announcementID,
+			AccountID:      accountID,
+		})
+		suite.NoError(err)
+	}
+
+	read, err = suite.db.IsAnnouncementRead(ctx, announcementID, accountID)
+	suite.NoError(err)
+	suite.True(read)
+}
+
+func (suite *AnnouncementTestSuite) TestAnnouncementReactions() {
+	ctx := context.Background()
+	announcementID := "01JAD2W8X2A9ZG3C5NQF1S8V6P"
+	accountID := suite.testAccounts["local_account_1"].ID
+
+	reactions, err := suite.db.GetAnnouncementReactions(ctx, announcementID)
+	suite.NoError(err)
+	suite.Len(reactions, 3)
+	suite.Nil(reactions[0].Emoji)
+	suite.Equal("rainbow", reactions[2].Emoji.Shortcode)
+
+	reaction := &gtsmodel.AnnouncementReaction{
+		ID:             id.NewULID(),
+		AnnouncementID: announcementID,
+		AccountID:      accountID,
+		Name:           "👍",
+	}
+	err = suite.db.PutAnnouncementReaction(ctx, reaction)
+	suite.NoError(err)
+
+	// Same name by same account is a duplicate.
+	reaction.ID = id.NewULID()
+	err = suite.db.PutAnnouncementReaction(ctx, reaction)
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	reactions, err = suite.db.GetAnnouncementReactions(ctx, announcementID)
+	suite.NoError(err)
+	suite.Len(reactions, 4)
+
+	err = suite.db.DeleteAnnouncementReaction(ctx, announcementID, accountID, "👍")
+	suite.NoError(err)
+
+	reactions, err = suite.db.GetAnnouncementReactions(ctx, announcementID)
+	suite.NoError(err)
+	suite.Len(reactions, 3)
+}
+
+func (suite *AnnouncementTestSuite) TestDeleteAnnouncementByID() {
+	ctx := context.Background()
+	announcementID := "01JAD2W8X2A9ZG3C5NQF1S8V6P"
+
+	err := suite.db.DeleteAnnouncementByID(ctx, announcementID)
+	suite.NoError(err)
+
+	_, err = suite.db.GetAnnouncementByID(ctx, announcementID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Reactions and reads should be gone too.
+	reactions, err := suite.db.GetAnnouncementReactions(ctx, announcementID)
+	suite.NoError(err)
+	suite.Empty(reactions)
+
+	read, err := suite.db.IsAnnouncementRead(ctx, announcementID, suite.testAccounts["local_account_2"].ID)
+	suite.NoError(err)
+	suite.False(read)
+}
+
+func (suite *AnnouncementTestSuite) TestPutAnnouncementDefaults() {
+	ctx := context.Background()
+
+	announcement := &gtsmodel.Announcement{
+		ID:        id.NewULID(),
+		AccountID: suite.testAccounts["admin_account"].ID,
+		Text:      "hello",
+		Content:   "<p>hello</p>",
+		AllDay:    util.Ptr(false),
+		Published: util.Ptr(false),
+	}
+	err := suite.db.PutAnnouncement(ctx, announcement)
+	suite.NoError(err)
+
+	dbAnnouncement, err := suite.db.GetAnnouncementByID(ctx, announcement.ID)
+	suite.NoError(err)
+	suite.False(dbAnnouncement.IsPublished())
+	suite.True(dbAnnouncement.PublishedAt.IsZero())
+	suite.True(dbAnnouncement.StartsAt.IsZero())
+	suite.True(dbAnnouncement.EndsAt.IsZero())
+	suite.Empty(dbAnnouncement.Emojis)
+}
+
+func TestAnnouncementTestSuite(t *testing.T) {
+	suite.Run(t, new(AnnouncementTestSuite))
+}
@@ -19,8 +19,13 @@ package bundb
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
@@ -66,6 +71,59 @@ func (a *applicationDB) getApplication(ctx context.Context, lookup string, dbQue
 	}, keyParts...)
 }
 
+func (a *applicationDB) GetApplicationsManagedByUserID(
+	ctx context.Context,
+	userID string,
+	page *paging.Page,
+) ([]*gtsmodel.Application, error) {
+	// Select IDs of applications
+	// managed by this user.
+	appIDs, err := pagedIDs(ctx, a.db.
+		NewSelect().
+		Table("applications").
+		Where("? = ?", bun.Ident("managed_by_user_id"), userID),
+		page,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Catch case of no items early.
+	if len(appIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// Load all input application IDs via cache loader callback.
+	apps, err := a.state.Caches.DB.Application.LoadIDs("ID",
+		appIDs,
+		func(uncached []string) ([]*gtsmodel.Application, error) {
+			// Preallocate expected length of uncached apps.
+			apps := make([]*gtsmodel.Application, 0, len(uncached))
+
+			// Perform database query scanning
+			// the remaining (uncached) app IDs.
+			if err := a.db.NewSelect().
+				Model(&apps).
+				Where("? IN (?)", bun.Ident("id"), bun.In(uncached)).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return apps, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reorder the apps by their
+	// IDs to ensure in correct order.
+	getID := func(app *gtsmodel.Application) string { return app.ID }
+	util.OrderBy(apps, appIDs, getID)
+
+	return apps, nil
+}
+
 func (a *applicationDB) PutApplication(ctx context.Context, app *gtsmodel.Application) error {
 	return a.state.Caches.DB.Application.Store(app, func() error {
 		_, err := a.db.NewInsert().Model(app).Exec(ctx)
@@ -84,11 +142,12 @@ func (a *applicationDB) DeleteApplicationByClientID(ctx context.Context, clientI
 
 	// NOTE about further side effects:
 	//
-	// We don't need to handle updating any statuses or users
-	// (both of which may contain refs to applications), as
-	// DeleteApplication__() is only ever called during an
-	// account deletion, which handles deletion of the user
-	// and all their statuses already.
+	// We don't update any statuses which may contain refs to
+	// this application: DeleteApplication__() is called either
+	// during an account deletion, which handles deletion of the
+	// user and all their statuses already, or when the app's
+	// creator deletes it, in which case statuses created with
+	// the app are simply populated without an application.
 	//
 
 	// Clear application from the cache.
@@ -174,6 +233,16 @@ func (a *applicationDB) GetAllTokens(ctx context.Context) ([]*gtsmodel.Token, er
 	return tokens, nil
 }
 
+func (a *applicationDB) GetTokenByID(ctx context.Context, id string) (*gtsmodel.Token, error) {
+	return a.getTokenBy(
+		"ID",
+		func(t *gtsmodel.Token) error {
+			return a.db.NewSelect().Model(t).Where("? = ?", bun.Ident("id"), id).Scan(ctx)
+		},
+		id,
+	)
+}
+
 func (a *applicationDB) GetTokenByCode(ctx context.Context, code string) (*gtsmodel.Token, error) {
 	return a.getTokenBy(
 		"Code",
@@ -204,6 +273,61 @@ func (a *applicationDB) GetTokenByRefresh(ctx context.Context, refresh string) (
 	)
 }
 
+func (a *applicationDB) GetAccessTokens(
+	ctx context.Context,
+	userID string,
+	page *paging.Page,
+) ([]*gtsmodel.Token, error) {
+	// Select IDs of tokens owned by this
+	// user that have an access token set,
+	// ie., not just an authorization code.
+	tokenIDs, err := pagedIDs(ctx, a.db.
+		NewSelect().
+		Table("tokens").
+		Where("? = ?", bun.Ident("user_id"), userID).
+		Where("? != ''", bun.Ident("access")),
+		page,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Catch case of no items early.
+	if len(tokenIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// Load all input token IDs via cache loader callback.
+	tokens, err := a.state.Caches.DB.Token.LoadIDs("ID",
+		tokenIDs,
+		func(uncached []string) ([]*gtsmodel.Token, error) {
+			// Preallocate expected length of uncached tokens.
+			tokens := make([]*gtsmodel.Token, 0, len(uncached))
+
+			// Perform database query scanning
+			// the remaining (uncached) token IDs.
+			if err := a.db.NewSelect().
+				Model(&tokens).
+				Where("? IN (?)", bun.Ident("id"), bun.In(uncached)).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return tokens, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reorder the tokens by their
+	// IDs to ensure in correct order.
+	getID := func(t *gtsmodel.Token) string { return t.ID }
+	util.OrderBy(tokens, tokenIDs, getID)
+
+	return tokens, nil
+}
+
 func (a *applicationDB) getTokenBy(lookup string, dbQuery func(*gtsmodel.Token) error, keyParts ...any) (*gtsmodel.Token, error) {
 	return a.state.Caches.DB.Token.LoadOne(lookup, func() (*gtsmodel.Token, error) {
 		var token gtsmodel.Token
@@ -223,6 +347,26 @@ func (a *applicationDB) PutToken(ctx context.Context, token *gtsmodel.Token) err
 	})
 }
 
+func (a *applicationDB) UpdateToken(ctx context.Context, token *gtsmodel.Token, columns ...string) error {
+	// Update the token's last-updated
+	token.UpdatedAt = time.Now()
+
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included
+		columns = append(columns, "updated_at")
+	}
+
+	return a.state.Caches.DB.Token.Store(token, func() error {
+		_, err := a.db.
+			NewUpdate().
+			Model(token).
+			Where("? = ?", bun.Ident("token.id"), token.ID).
+			Column(columns...).
+			Exec(ctx)
+		return err
+	})
+}
+
 func (a *applicationDB) DeleteTokenByID(ctx context.Context, id string) error {
 	_, err := a.db.NewDelete().
 		Table("tokens").
@@ -274,3 +418,80 @@ func (a *applicationDB) DeleteTokenByRefresh(ctx context.Context, refresh string
 	a.state.Caches.DB.Token.Invalidate("Refresh", refresh)
 	return nil
 }
+
+func (a *applicationDB) DeleteTokensByClientID(ctx context.Context, clientID string) error {
+	// Delete tokens owned by
+	// clientID, returning IDs.
+	var tokenIDs []string
+	if err := a.db.NewDelete().
+		Model((*gtsmodel.Token)(nil)).
+		Where("? = ?", bun.Ident("client_id"), clientID).
+		Returning("?", bun.Ident("id")).
+		Scan(ctx, &tokenIDs); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Invalidate all deleted tokens from the cache.
+	for _, id := range tokenIDs {
+		a.state.Caches.DB.Token.Invalidate("ID", id)
+	}
+
+	return nil
+}
+
+// pagedIDs executes the given select query for IDs of
+// items in one table, after adding the given paging
+// params to the query. The returned IDs will always
+// be in descending order, regardless of paging order.
+func pagedIDs(ctx context.Context, q *bun.SelectQuery, page *paging.Page) ([]string, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		ids = make([]string, 0, limit)
+	)
+
+	q = q.Column("id")
+
+	// Add paging param max ID.
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("id"), maxID)
+	}
+
+	// Add paging param min ID.
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("id"), minID)
+	}
+
+	// Add paging param order.
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("id"))
+	}
+
+	// Add paging param limit.
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	// Execute the query and scan into IDs.
+	if err := q.Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want items
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(ids)
+	}
+
+	return ids, nil
+}
@@ -20,8 +20,12 @@ package bundb
 import (
 	"context"
 	"errors"
+	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/uptrace/bun"
 )
@@ -67,6 +71,67 @@ func (b *basicDB) GetAll(ctx context.Context, i interface{}) error {
 	return err
 }
 
+func (b *basicDB) GetAllPage(ctx context.Context, i interface{}, after interface{}, limit int) error {
+	q := b.db.
+		NewSelect().
+		Model(i).
+		Limit(limit)
+
+	tm, ok := q.GetModel().(bun.TableModel)
+	if !ok {
+		return gtserror.Newf("%T is not a table model", i)
+	}
+
+	// Page by primary key(s) if the
+	// table has any, else by unique key.
+	table := tm.Table()
+	fields := table.PKs
+	if len(fields) == 0 {
+		// Pick the first unique key
+		// by name, for a stable order.
+		names := make([]string, 0, len(table.Unique))
+		for name := range table.Unique {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		if len(names) > 0 {
+			fields = table.Unique[names[0]]
+		}
+	}
+
+	if len(fields) == 0 {
+		return gtserror.Newf("%s has no primary or unique key to page by", table.Name)
+	}
+
+	for _, field := range fields {
+		q = q.OrderExpr("? ASC", bun.Ident(field.Name))
+	}
+
+	if after != nil {
+		// Select only entries with a key
+		// greater than that of after, using
+		// a row value comparison for keys
+		// made up of more than one column.
+		strct := reflect.Indirect(reflect.ValueOf(after))
+		cols := make([]string, len(fields))
+		args := make([]any, 0, 2*len(fields))
+		for i, field := range fields {
+			cols[i] = "?"
+			args = append(args, bun.Ident(field.Name))
+		}
+		for _, field := range fields {
+			args = append(args, field.Value(strct).Interface())
+		}
+
+		row := "(" + strings.Join(cols, ", ") + ")"
+		q = q.Where(row+" > "+row, args...)
+	}
+
+	err := q.Scan(ctx)
+	return err
+}
+
 func (b *basicDB) DeleteByID(ctx context.Context, id string, i interface{}) error {
 	q := b.db.
 		NewDelete().
@@ -21,6 +21,7 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"slices"
 	"testing"
 	"time"
 
@@ -117,6 +118,85 @@ func (suite *BasicTestSuite) TestGetAllStatuses() {
 	suite.Len(s, 25)
 }
 
+func (suite *BasicTestSuite) TestGetAllPage() {
+	ctx := context.Background()
+
+	// Page through a table keyed by
+	// primary key, and one without.
+	suite.testGetAllPage(ctx, func(after any) ([]any, error) {
+		var page []*gtsmodel.Status
+		err := suite.db.GetAllPage(ctx, &page, after, 5)
+		return toAny(page), err
+	}, func() (int, error) {
+		var all []*gtsmodel.Status
+		err := suite.db.GetAll(ctx, &all)
+		return len(all), err
+	}, func(entry any) string {
+		return entry.(*gtsmodel.Status).ID
+	})
+
+	suite.testGetAllPage(ctx, func(after any) ([]any, error) {
+		var page []*gtsmodel.StatusToTag
+		err := suite.db.GetAllPage(ctx, &page, after, 1)
+		return toAny(page), err
+	}, func() (int, error) {
+		var all []*gtsmodel.StatusToTag
+		err := suite.db.GetAll(ctx, &all)
+		return len(all), err
+	}, func(entry any) string {
+		stt := entry.(*gtsmodel.StatusToTag)
+		return stt.StatusID + stt.TagID
+	})
+}
+
+func (suite *BasicTestSuite) testGetAllPage(
+	ctx context.Context,
+	getPage func(after any) ([]any, error),
+	count func() (int, error),
+	key func(entry any) string,
+) {
+	var (
+		after any
+		keys  []string
+	)
+
+	for {
+		page, err := getPage(after)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		if len(page) == 0 {
+			break
+		}
+
+		for _, entry := range page {
+			keys = append(keys, key(entry))
+		}
+		after = page[len(page)-1]
+	}
+
+	total, err := count()
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Every entry should have been returned
+	// exactly once, in ascending key order.
+	suite.NotZero(total)
+	suite.Len(keys, total)
+	suite.True(slices.IsSorted(keys))
+	suite.Len(slices.Compact(slices.Clone(keys)), total)
+}
+
+func toAny[T any](in []T) []any {
+	out := make([]any, len(in))
+	for i, v := range in {
+		out[i] = v
+	}
+	return out
+}
+
 func (suite *BasicTestSuite) TestGetAllNotNull() {
 	where := []db.Where{{
 		Key:   "domain",
@@ -53,10 +53,13 @@ import (
 // DBService satisfies the DB interface
 type DBService struct {
 	db.Account
+	db.AccountArchive
 	db.Admin
 	db.AdvancedMigration
+	db.Announcement
 	db.Application
 	db.Basic
+	db.ContentLimit
 	db.Conversation
 	db.Domain
 	db.Emoji
@@ -72,19 +75,26 @@ type DBService struct {
 	db.Notification
 	db.Poll
 	db.Relationship
+	db.Relay
 	db.Report
 	db.Rule
+	db.ScheduledStatus
 	db.Search
 	db.Session
 	db.SinBinStatus
 	db.Status
 	db.StatusBookmark
+	db.StatusEdit
 	db.StatusFave
+	db.StatusReaction
+	db.Suggestion
 	db.Tag
 	db.Thread
 	db.Timeline
+	db.Trend
 	db.User
 	db.Tombstone
+	db.WebPush
 	db.WorkerTask
 	db *bun.DB
 }
@@ -182,6 +192,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		AccountArchive: &accountArchiveDB{
+			db:    db,
+			state: state,
+		},
 		Admin: &adminDB{
 			db:    db,
 			state: state,
@@ -190,6 +204,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Announcement: &announcementDB{
+			db:    db,
+			state: state,
+		},
 		Application: &applicationDB{
 			db:    db,
 			state: state,
@@ -197,6 +215,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 		Basic: &basicDB{
 			db: db,
 		},
+		ContentLimit: &contentLimitDB{
+			db:    db,
+			state: state,
+		},
 		Conversation: &conversationDB{
 			db:    db,
 			state: state,
@@ -257,6 +279,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Relay: &relayDB{
+			db:    db,
+			state: state,
+		},
 		Report: &reportDB{
 			db:    db,
 			state: state,
@@ -265,6 +291,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		ScheduledStatus: &scheduledStatusDB{
+			db:    db,
+			state: state,
+		},
 		Search: &searchDB{
 			db:    db,
 			state: state,
@@ -284,10 +314,22 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		StatusEdit: &statusEditDB{
+			db:    db,
+			state: state,
+		},
 		StatusFave: &statusFaveDB{
 			db:    db,
 			state: state,
 		},
+		StatusReaction: &statusReactionDB{
+			db:    db,
+			state: state,
+		},
+		Suggestion: &suggestionDB{
+			db:    db,
+			state: state,
+		},
 		Tag: &tagDB{
 			db:    db,
 			state: state,
@@ -300,6 +342,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Trend: &trendDB{
+			db:    db,
+			state: state,
+		},
 		User: &userDB{
 			db:    db,
 			state: state,
@@ -308,6 +354,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		WebPush: &webPushDB{
+			db:    db,
+			state: state,
+		},
 		WorkerTask: &workerTaskDB{
 			db: db,
 		},
@@ -57,6 +57,8 @@ type BunDBStandardTestSuite struct {
 	testPolls               map[string]*gtsmodel.Poll
 	testPollVotes           map[string]*gtsmodel.PollVote
 	testInteractionRequests map[string]*gtsmodel.InteractionRequest
+	testRelays              map[string]*gtsmodel.Relay
+	testTrends              map[string]*gtsmodel.Trend
 }
 
 func (suite *BunDBStandardTestSuite) SetupSuite() {
@@ -83,6 +85,8 @@ func (suite *BunDBStandardTestSuite) SetupSuite() {
 	suite.testPolls = testrig.NewTestPolls()
 	suite.testPollVotes = testrig.NewTestPollVotes()
 	suite.testInteractionRequests = testrig.NewTestInteractionRequests()
+	suite.testRelays = testrig.NewTestRelays()
+	suite.testTrends = testrig.NewTestTrends()
 }
 
 func (suite *BunDBStandardTestSuite) SetupTest() {
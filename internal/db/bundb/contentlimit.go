@@ -0,0 +1,261 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+type contentLimitDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (c *contentLimitDB) GetContentLimitByID(ctx context.Context, id string) (*gtsmodel.ContentLimit, error) {
+	return c.getContentLimit(
+		ctx,
+		"ID",
+		func(limit *gtsmodel.ContentLimit) error {
+			return c.db.
+				NewSelect().
+				Model(limit).
+				Where("? = ?", bun.Ident("id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (c *contentLimitDB) GetContentLimitByAccountID(ctx context.Context, accountID string) (*gtsmodel.ContentLimit, error) {
+	return c.getContentLimit(
+		ctx,
+		"AccountID",
+		func(limit *gtsmodel.ContentLimit) error {
+			return c.db.
+				NewSelect().
+				Model(limit).
+				Where("? = ?", bun.Ident("account_id"), accountID).
+				Scan(ctx)
+		},
+		accountID,
+	)
+}
+
+func (c *contentLimitDB) GetContentLimitByDomain(ctx context.Context, domain string) (*gtsmodel.ContentLimit, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getContentLimit(
+		ctx,
+		"Domain",
+		func(limit *gtsmodel.ContentLimit) error {
+			return c.db.
+				NewSelect().
+				Model(limit).
+				Where("? = ?", bun.Ident("domain"), domain).
+				Scan(ctx)
+		},
+		domain,
+	)
+}
+
+func (c *contentLimitDB) getContentLimit(ctx context.Context, lookup string, dbQuery func(*gtsmodel.ContentLimit) error, keyParts ...any) (*gtsmodel.ContentLimit, error) {
+	// Fetch content limit from database cache with loader callback
+	limit, err := c.state.Caches.DB.ContentLimit.LoadOne(lookup, func() (*gtsmodel.ContentLimit, error) {
+		var limit gtsmodel.ContentLimit
+
+		// Not cached! Perform database query.
+		if err := dbQuery(&limit); err != nil {
+			return nil, err
+		}
+
+		return &limit, nil
+	}, keyParts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return limit, nil
+	}
+
+	// Populate the content limit model.
+	if err := c.PopulateContentLimit(ctx, limit); err != nil {
+		return nil, gtserror.Newf("error(s) populating content limit: %w", err)
+	}
+
+	return limit, nil
+}
+
+func (c *contentLimitDB) GetContentLimits(ctx context.Context) ([]*gtsmodel.ContentLimit, error) {
+	var limitIDs []string
+
+	if err := c.db.
+		NewSelect().
+		Table("content_limits").
+		Column("id").
+		Order("id DESC").
+		Scan(ctx, &limitIDs); err != nil {
+		return nil, err
+	}
+
+	limits := make([]*gtsmodel.ContentLimit, 0, len(limitIDs))
+	for _, id := range limitIDs {
+		limit, err := c.GetContentLimitByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		limits = append(limits, limit)
+	}
+
+	return limits, nil
+}
+
+func (c *contentLimitDB) GetContentLimitsForAccount(ctx context.Context, account *gtsmodel.Account) (gtsmodel.ContentLimits, error) {
+	var limits gtsmodel.ContentLimits
+
+	// Limits are only ever needed for their
+	// values, so don't populate them.
+	ctx = gtscontext.SetBarebones(ctx)
+
+	// Check for a limit on the account itself.
+	limit, err := c.GetContentLimitByAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	if limit != nil {
+		limits = append(limits, limit)
+	}
+
+	// Check for limits on the account's domain,
+	// followed by each of its parent domains, so
+	// that limits on eg. "example.org" also apply
+	// to accounts on "art.example.org".
+	for domain := account.Domain; domain != ""; {
+		limit, err := c.GetContentLimitByDomain(ctx, domain)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, err
+		}
+
+		if limit != nil {
+			limits = append(limits, limit)
+		}
+
+		_, domain, _ = strings.Cut(domain, ".")
+	}
+
+	return limits, nil
+}
+
+func (c *contentLimitDB) PopulateContentLimit(ctx context.Context, limit *gtsmodel.ContentLimit) error {
+	var (
+		err  error
+		errs = gtserror.NewMultiError(2)
+	)
+
+	if limit.AccountID != "" && limit.Account == nil {
+		// Limited account is not set, fetch from database.
+		limit.Account, err = c.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			limit.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating content limit account: %w", err)
+		}
+	}
+
+	if limit.CreatedByAccount == nil {
+		// Content limit author is not set, fetch from database.
+		limit.CreatedByAccount, err = c.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			limit.CreatedByAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating content limit creator: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (c *contentLimitDB) PutContentLimit(ctx context.Context, limit *gtsmodel.ContentLimit) error {
+	if limit.Domain != "" {
+		// Normalize the domain as punycode
+		var err error
+		limit.Domain, err = util.Punify(limit.Domain)
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.state.Caches.DB.ContentLimit.Store(limit, func() error {
+		_, err := c.db.
+			NewInsert().
+			Model(limit).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (c *contentLimitDB) UpdateContentLimit(ctx context.Context, limit *gtsmodel.ContentLimit, columns ...string) error {
+	limit.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	return c.state.Caches.DB.ContentLimit.Store(limit, func() error {
+		_, err := c.db.
+			NewUpdate().
+			Model(limit).
+			Where("? = ?", bun.Ident("id"), limit.ID).
+			Column(columns...).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (c *contentLimitDB) DeleteContentLimitByID(ctx context.Context, id string) error {
+	if _, err := c.db.
+		NewDelete().
+		Table("content_limits").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Invalidate any cached content limit with this ID.
+	c.state.Caches.DB.ContentLimit.Invalidate("ID", id)
+
+	return nil
+}
@@ -0,0 +1,132 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type ContentLimitTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ContentLimitTestSuite) TestGetContentLimitsForAccount() {
+	var (
+		ctx     = context.Background()
+		admin   = suite.testAccounts["admin_account"]
+		account = suite.testAccounts["remote_account_1"]
+	)
+
+	// No limits yet.
+	limits, err := suite.db.GetContentLimitsForAccount(ctx, account)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(limits)
+
+	// Limit the domain of the account,
+	// as well as the account itself.
+	domainLimit := &gtsmodel.ContentLimit{
+		ID:                 "01JB3V4TFMD1B8Z9M2XQ5G6W7E",
+		Domain:             "fossbros-anonymous.io",
+		ForceSensitive:     util.Ptr(true),
+		RejectMedia:        util.Ptr(false),
+		CreatedByAccountID: admin.ID,
+	}
+	accountLimit := &gtsmodel.ContentLimit{
+		ID:                 "01JB3V5C1XJQ7T2R9S0H4N8K3A",
+		AccountID:          account.ID,
+		ForceSensitive:     util.Ptr(false),
+		ContentWarning:     "nsfw art",
+		RejectMedia:        util.Ptr(false),
+		CreatedByAccountID: admin.ID,
+	}
+
+	for _, limit := range []*gtsmodel.ContentLimit{domainLimit, accountLimit} {
+		if err := suite.db.PutContentLimit(ctx, limit); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Both should now apply, most specific first.
+	limits, err = suite.db.GetContentLimitsForAccount(ctx, account)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Len(limits, 2) {
+		suite.FailNow("")
+	}
+	suite.Equal(accountLimit.ID, limits[0].ID)
+	suite.Equal(domainLimit.ID, limits[1].ID)
+	suite.True(limits.ForceSensitive())
+	suite.False(limits.RejectMedia())
+	suite.Equal("nsfw art", limits.ContentWarning())
+	suite.Equal("nsfw art; spoilers", limits.ApplyContentWarning("spoilers"))
+	suite.Equal("nsfw art; spoilers", limits.ApplyContentWarning("nsfw art; spoilers"))
+
+	// Neither should apply to
+	// an unrelated local account.
+	limits, err = suite.db.GetContentLimitsForAccount(ctx, suite.testAccounts["local_account_1"])
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(limits)
+
+	// Update the domain limit.
+	domainLimit.RejectMedia = util.Ptr(true)
+	if err := suite.db.UpdateContentLimit(ctx, domainLimit, "reject_media"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	limits, err = suite.db.GetContentLimitsForAccount(ctx, account)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(limits.RejectMedia())
+
+	// Delete the account limit.
+	if err := suite.db.DeleteContentLimitByID(ctx, accountLimit.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetContentLimitByAccountID(ctx, account.ID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+
+	limits, err = suite.db.GetContentLimits(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Len(limits, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal(domainLimit.ID, limits[0].ID)
+	suite.Equal(admin.ID, limits[0].CreatedByAccount.ID)
+}
+
+func TestContentLimitTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentLimitTestSuite))
+}
@@ -0,0 +1,144 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+func (d *domainDB) GetDomainPermissionDraftByID(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.DomainPermissionDraft, error) {
+	var draft gtsmodel.DomainPermissionDraft
+
+	if err := d.db.
+		NewSelect().
+		Model(&draft).
+		Where("? = ?", bun.Ident("domain_permission_draft.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &draft, nil
+}
+
+func (d *domainDB) GetDomainPermissionDraft(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+	domain string,
+) (*gtsmodel.DomainPermissionDraft, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for easy case, domain referencing *us*
+	if domain == "" || domain == config.GetAccountDomain() ||
+		domain == config.GetHost() {
+		return nil, db.ErrNoEntries
+	}
+
+	var draft gtsmodel.DomainPermissionDraft
+
+	if err := d.db.
+		NewSelect().
+		Model(&draft).
+		Where("? = ?", bun.Ident("domain_permission_draft.permission_type"), permType).
+		Where("? = ?", bun.Ident("domain_permission_draft.domain"), domain).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &draft, nil
+}
+
+func (d *domainDB) GetDomainPermissionDrafts(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+	subscriptionID string,
+	domain string,
+) ([]*gtsmodel.DomainPermissionDraft, error) {
+	drafts := []*gtsmodel.DomainPermissionDraft{}
+
+	q := d.db.
+		NewSelect().
+		Model(&drafts).
+		Order("domain_permission_draft.id DESC")
+
+	if permType != gtsmodel.DomainPermissionUnknown {
+		q = q.Where("? = ?", bun.Ident("domain_permission_draft.permission_type"), permType)
+	}
+
+	if subscriptionID != "" {
+		q = q.Where("? = ?", bun.Ident("domain_permission_draft.subscription_id"), subscriptionID)
+	}
+
+	if domain != "" {
+		// Normalize the domain as punycode
+		domain, err := util.Punify(domain)
+		if err != nil {
+			return nil, err
+		}
+
+		q = q.Where("? = ?", bun.Ident("domain_permission_draft.domain"), domain)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return drafts, nil
+}
+
+func (d *domainDB) PutDomainPermissionDraft(
+	ctx context.Context,
+	draft *gtsmodel.DomainPermissionDraft,
+) error {
+	// Normalize the domain as punycode
+	var err error
+	draft.Domain, err = util.Punify(draft.Domain)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.
+		NewInsert().
+		Model(draft).
+		Exec(ctx)
+	return err
+}
+
+func (d *domainDB) DeleteDomainPermissionDraft(
+	ctx context.Context,
+	id string,
+) error {
+	_, err := d.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("domain_permission_drafts"), bun.Ident("domain_permission_draft")).
+		Where("? = ?", bun.Ident("domain_permission_draft.id"), id).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DomainPermissionSubscriptionTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestGetSubscriptionsByPriority() {
+	var (
+		ctx      = context.Background()
+		existing = testrig.NewTestDomainPermissionSubscriptions()["admin_sub_1"]
+	)
+
+	lowSub := &gtsmodel.DomainPermissionSubscription{
+		ID:                 "01JGE6A4JZQ8BM1YPN4B7P9J2C",
+		Priority:           10,
+		PermissionType:     gtsmodel.DomainPermissionBlock,
+		AsDraft:            util.Ptr(true),
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		URI:                "https://lists.example.org/baddies.txt",
+		ContentType:        gtsmodel.DomainPermSubContentTypePlain,
+	}
+	if err := suite.db.PutDomainPermissionSubscription(ctx, lowSub); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	subs, err := suite.db.GetDomainPermissionSubscriptions(ctx, gtsmodel.DomainPermissionBlock)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(subs, 2)
+	suite.Equal(existing.ID, subs[0].ID)
+	suite.Equal(lowSub.ID, subs[1].ID)
+
+	// No allow subscriptions.
+	subs, err = suite.db.GetDomainPermissionSubscriptions(ctx, gtsmodel.DomainPermissionAllow)
+	suite.NoError(err)
+	suite.Empty(subs)
+
+	// URI must be unique.
+	lowSub.ID = "01JGE6D9CNB1W4XCBFM4D6FQ84"
+	err = suite.db.PutDomainPermissionSubscription(ctx, lowSub)
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestDraftUniqueAndDelete() {
+	var (
+		ctx   = context.Background()
+		draft = testrig.NewTestDomainPermissionDrafts()["fossbros-anonymous.io"]
+	)
+
+	dbDraft, err := suite.db.GetDomainPermissionDraft(ctx, draft.PermissionType, draft.Domain)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(draft.ID, dbDraft.ID)
+
+	// Same type + domain can't be drafted twice.
+	dupe := &gtsmodel.DomainPermissionDraft{
+		ID:                 "01JGE6GDFQ2Z4YB7DBE3H0ZRBV",
+		PermissionType:     draft.PermissionType,
+		Domain:             draft.Domain,
+		CreatedByAccountID: draft.CreatedByAccountID,
+		Obfuscate:          util.Ptr(false),
+	}
+	err = suite.db.PutDomainPermissionDraft(ctx, dupe)
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	if err := suite.db.DeleteDomainPermissionDraft(ctx, draft.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetDomainPermissionDraftByID(ctx, draft.ID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+}
+
+func TestDomainPermissionSubscriptionTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainPermissionSubscriptionTestSuite))
+}
@@ -340,6 +340,36 @@ func (i *instanceDB) GetInstancePeers(ctx context.Context, includeSuspended bool
 	return instances, nil
 }
 
+func (i *instanceDB) GetInstancesFailingDelivery(ctx context.Context) ([]*gtsmodel.Instance, error) {
+	var instanceIDs []string
+
+	if err := i.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("instances"), bun.Ident("instance")).
+		// Select just the IDs of each instance.
+		Column("instance.id").
+		Where("? IS NOT NULL", bun.Ident("instance.delivery_failing_since")).
+		Scan(ctx, &instanceIDs); err != nil {
+		return nil, err
+	}
+
+	instances := make([]*gtsmodel.Instance, 0, len(instanceIDs))
+
+	for _, id := range instanceIDs {
+		// Select each instance by its ID.
+		instance, err := i.GetInstanceByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting instance %q: %v", id, err)
+			continue
+		}
+
+		// Append to return slice.
+		instances = append(instances, instance)
+	}
+
+	return instances, nil
+}
+
 func (i *instanceDB) GetInstanceAccounts(ctx context.Context, domain string, maxID string, limit int) ([]*gtsmodel.Account, error) {
 	// Ensure reasonable
 	if limit < 0 {
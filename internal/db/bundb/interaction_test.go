@@ -145,6 +145,7 @@ func (suite *InteractionTestSuite) TestGetPending() {
 		likes      = true
 		replies    = true
 		boosts     = true
+		quotes     = true
 		page       = &paging.Page{
 			Max:   paging.MaxID(id.Highest),
 			Limit: 20,
@@ -163,6 +164,7 @@ func (suite *InteractionTestSuite) TestGetPending() {
 		likes,
 		replies,
 		boosts,
+		quotes,
 		page,
 	)
 	suite.NoError(err)
@@ -193,6 +195,7 @@ func (suite *InteractionTestSuite) TestGetPendingRepliesOnly() {
 		likes      = false
 		replies    = true
 		boosts     = false
+		quotes     = false
 		page       = &paging.Page{
 			Max:   paging.MaxID(id.Highest),
 			Limit: 20,
@@ -211,6 +214,7 @@ func (suite *InteractionTestSuite) TestGetPendingRepliesOnly() {
 		likes,
 		replies,
 		boosts,
+		quotes,
 		page,
 	)
 	suite.NoError(err)
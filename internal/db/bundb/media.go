@@ -51,6 +51,25 @@ func (m *mediaDB) GetAttachmentByID(ctx context.Context, id string) (*gtsmodel.M
 	)
 }
 
+func (m *mediaDB) GetAttachmentByFileHash(ctx context.Context, hash string) (*gtsmodel.MediaAttachment, error) {
+	var id string
+
+	// Select the most recent cached
+	// attachment with given file hash.
+	if err := m.db.NewSelect().
+		Table("media_attachments").
+		Column("id").
+		Where("? = ?", bun.Ident("file_hash"), hash).
+		Where("? = ?", bun.Ident("cached"), true).
+		Order("id DESC").
+		Limit(1).
+		Scan(ctx, &id); err != nil {
+		return nil, err
+	}
+
+	return m.GetAttachmentByID(ctx, id)
+}
+
 func (m *mediaDB) GetAttachmentsByIDs(ctx context.Context, ids []string) ([]*gtsmodel.MediaAttachment, error) {
 	// Load all media IDs via cache loader callbacks.
 	media, err := m.state.Caches.DB.Media.LoadIDs("ID",
@@ -226,6 +245,19 @@ func (m *mediaDB) DeleteAttachment(ctx context.Context, id string) error {
 	return err
 }
 
+func (m *mediaDB) CountAttachmentsByFilePath(ctx context.Context, path string, excludeID string) (int, error) {
+	q := m.db.NewSelect().
+		Table("media_attachments").
+		Where("? = ?", bun.Ident("file_path"), path).
+		Where("? = ?", bun.Ident("cached"), true)
+
+	if excludeID != "" {
+		q = q.Where("? != ?", bun.Ident("id"), excludeID)
+	}
+
+	return q.Count(ctx)
+}
+
 func (m *mediaDB) GetAttachments(ctx context.Context, page *paging.Page) ([]*gtsmodel.MediaAttachment, error) {
 	maxID := page.GetMax()
 	limit := page.GetLimit()
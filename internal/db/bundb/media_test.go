@@ -23,6 +23,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type MediaTestSuite struct {
@@ -36,6 +39,87 @@ func (suite *MediaTestSuite) TestGetAttachmentByID() {
 	suite.NotNil(attachment)
 }
 
+func (suite *MediaTestSuite) TestUpdateAttachmentThumbnailVariants() {
+	ctx := context.Background()
+
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+	attachment, err := suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.NoError(err)
+	suite.Empty(attachment.ThumbnailVariants)
+
+	// Set thumbnail variants on the attachment.
+	attachment.ThumbnailVariants = []*gtsmodel.ThumbnailVariant{
+		{
+			Width:       320,
+			Height:      180,
+			Path:        attachment.AccountID + "/attachment/w320/" + attachment.ID + ".jpeg",
+			ContentType: "image/jpeg",
+			FileSize:    9001,
+			URL:         "http://localhost:8080/fileserver/" + attachment.AccountID + "/attachment/w320/" + attachment.ID + ".jpeg",
+		},
+		{
+			Width:       640,
+			Height:      360,
+			Path:        attachment.AccountID + "/attachment/w640/" + attachment.ID + ".jpeg",
+			ContentType: "image/jpeg",
+			FileSize:    25000,
+			URL:         "http://localhost:8080/fileserver/" + attachment.AccountID + "/attachment/w640/" + attachment.ID + ".jpeg",
+		},
+	}
+	err = suite.db.UpdateAttachment(ctx, attachment, "thumbnail_variants")
+	suite.NoError(err)
+
+	// Variants should be returned as set.
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Equal(attachment.ThumbnailVariants, dbAttachment.ThumbnailVariants)
+}
+
+func (suite *MediaTestSuite) TestSharedAttachmentFile() {
+	ctx := context.Background()
+
+	const hash = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	// Set two attachments to share the same stored file.
+	attachment1 := suite.testAttachments["admin_account_status_1_attachment_1"]
+	attachment1.File.Hash = hash
+	err := suite.db.UpdateAttachment(ctx, attachment1, "file_hash")
+	suite.NoError(err)
+
+	attachment2 := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+	attachment2.File.Hash = hash
+	attachment2.File.Path = attachment1.File.Path
+	err = suite.db.UpdateAttachment(ctx, attachment2, "file_hash", "file_path")
+	suite.NoError(err)
+
+	// Most recent attachment with hash should be returned.
+	attachment, err := suite.db.GetAttachmentByFileHash(ctx, hash)
+	suite.NoError(err)
+	suite.Equal(max(attachment1.ID, attachment2.ID), attachment.ID)
+
+	// Nothing stored with an unknown hash.
+	_, err = suite.db.GetAttachmentByFileHash(ctx, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Both attachments use the stored file.
+	count, err := suite.db.CountAttachmentsByFilePath(ctx, attachment1.File.Path, "")
+	suite.NoError(err)
+	suite.Equal(2, count)
+
+	count, err = suite.db.CountAttachmentsByFilePath(ctx, attachment1.File.Path, attachment1.ID)
+	suite.NoError(err)
+	suite.Equal(1, count)
+
+	// Uncached attachments no longer use the stored file.
+	attachment2.Cached = util.Ptr(false)
+	err = suite.db.UpdateAttachment(ctx, attachment2, "cached")
+	suite.NoError(err)
+
+	count, err = suite.db.CountAttachmentsByFilePath(ctx, attachment1.File.Path, attachment1.ID)
+	suite.NoError(err)
+	suite.Zero(count)
+}
+
 func (suite *MediaTestSuite) TestGetOlder() {
 	attachments, err := suite.db.GetCachedAttachmentsOlderThan(context.Background(), time.Now(), 20)
 	suite.NoError(err)
@@ -0,0 +1,95 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Create the status edits table.
+			if _, err := tx.
+				NewCreateTable().
+				Model(&gtsmodel.StatusEdit{}).
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Index edits by the status they belong to.
+			if _, err := tx.
+				NewCreateIndex().
+				Table("status_edits").
+				Index("status_edits_status_id_idx").
+				Column("status_id").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Add the new edit columns to statuses.
+			var editsType string
+			switch tx.Dialect().Name() {
+			case dialect.SQLite:
+				editsType = "VARCHAR"
+			case dialect.PG:
+				editsType = "VARCHAR ARRAY"
+			default:
+				panic("db conn was neither pg not sqlite")
+			}
+
+			for column, columnType := range map[string]string{
+				"edits":     editsType,
+				"edited_at": "TIMESTAMPTZ",
+			} {
+				exists, err := doesColumnExist(ctx, tx, "statuses", column)
+				if err != nil {
+					return err
+				} else if exists {
+					continue
+				}
+
+				if _, err := tx.
+					NewAddColumn().
+					Table("statuses").
+					ColumnExpr("? "+columnType, bun.Ident(column)).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,92 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Create the domain permission subscriptions table.
+			if _, err := tx.
+				NewCreateTable().
+				Model(&gtsmodel.DomainPermissionSubscription{}).
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Create the domain permission drafts table.
+			if _, err := tx.
+				NewCreateTable().
+				Model(&gtsmodel.DomainPermissionDraft{}).
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Index drafts, blocks and allows by the
+			// subscription they originated from, since
+			// we look them up that way on each fetch.
+			for _, index := range []struct {
+				table string
+				name  string
+			}{
+				{
+					table: "domain_permission_drafts",
+					name:  "domain_permission_drafts_subscription_id_idx",
+				},
+				{
+					table: "domain_blocks",
+					name:  "domain_blocks_subscription_id_idx",
+				},
+				{
+					table: "domain_allows",
+					name:  "domain_allows_subscription_id_idx",
+				},
+			} {
+				if _, err := tx.
+					NewCreateIndex().
+					Table(index.table).
+					Index(index.name).
+					Column("subscription_id").
+					IfNotExists().
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			for _, add := range []struct {
+				table      string
+				column     string
+				columnType string
+			}{
+				{"applications", "managed_by_user_id", "CHAR(26)"},
+				{"tokens", "last_used", "TIMESTAMPTZ"},
+			} {
+				exists, err := doesColumnExist(ctx, tx, add.table, add.column)
+				if err != nil {
+					return err
+				} else if exists {
+					continue
+				}
+
+				if _, err := tx.
+					NewAddColumn().
+					Table(add.table).
+					ColumnExpr("? "+add.columnType, bun.Ident(add.column)).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			// Index apps by the user that manages
+			// them, and tokens by the user that
+			// owns them, for listing these in the API.
+			for _, index := range []struct {
+				name   string
+				table  string
+				column string
+			}{
+				{"applications_managed_by_user_id_idx", "applications", "managed_by_user_id"},
+				{"tokens_user_id_idx", "tokens", "user_id"},
+			} {
+				if _, err := tx.
+					NewCreateIndex().
+					Table(index.table).
+					Index(index.name).
+					Column(index.column).
+					IfNotExists().
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,225 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+// Add a full-text search index for statuses and accounts,
+// replacing LIKE queries over the statuses + accounts tables.
+// See internal/db/bundb/searchindex.go for details.
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		if err := db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			var statements []string
+			switch tx.Dialect().Name() {
+			case dialect.SQLite:
+				statements = []string{
+					`CREATE TABLE IF NOT EXISTS "status_search" ("id" INTEGER PRIMARY KEY, "status_id" CHAR(26) NOT NULL UNIQUE, "content" TEXT NOT NULL)`,
+					`CREATE VIRTUAL TABLE IF NOT EXISTS "status_search_fts" USING fts5("status_id" UNINDEXED, "content", content = 'status_search', content_rowid = 'id', tokenize = 'unicode61 remove_diacritics 2')`,
+					`CREATE TRIGGER IF NOT EXISTS "status_search_ai" AFTER INSERT ON "status_search" BEGIN INSERT INTO "status_search_fts" ("rowid", "status_id", "content") VALUES (new."id", new."status_id", new."content"); END`,
+					`CREATE TRIGGER IF NOT EXISTS "status_search_ad" AFTER DELETE ON "status_search" BEGIN INSERT INTO "status_search_fts" ("status_search_fts", "rowid", "status_id", "content") VALUES ('delete', old."id", old."status_id", old."content"); END`,
+					`CREATE TABLE IF NOT EXISTS "account_search" ("id" INTEGER PRIMARY KEY, "account_id" CHAR(26) NOT NULL UNIQUE, "name" TEXT NOT NULL, "note" TEXT NOT NULL)`,
+					`CREATE VIRTUAL TABLE IF NOT EXISTS "account_search_fts" USING fts5("account_id" UNINDEXED, "name", "note", content = 'account_search', content_rowid = 'id', tokenize = 'unicode61 remove_diacritics 2')`,
+					`CREATE TRIGGER IF NOT EXISTS "account_search_ai" AFTER INSERT ON "account_search" BEGIN INSERT INTO "account_search_fts" ("rowid", "account_id", "name", "note") VALUES (new."id", new."account_id", new."name", new."note"); END`,
+					`CREATE TRIGGER IF NOT EXISTS "account_search_ad" AFTER DELETE ON "account_search" BEGIN INSERT INTO "account_search_fts" ("account_search_fts", "rowid", "account_id", "name", "note") VALUES ('delete', old."id", old."account_id", old."name", old."note"); END`,
+				}
+			case dialect.PG:
+				statements = []string{
+					`CREATE TABLE IF NOT EXISTS "status_search" ("status_id" CHAR(26) NOT NULL PRIMARY KEY, "vector" TSVECTOR NOT NULL)`,
+					`CREATE INDEX IF NOT EXISTS "status_search_vector_idx" ON "status_search" USING GIN ("vector")`,
+					`CREATE TABLE IF NOT EXISTS "account_search" ("account_id" CHAR(26) NOT NULL PRIMARY KEY, "vector" TSVECTOR NOT NULL)`,
+					`CREATE INDEX IF NOT EXISTS "account_search_vector_idx" ON "account_search" USING GIN ("vector")`,
+				}
+			default:
+				panic("unexpected dialect")
+			}
+
+			log.Info(ctx, "creating full-text search index...")
+			for _, statement := range statements {
+				if _, err := tx.ExecContext(ctx, statement); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		// Index in chunks, each one set-based INSERT
+		// in its own implicit transaction, rather than
+		// holding one enormous transaction open. Already
+		// indexed rows are skipped, so it's safe to
+		// resume if interrupted.
+		log.Info(ctx, "indexing statuses for full-text search, please wait; "+
+			"this may take a long time if your database has lots of statuses!")
+		type searchStatus struct {
+			ID             string
+			Content        string
+			ContentWarning string
+		}
+
+		var indexed int
+		for maxID := id.Highest; ; {
+			var statuses []searchStatus
+			if err := db.
+				NewSelect().
+				Table("statuses").
+				Column("id", "content", "content_warning").
+				Where("? IS NULL", bun.Ident("boost_of_id")).
+				Where("? < ?", bun.Ident("id"), maxID).
+				Order("id DESC").
+				Limit(1000).
+				Scan(ctx, &statuses); err != nil {
+				return err
+			}
+
+			if len(statuses) == 0 {
+				break
+			}
+
+			args := make([]any, 0, 2*len(statuses))
+			for _, status := range statuses {
+				content := searchText(
+					status.ContentWarning,
+					text.SanitizeToPlaintext(status.Content),
+				)
+				if content == "" {
+					continue
+				}
+				args = append(args, status.ID, content)
+			}
+
+			if len(args) != 0 {
+				values := searchValues(len(args)/2, 2)
+				query := `INSERT INTO "status_search" ("status_id", "content") ` +
+					`VALUES ` + values + ` ON CONFLICT DO NOTHING`
+				if db.Dialect().Name() == dialect.PG {
+					query = `INSERT INTO "status_search" ("status_id", "vector") ` +
+						`SELECT "v"."id", to_tsvector('simple', "v"."content") ` +
+						`FROM (VALUES ` + values + `) AS "v" ("id", "content") ON CONFLICT DO NOTHING`
+				}
+
+				if _, err := db.ExecContext(ctx, query, args...); err != nil {
+					return err
+				}
+			}
+
+			indexed += len(statuses)
+			log.Infof(ctx, "indexed %d statuses so far...", indexed)
+			maxID = statuses[len(statuses)-1].ID
+		}
+
+		log.Info(ctx, "indexing accounts for full-text search...")
+		type searchAccount struct {
+			ID          string
+			Username    string
+			DisplayName string
+			Note        string
+		}
+
+		for maxID := id.Highest; ; {
+			var accounts []searchAccount
+			if err := db.
+				NewSelect().
+				Table("accounts").
+				Column("id", "username", "display_name", "note").
+				Where("? < ?", bun.Ident("id"), maxID).
+				Order("id DESC").
+				Limit(1000).
+				Scan(ctx, &accounts); err != nil {
+				return err
+			}
+
+			if len(accounts) == 0 {
+				break
+			}
+
+			args := make([]any, 0, 3*len(accounts))
+			for _, account := range accounts {
+				args = append(args,
+					account.ID,
+					searchText(account.Username, account.DisplayName),
+					searchText(text.SanitizeToPlaintext(account.Note)),
+				)
+			}
+
+			values := searchValues(len(accounts), 3)
+			query := `INSERT INTO "account_search" ("account_id", "name", "note") ` +
+				`VALUES ` + values + ` ON CONFLICT DO NOTHING`
+			if db.Dialect().Name() == dialect.PG {
+				query = `INSERT INTO "account_search" ("account_id", "vector") ` +
+					`SELECT "v"."id", setweight(to_tsvector('simple', "v"."name"), 'A') || setweight(to_tsvector('simple', "v"."note"), 'B') ` +
+					`FROM (VALUES ` + values + `) AS "v" ("id", "name", "note") ON CONFLICT DO NOTHING`
+			}
+
+			if _, err := db.ExecContext(ctx, query, args...); err != nil {
+				return err
+			}
+
+			maxID = accounts[len(accounts)-1].ID
+		}
+
+		return nil
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
+
+// searchText normalizes the given text into space-separated
+// words for the full-text search index, the same way as
+// searchText in internal/db/bundb/searchquery.go.
+func searchText(texts ...string) string {
+	var words []string
+	for _, t := range texts {
+		words = append(words, strings.FieldsFunc(
+			strings.ToLower(t),
+			func(r rune) bool {
+				return !unicode.IsLetter(r) &&
+					!unicode.IsNumber(r) &&
+					!unicode.IsMark(r)
+			},
+		)...)
+	}
+	return strings.Join(words, " ")
+}
+
+// searchValues returns a VALUES list of n rows
+// of cols placeholders each, eg., "(?, ?), (?, ?)".
+func searchValues(n int, cols int) string {
+	row := "(" + strings.Repeat("?, ", cols-1) + "?)"
+	return strings.Repeat(row+", ", n-1) + row
+}
@@ -0,0 +1,93 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Add the new quote columns to statuses.
+			for _, column := range []string{
+				"quote_id",
+				"quote_uri",
+				"quote_account_id",
+			} {
+				exists, err := doesColumnExist(ctx, tx, "statuses", column)
+				if err != nil {
+					return err
+				} else if exists {
+					continue
+				}
+
+				columnType := "CHAR(26)"
+				if column == "quote_uri" {
+					columnType = "VARCHAR"
+				}
+
+				if _, err := tx.
+					NewAddColumn().
+					Table("statuses").
+					ColumnExpr("? "+columnType, bun.Ident(column)).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			// Index statuses by the status they quote.
+			if _, err := tx.
+				NewCreateIndex().
+				Table("statuses").
+				Index("statuses_quote_id_idx").
+				Column("quote_id").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Scheduled statuses may quote a status too.
+			exists, err := doesColumnExist(ctx, tx, "scheduled_statuses", "quoted_status_id")
+			if err != nil {
+				return err
+			} else if exists {
+				return nil
+			}
+
+			_, err = tx.
+				NewAddColumn().
+				Table("scheduled_statuses").
+				ColumnExpr("? CHAR(26)", bun.Ident("quoted_status_id")).
+				Exec(ctx)
+			return err
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,77 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Add the file hash column to media attachments.
+			exists, err := doesColumnExist(ctx, tx, "media_attachments", "file_hash")
+			if err != nil {
+				return err
+			}
+
+			if !exists {
+				if _, err := tx.
+					NewAddColumn().
+					Table("media_attachments").
+					ColumnExpr("? TEXT", bun.Ident("file_hash")).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			// Index media by file hash, as that's
+			// how existing identical files are found,
+			// and by file path, as that's how shared
+			// files are counted before deletion.
+			for index, column := range map[string]string{
+				"media_attachments_file_hash_idx": "file_hash",
+				"media_attachments_file_path_idx": "file_path",
+			} {
+				if _, err := tx.
+					NewCreateIndex().
+					Table("media_attachments").
+					Index(index).
+					Column(column).
+					IfNotExists().
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,71 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Add the URI of the relay actor which accepted
+			// our Follow, so that relayed activities are only
+			// accepted from that actor, not its whole domain.
+			exists, err := doesColumnExist(ctx, tx, "relays", "accepted_by_uri")
+			if err != nil {
+				return err
+			} else if exists {
+				return nil
+			}
+
+			if _, err := tx.
+				NewAddColumn().
+				Table("relays").
+				ColumnExpr("? VARCHAR", bun.Ident("accepted_by_uri")).
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// LitePub relays are followed directly, so
+			// the accepting actor is the followed actor.
+			// Mastodon-style relays will have it set the
+			// next time they Accept a Follow.
+			_, err = tx.
+				NewUpdate().
+				Table("relays").
+				Set("? = ?", bun.Ident("accepted_by_uri"), bun.Ident("actor_uri")).
+				Where("? IS NOT NULL", bun.Ident("actor_uri")).
+				Where("? = ?", bun.Ident("state"), 1). // accepted
+				Exec(ctx)
+			return err
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
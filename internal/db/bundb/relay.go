@@ -0,0 +1,157 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+// relayActorURIsKey is the key under which the
+// list of accepted relay actor URIs is cached.
+const relayActorURIsKey = "accepted"
+
+type relayDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (r *relayDB) GetRelayByID(ctx context.Context, id string) (*gtsmodel.Relay, error) {
+	return r.getRelay(ctx, "id", id)
+}
+
+func (r *relayDB) GetRelayByInboxURI(ctx context.Context, inboxURI string) (*gtsmodel.Relay, error) {
+	return r.getRelay(ctx, "inbox_uri", inboxURI)
+}
+
+func (r *relayDB) GetRelayByFollowURI(ctx context.Context, followURI string) (*gtsmodel.Relay, error) {
+	return r.getRelay(ctx, "follow_uri", followURI)
+}
+
+func (r *relayDB) getRelay(ctx context.Context, column string, value string) (*gtsmodel.Relay, error) {
+	var relay gtsmodel.Relay
+
+	if err := r.db.
+		NewSelect().
+		Model(&relay).
+		Where("? = ?", bun.Ident("relay."+column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &relay, nil
+}
+
+func (r *relayDB) GetRelays(ctx context.Context) ([]*gtsmodel.Relay, error) {
+	relays := make([]*gtsmodel.Relay, 0)
+
+	if err := r.db.
+		NewSelect().
+		Model(&relays).
+		Order("relay.id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return relays, nil
+}
+
+func (r *relayDB) PutRelay(ctx context.Context, relay *gtsmodel.Relay) error {
+	if _, err := r.db.
+		NewInsert().
+		Model(relay).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Invalidate the relay actors cache.
+	r.state.Caches.DB.RelayActorURIs.Invalidate(relayActorURIsKey)
+
+	return nil
+}
+
+func (r *relayDB) UpdateRelay(ctx context.Context, relay *gtsmodel.Relay, columns ...string) error {
+	relay.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	if _, err := r.db.
+		NewUpdate().
+		Model(relay).
+		Column(columns...).
+		Where("? = ?", bun.Ident("relay.id"), relay.ID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Invalidate the relay actors cache.
+	r.state.Caches.DB.RelayActorURIs.Invalidate(relayActorURIsKey)
+
+	return nil
+}
+
+func (r *relayDB) DeleteRelayByID(ctx context.Context, id string) error {
+	if _, err := r.db.
+		NewDelete().
+		Table("relays").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Invalidate the relay actors cache.
+	r.state.Caches.DB.RelayActorURIs.Invalidate(relayActorURIsKey)
+
+	return nil
+}
+
+func (r *relayDB) IsRelayActor(ctx context.Context, actorURI string) (bool, error) {
+	if actorURI == "" {
+		return false, nil
+	}
+
+	// Load the list of accepted relay actor URIs
+	// from the cache (hydrating it from the DB if needed).
+	actorURIs, err := r.state.Caches.DB.RelayActorURIs.Load(relayActorURIsKey, func() ([]string, error) {
+		var actorURIs []string
+
+		q := r.db.NewSelect().
+			Table("relays").
+			Column("accepted_by_uri").
+			Where("? = ?", bun.Ident("state"), gtsmodel.RelayStateAccepted).
+			Where("? IS NOT NULL", bun.Ident("accepted_by_uri"))
+		if err := q.Scan(ctx, &actorURIs); err != nil {
+			return nil, err
+		}
+
+		return actorURIs, nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(actorURIs, actorURI), nil
+}
@@ -0,0 +1,110 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type RelayTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *RelayTestSuite) TestGetRelays() {
+	relays, err := suite.db.GetRelays(context.Background())
+	suite.NoError(err)
+	suite.Len(relays, 2)
+	suite.Equal("https://relay.example.org/inbox", relays[0].InboxURI)
+	suite.False(relays[0].IsLitePub())
+	suite.Equal("https://litepub.example.org/inbox", relays[1].InboxURI)
+	suite.True(relays[1].IsLitePub())
+}
+
+func (suite *RelayTestSuite) TestGetRelayByFollowURI() {
+	relay, err := suite.db.GetRelayByFollowURI(
+		context.Background(),
+		"http://localhost:8080/users/localhost:8080/follow/01JAKZ3YB0Q5C3N4T6XVPD8WKM",
+	)
+	suite.NoError(err)
+	suite.Equal("01JAKZ3YB0Q5C3N4T6XVPD8WKM", relay.ID)
+
+	_, err = suite.db.GetRelayByFollowURI(
+		context.Background(),
+		"http://localhost:8080/users/localhost:8080/follow/01JAKZAW1PX9V4A2BNSF0K5GTR",
+	)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *RelayTestSuite) TestPutRelayAlreadyExists() {
+	relay := suite.testRelays["relay_accepted"]
+	err := suite.db.PutRelay(context.Background(), &gtsmodel.Relay{
+		ID:                 "01JAKZAW1PX9V4A2BNSF0K5GTR",
+		InboxURI:           relay.InboxURI,
+		Domain:             relay.Domain,
+		FollowURI:          "http://localhost:8080/users/localhost:8080/follow/01JAKZAW1PX9V4A2BNSF0K5GTR",
+		Forward:            util.Ptr(true),
+		CreatedByAccountID: relay.CreatedByAccountID,
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+}
+
+func (suite *RelayTestSuite) TestIsRelayActor() {
+	ctx := context.Background()
+
+	for actorURI, expect := range map[string]bool{
+		"https://relay.example.org/actor":            true,
+		"https://relay.example.org/users/someone":    false,
+		"https://sub.relay.example.org/actor":        false,
+		"https://litepub.example.org/relay":          false, // pending
+		"http://localhost:8080/users/localhost:8080": false,
+		"": false,
+	} {
+		isRelay, err := suite.db.IsRelayActor(ctx, actorURI)
+		suite.NoError(err)
+		suite.Equal(expect, isRelay, actorURI)
+	}
+
+	// Accepting the pending relay
+	// should invalidate the cache.
+	relay, err := suite.db.GetRelayByID(ctx, suite.testRelays["relay_pending"].ID)
+	suite.NoError(err)
+	relay.State = gtsmodel.RelayStateAccepted
+	relay.AcceptedByURI = relay.ActorURI
+	suite.NoError(suite.db.UpdateRelay(ctx, relay, "state", "accepted_by_uri"))
+
+	isRelay, err := suite.db.IsRelayActor(ctx, "https://litepub.example.org/relay")
+	suite.NoError(err)
+	suite.True(isRelay)
+
+	// As should deleting it.
+	suite.NoError(suite.db.DeleteRelayByID(ctx, relay.ID))
+
+	isRelay, err = suite.db.IsRelayActor(ctx, "https://litepub.example.org/relay")
+	suite.NoError(err)
+	suite.False(isRelay)
+}
+
+func TestRelayTestSuite(t *testing.T) {
+	suite.Run(t, new(RelayTestSuite))
+}
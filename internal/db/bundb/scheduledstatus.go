@@ -0,0 +1,277 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type scheduledStatusDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (s *scheduledStatusDB) GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, error) {
+	return s.getScheduledStatus(
+		ctx,
+		"ID",
+		func(status *gtsmodel.ScheduledStatus) error {
+			return s.db.
+				NewSelect().
+				Model(status).
+				Where("? = ?", bun.Ident("scheduled_status.id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (s *scheduledStatusDB) GetScheduledStatusesForAcct(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.ScheduledStatus, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		statusIDs = make([]string, 0, limit)
+	)
+
+	q := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("scheduled_statuses"), bun.Ident("scheduled_status")).
+		// Select only IDs from table.
+		Column("scheduled_status.id").
+		Where("? = ?", bun.Ident("scheduled_status.account_id"), accountID)
+
+	// Return only scheduled statuses
+	// with id lower than provided maxID.
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("scheduled_status.id"), maxID)
+	}
+
+	// Return only scheduled statuses
+	// with id greater than provided minID.
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("scheduled_status.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of
+		// statuses returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("scheduled_status.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("scheduled_status.id"))
+	}
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no statuses early
+	if len(statusIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want statuses
+	// to be sorted by ID desc, so reverse ids slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(statusIDs)
+	}
+
+	return s.getScheduledStatusesByIDs(ctx, statusIDs), nil
+}
+
+func (s *scheduledStatusDB) GetAllScheduledStatuses(ctx context.Context) ([]*gtsmodel.ScheduledStatus, error) {
+	var statusIDs []string
+
+	if err := s.db.
+		NewSelect().
+		Table("scheduled_statuses").
+		Column("id").
+		Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	return s.getScheduledStatusesByIDs(ctx, statusIDs), nil
+}
+
+func (s *scheduledStatusDB) CountScheduledStatusesForAcct(ctx context.Context, accountID string, after time.Time, before time.Time) (int, error) {
+	q := s.db.
+		NewSelect().
+		Table("scheduled_statuses").
+		Where("? = ?", bun.Ident("account_id"), accountID)
+
+	if !after.IsZero() {
+		q = q.Where("? >= ?", bun.Ident("scheduled_at"), after)
+	}
+
+	if !before.IsZero() {
+		q = q.Where("? < ?", bun.Ident("scheduled_at"), before)
+	}
+
+	return q.Count(ctx)
+}
+
+func (s *scheduledStatusDB) getScheduledStatusesByIDs(ctx context.Context, ids []string) []*gtsmodel.ScheduledStatus {
+	// Allocate return slice (will be at most len ids)
+	statuses := make([]*gtsmodel.ScheduledStatus, 0, len(ids))
+	for _, id := range ids {
+		status, err := s.GetScheduledStatusByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting scheduled status %q: %v", id, err)
+			continue
+		}
+
+		// Append to return slice
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+func (s *scheduledStatusDB) getScheduledStatus(ctx context.Context, lookup string, dbQuery func(*gtsmodel.ScheduledStatus) error, keyParts ...any) (*gtsmodel.ScheduledStatus, error) {
+	// Fetch scheduled status from database cache with loader callback
+	status, err := s.state.Caches.DB.ScheduledStatus.LoadOne(lookup, func() (*gtsmodel.ScheduledStatus, error) {
+		var status gtsmodel.ScheduledStatus
+
+		// Not cached! Perform database query
+		if err := dbQuery(&status); err != nil {
+			return nil, err
+		}
+
+		return &status, nil
+	}, keyParts...)
+	if err != nil {
+		// error already processed
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return status, nil
+	}
+
+	if err := s.PopulateScheduledStatus(ctx, status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+func (s *scheduledStatusDB) PopulateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error {
+	var (
+		err  error
+		errs = gtserror.NewMultiError(3)
+	)
+
+	if status.Account == nil {
+		// Scheduled status account is not set, fetch from the database.
+		status.Account, err = s.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			status.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating scheduled status account: %w", err)
+		}
+	}
+
+	if !status.MediaAttachmentsPopulated() {
+		// Scheduled status media are not set, fetch from the database.
+		status.MediaAttachments, err = s.state.DB.GetAttachmentsByIDs(
+			ctx,
+			status.MediaIDs,
+		)
+		if err != nil {
+			errs.Appendf("error populating scheduled status media: %w", err)
+		}
+	}
+
+	if status.ApplicationID != "" && status.Application == nil {
+		// Scheduled status application is not set, fetch from the database.
+		status.Application, err = s.state.DB.GetApplicationByID(
+			ctx,
+			status.ApplicationID,
+		)
+		if err != nil {
+			errs.Appendf("error populating scheduled status application: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (s *scheduledStatusDB) PutScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error {
+	return s.state.Caches.DB.ScheduledStatus.Store(status, func() error {
+		_, err := s.db.NewInsert().Model(status).Exec(ctx)
+		return err
+	})
+}
+
+func (s *scheduledStatusDB) UpdateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus, columns ...string) error {
+	// Update the scheduled status' last-updated
+	status.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	return s.state.Caches.DB.ScheduledStatus.Store(status, func() error {
+		_, err := s.db.
+			NewUpdate().
+			Model(status).
+			Where("? = ?", bun.Ident("scheduled_status.id"), status.ID).
+			Column(columns...).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (s *scheduledStatusDB) DeleteScheduledStatusByID(ctx context.Context, id string) error {
+	// Delete the scheduled status from DB.
+	if _, err := s.db.NewDelete().
+		TableExpr("? AS ?", bun.Ident("scheduled_statuses"), bun.Ident("scheduled_status")).
+		Where("? = ?", bun.Ident("scheduled_status.id"), id).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Invalidate any cached scheduled status model by ID.
+	s.state.Caches.DB.ScheduledStatus.Invalidate("ID", id)
+
+	return nil
+}
@@ -0,0 +1,137 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type ScheduledStatusTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ScheduledStatusTestSuite) putScheduledStatus(accountID string, scheduledAt time.Time) *gtsmodel.ScheduledStatus {
+	scheduled := &gtsmodel.ScheduledStatus{
+		ID:          id.NewULID(),
+		AccountID:   accountID,
+		ScheduledAt: scheduledAt,
+		Text:        "hello world",
+		Poll: gtsmodel.ScheduledStatusPoll{
+			Options:   []string{"yes", "no"},
+			ExpiresIn: 3600,
+			Multiple:  util.Ptr(false),
+		},
+		Sensitive:  util.Ptr(false),
+		Visibility: gtsmodel.VisibilityPublic,
+		LocalOnly:  util.Ptr(false),
+		Language:   "en",
+	}
+
+	if err := suite.db.PutScheduledStatus(context.Background(), scheduled); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return scheduled
+}
+
+func (suite *ScheduledStatusTestSuite) TestPutGetScheduledStatus() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	scheduledAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	scheduled := suite.putScheduledStatus(account.ID, scheduledAt)
+
+	// Invalidate cache to force a db hit.
+	suite.state.Caches.DB.ScheduledStatus.Invalidate("ID", scheduled.ID)
+
+	dbScheduled, err := suite.db.GetScheduledStatusByID(ctx, scheduled.ID)
+	suite.NoError(err)
+	suite.Equal(account.ID, dbScheduled.Account.ID)
+	suite.True(scheduledAt.Equal(dbScheduled.ScheduledAt))
+	suite.Equal("hello world", dbScheduled.Text)
+	suite.Equal([]string{"yes", "no"}, dbScheduled.Poll.Options)
+	suite.Equal(3600, dbScheduled.Poll.ExpiresIn)
+	suite.Empty(dbScheduled.MediaAttachments)
+}
+
+func (suite *ScheduledStatusTestSuite) TestGetScheduledStatusesForAcct() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	now := time.Now()
+
+	s1 := suite.putScheduledStatus(account.ID, now.Add(time.Hour))
+
+	// Ensure the second ID sorts after the first,
+	// as ULIDs in the same millisecond are random.
+	time.Sleep(2 * time.Millisecond)
+	s2 := suite.putScheduledStatus(account.ID, now.Add(48*time.Hour))
+	suite.putScheduledStatus(suite.testAccounts["local_account_2"].ID, now.Add(time.Hour))
+
+	scheduled, err := suite.db.GetScheduledStatusesForAcct(ctx, account.ID, &paging.Page{Limit: 20})
+	suite.NoError(err)
+	suite.Len(scheduled, 2)
+
+	// Newest first.
+	suite.Equal(s2.ID, scheduled[0].ID)
+	suite.Equal(s1.ID, scheduled[1].ID)
+
+	// Count within the next day.
+	count, err := suite.db.CountScheduledStatusesForAcct(ctx, account.ID, now, now.Add(24*time.Hour))
+	suite.NoError(err)
+	suite.Equal(1, count)
+
+	all, err := suite.db.GetAllScheduledStatuses(ctx)
+	suite.NoError(err)
+	suite.Len(all, 3)
+}
+
+func (suite *ScheduledStatusTestSuite) TestUpdateDeleteScheduledStatus() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	scheduled := suite.putScheduledStatus(account.ID, time.Now().Add(time.Hour))
+
+	later := time.Now().Add(72 * time.Hour).Truncate(time.Second)
+	scheduled.ScheduledAt = later
+	err := suite.db.UpdateScheduledStatus(ctx, scheduled, "scheduled_at")
+	suite.NoError(err)
+
+	suite.state.Caches.DB.ScheduledStatus.Invalidate("ID", scheduled.ID)
+	dbScheduled, err := suite.db.GetScheduledStatusByID(ctx, scheduled.ID)
+	suite.NoError(err)
+	suite.True(later.Equal(dbScheduled.ScheduledAt))
+
+	err = suite.db.DeleteScheduledStatusByID(ctx, scheduled.ID)
+	suite.NoError(err)
+
+	_, err = suite.db.GetScheduledStatusByID(ctx, scheduled.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestScheduledStatusTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledStatusTestSuite))
+}
@@ -29,33 +29,18 @@ import (
 	"github.com/uptrace/bun/dialect"
 )
 
-// todo: currently we pass an 'offset' parameter into functions owned by this struct,
-// which is ignored.
+// Text searches for accounts and statuses use the full-text search
+// index (see searchindex.go), and are ordered by relevance unless the
+// caller is paging with maxID and/or minID, in which case results are
+// ordered by ID as with other paged endpoints. When ordering by
+// relevance, 'offset' is used to page through results.
 //
-// The idea of 'offset' is to allow callers to page through results without supplying
-// maxID or minID params; they simply use the offset as more or less a 'page number'.
-// This works fine when you're dealing with something like Elasticsearch, but for
-// SQLite or Postgres 'LIKE' queries it doesn't really, because for each higher offset
-// you have to calculate the value of all the previous offsets as well *within the
-// execution time of the query*. It's MUCH more efficient to page using maxID and
-// minID for queries like this. For now, then, we just ignore the offset and hope that
-// the caller will page using maxID and minID instead.
+// When ordering by ID (including searches without any text, ie., only
+// operators), offset isn't supported, and no results are returned for
+// an offset greater than 0. Callers should page with maxID / minID.
 //
-// In future, however, it would be good to support offset in a way that doesn't totally
-// destroy database queries. One option would be to cache previous offsets when paging
-// down (which is the most common use case).
-//
-// For example, say a caller makes a call with offset 0: we run the query as normal,
-// and in a 10 minute cache or something, store the next maxID value as it would be for
-// offset 1, for the supplied query, limit, following, etc. Then when they call for
-// offset 1, instead of supplying 'offset' in the query and causing slowdown, we check
-// the cache to see if we have the next maxID value stored for that query, and use that
-// instead. If a caller out of the blue requests offset 4 or something, on an empty cache,
-// we could run the previous 4 queries and store the offsets for those before making the
-// 5th call for page 4.
-//
-// This isn't ideal, of course, but at least we could cover the most common use case of
-// a caller paging down through results.
+// todo: 'offset' is still ignored by SearchForTags, as it's much
+// more efficient to page using maxID and minID for tags.
 type searchDB struct {
 	db    *bun.DB
 	state *state.State
@@ -64,11 +49,11 @@ type searchDB struct {
 // Query example (SQLite):
 //
 //	SELECT "account"."id" FROM "accounts" AS "account"
+//	LEFT JOIN (SELECT "account_id", bm25("account_search_fts", 0, 4, 1) AS "rank" FROM "account_search_fts" WHERE ("account_search_fts" MATCH '"turtle"*')) AS "account_match" ON "account_match"."account_id" = "account"."id"
 //	WHERE (("account"."domain" IS NULL) OR ("account"."domain" != "account"."username"))
-//	AND ("account"."id" < 'ZZZZZZZZZZZZZZZZZZZZZZZZZZ')
-//	AND ("account"."id" IN (SELECT "target_account_id" FROM "follows" WHERE ("account_id" = '016T5Q3SQKBT337DAKVSKNXXW1')))
-//	AND ((SELECT "account"."username" || COALESCE("account"."display_name", '') || COALESCE("account"."note", '') AS "account_text") LIKE '%turtle%' ESCAPE '\')
-//	ORDER BY "account"."id" DESC LIMIT 10
+//	AND ("account"."id" IN (SELECT "follow"."target_account_id" FROM "follows" AS "follow" WHERE ("follow"."account_id" = '016T5Q3SQKBT337DAKVSKNXXW1')))
+//	AND (("account_match"."account_id" IS NOT NULL) OR ("account"."username" LIKE '%turtle%' ESCAPE '\') OR ("account"."display_name" LIKE '%turtle%' ESCAPE '\'))
+//	ORDER BY "account_match"."rank" IS NULL, "account_match"."rank", "account"."id" DESC LIMIT 10
 func (s *searchDB) SearchForAccounts(
 	ctx context.Context,
 	accountID string,
@@ -88,6 +73,7 @@ func (s *searchDB) SearchForAccounts(
 	var (
 		accountIDs  = make([]string, 0, limit)
 		frontToBack = true
+		byRelevance = (maxID == "" && minID == "")
 	)
 
 	q := s.db.
@@ -134,12 +120,28 @@ func (s *searchDB) SearchForAccounts(
 		// usernames that start with query.
 		query = query[1:]
 		q = whereStartsLike(q, bun.Ident("account.username"), query)
+		byRelevance = false
 	} else {
 		// Query looks like arbitrary string.
-		// Search using LIKE for matches of query
-		// string within accountText subquery.
-		subQ := s.accountText(following)
-		q = whereLike(q, subQ, query)
+		// Search for words in the full-text
+		// index, matching them as prefixes
+		// so partially typed names match, as
+		// well as names containing the query.
+		query = strings.TrimSpace(query)
+		if query == "" {
+			return nil, nil
+		}
+
+		// Account notes are only
+		// searched for followed accounts.
+		parsed := parseSearchQuery(query)
+		q = s.matchAccounts(q, query, &parsed, following, byRelevance)
+	}
+
+	if offset > 0 && !byRelevance {
+		// Can't page by offset when
+		// ordering by ID, see above.
+		return nil, nil
 	}
 
 	if limit > 0 {
@@ -147,10 +149,19 @@ func (s *searchDB) SearchForAccounts(
 		q = q.Limit(limit)
 	}
 
-	if frontToBack {
+	switch {
+	case byRelevance:
+		// Ordered by relevance
+		// already, page by offset.
+		q = q.
+			Order("account.id DESC").
+			Offset(offset)
+
+	case frontToBack:
 		// Page down.
 		q = q.Order("account.id DESC")
-	} else {
+
+	default:
 		// Page up.
 		q = q.Order("account.id ASC")
 	}
@@ -198,72 +209,126 @@ func (s *searchDB) followedAccounts(accountID string) *bun.SelectQuery {
 		Where("? = ?", bun.Ident("follow.account_id"), accountID)
 }
 
-// accountText returns a subquery that selects a concatenation
-// of account username and display name as "account_text". If
-// `following` is true, then account note will also be included
-// in the concatenation.
-func (s *searchDB) accountText(following bool) *bun.SelectQuery {
-	var (
-		accountText = s.db.NewSelect()
-		query       string
-		args        []interface{}
-	)
-
-	if following {
-		// If querying for accounts we follow,
-		// include note in text search params.
-		args = []interface{}{
-			bun.Ident("account.username"),
-			bun.Ident("account.display_name"), "",
-			bun.Ident("account.note"), "",
-			bun.Ident("account_text"),
-		}
-	} else {
-		// If querying for accounts we're not following,
-		// don't include note in text search params.
-		args = []interface{}{
-			bun.Ident("account.username"),
-			bun.Ident("account.display_name"), "",
-			bun.Ident("account_text"),
-		}
+// matchAccounts joins the accounts full-text search index
+// onto the given query, selecting only accounts matching
+// the parsed search query, or with a username or display
+// name containing the raw query text anywhere (the index
+// only matches whole words or word prefixes). If includeNote
+// is false, then only account username + display name are
+// matched in the index. If order is true, results are also
+// ordered by relevance, with index matches first.
+func (s *searchDB) matchAccounts(
+	q *bun.SelectQuery,
+	text string,
+	parsed *searchQuery,
+	includeNote bool,
+	order bool,
+) *bun.SelectQuery {
+	// Usernames + display names may contain
+	// the query text anywhere, as with LIKE.
+	nameLike := `%` + likeEscaper.Replace(text) + `%`
+	like := bun.Safe(likeOperator(q))
+	whereName := func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			WhereOr("? ? ? ESCAPE ?", bun.Ident("account.username"), like, nameLike, `\`).
+			WhereOr("? ? ? ESCAPE ?", bun.Ident("account.display_name"), like, nameLike, `\`)
+	}
+
+	if !parsed.hasText() {
+		// Nothing to match in the
+		// index, only match names.
+		return q.WhereGroup(" AND ", whereName)
 	}
 
-	// SQLite and Postgres use different syntaxes for
-	// concatenation, and we also need to use a
-	// different number of placeholders depending on
-	// following/not following. COALESCE calls ensure
-	// that we're not trying to concatenate null values.
-
-	switch d := s.db.Dialect().Name(); {
+	switch d := s.db.Dialect().Name(); d {
 
-	case d == dialect.SQLite && following:
-		query = "? || COALESCE(?, ?) || COALESCE(?, ?) AS ?"
+	case dialect.SQLite:
+		var column string
+		if !includeNote {
+			column = "name"
+		}
 
-	case d == dialect.SQLite && !following:
-		query = "? || COALESCE(?, ?) AS ?"
+		// Select index matches (and
+		// their rank) in a subquery,
+		// so names can match without.
+		matches := s.db.
+			NewSelect().
+			Table("account_search_fts").
+			Column("account_id").
+			ColumnExpr("bm25(?, 0, 4, 1) AS ?", // Weight name matches above note matches.
+				bun.Ident("account_search_fts"),
+				bun.Ident("rank"),
+			).
+			Where("? MATCH ?",
+				bun.Ident("account_search_fts"),
+				parsed.sqliteMatch(column, true),
+			)
+
+		q = q.
+			Join("LEFT JOIN (?) AS ? ON ? = ?",
+				matches,
+				bun.Ident("account_match"),
+				bun.Ident("account_match.account_id"),
+				bun.Ident("account.id"),
+			).
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				q = q.Where("? IS NOT NULL", bun.Ident("account_match.account_id"))
+				return whereName(q)
+			})
+
+		if order {
+			q = q.OrderExpr("? IS NULL, ?",
+				bun.Ident("account_match.rank"),
+				bun.Ident("account_match.rank"),
+			)
+		}
 
-	case d == dialect.PG && following:
-		query = "CONCAT(?, COALESCE(?, ?), COALESCE(?, ?)) AS ?"
+	case dialect.PG:
+		var weight string
+		if !includeNote {
+			weight = "A"
+		}
 
-	case d == dialect.PG && !following:
-		query = "CONCAT(?, COALESCE(?, ?)) AS ?"
+		tsquery := parsed.pgTSQuery(weight, true)
+
+		q = q.
+			Join("LEFT JOIN ? ON ? = ?",
+				bun.Ident("account_search"),
+				bun.Ident("account_search.account_id"),
+				bun.Ident("account.id"),
+			).
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				q = q.Where("? @@ to_tsquery('simple', ?)",
+					bun.Ident("account_search.vector"),
+					tsquery,
+				)
+				return whereName(q)
+			})
+
+		if order {
+			q = q.OrderExpr("COALESCE(ts_rank(?, to_tsquery('simple', ?)), 0) DESC",
+				bun.Ident("account_search.vector"),
+				tsquery,
+			)
+		}
 
 	default:
 		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
 	}
 
-	return accountText.ColumnExpr(query, args...)
+	return q
 }
 
 // Query example (SQLite):
 //
 //	SELECT "status"."id"
 //	FROM "statuses" AS "status"
+//	JOIN "status_search_fts" ON ("status_search_fts"."status_id" = "status"."id")
 //	WHERE ("status"."boost_of_id" IS NULL)
 //	AND (("status"."account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF') OR ("status"."in_reply_to_account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF'))
 //	AND ("status"."id" < 'ZZZZZZZZZZZZZZZZZZZZZZZZZZ')
-//	AND ((SELECT "status"."content" || COALESCE("status"."content_warning", '') AS "status_text") LIKE '%hello%' ESCAPE '\')
-//	ORDER BY "status"."id" DESC LIMIT 10
+//	AND ("status_search_fts" MATCH '"hello" "nice weather"')
+//	ORDER BY "status_search_fts"."rank", "status"."id" DESC LIMIT 10
 func (s *searchDB) SearchForStatuses(
 	ctx context.Context,
 	requestingAccountID string,
@@ -279,10 +344,15 @@ func (s *searchDB) SearchForStatuses(
 		limit = 0
 	}
 
+	// Parse any quoted
+	// phrases + operators.
+	parsed := parseSearchQuery(query)
+
 	// Make educated guess for slice size
 	var (
 		statusIDs   = make([]string, 0, limit)
 		frontToBack = true
+		byRelevance = (maxID == "" && minID == "" && parsed.hasText())
 	)
 
 	q := s.db.
@@ -317,22 +387,55 @@ func (s *searchDB) SearchForStatuses(
 		frontToBack = false
 	}
 
-	// Select status text as subquery.
-	statusTextSubq := s.statusText()
+	if parsed.hasMedia {
+		// Select only statuses with attachments.
+		q = whereHasAttachments(q)
+	}
+
+	if !parsed.before.IsZero() {
+		// Select only statuses created before given date.
+		q = q.Where("? < ?", bun.Ident("status.created_at"), parsed.before)
+	}
+
+	if !parsed.after.IsZero() {
+		// Select only statuses created after given date.
+		q = q.Where("? >= ?", bun.Ident("status.created_at"), parsed.after)
+	}
 
-	// Search using LIKE for matches of query
-	// string within statusText subquery.
-	q = whereLike(q, statusTextSubq, query)
+	if parsed.hasText() {
+		// Search for words +
+		// phrases in full-text index.
+		q = s.matchStatuses(q, &parsed, byRelevance)
+	} else if !parsed.hasOperators() {
+		// Nothing to search for,
+		// either text or operators.
+		return nil, nil
+	}
+
+	if offset > 0 && !byRelevance {
+		// Can't page by offset when
+		// ordering by ID, see above.
+		return nil, nil
+	}
 
 	if limit > 0 {
 		// Limit amount of statuses returned.
 		q = q.Limit(limit)
 	}
 
-	if frontToBack {
+	switch {
+	case byRelevance:
+		// Ordered by relevance
+		// already, page by offset.
+		q = q.
+			Order("status.id DESC").
+			Offset(offset)
+
+	case frontToBack:
 		// Page down.
 		q = q.Order("status.id DESC")
-	} else {
+
+	default:
 		// Page up.
 		q = q.Order("status.id ASC")
 	}
@@ -370,32 +473,61 @@ func (s *searchDB) SearchForStatuses(
 	return statuses, nil
 }
 
-// statusText returns a subquery that selects a concatenation
-// of status content and content warning as "status_text".
-func (s *searchDB) statusText() *bun.SelectQuery {
-	statusText := s.db.NewSelect()
-
-	// SQLite and Postgres use different
-	// syntaxes for concatenation.
+// matchStatuses joins the statuses full-text search
+// index onto the given query, selecting only statuses
+// matching the parsed search query. If order is true,
+// results are also ordered by relevance.
+func (s *searchDB) matchStatuses(
+	q *bun.SelectQuery,
+	parsed *searchQuery,
+	order bool,
+) *bun.SelectQuery {
 	switch d := s.db.Dialect().Name(); d {
 
 	case dialect.SQLite:
-		statusText = statusText.ColumnExpr(
-			"? || COALESCE(?, ?) AS ?",
-			bun.Ident("status.content"), bun.Ident("status.content_warning"), "",
-			bun.Ident("status_text"))
+		q = q.
+			Join("JOIN ? ON ? = ?",
+				bun.Ident("status_search_fts"),
+				bun.Ident("status_search_fts.status_id"),
+				bun.Ident("status.id"),
+			).
+			Where("? MATCH ?",
+				bun.Ident("status_search_fts"),
+				parsed.sqliteMatch("", false),
+			)
+
+		if order {
+			// Rank is bm25 by default,
+			// lower values are better.
+			q = q.OrderExpr("?", bun.Ident("status_search_fts.rank"))
+		}
 
 	case dialect.PG:
-		statusText = statusText.ColumnExpr(
-			"CONCAT(?, COALESCE(?, ?)) AS ?",
-			bun.Ident("status.content"), bun.Ident("status.content_warning"), "",
-			bun.Ident("status_text"))
+		tsquery := parsed.pgTSQuery("", false)
+
+		q = q.
+			Join("JOIN ? ON ? = ?",
+				bun.Ident("status_search"),
+				bun.Ident("status_search.status_id"),
+				bun.Ident("status.id"),
+			).
+			Where("? @@ to_tsquery('simple', ?)",
+				bun.Ident("status_search.vector"),
+				tsquery,
+			)
+
+		if order {
+			q = q.OrderExpr("ts_rank(?, to_tsquery('simple', ?)) DESC",
+				bun.Ident("status_search.vector"),
+				tsquery,
+			)
+		}
 
 	default:
 		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
 	}
 
-	return statusText
+	return q
 }
 
 // Query example (SQLite):
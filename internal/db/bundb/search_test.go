@@ -19,10 +19,12 @@ package bundb_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type SearchTestSuite struct {
@@ -123,6 +125,190 @@ func (suite *SearchTestSuite) TestSearchStatusesFromAccount() {
 	}
 }
 
+func (suite *SearchTestSuite) TestSearchStatusesPhrase() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Words in the right order.
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, `"little gif"`, "", "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal("01F8MH82FYRXD2RC6108DAJ5HB", statuses[0].ID)
+	}
+
+	// Words in the wrong order.
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, `"gif little"`, "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	// Words in the wrong order, but not as a phrase.
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, `gif little`, "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesHasMedia() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "trent has:media", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, "sloths has:media", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	// Operator alone.
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, "has:media", "", "", "", 10, 0)
+	suite.NoError(err)
+	for _, status := range statuses {
+		suite.NotEmpty(status.AttachmentIDs)
+	}
+	suite.NotEmpty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesBeforeAfter() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "post before:2022-01-01", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 3)
+
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, "post after:2022-01-01", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	// After is exclusive of the given day.
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, "sloths after:2022-05-20", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	// Invalid dates are treated as text.
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, "post before:yesterday", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesRelevance() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Put two statuses mentioning kohlrabi, the
+	// older of which is much more relevant.
+	var ids []string
+	for i, content := range []string{
+		"kohlrabi! kohlrabi! kohlrabi!",
+		"it's a long post that mentions kohlrabi once, amongst lots of other words about other things",
+	} {
+		status := &gtsmodel.Status{}
+		*status = *suite.testStatuses["local_account_1_status_1"]
+		status.ID = fmt.Sprintf("01JA7Z8ZTTPBRW3H3N4FHKRWV%d", i)
+		status.URI = status.URI + "/" + status.ID
+		status.URL = status.URL + "/" + status.ID
+		status.Content = content
+		suite.NoError(suite.db.PutStatus(ctx, status))
+		ids = append(ids, status.ID)
+	}
+
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, "kohlrabi", "", "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 2) {
+		suite.Equal(ids[0], statuses[0].ID)
+		suite.Equal(ids[1], statuses[1].ID)
+	}
+
+	// Page by offset.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "kohlrabi", "", "", "", 1, 1)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(ids[1], statuses[0].ID)
+	}
+
+	// Paging by ID orders by ID instead.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "kohlrabi", "", "ZZZZZZZZZZZZZZZZZZZZZZZZZZ", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 2) {
+		suite.Equal(ids[1], statuses[0].ID)
+	}
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesIndexUpdated() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	status := &gtsmodel.Status{}
+	*status = *suite.testStatuses["local_account_1_status_1"]
+
+	status.Content = "hmm, turnips"
+	suite.NoError(suite.db.UpdateStatus(ctx, status, "content"))
+
+	// Old content is no longer indexed.
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, "hello", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	// New content is.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "turnips", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	// Deleted statuses are removed from the index.
+	suite.NoError(suite.db.DeleteStatusByID(ctx, status.ID))
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "turnips", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchAccountsIndexUpdated() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	account := &gtsmodel.Account{}
+	*account = *suite.testAccounts["local_account_2"]
+
+	account.DisplayName = "Professor Parsnip"
+	suite.NoError(suite.db.UpdateAccount(ctx, account, "display_name"))
+
+	// Matches words by prefix.
+	accounts, err := suite.db.SearchForAccounts(ctx, testAccount.ID, "parsn", "", "", 10, false, 0)
+	suite.NoError(err)
+	if suite.Len(accounts, 1) {
+		suite.Equal(account.ID, accounts[0].ID)
+	}
+
+	// Deleted accounts are removed from the index.
+	suite.NoError(suite.db.DeleteAccount(ctx, account.ID))
+	accounts, err = suite.db.SearchForAccounts(ctx, testAccount.ID, "parsnip", "", "", 10, false, 0)
+	suite.NoError(err)
+	suite.Empty(accounts)
+}
+
+func (suite *SearchTestSuite) TestRebuildSearchIndex() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	suite.NoError(suite.db.RebuildSearchIndex(ctx))
+
+	// Statuses and accounts are
+	// all indexed again afterwards.
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, "hello", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, `"little gif"`, "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	accounts, err := suite.db.SearchForAccounts(ctx, testAccount.ID, "1happy", "", "", 10, false, 0)
+	suite.NoError(err)
+	suite.Len(accounts, 1)
+
+	// Rebuilding again is harmless.
+	suite.NoError(suite.db.RebuildSearchIndex(ctx))
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "hello", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+}
+
 func (suite *SearchTestSuite) TestSearchTags() {
 	// Search with full tag string.
 	tags, err := suite.db.SearchForTags(context.Background(), "welcome", "", "", 10, 0)
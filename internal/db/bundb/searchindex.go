@@ -0,0 +1,395 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+// The full-text search index is made up of one table for
+// statuses and one for accounts, which are kept up to date
+// by the status and account database functions.
+//
+// On Postgres, each table holds a tsvector per status or
+// account, indexed using GIN. Account vectors are weighted:
+// 'A' for username + display name, 'B' for the account note.
+//
+// On SQLite, each table holds the normalized text per status
+// or account, and is used as an external content table for
+// an FTS5 virtual table ("*_fts"), kept in sync by triggers.
+// The content table has an INTEGER PRIMARY KEY to use as
+// the FTS5 rowid, since those are stable across VACUUM.
+//
+// In both cases the text is normalized with searchText
+// before being stored, and queries use searchWords, so
+// that both sides of a search split words identically.
+
+// searchIndexBatchSize is the number of statuses
+// or accounts to index at once when rebuilding.
+const searchIndexBatchSize = 500
+
+// sqliteSearchIndex contains the statements to
+// create the SQLite full-text search index.
+var sqliteSearchIndex = []string{
+	`CREATE TABLE IF NOT EXISTS "status_search" ("id" INTEGER PRIMARY KEY, "status_id" CHAR(26) NOT NULL UNIQUE, "content" TEXT NOT NULL)`,
+	`CREATE VIRTUAL TABLE IF NOT EXISTS "status_search_fts" USING fts5("status_id" UNINDEXED, "content", content = 'status_search', content_rowid = 'id', tokenize = 'unicode61 remove_diacritics 2')`,
+	`CREATE TRIGGER IF NOT EXISTS "status_search_ai" AFTER INSERT ON "status_search" BEGIN INSERT INTO "status_search_fts" ("rowid", "status_id", "content") VALUES (new."id", new."status_id", new."content"); END`,
+	`CREATE TRIGGER IF NOT EXISTS "status_search_ad" AFTER DELETE ON "status_search" BEGIN INSERT INTO "status_search_fts" ("status_search_fts", "rowid", "status_id", "content") VALUES ('delete', old."id", old."status_id", old."content"); END`,
+	`CREATE TABLE IF NOT EXISTS "account_search" ("id" INTEGER PRIMARY KEY, "account_id" CHAR(26) NOT NULL UNIQUE, "name" TEXT NOT NULL, "note" TEXT NOT NULL)`,
+	`CREATE VIRTUAL TABLE IF NOT EXISTS "account_search_fts" USING fts5("account_id" UNINDEXED, "name", "note", content = 'account_search', content_rowid = 'id', tokenize = 'unicode61 remove_diacritics 2')`,
+	`CREATE TRIGGER IF NOT EXISTS "account_search_ai" AFTER INSERT ON "account_search" BEGIN INSERT INTO "account_search_fts" ("rowid", "account_id", "name", "note") VALUES (new."id", new."account_id", new."name", new."note"); END`,
+	`CREATE TRIGGER IF NOT EXISTS "account_search_ad" AFTER DELETE ON "account_search" BEGIN INSERT INTO "account_search_fts" ("account_search_fts", "rowid", "account_id", "name", "note") VALUES ('delete', old."id", old."account_id", old."name", old."note"); END`,
+}
+
+// pgSearchIndex contains the statements to
+// create the Postgres full-text search index.
+var pgSearchIndex = []string{
+	`CREATE TABLE IF NOT EXISTS "status_search" ("status_id" CHAR(26) NOT NULL PRIMARY KEY, "vector" TSVECTOR NOT NULL)`,
+	`CREATE INDEX IF NOT EXISTS "status_search_vector_idx" ON "status_search" USING GIN ("vector")`,
+	`CREATE TABLE IF NOT EXISTS "account_search" ("account_id" CHAR(26) NOT NULL PRIMARY KEY, "vector" TSVECTOR NOT NULL)`,
+	`CREATE INDEX IF NOT EXISTS "account_search_vector_idx" ON "account_search" USING GIN ("vector")`,
+}
+
+// searchIndexTables contains the tables making up the full-text
+// search index, in an order in which they can be safely dropped.
+var searchIndexTables = []string{
+	"status_search_fts",
+	"status_search",
+	"account_search_fts",
+	"account_search",
+}
+
+// statusSearchText returns the normalized text
+// of status to store in the full-text search index.
+func statusSearchText(status *gtsmodel.Status) string {
+	return searchText(
+		status.ContentWarning,
+		text.SanitizeToPlaintext(status.Content),
+	)
+}
+
+// accountSearchText returns the normalized name (username
+// + display name) and note of account to store in the
+// full-text search index.
+func accountSearchText(account *gtsmodel.Account) (name string, note string) {
+	name = searchText(account.Username, account.DisplayName)
+	note = searchText(text.SanitizeToPlaintext(account.Note))
+	return
+}
+
+// indexStatus (re)indexes the given status in the full-text
+// search index. Boosts and statuses without text are skipped.
+func indexStatus(ctx context.Context, tx bun.IDB, status *gtsmodel.Status) error {
+	if err := unindexStatus(ctx, tx, status.ID); err != nil {
+		return err
+	}
+
+	if status.BoostOfID != "" {
+		// Boosts have no text of their own.
+		return nil
+	}
+
+	content := statusSearchText(status)
+	if content == "" {
+		// Nothing to index.
+		return nil
+	}
+
+	var err error
+	switch d := tx.Dialect().Name(); d {
+	case dialect.SQLite:
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO ? (?, ?) VALUES (?, ?)",
+			bun.Ident("status_search"),
+			bun.Ident("status_id"), bun.Ident("content"),
+			status.ID, content,
+		)
+
+	case dialect.PG:
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO ? (?, ?) VALUES (?, to_tsvector('simple', ?))",
+			bun.Ident("status_search"),
+			bun.Ident("status_id"), bun.Ident("vector"),
+			status.ID, content,
+		)
+
+	default:
+		log.Panicf(ctx, "db conn %s was neither pg nor sqlite", d)
+	}
+
+	return err
+}
+
+// unindexStatus removes the status with given
+// ID from the full-text search index, if present.
+func unindexStatus(ctx context.Context, tx bun.IDB, statusID string) error {
+	_, err := tx.NewDelete().
+		Table("status_search").
+		Where("? = ?", bun.Ident("status_id"), statusID).
+		Exec(ctx)
+	return err
+}
+
+// indexAccount (re)indexes the given
+// account in the full-text search index.
+func indexAccount(ctx context.Context, tx bun.IDB, account *gtsmodel.Account) error {
+	if err := unindexAccount(ctx, tx, account.ID); err != nil {
+		return err
+	}
+
+	name, note := accountSearchText(account)
+
+	var err error
+	switch d := tx.Dialect().Name(); d {
+	case dialect.SQLite:
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO ? (?, ?, ?) VALUES (?, ?, ?)",
+			bun.Ident("account_search"),
+			bun.Ident("account_id"), bun.Ident("name"), bun.Ident("note"),
+			account.ID, name, note,
+		)
+
+	case dialect.PG:
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO ? (?, ?) VALUES (?, "+
+				"setweight(to_tsvector('simple', ?), 'A') || "+
+				"setweight(to_tsvector('simple', ?), 'B'))",
+			bun.Ident("account_search"),
+			bun.Ident("account_id"), bun.Ident("vector"),
+			account.ID, name, note,
+		)
+
+	default:
+		log.Panicf(ctx, "db conn %s was neither pg nor sqlite", d)
+	}
+
+	return err
+}
+
+// unindexAccount removes the account with given
+// ID from the full-text search index, if present.
+func unindexAccount(ctx context.Context, tx bun.IDB, accountID string) error {
+	_, err := tx.NewDelete().
+		Table("account_search").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx)
+	return err
+}
+
+// searchIndexColumnsChanged returns whether an update of the
+// given columns may change indexed text. No columns indicates
+// that all columns are being updated.
+func searchIndexColumnsChanged(columns []string, indexed ...string) bool {
+	if len(columns) == 0 {
+		return true
+	}
+
+	for _, column := range columns {
+		for _, i := range indexed {
+			if column == i {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// insertSearchValues returns a VALUES list of n
+// rows of cols placeholders each, eg., "(?, ?), (?, ?)".
+func insertSearchValues(n int, cols int) string {
+	row := "(" + strings.Repeat("?, ", cols-1) + "?)"
+	return strings.Repeat(row+", ", n-1) + row
+}
+
+// insertStatusesSearch indexes the given statuses in the full-text
+// search index with a single set-based INSERT, skipping boosts,
+// statuses without text, and statuses which are already indexed.
+func insertStatusesSearch(ctx context.Context, tx bun.IDB, statuses []*gtsmodel.Status) error {
+	args := make([]any, 0, 2*len(statuses))
+	for _, status := range statuses {
+		if status.BoostOfID != "" {
+			continue
+		}
+
+		content := statusSearchText(status)
+		if content == "" {
+			continue
+		}
+
+		args = append(args, status.ID, content)
+	}
+
+	if len(args) == 0 {
+		// Nothing to index.
+		return nil
+	}
+
+	values := insertSearchValues(len(args)/2, 2)
+
+	var query string
+	switch d := tx.Dialect().Name(); d {
+	case dialect.SQLite:
+		query = `INSERT INTO "status_search" ("status_id", "content") ` +
+			`VALUES ` + values + ` ON CONFLICT DO NOTHING`
+	case dialect.PG:
+		query = `INSERT INTO "status_search" ("status_id", "vector") ` +
+			`SELECT "v"."id", to_tsvector('simple', "v"."content") ` +
+			`FROM (VALUES ` + values + `) AS "v" ("id", "content") ON CONFLICT DO NOTHING`
+	default:
+		log.Panicf(ctx, "db conn %s was neither pg nor sqlite", d)
+	}
+
+	_, err := tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+// insertAccountsSearch indexes the given accounts in the full-text
+// search index with a single set-based INSERT, skipping accounts
+// which are already indexed.
+func insertAccountsSearch(ctx context.Context, tx bun.IDB, accounts []*gtsmodel.Account) error {
+	if len(accounts) == 0 {
+		// Nothing to index.
+		return nil
+	}
+
+	args := make([]any, 0, 3*len(accounts))
+	for _, account := range accounts {
+		name, note := accountSearchText(account)
+		args = append(args, account.ID, name, note)
+	}
+
+	values := insertSearchValues(len(accounts), 3)
+
+	var query string
+	switch d := tx.Dialect().Name(); d {
+	case dialect.SQLite:
+		query = `INSERT INTO "account_search" ("account_id", "name", "note") ` +
+			`VALUES ` + values + ` ON CONFLICT DO NOTHING`
+	case dialect.PG:
+		query = `INSERT INTO "account_search" ("account_id", "vector") ` +
+			`SELECT "v"."id", setweight(to_tsvector('simple', "v"."name"), 'A') || setweight(to_tsvector('simple', "v"."note"), 'B') ` +
+			`FROM (VALUES ` + values + `) AS "v" ("id", "name", "note") ON CONFLICT DO NOTHING`
+	default:
+		log.Panicf(ctx, "db conn %s was neither pg nor sqlite", d)
+	}
+
+	_, err := tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (s *searchDB) RebuildSearchIndex(ctx context.Context) error {
+	if err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Drop any existing index.
+		for _, table := range searchIndexTables {
+			if _, err := tx.
+				NewDropTable().
+				Table(table).
+				IfExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		// Recreate it.
+		var statements []string
+		switch d := tx.Dialect().Name(); d {
+		case dialect.SQLite:
+			statements = sqliteSearchIndex
+		case dialect.PG:
+			statements = pgSearchIndex
+		default:
+			log.Panicf(ctx, "db conn %s was neither pg nor sqlite", d)
+		}
+
+		for _, statement := range statements {
+			if _, err := tx.ExecContext(ctx, statement); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Index all statuses, page by page. Each page
+	// is a single INSERT, so that the rebuild doesn't
+	// hold one long transaction open over the whole
+	// table, and statuses created in the meantime
+	// (already indexed as normal) are left alone.
+	for maxID := id.Highest; ; {
+		var statuses []*gtsmodel.Status
+		if err := s.db.
+			NewSelect().
+			Model(&statuses).
+			Column("id", "content", "content_warning").
+			Where("? IS NULL", bun.Ident("boost_of_id")).
+			Where("? < ?", bun.Ident("id"), maxID).
+			Order("id DESC").
+			Limit(searchIndexBatchSize).
+			Scan(ctx); err != nil {
+			return err
+		}
+
+		if len(statuses) == 0 {
+			break
+		}
+
+		if err := insertStatusesSearch(ctx, s.db, statuses); err != nil {
+			return err
+		}
+
+		maxID = statuses[len(statuses)-1].ID
+	}
+
+	// Index all accounts, page by page.
+	for maxID := id.Highest; ; {
+		var accounts []*gtsmodel.Account
+		if err := s.db.
+			NewSelect().
+			Model(&accounts).
+			Column("id", "username", "display_name", "note").
+			Where("? < ?", bun.Ident("id"), maxID).
+			Order("id DESC").
+			Limit(searchIndexBatchSize).
+			Scan(ctx); err != nil {
+			return err
+		}
+
+		if len(accounts) == 0 {
+			break
+		}
+
+		if err := insertAccountsSearch(ctx, s.db, accounts); err != nil {
+			return err
+		}
+
+		maxID = accounts[len(accounts)-1].ID
+	}
+
+	return nil
+}
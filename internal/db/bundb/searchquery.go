@@ -0,0 +1,250 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// searchDateLayout is the layout expected for
+// the value of before: and after: operators.
+const searchDateLayout = time.DateOnly
+
+// searchQuery is a free-text search query parsed
+// into words, quoted phrases and search operators,
+// ready to be turned into a full-text index query.
+type searchQuery struct {
+	// terms are the individual, normalized
+	// words that must appear in results.
+	terms []string
+
+	// phrases are quoted phrases that must
+	// appear in results, each split into
+	// its ordered, normalized words.
+	phrases [][]string
+
+	// hasMedia is set by the has:media operator.
+	hasMedia bool
+
+	// before is set by the before:YYYY-MM-DD operator,
+	// and is the start of the given day (UTC).
+	before time.Time
+
+	// after is set by the after:YYYY-MM-DD operator,
+	// and is the start of the day AFTER the given day
+	// (UTC), ie., the given day itself is excluded.
+	after time.Time
+}
+
+// parseSearchQuery parses the given free-text query. Text in
+// double quotes is treated as a phrase, and the operators
+// has:media, before:YYYY-MM-DD and after:YYYY-MM-DD are
+// recognized. Anything else, including operators with
+// values we don't understand, is treated as plain text.
+func parseSearchQuery(query string) searchQuery {
+	var parsed searchQuery
+
+	for query != "" {
+		query = strings.TrimLeftFunc(query, unicode.IsSpace)
+		if query == "" {
+			break
+		}
+
+		if query[0] == '"' {
+			// Quoted phrase, read up to the
+			// closing quote or end of query.
+			var phrase string
+			phrase, query, _ = strings.Cut(query[1:], `"`)
+
+			words := searchWords(phrase)
+			switch len(words) {
+			case 0:
+				// Nothing to search.
+			case 1:
+				// Just a single word.
+				parsed.terms = append(parsed.terms, words[0])
+			default:
+				parsed.phrases = append(parsed.phrases, words)
+			}
+			continue
+		}
+
+		// Read the next whitespace-separated part.
+		end := strings.IndexFunc(query, unicode.IsSpace)
+		if end == -1 {
+			end = len(query)
+		}
+		part := query[:end]
+		query = query[end:]
+
+		if parsed.parseOperator(part) {
+			continue
+		}
+
+		parsed.terms = append(parsed.terms, searchWords(part)...)
+	}
+
+	return parsed
+}
+
+// parseOperator attempts to parse part as a search
+// operator, returning false if it wasn't recognized.
+func (s *searchQuery) parseOperator(part string) bool {
+	op, arg, ok := strings.Cut(part, ":")
+	if !ok {
+		return false
+	}
+
+	switch strings.ToLower(op) {
+	case "has":
+		if strings.ToLower(arg) != "media" {
+			return false
+		}
+		s.hasMedia = true
+		return true
+
+	case "before":
+		t, err := time.Parse(searchDateLayout, arg)
+		if err != nil {
+			return false
+		}
+		s.before = t
+		return true
+
+	case "after":
+		t, err := time.Parse(searchDateLayout, arg)
+		if err != nil {
+			return false
+		}
+		s.after = t.AddDate(0, 0, 1)
+		return true
+
+	default:
+		return false
+	}
+}
+
+// hasText returns whether the query contains any
+// words or phrases to look up in the full-text index.
+func (s *searchQuery) hasText() bool {
+	return len(s.terms) > 0 || len(s.phrases) > 0
+}
+
+// hasOperators returns whether the query contains
+// any operators with which to filter results.
+func (s *searchQuery) hasOperators() bool {
+	return s.hasMedia || !s.before.IsZero() || !s.after.IsZero()
+}
+
+// sqliteMatch returns the query's words and phrases as
+// an SQLite FTS5 MATCH expression. If column is set,
+// matches are restricted to that column of the index.
+// If prefix is set, words also match as word prefixes.
+//
+// Words only ever contain letters, numbers and marks
+// (see searchWords) so they need no further escaping.
+func (s *searchQuery) sqliteMatch(column string, prefix bool) string {
+	var (
+		parts  = make([]string, 0, len(s.terms)+len(s.phrases))
+		filter string
+		suffix string
+	)
+
+	if column != "" {
+		filter = column + " : "
+	}
+
+	if prefix {
+		suffix = "*"
+	}
+
+	for _, term := range s.terms {
+		parts = append(parts, filter+`"`+term+`"`+suffix)
+	}
+
+	for _, phrase := range s.phrases {
+		parts = append(parts, filter+`"`+strings.Join(phrase, " ")+`"`)
+	}
+
+	// Space-separated parts are implicitly AND'ed.
+	return strings.Join(parts, " ")
+}
+
+// pgTSQuery returns the query's words and phrases as a
+// Postgres tsquery, to be used with to_tsquery. If weight
+// is set, matches are restricted to lexemes with that
+// weight. If prefix is set, words also match as prefixes.
+//
+// Words only ever contain letters, numbers and marks
+// (see searchWords) so they need no further escaping.
+func (s *searchQuery) pgTSQuery(weight string, prefix bool) string {
+	parts := make([]string, 0, len(s.terms)+len(s.phrases))
+
+	lexeme := func(word string, prefix bool) string {
+		l := "'" + word + "'"
+		if prefix || weight != "" {
+			l += ":"
+		}
+		if prefix {
+			l += "*"
+		}
+		return l + weight
+	}
+
+	for _, term := range s.terms {
+		parts = append(parts, lexeme(term, prefix))
+	}
+
+	for _, phrase := range s.phrases {
+		lexemes := make([]string, len(phrase))
+		for i, word := range phrase {
+			lexemes[i] = lexeme(word, false)
+		}
+		parts = append(parts, "("+strings.Join(lexemes, " <-> ")+")")
+	}
+
+	return strings.Join(parts, " & ")
+}
+
+// searchWords splits the given text into lowercase words
+// the way they're stored in the full-text search index:
+// runs of letters, numbers and marks, split on anything
+// else (whitespace, punctuation, symbols, underscores).
+func searchWords(text string) []string {
+	return strings.FieldsFunc(
+		strings.ToLower(text),
+		func(r rune) bool {
+			return !unicode.IsLetter(r) &&
+				!unicode.IsNumber(r) &&
+				!unicode.IsMark(r)
+		},
+	)
+}
+
+// searchText normalizes the given text into
+// space-separated searchWords, for storage
+// in the full-text search index.
+func searchText(text ...string) string {
+	var words []string
+	for _, t := range text {
+		words = append(words, searchWords(t)...)
+	}
+	return strings.Join(words, " ")
+}
@@ -181,7 +181,7 @@ func (s *statusDB) getStatus(ctx context.Context, lookup string, dbQuery func(*g
 func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status) error {
 	var (
 		err  error
-		errs = gtserror.NewMultiError(9)
+		errs = gtserror.NewMultiError(10)
 	)
 
 	if status.Account == nil {
@@ -243,6 +243,30 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if status.QuoteID != "" {
+		if status.Quote == nil {
+			// Status quote is not set, fetch from database.
+			status.Quote, err = s.GetStatusByID(
+				gtscontext.SetBarebones(ctx),
+				status.QuoteID,
+			)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				errs.Appendf("error populating status quote: %w", err)
+			}
+		}
+
+		if status.QuoteAccount == nil && status.QuoteAccountID != "" {
+			// Status quote author is not set, fetch from database.
+			status.QuoteAccount, err = s.state.DB.GetAccountByID(
+				gtscontext.SetBarebones(ctx),
+				status.QuoteAccountID,
+			)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				errs.Appendf("error populating status quote author: %w", err)
+			}
+		}
+	}
+
 	if status.PollID != "" && status.Poll == nil {
 		// Status poll is not set, fetch from database.
 		status.Poll, err = s.state.DB.GetPollByID(
@@ -298,13 +322,26 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if !status.EditsPopulated() {
+		// Status edits are out-of-date with IDs, repopulate.
+		status.Edits, err = s.state.DB.GetStatusEditsByIDs(
+			gtscontext.SetBarebones(ctx),
+			status.EditIDs,
+		)
+		if err != nil {
+			errs.Appendf("error populating status edits: %w", err)
+		}
+	}
+
 	if status.CreatedWithApplicationID != "" && status.CreatedWithApplication == nil {
 		// Populate the status' expected CreatedWithApplication (not always set).
+		// The application may since have been deleted by its creator, in which
+		// case we just leave it unset rather than failing to load the status.
 		status.CreatedWithApplication, err = s.state.DB.GetApplicationByID(
 			ctx, // these are already barebones
 			status.CreatedWithApplicationID,
 		)
-		if err != nil {
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
 			errs.Appendf("error populating status application: %w", err)
 		}
 	}
@@ -384,8 +421,12 @@ func (s *statusDB) PutStatus(ctx context.Context, status *gtsmodel.Status) error
 			}
 
 			// Finally, insert the status
-			_, err := tx.NewInsert().Model(status).Exec(ctx)
-			return err
+			if _, err := tx.NewInsert().Model(status).Exec(ctx); err != nil {
+				return err
+			}
+
+			// Add the status to the search index.
+			return indexStatus(ctx, tx, status)
 		})
 	})
 }
@@ -434,6 +475,22 @@ func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status, co
 				}
 			}
 
+			// delete links between this status and any emojis it no longer uses
+			if err := deleteStaleStatusLinks(ctx, tx,
+				"status_to_emojis", "emoji_id",
+				status.ID, status.EmojiIDs,
+			); err != nil {
+				return err
+			}
+
+			// delete links between this status and any tags it no longer uses
+			if err := deleteStaleStatusLinks(ctx, tx,
+				"status_to_tags", "tag_id",
+				status.ID, status.TagIDs,
+			); err != nil {
+				return err
+			}
+
 			// change the status ID of the media attachments to the new status
 			for _, a := range status.Attachments {
 				a.StatusID = status.ID
@@ -467,17 +524,52 @@ func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status, co
 			}
 
 			// Finally, update the status
-			_, err := tx.
+			if _, err := tx.
 				NewUpdate().
 				Model(status).
 				Column(columns...).
 				Where("? = ?", bun.Ident("status.id"), status.ID).
-				Exec(ctx)
-			return err
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			if !searchIndexColumnsChanged(columns,
+				"content", "content_warning",
+			) {
+				// Indexed text unchanged.
+				return nil
+			}
+
+			// Update the status in the search index.
+			return indexStatus(ctx, tx, status)
 		})
 	})
 }
 
+// deleteStaleStatusLinks deletes rows from the given status relational
+// table (e.g. status_to_tags) for status with ID, where the value in given
+// column is NOT contained in keep. i.e. links to no-longer-used models.
+func deleteStaleStatusLinks(
+	ctx context.Context,
+	tx bun.Tx,
+	table string,
+	column string,
+	statusID string,
+	keep []string,
+) error {
+	q := tx.NewDelete().
+		Table(table).
+		Where("? = ?", bun.Ident("status_id"), statusID)
+
+	if len(keep) > 0 {
+		// Only delete links NOT in the keep slice.
+		q = q.Where("? NOT IN (?)", bun.Ident(column), bun.In(keep))
+	}
+
+	_, err := q.Exec(ctx)
+	return err
+}
+
 func (s *statusDB) DeleteStatusByID(ctx context.Context, id string) error {
 	// Gather necessary fields from
 	// deleted for cache invaliation.
@@ -504,6 +596,21 @@ func (s *statusDB) DeleteStatusByID(ctx context.Context, id string) error {
 			return err
 		}
 
+		// Delete any historical
+		// edits of this status.
+		if _, err := tx.
+			NewDelete().
+			Table("status_edits").
+			Where("? = ?", bun.Ident("status_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Remove the status from the search index.
+		if err := unindexStatus(ctx, tx, id); err != nil {
+			return err
+		}
+
 		// Delete links between this status
 		// and any threads it was a part of.
 		if _, err := tx.
@@ -536,6 +643,9 @@ func (s *statusDB) DeleteStatusByID(ctx context.Context, id string) error {
 		return err
 	}
 
+	// Invalidate any cached edits of this status.
+	s.state.Caches.DB.StatusEdit.Invalidate("StatusID", id)
+
 	// Invalidate cached status by its ID, manually
 	// call the invalidate hook in case not cached.
 	s.state.Caches.DB.Status.Invalidate("ID", id)
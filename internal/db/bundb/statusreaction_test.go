@@ -0,0 +1,142 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type StatusReactionTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *StatusReactionTestSuite) putReaction(
+	ctx context.Context,
+	id string,
+	account *gtsmodel.Account,
+	status *gtsmodel.Status,
+	name string,
+	emojiID string,
+) *gtsmodel.StatusReaction {
+	reaction := &gtsmodel.StatusReaction{
+		ID:              id,
+		AccountID:       account.ID,
+		TargetAccountID: status.AccountID,
+		StatusID:        status.ID,
+		Name:            name,
+		EmojiID:         emojiID,
+		URI:             "http://localhost:8080/users/" + account.Username + "/liked/" + id,
+	}
+
+	if err := suite.db.PutStatusReaction(ctx, reaction); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return reaction
+}
+
+func (suite *StatusReactionTestSuite) TestPutGetStatusReactions() {
+	var (
+		ctx      = context.Background()
+		status   = suite.testStatuses["admin_account_status_1"]
+		account1 = suite.testAccounts["local_account_1"]
+		account2 = suite.testAccounts["local_account_2"]
+		emoji    = suite.testEmojis["rainbow"]
+	)
+
+	suite.putReaction(ctx, "01JAFYN4JPS9N5ZPPDJGEDAMDY", account1, status, "🐸", "")
+	suite.putReaction(ctx, "01JAFYQ0SFX3Q2PCRGJ5WY4M4F", account2, status, "rainbow", emoji.ID)
+
+	reactions, err := suite.db.GetStatusReactions(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Len(reactions, 2) {
+		suite.FailNow("")
+	}
+
+	// Should be oldest first,
+	// and fully populated.
+	suite.Equal("🐸", reactions[0].Name)
+	suite.Nil(reactions[0].Emoji)
+	suite.Equal("rainbow", reactions[1].Name)
+	suite.NotNil(reactions[1].Emoji)
+	for _, reaction := range reactions {
+		suite.NotNil(reaction.Account)
+		suite.NotNil(reaction.TargetAccount)
+		suite.NotNil(reaction.Status)
+	}
+
+	// Should be able to get by account, status and name.
+	reaction, err := suite.db.GetStatusReaction(ctx, account2.ID, status.ID, "rainbow")
+	suite.NoError(err)
+	suite.Equal("01JAFYQ0SFX3Q2PCRGJ5WY4M4F", reaction.ID)
+
+	// Same account can react with another emoji...
+	suite.putReaction(ctx, "01JAFYRA3S5GQ0ZBAVVJ9JN8V7", account1, status, "rainbow", emoji.ID)
+
+	// ...but not with the same emoji again.
+	err = suite.db.PutStatusReaction(ctx, &gtsmodel.StatusReaction{
+		ID:              "01JAFYS5TSKVQ1NNH4JRVQM2W4",
+		AccountID:       account1.ID,
+		TargetAccountID: status.AccountID,
+		StatusID:        status.ID,
+		Name:            "🐸",
+		URI:             "http://localhost:8080/users/the_mighty_zork/liked/01JAFYS5TSKVQ1NNH4JRVQM2W4",
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+}
+
+func (suite *StatusReactionTestSuite) TestDeleteStatusReactions() {
+	var (
+		ctx     = context.Background()
+		status  = suite.testStatuses["admin_account_status_1"]
+		account = suite.testAccounts["local_account_1"]
+	)
+
+	reaction := suite.putReaction(ctx, "01JAFYN4JPS9N5ZPPDJGEDAMDY", account, status, "🐸", "")
+
+	if err := suite.db.DeleteStatusReactionByID(ctx, reaction.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err := suite.db.GetStatusReactionByID(ctx, reaction.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Put it back then delete
+	// all reactions to the status.
+	suite.putReaction(ctx, "01JAFYQ0SFX3Q2PCRGJ5WY4M4F", account, status, "🐸", "")
+
+	if err := suite.db.DeleteStatusReactionsForStatus(ctx, status.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	reactions, err := suite.db.GetStatusReactions(ctx, status.ID)
+	suite.NoError(err)
+	suite.Empty(reactions)
+}
+
+func TestStatusReactionTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusReactionTestSuite))
+}
@@ -0,0 +1,282 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type suggestionDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (s *suggestionDB) GetFeaturedSuggestions(ctx context.Context) ([]*gtsmodel.FeaturedSuggestion, error) {
+	suggestions := make([]*gtsmodel.FeaturedSuggestion, 0)
+
+	if err := s.db.
+		NewSelect().
+		Model(&suggestions).
+		Order("featured_suggestion.id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// Populate featured accounts, skipping
+	// any that have since been deleted.
+	populated := suggestions[:0]
+	for _, suggestion := range suggestions {
+		if err := s.populateFeaturedSuggestion(ctx, suggestion); err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				return nil, err
+			}
+
+			log.Warnf(ctx, "missing account %s for featured suggestion", suggestion.AccountID)
+			continue
+		}
+		populated = append(populated, suggestion)
+	}
+
+	return populated, nil
+}
+
+func (s *suggestionDB) GetFeaturedSuggestionByAccountID(ctx context.Context, accountID string) (*gtsmodel.FeaturedSuggestion, error) {
+	var suggestion gtsmodel.FeaturedSuggestion
+
+	if err := s.db.
+		NewSelect().
+		Model(&suggestion).
+		Where("? = ?", bun.Ident("featured_suggestion.account_id"), accountID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.populateFeaturedSuggestion(ctx, &suggestion); err != nil {
+		return nil, err
+	}
+
+	return &suggestion, nil
+}
+
+func (s *suggestionDB) populateFeaturedSuggestion(ctx context.Context, suggestion *gtsmodel.FeaturedSuggestion) error {
+	if suggestion.Account != nil {
+		return nil
+	}
+
+	var err error
+	suggestion.Account, err = s.state.DB.GetAccountByID(ctx, suggestion.AccountID)
+	if err != nil {
+		return gtserror.Newf("error populating featured suggestion account: %w", err)
+	}
+
+	return nil
+}
+
+func (s *suggestionDB) PutFeaturedSuggestion(ctx context.Context, suggestion *gtsmodel.FeaturedSuggestion) error {
+	_, err := s.db.
+		NewInsert().
+		Model(suggestion).
+		Exec(ctx)
+	return err
+}
+
+func (s *suggestionDB) DeleteFeaturedSuggestionByAccountID(ctx context.Context, accountID string) error {
+	_, err := s.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("featured_suggestions"), bun.Ident("featured_suggestion")).
+		Where("? = ?", bun.Ident("featured_suggestion.account_id"), accountID).
+		Exec(ctx)
+	return err
+}
+
+func (s *suggestionDB) GetSuggestionDismissedAccountIDs(ctx context.Context, accountID string) ([]string, error) {
+	accountIDs := make([]string, 0)
+
+	if err := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("suggestion_dismissals"), bun.Ident("suggestion_dismissal")).
+		Column("suggestion_dismissal.target_account_id").
+		Where("? = ?", bun.Ident("suggestion_dismissal.account_id"), accountID).
+		Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	return accountIDs, nil
+}
+
+func (s *suggestionDB) PutSuggestionDismissal(ctx context.Context, dismissal *gtsmodel.SuggestionDismissal) error {
+	_, err := s.db.
+		NewInsert().
+		Model(dismissal).
+		Exec(ctx)
+	return err
+}
+
+func (s *suggestionDB) DeleteSuggestionsByAccountID(ctx context.Context, accountID string) error {
+	return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("suggestion_dismissals"), bun.Ident("suggestion_dismissal")).
+			Where("? = ?", bun.Ident("suggestion_dismissal.account_id"), accountID).
+			WhereOr("? = ?", bun.Ident("suggestion_dismissal.target_account_id"), accountID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		_, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("featured_suggestions"), bun.Ident("featured_suggestion")).
+			Where("? = ?", bun.Ident("featured_suggestion.account_id"), accountID).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (s *suggestionDB) GetFriendsOfFriendsIDs(ctx context.Context, accountID string, limit int) ([]string, error) {
+	accountIDs := make([]string, 0, limit)
+
+	if err := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
+		// Join on follows of each followed account.
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("follows"), bun.Ident("friend_follow"),
+			bun.Ident("friend_follow.account_id"), bun.Ident("follow.target_account_id"),
+		).
+		// Join on settings of each followed account,
+		// which will only be present for local accounts.
+		Join(
+			"LEFT JOIN ? AS ? ON ? = ?",
+			bun.Ident("account_settings"), bun.Ident("settings"),
+			bun.Ident("settings.account_id"), bun.Ident("follow.target_account_id"),
+		).
+		Column("friend_follow.target_account_id").
+		Where("? = ?", bun.Ident("follow.account_id"), accountID).
+		Where("? != ?", bun.Ident("friend_follow.target_account_id"), accountID).
+		Where("? NOT IN (?)", bun.Ident("friend_follow.target_account_id"), s.followingQuery(accountID)).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("settings.hide_collections")).
+				WhereOr("? = ?", bun.Ident("settings.hide_collections"), false)
+		}).
+		Group("friend_follow.target_account_id").
+		OrderExpr("COUNT(*) DESC").
+		Order("friend_follow.target_account_id ASC").
+		Limit(limit).
+		Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	return accountIDs, nil
+}
+
+func (s *suggestionDB) GetInteractedAccountIDs(ctx context.Context, accountID string, limit int) ([]string, error) {
+	// Select target account IDs of faves by the account.
+	faves := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+		ColumnExpr("? AS ?", bun.Ident("status_fave.target_account_id"), bun.Ident("account_id")).
+		Where("? = ?", bun.Ident("status_fave.account_id"), accountID)
+
+	// Select replied-to account IDs of replies by the account.
+	replies := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		ColumnExpr("? AS ?", bun.Ident("status.in_reply_to_account_id"), bun.Ident("account_id")).
+		Where("? = ?", bun.Ident("status.account_id"), accountID).
+		Where("? IS NOT NULL", bun.Ident("status.in_reply_to_account_id"))
+
+	// Select boosted account IDs of boosts by the account.
+	boosts := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		ColumnExpr("? AS ?", bun.Ident("status.boost_of_account_id"), bun.Ident("account_id")).
+		Where("? = ?", bun.Ident("status.account_id"), accountID).
+		Where("? IS NOT NULL", bun.Ident("status.boost_of_account_id"))
+
+	accountIDs := make([]string, 0, limit)
+
+	if err := s.db.
+		NewSelect().
+		// Bun wraps union queries in parentheses,
+		// which SQLite doesn't support, so select
+		// from each of them as subqueries instead.
+		TableExpr("(SELECT * FROM (?) AS ? UNION ALL SELECT * FROM (?) AS ? UNION ALL SELECT * FROM (?) AS ?) AS ?",
+			faves, bun.Ident("faves"),
+			replies, bun.Ident("replies"),
+			boosts, bun.Ident("boosts"),
+			bun.Ident("interaction"),
+		).
+		Column("interaction.account_id").
+		Where("? != ?", bun.Ident("interaction.account_id"), accountID).
+		Where("? NOT IN (?)", bun.Ident("interaction.account_id"), s.followingQuery(accountID)).
+		Group("interaction.account_id").
+		OrderExpr("COUNT(*) DESC").
+		Order("interaction.account_id ASC").
+		Limit(limit).
+		Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	return accountIDs, nil
+}
+
+func (s *suggestionDB) GetMostFollowedLocalAccountIDs(ctx context.Context, limit int) ([]string, error) {
+	accountIDs := make([]string, 0, limit)
+
+	if err := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("account"),
+			bun.Ident("account.id"), bun.Ident("follow.target_account_id"),
+		).
+		Column("follow.target_account_id").
+		Where("? IS NULL", bun.Ident("account.domain")).
+		Where("? = ?", bun.Ident("account.discoverable"), true).
+		Where("? IS NULL", bun.Ident("account.suspended_at")).
+		Group("follow.target_account_id").
+		OrderExpr("COUNT(*) DESC").
+		Order("follow.target_account_id ASC").
+		Limit(limit).
+		Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	return accountIDs, nil
+}
+
+// followingQuery returns a subquery selecting
+// the IDs of accounts followed by the account.
+func (s *suggestionDB) followingQuery(accountID string) *bun.SelectQuery {
+	return s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("following")).
+		Column("following.target_account_id").
+		Where("? = ?", bun.Ident("following.account_id"), accountID)
+}
@@ -0,0 +1,175 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type SuggestionTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *SuggestionTestSuite) TestGetFeaturedSuggestions() {
+	featured, err := suite.db.GetFeaturedSuggestions(context.Background())
+	suite.NoError(err)
+	suite.Len(featured, 1)
+	suite.Equal(suite.testAccounts["local_account_1"].ID, featured[0].AccountID)
+	suite.NotNil(featured[0].Account)
+}
+
+func (suite *SuggestionTestSuite) TestPutDeleteFeaturedSuggestion() {
+	ctx := context.Background()
+	accountID := suite.testAccounts["admin_account"].ID
+
+	suggestion := &gtsmodel.FeaturedSuggestion{
+		ID:                 id.NewULID(),
+		AccountID:          accountID,
+		CreatedByAccountID: accountID,
+	}
+	err := suite.db.PutFeaturedSuggestion(ctx, suggestion)
+	suite.NoError(err)
+
+	// Featuring the same account twice is a duplicate.
+	suggestion.ID = id.NewULID()
+	err = suite.db.PutFeaturedSuggestion(ctx, suggestion)
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	featured, err := suite.db.GetFeaturedSuggestions(ctx)
+	suite.NoError(err)
+	suite.Len(featured, 2)
+
+	err = suite.db.DeleteFeaturedSuggestionByAccountID(ctx, accountID)
+	suite.NoError(err)
+
+	_, err = suite.db.GetFeaturedSuggestionByAccountID(ctx, accountID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *SuggestionTestSuite) TestSuggestionDismissals() {
+	ctx := context.Background()
+	accountID := suite.testAccounts["local_account_2"].ID
+	targetAccountID := suite.testAccounts["admin_account"].ID
+
+	dismissal := &gtsmodel.SuggestionDismissal{
+		ID:              id.NewULID(),
+		AccountID:       accountID,
+		TargetAccountID: targetAccountID,
+	}
+	err := suite.db.PutSuggestionDismissal(ctx, dismissal)
+	suite.NoError(err)
+
+	// Dismissing the same account twice is a duplicate.
+	dismissal.ID = id.NewULID()
+	err = suite.db.PutSuggestionDismissal(ctx, dismissal)
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	dismissed, err := suite.db.GetSuggestionDismissedAccountIDs(ctx, accountID)
+	suite.NoError(err)
+	suite.Equal([]string{targetAccountID}, dismissed)
+
+	// Deleting suggestions of the target should
+	// remove dismissals targeting it as well.
+	err = suite.db.DeleteSuggestionsByAccountID(ctx, targetAccountID)
+	suite.NoError(err)
+
+	dismissed, err = suite.db.GetSuggestionDismissedAccountIDs(ctx, accountID)
+	suite.NoError(err)
+	suite.Empty(dismissed)
+}
+
+func (suite *SuggestionTestSuite) TestDeleteSuggestionsByAccountIDFeatured() {
+	ctx := context.Background()
+	accountID := suite.testAccounts["local_account_1"].ID
+
+	err := suite.db.DeleteSuggestionsByAccountID(ctx, accountID)
+	suite.NoError(err)
+
+	featured, err := suite.db.GetFeaturedSuggestions(ctx)
+	suite.NoError(err)
+	suite.Empty(featured)
+}
+
+func (suite *SuggestionTestSuite) TestGetFriendsOfFriendsIDs() {
+	ctx := context.Background()
+
+	// Admin follows local_account_1,
+	// who follows local_account_2.
+	accountIDs, err := suite.db.GetFriendsOfFriendsIDs(ctx, suite.testAccounts["admin_account"].ID, 10)
+	suite.NoError(err)
+	suite.Equal([]string{suite.testAccounts["local_account_2"].ID}, accountIDs)
+
+	// Once local_account_1 hides their collections,
+	// their follows shouldn't be used any more.
+	settings, err := suite.db.GetAccountSettings(ctx, suite.testAccounts["local_account_1"].ID)
+	suite.NoError(err)
+
+	settings.HideCollections = util.Ptr(true)
+	err = suite.db.UpdateAccountSettings(ctx, settings, "hide_collections")
+	suite.NoError(err)
+
+	accountIDs, err = suite.db.GetFriendsOfFriendsIDs(ctx, suite.testAccounts["admin_account"].ID, 10)
+	suite.NoError(err)
+	suite.Empty(accountIDs)
+}
+
+func (suite *SuggestionTestSuite) TestGetInteractedAccountIDs() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	remoteStatus := suite.testStatuses["remote_account_1_status_1"]
+
+	// local_account_1 only interacts
+	// with accounts they follow.
+	accountIDs, err := suite.db.GetInteractedAccountIDs(ctx, account.ID, 10)
+	suite.NoError(err)
+	suite.Empty(accountIDs)
+
+	err = suite.db.PutStatusFave(ctx, &gtsmodel.StatusFave{
+		ID:              id.NewULID(),
+		AccountID:       account.ID,
+		TargetAccountID: remoteStatus.AccountID,
+		StatusID:        remoteStatus.ID,
+		URI:             "http://localhost:8080/whatever",
+	})
+	suite.NoError(err)
+
+	accountIDs, err = suite.db.GetInteractedAccountIDs(ctx, account.ID, 10)
+	suite.NoError(err)
+	suite.Equal([]string{remoteStatus.AccountID}, accountIDs)
+}
+
+func (suite *SuggestionTestSuite) TestGetMostFollowedLocalAccountIDs() {
+	// local_account_2 isn't discoverable.
+	accountIDs, err := suite.db.GetMostFollowedLocalAccountIDs(context.Background(), 10)
+	suite.NoError(err)
+	suite.Equal([]string{
+		suite.testAccounts["local_account_1"].ID,
+		suite.testAccounts["admin_account"].ID,
+	}, accountIDs)
+}
+
+func TestSuggestionTestSuite(t *testing.T) {
+	suite.Run(t, new(SuggestionTestSuite))
+}
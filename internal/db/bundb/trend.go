@@ -0,0 +1,214 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type trendDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (t *trendDB) GetTrendByID(ctx context.Context, id string) (*gtsmodel.Trend, error) {
+	var trend gtsmodel.Trend
+
+	if err := t.db.
+		NewSelect().
+		Model(&trend).
+		Where("? = ?", bun.Ident("trend.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &trend, nil
+}
+
+func (t *trendDB) GetTrendByTarget(ctx context.Context, trendType gtsmodel.TrendType, targetID string) (*gtsmodel.Trend, error) {
+	var trend gtsmodel.Trend
+
+	if err := t.db.
+		NewSelect().
+		Model(&trend).
+		Where("? = ?", bun.Ident("trend.type"), trendType).
+		Where("? = ?", bun.Ident("trend.target_id"), targetID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &trend, nil
+}
+
+func (t *trendDB) PutTrend(ctx context.Context, trend *gtsmodel.Trend) error {
+	_, err := t.db.
+		NewInsert().
+		Model(trend).
+		Exec(ctx)
+	return err
+}
+
+func (t *trendDB) UpdateTrend(ctx context.Context, trend *gtsmodel.Trend, columns ...string) error {
+	trend.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := t.db.
+		NewUpdate().
+		Model(trend).
+		Column(columns...).
+		Where("? = ?", bun.Ident("trend.id"), trend.ID).
+		Exec(ctx)
+	return err
+}
+
+func (t *trendDB) GetTagUsage(ctx context.Context, since time.Time, until time.Time) ([]*gtsmodel.TrendUsage, error) {
+	minID, maxID, err := idRange(since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make([]*gtsmodel.TrendUsage, 0)
+
+	if err := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		ColumnExpr("? AS ?", bun.Ident("status_to_tag.tag_id"), bun.Ident("target_id")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("uses")).
+		ColumnExpr("COUNT(DISTINCT ?) AS ?", bun.Ident("status.account_id"), bun.Ident("accounts")).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("status_to_tag.status_id"),
+		).
+		Where("? >= ?", bun.Ident("status_to_tag.status_id"), minID).
+		Where("? < ?", bun.Ident("status_to_tag.status_id"), maxID).
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		Where("NOT ? = ?", bun.Ident("status.pending_approval"), true).
+		Group("status_to_tag.tag_id").
+		Scan(ctx, &usage); err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
+
+func (t *trendDB) GetStatusEngagement(ctx context.Context, since time.Time, until time.Time) ([]*gtsmodel.TrendUsage, error) {
+	minID, maxID, err := idRange(since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	// Select status ID + account ID of each fave in range.
+	faves := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+		ColumnExpr("? AS ?", bun.Ident("status_fave.status_id"), bun.Ident("target_id")).
+		ColumnExpr("? AS ?", bun.Ident("status_fave.account_id"), bun.Ident("account_id")).
+		Where("? >= ?", bun.Ident("status_fave.id"), minID).
+		Where("? < ?", bun.Ident("status_fave.id"), maxID).
+		Where("NOT ? = ?", bun.Ident("status_fave.pending_approval"), true)
+
+	// Select boosted status ID + account ID of each boost in range.
+	boosts := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		ColumnExpr("? AS ?", bun.Ident("status.boost_of_id"), bun.Ident("target_id")).
+		ColumnExpr("? AS ?", bun.Ident("status.account_id"), bun.Ident("account_id")).
+		Where("? >= ?", bun.Ident("status.id"), minID).
+		Where("? < ?", bun.Ident("status.id"), maxID).
+		Where("? IS NOT NULL", bun.Ident("status.boost_of_id")).
+		Where("NOT ? = ?", bun.Ident("status.pending_approval"), true)
+
+	usage := make([]*gtsmodel.TrendUsage, 0)
+
+	// Count both together per target status.
+	if err := t.db.
+		NewSelect().
+		// Bun wraps union queries in parentheses,
+		// which SQLite doesn't support, so select
+		// from each of them as subqueries instead.
+		TableExpr("(SELECT * FROM (?) AS ? UNION ALL SELECT * FROM (?) AS ?) AS ?",
+			faves, bun.Ident("faves"),
+			boosts, bun.Ident("boosts"),
+			bun.Ident("engagement"),
+		).
+		ColumnExpr("? AS ?", bun.Ident("engagement.target_id"), bun.Ident("target_id")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("uses")).
+		ColumnExpr("COUNT(DISTINCT ?) AS ?", bun.Ident("engagement.account_id"), bun.Ident("accounts")).
+		Group("engagement.target_id").
+		Scan(ctx, &usage); err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
+
+func (t *trendDB) GetPublicStatusContent(ctx context.Context, since time.Time, until time.Time) ([]*gtsmodel.Status, error) {
+	minID, maxID, err := idRange(since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*gtsmodel.Status, 0)
+
+	if err := t.db.
+		NewSelect().
+		Model(&statuses).
+		Column("status.id", "status.account_id", "status.content").
+		Where("? >= ?", bun.Ident("status.id"), minID).
+		Where("? < ?", bun.Ident("status.id"), maxID).
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		Where("NOT ? = ?", bun.Ident("status.pending_approval"), true).
+		Order("status.id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+// idRange returns the range [minID, maxID) of ULIDs
+// which fall within the given period of time.
+func idRange(since time.Time, until time.Time) (string, string, error) {
+	// Use zero entropy for the bounds so they aren't
+	// affected by the random part of the ULID: IDs
+	// generated in the same millisecond as since
+	// or until should always be included in the range.
+	minID, err := id.MinULIDFromTime(since)
+	if err != nil {
+		return "", "", err
+	}
+
+	maxID, err := id.MinULIDFromTime(until.Add(time.Millisecond))
+	if err != nil {
+		return "", "", err
+	}
+
+	return minID, maxID, nil
+}
@@ -0,0 +1,223 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type TrendTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+// putStatus stores a new public status
+// by the given account, created now.
+func (suite *TrendTestSuite) putStatus(account *gtsmodel.Account, content string, tagIDs ...string) *gtsmodel.Status {
+	return suite.putStatusWithID(id.NewULID(), account, content, tagIDs...)
+}
+
+// putStatusWithID stores a new public status
+// with the given ID by the given account.
+func (suite *TrendTestSuite) putStatusWithID(statusID string, account *gtsmodel.Account, content string, tagIDs ...string) *gtsmodel.Status {
+	status := &gtsmodel.Status{
+		ID:                  statusID,
+		URI:                 "http://localhost:8080/users/" + account.Username + "/statuses/" + statusID,
+		Content:             content,
+		TagIDs:              tagIDs,
+		Local:               util.Ptr(true),
+		AccountID:           account.ID,
+		AccountURI:          account.URI,
+		Visibility:          gtsmodel.VisibilityPublic,
+		ActivityStreamsType: ap.ObjectNote,
+		Federated:           util.Ptr(true),
+	}
+
+	if err := suite.db.PutStatus(context.Background(), status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return status
+}
+
+func (suite *TrendTestSuite) TestGetTrendByTarget() {
+	testTrend := suite.testTrends["tag_welcome_approved"]
+
+	trend, err := suite.db.GetTrendByTarget(context.Background(), gtsmodel.TrendTypeTag, testTrend.TargetID)
+	suite.NoError(err)
+	suite.Equal(testTrend.ID, trend.ID)
+	suite.Equal(gtsmodel.TrendStateApproved, trend.State)
+
+	// Same target but different type.
+	_, err = suite.db.GetTrendByTarget(context.Background(), gtsmodel.TrendTypeStatus, testTrend.TargetID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *TrendTestSuite) TestPutUpdateTrend() {
+	ctx := context.Background()
+
+	trend := &gtsmodel.Trend{
+		ID:       id.NewULID(),
+		Type:     gtsmodel.TrendTypeLink,
+		TargetID: "https://example.org/some/article",
+	}
+	err := suite.db.PutTrend(ctx, trend)
+	suite.NoError(err)
+
+	// Same type + target is a duplicate.
+	err = suite.db.PutTrend(ctx, &gtsmodel.Trend{
+		ID:       id.NewULID(),
+		Type:     gtsmodel.TrendTypeLink,
+		TargetID: "https://example.org/some/article",
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	dbTrend, err := suite.db.GetTrendByID(ctx, trend.ID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.TrendStatePending, dbTrend.State)
+
+	dbTrend.State = gtsmodel.TrendStateRejected
+	dbTrend.ReviewedAt = time.Now()
+	dbTrend.ReviewedByAccountID = suite.testAccounts["admin_account"].ID
+	err = suite.db.UpdateTrend(ctx, dbTrend, "state", "reviewed_at", "reviewed_by_account_id")
+	suite.NoError(err)
+
+	dbTrend, err = suite.db.GetTrendByID(ctx, trend.ID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.TrendStateRejected, dbTrend.State)
+	suite.Equal(suite.testAccounts["admin_account"].ID, dbTrend.ReviewedByAccountID)
+}
+
+func (suite *TrendTestSuite) TestGetTagUsage() {
+	ctx := context.Background()
+	tagID := suite.testTags["welcome"].ID
+
+	suite.putStatus(suite.testAccounts["local_account_1"], "<p>#welcome</p>", tagID)
+	suite.putStatus(suite.testAccounts["local_account_1"], "<p>#welcome again</p>", tagID)
+	suite.putStatus(suite.testAccounts["local_account_2"], "<p>#welcome</p>", tagID)
+
+	usage, err := suite.db.GetTagUsage(ctx, time.Now().Add(-time.Hour), time.Now().Add(time.Minute))
+	suite.NoError(err)
+	suite.Len(usage, 1)
+	suite.Equal(tagID, usage[0].TargetID)
+	suite.Equal(3, usage[0].Uses)
+	suite.Equal(2, usage[0].Accounts)
+
+	// Test statuses using tags were
+	// created long before this window.
+	usage, err = suite.db.GetTagUsage(ctx, time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour))
+	suite.NoError(err)
+	suite.Empty(usage)
+}
+
+func (suite *TrendTestSuite) TestGetTagUsageBounds() {
+	ctx := context.Background()
+	tagID := suite.testTags["welcome"].ID
+	since := time.Now().Add(-time.Minute).Truncate(time.Millisecond)
+	until := since.Add(30 * time.Second)
+
+	// Lowest possible IDs in the first + last
+	// millisecond of the window, and just after.
+	first, err := id.MinULIDFromTime(since)
+	suite.NoError(err)
+	last, err := id.MinULIDFromTime(until)
+	suite.NoError(err)
+	after, err := id.MinULIDFromTime(until.Add(time.Millisecond))
+	suite.NoError(err)
+
+	suite.putStatusWithID(first, suite.testAccounts["local_account_1"], "<p>#welcome</p>", tagID)
+	suite.putStatusWithID(last, suite.testAccounts["local_account_1"], "<p>#welcome</p>", tagID)
+	suite.putStatusWithID(after, suite.testAccounts["local_account_1"], "<p>#welcome</p>", tagID)
+
+	// Both ends of the window are
+	// included, but nothing after.
+	usage, err := suite.db.GetTagUsage(ctx, since, until)
+	suite.NoError(err)
+	if suite.Len(usage, 1) {
+		suite.Equal(2, usage[0].Uses)
+	}
+}
+
+func (suite *TrendTestSuite) TestGetStatusEngagement() {
+	ctx := context.Background()
+	status := suite.putStatus(suite.testAccounts["local_account_1"], "<p>hello</p>")
+
+	for _, account := range []*gtsmodel.Account{
+		suite.testAccounts["local_account_2"],
+		suite.testAccounts["admin_account"],
+	} {
+		faveID := id.NewULID()
+		if err := suite.db.PutStatusFave(ctx, &gtsmodel.StatusFave{
+			ID:              faveID,
+			AccountID:       account.ID,
+			TargetAccountID: status.AccountID,
+			StatusID:        status.ID,
+			URI:             "http://localhost:8080/users/" + account.Username + "/liked/" + faveID,
+		}); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Boost by an account that also faved.
+	boostID := id.NewULID()
+	if err := suite.db.PutStatus(ctx, &gtsmodel.Status{
+		ID:                  boostID,
+		URI:                 "http://localhost:8080/users/admin/statuses/" + boostID,
+		Local:               util.Ptr(true),
+		AccountID:           suite.testAccounts["admin_account"].ID,
+		AccountURI:          suite.testAccounts["admin_account"].URI,
+		BoostOfID:           status.ID,
+		BoostOfAccountID:    status.AccountID,
+		Visibility:          gtsmodel.VisibilityPublic,
+		ActivityStreamsType: ap.ActivityAnnounce,
+		Federated:           util.Ptr(true),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	usage, err := suite.db.GetStatusEngagement(ctx, time.Now().Add(-time.Hour), time.Now().Add(time.Minute))
+	suite.NoError(err)
+	suite.Len(usage, 1)
+	suite.Equal(status.ID, usage[0].TargetID)
+	suite.Equal(3, usage[0].Uses)
+	suite.Equal(2, usage[0].Accounts)
+}
+
+func (suite *TrendTestSuite) TestGetPublicStatusContent() {
+	ctx := context.Background()
+	status := suite.putStatus(suite.testAccounts["local_account_1"], `<p><a href="https://example.org/">link</a></p>`)
+
+	statuses, err := suite.db.GetPublicStatusContent(ctx, time.Now().Add(-time.Hour), time.Now().Add(time.Minute))
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+	suite.Equal(status.ID, statuses[0].ID)
+	suite.Equal(status.AccountID, statuses[0].AccountID)
+	suite.Equal(status.Content, statuses[0].Content)
+}
+
+func TestTrendTestSuite(t *testing.T) {
+	suite.Run(t, new(TrendTestSuite))
+}
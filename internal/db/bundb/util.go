@@ -115,6 +115,33 @@ func whereStartsLike(
 	)
 }
 
+// whereHasAttachments appends a WHERE clause to the
+// given SelectQuery, which selects only statuses (with
+// table alias "status") that have media attachments.
+func whereHasAttachments(query *bun.SelectQuery) *bun.SelectQuery {
+	// Attachments are stored as a json object; this
+	// implementation differs between SQLite and Postgres,
+	// so we have to be thorough to cover all eventualities
+	return query.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+		switch d := q.Dialect().Name(); d {
+		case dialect.PG:
+			return q.
+				Where("? IS NOT NULL", bun.Ident("status.attachments")).
+				Where("? != '{}'", bun.Ident("status.attachments"))
+		case dialect.SQLite:
+			return q.
+				Where("? IS NOT NULL", bun.Ident("status.attachments")).
+				Where("? != ''", bun.Ident("status.attachments")).
+				Where("? != 'null'", bun.Ident("status.attachments")).
+				Where("? != '{}'", bun.Ident("status.attachments")).
+				Where("? != '[]'", bun.Ident("status.attachments"))
+		default:
+			log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+			return q
+		}
+	})
+}
+
 // exists checks the results of a SelectQuery for the existence of the data in question, masking ErrNoEntries errors.
 func exists(ctx context.Context, query *bun.SelectQuery) (bool, error) {
 	exists, err := query.Exists(ctx)
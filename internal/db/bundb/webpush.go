@@ -0,0 +1,177 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type webPushDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (w *webPushDB) GetVAPIDKeyPair(ctx context.Context) (*gtsmodel.VAPIDKeyPair, error) {
+	var vapidKeyPair gtsmodel.VAPIDKeyPair
+
+	// There's only one key pair
+	// row, so just take the first.
+	if err := w.db.NewSelect().
+		Model(&vapidKeyPair).
+		Limit(1).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &vapidKeyPair, nil
+}
+
+func (w *webPushDB) PutVAPIDKeyPair(ctx context.Context, vapidKeyPair *gtsmodel.VAPIDKeyPair) error {
+	// Make sure there's only ever one key pair.
+	vapidKeyPair.ID = 1
+
+	_, err := w.db.NewInsert().
+		Model(vapidKeyPair).
+		Exec(ctx)
+	return err
+}
+
+func (w *webPushDB) DeleteVAPIDKeyPair(ctx context.Context) error {
+	if _, err := w.db.NewDelete().
+		Table("vapid_key_pairs").
+		Where("? = ?", bun.Ident("id"), 1).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	return nil
+}
+
+func (w *webPushDB) GetWebPushSubscriptionByTokenID(ctx context.Context, tokenID string) (*gtsmodel.WebPushSubscription, error) {
+	return w.state.Caches.DB.WebPushSubscription.LoadOne("TokenID",
+		func() (*gtsmodel.WebPushSubscription, error) {
+			var subscription gtsmodel.WebPushSubscription
+
+			if err := w.db.NewSelect().
+				Model(&subscription).
+				Where("? = ?", bun.Ident("token_id"), tokenID).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return &subscription, nil
+		}, tokenID,
+	)
+}
+
+func (w *webPushDB) GetWebPushSubscriptionsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.WebPushSubscription, error) {
+	// Select IDs of all subscriptions owned by this account.
+	var ids []string
+	if err := w.db.NewSelect().
+		Table("web_push_subscriptions").
+		Column("id").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Order("id DESC").
+		Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	// Load all subscriptions via cache loader callback.
+	return w.state.Caches.DB.WebPushSubscription.LoadIDs("ID",
+		ids,
+		func(uncached []string) ([]*gtsmodel.WebPushSubscription, error) {
+			subscriptions := make([]*gtsmodel.WebPushSubscription, 0, len(uncached))
+
+			if err := w.db.NewSelect().
+				Model(&subscriptions).
+				Where("? IN (?)", bun.Ident("id"), bun.In(uncached)).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return subscriptions, nil
+		},
+	)
+}
+
+func (w *webPushDB) PutWebPushSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription) error {
+	return w.state.Caches.DB.WebPushSubscription.Store(subscription, func() error {
+		_, err := w.db.NewInsert().
+			Model(subscription).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (w *webPushDB) UpdateWebPushSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription, columns ...string) error {
+	subscription.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	// Update database, then invalidate cache entry, as the
+	// unique token ID may have changed along with the row.
+	if _, err := w.db.NewUpdate().
+		Model(subscription).
+		Where("? = ?", bun.Ident("id"), subscription.ID).
+		Column(columns...).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	w.state.Caches.DB.WebPushSubscription.Invalidate("ID", subscription.ID)
+	return nil
+}
+
+func (w *webPushDB) DeleteWebPushSubscriptionByTokenID(ctx context.Context, tokenID string) error {
+	if _, err := w.db.NewDelete().
+		Table("web_push_subscriptions").
+		Where("? = ?", bun.Ident("token_id"), tokenID).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	w.state.Caches.DB.WebPushSubscription.Invalidate("TokenID", tokenID)
+	return nil
+}
+
+func (w *webPushDB) DeleteWebPushSubscriptionsByAccountID(ctx context.Context, accountID string) error {
+	if _, err := w.db.NewDelete().
+		Table("web_push_subscriptions").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	w.state.Caches.DB.WebPushSubscription.Invalidate("AccountID", accountID)
+	return nil
+}
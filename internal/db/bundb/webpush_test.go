@@ -0,0 +1,121 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type WebPushTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *WebPushTestSuite) TestVAPIDKeyPair() {
+	ctx := context.Background()
+
+	// No key pair yet.
+	_, err := suite.db.GetVAPIDKeyPair(ctx)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	vapidKeyPair := &gtsmodel.VAPIDKeyPair{
+		Public:  "public",
+		Private: "private",
+	}
+	if err := suite.db.PutVAPIDKeyPair(ctx, vapidKeyPair); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// There can only be one.
+	err = suite.db.PutVAPIDKeyPair(ctx, &gtsmodel.VAPIDKeyPair{
+		Public:  "another public",
+		Private: "another private",
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	dbVAPIDKeyPair, err := suite.db.GetVAPIDKeyPair(ctx)
+	suite.NoError(err)
+	suite.Equal("public", dbVAPIDKeyPair.Public)
+	suite.Equal("private", dbVAPIDKeyPair.Private)
+
+	suite.NoError(suite.db.DeleteVAPIDKeyPair(ctx))
+	_, err = suite.db.GetVAPIDKeyPair(ctx)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *WebPushTestSuite) TestWebPushSubscriptions() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	token := suite.testTokens["local_account_1"]
+
+	subscription := &gtsmodel.WebPushSubscription{
+		ID:        id.NewULID(),
+		AccountID: account.ID,
+		TokenID:   token.ID,
+		Endpoint:  "https://push.example.org/send/123",
+		Auth:      "auth",
+		P256dh:    "p256dh",
+		Policy:    gtsmodel.WebPushNotificationPolicyAll,
+	}
+	subscription.NotificationFlags.Set(gtsmodel.NotificationMention, true)
+
+	if err := suite.db.PutWebPushSubscription(ctx, subscription); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Invalidate cache to force a db hit.
+	suite.state.Caches.DB.WebPushSubscription.Invalidate("ID", subscription.ID)
+
+	dbSubscription, err := suite.db.GetWebPushSubscriptionByTokenID(ctx, token.ID)
+	suite.NoError(err)
+	suite.Equal(subscription.ID, dbSubscription.ID)
+	suite.True(dbSubscription.NotificationFlags.Get(gtsmodel.NotificationMention))
+	suite.False(dbSubscription.NotificationFlags.Get(gtsmodel.NotificationFollow))
+
+	// Update alerts and policy.
+	dbSubscription.NotificationFlags.Set(gtsmodel.NotificationFollow, true)
+	dbSubscription.Policy = gtsmodel.WebPushNotificationPolicyFollowed
+	if err := suite.db.UpdateWebPushSubscription(ctx, dbSubscription, "notification_flags", "policy"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	subscriptions, err := suite.db.GetWebPushSubscriptionsByAccountID(ctx, account.ID)
+	suite.NoError(err)
+	if suite.Len(subscriptions, 1) {
+		suite.True(subscriptions[0].NotificationFlags.Get(gtsmodel.NotificationFollow))
+		suite.Equal(gtsmodel.WebPushNotificationPolicyFollowed, subscriptions[0].Policy)
+	}
+
+	// Delete by account.
+	suite.NoError(suite.db.DeleteWebPushSubscriptionsByAccountID(ctx, account.ID))
+	_, err = suite.db.GetWebPushSubscriptionByTokenID(ctx, token.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	subscriptions, err = suite.db.GetWebPushSubscriptionsByAccountID(ctx, account.ID)
+	suite.NoError(err)
+	suite.Empty(subscriptions)
+}
+
+func TestWebPushTestSuite(t *testing.T) {
+	suite.Run(t, new(WebPushTestSuite))
+}
@@ -49,6 +49,15 @@ func (w *workerTaskDB) PutWorkerTasks(ctx context.Context, tasks []*gtsmodel.Wor
 	return errors.Join(errs...)
 }
 
+func (w *workerTaskDB) UpdateWorkerTask(ctx context.Context, task *gtsmodel.WorkerTask, columns ...string) error {
+	_, err := w.db.NewUpdate().
+		Model(task).
+		Column(columns...).
+		Where("? = ?", bun.Ident("id"), task.ID).
+		Exec(ctx)
+	return err
+}
+
 func (w *workerTaskDB) DeleteWorkerTaskByID(ctx context.Context, id uint) error {
 	_, err := w.db.NewDelete().
 		Table("worker_tasks").
@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type ContentLimit interface {
+	// GetContentLimitByID gets one content limit with the given id.
+	GetContentLimitByID(ctx context.Context, id string) (*gtsmodel.ContentLimit, error)
+
+	// GetContentLimitByAccountID gets the account-level content limit for the given account id.
+	GetContentLimitByAccountID(ctx context.Context, accountID string) (*gtsmodel.ContentLimit, error)
+
+	// GetContentLimitByDomain gets the domain-level content limit for exactly the given domain.
+	GetContentLimitByDomain(ctx context.Context, domain string) (*gtsmodel.ContentLimit, error)
+
+	// GetContentLimits gets all content limits, newest first.
+	GetContentLimits(ctx context.Context) ([]*gtsmodel.ContentLimit, error)
+
+	// GetContentLimitsForAccount gets all content limits that apply to the given account,
+	// ie., any account-level limit, and the domain-level limits for the account's domain
+	// and each of its parent domains.
+	GetContentLimitsForAccount(ctx context.Context, account *gtsmodel.Account) (gtsmodel.ContentLimits, error)
+
+	// PopulateContentLimit populates the struct pointers on the given content limit.
+	PopulateContentLimit(ctx context.Context, limit *gtsmodel.ContentLimit) error
+
+	// PutContentLimit puts one content limit in the database.
+	PutContentLimit(ctx context.Context, limit *gtsmodel.ContentLimit) error
+
+	// UpdateContentLimit updates the given content limit, only updating given columns if provided.
+	UpdateContentLimit(ctx context.Context, limit *gtsmodel.ContentLimit, columns ...string) error
+
+	// DeleteContentLimitByID deletes one content limit with the given id.
+	DeleteContentLimitByID(ctx context.Context, id string) error
+}
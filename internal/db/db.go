@@ -25,10 +25,13 @@ const (
 // DB provides methods for interacting with an underlying database or other storage mechanism.
 type DB interface {
 	Account
+	AccountArchive
 	Admin
 	AdvancedMigration
+	Announcement
 	Application
 	Basic
+	ContentLimit
 	Conversation
 	Domain
 	Emoji
@@ -44,18 +47,25 @@ type DB interface {
 	Notification
 	Poll
 	Relationship
+	Relay
 	Report
 	Rule
+	ScheduledStatus
 	Search
 	Session
 	SinBinStatus
 	Status
 	StatusBookmark
+	StatusEdit
 	StatusFave
+	StatusReaction
+	Suggestion
 	Tag
 	Thread
 	Timeline
+	Trend
 	User
 	Tombstone
+	WebPush
 	WorkerTask
 }
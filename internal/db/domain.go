@@ -42,6 +42,9 @@ type Domain interface {
 	// GetDomainAllows returns all instance-level domain allows currently enforced by this instance.
 	GetDomainAllows(ctx context.Context) ([]*gtsmodel.DomainAllow, error)
 
+	// UpdateDomainAllow updates the given domain allow, setting the provided columns (empty for all).
+	UpdateDomainAllow(ctx context.Context, allow *gtsmodel.DomainAllow, columns ...string) error
+
 	// DeleteDomainAllow deletes an instance-level domain allow with the given domain, if it exists.
 	DeleteDomainAllow(ctx context.Context, domain string) error
 
@@ -57,9 +60,48 @@ type Domain interface {
 	// GetDomainBlocks returns all instance-level domain blocks currently enforced by this instance.
 	GetDomainBlocks(ctx context.Context) ([]*gtsmodel.DomainBlock, error)
 
+	// UpdateDomainBlock updates the given domain block, setting the provided columns (empty for all).
+	UpdateDomainBlock(ctx context.Context, block *gtsmodel.DomainBlock, columns ...string) error
+
 	// DeleteDomainBlock deletes an instance-level domain block with the given domain, if it exists.
 	DeleteDomainBlock(ctx context.Context, domain string) error
 
+	/*
+		Domain permission draft + subscription functions.
+	*/
+
+	// GetDomainPermissionDraftByID returns one domain permission draft with the given id, if it exists.
+	GetDomainPermissionDraftByID(ctx context.Context, id string) (*gtsmodel.DomainPermissionDraft, error)
+
+	// GetDomainPermissionDraft returns the domain permission draft of the given type for the given domain, if it exists.
+	GetDomainPermissionDraft(ctx context.Context, permType gtsmodel.DomainPermissionType, domain string) (*gtsmodel.DomainPermissionDraft, error)
+
+	// GetDomainPermissionDrafts returns domain permission drafts, optionally filtered
+	// by permission type (Unknown for any), subscription ID, and domain (empty for any).
+	GetDomainPermissionDrafts(ctx context.Context, permType gtsmodel.DomainPermissionType, subscriptionID string, domain string) ([]*gtsmodel.DomainPermissionDraft, error)
+
+	// PutDomainPermissionDraft stores one domain permission draft.
+	PutDomainPermissionDraft(ctx context.Context, draft *gtsmodel.DomainPermissionDraft) error
+
+	// DeleteDomainPermissionDraft deletes one domain permission draft with the given id.
+	DeleteDomainPermissionDraft(ctx context.Context, id string) error
+
+	// GetDomainPermissionSubscriptionByID returns one domain permission subscription with the given id, if it exists.
+	GetDomainPermissionSubscriptionByID(ctx context.Context, id string) (*gtsmodel.DomainPermissionSubscription, error)
+
+	// GetDomainPermissionSubscriptions returns domain permission subscriptions of the given type (Unknown
+	// for any), ordered by priority descending, so that higher priority subscriptions come first.
+	GetDomainPermissionSubscriptions(ctx context.Context, permType gtsmodel.DomainPermissionType) ([]*gtsmodel.DomainPermissionSubscription, error)
+
+	// PutDomainPermissionSubscription stores one domain permission subscription.
+	PutDomainPermissionSubscription(ctx context.Context, sub *gtsmodel.DomainPermissionSubscription) error
+
+	// UpdateDomainPermissionSubscription updates the given domain permission subscription, setting the provided columns (empty for all).
+	UpdateDomainPermissionSubscription(ctx context.Context, sub *gtsmodel.DomainPermissionSubscription, columns ...string) error
+
+	// DeleteDomainPermissionSubscription deletes one domain permission subscription with the given id.
+	DeleteDomainPermissionSubscription(ctx context.Context, id string) error
+
 	/*
 		Block/allow checking functions.
 	*/
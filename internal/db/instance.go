@@ -49,6 +49,11 @@ type Instance interface {
 	// UpdateInstance updates the given instance entry.
 	UpdateInstance(ctx context.Context, instance *gtsmodel.Instance, columns ...string) error
 
+	// GetInstancesFailingDelivery returns a slice of instances that
+	// deliveries are currently failing to, or that have been marked
+	// as unavailable for deliveries.
+	GetInstancesFailingDelivery(ctx context.Context) ([]*gtsmodel.Instance, error)
+
 	// GetInstanceAccounts returns a slice of accounts from the given instance, arranged by ID.
 	GetInstanceAccounts(ctx context.Context, domain string, maxID string, limit int) ([]*gtsmodel.Account, error)
 
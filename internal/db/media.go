@@ -30,6 +30,10 @@ type Media interface {
 	// GetAttachmentByID gets a single attachment by its ID.
 	GetAttachmentByID(ctx context.Context, id string) (*gtsmodel.MediaAttachment, error)
 
+	// GetAttachmentByFileHash gets a single cached attachment whose
+	// stored file has the given hash, preferring the most recent.
+	GetAttachmentByFileHash(ctx context.Context, hash string) (*gtsmodel.MediaAttachment, error)
+
 	// GetAttachmentsByIDs fetches a list of media attachments for given IDs.
 	GetAttachmentsByIDs(ctx context.Context, ids []string) ([]*gtsmodel.MediaAttachment, error)
 
@@ -42,6 +46,12 @@ type Media interface {
 	// DeleteAttachment deletes the attachment with given ID from the database.
 	DeleteAttachment(ctx context.Context, id string) error
 
+	// CountAttachmentsByFilePath counts the cached attachments, other than the one
+	// with excludeID, whose file is stored at the given path. Files in storage may be
+	// shared between attachments with identical contents, so this acts as a reference
+	// count that must be checked before removing any attachment file from storage.
+	CountAttachmentsByFilePath(ctx context.Context, path string, excludeID string) (int, error)
+
 	// GetAttachments fetches media attachments up to a given max ID, and at most limit.
 	GetAttachments(ctx context.Context, page *paging.Page) ([]*gtsmodel.MediaAttachment, error)
 
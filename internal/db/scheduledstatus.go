@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type ScheduledStatus interface {
+	// GetScheduledStatusByID gets one scheduled status with the given ID.
+	GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, error)
+
+	// GetScheduledStatusesForAcct gets a page of scheduled statuses owned by the given account ID.
+	GetScheduledStatusesForAcct(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.ScheduledStatus, error)
+
+	// GetAllScheduledStatuses gets all scheduled statuses, across all accounts.
+	// This is used when (re)scheduling the publishing of all statuses on startup.
+	GetAllScheduledStatuses(ctx context.Context) ([]*gtsmodel.ScheduledStatus, error)
+
+	// CountScheduledStatusesForAcct counts the scheduled statuses owned by the given account ID,
+	// optionally only those scheduled between the given times (zero times are ignored).
+	CountScheduledStatusesForAcct(ctx context.Context, accountID string, after time.Time, before time.Time) (int, error)
+
+	// PopulateScheduledStatus ensures that all sub-models of a scheduled status are populated (account, media, application).
+	PopulateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error
+
+	// PutScheduledStatus puts the given scheduled status in the database.
+	PutScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error
+
+	// UpdateScheduledStatus updates the given scheduled status in the database.
+	// If no columns are provided, then all columns will be updated.
+	UpdateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus, columns ...string) error
+
+	// DeleteScheduledStatusByID deletes one scheduled status with the given ID.
+	DeleteScheduledStatusByID(ctx context.Context, id string) error
+}
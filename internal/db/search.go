@@ -25,12 +25,18 @@ import (
 
 type Search interface {
 	// SearchForAccounts uses the given query text to search for accounts that accountID follows.
+	// Results are ordered by relevance, unless paging by maxID / minID, in which case by ID.
 	SearchForAccounts(ctx context.Context, accountID string, query string, maxID string, minID string, limit int, following bool, offset int) ([]*gtsmodel.Account, error)
 
 	// SearchForStatuses uses the given query text to search for statuses created by requestingAccountID, or in reply to requestingAccountID.
 	// If fromAccountID is used, the results are restricted to statuses created by fromAccountID.
+	// The query may contain "quoted phrases", and has:media, before:YYYY-MM-DD and after:YYYY-MM-DD operators.
+	// Results are ordered by relevance, unless paging by maxID / minID, in which case by ID.
 	SearchForStatuses(ctx context.Context, requestingAccountID string, query string, fromAccountID string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, error)
 
 	// SearchForTags searches for tags that start with the given query text (case insensitive).
 	SearchForTags(ctx context.Context, query string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Tag, error)
+
+	// RebuildSearchIndex drops and recreates the full-text search index for statuses and accounts.
+	RebuildSearchIndex(ctx context.Context) error
 }
@@ -0,0 +1,68 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Suggestion handles getting/creation/deletion of featured
+// suggestions and suggestion dismissals, and the queries
+// used to find accounts worth suggesting to an account.
+type Suggestion interface {
+	// GetFeaturedSuggestions gets all featured suggestions, oldest first.
+	GetFeaturedSuggestions(ctx context.Context) ([]*gtsmodel.FeaturedSuggestion, error)
+
+	// GetFeaturedSuggestionByAccountID gets one featured suggestion by the ID of the featured account.
+	GetFeaturedSuggestionByAccountID(ctx context.Context, accountID string) (*gtsmodel.FeaturedSuggestion, error)
+
+	// PutFeaturedSuggestion puts the given featured suggestion in the database.
+	PutFeaturedSuggestion(ctx context.Context, suggestion *gtsmodel.FeaturedSuggestion) error
+
+	// DeleteFeaturedSuggestionByAccountID deletes one featured suggestion by the ID of the featured account.
+	DeleteFeaturedSuggestionByAccountID(ctx context.Context, accountID string) error
+
+	// GetSuggestionDismissedAccountIDs gets the IDs of all
+	// accounts dismissed from suggestions by the given account.
+	GetSuggestionDismissedAccountIDs(ctx context.Context, accountID string) ([]string, error)
+
+	// PutSuggestionDismissal puts the given suggestion dismissal in the database.
+	PutSuggestionDismissal(ctx context.Context, dismissal *gtsmodel.SuggestionDismissal) error
+
+	// DeleteSuggestionsByAccountID deletes all suggestion dismissals
+	// by or targeting the given account, and any featured suggestion
+	// of the given account.
+	DeleteSuggestionsByAccountID(ctx context.Context, accountID string) error
+
+	// GetFriendsOfFriendsIDs gets the IDs of accounts followed by the
+	// accounts that the given account follows, but not by the account
+	// itself, most commonly followed first. Accounts followed by those
+	// hiding their collections are not included.
+	GetFriendsOfFriendsIDs(ctx context.Context, accountID string, limit int) ([]string, error)
+
+	// GetInteractedAccountIDs gets the IDs of accounts whose statuses
+	// the given account has faved, boosted or replied to, but which it
+	// doesn't follow, most interacted with first.
+	GetInteractedAccountIDs(ctx context.Context, accountID string, limit int) ([]string, error)
+
+	// GetMostFollowedLocalAccountIDs gets the IDs of discoverable,
+	// non-suspended local accounts, most followed first.
+	GetMostFollowedLocalAccountIDs(ctx context.Context, limit int) ([]string, error)
+}
@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Trend handles getting/creation/updating of trend review
+// states, and the usage queries used to calculate trends.
+type Trend interface {
+	// GetTrendByID gets one trend by its db id.
+	GetTrendByID(ctx context.Context, id string) (*gtsmodel.Trend, error)
+
+	// GetTrendByTarget gets one trend by its type and the ID (or URL) of its target.
+	GetTrendByTarget(ctx context.Context, trendType gtsmodel.TrendType, targetID string) (*gtsmodel.Trend, error)
+
+	// PutTrend puts the given trend in the database.
+	PutTrend(ctx context.Context, trend *gtsmodel.Trend) error
+
+	// UpdateTrend updates one trend by its db id.
+	// If no columns are given, every column will be updated.
+	UpdateTrend(ctx context.Context, trend *gtsmodel.Trend, columns ...string) error
+
+	// GetTagUsage returns the usage of each tag by public
+	// statuses created within the given period of time.
+	GetTagUsage(ctx context.Context, since time.Time, until time.Time) ([]*gtsmodel.TrendUsage, error)
+
+	// GetStatusEngagement returns the number of faves and boosts
+	// created within the given period of time, per target status.
+	GetStatusEngagement(ctx context.Context, since time.Time, until time.Time) ([]*gtsmodel.TrendUsage, error)
+
+	// GetPublicStatusContent returns public, non-boost statuses
+	// created within the given period of time. As this may be a
+	// lot of statuses, the cache is bypassed, and only the ID,
+	// AccountID and Content fields of each status are populated.
+	GetPublicStatusContent(ctx context.Context, since time.Time, until time.Time) ([]*gtsmodel.Status, error)
+}
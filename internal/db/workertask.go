@@ -30,6 +30,10 @@ type WorkerTask interface {
 	// PutWorkerTasks persists the given worker tasks to the database.
 	PutWorkerTasks(ctx context.Context, tasks []*gtsmodel.WorkerTask) error
 
+	// UpdateWorkerTask updates the given worker task in the database,
+	// updating only the given columns, or all if none are provided.
+	UpdateWorkerTask(ctx context.Context, task *gtsmodel.WorkerTask, columns ...string) error
+
 	// DeleteWorkerTask deletes worker task with given ID from database.
 	DeleteWorkerTaskByID(ctx context.Context, id uint) error
 }
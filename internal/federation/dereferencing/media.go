@@ -21,10 +21,12 @@ import (
 	"context"
 	"io"
 	"net/url"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -92,6 +94,44 @@ func (d *Dereferencer) GetMedia(
 	)
 }
 
+// putRejectedMedia stores a new uncached media attachment
+// for the given placeholder, owned by the given account ID,
+// without ever dereferencing it. This is used for media from
+// accounts / domains that an admin has rejected media from,
+// so that the API can still link to the remote media.
+func (d *Dereferencer) putRejectedMedia(
+	ctx context.Context,
+	accountID string,
+	statusID string,
+	placeholder *gtsmodel.MediaAttachment,
+) (
+	*gtsmodel.MediaAttachment,
+	error,
+) {
+	now := time.Now()
+	attachment := &gtsmodel.MediaAttachment{
+		ID:          id.NewULID(),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		StatusID:    statusID,
+		RemoteURL:   placeholder.RemoteURL,
+		Type:        gtsmodel.FileTypeUnknown,
+		AccountID:   accountID,
+		Description: placeholder.Description,
+		Blurhash:    placeholder.Blurhash,
+		Processing:  gtsmodel.ProcessingStatusReceived,
+		Avatar:      util.Ptr(false),
+		Header:      util.Ptr(false),
+		Cached:      util.Ptr(false),
+	}
+
+	if err := d.state.DB.PutAttachment(ctx, attachment); err != nil {
+		return nil, gtserror.Newf("db error putting attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
 // RefreshMedia ensures that given media is up-to-date,
 // both in terms of being cached in local instance,
 // storage and compared to extra info in information
@@ -0,0 +1,127 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// dereferenceQuote ensures that the status quoted by the given
+// status (if any) is dereferenced and linked to it. This is done
+// synchronously for new statuses, so that the quote is available
+// straight away for visibility checks and rendering, else async.
+func (d *Dereferencer) dereferenceQuote(
+	ctx context.Context,
+	requestUser string,
+	status *gtsmodel.Status,
+	isNew bool,
+) {
+	if status.QuoteURI == "" || status.QuoteID != "" {
+		// Either not a quote,
+		// or already linked.
+		return
+	}
+
+	if isNew {
+		if err := d.DereferenceStatusQuote(ctx, requestUser, status); err != nil {
+			log.Error(ctx, err)
+		}
+		return
+	}
+
+	// This is an existing status, dereference the quote asynchronously.
+	d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		if err := d.DereferenceStatusQuote(ctx, requestUser, status); err != nil {
+			log.Error(ctx, err)
+		}
+	})
+}
+
+// DereferenceStatusQuote dereferences the status quoted by the given
+// status, links the two together, and then re-checks whether the quote
+// is actually permitted by the quoted status' interaction policy. If it
+// is not, the given (quoting) status will be deleted from the database.
+func (d *Dereferencer) DereferenceStatusQuote(
+	ctx context.Context,
+	requestUser string,
+	status *gtsmodel.Status,
+) error {
+	if status.QuoteURI == "" ||
+		status.QuoteURI == status.URI {
+		// Nothing to do.
+		return nil
+	}
+
+	// Parse the quoted status URI.
+	uri, err := url.Parse(status.QuoteURI)
+	if err != nil {
+		return gtserror.Newf("invalid quote uri %q: %w", status.QuoteURI, err)
+	}
+
+	// Fetch the quoted status, without dereferencing its thread.
+	quote, _, _, err := d.getStatusByURI(ctx, requestUser, uri)
+	if err != nil {
+		if quote == nil {
+			return gtserror.Newf("error getting quote %s: %w", uri, err)
+		}
+
+		// We have an existing (but stale)
+		// version of the quote, use that.
+		log.Warnf(ctx, "error updating quote %s: %v", uri, err)
+	}
+
+	// Link quote to the status.
+	status.QuoteID = quote.ID
+	status.Quote = quote
+	status.QuoteAccountID = quote.AccountID
+	status.QuoteAccount = quote.Account
+
+	// Now the quoted status is known,
+	// check the quote is permitted.
+	permitted, err := d.isPermittedQuote(ctx,
+		requestUser,
+		status,
+	)
+	if err != nil {
+		return gtserror.Newf("error checking quote permissivity: %w", err)
+	}
+
+	if !permitted {
+		log.Infof(ctx, "deleting unpermitted quote: %s", status.URI)
+		if err := d.state.DB.DeleteStatusByID(ctx, status.ID); err != nil {
+			return gtserror.Newf("error deleting status %s: %w", status.URI, err)
+		}
+		return nil
+	}
+
+	// Update the quote fields in the database.
+	if err := d.state.DB.UpdateStatus(ctx, status,
+		"quote_id",
+		"quote_account_id",
+		"pending_approval",
+	); err != nil {
+		return gtserror.Newf("error updating status %s: %w", status.URI, err)
+	}
+
+	return nil
+}
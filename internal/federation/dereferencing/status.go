@@ -96,6 +96,13 @@ func (d *Dereferencer) GetStatusByURI(ctx context.Context, requestUser string, u
 
 	} else if statusable != nil {
 
+		// Deref quoted status.
+		d.dereferenceQuote(ctx,
+			requestUser,
+			status,
+			isNew,
+		)
+
 		// Deref parents + children.
 		d.dereferenceThread(ctx,
 			requestUser,
@@ -205,6 +212,13 @@ func (d *Dereferencer) RefreshStatus(
 	)
 
 	if statusable != nil {
+		// Deref quoted status.
+		d.dereferenceQuote(ctx,
+			requestUser,
+			latest,
+			isNew,
+		)
+
 		// Deref parents + children.
 		d.dereferenceThread(ctx,
 			requestUser,
@@ -254,6 +268,11 @@ func (d *Dereferencer) RefreshStatusAsync(
 			return
 		}
 		if statusable != nil {
+			if latest.QuoteURI != "" && latest.QuoteID == "" {
+				if err := d.DereferenceStatusQuote(ctx, requestUser, latest); err != nil {
+					log.Error(ctx, err)
+				}
+			}
 			if err := d.DereferenceStatusAncestors(ctx, requestUser, latest); err != nil {
 				log.Error(ctx, err)
 			}
@@ -549,6 +568,16 @@ func (d *Dereferencer) enrichStatus(
 		return nil, nil, gtserror.SetNotPermitted(err)
 	}
 
+	// Check for any admin content limits on the status author.
+	// Only media rejection is applied here, as it determines
+	// whether we fetch media at all; forced sensitive flags and
+	// content warnings are applied at serialization, so that
+	// changes to limits apply to already stored statuses too.
+	limits, err := d.state.DB.GetContentLimitsForAccount(ctx, latestStatus.Account)
+	if err != nil {
+		return nil, nil, gtserror.Newf("db error getting content limits for status %s: %w", uri, err)
+	}
+
 	// Ensure the status' mentions are populated, and pass in existing to check for changes.
 	if err := d.fetchStatusMentions(ctx, requestUser, status, latestStatus); err != nil {
 		return nil, nil, gtserror.Newf("error populating mentions for status %s: %w", uri, err)
@@ -571,7 +600,7 @@ func (d *Dereferencer) enrichStatus(
 	}
 
 	// Ensure the status' media attachments are populated, passing in existing to check for changes.
-	if err := d.fetchStatusAttachments(ctx, requestUser, status, latestStatus); err != nil {
+	if err := d.fetchStatusAttachments(ctx, requestUser, status, latestStatus, limits.RejectMedia()); err != nil {
 		return nil, nil, gtserror.Newf("error populating attachments for status %s: %w", uri, err)
 	}
 
@@ -580,6 +609,14 @@ func (d *Dereferencer) enrichStatus(
 		return nil, nil, gtserror.Newf("error populating emojis for status %s: %w", uri, err)
 	}
 
+	if !isNew {
+		// Check for any edits made to an existing status,
+		// storing the previous version as a revision if so.
+		if err := d.handleStatusEdit(ctx, status, latestStatus); err != nil {
+			return nil, nil, gtserror.Newf("error handling edit for status %s: %w", uri, err)
+		}
+	}
+
 	if isNew {
 		// This is new, put the status in the database.
 		err := d.state.DB.PutStatus(ctx, latestStatus)
@@ -596,6 +633,79 @@ func (d *Dereferencer) enrichStatus(
 	return latestStatus, statusable, nil
 }
 
+// handleStatusEdit compares the existing status model with the latest
+// dereferenced version of it, and if any of the fields shown in edit
+// history have changed, stores the existing version as a revision.
+func (d *Dereferencer) handleStatusEdit(
+	ctx context.Context,
+	existing *gtsmodel.Status,
+	status *gtsmodel.Status,
+) error {
+	// Carry-over existing revisions,
+	// these aren't sent by the remote.
+	status.EditIDs = existing.EditIDs
+	status.Edits = existing.Edits
+
+	if status.EditedAt.IsZero() {
+		// Carry-over existing edit time if
+		// latest version didn't include one.
+		status.EditedAt = existing.EditedAt
+	}
+
+	if existing.Content == status.Content &&
+		existing.ContentWarning == status.ContentWarning &&
+		util.PtrOrZero(existing.Sensitive) == util.PtrOrZero(status.Sensitive) &&
+		existing.PollID == status.PollID &&
+		slices.Equal(existing.AttachmentIDs, status.AttachmentIDs) {
+		// Nothing changed that
+		// we keep history for.
+		return nil
+	}
+
+	if !status.EditedAt.After(existing.EditedAt) {
+		// Remote didn't give a newer edit
+		// time, so take it as edited now.
+		status.EditedAt = status.FetchedAt
+	}
+
+	// Snapshot the existing version of
+	// the status as a historical revision.
+	edit := &gtsmodel.StatusEdit{
+		ID:             id.NewULID(),
+		CreatedAt:      existing.CreatedAt,
+		StatusID:       existing.ID,
+		Content:        existing.Content,
+		ContentWarning: existing.ContentWarning,
+		Text:           existing.Text,
+		Language:       existing.Language,
+		Sensitive:      existing.Sensitive,
+		AttachmentIDs:  existing.AttachmentIDs,
+		Attachments:    existing.Attachments,
+	}
+
+	if !existing.EditedAt.IsZero() {
+		// Existing version dates
+		// from when it was edited.
+		edit.CreatedAt = existing.EditedAt
+	}
+
+	if existing.Poll != nil {
+		edit.PollOptions = existing.Poll.Options
+		edit.PollVotes = existing.Poll.Votes
+	}
+
+	// Insert the revision in the database.
+	if err := d.state.DB.PutStatusEdit(ctx, edit); err != nil {
+		return gtserror.Newf("error putting status edit in database: %w", err)
+	}
+
+	// Add the revision to the status.
+	status.EditIDs = append(slices.Clone(status.EditIDs), edit.ID)
+	status.Edits = append(slices.Clone(status.Edits), edit)
+
+	return nil
+}
+
 func (d *Dereferencer) fetchStatusMentions(
 	ctx context.Context,
 	requestUser string,
@@ -886,6 +996,7 @@ func (d *Dereferencer) fetchStatusAttachments(
 	requestUser string,
 	existing *gtsmodel.Status,
 	status *gtsmodel.Status,
+	rejectMedia bool,
 ) error {
 	// Allocate new slice to take the yet-to-be fetched attachment IDs.
 	status.AttachmentIDs = make([]string, len(status.Attachments))
@@ -896,6 +1007,13 @@ func (d *Dereferencer) fetchStatusAttachments(
 		// Look for existing media attachment with remote URL first.
 		existing, ok := existing.GetAttachmentByRemoteURL(placeholder.RemoteURL)
 		if ok && existing.ID != "" {
+			if rejectMedia {
+				// Media is rejected, so keep the existing
+				// attachment as-is, without recaching it.
+				status.Attachments[i] = existing
+				status.AttachmentIDs[i] = existing.ID
+				continue
+			}
 
 			// Ensure the existing media attachment is up-to-date and cached.
 			existing, err := d.updateAttachment(ctx, requestUser, existing, placeholder)
@@ -914,6 +1032,24 @@ func (d *Dereferencer) fetchStatusAttachments(
 			continue
 		}
 
+		if rejectMedia {
+			// Media is rejected, so only store a
+			// placeholder without dereferencing it.
+			attachment, err := d.putRejectedMedia(ctx,
+				status.AccountID,
+				status.ID,
+				placeholder,
+			)
+			if err != nil {
+				log.Errorf(ctx, "error storing rejected attachment %s: %v", placeholder.RemoteURL, err)
+				continue
+			}
+
+			status.Attachments[i] = attachment
+			status.AttachmentIDs[i] = attachment.ID
+			continue
+		}
+
 		// Load this new media attachment.
 		attachment, err := d.GetMedia(
 			ctx,
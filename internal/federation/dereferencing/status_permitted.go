@@ -44,11 +44,12 @@ import (
 // If status is not permitted to be stored, the function
 // will clean up after itself by removing the status.
 //
-// If status is a reply or a boost, and the author of
-// the given status is only permitted to reply or boost
-// pending approval, then "PendingApproval" will be set
-// to "true" on status. Callers should check this
-// and handle it as appropriate.
+// If status is a reply, a quote, or a boost, and the author
+// of the given status is only permitted to reply, quote,
+// or boost pending approval, then "PendingApproval" will
+// be set to "true" on status (and "QuotePendingApproval"
+// too, for quotes). Callers should check this and handle
+// it as appropriate.
 //
 // If status is a reply that is not permitted based on
 // interaction policies, or status replies to a status
@@ -98,6 +99,17 @@ func (d *Dereferencer) isPermittedStatus(
 		permitted = true
 	}
 
+	if permitted && status.QuoteURI != "" {
+		// Status is (also) a quote, check permissivity.
+		permitted, err = d.isPermittedQuote(ctx,
+			requestUser,
+			status,
+		)
+		if err != nil {
+			return false, gtserror.Newf("error checking quote permissivity: %w", err)
+		}
+	}
+
 	if !permitted && existing != nil {
 		log.Infof(ctx, "deleting unpermitted: %s", existing.URI)
 
@@ -438,6 +450,144 @@ func (d *Dereferencer) isPermittedBoost(
 	return true, nil
 }
 
+func (d *Dereferencer) isPermittedQuote(
+	ctx context.Context,
+	requestUser string,
+	status *gtsmodel.Status,
+) (bool, error) {
+	// Check if status with this URI has previously been rejected.
+	req, err := d.state.DB.GetInteractionRequestByInteractionURI(
+		gtscontext.SetBarebones(ctx),
+		status.URI,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting interaction request: %w", err)
+		return false, err
+	}
+
+	if req != nil && req.IsRejected() {
+		// This status has been
+		// rejected reviously, so
+		// it's not permitted now.
+		return false, nil
+	}
+
+	// Extract quote from status.
+	quote := status.Quote
+	if quote == nil {
+		// We didn't have the quoted status in
+		// our database (yet) so we can't know if
+		// this quote is permitted or not. For now
+		// just return true; the quote will be
+		// checked again once it's dereferenced.
+		return true, nil
+	}
+
+	if quote.BoostOfID != "" {
+		// We do not permit quotes of
+		// boost wrapper statuses. (this
+		// shouldn't be able to happen).
+		log.Info(ctx, "rejecting quote of boost wrapper status")
+		return false, nil
+	}
+
+	// Check visibility of local
+	// quote to quoting account.
+	if quote.IsLocal() {
+		visible, err := d.visFilter.StatusVisible(ctx,
+			status.Account,
+			quote,
+		)
+		if err != nil {
+			err := gtserror.Newf("error checking quote visibility: %w", err)
+			return false, err
+		}
+
+		// Our status is not visible to the
+		// account trying to do the quote.
+		if !visible {
+			return false, nil
+		}
+	}
+
+	// Check interaction policy of quote.
+	quoteable, err := d.intFilter.StatusQuoteable(ctx,
+		status.Account,
+		quote,
+	)
+	if err != nil {
+		err := gtserror.Newf("error checking status quoteability: %w", err)
+		return false, err
+	}
+
+	if quoteable.Forbidden() {
+		// Quoter is not permitted
+		// to do this interaction.
+		return false, nil
+	}
+
+	if quoteable.Permitted() &&
+		!quoteable.MatchedOnCollection() {
+		// Quoter is permitted to do this
+		// interaction, and didn't match on
+		// a collection so we don't need to
+		// do further checking.
+		return true, nil
+	}
+
+	// Quoter is permitted to do this
+	// interaction pending approval, or
+	// permitted but matched on a collection.
+	//
+	// Check if we can dereference
+	// an Accept that grants approval.
+
+	if status.ApprovedByURI == "" {
+		// Status doesn't claim to be approved.
+		//
+		// For quotes of local statuses that's
+		// fine, we can put it in the DB pending
+		// approval, and continue processing it,
+		// unless it's already pending approval as
+		// a reply, as we can only track one pending
+		// interaction per status.
+		//
+		// For quotes of remote statuses, though
+		// we should be polite and just drop it.
+		if quote.IsLocal() &&
+			!util.PtrOrValue(status.PendingApproval, false) {
+			status.PendingApproval = util.Ptr(true)
+			status.PreApproved = quoteable.MatchedOnCollection()
+			status.QuotePendingApproval = true
+			return true, nil
+		}
+
+		return false, nil
+	}
+
+	// Status claims to be approved, check
+	// this by dereferencing the Accept and
+	// inspecting the return value.
+	if err := d.validateApprovedBy(
+		ctx,
+		requestUser,
+		status.ApprovedByURI,
+		status.URI,
+		quote.AccountURI,
+	); err != nil {
+
+		// Error dereferencing means we couldn't
+		// get the Accept right now or it wasn't
+		// valid, so we shouldn't store this status.
+		log.Errorf(ctx, "undereferencable ApprovedByURI: %v", err)
+		return false, nil
+	}
+
+	// Status has been approved.
+	status.PendingApproval = util.Ptr(false)
+	return true, nil
+}
+
 // validateApprovedBy dereferences the activitystreams Accept at
 // the specified IRI, and checks the Accept for validity against
 // the provided expectedObject and expectedActor.
@@ -26,6 +26,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -70,6 +71,35 @@ func (suite *StatusTestSuite) TestDereferenceSimpleStatus() {
 	suite.Nil(account.PrivateKey)
 }
 
+func (suite *StatusTestSuite) TestDereferenceStatusContentLimited() {
+	ctx := context.Background()
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	// Limit content from the status author's domain.
+	if err := suite.db.PutContentLimit(ctx, &gtsmodel.ContentLimit{
+		ID:                 "01JBB6M1CR6FBNPZ8Z6R3FV9ZC",
+		Domain:             "unknown-instance.com",
+		ForceSensitive:     util.Ptr(true),
+		ContentWarning:     "limited",
+		RejectMedia:        util.Ptr(false),
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statusURL := testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person/statuses/01FE4NTHKWW7THT67EF10EB839")
+	status, _, err := suite.dereferencer.GetStatusByURI(ctx, fetchingAccount.Username, statusURL)
+	suite.NoError(err)
+	suite.NotNil(status)
+
+	// Limits are only applied at serialization,
+	// so the status should be stored unchanged.
+	dbStatus, err := suite.db.GetStatusByURI(ctx, status.URI)
+	suite.NoError(err)
+	suite.Empty(dbStatus.ContentWarning)
+	suite.False(*dbStatus.Sensitive)
+}
+
 func (suite *StatusTestSuite) TestDereferenceStatusWithMention() {
 	fetchingAccount := suite.testAccounts["local_account_1"]
 
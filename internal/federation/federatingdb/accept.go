@@ -71,6 +71,28 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 	// Iterate all provided objects in the activity,
 	// handling the ones we know how to handle.
 	for _, object := range ap.ExtractObjects(accept) {
+		// Check for Accept of a Follow
+		// sent to a relay by our instance.
+		relay, err := f.getRelayFollow(ctx,
+			object,
+			receivingAcct,
+			requestingAcct,
+		)
+		if err != nil {
+			return err
+		}
+
+		if relay != nil {
+			if err := f.setRelayState(ctx,
+				relay,
+				gtsmodel.RelayStateAccepted,
+				requestingAcct,
+			); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if asType := object.GetType(); asType != nil {
 
 			// Check and handle any vocab.Type objects.
@@ -325,11 +347,12 @@ func (f *federatingDB) acceptStatusIRI(
 		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
 	}
 
-	// Make sure the target of the interaction (reply/boost)
+	// Make sure the target of the interaction (reply/boost/quote)
 	// is the same as the account doing the Accept.
 	if status.BoostOfAccountID != requestingAcct.ID &&
-		status.InReplyToAccountID != requestingAcct.ID {
-		const text = "status reply to or boost of account and requesting account were not the same"
+		status.InReplyToAccountID != requestingAcct.ID &&
+		status.QuoteAccountID != requestingAcct.ID {
+		const text = "status reply to, boost of, or quote of account and requesting account were not the same"
 		return gtserror.NewErrorForbidden(errors.New(text), text)
 	}
 
@@ -347,8 +370,8 @@ func (f *federatingDB) acceptStatusIRI(
 	}
 
 	var apObjectType string
-	if status.InReplyToID != "" {
-		// Accepting a Reply.
+	if status.InReplyToID != "" || status.QuoteID != "" {
+		// Accepting a Reply or Quote.
 		apObjectType = ap.ObjectNote
 	} else {
 		// Accepting an Announce.
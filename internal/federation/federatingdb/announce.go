@@ -46,6 +46,30 @@ func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStre
 		return nil
 	}
 
+	// Relays either Announce (LitePub) or forward
+	// (Mastodon) public posts from other instances,
+	// so dereference the announced statuses instead.
+	relay, err := f.isRelay(ctx, requestingAcct)
+	if err != nil {
+		return err
+	}
+
+	if relay {
+		for _, object := range ap.ExtractObjects(announce) {
+			var statusIRI *url.URL
+			if asType := object.GetType(); asType != nil {
+				statusIRI = ap.GetJSONLDId(asType)
+			} else if object.IsIRI() {
+				statusIRI = object.GetIRI()
+			}
+
+			if statusIRI != nil {
+				f.relayStatus(statusIRI, receivingAcct, requestingAcct)
+			}
+		}
+		return nil
+	}
+
 	// Ensure requestingAccount is among
 	// the Actors doing the Announce.
 	//
@@ -317,6 +317,22 @@ func (f *federatingDB) createStatusable(
 	statusable ap.Statusable,
 	forwarded bool,
 ) error {
+	if forwarded {
+		// Statuses forwarded by a relay aren't relevant
+		// to the receiver by the usual heuristics, but
+		// are wanted for the federated timeline, so skip
+		// the spam filter and deref them from origin.
+		relay, err := f.isRelay(ctx, requester)
+		if err != nil {
+			return err
+		}
+
+		if relay {
+			f.relayStatus(ap.GetJSONLDId(statusable), receiver, requester)
+			return nil
+		}
+	}
+
 	// Check whether this status is both
 	// relevant, and doesn't look like spam.
 	err := f.spamFilter.StatusableOK(ctx,
@@ -451,6 +467,16 @@ func (f *federatingDB) activityLike(ctx context.Context, asType vocab.Type, rece
 		return errors.New("activityLike: could not convert type to like")
 	}
 
+	if ap.ExtractReaction(like) != "" {
+		// This Like carries an emoji,
+		// so handle it as a reaction.
+		return f.activityReaction(ctx,
+			like,
+			receivingAccount,
+			requestingAccount,
+		)
+	}
+
 	fave, err := f.converter.ASLikeToFave(ctx, like)
 	if err != nil {
 		return fmt.Errorf("activityLike: could not convert Like to fave: %w", err)
@@ -486,6 +512,49 @@ func (f *federatingDB) activityLike(ctx context.Context, asType vocab.Type, rece
 	return nil
 }
 
+func (f *federatingDB) activityReaction(ctx context.Context, like vocab.ActivityStreamsLike, receivingAccount *gtsmodel.Account, requestingAccount *gtsmodel.Account) error {
+	reaction, err := f.converter.ASLikeToReaction(ctx, like)
+	if err != nil {
+		return fmt.Errorf("activityReaction: could not convert Like to reaction: %w", err)
+	}
+
+	if reaction.AccountID != requestingAccount.ID {
+		return fmt.Errorf(
+			"activityReaction: requestingAccount %s is not Like actor account %s",
+			requestingAccount.URI, reaction.Account.URI,
+		)
+	}
+
+	existing, err := f.state.DB.GetStatusReactionByURI(
+		gtscontext.SetBarebones(ctx),
+		reaction.URI,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return fmt.Errorf("activityReaction: database error getting reaction: %w", err)
+	}
+
+	if existing != nil {
+		// We've already handled this
+		// reaction, nothing to do.
+		return nil
+	}
+
+	reaction.ID = id.NewULID()
+
+	// The reaction is stored by the worker, once it's
+	// been checked against the interaction policy of
+	// the status, and any custom emoji dereferenced.
+	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+		APObjectType:   ap.ActivityEmojiReact,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       reaction,
+		Receiving:      receivingAccount,
+		Requesting:     requestingAccount,
+	})
+
+	return nil
+}
+
 /*
 	FLAG HANDLERS
 */
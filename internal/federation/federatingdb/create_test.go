@@ -135,6 +135,48 @@ func (suite *CreateTestSuite) TestCreateFlag1() {
 	}
 }
 
+func (suite *CreateTestSuite) TestCreateReaction() {
+	reactedAccount := suite.testAccounts["local_account_1"]
+	reactingAccount := suite.testAccounts["remote_account_1"]
+	reactedStatus := suite.testStatuses["local_account_1_status_1"]
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "` + reactingAccount.URI + `",
+  "content": "🐸",
+  "id": "http://fossbros-anonymous.io/reactions/5ba2a5f0-3a6d-4fa5-9e9a-8a5b8bde4a3e",
+  "object": "` + reactedStatus.URI + `",
+  "type": "Like"
+}`
+
+	m := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t, err := streams.ToType(context.Background(), m)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx := createTestContext(reactedAccount, reactingAccount)
+	if err := suite.federatingDB.Create(ctx, t); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// should be a reaction heading to the processor
+	// now, rather than a fave, which we can intercept here
+	msg, _ := suite.getFederatorMsg(5 * time.Second)
+	suite.Equal(ap.ActivityEmojiReact, msg.APObjectType)
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+
+	reaction := msg.GTSModel.(*gtsmodel.StatusReaction)
+	suite.Equal("🐸", reaction.Name)
+	suite.Equal(reactingAccount.ID, reaction.AccountID)
+	suite.Equal(reactedStatus.ID, reaction.StatusID)
+	suite.Empty(reaction.EmojiID)
+}
+
 func TestCreateTestSuite(t *testing.T) {
 	suite.Run(t, &CreateTestSuite{})
 }
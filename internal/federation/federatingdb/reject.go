@@ -52,6 +52,28 @@ func (f *federatingDB) Reject(ctx context.Context, reject vocab.ActivityStreamsR
 	}
 
 	for _, object := range ap.ExtractObjects(reject) {
+		// Check for Reject of a Follow
+		// sent to a relay by our instance.
+		relay, err := f.getRelayFollow(ctx,
+			object,
+			receivingAcct,
+			requestingAcct,
+		)
+		if err != nil {
+			return err
+		}
+
+		if relay != nil {
+			if err := f.setRelayState(ctx,
+				relay,
+				gtsmodel.RelayStateRejected,
+				requestingAcct,
+			); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if asType := object.GetType(); asType != nil {
 			// Check and handle any vocab.Type objects.
 			switch name := asType.GetTypeName(); name {
@@ -271,11 +293,22 @@ func (f *federatingDB) rejectStatusIRI(
 		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
 	}
 
-	// Check if we're dealing with a reply
-	// or an announce, and make sure the
-	// requester is permitted to Reject.
-	var apObjectType string
-	if status.InReplyToID != "" {
+	// Check if we're dealing with a reply,
+	// a quote, or an announce, and make sure
+	// the requester is permitted to Reject.
+	var (
+		apObjectType string
+		isQuote      = status.QuoteID != "" &&
+			status.QuoteAccountID == requestingAcct.ID &&
+			status.InReplyToAccountID != requestingAcct.ID
+	)
+
+	if isQuote {
+		// Rejecting a Quote. We've already
+		// checked the requester is the quotee.
+		apObjectType = ap.ObjectNote
+
+	} else if status.InReplyToID != "" {
 		// Rejecting a Reply.
 		apObjectType = ap.ObjectNote
 		if status.InReplyToAccountID != requestingAcct.ID {
@@ -321,7 +354,13 @@ func (f *federatingDB) rejectStatusIRI(
 			RejectedAt:           time.Now(),
 		}
 
-		if apObjectType == ap.ObjectNote {
+		if isQuote {
+			// Quote.
+			req.InteractionType = gtsmodel.InteractionQuote
+			req.StatusID = status.QuoteID
+			req.Status = status.Quote
+			req.Quote = status
+		} else if apObjectType == ap.ObjectNote {
 			// Reply.
 			req.InteractionType = gtsmodel.InteractionReply
 			req.StatusID = status.InReplyToID
@@ -0,0 +1,154 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// getRelayFollow returns the relay to which the given
+// Follow object was sent by our instance account, if
+// the object is such a Follow, else nil.
+func (f *federatingDB) getRelayFollow(
+	ctx context.Context,
+	object ap.TypeOrIRI,
+	receivingAcct *gtsmodel.Account,
+	requestingAcct *gtsmodel.Account,
+) (*gtsmodel.Relay, error) {
+	if !receivingAcct.IsLocal() || !receivingAcct.IsInstance() {
+		// Relays are only
+		// followed by us.
+		return nil, nil
+	}
+
+	var followIRI *url.URL
+	if asType := object.GetType(); asType != nil {
+		if asType.GetTypeName() != ap.ActivityFollow {
+			return nil, nil
+		}
+		followIRI = ap.GetJSONLDId(asType)
+	} else if object.IsIRI() {
+		followIRI = object.GetIRI()
+	}
+
+	if followIRI == nil {
+		return nil, nil
+	}
+
+	relay, err := f.state.DB.GetRelayByFollowURI(ctx, followIRI.String())
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Not a relay
+			// Follow, ignore.
+			return nil, nil
+		}
+
+		err := gtserror.Newf("db error getting relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Make sure the relay is the
+	// one making the request.
+	if relay.Domain != requestingAcct.Domain {
+		const text = "Relay domain and requesting account domain were not the same"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	if relay.IsLitePub() && relay.ActorURI != requestingAcct.URI {
+		const text = "Relay actor and requesting account were not the same"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	return relay, nil
+}
+
+// setRelayState updates the state of the given relay
+// in response to an Accept or Reject by requestingAcct.
+// On Accept, the requesting account is stored as the
+// relay actor from which relayed activities are accepted.
+func (f *federatingDB) setRelayState(
+	ctx context.Context,
+	relay *gtsmodel.Relay,
+	state gtsmodel.RelayState,
+	requestingAcct *gtsmodel.Account,
+) error {
+	// Lock on the Follow URI
+	// as we're updating it.
+	unlock := f.state.FedLocks.Lock(relay.FollowURI)
+	defer unlock()
+
+	relay.State = state
+	relay.AcceptedByURI = ""
+	if state == gtsmodel.RelayStateAccepted {
+		relay.AcceptedByURI = requestingAcct.URI
+	}
+
+	if err := f.state.DB.UpdateRelay(ctx, relay,
+		"state",
+		"accepted_by_uri",
+	); err != nil {
+		err := gtserror.Newf("db error updating relay: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// isRelay returns whether the given requesting account
+// is the actor of a relay that we're subscribed to.
+func (f *federatingDB) isRelay(
+	ctx context.Context,
+	requestingAcct *gtsmodel.Account,
+) (bool, error) {
+	if requestingAcct.IsLocal() {
+		return false, nil
+	}
+
+	relay, err := f.state.DB.IsRelayActor(ctx, requestingAcct.URI)
+	if err != nil {
+		return false, gtserror.Newf("db error checking relay actor: %w", err)
+	}
+
+	return relay, nil
+}
+
+// relayStatus passes the given status IRI
+// to the processor to be dereferenced and
+// stored asynchronously, as relayed content
+// must be fetched from its origin instance.
+func (f *federatingDB) relayStatus(
+	statusIRI *url.URL,
+	receivingAcct *gtsmodel.Account,
+	requestingAcct *gtsmodel.Account,
+) {
+	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityCreate,
+		APIRI:          statusIRI,
+		Receiving:      receivingAcct,
+		Requesting:     requestingAcct,
+	})
+}
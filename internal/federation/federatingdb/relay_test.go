@@ -0,0 +1,229 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RelayTestSuite struct {
+	FederatingDBTestSuite
+}
+
+func (suite *RelayTestSuite) TestAcceptRelayFollow() {
+	instanceAccount := suite.testAccounts["instance_account"]
+	relay := testrig.NewTestRelays()["relay_pending"]
+	relayAccount := &gtsmodel.Account{
+		URI:    relay.ActorURI,
+		Domain: relay.Domain,
+	}
+	ctx := createTestContext(instanceAccount, relayAccount)
+
+	// The relay accepts our Follow by IRI.
+	accept := streams.NewActivityStreamsAccept()
+	ap.SetJSONLDId(accept, testrig.URLMustParse("https://litepub.example.org/activities/accept"))
+	ap.AppendActorIRIs(accept, testrig.URLMustParse(relay.ActorURI))
+	ap.AppendObjectIRIs(accept, testrig.URLMustParse(relay.FollowURI))
+
+	err := suite.federatingDB.Accept(ctx, accept)
+	suite.NoError(err)
+
+	// Nothing should be passed to the processor.
+	_, ok := suite.getFederatorMsg(time.Second)
+	suite.False(ok)
+
+	// Relay should now be accepted.
+	dbRelay, err := suite.state.DB.GetRelayByID(ctx, relay.ID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.RelayStateAccepted, dbRelay.State)
+
+	suite.Equal(relay.ActorURI, dbRelay.AcceptedByURI)
+
+	isRelay, err := suite.state.DB.IsRelayActor(ctx, relay.ActorURI)
+	suite.NoError(err)
+	suite.True(isRelay)
+
+	// Other accounts on the relay
+	// domain aren't trusted as relays.
+	isRelay, err = suite.state.DB.IsRelayActor(ctx, "https://litepub.example.org/users/someone")
+	suite.NoError(err)
+	suite.False(isRelay)
+}
+
+func (suite *RelayTestSuite) TestAcceptRelayFollowWrongDomain() {
+	instanceAccount := suite.testAccounts["instance_account"]
+	remoteAccount := suite.testAccounts["remote_account_1"]
+	relay := testrig.NewTestRelays()["relay_pending"]
+	ctx := createTestContext(instanceAccount, remoteAccount)
+
+	// Someone other than the relay
+	// tries to accept our Follow.
+	accept := streams.NewActivityStreamsAccept()
+	ap.SetJSONLDId(accept, testrig.URLMustParse("http://fossbros-anonymous.io/activities/accept"))
+	ap.AppendActorIRIs(accept, testrig.URLMustParse(remoteAccount.URI))
+	ap.AppendObjectIRIs(accept, testrig.URLMustParse(relay.FollowURI))
+
+	err := suite.federatingDB.Accept(ctx, accept)
+	suite.EqualError(err, "Relay domain and requesting account domain were not the same")
+
+	// Relay should still be pending.
+	dbRelay, err := suite.state.DB.GetRelayByID(ctx, relay.ID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.RelayStatePending, dbRelay.State)
+}
+
+func (suite *RelayTestSuite) TestRejectRelayFollow() {
+	instanceAccount := suite.testAccounts["instance_account"]
+	relay := testrig.NewTestRelays()["relay_pending"]
+	relayAccount := &gtsmodel.Account{
+		URI:    relay.ActorURI,
+		Domain: relay.Domain,
+	}
+	ctx := createTestContext(instanceAccount, relayAccount)
+
+	// The relay rejects our Follow.
+	follow, err := suite.tc.RelayToASFollow(ctx, relay, instanceAccount)
+	suite.NoError(err)
+
+	reject := streams.NewActivityStreamsReject()
+	ap.SetJSONLDId(reject, testrig.URLMustParse("https://litepub.example.org/activities/reject"))
+	ap.AppendActorIRIs(reject, testrig.URLMustParse(relay.ActorURI))
+	object := streams.NewActivityStreamsObjectProperty()
+	object.AppendActivityStreamsFollow(follow)
+	reject.SetActivityStreamsObject(object)
+
+	err = suite.federatingDB.Reject(ctx, reject)
+	suite.NoError(err)
+
+	dbRelay, err := suite.state.DB.GetRelayByID(ctx, relay.ID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.RelayStateRejected, dbRelay.State)
+}
+
+func (suite *RelayTestSuite) TestRelayedAnnounce() {
+	instanceAccount := suite.testAccounts["instance_account"]
+	relayAccount := &gtsmodel.Account{
+		URI:    "https://relay.example.org/actor",
+		Domain: "relay.example.org",
+	}
+	ctx := createTestContext(instanceAccount, relayAccount)
+
+	// LitePub-style relayed Announce of a remote status.
+	statusIRI := testrig.URLMustParse("https://somewhere.else.example.org/statuses/01JAM0D7Y1V4TG3RSM2WXQ8BZE")
+	announce := streams.NewActivityStreamsAnnounce()
+	ap.SetJSONLDId(announce, testrig.URLMustParse("https://relay.example.org/activities/announce"))
+	ap.AppendActorIRIs(announce, testrig.URLMustParse(relayAccount.URI))
+	ap.AppendObjectIRIs(announce, statusIRI)
+	ap.AppendTo(announce, testrig.URLMustParse(pub.PublicActivityPubIRI))
+
+	err := suite.federatingDB.Announce(ctx, announce)
+	suite.NoError(err)
+
+	// Status should be passed to the processor
+	// to be dereferenced by IRI, not as a boost.
+	msg, ok := suite.getFederatorMsg(5 * time.Second)
+	suite.True(ok)
+	suite.Equal(ap.ObjectNote, msg.APObjectType)
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+	suite.Equal(statusIRI.String(), msg.APIRI.String())
+	suite.Nil(msg.GTSModel)
+}
+
+func (suite *RelayTestSuite) TestRelayedAnnounceWrongActor() {
+	instanceAccount := suite.testAccounts["instance_account"]
+	otherAccount := &gtsmodel.Account{
+		URI:    "https://relay.example.org/users/someone",
+		Domain: "relay.example.org",
+	}
+	ctx := createTestContext(instanceAccount, otherAccount)
+
+	// Another account on the relay's domain
+	// tries to pass off an Announce as relayed.
+	statusIRI := testrig.URLMustParse("https://somewhere.else.example.org/statuses/01JAM0D7Y1V4TG3RSM2WXQ8BZE")
+	announce := streams.NewActivityStreamsAnnounce()
+	ap.SetJSONLDId(announce, testrig.URLMustParse("https://relay.example.org/activities/announce"))
+	ap.AppendActorIRIs(announce, testrig.URLMustParse("https://relay.example.org/actor"))
+	ap.AppendObjectIRIs(announce, statusIRI)
+	ap.AppendTo(announce, testrig.URLMustParse(pub.PublicActivityPubIRI))
+
+	// It's handled as a normal Announce, not a relayed one.
+	err := suite.federatingDB.Announce(ctx, announce)
+	suite.ErrorContains(err, "was not among Announce Actors")
+
+	_, ok := suite.getFederatorMsg(time.Second)
+	suite.False(ok)
+}
+
+func (suite *RelayTestSuite) TestRelayedCreate() {
+	instanceAccount := suite.testAccounts["instance_account"]
+	remoteAccount := suite.testAccounts["remote_account_1"]
+	relayAccount := &gtsmodel.Account{
+		URI:    "https://relay.example.org/actor",
+		Domain: "relay.example.org",
+	}
+	ctx := createTestContext(instanceAccount, relayAccount)
+
+	// Mastodon-style relay forwards a Create
+	// from a remote account we don't follow.
+	noteIRI := testrig.URLMustParse("http://fossbros-anonymous.io/users/foss_satan/statuses/01JAM0FQ4D8XK2W5NB7RCT9YHV")
+	note := testrig.NewAPNote(
+		noteIRI,
+		noteIRI,
+		time.Now(),
+		"hello relay subscribers",
+		"",
+		testrig.URLMustParse(remoteAccount.URI),
+		[]*url.URL{testrig.URLMustParse(pub.PublicActivityPubIRI)},
+		nil,
+		false,
+		nil,
+		nil,
+		nil,
+	)
+	create := testrig.WrapAPNoteInCreate(
+		testrig.URLMustParse(noteIRI.String()+"/activity"),
+		testrig.URLMustParse(remoteAccount.URI),
+		time.Now(),
+		note,
+	)
+
+	err := suite.federatingDB.Create(ctx, create)
+	suite.NoError(err)
+
+	// Status should be passed to the processor
+	// to be dereferenced from origin by IRI.
+	msg, ok := suite.getFederatorMsg(5 * time.Second)
+	suite.True(ok)
+	suite.Equal(ap.ObjectNote, msg.APObjectType)
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+	suite.Equal(noteIRI.String(), msg.APIRI.String())
+	suite.Nil(msg.APObject)
+}
+
+func TestRelayTestSuite(t *testing.T) {
+	suite.Run(t, &RelayTestSuite{})
+}
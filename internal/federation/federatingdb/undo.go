@@ -47,6 +47,18 @@ func (f *federatingDB) Undo(ctx context.Context, undo vocab.ActivityStreamsUndo)
 		// else skip handling (likely) IRI.
 		asType := object.GetType()
 		if asType == nil {
+			// Pleroma and friends Undo their
+			// EmojiReacts by IRI only, so check
+			// if this IRI is a stored reaction.
+			if iri := object.GetIRI(); iri != nil {
+				if err := f.undoReactionByURI(
+					ctx,
+					requestingAcct,
+					iri.String(),
+				); err != nil {
+					return err
+				}
+			}
 			continue
 		}
 
@@ -192,6 +204,17 @@ func (f *federatingDB) undoLike(
 		return nil
 	}
 
+	if ap.ExtractReaction(asLike) != "" {
+		// This Like carries an emoji,
+		// so it's a reaction to undo.
+		return f.undoReaction(
+			ctx,
+			receivingAcct,
+			requestingAcct,
+			asLike,
+		)
+	}
+
 	// Convert AS Like to barebones *gtsmodel.StatusFave,
 	// retrieving liking acct and target status from the DB.
 	fave, err := f.converter.ASLikeToFave(
@@ -260,6 +283,110 @@ func (f *federatingDB) undoLike(
 	return nil
 }
 
+func (f *federatingDB) undoReaction(
+	ctx context.Context,
+	receivingAcct *gtsmodel.Account,
+	requestingAcct *gtsmodel.Account,
+	asLike vocab.ActivityStreamsLike,
+) error {
+	// Convert AS Like to barebones *gtsmodel.StatusReaction,
+	// retrieving reacting acct and target status from the DB.
+	reaction, err := f.converter.ASLikeToReaction(
+		gtscontext.SetBarebones(ctx),
+		asLike,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error converting AS Like to reaction: %w", err)
+		return err
+	}
+
+	// We were missing status, account,
+	// or other for this Like, so we
+	// cannot Undo anything.
+	if reaction == nil {
+		return nil
+	}
+
+	// Ensure addressee is reaction target.
+	if reaction.TargetAccountID != receivingAcct.ID {
+		const text = "receivingAcct was not Reaction target"
+		return gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	// Ensure requester is reaction origin.
+	if reaction.AccountID != requestingAcct.ID {
+		const text = "requestingAcct was not Reaction origin"
+		return gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	// Fetch reaction from the DB so we know the ID
+	// to delete it, selecting by account, target status
+	// and emoji, in case the URI has changed somehow.
+	reaction, err = f.state.DB.GetStatusReaction(
+		gtscontext.SetBarebones(ctx),
+		reaction.AccountID,
+		reaction.StatusID,
+		reaction.Name,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting reaction: %w", err)
+		return err
+	}
+
+	if reaction == nil {
+		// We didn't have this reaction
+		// stored anyway, so we can't
+		// Undo it, just ignore.
+		return nil
+	}
+
+	// Delete the reaction.
+	if err := f.state.DB.DeleteStatusReactionByID(ctx, reaction.ID); err != nil {
+		err := gtserror.Newf("db error deleting reaction %s: %w", reaction.ID, err)
+		return err
+	}
+
+	log.Debug(ctx, "Reaction undone")
+	return nil
+}
+
+func (f *federatingDB) undoReactionByURI(
+	ctx context.Context,
+	requestingAcct *gtsmodel.Account,
+	uri string,
+) error {
+	reaction, err := f.state.DB.GetStatusReactionByURI(
+		gtscontext.SetBarebones(ctx),
+		uri,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting reaction %s: %w", uri, err)
+		return err
+	}
+
+	if reaction == nil {
+		// Not a reaction (or not
+		// one we have), ignore.
+		log.Debugf(ctx, "unhandled object iri: %s", uri)
+		return nil
+	}
+
+	// Ensure requester is reaction origin.
+	if reaction.AccountID != requestingAcct.ID {
+		const text = "requestingAcct was not Reaction origin"
+		return gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	// Delete the reaction.
+	if err := f.state.DB.DeleteStatusReactionByID(ctx, reaction.ID); err != nil {
+		err := gtserror.Newf("db error deleting reaction %s: %w", reaction.ID, err)
+		return err
+	}
+
+	log.Debug(ctx, "Reaction undone")
+	return nil
+}
+
 func (f *federatingDB) undoBlock(
 	ctx context.Context,
 	receivingAcct *gtsmodel.Account,
@@ -328,6 +328,141 @@ func (f *Filter) StatusBoostable(
 	}
 }
 
+// StatusQuoteable checks if the given status
+// is quoteable by the requester account.
+//
+// Callers to this function should have already
+// checked the visibility of status to requester,
+// including taking account of blocks, as this
+// function does not do visibility checks, only
+// interaction policy checks.
+func (f *Filter) StatusQuoteable(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	status *gtsmodel.Status,
+) (*gtsmodel.PolicyCheckResult, error) {
+	if status.Visibility == gtsmodel.VisibilityDirect {
+		log.Trace(ctx, "direct statuses are not quoteable")
+		return &gtsmodel.PolicyCheckResult{
+			Permission: gtsmodel.PolicyPermissionForbidden,
+		}, nil
+	}
+
+	if requester.ID == status.AccountID {
+		// Status author themself can
+		// always quote non-directs,
+		// no need for further checks.
+		return &gtsmodel.PolicyCheckResult{
+			Permission:         gtsmodel.PolicyPermissionPermitted,
+			PermittedMatchedOn: util.Ptr(gtsmodel.PolicyValueAuthor),
+		}, nil
+	}
+
+	switch {
+	// If status has policy set, check against that.
+	case status.InteractionPolicy != nil:
+		rules := status.InteractionPolicy.CanQuote
+		if rules.IsZero() {
+			// Policy was set before quote
+			// rules existed (or by a remote
+			// that doesn't know about them),
+			// so treat quotes like announces.
+			rules = status.InteractionPolicy.CanAnnounce
+		}
+
+		return f.checkPolicy(
+			ctx,
+			requester,
+			status,
+			rules,
+		)
+
+	// If status is local and has no policy set,
+	// check against the default policy for this
+	// visibility, as we're interaction-policy aware.
+	case *status.Local:
+		policy := gtsmodel.DefaultInteractionPolicyFor(status.Visibility)
+		return f.checkPolicy(
+			ctx,
+			requester,
+			status,
+			policy.CanQuote,
+		)
+
+	// Otherwise, assume the status is from an
+	// instance that does not use / does not care
+	// about interaction policies, and just return OK.
+	default:
+		return &gtsmodel.PolicyCheckResult{
+			Permission: gtsmodel.PolicyPermissionPermitted,
+		}, nil
+	}
+}
+
+// StatusReactable checks if the given status
+// can be emoji reacted to by the requester account.
+//
+// Callers to this function should have already
+// checked the visibility of status to requester,
+// including taking account of blocks, as this
+// function does not do visibility checks, only
+// interaction policy checks.
+func (f *Filter) StatusReactable(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	status *gtsmodel.Status,
+) (*gtsmodel.PolicyCheckResult, error) {
+	if requester.ID == status.AccountID {
+		// Status author themself can
+		// always react to their own status,
+		// no need for further checks.
+		return &gtsmodel.PolicyCheckResult{
+			Permission:         gtsmodel.PolicyPermissionPermitted,
+			PermittedMatchedOn: util.Ptr(gtsmodel.PolicyValueAuthor),
+		}, nil
+	}
+
+	switch {
+	// If status has policy set, check against that.
+	case status.InteractionPolicy != nil:
+		rules := status.InteractionPolicy.CanReact
+		if rules.IsZero() {
+			// Policy was set before react
+			// rules existed (or by a remote
+			// that doesn't know about them),
+			// so treat reactions like likes.
+			rules = status.InteractionPolicy.CanLike
+		}
+
+		return f.checkPolicy(
+			ctx,
+			requester,
+			status,
+			rules,
+		)
+
+	// If status is local and has no policy set,
+	// check against the default policy for this
+	// visibility, as we're interaction-policy aware.
+	case *status.Local:
+		policy := gtsmodel.DefaultInteractionPolicyFor(status.Visibility)
+		return f.checkPolicy(
+			ctx,
+			requester,
+			status,
+			policy.CanReact,
+		)
+
+	// Otherwise, assume the status is from an
+	// instance that does not use / does not care
+	// about interaction policies, and just return OK.
+	default:
+		return &gtsmodel.PolicyCheckResult{
+			Permission: gtsmodel.PolicyPermissionPermitted,
+		}, nil
+	}
+}
+
 func (f *Filter) checkPolicy(
 	ctx context.Context,
 	requester *gtsmodel.Account,
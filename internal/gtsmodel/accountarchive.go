@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// AccountArchive represents a downloadable archive of
+// all of an account's data (profile, statuses, likes,
+// bookmarks and media), built in the background on
+// request of the account, and removed after expiry.
+type AccountArchive struct {
+	ID        string              `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID string              `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the account this archive belongs to.
+	Account   *Account            `bun:"-"`                                                           // Account corresponding to accountID.
+	State     AccountArchiveState `bun:",notnull,default:0"`                                          // State of building this archive.
+	Path      string              `bun:",nullzero"`                                                   // Storage path of the archive zip file, once built.
+	Size      int64               `bun:",nullzero"`                                                   // Size of the archive zip file in bytes, once built.
+	ExpiresAt time.Time           `bun:"type:timestamptz,nullzero"`                                   // When this archive expires and is removed, once built (or failed).
+}
+
+// AccountArchiveState is the
+// state of an account archive.
+type AccountArchiveState uint8
+
+const (
+	AccountArchiveStateProcessing AccountArchiveState = iota // Archive is being built.
+	AccountArchiveStateReady                                 // Archive is built and ready for download.
+	AccountArchiveStateFailed                                // Building the archive failed.
+)
+
+func (s AccountArchiveState) String() string {
+	switch s {
+	case AccountArchiveStateProcessing:
+		return "processing"
+	case AccountArchiveStateReady:
+		return "ready"
+	case AccountArchiveStateFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
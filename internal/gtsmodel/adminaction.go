@@ -73,6 +73,8 @@ const (
 	AdminActionSuspend
 	AdminActionUnsuspend
 	AdminActionExpireKeys
+	AdminActionLimitContent
+	AdminActionUnlimitContent
 )
 
 func (t AdminActionType) String() string {
@@ -91,6 +93,10 @@ func (t AdminActionType) String() string {
 		return "unsuspend"
 	case AdminActionExpireKeys:
 		return "expire-keys"
+	case AdminActionLimitContent:
+		return "limit-content"
+	case AdminActionUnlimitContent:
+		return "unlimit-content"
 	default:
 		return "unknown"
 	}
@@ -112,6 +118,10 @@ func NewAdminActionType(in string) AdminActionType {
 		return AdminActionUnsuspend
 	case "expire-keys":
 		return AdminActionExpireKeys
+	case "limit-content":
+		return AdminActionLimitContent
+	case "unlimit-content":
+		return AdminActionUnlimitContent
 	default:
 		return AdminActionUnknown
 	}
@@ -0,0 +1,87 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Announcement models an instance-wide
+// announcement made by an admin, shown
+// to all local users while published.
+type Announcement struct {
+	ID          string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt   time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt   time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	PublishedAt time.Time `bun:"type:timestamptz,nullzero"`                                   // when was item first published, if at all
+	AccountID   string    `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the admin account that created the announcement
+	Text        string    `bun:",nullzero,notnull"`                                           // markdown-formatted text of the announcement, as submitted by the admin
+	Content     string    `bun:",nullzero,notnull"`                                           // html-formatted content of the announcement, parsed from Text
+	EmojiIDs    []string  `bun:"emojis,array"`                                                // database IDs of any emojis used in the announcement
+	Emojis      []*Emoji  `bun:"-"`                                                           // emojis corresponding to EmojiIDs
+	StartsAt    time.Time `bun:"type:timestamptz,nullzero"`                                   // optional time at which the announced event starts
+	EndsAt      time.Time `bun:"type:timestamptz,nullzero"`                                   // optional time at which the announced event ends, after which the announcement is hidden from users
+	AllDay      *bool     `bun:",nullzero,notnull,default:false"`                             // StartsAt and EndsAt should be treated as dates rather than times
+	Published   *bool     `bun:",nullzero,notnull,default:false"`                             // announcement is visible to users (else only to admins)
+}
+
+// IsPublished returns true if the announcement is
+// published, ie., it should be shown to users.
+func (a *Announcement) IsPublished() bool {
+	return a.Published != nil && *a.Published
+}
+
+// IsEnded returns true if the announcement
+// has an end time, and that time has passed.
+func (a *Announcement) IsEnded() bool {
+	return !a.EndsAt.IsZero() && a.EndsAt.Before(time.Now())
+}
+
+// EmojisPopulated returns whether emojis are
+// populated according to current EmojiIDs.
+func (a *Announcement) EmojisPopulated() bool {
+	if len(a.EmojiIDs) != len(a.Emojis) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range a.EmojiIDs {
+		if a.Emojis[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
+
+// AnnouncementRead marks an announcement
+// as read (dismissed) by the given account.
+type AnnouncementRead struct {
+	ID             string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                 // id of this item in the database
+	CreatedAt      time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                              // when was item created
+	AnnouncementID string    `bun:"type:CHAR(26),nullzero,notnull,unique:announcement_reads_announcement_id_account_id_uniq"` // id of the announcement read
+	AccountID      string    `bun:"type:CHAR(26),nullzero,notnull,unique:announcement_reads_announcement_id_account_id_uniq"` // id of the account that read it
+}
+
+// AnnouncementReaction is an emoji
+// reaction to an announcement by a user.
+type AnnouncementReaction struct {
+	ID             string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                          // id of this item in the database
+	CreatedAt      time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                       // when was item created
+	AnnouncementID string    `bun:"type:CHAR(26),nullzero,notnull,unique:announcement_reactions_announcement_id_account_id_name_uniq"` // id of the announcement reacted to
+	AccountID      string    `bun:"type:CHAR(26),nullzero,notnull,unique:announcement_reactions_announcement_id_account_id_name_uniq"` // id of the reacting account
+	Name           string    `bun:",nullzero,notnull,unique:announcement_reactions_announcement_id_account_id_name_uniq"`              // unicode emoji, or shortcode of custom emoji
+	EmojiID        string    `bun:"type:CHAR(26),nullzero"`                                                                            // id of the custom emoji, if not a unicode emoji
+	Emoji          *Emoji    `bun:"-"`                                                                                                 // custom emoji corresponding to EmojiID
+}
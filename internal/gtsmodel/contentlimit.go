@@ -0,0 +1,100 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import (
+	"strings"
+	"time"
+)
+
+// ContentLimit represents a limit placed by an admin on
+// the content of either a single account, or of all
+// accounts on a domain (and its subdomains). Exactly one
+// of AccountID or Domain will be set.
+type ContentLimit struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID          string    `bun:"type:CHAR(26),nullzero,unique"`                               // ID of the account this limit applies to, if account-level.
+	Account            *Account  `bun:"-"`                                                           // Account corresponding to AccountID.
+	Domain             string    `bun:",nullzero,unique"`                                            // Domain this limit applies to, if domain-level. Eg. 'whatever.com'
+	ForceSensitive     *bool     `bun:",nullzero,notnull,default:false"`                             // Mark all statuses as sensitive.
+	ContentWarning     string    `bun:",nullzero"`                                                   // Content warning to add to all statuses, if any.
+	RejectMedia        *bool     `bun:",nullzero,notnull,default:false"`                             // Don't fetch or serve media attached to statuses.
+	CreatedByAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this limit.
+	CreatedByAccount   *Account  `bun:"-"`                                                           // Account corresponding to CreatedByAccountID.
+	PrivateComment     string    `bun:""`                                                            // Private comment on this limit, viewable to admins.
+}
+
+// ContentLimits is a set of content limits that all
+// apply to one account, ordered from most to least
+// specific (ie., account-level limit first, followed
+// by domain-level limits from subdomain to parent).
+type ContentLimits []*ContentLimit
+
+// ForceSensitive returns true if
+// any of the limits force the
+// sensitive flag on statuses.
+func (ls ContentLimits) ForceSensitive() bool {
+	for _, l := range ls {
+		if *l.ForceSensitive {
+			return true
+		}
+	}
+	return false
+}
+
+// ContentWarning returns the content warning
+// of the most specific limit that sets one.
+func (ls ContentLimits) ContentWarning() string {
+	for _, l := range ls {
+		if l.ContentWarning != "" {
+			return l.ContentWarning
+		}
+	}
+	return ""
+}
+
+// RejectMedia returns true if any of
+// the limits reject status media.
+func (ls ContentLimits) RejectMedia() bool {
+	for _, l := range ls {
+		if *l.RejectMedia {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyContentWarning returns the given existing
+// status content warning with the content warning
+// of these limits prepended to it. Applying it
+// more than once will not prepend it again.
+func (ls ContentLimits) ApplyContentWarning(existing string) string {
+	cw := ls.ContentWarning()
+	switch {
+	case cw == "":
+		return existing
+	case existing == "":
+		return cw
+	case strings.HasPrefix(existing, cw):
+		return existing
+	default:
+		return cw + "; " + existing
+	}
+}
@@ -43,4 +43,6 @@ type Instance struct {
 	Reputation             int64        `bun:",notnull,default:0"`                                          // Reputation score of this instance
 	Version                string       `bun:",nullzero"`                                                   // Version of the software used on this instance
 	Rules                  []Rule       `bun:"-"`                                                           // List of instance rules
+	DeliveryFailingSince   time.Time    `bun:"type:timestamptz,nullzero"`                                   // Time of the first failed delivery to this instance since the last successful one, if any.
+	DeliveryUnavailableAt  time.Time    `bun:"type:timestamptz,nullzero"`                                   // When was this instance marked as unavailable for deliveries, if at all?
 }
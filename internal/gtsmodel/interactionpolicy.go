@@ -195,6 +195,14 @@ type InteractionPolicy struct {
 	// interaction will be accepted
 	// for an item with this policy.
 	CanAnnounce PolicyRules
+	// Conditions in which a Quote
+	// interaction will be accepted
+	// for an item with this policy.
+	CanQuote PolicyRules
+	// Conditions in which an emoji
+	// reaction will be accepted
+	// for an item with this policy.
+	CanReact PolicyRules
 }
 
 // PolicyRules represents the rules according
@@ -211,6 +219,13 @@ type PolicyRules struct {
 	WithApproval PolicyValues
 }
 
+// IsZero returns true if no PolicyValues at all
+// are set on these rules, ie., the rules were
+// never set, as opposed to being set to empty.
+func (pr PolicyRules) IsZero() bool {
+	return pr.Always == nil && pr.WithApproval == nil
+}
+
 // Returns the default interaction policy
 // for the given visibility level.
 func DefaultInteractionPolicyFor(v Visibility) *InteractionPolicy {
@@ -250,6 +265,20 @@ var defaultPolicyPublic = &InteractionPolicy{
 		},
 		WithApproval: make(PolicyValues, 0),
 	},
+	CanQuote: PolicyRules{
+		// Anyone can quote.
+		Always: PolicyValues{
+			PolicyValuePublic,
+		},
+		WithApproval: make(PolicyValues, 0),
+	},
+	CanReact: PolicyRules{
+		// Anyone can react.
+		Always: PolicyValues{
+			PolicyValuePublic,
+		},
+		WithApproval: make(PolicyValues, 0),
+	},
 }
 
 // Returns the default interaction policy
@@ -293,6 +322,23 @@ var defaultPolicyFollowersOnly = &InteractionPolicy{
 		},
 		WithApproval: make(PolicyValues, 0),
 	},
+	CanQuote: PolicyRules{
+		// Only self can quote.
+		Always: PolicyValues{
+			PolicyValueAuthor,
+		},
+		WithApproval: make(PolicyValues, 0),
+	},
+	CanReact: PolicyRules{
+		// Self, followers and
+		// mentioned can react.
+		Always: PolicyValues{
+			PolicyValueAuthor,
+			PolicyValueFollowers,
+			PolicyValueMentioned,
+		},
+		WithApproval: make(PolicyValues, 0),
+	},
 }
 
 // Returns the default interaction policy for
@@ -327,6 +373,22 @@ var defaultPolicyDirect = &InteractionPolicy{
 		},
 		WithApproval: make(PolicyValues, 0),
 	},
+	CanQuote: PolicyRules{
+		// Only self can quote.
+		Always: PolicyValues{
+			PolicyValueAuthor,
+		},
+		WithApproval: make(PolicyValues, 0),
+	},
+	CanReact: PolicyRules{
+		// Mentioned and self
+		// can always react.
+		Always: PolicyValues{
+			PolicyValueAuthor,
+			PolicyValueMentioned,
+		},
+		WithApproval: make(PolicyValues, 0),
+	},
 }
 
 // Returns the default interaction policy
@@ -24,24 +24,25 @@ import (
 // MediaAttachment represents a user-uploaded media attachment: an image/video/audio/gif that is
 // somewhere in storage and that can be retrieved and served by the router.
 type MediaAttachment struct {
-	ID                string           `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
-	CreatedAt         time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
-	UpdatedAt         time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
-	StatusID          string           `bun:"type:CHAR(26),nullzero"`                                      // ID of the status to which this is attached
-	URL               string           `bun:",nullzero"`                                                   // Where can the attachment be retrieved on *this* server
-	RemoteURL         string           `bun:",nullzero"`                                                   // Where can the attachment be retrieved on a remote server (empty for local media)
-	Type              FileType         `bun:",notnull,default:0"`                                          // Type of file (image/gifv/audio/video/unknown)
-	FileMeta          FileMeta         `bun:",embed:,notnull"`                                             // Metadata about the file
-	AccountID         string           `bun:"type:CHAR(26),nullzero,notnull"`                              // To which account does this attachment belong
-	Description       string           `bun:""`                                                            // Description of the attachment (for screenreaders)
-	ScheduledStatusID string           `bun:"type:CHAR(26),nullzero"`                                      // To which scheduled status does this attachment belong
-	Blurhash          string           `bun:",nullzero"`                                                   // What is the generated blurhash of this attachment
-	Processing        ProcessingStatus `bun:",notnull,default:2"`                                          // What is the processing status of this attachment
-	File              File             `bun:",embed:file_,notnull,nullzero"`                               // metadata for the whole file
-	Thumbnail         Thumbnail        `bun:",embed:thumbnail_,notnull,nullzero"`                          // small image thumbnail derived from a larger image, video, or audio file.
-	Avatar            *bool            `bun:",nullzero,notnull,default:false"`                             // Is this attachment being used as an avatar?
-	Header            *bool            `bun:",nullzero,notnull,default:false"`                             // Is this attachment being used as a header?
-	Cached            *bool            `bun:",nullzero,notnull,default:false"`                             // Is this attachment currently cached by our instance?
+	ID                string              `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt         time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt         time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	StatusID          string              `bun:"type:CHAR(26),nullzero"`                                      // ID of the status to which this is attached
+	URL               string              `bun:",nullzero"`                                                   // Where can the attachment be retrieved on *this* server
+	RemoteURL         string              `bun:",nullzero"`                                                   // Where can the attachment be retrieved on a remote server (empty for local media)
+	Type              FileType            `bun:",notnull,default:0"`                                          // Type of file (image/gifv/audio/video/unknown)
+	FileMeta          FileMeta            `bun:",embed:,notnull"`                                             // Metadata about the file
+	AccountID         string              `bun:"type:CHAR(26),nullzero,notnull"`                              // To which account does this attachment belong
+	Description       string              `bun:""`                                                            // Description of the attachment (for screenreaders)
+	ScheduledStatusID string              `bun:"type:CHAR(26),nullzero"`                                      // To which scheduled status does this attachment belong
+	Blurhash          string              `bun:",nullzero"`                                                   // What is the generated blurhash of this attachment
+	Processing        ProcessingStatus    `bun:",notnull,default:2"`                                          // What is the processing status of this attachment
+	File              File                `bun:",embed:file_,notnull,nullzero"`                               // metadata for the whole file
+	Thumbnail         Thumbnail           `bun:",embed:thumbnail_,notnull,nullzero"`                          // small image thumbnail derived from a larger image, video, or audio file.
+	ThumbnailVariants []*ThumbnailVariant `bun:""`                                                            // additional differently-sized thumbnails derived from the file, smallest first.
+	Avatar            *bool               `bun:",nullzero,notnull,default:false"`                             // Is this attachment being used as an avatar?
+	Header            *bool               `bun:",nullzero,notnull,default:false"`                             // Is this attachment being used as a header?
+	Cached            *bool               `bun:",nullzero,notnull,default:false"`                             // Is this attachment currently cached by our instance?
 }
 
 // IsLocal returns whether media attachment is local.
@@ -56,9 +57,10 @@ func (m *MediaAttachment) IsRemote() bool {
 
 // File refers to the metadata for the whole file
 type File struct {
-	Path        string `bun:",notnull"` // Path of the file in storage.
-	ContentType string `bun:",notnull"` // MIME content type of the file.
-	FileSize    int    `bun:",notnull"` // File size in bytes
+	Path        string `bun:",notnull"`  // Path of the file in storage.
+	ContentType string `bun:",notnull"`  // MIME content type of the file.
+	FileSize    int    `bun:",notnull"`  // File size in bytes
+	Hash        string `bun:",nullzero"` // Hex-encoded SHA-256 hash of the file contents, used to share identical files in storage.
 }
 
 // Thumbnail refers to a small image thumbnail derived from a larger image, video, or audio file.
@@ -70,6 +72,18 @@ type Thumbnail struct {
 	RemoteURL   string `bun:",nullzero"` // What is the remote URL of the thumbnail (empty for local media)
 }
 
+// ThumbnailVariant refers to an additional, differently-sized image thumbnail
+// derived from a larger image or video file, for use in responsive display.
+// Very wide or very tall media is cropped around its focus for thumbnail variants.
+type ThumbnailVariant struct {
+	Width       int    // Width in pixels.
+	Height      int    // Height in pixels.
+	Path        string // Path of the file in storage.
+	ContentType string // MIME content type of the file.
+	FileSize    int    // File size in bytes.
+	URL         string // What is the URL of the thumbnail on the local server.
+}
+
 // ProcessingStatus refers to how far along in the processing stage the attachment is.
 type ProcessingStatus int
 
@@ -59,6 +59,13 @@ type Mention struct {
 	//
 	// This will not be put in the database, it's just for convenience.
 	TargetAccountURL string `bun:"-"`
+
+	// IsNew indicates that this mention has only just
+	// been parsed and is not yet stored in the database,
+	// as opposed to being an existing mention of a status.
+	//
+	// This will not be put in the database, it's just for convenience.
+	IsNew bool `bun:"-"`
 }
 
 // ParseMentionFunc describes a function that takes a lowercase account namestring
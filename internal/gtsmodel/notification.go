@@ -50,4 +50,6 @@ const (
 	NotificationPendingFave   NotificationType = "pending.favourite" // Someone has faved a status of yours, which requires approval by you.
 	NotificationPendingReply  NotificationType = "pending.reply"     // Someone has replied to a status of yours, which requires approval by you.
 	NotificationPendingReblog NotificationType = "pending.reblog"    // Someone has boosted a status of yours, which requires approval by you.
+	NotificationPendingQuote  NotificationType = "pending.quote"     // Someone has quoted a status of yours, which requires approval by you.
+	NotificationReaction      NotificationType = "reaction"          // NotificationReaction -- someone emoji reacted to one of your statuses
 )
@@ -0,0 +1,70 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Relay represents a subscription to a fediverse relay,
+// which is followed by the instance account in order to
+// receive public posts from other instances subscribed to
+// the same relay, and (optionally) to forward public posts
+// from this instance to them.
+type Relay struct {
+	ID                 string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	InboxURI           string     `bun:",nullzero,notnull,unique"`                                    // URI of the inbox to which we deliver our Follow and forwarded posts.
+	ActorURI           string     `bun:",nullzero"`                                                   // URI of the relay actor. Only set for LitePub-style relays, which are followed directly.
+	Domain             string     `bun:",nullzero,notnull"`                                           // Domain of the relay, from which relayed activities are accepted.
+	FollowURI          string     `bun:",nullzero,notnull,unique"`                                    // URI of the Follow sent by the instance account to the relay.
+	State              RelayState `bun:",notnull,default:0"`                                          // State of the Follow sent to the relay.
+	AcceptedByURI      string     `bun:",nullzero"`                                                   // URI of the relay actor which Accepted our Follow. Relayed activities are only accepted from this actor.
+	Forward            *bool      `bun:",nullzero,notnull,default:true"`                              // Forward public posts created on this instance to the relay.
+	CreatedByAccountID string     `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the admin who added this relay.
+}
+
+// IsLitePub returns whether this is a LitePub-style relay,
+// ie., one whose actor is followed directly, as opposed to a
+// Mastodon-style relay, which is followed by sending a Follow
+// of the Public collection to its inbox.
+func (r *Relay) IsLitePub() bool {
+	return r.ActorURI != ""
+}
+
+// RelayState is the state of
+// the Follow sent to a relay.
+type RelayState uint8
+
+const (
+	RelayStatePending  RelayState = iota // Follow sent, awaiting Accept or Reject.
+	RelayStateAccepted                   // Follow accepted by the relay.
+	RelayStateRejected                   // Follow rejected by the relay.
+)
+
+func (s RelayState) String() string {
+	switch s {
+	case RelayStatePending:
+		return "pending"
+	case RelayStateAccepted:
+		return "accepted"
+	case RelayStateRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,73 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// ScheduledStatus represents a status that a local account
+// has requested to be posted at some point in the future.
+//
+// Once the scheduled time is reached the status is created
+// (and federated) as though it was posted at that time, and
+// the scheduled status is removed from the database.
+type ScheduledStatus struct {
+	ID                string              `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt         time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt         time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID         string              `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the account that scheduled this status.
+	Account           *Account            `bun:"-"`                                                           // Account corresponding to AccountID.
+	ScheduledAt       time.Time           `bun:"type:timestamptz,nullzero,notnull"`                           // Time at which the status should be posted.
+	Text              string              `bun:""`                                                            // Text content of the status, as submitted.
+	Poll              ScheduledStatusPoll `bun:"embed:poll_"`                                                 // Poll to attach to the status, if any.
+	MediaIDs          []string            `bun:"attachments,array"`                                           // Database IDs of media attachments to attach.
+	MediaAttachments  []*MediaAttachment  `bun:"-"`                                                           // Attachments corresponding to MediaIDs.
+	Sensitive         *bool               `bun:",nullzero,notnull,default:false"`                             // Status and attached media should be marked as sensitive.
+	SpoilerText       string              `bun:""`                                                            // Content warning text of the status, as submitted.
+	Visibility        Visibility          `bun:",nullzero,notnull"`                                           // Visibility of the status.
+	LocalOnly         *bool               `bun:",nullzero,notnull,default:false"`                             // Status should not be federated.
+	InReplyToID       string              `bun:"type:CHAR(26),nullzero"`                                      // ID of the status being replied to, if any.
+	QuotedStatusID    string              `bun:"type:CHAR(26),nullzero"`                                      // ID of the status being quoted, if any.
+	Language          string              `bun:",nullzero"`                                                   // Language of the status, as submitted.
+	ContentType       string              `bun:",nullzero"`                                                   // Content type to use when parsing status text.
+	InteractionPolicy *InteractionPolicy  `bun:""`                                                            // Interaction policy to set on the status, if any.
+	ApplicationID     string              `bun:"type:CHAR(26),nullzero"`                                      // ID of the application used to schedule the status.
+	Application       *Application        `bun:"-"`                                                           // Application corresponding to ApplicationID.
+}
+
+// ScheduledStatusPoll represents the
+// poll options of a scheduled status.
+type ScheduledStatusPoll struct {
+	Options    []string `bun:",array"`    // Options of the poll, as submitted. Empty for no poll.
+	ExpiresIn  int      `bun:",nullzero"` // Duration in seconds that the poll should be open for, from when it's posted.
+	Multiple   *bool    `bun:",nullzero"` // Poll allows multiple choices.
+	HideTotals *bool    `bun:",nullzero"` // Hide vote counts until the poll ends.
+}
+
+// MediaAttachmentsPopulated returns whether media attachments are populated according to current MediaIDs.
+func (s *ScheduledStatus) MediaAttachmentsPopulated() bool {
+	if len(s.MediaIDs) != len(s.MediaAttachments) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range s.MediaIDs {
+		if s.MediaAttachments[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
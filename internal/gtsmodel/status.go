@@ -29,6 +29,7 @@ type Status struct {
 	UpdatedAt                time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
 	FetchedAt                time.Time          `bun:"type:timestamptz,nullzero"`                                   // when was item (remote) last fetched.
 	PinnedAt                 time.Time          `bun:"type:timestamptz,nullzero"`                                   // Status was pinned by owning account at this time.
+	EditedAt                 time.Time          `bun:"type:timestamptz,nullzero"`                                   // Status was last edited at this time; zero if never edited.
 	URI                      string             `bun:",unique,nullzero,notnull"`                                    // activitypub URI of this status
 	URL                      string             `bun:",nullzero"`                                                   // web url for viewing this status
 	Content                  string             `bun:""`                                                            // content of this status; likely html-formatted but not guaranteed
@@ -40,6 +41,8 @@ type Status struct {
 	Mentions                 []*Mention         `bun:"attached_mentions,rel:has-many"`                              // Mentions corresponding to mentionIDs
 	EmojiIDs                 []string           `bun:"emojis,array"`                                                // Database IDs of any emojis used in this status
 	Emojis                   []*Emoji           `bun:"attached_emojis,m2m:status_to_emojis"`                        // Emojis corresponding to emojiIDs. https://bun.uptrace.dev/guide/relations.html#many-to-many-relation
+	EditIDs                  []string           `bun:"edits,array"`                                                 // Database IDs of historical revisions of this status, oldest first
+	Edits                    []*StatusEdit      `bun:"-"`                                                           // Edits corresponding to editIDs
 	Local                    *bool              `bun:",nullzero,notnull,default:false"`                             // is this status from a local account?
 	AccountID                string             `bun:"type:CHAR(26),nullzero,notnull"`                              // which account posted this status?
 	Account                  *Account           `bun:"rel:belongs-to"`                                              // account corresponding to accountID
@@ -54,6 +57,11 @@ type Status struct {
 	BoostOfAccountID         string             `bun:"type:CHAR(26),nullzero"`                                      // id of the account that owns the boosted status
 	BoostOf                  *Status            `bun:"-"`                                                           // status that corresponds to boostOfID
 	BoostOfAccount           *Account           `bun:"rel:belongs-to"`                                              // account that corresponds to boostOfAccountID
+	QuoteID                  string             `bun:"type:CHAR(26),nullzero"`                                      // id of the status this status quotes
+	QuoteURI                 string             `bun:",nullzero"`                                                   // activitypub uri of the status this status quotes
+	QuoteAccountID           string             `bun:"type:CHAR(26),nullzero"`                                      // id of the account that owns the quoted status
+	Quote                    *Status            `bun:"-"`                                                           // status corresponding to quoteID
+	QuoteAccount             *Account           `bun:"rel:belongs-to"`                                              // account corresponding to quoteAccountID
 	ThreadID                 string             `bun:"type:CHAR(26),nullzero"`                                      // id of the thread to which this status belongs; only set for remote statuses if a local account is involved at some point in the thread, otherwise null
 	PollID                   string             `bun:"type:CHAR(26),nullzero"`                                      //
 	Poll                     *Poll              `bun:"-"`                                                           //
@@ -67,8 +75,9 @@ type Status struct {
 	Text                     string             `bun:""`                                                            // Original text of the status without formatting
 	Federated                *bool              `bun:",notnull"`                                                    // This status will be federated beyond the local timeline(s)
 	InteractionPolicy        *InteractionPolicy `bun:""`                                                            // InteractionPolicy for this status. If null then the default InteractionPolicy should be assumed for this status's Visibility. Always null for boost wrappers.
-	PendingApproval          *bool              `bun:",nullzero,notnull,default:false"`                             // If true then status is a reply or boost wrapper that must be Approved by the reply-ee or boost-ee before being fully distributed.
+	PendingApproval          *bool              `bun:",nullzero,notnull,default:false"`                             // If true then status is a reply, quote, or boost wrapper that must be Approved by the reply-ee, quote-ee, or boost-ee before being fully distributed.
 	PreApproved              bool               `bun:"-"`                                                           // If true, then status is a reply to or boost wrapper of a status on our instance, has permission to do the interaction, and an Accept should be sent out for it immediately. Field not stored in the DB.
+	QuotePendingApproval     bool               `bun:"-"`                                                           // If true, then PendingApproval is set because this status quotes a status that requires approval to be quoted, rather than because of a reply. Field not stored in the DB.
 	ApprovedByURI            string             `bun:",nullzero"`                                                   // URI of an Accept Activity that approves the Announce or Create Activity that this status was/will be attached to.
 }
 
@@ -148,6 +157,20 @@ func (s *Status) EmojisPopulated() bool {
 	return true
 }
 
+// EditsPopulated returns whether edits are populated according to current EditIDs.
+func (s *Status) EditsPopulated() bool {
+	if len(s.EditIDs) != len(s.Edits) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range s.EditIDs {
+		if s.Edits[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
+
 // EmojissUpToDate returns whether status emoji attachments of receiving status are up-to-date
 // according to emoji attachments of the passed status, by comparing their emoji URIs. We don't
 // use IDs as this is used to determine whether there are new emojis to fetch.
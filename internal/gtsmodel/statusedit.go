@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package gtsmodel
+
+import "time"
+
+// StatusEdit represents a **historical** revision of a Status,
+// as it was before an edit was made to it. The Status itself
+// will always contain the latest up-to-date version.
+//
+// Note that stored revisions of remote statuses may not match
+// exactly those on the origin server, they're just a best-effort
+// record of each version of the status that we've seen.
+type StatusEdit struct {
+	ID             string             `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // ID of this item in the database.
+	CreatedAt      time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Time at which this revision was created (ie., when status was created / last edited).
+	StatusID       string             `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the status that this is a revision of.
+	Content        string             `bun:""`                                                            // Content of the status at this revision; likely html-formatted but not guaranteed.
+	ContentWarning string             `bun:",nullzero"`                                                   // CW string of the status at this revision.
+	Text           string             `bun:""`                                                            // Original text of the status at this revision, without formatting.
+	Language       string             `bun:",nullzero"`                                                   // Language of the status at this revision.
+	Sensitive      *bool              `bun:",nullzero,notnull,default:false"`                             // Status was marked sensitive at this revision.
+	AttachmentIDs  []string           `bun:"attachments,array"`                                           // Database IDs of media attachments attached at this revision.
+	Attachments    []*MediaAttachment `bun:"-"`                                                           // Attachments corresponding to AttachmentIDs.
+	PollOptions    []string           `bun:",array"`                                                      // Poll options of the status poll at this revision, if any.
+	PollVotes      []int              `bun:",array"`                                                      // Poll vote counts of the status poll at this revision, if any.
+}
+
+// AttachmentsPopulated returns whether media attachments are populated according to current AttachmentIDs.
+func (e *StatusEdit) AttachmentsPopulated() bool {
+	if len(e.AttachmentIDs) != len(e.Attachments) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range e.AttachmentIDs {
+		if e.Attachments[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,41 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// FeaturedSuggestion represents a local account which
+// has been featured by an admin, to be suggested to
+// other accounts as one worth following.
+type FeaturedSuggestion struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	AccountID          string    `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // ID of the featured account.
+	Account            *Account  `bun:"-"`                                                           // Featured account corresponding to AccountID.
+	CreatedByAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the admin who featured this account.
+}
+
+// SuggestionDismissal represents an account
+// which has been dismissed from the follow
+// suggestions shown to another account.
+type SuggestionDismissal struct {
+	ID              string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                      // id of this item in the database
+	CreatedAt       time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                   // when was item created
+	AccountID       string    `bun:"type:CHAR(26),unique:suggestion_dismissals_account_id_target_account_id_uniq,nullzero,notnull"` // ID of the account that dismissed the suggestion.
+	TargetAccountID string    `bun:"type:CHAR(26),unique:suggestion_dismissals_account_id_target_account_id_uniq,nullzero,notnull"` // ID of the dismissed account.
+}
@@ -0,0 +1,88 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Trend represents the admin review state of a tag,
+// status or link that has been found to be trending on
+// this instance. Only approved trends are shown to users.
+type Trend struct {
+	ID                  string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt           time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt           time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Type                TrendType  `bun:",notnull,unique:trends_type_target_id_uniq"`                  // Type of the trending item.
+	TargetID            string     `bun:",nullzero,notnull,unique:trends_type_target_id_uniq"`         // ID of the trending tag or status, or URL of the trending link.
+	State               TrendState `bun:",notnull,default:0"`                                          // Review state of this trend.
+	ReviewedAt          time.Time  `bun:"type:timestamptz,nullzero"`                                   // When was this trend last approved or rejected.
+	ReviewedByAccountID string     `bun:"type:CHAR(26),nullzero"`                                      // Account ID of the admin who last approved or rejected this trend.
+}
+
+// TrendType is the type of a trending item.
+type TrendType uint8
+
+const (
+	TrendTypeTag    TrendType = 1 // Trending tag, target ID is the tag ID.
+	TrendTypeStatus TrendType = 2 // Trending status, target ID is the status ID.
+	TrendTypeLink   TrendType = 3 // Trending link, target ID is the link URL.
+)
+
+func (t TrendType) String() string {
+	switch t {
+	case TrendTypeTag:
+		return "tag"
+	case TrendTypeStatus:
+		return "status"
+	case TrendTypeLink:
+		return "link"
+	default:
+		return "unknown"
+	}
+}
+
+// TrendState is the admin
+// review state of a trend.
+type TrendState uint8
+
+const (
+	TrendStatePending  TrendState = iota // Not yet reviewed, not shown to users.
+	TrendStateApproved                   // Approved, shown to users.
+	TrendStateRejected                   // Rejected, not shown to users.
+)
+
+func (s TrendState) String() string {
+	switch s {
+	case TrendStatePending:
+		return "pending"
+	case TrendStateApproved:
+		return "approved"
+	case TrendStateRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
+// TrendUsage is the usage of a tag, status or link within
+// some period of time, as computed from the database when
+// calculating trends. It is not itself stored in the database.
+type TrendUsage struct {
+	TargetID string `bun:"target_id"` // ID of the tag or status, or URL of the link.
+	Uses     int    `bun:"uses"`      // Number of uses in the period (for statuses, faves + boosts).
+	Accounts int    `bun:"accounts"`  // Number of distinct accounts responsible for those uses.
+}
@@ -56,6 +56,16 @@ type User struct {
 	ResetPasswordToken     string       `bun:",nullzero"`                                                   // The generated token that the user can use to reset their password
 	ResetPasswordSentAt    time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did we email the user their reset-password email?
 	ExternalID             string       `bun:",nullzero,unique"`                                            // If the login for the user is managed externally (e.g OIDC), we need to keep a stable reference to the external object (e.g OIDC sub claim)
+	TwoFactorSecret        string       `bun:",nullzero"`                                                   // Base32-encoded TOTP secret of this user; may be set before 2FA is enabled, while enrolment is pending.
+	TwoFactorBackups       []string     `bun:"two_factor_backups,array"`                                    // bcrypt hashes of unused 2FA recovery codes.
+	TwoFactorEnabledAt     time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did the user enable 2FA? Zero if not enabled.
+	TwoFactorLastStep      int64        `bun:",nullzero"`                                                   // TOTP time step of the last accepted 2FA code, so that codes can't be replayed.
+}
+
+// TwoFactorEnabled returns true if the user
+// has enabled two factor authentication.
+func (u *User) TwoFactorEnabled() bool {
+	return !u.TwoFactorEnabledAt.IsZero()
 }
 
 // DeniedUser represents one user sign-up that
@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// WebPushSubscription represents an access token's Web Push
+// subscription. There can be at most one per access token.
+type WebPushSubscription struct {
+	ID                string                               `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt         time.Time                            `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt         time.Time                            `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID         string                               `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the account that owns this subscription.
+	TokenID           string                               `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // ID of the access token this subscription belongs to.
+	Endpoint          string                               `bun:",nullzero,notnull"`                                           // URL of the Web Push endpoint to deliver notifications to.
+	Auth              string                               `bun:",nullzero,notnull"`                                           // Base64-encoded auth secret provided by the client.
+	P256dh            string                               `bun:",nullzero,notnull"`                                           // Base64-encoded P-256 ECDH public key provided by the client.
+	NotificationFlags WebPushSubscriptionNotificationFlags `bun:",notnull"`                                                    // Which notification types should be pushed.
+	Policy            WebPushNotificationPolicy            `bun:",nullzero,notnull,default:'all'"`                             // Which accounts' notifications should be pushed.
+}
+
+// WebPushSubscriptionNotificationFlags is a
+// bitfield of notification types to be pushed.
+type WebPushSubscriptionNotificationFlags int64
+
+// webPushNotificationFlags maps each notification
+// type that may be pushed to its bit in the flags.
+var webPushNotificationFlags = map[NotificationType]WebPushSubscriptionNotificationFlags{
+	NotificationFollow:        1 << 0,
+	NotificationFollowRequest: 1 << 1,
+	NotificationMention:       1 << 2,
+	NotificationReblog:        1 << 3,
+	NotificationFave:          1 << 4,
+	NotificationPoll:          1 << 5,
+	NotificationStatus:        1 << 6,
+	NotificationSignup:        1 << 7,
+	NotificationPendingFave:   1 << 8,
+	NotificationPendingReply:  1 << 9,
+	NotificationPendingReblog: 1 << 10,
+	NotificationPendingQuote:  1 << 11,
+	NotificationReaction:      1 << 12,
+}
+
+// Get returns whether notifications of the given type should be pushed.
+func (n WebPushSubscriptionNotificationFlags) Get(notificationType NotificationType) bool {
+	flag, ok := webPushNotificationFlags[notificationType]
+	return ok && n&flag != 0
+}
+
+// Set sets whether notifications of the given type should be pushed.
+func (n *WebPushSubscriptionNotificationFlags) Set(notificationType NotificationType, value bool) {
+	flag, ok := webPushNotificationFlags[notificationType]
+	if !ok {
+		return
+	}
+
+	if value {
+		*n |= flag
+	} else {
+		*n &^= flag
+	}
+}
+
+// WebPushNotificationPolicy describes which
+// accounts' notifications should be pushed.
+type WebPushNotificationPolicy string
+
+const (
+	WebPushNotificationPolicyAll      WebPushNotificationPolicy = "all"      // Push notifications from anyone.
+	WebPushNotificationPolicyFollowed WebPushNotificationPolicy = "followed" // Push notifications from accounts the user follows.
+	WebPushNotificationPolicyFollower WebPushNotificationPolicy = "follower" // Push notifications from accounts following the user.
+	WebPushNotificationPolicyNone     WebPushNotificationPolicy = "none"     // Don't push any notifications.
+)
+
+// VAPIDKeyPair represents the instance's key pair used
+// to identify itself to Web Push servers (RFC 8292).
+// There should only ever be one of these in the database.
+type VAPIDKeyPair struct {
+	ID      int    `bun:",pk,notnull"`       // Always 1, as there's only one key pair.
+	Public  string `bun:",nullzero,notnull"` // Base64url-encoded uncompressed P-256 public key.
+	Private string `bun:",nullzero,notnull"` // Base64-encoded PKCS #8 P-256 private key.
+}
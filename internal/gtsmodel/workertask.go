@@ -25,6 +25,13 @@ const (
 	DeliveryWorker  WorkerType = 1
 	FederatorWorker WorkerType = 2
 	ClientWorker    WorkerType = 3
+
+	// ImportWorker tasks are account data imports.
+	// Unlike the other worker types, these are
+	// persisted for the whole lifetime of an import
+	// (not just on shutdown), so that an interrupted
+	// import can resume from its last progress.
+	ImportWorker WorkerType = 4
 )
 
 // WorkerTask represents a queued worker task
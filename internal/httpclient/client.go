@@ -329,8 +329,10 @@ func (c *Client) do(r *Request) (rsp *http.Response, retry bool, err error) {
 
 		// Codes over 500 (and 429: too many requests)
 		// are generally temporary errors. For these
-		// we replace the response with a loggable error.
+		// we replace the response with a loggable error,
+		// keeping the status code for callers to inspect.
 		err = fmt.Errorf(`http response: %s`, rsp.Status)
+		err = gtserror.WithStatusCode(err, rsp.StatusCode)
 
 		// Search for a provided "Retry-After" header value.
 		if after := rsp.Header.Get("Retry-After"); after != "" {
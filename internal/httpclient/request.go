@@ -75,6 +75,12 @@ func (r *Request) BackOff() time.Duration {
 	return r.backoff
 }
 
+// Attempts returns the number of delivery
+// attempts made so far for this request.
+func (r *Request) Attempts() uint {
+	return r.attempts
+}
+
 type uintPtr struct{ u *uint }
 
 func (f uintPtr) String() string {
@@ -54,6 +54,18 @@ func NewULIDFromTime(t time.Time) (string, error) {
 	return newUlid.String(), nil
 }
 
+// MinULIDFromTime returns the lowest possible ULID string for the given
+// time, ie., with zero entropy, or an error if something goes wrong. This
+// sorts before every ULID generated at or after t, so is suitable for use
+// as an inclusive lower bound when selecting IDs by time.
+func MinULIDFromTime(t time.Time) (string, error) {
+	minUlid, err := ulid.New(ulid.Timestamp(t), nil)
+	if err != nil {
+		return "", err
+	}
+	return minUlid.String(), nil
+}
+
 // NewRandomULID returns a new ULID string using a random time in an ~80 year range around the current datetime, or an error if something goes wrong.
 func NewRandomULID() (string, error) {
 	b1, err := rand.Int(rand.Reader, big.NewInt(randomRange))
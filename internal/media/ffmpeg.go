@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"image"
 	"os"
 	"path"
 	"strconv"
@@ -63,8 +64,210 @@ func ffmpegClearMetadata(ctx context.Context, outpath, inpath string) error {
 	)
 }
 
+// ffmpegTranscodeImage transcodes the first frame of input image to a jpeg or webp
+// image, applying any orientation so the output is upright, and dropping all metadata.
+// The output format is given explicitly, as outpath is expected to have no extension.
+func ffmpegTranscodeImage(ctx context.Context, inpath, outpath, format string, orientation int) error {
+	args := []string{
+		// Only log errors.
+		"-loglevel", "error",
+
+		// Don't apply any rotation
+		// automatically, we apply
+		// orientation ourselves.
+		"-noautorotate",
+
+		// Input file.
+		"-i", inpath,
+
+		// Drop all metadata,
+		// e.g. EXIF and GPS.
+		"-map_metadata", "-1",
+
+		// Only one frame.
+		"-frames:v", "1",
+	}
+
+	// Rotate / flip according to orientation.
+	// (transpose filter: https://ffmpeg.org/ffmpeg-filters.html#transpose-1)
+	var filter string
+	switch orientation {
+	case orientationFlipH:
+		filter = "hflip"
+	case orientationFlipV:
+		filter = "vflip"
+	case orientationRotate90:
+		filter = "transpose=cclock"
+	case orientationRotate180:
+		filter = "hflip,vflip"
+	case orientationRotate270:
+		filter = "transpose=clock"
+	case orientationTranspose:
+		filter = "transpose=cclock_flip"
+	case orientationTransverse:
+		filter = "transpose=clock_flip"
+	}
+	if filter != "" {
+		args = append(args, "-filter:v", filter)
+	}
+
+	switch format {
+	case "jpeg":
+		args = append(args,
+			// Encode as (full range) jpeg,
+			// with high quality (2-31).
+			"-codec:v", "mjpeg",
+			"-pix_fmt", "yuvj420p",
+			"-qscale:v", "2",
+
+			// Write single image.
+			"-f", "image2",
+			"-update", "1",
+		)
+
+	case "webp":
+		args = append(args,
+			// Encode using libwebp
+			// (NOT as libwebp_anim),
+			// keeping transparency.
+			"-codec:v", "libwebp",
+			"-quality", "90",
+			"-f", "webp",
+		)
+
+	default:
+		return gtserror.Newf("unsupported image format: %s", format)
+	}
+
+	args = append(args,
+		// Overwrite.
+		"-y",
+
+		// Output.
+		outpath,
+	)
+
+	return ffmpeg(ctx, inpath, outpath, args...)
+}
+
+// ffmpegTranscodeVideo transcodes input video to H.264 / AAC in an
+// mp4 container, which has the widest support among web browsers,
+// dropping all metadata. Note that ffmpeg automatically applies any
+// rotation, so the output is upright. The output format is given
+// explicitly, as outpath is expected to have no extension.
+func ffmpegTranscodeVideo(ctx context.Context, inpath, outpath string) error {
+	return ffmpeg(ctx, inpath, outpath,
+
+		// Only log errors.
+		"-loglevel", "error",
+
+		// Input file.
+		"-i", inpath,
+
+		// Drop all metadata,
+		// e.g. GPS location.
+		"-map_metadata", "-1",
+
+		// Use first video stream,
+		// and first audio (if any).
+		"-map", "0:v:0",
+		"-map", "0:a:0?",
+
+		// Encode video using libx264, with
+		// a fast preset given we're running
+		// as WebAssembly, and the default
+		// quality factor (crf 23).
+		// (libx264 codec: https://ffmpeg.org/ffmpeg-codecs.html#libx264_002c-libx264rgb)
+		"-codec:v", "libx264",
+		"-preset", "veryfast",
+		"-crf", "23",
+
+		// Most widely supported pixel format,
+		// which requires even dimensions.
+		"-pix_fmt", "yuv420p",
+		"-filter:v", "scale=trunc(iw/2)*2:trunc(ih/2)*2",
+
+		// Encode audio using native aac.
+		"-codec:a", "aac",
+		"-b:a", "128k",
+
+		// NOTE: we can't use "-movflags +faststart"
+		// here as that requires re-opening output,
+		// which our mounted filesystem truncates.
+		"-f", "mp4",
+
+		// Overwrite.
+		"-y",
+
+		// Output.
+		outpath,
+	)
+}
+
+// ffmpegTranscodeAudio transcodes first audio stream of input to either mp3
+// or opus, keeping tags but dropping any other streams (e.g. album art). The
+// output format is given explicitly, as outpath is expected to have no extension.
+func ffmpegTranscodeAudio(ctx context.Context, inpath, outpath, format string) error {
+	args := []string{
+		// Only log errors.
+		"-loglevel", "error",
+
+		// Input file.
+		"-i", inpath,
+
+		// Drop all metadata.
+		"-map_metadata", "-1",
+
+		// Only first audio stream.
+		"-map", "0:a:0",
+	}
+
+	switch format {
+	case "mp3":
+		args = append(args,
+			// Encode using libmp3lame,
+			// at high quality VBR (0-9).
+			"-codec:a", "libmp3lame",
+			"-qscale:a", "2",
+			"-f", "mp3",
+		)
+
+	case "opus":
+		args = append(args,
+			// Encode using libopus.
+			"-codec:a", "libopus",
+			"-b:a", "128k",
+			"-f", "opus",
+		)
+
+	default:
+		return gtserror.Newf("unsupported audio format: %s", format)
+	}
+
+	args = append(args,
+		// Overwrite.
+		"-y",
+
+		// Output.
+		outpath,
+	)
+
+	return ffmpeg(ctx, inpath, outpath, args...)
+}
+
 // ffmpegGenerateWebpThumb generates a thumbnail webp from input media of any type, useful for any media.
-func ffmpegGenerateWebpThumb(ctx context.Context, inpath, outpath string, width, height int, pixfmt string) error {
+// If crop is non-empty, only the given area of the input is used for the thumbnail before scaling.
+func ffmpegGenerateWebpThumb(ctx context.Context, inpath, outpath string, width, height int, crop image.Rectangle, pixfmt string) error {
+	var cropFilter string
+	if !crop.Empty() {
+		// Crop to given area before scaling
+		// (crop filter: https://ffmpeg.org/ffmpeg-filters.html#crop)
+		cropFilter = "crop=" + strconv.Itoa(crop.Dx()) +
+			":" + strconv.Itoa(crop.Dy()) +
+			":" + strconv.Itoa(crop.Min.X) +
+			":" + strconv.Itoa(crop.Min.Y) + ","
+	}
+
 	// Generate thumb with ffmpeg.
 	return ffmpeg(ctx, inpath, outpath,
 
@@ -81,7 +284,7 @@ func ffmpegGenerateWebpThumb(ctx context.Context, inpath, outpath string, width,
 		// Select thumb from first 7 frames.
 		// (in particular <= 7 reduced memory usage, marginally)
 		// (thumb filter: https://ffmpeg.org/ffmpeg-filters.html#thumbnail)
-		"-filter:v", "thumbnail=n=7,"+
+		"-filter:v", "thumbnail=n=7,"+cropFilter+
 
 			// Scale to dimensions
 			// (scale filter: https://ffmpeg.org/ffmpeg-filters.html#scale)
@@ -22,6 +22,9 @@ import (
 	"context"
 	"crypto/md5"
 	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
 	"io"
 	"os"
 	"testing"
@@ -278,14 +281,151 @@ func (suite *ManagerTestSuite) TestSimpleJpegProcess() {
 	suite.Equal(22858, attachment.Thumbnail.FileSize)
 	suite.Equal("LiB|W-#6RQR.~qvzRjWF_3rqV@a$", attachment.Blurhash)
 
+	// additional thumbnail sizes should have been generated
+	suite.Len(attachment.ThumbnailVariants, 3)
+	for i, size := range []struct{ width, height int }{
+		{320, 180},
+		{640, 360},
+		{1280, 720},
+	} {
+		variant := attachment.ThumbnailVariants[i]
+		suite.Equal(size.width, variant.Width)
+		suite.Equal(size.height, variant.Height)
+		suite.Equal("image/jpeg", variant.ContentType)
+		suite.NotZero(variant.FileSize)
+	}
+
 	// now make sure the attachment is in the database
 	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
 	suite.NoError(err)
 	suite.NotNil(dbAttachment)
+	suite.Equal(attachment.ThumbnailVariants, dbAttachment.ThumbnailVariants)
 
 	// ensure the files contain the expected data.
 	equalFiles(suite.T(), suite.state.Storage, dbAttachment.File.Path, "./test/test-jpeg-processed.jpg")
 	equalFiles(suite.T(), suite.state.Storage, dbAttachment.Thumbnail.Path, "./test/test-jpeg-thumbnail.jpeg")
+
+	// ensure the thumbnail variants were stored.
+	for _, variant := range dbAttachment.ThumbnailVariants {
+		have, err := suite.state.Storage.Has(ctx, variant.Path)
+		suite.NoError(err)
+		suite.True(have)
+	}
+}
+
+func (suite *ManagerTestSuite) TestRefocusMedia() {
+	ctx := context.Background()
+
+	// Encode a very wide test jpeg, which
+	// has a different color on each half.
+	img := image.NewRGBA(image.Rect(0, 0, 3000, 600))
+	for x := 0; x < 3000; x++ {
+		c := color.RGBA{R: 255, A: 255}
+		if x >= 1500 {
+			c = color.RGBA{B: 255, A: 255}
+		}
+		for y := 0; y < 600; y++ {
+			img.Set(x, y, c)
+		}
+	}
+	buf := new(bytes.Buffer)
+	if err := jpeg.Encode(buf, img, nil); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	data := func(_ context.Context) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+	}
+
+	// Process the media focused on the far left (red) half.
+	processing, err := suite.manager.CreateMedia(ctx,
+		"01FS1X72SK9ZPW0J1QQ68BD264",
+		data,
+		media.AdditionalMediaInfo{FocusX: util.Ptr[float32](-1)},
+	)
+	suite.NoError(err)
+	attachment, err := processing.Load(ctx)
+	suite.NoError(err)
+	suite.Len(attachment.ThumbnailVariants, 2)
+
+	// variantColor returns whether the
+	// stored variant is mostly red or blue.
+	variantColor := func(variant *gtsmodel.ThumbnailVariant) string {
+		b, err := suite.state.Storage.Get(ctx, variant.Path)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		thumb, err := jpeg.Decode(bytes.NewReader(b))
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		if r, _, b, _ := thumb.At(0, 0).RGBA(); r > b {
+			return "red"
+		}
+		return "blue"
+	}
+
+	for _, variant := range attachment.ThumbnailVariants {
+		suite.Equal("red", variantColor(variant))
+	}
+
+	// Move focus to the far right (blue) half.
+	attachment.FileMeta.Focus.X = 1
+	err = suite.manager.RefocusMedia(ctx, attachment)
+	suite.NoError(err)
+
+	// Variants should have been regenerated around new focus.
+	suite.Len(attachment.ThumbnailVariants, 2)
+	for _, variant := range attachment.ThumbnailVariants {
+		suite.Equal("blue", variantColor(variant))
+	}
+}
+
+func (suite *ManagerTestSuite) TestSimpleJpegProcessDuplicate() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the same media twice
+	var attachments []*gtsmodel.MediaAttachment
+	for i := 0; i < 2; i++ {
+		processing, err := suite.manager.CreateMedia(ctx,
+			accountID,
+			data,
+			media.AdditionalMediaInfo{},
+		)
+		suite.NoError(err)
+
+		attachment, err := processing.Load(ctx)
+		suite.NoError(err)
+		suite.NotNil(attachment)
+		attachments = append(attachments, attachment)
+	}
+
+	// both should have the same file hash,
+	// and share the first stored media file
+	suite.NotEmpty(attachments[0].File.Hash)
+	suite.Equal(attachments[0].File.Hash, attachments[1].File.Hash)
+	suite.Equal(attachments[0].File.Path, attachments[1].File.Path)
+	suite.Equal(attachments[0].File.FileSize, attachments[1].File.FileSize)
+
+	// but still have their own thumbnails
+	suite.NotEqual(attachments[0].Thumbnail.Path, attachments[1].Thumbnail.Path)
+
+	// only the first should have stored its own media file
+	path := "01FS1X72SK9ZPW0J1QQ68BD264/attachment/original/" + attachments[1].ID + ".jpeg"
+	has, err := suite.storage.Has(ctx, path)
+	suite.NoError(err)
+	suite.False(has)
 }
 
 func (suite *ManagerTestSuite) TestSimpleJpegProcessTooLarge() {
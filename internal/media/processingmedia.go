@@ -19,13 +19,17 @@ package media
 
 import (
 	"context"
+	"errors"
 	"os"
 
 	errorsv2 "codeberg.org/gruf/go-errors/v2"
 	"codeberg.org/gruf/go-runners"
 
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
@@ -42,6 +46,7 @@ type ProcessingMedia struct {
 	proc   runners.Processor         // proc helps synchronize only a singular running processing instance
 	err    error                     // error stores permanent error value when done
 	mgr    *Manager                  // mgr instance (access to db / storage)
+	unlock func()                    // unlock releases storage lock on media file path, if held
 }
 
 // ID returns the ID of the underlying media.
@@ -84,6 +89,16 @@ func (p *ProcessingMedia) load(ctx context.Context) (
 			return p.err
 		}
 
+		defer func() {
+			// Release any storage lock on media
+			// file path taken by storeFile(), only
+			// AFTER the media is updated in db.
+			if p.unlock != nil {
+				p.unlock()
+				p.unlock = nil
+			}
+		}()
+
 		defer func() {
 			// This is only done when ctx NOT cancelled.
 			if done = (err == nil || !errorsv2.IsV2(err,
@@ -145,10 +160,16 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 		// can remove them on error.
 		temppath  string
 		thumbpath string
+
+		// additional thumbnail
+		// variants, and paths.
+		variants     []thumbVariant
+		variantpaths []string
 	)
 
 	defer func() {
-		if err := remove(temppath, thumbpath); err != nil {
+		paths := append([]string{temppath, thumbpath}, variantpaths...)
+		if err := remove(paths...); err != nil {
 			log.Errorf(ctx, "error(s) cleaning up files: %v", err)
 		}
 	}()
@@ -170,7 +191,49 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 		return nil
 	}
 
-	var ext string
+	// Determine media type and extension from ffprobe format data.
+	typ, ext := result.GetFileType()
+
+	// Whether media was transcoded.
+	var transcoded bool
+
+	if config.GetMediaTranscode() {
+		// Check whether media is in a format not widely supported by
+		// web browsers, in which case it will need to be transcoded.
+		if format := result.TranscodeFormat(typ, ext); format != "" {
+			outpath, outresult, err := transcode(ctx, temppath, result, format)
+			switch {
+			case errors.Is(err, errTranscodeLimit) &&
+				typ == gtsmodel.FileTypeUnknown:
+				// Media exceeds transcode limits, and
+				// can't be stored in its original format
+				// (e.g. TIFF), so reject it like any
+				// other unsupported media type.
+				log.Warnf(ctx, "not transcoding unsupported media: %v", err)
+				return nil
+
+			case errors.Is(err, errTranscodeLimit):
+				// Media exceeds transcode limits, fall
+				// back to storing the original file as
+				// it was before transcoding was added,
+				// with metadata cleaned as usual below.
+				log.Warnf(ctx, "not transcoding media, storing original: %v", err)
+
+			case err != nil:
+				return gtserror.Newf("error transcoding: %w", err)
+
+			default:
+				// Update path var
+				// AFTER successful.
+				temppath = outpath
+				result = outresult
+
+				// Update type and extension from transcoded data.
+				typ, ext = result.GetFileType()
+				transcoded = true
+			}
+		}
+	}
 
 	// Extract any video stream metadata from media.
 	// This will always be used regardless of type,
@@ -186,7 +249,7 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	p.media.FileMeta.Original.Bitrate = util.PtrIf(result.bitrate)
 
 	// Set media type from ffprobe format data.
-	p.media.Type, ext = result.GetFileType()
+	p.media.Type = typ
 
 	// Add file extension to path.
 	newpath := temppath + "." + ext
@@ -204,6 +267,12 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	case gtsmodel.FileTypeImage,
 		gtsmodel.FileTypeVideo,
 		gtsmodel.FileTypeGifv:
+		if transcoded {
+			// Metadata was already
+			// dropped on transcode.
+			break
+		}
+
 		// Attempt to clean as metadata from file as possible.
 		if err := clearMetadata(ctx, temppath); err != nil {
 			return gtserror.Newf("error cleaning metadata: %w", err)
@@ -250,34 +319,44 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 			// Set newly determined blurhash.
 			p.media.Blurhash = newBlurhash
 		}
+
+		// Determine additional thumbnail sizes
+		// for responsive display, which very
+		// wide / tall media is cropped for.
+		variants = thumbVariants(
+			width,
+			height,
+			p.media.FileMeta.Focus.X,
+			p.media.FileMeta.Focus.Y,
+		)
+
+		// Generate the additional thumbnail variants from media.
+		variantpaths, err = generateThumbVariants(ctx, temppath,
+			variants,
+			result.orientation,
+			result.PixFmt(),
+		)
+		if err != nil {
+			return gtserror.Newf("error generating thumb variants: %w", err)
+		}
 	}
 
-	// Calculate final media attachment file path.
-	p.media.File.Path = uris.StoragePathForAttachment(
-		p.media.AccountID,
-		string(TypeAttachment),
-		string(SizeOriginal),
-		p.media.ID,
-		ext,
-	)
+	// Calculate hash of final media file contents.
+	p.media.File.Hash, err = hashFile(temppath)
+	if err != nil {
+		return gtserror.Newf("error hashing media: %w", err)
+	}
 
 	// Get mimetype for the file container
 	// type, falling back to generic data.
 	p.media.File.ContentType = getMimeType(ext)
 
-	// Copy temporary file into storage at path.
-	filesz, err := p.mgr.state.Storage.PutFile(ctx,
-		p.media.File.Path,
-		temppath,
-		p.media.File.ContentType,
-	)
-	if err != nil {
-		return gtserror.Newf("error writing media to storage: %w", err)
+	// Copy temporary file into storage,
+	// (or share existing identical file).
+	if err := p.storeFile(ctx, temppath, ext); err != nil {
+		return err
 	}
 
-	// Set final determined file size.
-	p.media.File.FileSize = int(filesz)
-
 	if thumbpath != "" {
 		// Determine final thumbnail ext.
 		thumbExt := getExtension(thumbpath)
@@ -317,6 +396,17 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 		)
 	}
 
+	// Drop any variants from previous processing,
+	// and copy newly generated variants into storage.
+	p.media.ThumbnailVariants = nil
+	if err := p.mgr.storeThumbVariants(ctx,
+		p.media,
+		variants,
+		variantpaths,
+	); err != nil {
+		return err
+	}
+
 	// Generate a media attachment URL.
 	p.media.URL = uris.URIForAttachment(
 		p.media.AccountID,
@@ -335,14 +425,121 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	return nil
 }
 
+// storeFile copies the final media file at temppath into storage, setting
+// file path and size. If another cached media's file has identical contents
+// then that is shared instead, rather than storing yet another copy of it.
+func (p *ProcessingMedia) storeFile(ctx context.Context, temppath string, ext string) error {
+	// Look for existing media with identical file contents.
+	existing, err := p.mgr.state.DB.GetAttachmentByFileHash(ctx,
+		p.media.File.Hash,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting media by file hash: %w", err)
+	}
+
+	if existing != nil && existing.ID != p.media.ID {
+		// Lock existing file path, so it can't be
+		// removed from storage while we share it.
+		unlock := p.mgr.state.StorageLocks.Lock(existing.File.Path)
+
+		// Ensure existing file is still in storage.
+		has, err := p.mgr.state.Storage.Has(ctx,
+			existing.File.Path,
+		)
+		if err != nil {
+			unlock()
+			return gtserror.Newf("error checking storage for %s: %w", existing.File.Path, err)
+		}
+
+		if has {
+			// Share the existing file, keeping
+			// its path locked until media is
+			// updated in the database by load().
+			p.media.File.Path = existing.File.Path
+			p.media.File.FileSize = existing.File.FileSize
+			p.unlock = unlock
+			return nil
+		}
+
+		unlock()
+	}
+
+	// Calculate final media attachment file path.
+	path := uris.StoragePathForAttachment(
+		p.media.AccountID,
+		string(TypeAttachment),
+		string(SizeOriginal),
+		p.media.ID,
+		ext,
+	)
+
+	// Lock file path until media is updated in
+	// the database by load(), so it can't be
+	// shared or removed by others in the meantime.
+	p.unlock = p.mgr.state.StorageLocks.Lock(path)
+
+	// Files are never overwritten while shared with other
+	// media, which may be the case when recaching media.
+	refs, err := p.mgr.state.DB.CountAttachmentsByFilePath(ctx,
+		path,
+		p.media.ID,
+	)
+	if err != nil {
+		return gtserror.Newf("error counting media with file %s: %w", path, err)
+	}
+
+	if refs > 0 {
+		// Store at a new unique path instead.
+		path = uris.StoragePathForAttachment(
+			p.media.AccountID,
+			string(TypeAttachment),
+			string(SizeOriginal),
+			id.NewULID(),
+			ext,
+		)
+
+		// Swap lock to the new path.
+		p.unlock()
+		p.unlock = p.mgr.state.StorageLocks.Lock(path)
+	}
+
+	// Set path BEFORE storing, so
+	// it gets removed on failure.
+	p.media.File.Path = path
+
+	// Copy temporary file into storage at path.
+	filesz, err := p.mgr.state.Storage.PutFile(ctx,
+		p.media.File.Path,
+		temppath,
+		p.media.File.ContentType,
+	)
+	if err != nil {
+		return gtserror.Newf("error writing media to storage: %w", err)
+	}
+
+	// Set final determined file size.
+	p.media.File.FileSize = int(filesz)
+
+	return nil
+}
+
 // cleanup will remove any traces of processing media from storage.
 // and perform any other necessary cleanup steps after failure.
 func (p *ProcessingMedia) cleanup(ctx context.Context) {
 	if p.media.File.Path != "" {
-		// Ensure media file at path is deleted from storage.
-		err := p.mgr.state.Storage.Delete(ctx, p.media.File.Path)
-		if err != nil && !storage.IsNotFound(err) {
-			log.Errorf(ctx, "error deleting %s: %v", p.media.File.Path, err)
+		// Check whether media file is shared with other media.
+		refs, err := p.mgr.state.DB.CountAttachmentsByFilePath(ctx,
+			p.media.File.Path,
+			p.media.ID,
+		)
+		if err != nil {
+			log.Errorf(ctx, "error counting media with file %s: %v", p.media.File.Path, err)
+		} else if refs == 0 {
+			// Ensure media file at path is deleted from storage.
+			err := p.mgr.state.Storage.Delete(ctx, p.media.File.Path)
+			if err != nil && !storage.IsNotFound(err) {
+				log.Errorf(ctx, "error deleting %s: %v", p.media.File.Path, err)
+			}
 		}
 	}
 
@@ -354,16 +551,26 @@ func (p *ProcessingMedia) cleanup(ctx context.Context) {
 		}
 	}
 
+	for _, variant := range p.media.ThumbnailVariants {
+		// Ensure thumbnail variant at path is deleted from storage.
+		err := p.mgr.state.Storage.Delete(ctx, variant.Path)
+		if err != nil && !storage.IsNotFound(err) {
+			log.Errorf(ctx, "error deleting %s: %v", variant.Path, err)
+		}
+	}
+
 	// Unset all processor-calculated media fields.
 	p.media.FileMeta.Original = gtsmodel.Original{}
 	p.media.FileMeta.Small = gtsmodel.Small{}
 	p.media.File.ContentType = ""
 	p.media.File.FileSize = 0
 	p.media.File.Path = ""
+	p.media.File.Hash = ""
 	p.media.Thumbnail.FileSize = 0
 	p.media.Thumbnail.ContentType = ""
 	p.media.Thumbnail.Path = ""
 	p.media.Thumbnail.URL = ""
+	p.media.ThumbnailVariants = nil
 	p.media.URL = ""
 
 	// Also ensure marked as unknown and finished
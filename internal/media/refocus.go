@@ -0,0 +1,201 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"os"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// RefocusMedia regenerates the thumbnail variants of given media
+// after its focus has changed, where media is cropped around its
+// focus for its variants (see focusCrop()). The media's variants
+// are updated in place, it is up to the caller to then update the
+// "thumbnail_variants" column in the database. If the original file
+// is not currently cached, the variants are instead dropped.
+func (m *Manager) RefocusMedia(ctx context.Context, media *gtsmodel.MediaAttachment) error {
+	if len(media.ThumbnailVariants) == 0 {
+		// No variants
+		// to refocus.
+		return nil
+	}
+
+	// Check whether variants are
+	// cropped, else focus is unused.
+	width := media.FileMeta.Original.Width
+	height := media.FileMeta.Original.Height
+	if !isFocusCropped(width, height) {
+		return nil
+	}
+
+	// Drop old variants, deleting any of their
+	// files not overwritten by the new variants.
+	old := media.ThumbnailVariants
+	media.ThumbnailVariants = nil
+	defer func() {
+		for _, variant := range old {
+			if slices.ContainsFunc(media.ThumbnailVariants, func(v *gtsmodel.ThumbnailVariant) bool {
+				return v.Path == variant.Path
+			}) {
+				continue
+			}
+
+			if err := m.state.Storage.Delete(ctx, variant.Path); err != nil {
+				log.Errorf(ctx, "error deleting old thumb variant %s: %v", variant.Path, err)
+			}
+		}
+	}()
+
+	if !*media.Cached {
+		// Variants will be generated
+		// again on media recache.
+		return nil
+	}
+
+	var (
+		// predefine temporary media
+		// file paths so we can
+		// remove them on return.
+		temppath     string
+		variantpaths []string
+	)
+
+	defer func() {
+		paths := append([]string{temppath}, variantpaths...)
+		if err := remove(paths...); err != nil {
+			log.Errorf(ctx, "error(s) cleaning up files: %v", err)
+		}
+	}()
+
+	// Open original media file from storage.
+	rc, err := m.state.Storage.GetStream(ctx, media.File.Path)
+	if err != nil {
+		return gtserror.Newf("error opening media from storage: %w", err)
+	}
+
+	// Drain reader to tmp file
+	// (this reader handles close).
+	temppath, err = drainToTmp(rc)
+	if err != nil {
+		return gtserror.Newf("error draining data to tmp: %w", err)
+	}
+
+	// Variant generation requires the file ext.
+	newpath := temppath + "." + getExtension(media.File.Path)
+	if err := os.Rename(temppath, newpath); err != nil {
+		return gtserror.Newf("error renaming to %s - >%s: %w", temppath, newpath, err)
+	}
+	temppath = newpath
+
+	// Probe stored file for
+	// orientation and pixel format.
+	result, err := probe(ctx, temppath)
+	if err != nil {
+		return gtserror.Newf("ffprobe error: %w", err)
+	} else if result == nil {
+		return gtserror.New("unsupported data type")
+	}
+
+	// Determine variant sizes with new focus.
+	variants := thumbVariants(
+		width,
+		height,
+		media.FileMeta.Focus.X,
+		media.FileMeta.Focus.Y,
+	)
+
+	// Generate the thumbnail variants from media.
+	variantpaths, err = generateThumbVariants(ctx, temppath,
+		variants,
+		result.orientation,
+		result.PixFmt(),
+	)
+	if err != nil {
+		return gtserror.Newf("error generating thumb variants: %w", err)
+	}
+
+	// Copy new variant files into storage.
+	return m.storeThumbVariants(ctx,
+		media,
+		variants,
+		variantpaths,
+	)
+}
+
+// storeThumbVariants copies generated thumbnail variant files at given
+// paths into storage, appending each variant to the media's variants.
+func (m *Manager) storeThumbVariants(
+	ctx context.Context,
+	media *gtsmodel.MediaAttachment,
+	variants []thumbVariant,
+	variantpaths []string,
+) error {
+	for i, variant := range variants {
+		// Determine final variant ext.
+		variantExt := getExtension(variantpaths[i])
+
+		// Calculate final variant storage path / URL.
+		thumb := &gtsmodel.ThumbnailVariant{
+			Width:  variant.width,
+			Height: variant.height,
+			Path: uris.StoragePathForAttachment(
+				media.AccountID,
+				string(TypeAttachment),
+				string(variant.size),
+				media.ID,
+				variantExt,
+			),
+			ContentType: getMimeType(variantExt),
+			URL: uris.URIForAttachment(
+				media.AccountID,
+				string(TypeAttachment),
+				string(variant.size),
+				media.ID,
+				variantExt,
+			),
+		}
+
+		// Append variant BEFORE storing, so it
+		// gets removed by cleanup() on failure.
+		media.ThumbnailVariants = append(
+			media.ThumbnailVariants,
+			thumb,
+		)
+
+		// Copy variant file into storage at path.
+		variantsz, err := m.state.Storage.PutFile(ctx,
+			thumb.Path,
+			variantpaths[i],
+			thumb.ContentType,
+		)
+		if err != nil {
+			return gtserror.Newf("error writing thumb variant to storage: %w", err)
+		}
+
+		// Set final determined variant size.
+		thumb.FileSize = int(variantsz)
+	}
+
+	return nil
+}
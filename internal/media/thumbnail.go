@@ -24,6 +24,7 @@ import (
 	"image/jpeg"
 	"image/png"
 	"io"
+	"math"
 	"os"
 	"strings"
 
@@ -36,6 +37,13 @@ import (
 const (
 	maxThumbWidth  = 512
 	maxThumbHeight = 512
+
+	// Aspect ratio bounds for thumbnail
+	// variants, media with aspect ratio
+	// outside of these is cropped around
+	// its focus when generating variants.
+	minVariantAspect = 0.5
+	maxVariantAspect = 2.0
 )
 
 // thumbSize returns the dimensions to use for an input
@@ -67,6 +75,98 @@ func thumbSize(width, height int, aspect float32) (int, int) {
 	}
 }
 
+// thumbVariant describes an additional
+// thumbnail size to generate for media.
+type thumbVariant struct {
+	size   Size            // storage size key
+	width  int             // output width
+	height int             // output height
+	crop   image.Rectangle // area of input
+}
+
+// thumbVariants returns the additional thumbnail variants
+// to generate for media of given (oriented) dimensions, with
+// given focus. Variants are only generated for widths smaller
+// than the media, i.e. we never scale up, and all variants
+// share the same crop determined by focusCrop().
+func thumbVariants(width, height int, focusX, focusY float32) []thumbVariant {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+
+	// Determine crop area and its dimens.
+	crop := focusCrop(width, height, focusX, focusY)
+	cropW, cropH := crop.Dx(), crop.Dy()
+
+	var variants []thumbVariant
+	for _, v := range thumbVariantSizes {
+		if v.width >= cropW {
+			// Sizes are in ascending
+			// order, no more to do.
+			break
+		}
+
+		// Calculate height that maintains crop aspect ratio.
+		h := int(math.Round(float64(v.width) * float64(cropH) / float64(cropW)))
+
+		variants = append(variants, thumbVariant{
+			size:   v.size,
+			width:  v.width,
+			height: max(h, 1),
+			crop:   crop,
+		})
+	}
+
+	return variants
+}
+
+// focusCrop returns the area of media with given (oriented)
+// dimensions to use when generating thumbnail variants. Media
+// with an aspect ratio outside of variant aspect bounds gets
+// cropped to within them, keeping the focus as close to the
+// center of the cropped area as possible. Focus coordinates
+// are between -1 and 1, where (-1, 1) is the top-left corner.
+func focusCrop(width, height int, focusX, focusY float32) image.Rectangle {
+	crop := image.Rect(0, 0, width, height)
+	aspect := float64(width) / float64(height)
+
+	switch {
+	// Too wide, crop horizontally.
+	case aspect > maxVariantAspect:
+		w := int(math.Round(float64(height) * maxVariantAspect))
+		x := focusOffset(width, w, (float64(focusX)+1)/2)
+		crop.Min.X, crop.Max.X = x, x+w
+
+	// Too tall, crop vertically.
+	case aspect < minVariantAspect:
+		h := int(math.Round(float64(width) / minVariantAspect))
+		y := focusOffset(height, h, (1-float64(focusY))/2)
+		crop.Min.Y, crop.Max.Y = y, y+h
+	}
+
+	return crop
+}
+
+// isFocusCropped returns whether media with given
+// dimensions is cropped around its focus when
+// generating thumbnail variants (see focusCrop()).
+func isFocusCropped(width, height int) bool {
+	if width <= 0 || height <= 0 {
+		return false
+	}
+	aspect := float64(width) / float64(height)
+	return aspect > maxVariantAspect ||
+		aspect < minVariantAspect
+}
+
+// focusOffset returns the offset of a section of given size
+// within total, centered on focus (a fraction of total) where
+// possible, else clamped to stay within bounds of the total.
+func focusOffset(total, size int, focus float64) int {
+	offset := int(math.Round(focus*float64(total) - float64(size)/2))
+	return max(0, min(offset, total-size))
+}
+
 // generateThumb generates a thumbnail for the
 // input file at path, resizing it to the given
 // dimensions and generating a blurhash if needed.
@@ -100,87 +200,20 @@ func generateThumb(
 	// Check for the few media types we
 	// have native Go decoding that allow
 	// us to generate thumbs natively.
-	switch {
+	if decode := nativeDecoder(ext, pixfmt); decode != nil {
 
-	case ext == "jpeg":
 		// Replace the "webp" with "jpeg", as we'll
 		// use our native Go thumbnailing generation.
 		outpath = outpath[:len(outpath)-4] + "jpeg"
 
-		log.Debug(ctx, "generating thumb from jpeg")
+		log.Debugf(ctx, "generating thumb from %s", ext)
 		blurhash, err := generateNativeThumb(
 			filepath,
 			outpath,
 			width,
 			height,
 			orientation,
-			jpeg.Decode,
-			needBlurhash,
-		)
-		return outpath, blurhash, err
-
-	// We specifically only allow generating native
-	// thumbnails from gif IF it doesn't contain an
-	// alpha channel. We'll ultimately be encoding to
-	// jpeg which doesn't support transparency layers.
-	case ext == "gif" && !containsAlpha(pixfmt):
-
-		// Replace the "webp" with "jpeg", as we'll
-		// use our native Go thumbnailing generation.
-		outpath = outpath[:len(outpath)-4] + "jpeg"
-
-		log.Debug(ctx, "generating thumb from gif")
-		blurhash, err := generateNativeThumb(
-			filepath,
-			outpath,
-			width,
-			height,
-			orientation,
-			gif.Decode,
-			needBlurhash,
-		)
-		return outpath, blurhash, err
-
-	// We specifically only allow generating native
-	// thumbnails from png IF it doesn't contain an
-	// alpha channel. We'll ultimately be encoding to
-	// jpeg which doesn't support transparency layers.
-	case ext == "png" && !containsAlpha(pixfmt):
-
-		// Replace the "webp" with "jpeg", as we'll
-		// use our native Go thumbnailing generation.
-		outpath = outpath[:len(outpath)-4] + "jpeg"
-
-		log.Debug(ctx, "generating thumb from png")
-		blurhash, err := generateNativeThumb(
-			filepath,
-			outpath,
-			width,
-			height,
-			orientation,
-			png.Decode,
-			needBlurhash,
-		)
-		return outpath, blurhash, err
-
-	// We specifically only allow generating native
-	// thumbnails from webp IF it doesn't contain an
-	// alpha channel. We'll ultimately be encoding to
-	// jpeg which doesn't support transparency layers.
-	case ext == "webp" && !containsAlpha(pixfmt):
-
-		// Replace the "webp" with "jpeg", as we'll
-		// use our native Go thumbnailing generation.
-		outpath = outpath[:len(outpath)-4] + "jpeg"
-
-		log.Debug(ctx, "generating thumb from webp")
-		blurhash, err := generateNativeThumb(
-			filepath,
-			outpath,
-			width,
-			height,
-			orientation,
-			webp.Decode,
+			decode,
 			needBlurhash,
 		)
 		return outpath, blurhash, err
@@ -194,6 +227,7 @@ func generateThumb(
 		outpath,
 		width,
 		height,
+		image.Rectangle{},
 		pixfmt,
 	); err != nil {
 		return outpath, "", err
@@ -210,6 +244,116 @@ func generateThumb(
 	return outpath, blurhash, err
 }
 
+// generateThumbVariants generates the given additional
+// thumbnail variants for the input file at path, cropping
+// and resizing as needed. As with generateThumb, native
+// Go libraries are used where possible, else falling back
+// to ffmpeg. Output paths are returned in variant order,
+// and may be partially populated on error for cleanup.
+func generateThumbVariants(
+	ctx context.Context,
+	filepath string,
+	variants []thumbVariant,
+	orientation int,
+	pixfmt string,
+) (
+	outpaths []string,
+	err error,
+) {
+	if len(variants) == 0 {
+		// Nothing to do.
+		return nil, nil
+	}
+
+	var base, ext string
+
+	// Split input path on extension, as with generateThumb.
+	if i := strings.IndexByte(filepath, '.'); i != -1 {
+		base = filepath[:i]
+		ext = filepath[i+1:]
+	} else {
+		return nil, gtserror.New("input file missing extension")
+	}
+
+	if decode := nativeDecoder(ext, pixfmt); decode != nil {
+		log.Debugf(ctx, "generating thumb variants from %s", ext)
+
+		// Decode input image only once
+		// for all of the variant sizes.
+		img, err := decodeNative(
+			filepath,
+			orientation,
+			decode,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, variant := range variants {
+			outpath := base + "_thumb_" + string(variant.size) + ".jpeg"
+			outpaths = append(outpaths, outpath)
+
+			// Crop and resize image to variant dimens.
+			thumb := cropImage(img, variant.crop)
+			thumb = resizeDownLinear(thumb,
+				variant.width,
+				variant.height,
+			)
+
+			// Write variant thumb to output path.
+			if err := encodeJPEG(outpath, thumb); err != nil {
+				return outpaths, err
+			}
+		}
+
+		return outpaths, nil
+	}
+
+	log.Debug(ctx, "generating thumb variants with ffmpeg")
+	for _, variant := range variants {
+		outpath := base + "_thumb_" + string(variant.size) + ".webp"
+		outpaths = append(outpaths, outpath)
+
+		// Generate each variant
+		// with a separate ffmpeg
+		// run, as these only
+		// allow one output file.
+		if err := ffmpegGenerateWebpThumb(ctx,
+			filepath,
+			outpath,
+			variant.width,
+			variant.height,
+			variant.crop,
+			pixfmt,
+		); err != nil {
+			return outpaths, err
+		}
+	}
+
+	return outpaths, nil
+}
+
+// nativeDecoder returns a native Go decode function for
+// media with given extension and pixel format, or nil if
+// it must instead be handled by ffmpeg. We specifically
+// only allow native decoding of gif, png and webp IF they
+// don't contain an alpha channel. We'll ultimately be
+// encoding to jpeg which doesn't support transparency layers.
+func nativeDecoder(ext string, pixfmt string) func(io.Reader) (image.Image, error) {
+	switch {
+	case ext == "jpeg":
+		return jpeg.Decode
+	case ext == "gif" && !containsAlpha(pixfmt):
+		return gif.Decode
+	case ext == "png" && !containsAlpha(pixfmt):
+		return png.Decode
+	case ext == "webp" && !containsAlpha(pixfmt):
+		return webp.Decode
+	default:
+		return nil
+	}
+}
+
 // generateNativeThumb generates a thumbnail
 // using native Go code, using given decode
 // function to get image, resize to given dimens,
@@ -225,11 +369,64 @@ func generateNativeThumb(
 ) (
 	string, // blurhash
 	error,
+) {
+	// Decode image into memory.
+	img, err := decodeNative(
+		inpath,
+		orientation,
+		decode,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	// Resize image to dimens.
+	img = resizeDownLinear(img,
+		width, height,
+	)
+
+	// Write thumb to output path.
+	if err := encodeJPEG(outpath, img); err != nil {
+		return "", err
+	}
+
+	if needBlurhash {
+		// for generating blurhashes, it's more
+		// cost effective to lose detail since
+		// it's blurry, so make a tiny version.
+		tiny := resizeDownLinear(img, 32, 0)
+
+		// Drop the larger image
+		// ref as soon as possible
+		// to allow GC to claim.
+		img = nil //nolint
+
+		// Generate blurhash for the tiny thumbnail.
+		blurhash, err := blurhash.Encode(4, 3, tiny)
+		if err != nil {
+			return "", gtserror.Newf("error generating blurhash: %w", err)
+		}
+
+		return blurhash, nil
+	}
+
+	return "", nil
+}
+
+// decodeNative decodes the image at input path using
+// given decode function, applying given orientation.
+func decodeNative(
+	inpath string,
+	orientation int,
+	decode func(io.Reader) (image.Image, error),
+) (
+	image.Image,
+	error,
 ) {
 	// Open input file at given path.
 	infile, err := os.Open(inpath)
 	if err != nil {
-		return "", gtserror.Newf("error opening input file %s: %w", inpath, err)
+		return nil, gtserror.Newf("error opening input file %s: %w", inpath, err)
 	}
 
 	// Decode image into memory.
@@ -239,7 +436,7 @@ func generateNativeThumb(
 	_ = infile.Close()
 
 	if err != nil {
-		return "", gtserror.Newf("error decoding file %s: %w", inpath, err)
+		return nil, gtserror.Newf("error decoding file %s: %w", inpath, err)
 	}
 
 	// Apply orientation BEFORE any resize,
@@ -262,15 +459,15 @@ func generateNativeThumb(
 		img = transverse(img)
 	}
 
-	// Resize image to dimens.
-	img = resizeDownLinear(img,
-		width, height,
-	)
+	return img, nil
+}
 
+// encodeJPEG encodes the given image as JPEG to output path.
+func encodeJPEG(outpath string, img image.Image) error {
 	// Open output file at given path.
 	outfile, err := os.Create(outpath)
 	if err != nil {
-		return "", gtserror.Newf("error opening output file %s: %w", outpath, err)
+		return gtserror.Newf("error opening output file %s: %w", outpath, err)
 	}
 
 	// Encode in-memory image to output file.
@@ -281,30 +478,34 @@ func generateNativeThumb(
 	_ = outfile.Close()
 
 	if err != nil {
-		return "", gtserror.Newf("error encoding image: %w", err)
+		return gtserror.Newf("error encoding image: %w", err)
 	}
 
-	if needBlurhash {
-		// for generating blurhashes, it's more
-		// cost effective to lose detail since
-		// it's blurry, so make a tiny version.
-		tiny := resizeDownLinear(img, 32, 0)
+	return nil
+}
 
-		// Drop the larger image
-		// ref as soon as possible
-		// to allow GC to claim.
-		img = nil //nolint
+// cropImage returns the area of image within
+// given crop rectangle (relative to image bounds).
+// If crop covers the whole image, it's returned as-is.
+func cropImage(img image.Image, crop image.Rectangle) image.Image {
+	bounds := img.Bounds()
+	crop = crop.Add(bounds.Min)
+	if crop.Empty() || crop == bounds {
+		return img
+	}
 
-		// Generate blurhash for the tiny thumbnail.
-		blurhash, err := blurhash.Encode(4, 3, tiny)
-		if err != nil {
-			return "", gtserror.Newf("error generating blurhash: %w", err)
-		}
+	// All of the stdlib image types that
+	// may be returned by our decoders, or
+	// by orientation, support sub-images.
+	type subImager interface {
+		SubImage(image.Rectangle) image.Image
+	}
 
-		return blurhash, nil
+	if img, ok := img.(subImager); ok {
+		return img.SubImage(crop)
 	}
 
-	return "", nil
+	return img
 }
 
 // generateWebpBlurhash generates a blurhash for Webp at filepath.
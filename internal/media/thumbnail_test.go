@@ -0,0 +1,173 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestThumbVariants(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		width, height int
+		focusX        float32
+		focusY        float32
+		expect        []thumbVariant
+	}{
+		{
+			name:   "landscape",
+			width:  2000,
+			height: 1000,
+			expect: []thumbVariant{
+				{size: SizeW320, width: 320, height: 160, crop: image.Rect(0, 0, 2000, 1000)},
+				{size: SizeW640, width: 640, height: 320, crop: image.Rect(0, 0, 2000, 1000)},
+				{size: SizeW1280, width: 1280, height: 640, crop: image.Rect(0, 0, 2000, 1000)},
+			},
+		},
+		{
+			name:   "no upscale",
+			width:  600,
+			height: 400,
+			expect: []thumbVariant{
+				{size: SizeW320, width: 320, height: 213, crop: image.Rect(0, 0, 600, 400)},
+			},
+		},
+		{
+			name:   "too small",
+			width:  320,
+			height: 320,
+			expect: nil,
+		},
+		{
+			name:   "wide centered",
+			width:  4000,
+			height: 500,
+			expect: []thumbVariant{
+				{size: SizeW320, width: 320, height: 160, crop: image.Rect(1500, 0, 2500, 500)},
+				{size: SizeW640, width: 640, height: 320, crop: image.Rect(1500, 0, 2500, 500)},
+			},
+		},
+		{
+			name:   "wide focus right",
+			width:  4000,
+			height: 500,
+			focusX: 0.9,
+			expect: []thumbVariant{
+				{size: SizeW320, width: 320, height: 160, crop: image.Rect(3000, 0, 4000, 500)},
+				{size: SizeW640, width: 640, height: 320, crop: image.Rect(3000, 0, 4000, 500)},
+			},
+		},
+		{
+			name:   "tall focus top",
+			width:  800,
+			height: 4000,
+			focusY: 0.75,
+			expect: []thumbVariant{
+				{size: SizeW320, width: 320, height: 640, crop: image.Rect(0, 0, 800, 1600)},
+				{size: SizeW640, width: 640, height: 1280, crop: image.Rect(0, 0, 800, 1600)},
+			},
+		},
+		{
+			name:   "tall focus below center",
+			width:  800,
+			height: 4000,
+			focusY: -0.5,
+			expect: []thumbVariant{
+				{size: SizeW320, width: 320, height: 640, crop: image.Rect(0, 2200, 800, 3800)},
+				{size: SizeW640, width: 640, height: 1280, crop: image.Rect(0, 2200, 800, 3800)},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			variants := thumbVariants(test.width, test.height, test.focusX, test.focusY)
+			if len(variants) != len(test.expect) {
+				t.Fatalf("expected %d variants, got %d: %+v", len(test.expect), len(variants), variants)
+			}
+			for i, variant := range variants {
+				if variant != test.expect[i] {
+					t.Errorf("expected variant %+v, got %+v", test.expect[i], variant)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateThumbVariantsNative(t *testing.T) {
+	ctx := context.Background()
+	inpath := filepath.Join(t.TempDir(), "input.jpeg")
+
+	// Write a very wide test jpeg, which
+	// has a different color on each half.
+	img := image.NewRGBA(image.Rect(0, 0, 3000, 600))
+	for x := 0; x < 3000; x++ {
+		c := color.RGBA{R: 255, A: 255}
+		if x >= 1500 {
+			c = color.RGBA{B: 255, A: 255}
+		}
+		for y := 0; y < 600; y++ {
+			img.Set(x, y, c)
+		}
+	}
+	file, err := os.Create(inpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := jpeg.Encode(file, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	_ = file.Close()
+
+	// Focus on the far right (blue) half.
+	variants := thumbVariants(3000, 600, 1, 0)
+	if len(variants) != 2 {
+		t.Fatalf("expected 2 variants, got %d", len(variants))
+	}
+
+	outpaths, err := generateThumbVariants(ctx, inpath, variants, 0, "yuvj420p")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, outpath := range outpaths {
+		file, err := os.Open(outpath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		thumb, err := jpeg.Decode(file)
+		_ = file.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Check output dimensions match variant.
+		if sz := thumb.Bounds().Size(); sz.X != variants[i].width || sz.Y != variants[i].height {
+			t.Errorf("expected %dx%d thumb, got %dx%d", variants[i].width, variants[i].height, sz.X, sz.Y)
+		}
+
+		// Check thumb was cropped to the focused (blue) side.
+		if r, _, b, _ := thumb.At(0, 0).RGBA(); r > b {
+			t.Errorf("expected thumb cropped around focus, got color %v", thumb.At(0, 0))
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// errTranscodeLimit is returned when media requiring
+// transcoding exceeds the configured size / duration limits.
+var errTranscodeLimit = errors.New("media exceeds transcode limits")
+
+// TranscodeFormat determines the file extension of the format to
+// transcode probed media to, given its already determined file type
+// and extension (see GetFileType()), if the media is in a format not
+// widely supported by web browsers. An empty string is returned when
+// media should be stored as-is, or when it can't be transcoded at all.
+func (res *result) TranscodeFormat(typ gtsmodel.FileType, ext string) string {
+	switch typ {
+	case gtsmodel.FileTypeImage:
+		switch ext {
+		case "jpeg", "png", "apng", "gif", "webp":
+			// Widely supported.
+			return ""
+		}
+		return res.imageFormat()
+
+	case gtsmodel.FileTypeVideo,
+		gtsmodel.FileTypeGifv:
+		if res.isStill() {
+			// e.g. HEIC images appear as
+			// single-frame mp4 video streams.
+			return res.imageFormat()
+		}
+		if res.webSafeVideo(ext) {
+			return ""
+		}
+		return "mp4"
+
+	case gtsmodel.FileTypeAudio:
+		switch ext {
+		case "mp3", "m4a", "opus":
+			// Widely supported.
+			return ""
+		case "ogg":
+			if res.audio[0].codec == "vorbis" {
+				// Widely supported.
+				return ""
+			}
+		}
+		return config.GetMediaTranscodeAudioFormat()
+
+	case gtsmodel.FileTypeUnknown:
+		// Media that ffprobe could read, but
+		// that we don't otherwise support as-is,
+		// e.g. TIFF, BMP, WAV. Determine target
+		// format from the contained streams.
+		switch {
+		case len(res.video) > 0 && res.isStill():
+			return res.imageFormat()
+		case len(res.video) > 0:
+			return "mp4"
+		case len(res.audio) > 0:
+			return config.GetMediaTranscodeAudioFormat()
+		}
+	}
+
+	return ""
+}
+
+// imageFormat returns the format to transcode
+// images to: webp for images with transparency,
+// else the more widely supported jpeg.
+func (res *result) imageFormat() string {
+	if containsAlpha(res.PixFmt()) {
+		return "webp"
+	}
+	return "jpeg"
+}
+
+// isStill returns whether result contains
+// a single still image, i.e. video streams
+// without any framerate, and no audio.
+func (res *result) isStill() bool {
+	if len(res.audio) > 0 {
+		return false
+	}
+	for _, stream := range res.video {
+		if stream.framerate > 0 {
+			return false
+		}
+	}
+	return len(res.video) > 0
+}
+
+// webSafeVideo returns whether video of given
+// extension contains only stream codecs that
+// are widely supported by web browsers.
+func (res *result) webSafeVideo(ext string) bool {
+	switch ext {
+	case "webm":
+		// GetFileType() only returns
+		// webm for supported codecs.
+		return true
+
+	case "mp4":
+		for _, stream := range res.video {
+			switch stream.codec {
+			case "h264", "vp9", "av1":
+			default:
+				return false
+			}
+		}
+		for _, stream := range res.audio {
+			switch stream.codec {
+			case "aac", "mp3", "opus":
+			default:
+				return false
+			}
+		}
+		return true
+
+	default:
+		return false
+	}
+}
+
+// transcode transcodes the media file at inpath, with the given probed
+// result, to the given format (see TranscodeFormat()). On success the
+// input file is removed, and the path and probed result of the transcoded
+// output file are returned. If media exceeds the configured transcode
+// limits, errTranscodeLimit is returned. Note this runs ffmpeg and so is
+// limited by the ffmpeg pool size, just like all other media processing.
+func transcode(ctx context.Context, inpath string, res *result, format string) (string, *result, error) {
+	// Check input size against limit.
+	stat, err := os.Stat(inpath)
+	if err != nil {
+		return "", nil, gtserror.Newf("error statting %s: %w", inpath, err)
+	}
+	max := int64(config.GetMediaTranscodeMaxSize())
+	if max > 0 && stat.Size() > max {
+		return "", nil, gtserror.Newf("%w: size %d > %d", errTranscodeLimit, stat.Size(), max)
+	}
+
+	// Output path WITHOUT extension, this
+	// gets added after the file type of the
+	// transcoded file has been determined.
+	outpath := inpath + "_transcoded"
+
+	switch format {
+	case "jpeg", "webp":
+		log.Debugf(ctx, "transcoding image %s to %s", res.format, format)
+		err = ffmpegTranscodeImage(ctx, inpath, outpath, format, res.orientation)
+
+	case "mp4":
+		if err := checkTranscodeDuration(res); err != nil {
+			return "", nil, err
+		}
+		log.Debugf(ctx, "transcoding video %s to %s", res.format, format)
+		err = ffmpegTranscodeVideo(ctx, inpath, outpath)
+
+	case "mp3", "opus":
+		if err := checkTranscodeDuration(res); err != nil {
+			return "", nil, err
+		}
+		log.Debugf(ctx, "transcoding audio %s to %s", res.format, format)
+		err = ffmpegTranscodeAudio(ctx, inpath, outpath, format)
+
+	default:
+		return "", nil, gtserror.Newf("unsupported transcode format: %s", format)
+	}
+
+	if err != nil {
+		_ = os.Remove(outpath)
+		return "", nil, gtserror.Newf("error transcoding to %s: %w", format, err)
+	}
+
+	// Probe the newly transcoded output.
+	result, err := probe(ctx, outpath)
+	if err != nil {
+		_ = os.Remove(outpath)
+		return "", nil, gtserror.Newf("error probing transcoded file: %w", err)
+	}
+
+	// Done with original input.
+	if err := os.Remove(inpath); err != nil {
+		log.Errorf(ctx, "error removing %s: %v", inpath, err)
+	}
+
+	return outpath, result, nil
+}
+
+// checkTranscodeDuration checks media
+// duration against the configured limit.
+func checkTranscodeDuration(res *result) error {
+	max := config.GetMediaTranscodeMaxDuration()
+	if max <= 0 {
+		return nil
+	}
+	dur := time.Duration(res.duration * float64(time.Second))
+	if dur > max {
+		return gtserror.Newf("%w: duration %s > %s", errTranscodeLimit, dur, max)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+func TestTranscodeFormat(t *testing.T) {
+	config.SetMediaTranscodeAudioFormat(config.MediaTranscodeAudioFormatOpus)
+	defer config.SetMediaTranscodeAudioFormat("")
+
+	video := func(codec string, framerate float32) videoStream {
+		return videoStream{stream: stream{codec: codec}, framerate: framerate}
+	}
+	audio := func(codec string) audioStream {
+		return audioStream{stream: stream{codec: codec}}
+	}
+
+	for _, test := range []struct {
+		name   string
+		res    result
+		expect string
+	}{
+		{
+			name:   "jpeg",
+			res:    result{format: "image2", video: []videoStream{video("mjpeg", 0)}},
+			expect: "",
+		},
+		{
+			name:   "tiff",
+			res:    result{format: "tiff_pipe", video: []videoStream{video("tiff", 0)}},
+			expect: "jpeg",
+		},
+		{
+			name: "transparent bmp",
+			res: result{format: "bmp_pipe", video: []videoStream{{
+				stream: stream{codec: "bmp"},
+				pixfmt: "bgra",
+			}}},
+			expect: "webp",
+		},
+		{
+			name:   "heic",
+			res:    result{format: "mov,mp4,m4a,3gp,3g2,mj2", video: []videoStream{video("hevc", 0)}},
+			expect: "jpeg",
+		},
+		{
+			name:   "h264 mp4",
+			res:    result{format: "mov,mp4,m4a,3gp,3g2,mj2", video: []videoStream{video("h264", 30)}, audio: []audioStream{audio("aac")}, duration: 60},
+			expect: "",
+		},
+		{
+			name:   "hevc mp4",
+			res:    result{format: "mov,mp4,m4a,3gp,3g2,mj2", video: []videoStream{video("hevc", 30)}, audio: []audioStream{audio("aac")}, duration: 60},
+			expect: "mp4",
+		},
+		{
+			name:   "avi",
+			res:    result{format: "avi", video: []videoStream{video("mpeg4", 25)}, audio: []audioStream{audio("mp3")}},
+			expect: "mp4",
+		},
+		{
+			name:   "mkv",
+			res:    result{format: "matroska,webm", video: []videoStream{video("h264", 25)}, audio: []audioStream{audio("aac")}},
+			expect: "mp4",
+		},
+		{
+			name:   "webm",
+			res:    result{format: "matroska,webm", video: []videoStream{video("vp9", 25)}, audio: []audioStream{audio("opus")}},
+			expect: "",
+		},
+		{
+			name:   "mp3",
+			res:    result{format: "mp3", audio: []audioStream{audio("mp3")}},
+			expect: "",
+		},
+		{
+			name:   "vorbis",
+			res:    result{format: "ogg", audio: []audioStream{audio("vorbis")}},
+			expect: "",
+		},
+		{
+			name:   "flac",
+			res:    result{format: "flac", audio: []audioStream{audio("flac")}},
+			expect: "opus",
+		},
+		{
+			name:   "wav",
+			res:    result{format: "wav", audio: []audioStream{audio("pcm_s16le")}},
+			expect: "opus",
+		},
+		{
+			name:   "nothing",
+			res:    result{format: "tty"},
+			expect: "",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			typ, ext := test.res.GetFileType()
+			if format := test.res.TranscodeFormat(typ, ext); format != test.expect {
+				t.Errorf("expected transcode format %q, got %q", test.expect, format)
+			}
+		})
+	}
+}
@@ -29,8 +29,35 @@ const (
 	SizeSmall    Size = "small"    // SizeSmall is the key for small/thumbnail versions of media
 	SizeOriginal Size = "original" // SizeOriginal is the key for original/fullsize versions of media and emoji
 	SizeStatic   Size = "static"   // SizeStatic is the key for static (non-animated) versions of emoji
+	SizeW320     Size = "w320"     // SizeW320 is the key for 320px wide thumbnail variants of media
+	SizeW640     Size = "w640"     // SizeW640 is the key for 640px wide thumbnail variants of media
+	SizeW1280    Size = "w1280"    // SizeW1280 is the key for 1280px wide thumbnail variants of media
 )
 
+// thumbVariantSizes contains the size keys and
+// widths of additional thumbnail variants that
+// are generated for responsive display of media.
+var thumbVariantSizes = []struct {
+	size  Size
+	width int
+}{
+	{size: SizeW320, width: 320},
+	{size: SizeW640, width: 640},
+	{size: SizeW1280, width: 1280},
+}
+
+// ThumbVariantWidth returns the width in pixels of
+// thumbnail variants with the given size key, or zero
+// if the size is not that of a thumbnail variant.
+func ThumbVariantWidth(size Size) int {
+	for _, variant := range thumbVariantSizes {
+		if variant.size == size {
+			return variant.width
+		}
+	}
+	return 0
+}
+
 type Type string
 
 const (
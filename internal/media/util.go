@@ -19,6 +19,8 @@ package media
 
 import (
 	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -152,6 +154,27 @@ func drainToTmp(rc io.ReadCloser) (string, error) {
 	return path, nil
 }
 
+// Hash returns the hex-encoded SHA-256 hash of all
+// data read from r, i.e. the form of media file hash
+// used to find identical files to share in storage.
+func Hash(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashFile returns the media file hash of file at path.
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	return Hash(file)
+}
+
 // remove only removes paths if not-empty.
 func remove(paths ...string) error {
 	var errs []error
@@ -25,7 +25,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
-	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/technologize/otel-go-contrib/otelginmetrics"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/extra/bunotel"
@@ -41,7 +41,7 @@ const (
 	serviceName = "GoToSocial"
 )
 
-func Initialize(db db.DB) error {
+func Initialize(state *state.State) error {
 	if !config.GetMetricsEnabled() {
 		return nil
 	}
@@ -80,7 +80,7 @@ func Initialize(db db.DB) error {
 		"gotosocial.instance.total_users",
 		metric.WithDescription("Total number of users on this instance"),
 		metric.WithInt64Callback(func(c context.Context, o metric.Int64Observer) error {
-			userCount, err := db.CountInstanceUsers(c, thisInstance)
+			userCount, err := state.DB.CountInstanceUsers(c, thisInstance)
 			if err != nil {
 				return err
 			}
@@ -96,7 +96,7 @@ func Initialize(db db.DB) error {
 		"gotosocial.instance.total_statuses",
 		metric.WithDescription("Total number of statuses on this instance"),
 		metric.WithInt64Callback(func(c context.Context, o metric.Int64Observer) error {
-			statusCount, err := db.CountInstanceStatuses(c, thisInstance)
+			statusCount, err := state.DB.CountInstanceStatuses(c, thisInstance)
 			if err != nil {
 				return err
 			}
@@ -112,7 +112,7 @@ func Initialize(db db.DB) error {
 		"gotosocial.instance.total_federating_instances",
 		metric.WithDescription("Total number of other instances this instance is federating with"),
 		metric.WithInt64Callback(func(c context.Context, o metric.Int64Observer) error {
-			federatingCount, err := db.CountInstanceDomains(c, thisInstance)
+			federatingCount, err := state.DB.CountInstanceDomains(c, thisInstance)
 			if err != nil {
 				return err
 			}
@@ -124,6 +124,32 @@ func Initialize(db db.DB) error {
 		return err
 	}
 
+	_, err = meter.Int64ObservableGauge(
+		"gotosocial.delivery.failing_instances",
+		metric.WithDescription("Number of instances that deliveries are currently failing to, but which are not yet marked unavailable"),
+		metric.WithInt64Callback(func(c context.Context, o metric.Int64Observer) error {
+			failing, _ := state.Workers.Delivery.Health.Counts()
+			o.Observe(int64(failing))
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.Int64ObservableGauge(
+		"gotosocial.delivery.unavailable_instances",
+		metric.WithDescription("Number of instances marked unavailable, that deliveries are being skipped to"),
+		metric.WithInt64Callback(func(c context.Context, o metric.Int64Observer) error {
+			_, unavailable := state.Workers.Delivery.Health.Counts()
+			o.Observe(int64(unavailable))
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
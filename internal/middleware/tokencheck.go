@@ -18,7 +18,9 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -65,6 +67,10 @@ func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.
 		}
 		c.Set(oauth.SessionAuthorizedToken, ti)
 
+		// Keep track of when the token was last used,
+		// so users can see (and revoke) stale sessions.
+		updateTokenLastUsed(ctx, dbConn, ti.GetAccess())
+
 		// check for user-level token
 		if userID := ti.GetUserID(); userID != "" {
 			log.Tracef(ctx, "authenticated user %s with bearer token, scope is %s", userID, ti.GetScope())
@@ -138,3 +144,35 @@ func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.
 		}
 	}
 }
+
+// tokenLastUsedPrecision is how often the last used time of
+// a token will be updated; we don't want to write to the db
+// for every single request made with a token, so it's just
+// an approximation.
+const tokenLastUsedPrecision = time.Hour
+
+// updateTokenLastUsed sets the last used time of the token
+// with the given access code to now, if it hasn't been set
+// within tokenLastUsedPrecision. Errors are just logged, as
+// this isn't important enough to fail the request over.
+func updateTokenLastUsed(ctx context.Context, dbConn db.DB, access string) {
+	if access == "" {
+		return
+	}
+
+	token, err := dbConn.GetTokenByAccess(ctx, access)
+	if err != nil {
+		log.Errorf(ctx, "database error getting token: %v", err)
+		return
+	}
+
+	if time.Since(token.LastUsed) < tokenLastUsedPrecision {
+		// Recent enough.
+		return
+	}
+
+	token.LastUsed = time.Now()
+	if err := dbConn.UpdateToken(ctx, token, "last_used"); err != nil {
+		log.Errorf(ctx, "database error updating token last used: %v", err)
+	}
+}
@@ -0,0 +1,655 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+const (
+	// How long a built archive is
+	// kept available for download.
+	archiveExpiry = 7 * 24 * time.Hour
+
+	// How long an account has to wait
+	// between requesting new archives.
+	archiveCooldown = 24 * time.Hour
+
+	// Page size used when
+	// selecting archive statuses.
+	archivePageSize = 100
+
+	// JSON-LD context of archive collections.
+	archiveContext = "https://www.w3.org/ns/activitystreams"
+)
+
+// archivePath returns the storage path
+// for an account archive's zip file.
+func archivePath(accountID string, archiveID string) string {
+	return accountID + "/archive/original/" + archiveID + ".zip"
+}
+
+// archiveMediaPath returns the path inside an
+// archive zip file for the given media attachment,
+// following the layout used by Mastodon archives.
+// (the file name is built from the attachment's ID,
+// as stored file paths may be shared between media).
+func archiveMediaPath(attachment *gtsmodel.MediaAttachment) string {
+	return "media_attachments/files/" + attachment.ID + "/original/" + attachment.ID + path.Ext(attachment.File.Path)
+}
+
+// ArchiveCreate requests a new archive of all of requester's
+// data, which will be built asynchronously, and can then be
+// downloaded until it expires. Any previous archives of
+// requester are removed when a new one is requested.
+func (p *Processor) ArchiveCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) (*apimodel.AccountArchive, gtserror.WithCode) {
+	prevArchives, err := p.state.DB.GetAccountArchivesByAccountID(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting archives: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, prev := range prevArchives {
+		if prev.State == gtsmodel.AccountArchiveStateProcessing {
+			const text = "an archive is already being built"
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+		}
+
+		if prev.State == gtsmodel.AccountArchiveStateReady &&
+			time.Since(prev.CreatedAt) < archiveCooldown {
+			text := fmt.Sprintf("a new archive can only be requested once every %s", archiveCooldown)
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+	}
+
+	// Clear out previous archives,
+	// they're superseded by this one.
+	for _, prev := range prevArchives {
+		if err := p.removeArchive(ctx, prev); err != nil {
+			err := gtserror.Newf("error removing previous archive: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	archive := &gtsmodel.AccountArchive{
+		ID:        id.NewULID(),
+		AccountID: requester.ID,
+		Account:   requester,
+		State:     gtsmodel.AccountArchiveStateProcessing,
+	}
+
+	if err := p.state.DB.PutAccountArchive(ctx, archive); err != nil {
+		err := gtserror.Newf("db error putting archive: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Build the archive asynchronously.
+	p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+		p.buildArchive(ctx, archive.ID)
+	})
+
+	apiArchive, err := p.converter.AccountArchiveToAPIAccountArchive(ctx, archive)
+	if err != nil {
+		err := gtserror.Newf("error converting archive: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiArchive, nil
+}
+
+// ArchivesGet returns the archives of requester, newest first.
+func (p *Processor) ArchivesGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([]*apimodel.AccountArchive, gtserror.WithCode) {
+	archives, err := p.state.DB.GetAccountArchivesByAccountID(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting archives: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiArchives := make([]*apimodel.AccountArchive, 0, len(archives))
+	for _, archive := range archives {
+		apiArchive, err := p.converter.AccountArchiveToAPIAccountArchive(ctx, archive)
+		if err != nil {
+			err := gtserror.Newf("error converting archive: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiArchives = append(apiArchives, apiArchive)
+	}
+
+	return apiArchives, nil
+}
+
+// ArchiveFileGet returns the zip file content of
+// requester's archive with the given ID, if ready.
+func (p *Processor) ArchiveFileGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	archiveID string,
+) (*apimodel.Content, gtserror.WithCode) {
+	archive, err := p.state.DB.GetAccountArchiveByID(ctx, archiveID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting archive: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if archive == nil || archive.AccountID != requester.ID {
+		err := fmt.Errorf("archive %s not found", archiveID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if archive.State != gtsmodel.AccountArchiveStateReady {
+		const text = "archive not ready for download"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	content := &apimodel.Content{
+		ContentType:    "application/zip",
+		ContentLength:  archive.Size,
+		ContentUpdated: archive.UpdatedAt,
+	}
+
+	// If storage can serve
+	// directly, use that URL.
+	if url := p.state.Storage.URL(ctx, archive.Path); url != nil {
+		content.URL = url
+		return content, nil
+	}
+
+	content.Content, err = p.state.Storage.GetStream(ctx, archive.Path)
+	if err != nil {
+		err := gtserror.Newf("error getting archive from storage: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return content, nil
+}
+
+// ScheduleArchives restarts building any archives that were
+// interrupted by a shutdown, and schedules the removal of
+// all other archives from the database at their expiry.
+func (p *Processor) ScheduleArchives(ctx context.Context) error {
+	archives, err := p.state.DB.GetAccountArchives(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting archives: %w", err)
+	}
+
+	for _, archive := range archives {
+		if archive.State == gtsmodel.AccountArchiveStateProcessing {
+			p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+				p.buildArchive(ctx, archive.ID)
+			})
+			continue
+		}
+
+		p.scheduleArchiveExpiry(ctx, archive)
+	}
+
+	return nil
+}
+
+// scheduleArchiveExpiry schedules removal
+// of the given archive at its expiry time.
+func (p *Processor) scheduleArchiveExpiry(ctx context.Context, archive *gtsmodel.AccountArchive) {
+	if !p.state.Workers.Scheduler.AddOnce(
+		archive.ID,
+		archive.ExpiresAt,
+		func(ctx context.Context, _ time.Time) {
+			if err := p.removeArchive(ctx, archive); err != nil {
+				log.Errorf(ctx, "error removing expired archive %s: %v", archive.ID, err)
+			}
+		},
+	) {
+		log.Errorf(ctx, "failed scheduling expiry of archive %s", archive.ID)
+	}
+}
+
+// removeArchive removes the given archive,
+// and its zip file (if any) from storage.
+func (p *Processor) removeArchive(ctx context.Context, archive *gtsmodel.AccountArchive) error {
+	// Cancel any scheduled expiry.
+	p.state.Workers.Scheduler.Cancel(archive.ID)
+
+	if archive.Path != "" {
+		err := p.state.Storage.Delete(ctx, archive.Path)
+		if err != nil && !storage.IsNotFound(err) {
+			return gtserror.Newf("error deleting archive from storage: %w", err)
+		}
+	}
+
+	if err := p.state.DB.DeleteAccountArchiveByID(ctx, archive.ID); err != nil {
+		return gtserror.Newf("db error deleting archive: %w", err)
+	}
+
+	return nil
+}
+
+// buildArchive builds the zip file of the account archive with
+// given ID, and puts it in storage, marking the archive ready.
+func (p *Processor) buildArchive(ctx context.Context, archiveID string) {
+	l := log.WithContext(ctx).WithField("archive", archiveID)
+
+	archive, err := p.state.DB.GetAccountArchiveByID(ctx, archiveID)
+	if err != nil {
+		// Archive was probably removed
+		// in the meantime, nothing to do.
+		l.Errorf("db error getting archive: %v", err)
+		return
+	}
+
+	account, err := p.state.DB.GetAccountByID(ctx, archive.AccountID)
+	if err != nil {
+		l.Errorf("db error getting archive account: %v", err)
+		return
+	}
+
+	l.Info("building archive")
+
+	size, err := p.writeArchiveFile(ctx, account, archive)
+	if ctx.Err() != nil {
+		// We're being stopped, so leave
+		// the archive processing, to be
+		// built again on next startup.
+		l.Info("archive build interrupted")
+		return
+	}
+
+	if err != nil {
+		l.Errorf("error building archive: %v", err)
+		archive.State = gtsmodel.AccountArchiveStateFailed
+	} else {
+		l.Info("archive ready")
+		archive.State = gtsmodel.AccountArchiveStateReady
+		archive.Size = size
+	}
+
+	archive.ExpiresAt = time.Now().Add(archiveExpiry)
+	if err := p.state.DB.UpdateAccountArchive(ctx,
+		archive,
+		"state",
+		"path",
+		"size",
+		"expires_at",
+	); err != nil {
+		l.Errorf("db error updating archive: %v", err)
+		return
+	}
+
+	p.scheduleArchiveExpiry(ctx, archive)
+}
+
+// writeArchiveFile writes the given account's archive to a temporary
+// zip file, then moves it into storage, returning the stored size.
+func (p *Processor) writeArchiveFile(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	archive *gtsmodel.AccountArchive,
+) (int64, error) {
+	file, err := os.CreateTemp("", "gotosocial-archive-*.zip")
+	if err != nil {
+		return 0, gtserror.Newf("error creating temp file: %w", err)
+	}
+
+	defer func() {
+		// Always clean up temp file.
+		if err := os.Remove(file.Name()); err != nil {
+			log.Errorf(ctx, "error removing temp file: %v", err)
+		}
+	}()
+
+	// Write zip archive to file.
+	err = p.writeArchive(ctx, account, file)
+	if e := file.Close(); e != nil && err == nil {
+		err = gtserror.Newf("error closing temp file: %w", e)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	archive.Path = archivePath(account.ID, archive.ID)
+	return p.state.Storage.PutFile(ctx,
+		archive.Path,
+		file.Name(),
+		"application/zip",
+	)
+}
+
+// writeArchive writes an archive of all of the given account's
+// data to w, as a zip file in Mastodon-compatible layout:
+//
+//   - actor.json: the account as an ActivityPub actor.
+//   - outbox.json: all statuses of the account, as Create / Announce activities.
+//   - likes.json: URIs of all statuses liked by the account.
+//   - bookmarks.json: URIs of all statuses bookmarked by the account.
+//   - avatar / header image, and all media attached to statuses.
+func (p *Processor) writeArchive(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	w io.Writer,
+) error {
+	zw := zip.NewWriter(w)
+
+	if err := p.writeArchiveActor(ctx, zw, account); err != nil {
+		return err
+	}
+
+	if err := p.writeArchiveOutbox(ctx, zw, account); err != nil {
+		return err
+	}
+
+	if err := p.writeArchiveLikes(ctx, zw, account); err != nil {
+		return err
+	}
+
+	if err := p.writeArchiveBookmarks(ctx, zw, account); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+func (p *Processor) writeArchiveActor(
+	ctx context.Context,
+	zw *zip.Writer,
+	account *gtsmodel.Account,
+) error {
+	person, err := p.converter.AccountToAS(ctx, account)
+	if err != nil {
+		return gtserror.Newf("error converting account: %w", err)
+	}
+
+	actor, err := ap.Serialize(person)
+	if err != nil {
+		return gtserror.Newf("error serializing account: %w", err)
+	}
+
+	// Point avatar + header at files in the archive.
+	for _, image := range []struct {
+		key        string
+		name       string
+		attachment *gtsmodel.MediaAttachment
+	}{
+		{"icon", "avatar", account.AvatarMediaAttachment},
+		{"image", "header", account.HeaderMediaAttachment},
+	} {
+		if image.attachment == nil {
+			continue
+		}
+
+		name := image.name + path.Ext(image.attachment.File.Path)
+		if err := p.writeArchiveMedia(ctx, zw, name, image.attachment); err != nil {
+			return err
+		}
+
+		actor[image.key] = map[string]any{
+			"type":      "Image",
+			"mediaType": image.attachment.File.ContentType,
+			"url":       name,
+		}
+	}
+
+	return writeArchiveJSON(zw, "actor.json", actor)
+}
+
+func (p *Processor) writeArchiveOutbox(
+	ctx context.Context,
+	zw *zip.Writer,
+	account *gtsmodel.Account,
+) error {
+	var (
+		items []any
+		minID = id.Lowest
+	)
+
+	for {
+		// Page up through statuses, oldest first.
+		statuses, err := p.state.DB.GetAccountStatuses(ctx,
+			account.ID,
+			archivePageSize,
+			false, // include replies
+			false, // include boosts
+			"",
+			minID,
+			false, // not media only
+			false, // not public only
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting statuses: %w", err)
+		}
+
+		if len(statuses) == 0 {
+			break
+		}
+
+		// Statuses are returned newest
+		// first, so iterate backwards.
+		for i := len(statuses) - 1; i >= 0; i-- {
+			item, err := p.archiveOutboxItem(ctx, zw, account, statuses[i])
+			if err != nil {
+				return err
+			}
+			items = append(items, item)
+		}
+
+		minID = statuses[0].ID
+	}
+
+	return writeArchiveJSON(zw, "outbox.json", map[string]any{
+		"@context":     archiveContext,
+		"id":           uris.GenerateURIsForAccount(account.Username).OutboxURI,
+		"type":         ap.ObjectOrderedCollection,
+		"totalItems":   len(items),
+		"orderedItems": items,
+	})
+}
+
+// archiveOutboxItem serializes the given status of account as an
+// outbox item, writing any attached media into the archive.
+func (p *Processor) archiveOutboxItem(
+	ctx context.Context,
+	zw *zip.Writer,
+	account *gtsmodel.Account,
+	status *gtsmodel.Status,
+) (map[string]any, error) {
+	if status.BoostOfID != "" {
+		announce, err := p.converter.BoostToAS(ctx, status, account, status.BoostOfAccount)
+		if err != nil {
+			return nil, gtserror.Newf("error converting boost %s: %w", status.ID, err)
+		}
+
+		item, err := ap.Serialize(announce)
+		if err != nil {
+			return nil, gtserror.Newf("error serializing boost %s: %w", status.ID, err)
+		}
+
+		return item, nil
+	}
+
+	statusable, err := p.converter.StatusToAS(ctx, status)
+	if err != nil {
+		return nil, gtserror.Newf("error converting status %s: %w", status.ID, err)
+	}
+
+	item, err := ap.Serialize(typeutils.WrapStatusableInCreate(statusable, false))
+	if err != nil {
+		return nil, gtserror.Newf("error serializing status %s: %w", status.ID, err)
+	}
+
+	// Write media into the archive, keeping
+	// track of the paths we wrote them to.
+	mediaPaths := make(map[string]string, len(status.Attachments))
+	for _, attachment := range status.Attachments {
+		name := archiveMediaPath(attachment)
+		if err := p.writeArchiveMedia(ctx, zw, name, attachment); err != nil {
+			return nil, err
+		}
+		mediaPaths[attachment.URL] = "/" + name
+	}
+
+	// Point attachments at the archived media.
+	if object, ok := item["object"].(map[string]any); ok {
+		var attachments []any
+		switch a := object["attachment"].(type) {
+		case []any:
+			attachments = a
+		case map[string]any:
+			attachments = []any{a}
+		}
+
+		for _, a := range attachments {
+			a, ok := a.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			url, _ := a["url"].(string)
+			if mediaPath, ok := mediaPaths[url]; ok {
+				a["url"] = mediaPath
+			}
+		}
+	}
+
+	return item, nil
+}
+
+func (p *Processor) writeArchiveLikes(
+	ctx context.Context,
+	zw *zip.Writer,
+	account *gtsmodel.Account,
+) error {
+	// Get all faved statuses
+	// using a limit of 0.
+	statuses, _, _, err := p.state.DB.GetFavedTimeline(ctx, account.ID, "", "", 0)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting faves: %w", err)
+	}
+
+	statusURIs := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		statusURIs = append(statusURIs, status.URI)
+	}
+
+	return writeArchiveJSON(zw, "likes.json", map[string]any{
+		"@context":     archiveContext,
+		"id":           "likes.json",
+		"type":         ap.ObjectOrderedCollection,
+		"orderedItems": statusURIs,
+	})
+}
+
+func (p *Processor) writeArchiveBookmarks(
+	ctx context.Context,
+	zw *zip.Writer,
+	account *gtsmodel.Account,
+) error {
+	// Get all bookmarks
+	// using a limit of 0.
+	bookmarks, err := p.state.DB.GetStatusBookmarks(ctx, account.ID, 0, "", "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting bookmarks: %w", err)
+	}
+
+	statusURIs := make([]string, 0, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		if bookmark.Status != nil {
+			statusURIs = append(statusURIs, bookmark.Status.URI)
+		}
+	}
+
+	return writeArchiveJSON(zw, "bookmarks.json", map[string]any{
+		"@context":     archiveContext,
+		"id":           "bookmarks.json",
+		"type":         ap.ObjectOrderedCollection,
+		"orderedItems": statusURIs,
+	})
+}
+
+// writeArchiveMedia copies the original file of
+// the given attachment from storage into the archive.
+func (p *Processor) writeArchiveMedia(
+	ctx context.Context,
+	zw *zip.Writer,
+	name string,
+	attachment *gtsmodel.MediaAttachment,
+) error {
+	if attachment.File.Path == "" {
+		// Media not stored
+		// locally, skip it.
+		return nil
+	}
+
+	rc, err := p.state.Storage.GetStream(ctx, attachment.File.Path)
+	if err != nil {
+		if storage.IsNotFound(err) {
+			// Missing from storage,
+			// just skip this one.
+			return nil
+		}
+		return gtserror.Newf("error getting media %s from storage: %w", attachment.ID, err)
+	}
+	defer rc.Close()
+
+	w, err := zw.Create(name)
+	if err != nil {
+		return gtserror.Newf("error creating %s in archive: %w", name, err)
+	}
+
+	if _, err := io.Copy(w, rc); err != nil {
+		return gtserror.Newf("error writing %s to archive: %w", name, err)
+	}
+
+	return nil
+}
+
+// writeArchiveJSON writes v as
+// JSON to archive file with name.
+func writeArchiveJSON(zw *zip.Writer, name string, v any) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return gtserror.Newf("error creating %s in archive: %w", name, err)
+	}
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		return gtserror.Newf("error writing %s to archive: %w", name, err)
+	}
+
+	return nil
+}
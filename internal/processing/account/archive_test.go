@@ -0,0 +1,386 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type ArchiveTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *ArchiveTestSuite) TestArchiveCreate() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+	)
+
+	apiArchive, errWithCode := suite.accountProcessor.ArchiveCreate(ctx, testAccount)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("processing", apiArchive.State)
+	suite.Nil(apiArchive.URL)
+
+	// Another archive can't be
+	// requested while processing.
+	_, errWithCode = suite.accountProcessor.ArchiveCreate(ctx, testAccount)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// Run the queued build.
+	fn, ok := suite.state.Workers.Processing.Queue.Pop()
+	if !ok {
+		suite.FailNow("expected archive build to be queued")
+	}
+	fn(ctx)
+
+	archive, err := suite.state.DB.GetAccountArchiveByID(ctx, apiArchive.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.AccountArchiveStateReady, archive.State)
+	suite.NotZero(archive.Size)
+	suite.WithinDuration(time.Now().Add(7*24*time.Hour), archive.ExpiresAt, time.Minute)
+
+	// Download the built archive.
+	content, errWithCode := suite.accountProcessor.ArchiveFileGet(ctx, testAccount, archive.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	b, err := io.ReadAll(content.Content)
+	content.Content.Close()
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(archive.Size, int64(len(b)))
+
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	files := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	for _, name := range []string{
+		"actor.json",
+		"outbox.json",
+		"likes.json",
+		"bookmarks.json",
+	} {
+		suite.Contains(files, name)
+	}
+
+	// Outbox should contain every status
+	// by the account, and all attachments
+	// should be included in the archive.
+	rc, err := files["outbox.json"].Open()
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	var outbox struct {
+		TotalItems   int `json:"totalItems"`
+		OrderedItems []struct {
+			Type   string `json:"type"`
+			Object any    `json:"object"`
+		} `json:"orderedItems"`
+	}
+	err = json.NewDecoder(rc).Decode(&outbox)
+	rc.Close()
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statuses, err := suite.state.DB.GetAccountStatuses(ctx, testAccount.ID, 0, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(len(statuses), outbox.TotalItems)
+	suite.Len(outbox.OrderedItems, len(statuses))
+
+	for _, status := range statuses {
+		for _, attachmentID := range status.AttachmentIDs {
+			attachment, err := suite.state.DB.GetAttachmentByID(ctx, attachmentID)
+			if err != nil {
+				suite.FailNow(err.Error())
+			}
+			suite.Contains(files, "media_attachments/files/"+attachment.ID+"/original/"+path.Base(attachment.File.Path))
+		}
+	}
+
+	// A new archive can't be requested
+	// right after one has been built.
+	_, errWithCode = suite.accountProcessor.ArchiveCreate(ctx, testAccount)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Only the owner can download.
+	_, errWithCode = suite.accountProcessor.ArchiveFileGet(ctx, suite.testAccounts["local_account_2"], archive.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *ArchiveTestSuite) TestArchiveImport() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_2"]
+		actorID     = "https://mastodon.example.org/users/someone"
+		public      = []string{"https://www.w3.org/ns/activitystreams#Public"}
+		followers   = []string{actorID + "/followers"}
+	)
+
+	// Build a minimal archive with a
+	// public post, a reply to it, a
+	// direct message, and a boost.
+	note := func(id string, published string, inReplyTo any, to []string, cc []string, content string) map[string]any {
+		return map[string]any{
+			"type": "Create",
+			"object": map[string]any{
+				"id":           actorID + "/statuses/" + id,
+				"type":         "Note",
+				"attributedTo": actorID,
+				"published":    published,
+				"inReplyTo":    inReplyTo,
+				"content":      content,
+				"contentMap":   map[string]string{"en": content},
+				"to":           to,
+				"cc":           cc,
+			},
+		}
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, v := range map[string]any{
+		"actor.json": map[string]any{
+			"id":   actorID,
+			"type": "Person",
+		},
+		"outbox.json": map[string]any{
+			"type": "OrderedCollection",
+			"orderedItems": []any{
+				note("1", "2020-01-01T10:00:00Z", nil, public, followers, "<p>hello world</p>"),
+				note("2", "2020-01-01T11:00:00Z", actorID+"/statuses/1", public, followers, "<p>replying to myself</p>"),
+				note("3", "2020-01-01T12:00:00Z", nil, []string{"https://mastodon.example.org/users/someone_else"}, nil, "<p>secret</p>"),
+				map[string]any{
+					"type":   "Announce",
+					"object": "https://mastodon.example.org/users/someone_else/statuses/4",
+				},
+			},
+		},
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		if err := json.NewEncoder(w).Encode(v); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+	if err := zw.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Persist an uploaded archive
+	// import, as if interrupted.
+	const archivePath = "import/archive.zip"
+	if _, err := suite.state.Storage.Put(ctx, archivePath, buf.Bytes()); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	taskData, err := json.Marshal(map[string]any{
+		"account_id": testAccount.ID,
+		"type":       "archive",
+		"path":       archivePath,
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.state.DB.PutWorkerTasks(ctx, []*gtsmodel.WorkerTask{{
+		WorkerType: gtsmodel.ImportWorker,
+		TaskData:   taskData,
+		CreatedAt:  time.Now(),
+	}}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	before, err := suite.state.DB.GetAccountStatuses(ctx, testAccount.ID, 0, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Resume the import, and run
+	// the queued processing fn.
+	if err := suite.accountProcessor.ResumeImports(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	fn, ok := suite.state.Workers.Processing.Queue.Pop()
+	if !ok {
+		suite.FailNow("expected import to be queued")
+	}
+	fn(ctx)
+
+	// Only the post and
+	// reply were imported.
+	after, err := suite.state.DB.GetAccountStatuses(ctx, testAccount.ID, 0, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(after, len(before)+2)
+
+	var post, reply *gtsmodel.Status
+	for _, status := range after {
+		switch status.Content {
+		case "<p>hello world</p>":
+			post = status
+		case "<p>replying to myself</p>":
+			reply = status
+		}
+	}
+	if post == nil || reply == nil {
+		suite.FailNow("imported statuses not found")
+	}
+
+	suite.Equal(gtsmodel.VisibilityPublic, post.Visibility)
+	suite.Equal(time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC), post.CreatedAt.UTC())
+	suite.Equal("en", post.Language)
+	suite.Equal(post.ID, reply.InReplyToID)
+	suite.Equal(post.ThreadID, reply.ThreadID)
+
+	// Uploaded archive should be
+	// removed from storage when done.
+	_, err = suite.state.Storage.Get(ctx, archivePath)
+	suite.Error(err)
+}
+
+func (suite *ArchiveTestSuite) TestArchiveImportTooLarge() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_2"]
+		actorID     = "https://mastodon.example.org/users/someone"
+	)
+
+	// Only allow JSON files
+	// smaller than the outbox.
+	config.SetAccountsArchiveImportMaxJSONSize(128)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, v := range map[string]any{
+		"actor.json": map[string]any{
+			"id":   actorID,
+			"type": "Person",
+		},
+		"outbox.json": map[string]any{
+			"type": "OrderedCollection",
+			"orderedItems": []any{
+				map[string]any{
+					"type": "Create",
+					"object": map[string]any{
+						"id":           actorID + "/statuses/1",
+						"type":         "Note",
+						"attributedTo": actorID,
+						"published":    "2020-01-01T10:00:00Z",
+						"content":      "<p>hello world</p>",
+						"to":           []string{"https://www.w3.org/ns/activitystreams#Public"},
+					},
+				},
+			},
+		},
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		if err := json.NewEncoder(w).Encode(v); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+	if err := zw.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	const archivePath = "import/archive.zip"
+	if _, err := suite.state.Storage.Put(ctx, archivePath, buf.Bytes()); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	taskData, err := json.Marshal(map[string]any{
+		"account_id": testAccount.ID,
+		"type":       "archive",
+		"path":       archivePath,
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.state.DB.PutWorkerTasks(ctx, []*gtsmodel.WorkerTask{{
+		WorkerType: gtsmodel.ImportWorker,
+		TaskData:   taskData,
+		CreatedAt:  time.Now(),
+	}}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	before, err := suite.state.DB.GetAccountStatuses(ctx, testAccount.ID, 0, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.accountProcessor.ResumeImports(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	fn, ok := suite.state.Workers.Processing.Queue.Pop()
+	if !ok {
+		suite.FailNow("expected import to be queued")
+	}
+	fn(ctx)
+
+	// Nothing should have been imported,
+	// and the failed import cleaned up.
+	after, err := suite.state.DB.GetAccountStatuses(ctx, testAccount.ID, 0, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(after, len(before))
+
+	tasks, err := suite.state.DB.GetWorkerTasks(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(tasks)
+}
+
+func TestArchiveTestSuite(t *testing.T) {
+	suite.Run(t, new(ArchiveTestSuite))
+}
@@ -0,0 +1,561 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"codeberg.org/gruf/go-iotools"
+	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// archiveActor models the parts of
+// actor.json in an archive that we use.
+type archiveActor struct {
+	ID string `json:"id"`
+}
+
+// archiveOutbox models the parts of
+// outbox.json in an archive that we use.
+type archiveOutbox struct {
+	OrderedItems []json.RawMessage `json:"orderedItems"`
+}
+
+// archiveActivity models the parts of an
+// activity in an archive outbox that we use.
+type archiveActivity struct {
+	Type   string          `json:"type"`
+	Object json.RawMessage `json:"object"`
+}
+
+// archiveNote models the parts of a Note
+// in an archive outbox that we use.
+type archiveNote struct {
+	ID           string              `json:"id"`
+	Type         string              `json:"type"`
+	AttributedTo string              `json:"attributedTo"`
+	Published    time.Time           `json:"published"`
+	InReplyTo    string              `json:"inReplyTo"`
+	Summary      string              `json:"summary"`
+	Content      string              `json:"content"`
+	ContentMap   map[string]string   `json:"contentMap"`
+	Sensitive    bool                `json:"sensitive"`
+	To           archiveIRIs         `json:"to"`
+	Cc           archiveIRIs         `json:"cc"`
+	Attachment   []archiveAttachment `json:"attachment"`
+}
+
+// archiveAttachment models the parts of a
+// Note attachment in an archive that we use.
+type archiveAttachment struct {
+	URL        string    `json:"url"`
+	Name       string    `json:"name"`
+	Blurhash   string    `json:"blurhash"`
+	FocalPoint []float32 `json:"focalPoint"`
+}
+
+// archiveIRIs is a list of IRIs, which
+// may be serialized as a single string.
+type archiveIRIs []string
+
+func (a *archiveIRIs) UnmarshalJSON(b []byte) error {
+	var iri string
+	if err := json.Unmarshal(b, &iri); err == nil {
+		*a = archiveIRIs{iri}
+		return nil
+	}
+	return json.Unmarshal(b, (*[]string)(a))
+}
+
+// importArchiveData stores the given Mastodon-compatible
+// archive zip file, and starts importing statuses from it.
+func (p *Processor) importArchiveData(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	data *multipart.FileHeader,
+	overwrite bool,
+) gtserror.WithCode {
+	if overwrite {
+		const text = "overwrite mode not supported for archive imports"
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	file, err := data.Open()
+	if err != nil {
+		err := fmt.Errorf("error opening archive file: %w", err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer file.Close()
+
+	// Check it's a readable zip
+	// before we go any further.
+	if _, err := zip.NewReader(file, data.Size); err != nil {
+		err := fmt.Errorf("error reading archive file: %w", err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Copy the upload to a temp file, and from
+	// there into storage, so that it's still
+	// available if the import gets resumed.
+	tmp, err := os.CreateTemp("", "gotosocial-import-*.zip")
+	if err != nil {
+		err := gtserror.Newf("error creating temp file: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	defer func() {
+		// Always clean up temp file.
+		if err := os.Remove(tmp.Name()); err != nil {
+			log.Errorf(ctx, "error removing temp file: %v", err)
+		}
+	}()
+
+	_, err = io.Copy(tmp, io.NewSectionReader(file, 0, data.Size))
+	if e := tmp.Close(); e != nil && err == nil {
+		err = e
+	}
+	if err != nil {
+		err := gtserror.Newf("error writing temp file: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	storagePath := archivePath(requester.ID, id.NewULID())
+	if _, err := p.state.Storage.PutFile(ctx, storagePath, tmp.Name(), "application/zip"); err != nil {
+		err := gtserror.Newf("error storing archive file: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return p.startImport(ctx, &importTask{
+		AccountID: requester.ID,
+		Type:      "archive",
+		Path:      storagePath,
+	})
+}
+
+// importArchive imports statuses from the outbox of
+// the stored archive of the given import task, as
+// backdated local statuses by the requester.
+//
+// Only statuses authored by the archive's actor are
+// imported, and only if they're not direct messages,
+// and don't reply to statuses not in the archive.
+// Imported statuses are not federated.
+func (p *Processor) importArchive(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	data *importTask,
+	progress func(),
+) error {
+	// Fetch the stored archive into a temp
+	// file, since we need random access.
+	tmp, err := os.CreateTemp("", "gotosocial-import-*.zip")
+	if err != nil {
+		return gtserror.Newf("error creating temp file: %w", err)
+	}
+
+	defer func() {
+		// Always clean up temp file.
+		if err := os.Remove(tmp.Name()); err != nil {
+			log.Errorf(ctx, "error removing temp file: %v", err)
+		}
+	}()
+
+	rc, err := p.state.Storage.GetStream(ctx, data.Path)
+	if err != nil {
+		tmp.Close()
+		return gtserror.Newf("error getting archive from storage: %w", err)
+	}
+
+	_, err = io.Copy(tmp, rc)
+	rc.Close()
+	tmp.Close()
+	if err != nil {
+		return gtserror.Newf("error writing temp file: %w", err)
+	}
+
+	zr, err := zip.OpenReader(tmp.Name())
+	if err != nil {
+		return fmt.Errorf("error opening archive: %w", err)
+	}
+	defer zr.Close()
+
+	var actor archiveActor
+	if err := readArchiveJSON(&zr.Reader, "actor.json", &actor); err != nil {
+		return err
+	}
+
+	var outbox archiveOutbox
+	if err := readArchiveJSON(&zr.Reader, "outbox.json", &outbox); err != nil {
+		return err
+	}
+
+	if data.Imported == nil {
+		data.Imported = make(map[string]string)
+	}
+
+	for _, item := range remaining(outbox.OrderedItems, data) {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		note, ok := parseArchiveNote(item, actor.ID)
+		if ok {
+			statusID, err := p.importArchiveNote(ctx,
+				requester,
+				&zr.Reader,
+				note,
+				data.Imported,
+			)
+			if err != nil {
+				log.Errorf(ctx, "could not import status %s: %v", note.ID, err)
+			} else if statusID != "" {
+				data.Imported[note.ID] = statusID
+			}
+		}
+
+		progress()
+	}
+
+	return nil
+}
+
+// parseArchiveNote parses the given archive outbox item, returning
+// the Note it creates, if any, as long as it's by the given actor.
+func parseArchiveNote(item json.RawMessage, actorID string) (*archiveNote, bool) {
+	var activity archiveActivity
+	if err := json.Unmarshal(item, &activity); err != nil {
+		return nil, false
+	}
+
+	if activity.Type != ap.ActivityCreate {
+		// Boosts etc.
+		// not supported.
+		return nil, false
+	}
+
+	var note archiveNote
+	if err := json.Unmarshal(activity.Object, &note); err != nil {
+		return nil, false
+	}
+
+	if note.Type != ap.ObjectNote ||
+		note.ID == "" ||
+		note.AttributedTo != actorID {
+		return nil, false
+	}
+
+	return &note, true
+}
+
+// importArchiveNote creates a local status by requester from the
+// given archive note, returning the ID of the created status, or
+// an empty string if the note shouldn't be imported.
+func (p *Processor) importArchiveNote(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	zr *zip.Reader,
+	note *archiveNote,
+	imported map[string]string,
+) (string, error) {
+	if statusID, ok := imported[note.ID]; ok {
+		// Already imported
+		// this one, skip.
+		return statusID, nil
+	}
+
+	visibility := archiveNoteVisibility(note, requester)
+	if visibility == gtsmodel.VisibilityDirect {
+		// Don't import DMs, we can't
+		// (and shouldn't) recreate them.
+		return "", nil
+	}
+
+	var inReplyTo *gtsmodel.Status
+	if note.InReplyTo != "" {
+		parentID, ok := imported[note.InReplyTo]
+		if !ok {
+			// Reply to a status we
+			// didn't import, skip.
+			return "", nil
+		}
+
+		var err error
+		inReplyTo, err = p.state.DB.GetStatusByID(ctx, parentID)
+		if err != nil {
+			return "", gtserror.Newf("db error getting parent status: %w", err)
+		}
+	}
+
+	published := note.Published
+	if published.IsZero() || published.After(time.Now()) {
+		published = time.Now()
+	}
+
+	// Backdate status ID to when it was originally published.
+	statusID, err := id.NewULIDFromTime(published)
+	if err != nil {
+		return "", gtserror.Newf("error generating status id: %w", err)
+	}
+
+	accountURIs := uris.GenerateURIsForAccount(requester.Username)
+	status := &gtsmodel.Status{
+		ID:                  statusID,
+		URI:                 accountURIs.StatusesURI + "/" + statusID,
+		URL:                 accountURIs.StatusesURL + "/" + statusID,
+		CreatedAt:           published,
+		UpdatedAt:           published,
+		Local:               util.Ptr(true),
+		Account:             requester,
+		AccountID:           requester.ID,
+		AccountURI:          requester.URI,
+		ActivityStreamsType: ap.ObjectNote,
+		Visibility:          visibility,
+		Sensitive:           util.Ptr(note.Sensitive),
+		ContentWarning:      text.SanitizeToPlaintext(note.Summary),
+		Content:             text.SanitizeToHTML(note.Content),
+		Text:                text.SanitizeToPlaintext(note.Content),
+		Federated:           util.Ptr(true),
+	}
+
+	for lang := range note.ContentMap {
+		// Take language
+		// from content map.
+		status.Language = lang
+		break
+	}
+
+	if inReplyTo != nil {
+		status.InReplyToID = inReplyTo.ID
+		status.InReplyToURI = inReplyTo.URI
+		status.InReplyToAccountID = inReplyTo.AccountID
+		status.InReplyTo = inReplyTo
+		status.ThreadID = inReplyTo.ThreadID
+	}
+
+	if status.ThreadID == "" {
+		// Mark new thread
+		// starting from here.
+		status.ThreadID = id.NewULID()
+		if err := p.state.DB.PutThread(ctx, &gtsmodel.Thread{
+			ID: status.ThreadID,
+		}); err != nil {
+			return "", gtserror.Newf("db error putting thread: %w", err)
+		}
+	}
+
+	// Store attached media from the archive.
+	for _, a := range note.Attachment {
+		attachment, err := p.importArchiveMedia(ctx, requester, zr, status, &a)
+		if err != nil {
+			log.Errorf(ctx, "could not import attachment %s: %v", a.URL, err)
+			continue
+		}
+
+		status.AttachmentIDs = append(status.AttachmentIDs, attachment.ID)
+		status.Attachments = append(status.Attachments, attachment)
+	}
+
+	if err := p.state.DB.PutStatus(ctx, status); err != nil {
+		return "", gtserror.Newf("db error putting status: %w", err)
+	}
+
+	// Update account stats to
+	// include the imported status.
+	if err := p.state.DB.PopulateAccountStats(ctx, requester); err != nil {
+		return "", gtserror.Newf("db error getting account stats: %w", err)
+	}
+
+	*requester.Stats.StatusesCount++
+	if status.CreatedAt.After(requester.Stats.LastStatusAt) {
+		requester.Stats.LastStatusAt = status.CreatedAt
+	}
+
+	if err := p.state.DB.UpdateAccountStats(ctx,
+		requester.Stats,
+		"statuses_count",
+		"last_status_at",
+	); err != nil {
+		return "", gtserror.Newf("db error updating account stats: %w", err)
+	}
+
+	return status.ID, nil
+}
+
+// archiveNoteVisibility derives a visibility for the given
+// archive note by requester, from its to and cc fields.
+func archiveNoteVisibility(note *archiveNote, requester *gtsmodel.Account) gtsmodel.Visibility {
+	isFollowers := func(iri string) bool {
+		return iri == requester.FollowersURI ||
+			strings.HasSuffix(iri, "/followers")
+	}
+
+	switch {
+	case containsFunc(note.To, pub.IsPublic):
+		return gtsmodel.VisibilityPublic
+	case containsFunc(note.Cc, pub.IsPublic):
+		return gtsmodel.VisibilityUnlocked
+	case containsFunc(note.To, isFollowers),
+		containsFunc(note.Cc, isFollowers):
+		return gtsmodel.VisibilityFollowersOnly
+	default:
+		return gtsmodel.VisibilityDirect
+	}
+}
+
+func containsFunc(iris []string, f func(string) bool) bool {
+	for _, iri := range iris {
+		if f(iri) {
+			return true
+		}
+	}
+	return false
+}
+
+// importArchiveMedia stores the media of the given archive
+// attachment as local media of requester, attached to status.
+func (p *Processor) importArchiveMedia(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	zr *zip.Reader,
+	status *gtsmodel.Status,
+	a *archiveAttachment,
+) (*gtsmodel.MediaAttachment, error) {
+	// Attachment URLs in archives are paths
+	// (or URLs with paths) of files in the zip.
+	u, err := url.Parse(a.URL)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimPrefix(path.Clean(u.Path), "/")
+
+	f, err := zr.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s in archive: %w", name, err)
+	}
+
+	// Wrap the file reader to ensure is limited to max.
+	maxsz := int64(config.GetMediaLocalMaxSize())
+	rc, _, _ := iotools.UpdateReadCloserLimit(f, maxsz)
+
+	info := media.AdditionalMediaInfo{
+		CreatedAt:   &status.CreatedAt,
+		StatusID:    &status.ID,
+		Description: &a.Name,
+		Blurhash:    &a.Blurhash,
+	}
+
+	if len(a.FocalPoint) == 2 {
+		info.FocusX = &a.FocalPoint[0]
+		info.FocusY = &a.FocalPoint[1]
+	}
+
+	attachment, errWithCode := p.c.StoreLocalMedia(ctx,
+		requester.ID,
+		func(ctx context.Context) (io.ReadCloser, error) {
+			return rc, nil
+		},
+		info,
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return attachment, nil
+}
+
+// readArchiveJSON reads archive file with name as JSON into v,
+// returning an error if the file is larger than the configured
+// accounts-archive-import-max-json-size.
+func readArchiveJSON(zr *zip.Reader, name string, v any) error {
+	maxsz := config.GetAccountsArchiveImportMaxJSONSize()
+
+	var zf *zip.File
+	for _, f := range zr.File {
+		if f.Name == name {
+			zf = f
+			break
+		}
+	}
+
+	if zf == nil {
+		return fmt.Errorf("error opening %s in archive: %w", name, os.ErrNotExist)
+	}
+
+	// Check the size the archive says the file is
+	// first, so we don't even start on a huge file.
+	if zf.UncompressedSize64 > uint64(maxsz) {
+		return fmt.Errorf("%s in archive exceeds max size %s", name, maxsz)
+	}
+
+	f, err := zf.Open()
+	if err != nil {
+		return fmt.Errorf("error opening %s in archive: %w", name, err)
+	}
+	defer f.Close()
+
+	// The declared size can't be trusted, so also
+	// make sure not to read more than max, erroring
+	// rather than decoding a truncated file.
+	r := &sizeLimitedReader{r: f, n: int64(maxsz)}
+
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		return fmt.Errorf("error reading %s in archive: %w", name, err)
+	}
+
+	return nil
+}
+
+// errSizeLimitExceeded is returned by sizeLimitedReader
+// once more than its limit has been read from it.
+var errSizeLimitExceeded = errors.New("size limit exceeded")
+
+// sizeLimitedReader is like io.LimitedReader, except
+// that it errors instead of returning io.EOF at limit.
+type sizeLimitedReader struct {
+	r io.Reader
+	n int64 // bytes remaining
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	if l.n -= int64(n); l.n < 0 {
+		return n, errSizeLimitExceeded
+	}
+	return n, err
+}
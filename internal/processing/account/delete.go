@@ -458,6 +458,18 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 		return gtserror.Newf("error deleting faves targeting account: %w", err)
 	}
 
+	// Delete all reactions targeting given account.
+	if err := p.state.DB.DeleteStatusReactions(ctx, account.ID, ""); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting reactions targeting account: %w", err)
+	}
+
+	// Delete all reactions owned by given account.
+	if err := p.state.DB.DeleteStatusReactions(ctx, "", account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting reactions by account: %w", err)
+	}
+
 	// TODO: add status mutes here when they're implemented.
 
 	// Delete all conversations owned by given account.
@@ -474,12 +486,62 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 		return gtserror.Newf("error deleting poll votes by account: %w", err)
 	}
 
+	// Delete all scheduled statuses owned by given account,
+	// cancelling publishing of any that are still pending.
+	scheduled, err := p.state.DB.GetScheduledStatusesForAcct(
+		gtscontext.SetBarebones(ctx),
+		account.ID,
+		nil, // all
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting scheduled statuses by account: %w", err)
+	}
+
+	for _, s := range scheduled {
+		p.state.Workers.Scheduler.Cancel(s.ID)
+		if err := p.state.DB.DeleteScheduledStatusByID(ctx, s.ID); err != nil {
+			return gtserror.Newf("error deleting scheduled status %s: %w", s.ID, err)
+		}
+	}
+
 	// Delete all followed tags owned by given account.
 	if err := p.state.DB.DeleteFollowedTagsByAccountID(ctx, account.ID); // nocollapse
 	err != nil && !errors.Is(err, db.ErrNoEntries) {
 		return gtserror.Newf("error deleting followed tags by account: %w", err)
 	}
 
+	// Delete all Web Push subscriptions owned by given account.
+	if err := p.state.DB.DeleteWebPushSubscriptionsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting web push subscriptions by account: %w", err)
+	}
+
+	// Delete all announcement read markers and reactions owned by given account.
+	if err := p.state.DB.DeleteAnnouncementReadsAndReactionsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting announcement reads and reactions by account: %w", err)
+	}
+
+	// Delete all suggestion dismissals by or targeting given
+	// account, and stop featuring it as a suggested account.
+	if err := p.state.DB.DeleteSuggestionsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting suggestions by account: %w", err)
+	}
+
+	// Delete all data archives of given account,
+	// removing their zip files from storage.
+	archives, err := p.state.DB.GetAccountArchivesByAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting archives by account: %w", err)
+	}
+
+	for _, archive := range archives {
+		if err := p.removeArchive(ctx, archive); err != nil {
+			return gtserror.Newf("error deleting archive %s: %w", archive.ID, err)
+		}
+	}
+
 	// Delete account stats model.
 	if err := p.state.DB.DeleteAccountStats(ctx, account.ID); err != nil {
 		return gtserror.Newf("error deleting stats for account: %w", err)
@@ -0,0 +1,106 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// DirectoryGet returns a page of accounts from the profile directory,
+// ie., accounts which have opted in to being discoverable. If newest
+// is true, newest accounts are returned first, else accounts which
+// most recently posted a status are returned first.
+//
+// Requester may be nil, in which case the directory is fetched without
+// filtering out accounts blocked or muted by the requester.
+func (p *Processor) DirectoryGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	local bool,
+	newest bool,
+	limit int,
+	offset int,
+) ([]*apimodel.Account, gtserror.WithCode) {
+	accounts, err := p.state.DB.GetDirectoryAccounts(ctx, local, newest, limit, offset)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting directory accounts: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAccounts := make([]*apimodel.Account, 0, len(accounts))
+	for _, account := range accounts {
+		if account.IsInstance() {
+			// Instance accounts are
+			// never shown in directory.
+			continue
+		}
+
+		if requester != nil {
+			visible, err := p.directoryVisible(ctx, requester, account)
+			if err != nil {
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+			if !visible {
+				continue
+			}
+		}
+
+		apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, account)
+		if err != nil {
+			err := gtserror.Newf("error converting account %s to frontend representation: %w", account.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		apiAccounts = append(apiAccounts, apiAccount)
+	}
+
+	return apiAccounts, nil
+}
+
+// directoryVisible returns whether the given
+// account should be shown to requester in the
+// profile directory, ie., neither of them have
+// blocked the other, and requester hasn't muted it.
+func (p *Processor) directoryVisible(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	account *gtsmodel.Account,
+) (bool, error) {
+	blocked, err := p.state.DB.IsEitherBlocked(ctx, requester.ID, account.ID)
+	if err != nil {
+		return false, gtserror.Newf("db error checking block with account %s: %w", account.ID, err)
+	}
+
+	if blocked {
+		return false, nil
+	}
+
+	muted, err := p.state.DB.IsMuted(ctx, requester.ID, account.ID)
+	if err != nil {
+		return false, gtserror.Newf("db error checking mute of account %s: %w", account.ID, err)
+	}
+
+	return !muted, nil
+}
@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type DirectoryTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *DirectoryTestSuite) directoryAccountIDs(requester *gtsmodel.Account) []string {
+	accounts, errWithCode := suite.accountProcessor.DirectoryGet(context.Background(), requester, true, true, 20, 0)
+	suite.NoError(errWithCode)
+
+	accountIDs := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		accountIDs = append(accountIDs, account.ID)
+	}
+	return accountIDs
+}
+
+func (suite *DirectoryTestSuite) TestDirectoryGet() {
+	// The instance account is discoverable,
+	// but should never appear in the directory.
+	suite.ElementsMatch([]string{
+		suite.testAccounts["local_account_1"].ID,
+		suite.testAccounts["admin_account"].ID,
+	}, suite.directoryAccountIDs(nil))
+}
+
+func (suite *DirectoryTestSuite) TestDirectoryGetBlocked() {
+	requester := suite.testAccounts["local_account_2"]
+	admin := suite.testAccounts["admin_account"]
+
+	err := suite.db.PutBlock(context.Background(), &gtsmodel.Block{
+		ID:              id.NewULID(),
+		URI:             "http://localhost:8080/users/admin/blocks/" + id.NewULID(),
+		AccountID:       admin.ID,
+		TargetAccountID: requester.ID,
+	})
+	suite.NoError(err)
+
+	// Admin blocks requester, so shouldn't be shown to them.
+	suite.Equal([]string{
+		suite.testAccounts["local_account_1"].ID,
+	}, suite.directoryAccountIDs(requester))
+
+	// Logged-out viewers still see admin.
+	suite.Contains(suite.directoryAccountIDs(nil), admin.ID)
+}
+
+func TestDirectoryTestSuite(t *testing.T) {
+	suite.Run(t, new(DirectoryTestSuite))
+}
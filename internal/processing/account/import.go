@@ -20,16 +20,68 @@ package account
 import (
 	"context"
 	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"mime/multipart"
+	"net/url"
+	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 )
 
+// importProgressInterval is the minimum interval between
+// persisting the progress of a running import. Task data
+// contains all records of the import, so it's not rewritten
+// after every entry; instead, on a crash, up to one interval
+// of entries may be processed again after a restart.
+const importProgressInterval = 10 * time.Second
+
+// importTask is the data of one account data
+// import. It's serialized and persisted as a
+// worker task for as long as the import runs,
+// so that it can be resumed after a restart.
+type importTask struct {
+	// ID of the importing account.
+	AccountID string `json:"account_id"`
+
+	// Type of the imported data,
+	// eg., "following", "mutes".
+	Type string `json:"type"`
+
+	// Overwrite existing data
+	// rather than merging.
+	Overwrite bool `json:"overwrite"`
+
+	// Records parsed
+	// from the CSV file.
+	Records [][]string `json:"records,omitempty"`
+
+	// Storage path of the uploaded
+	// file, for archive imports.
+	Path string `json:"path,omitempty"`
+
+	// URIs of statuses in an archive
+	// mapped to the IDs of the statuses
+	// imported from them, for threading.
+	Imported map[string]string `json:"imported,omitempty"`
+
+	// Number of entries
+	// processed so far.
+	Progress int `json:"progress"`
+}
+
+// ImportData parses the given data file of the given
+// type, and starts importing it for requester in the
+// background. Progress of the import is persisted in
+// the database, so that it survives restarts.
 func (p *Processor) ImportData(
 	ctx context.Context,
 	requester *gtsmodel.Account,
@@ -39,37 +91,29 @@ func (p *Processor) ImportData(
 ) gtserror.WithCode {
 	switch importType {
 
-	case "following":
-		return p.importFollowing(
-			ctx,
-			requester,
-			data,
-			overwrite,
-		)
+	case "following",
+		"blocks",
+		"mutes",
+		"bookmarks",
+		"lists":
+		// Supported CSV types.
 
-	case "blocks":
-		return p.importBlocks(
-			ctx,
-			requester,
-			data,
-			overwrite,
-		)
+	case "archive":
+		// Archives are zip files, not CSV.
+		return p.importArchiveData(ctx, requester, data, overwrite)
+
+	case "domain_blocks":
+		const text = "import type domain_blocks not supported: per-account domain blocks are not implemented"
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
 
 	default:
 		const text = "import type not yet supported"
 		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
 	}
-}
 
-func (p *Processor) importFollowing(
-	ctx context.Context,
-	requester *gtsmodel.Account,
-	followingData *multipart.FileHeader,
-	overwrite bool,
-) gtserror.WithCode {
-	file, err := followingData.Open()
+	file, err := data.Open()
 	if err != nil {
-		err := fmt.Errorf("error opening following data file: %w", err)
+		err := fmt.Errorf("error opening %s data file: %w", importType, err)
 		return gtserror.NewErrorBadRequest(err, err.Error())
 	}
 	defer file.Close()
@@ -77,303 +121,795 @@ func (p *Processor) importFollowing(
 	// Parse records out of the file.
 	records, err := csv.NewReader(file).ReadAll()
 	if err != nil {
-		err := fmt.Errorf("error reading following data file: %w", err)
+		err := fmt.Errorf("error reading %s data file: %w", importType, err)
 		return gtserror.NewErrorBadRequest(err, err.Error())
 	}
 
+	return p.startImport(ctx, &importTask{
+		AccountID: requester.ID,
+		Type:      importType,
+		Overwrite: overwrite,
+		Records:   records,
+	})
+}
+
+// startImport persists the given import
+// task, and starts running it in the background.
+func (p *Processor) startImport(ctx context.Context, data *importTask) gtserror.WithCode {
+	// Serialize the import task data.
+	taskData, err := json.Marshal(data)
+	if err != nil {
+		err := gtserror.Newf("error serializing import task: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Persist the task before starting,
+	// so it's not lost if we're stopped.
+	task := &gtsmodel.WorkerTask{
+		// ID is autoincrement
+		WorkerType: gtsmodel.ImportWorker,
+		TaskData:   taskData,
+		CreatedAt:  time.Now(),
+	}
+	if err := p.state.DB.PutWorkerTasks(ctx, []*gtsmodel.WorkerTask{task}); err != nil {
+		err := gtserror.Newf("db error putting import task: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Do remaining processing of this import asynchronously.
+	p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+		p.runImport(ctx, task)
+	})
+
+	return nil
+}
+
+// ResumeImports fetches any account data imports that were
+// interrupted by a shutdown from the database, and resumes
+// them in the background from where they left off.
+func (p *Processor) ResumeImports(ctx context.Context) error {
+	tasks, err := p.state.DB.GetWorkerTasks(ctx)
+	if err != nil {
+		return gtserror.Newf("db error getting worker tasks: %w", err)
+	}
+
+	var count int
+	for _, task := range tasks {
+		if task.WorkerType != gtsmodel.ImportWorker {
+			continue
+		}
+
+		p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+			p.runImport(ctx, task)
+		})
+		count++
+	}
+
+	log.Infof(ctx, "resumed %d account data imports", count)
+	return nil
+}
+
+// runImport runs the given import task from its last
+// recorded progress, deleting the task once it's done.
+func (p *Processor) runImport(ctx context.Context, task *gtsmodel.WorkerTask) {
+	l := log.WithContext(ctx).WithField("task", task.ID)
+
+	var data importTask
+	if err := json.Unmarshal(task.TaskData, &data); err != nil {
+		l.Errorf("error deserializing import task: %v", err)
+		p.deleteImport(ctx, task, nil)
+		return
+	}
+
+	requester, err := p.state.DB.GetAccountByID(ctx, data.AccountID)
+	if err != nil {
+		// Account is probably gone,
+		// nothing left to import to.
+		l.Errorf("error getting importing account %s: %v", data.AccountID, err)
+		p.deleteImport(ctx, task, &data)
+		return
+	}
+
+	l = l.WithField("account", requester.Username).WithField("type", data.Type)
+	l.Info("running import")
+
+	// save persists where
+	// we're up to in this import.
+	save := func(ctx context.Context) {
+		taskData, err := json.Marshal(&data)
+		if err != nil {
+			l.Errorf("error serializing import task: %v", err)
+			return
+		}
+
+		task.TaskData = taskData
+		if err := p.state.DB.UpdateWorkerTask(ctx, task, "task_data"); err != nil {
+			l.Errorf("db error updating import task: %v", err)
+		}
+	}
+
+	// progress should be called after each
+	// entry is processed, to record where we're
+	// up to in this import. It's only persisted
+	// once per importProgressInterval.
+	lastSave := time.Now()
+	progress := func() {
+		data.Progress++
+
+		if time.Since(lastSave) < importProgressInterval {
+			return
+		}
+
+		save(ctx)
+		lastSave = time.Now()
+	}
+
+	switch data.Type {
+	case "following":
+		err = p.importFollowing(ctx, requester, &data, progress)
+	case "blocks":
+		err = p.importBlocks(ctx, requester, &data, progress)
+	case "mutes":
+		err = p.importMutes(ctx, requester, &data, progress)
+	case "bookmarks":
+		err = p.importBookmarks(ctx, requester, &data, progress)
+	case "lists":
+		err = p.importLists(ctx, requester, &data, progress)
+	case "archive":
+		err = p.importArchive(ctx, requester, &data, progress)
+	default:
+		err = fmt.Errorf("unsupported import type %s", data.Type)
+	}
+
+	if ctx.Err() != nil {
+		// We're being stopped, so
+		// leave the task in place
+		// to resume on next startup,
+		// saving any unsaved progress.
+		save(context.WithoutCancel(ctx))
+		l.Info("import interrupted")
+		return
+	}
+
+	if err != nil {
+		l.Errorf("error importing: %v", err)
+	} else {
+		l.Info("import finished")
+	}
+
+	p.deleteImport(ctx, task, &data)
+}
+
+// deleteImport deletes the given (finished) import
+// task from the db, along with any uploaded file.
+func (p *Processor) deleteImport(ctx context.Context, task *gtsmodel.WorkerTask, data *importTask) {
+	if data != nil && data.Path != "" {
+		err := p.state.Storage.Delete(ctx, data.Path)
+		if err != nil && !storage.IsNotFound(err) {
+			log.Errorf(ctx, "error deleting import file %s: %v", data.Path, err)
+		}
+	}
+
+	if err := p.state.DB.DeleteWorkerTaskByID(ctx, task.ID); err != nil {
+		log.Errorf(ctx, "db error deleting import task %d: %v", task.ID, err)
+	}
+}
+
+// remaining returns the entries of an import
+// which haven't been processed yet, according
+// to the progress of the given import.
+func remaining[T any](entries []T, data *importTask) []T {
+	if data.Progress >= len(entries) {
+		return nil
+	}
+	return entries[data.Progress:]
+}
+
+func (p *Processor) importFollowing(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	data *importTask,
+	progress func(),
+) error {
 	// Convert the records into a slice of barebones follows.
 	//
 	// Only TargetAccount.Username, TargetAccount.Domain,
 	// and ShowReblogs will be set on each Follow.
-	follows, err := p.converter.CSVToFollowing(ctx, records)
+	follows, err := p.converter.CSVToFollowing(ctx, data.Records)
 	if err != nil {
-		err := fmt.Errorf("error converting records to follows: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+		return fmt.Errorf("error converting records to follows: %w", err)
 	}
 
-	// Do remaining processing of this import asynchronously.
-	f := importFollowingAsyncF(p, requester, follows, overwrite)
-	p.state.Workers.Processing.Queue.Push(f)
+	// Go through the follows parsed from CSV
+	// file, and create / update each one.
+	for _, follow := range remaining(follows, data) {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		// Get the target account, dereferencing it if necessary.
+		targetAcct, _, err := p.federator.Dereferencer.GetAccountByUsernameDomain(
+			ctx,
+			requester.Username,
+			follow.TargetAccount.Username,
+			follow.TargetAccount.Domain,
+		)
+		if err != nil {
+			log.Errorf(ctx, "could not retrieve account: %v", err)
+			progress()
+			continue
+		}
+
+		// Use the processor's FollowCreate function
+		// to create or update the follow. This takes
+		// account of existing follows, and also sends
+		// the follow to the FromClientAPI processor.
+		if _, errWithCode := p.FollowCreate(
+			ctx,
+			requester,
+			&apimodel.AccountFollowRequest{
+				ID:      targetAcct.ID,
+				Reblogs: follow.ShowReblogs,
+				Notify:  follow.Notify,
+			},
+		); errWithCode != nil {
+			log.Errorf(ctx, "could not follow account: %v", errWithCode.Unwrap())
+		}
+
+		progress()
+	}
+
+	if !data.Overwrite {
+		// Merging,
+		// we're done.
+		return nil
+	}
+
+	// We're overwriting, so now we've created
+	// (or tried to create) the wanted follows,
+	// go through existing follow(-request)s
+	// owned by requester, and remove unwanted.
+	wanted := make(map[string]struct{}, len(follows))
+	for _, follow := range follows {
+		wanted[follow.TargetAccount.Username+"@"+follow.TargetAccount.Domain] = struct{}{}
+	}
+
+	prevFollows, err := p.state.DB.GetAccountFollows(ctx, requester.ID, nil)
+	if err != nil {
+		return gtserror.Newf("db error getting following: %w", err)
+	}
+
+	prevFollowReqs, err := p.state.DB.GetAccountFollowRequesting(ctx, requester.ID, nil)
+	if err != nil {
+		return gtserror.Newf("db error getting follow requesting: %w", err)
+	}
+
+	// AccountIDs to unfollow.
+	toRemove := []string{}
+
+	// Check previous follows.
+	for _, prev := range prevFollows {
+		username := prev.TargetAccount.Username
+		domain := prev.TargetAccount.Domain
+
+		if _, ok := wanted[username+"@"+domain]; !ok {
+			toRemove = append(toRemove, prev.TargetAccountID)
+		}
+	}
+
+	// Now any pending follow requests.
+	for _, prev := range prevFollowReqs {
+		username := prev.TargetAccount.Username
+		domain := prev.TargetAccount.Domain
+
+		if _, ok := wanted[username+"@"+domain]; !ok {
+			toRemove = append(toRemove, prev.TargetAccountID)
+		}
+	}
+
+	// Remove each discovered
+	// unwanted follow.
+	for _, accountID := range toRemove {
+		if _, errWithCode := p.FollowRemove(
+			ctx,
+			requester,
+			accountID,
+		); errWithCode != nil {
+			log.Errorf(ctx, "could not unfollow account: %v", errWithCode.Unwrap())
+		}
+	}
 
 	return nil
 }
 
-func importFollowingAsyncF(
-	p *Processor,
+func (p *Processor) importBlocks(
+	ctx context.Context,
 	requester *gtsmodel.Account,
-	follows []*gtsmodel.Follow,
-	overwrite bool,
-) func(context.Context) {
-	return func(ctx context.Context) {
-		// Map used to store wanted
-		// follow targets (if overwriting).
-		var wantedFollows map[string]struct{}
-
-		if overwrite {
-			// If we're overwriting, we need to get current
-			// follow(-req)s owned by requester *before*
-			// making any changes, so that we can remove
-			// unwanted follows after we've created new ones.
-			prevFollows, err := p.state.DB.GetAccountFollows(ctx, requester.ID, nil)
-			if err != nil {
-				log.Errorf(ctx, "db error getting following: %v", err)
-				return
-			}
+	data *importTask,
+	progress func(),
+) error {
+	// Convert the records into a slice of barebones blocks.
+	//
+	// Only TargetAccount.Username and TargetAccount.Domain,
+	// will be set on each Block.
+	blocks, err := p.converter.CSVToBlocks(ctx, data.Records)
+	if err != nil {
+		return fmt.Errorf("error converting records to blocks: %w", err)
+	}
 
-			prevFollowReqs, err := p.state.DB.GetAccountFollowRequesting(ctx, requester.ID, nil)
-			if err != nil {
-				log.Errorf(ctx, "db error getting follow requesting: %v", err)
-				return
-			}
+	// Go through the blocks parsed from CSV
+	// file, and create / update each one.
+	for _, block := range remaining(blocks, data) {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 
-			// Initialize new follows map.
-			wantedFollows = make(map[string]struct{}, len(follows))
-
-			// Once we've created (or tried to create)
-			// the required follows, go through previous
-			// follow(-request)s and remove unwanted ones.
-			defer func() {
-
-				// AccountIDs to unfollow.
-				toRemove := []string{}
-
-				// Check previous follows.
-				for _, prev := range prevFollows {
-					username := prev.TargetAccount.Username
-					domain := prev.TargetAccount.Domain
-
-					_, wanted := wantedFollows[username+"@"+domain]
-					if !wanted {
-						toRemove = append(toRemove, prev.TargetAccountID)
-					}
-				}
-
-				// Now any pending follow requests.
-				for _, prev := range prevFollowReqs {
-					username := prev.TargetAccount.Username
-					domain := prev.TargetAccount.Domain
-
-					_, wanted := wantedFollows[username+"@"+domain]
-					if !wanted {
-						toRemove = append(toRemove, prev.TargetAccountID)
-					}
-				}
-
-				// Remove each discovered
-				// unwanted follow.
-				for _, accountID := range toRemove {
-					if _, errWithCode := p.FollowRemove(
-						ctx,
-						requester,
-						accountID,
-					); errWithCode != nil {
-						log.Errorf(ctx, "could not unfollow account: %v", errWithCode.Unwrap())
-						continue
-					}
-				}
-			}()
-		}
-
-		// Go through the follows parsed from CSV
-		// file, and create / update each one.
-		for _, follow := range follows {
-			var (
-				// Username of the target.
-				username = follow.TargetAccount.Username
-
-				// Domain of the target.
-				// Empty for our domain.
-				domain = follow.TargetAccount.Domain
-
-				// Show reblogs on
-				// the new follow.
-				showReblogs = follow.ShowReblogs
-
-				// Notify when new
-				// follow posts.
-				notify = follow.Notify
-			)
-
-			if overwrite {
-				// We'll be overwriting, so store
-				// this new follow in our handy map.
-				wantedFollows[username+"@"+domain] = struct{}{}
-			}
+		// Get the target account, dereferencing it if necessary.
+		targetAcct, _, err := p.federator.Dereferencer.GetAccountByUsernameDomain(
+			ctx,
+			// Provide empty request user to use the
+			// instance account to deref the account.
+			//
+			// It's pointless to make lots of calls
+			// to a remote from an account that's about
+			// to block that account.
+			"",
+			block.TargetAccount.Username,
+			block.TargetAccount.Domain,
+		)
+		if err != nil {
+			log.Errorf(ctx, "could not retrieve account: %v", err)
+			progress()
+			continue
+		}
 
-			// Get the target account, dereferencing it if necessary.
-			targetAcct, _, err := p.federator.Dereferencer.GetAccountByUsernameDomain(
-				ctx,
-				requester.Username,
-				username,
-				domain,
-			)
-			if err != nil {
-				log.Errorf(ctx, "could not retrieve account: %v", err)
-				continue
-			}
+		// Use the processor's BlockCreate function
+		// to create or update the block. This takes
+		// account of existing blocks, and also sends
+		// the block to the FromClientAPI processor.
+		if _, errWithCode := p.BlockCreate(
+			ctx,
+			requester,
+			targetAcct.ID,
+		); errWithCode != nil {
+			log.Errorf(ctx, "could not block account: %v", errWithCode.Unwrap())
+		}
 
-			// Use the processor's FollowCreate function
-			// to create or update the follow. This takes
-			// account of existing follows, and also sends
-			// the follow to the FromClientAPI processor.
-			if _, errWithCode := p.FollowCreate(
-				ctx,
-				requester,
-				&apimodel.AccountFollowRequest{
-					ID:      targetAcct.ID,
-					Reblogs: showReblogs,
-					Notify:  notify,
-				},
-			); errWithCode != nil {
-				log.Errorf(ctx, "could not follow account: %v", errWithCode.Unwrap())
-				continue
-			}
+		progress()
+	}
+
+	if !data.Overwrite {
+		// Merging,
+		// we're done.
+		return nil
+	}
+
+	// We're overwriting, so now we've created
+	// (or tried to create) the wanted blocks,
+	// go through existing blocks owned by
+	// requester, and remove unwanted ones.
+	wanted := make(map[string]struct{}, len(blocks))
+	for _, block := range blocks {
+		wanted[block.TargetAccount.Username+"@"+block.TargetAccount.Domain] = struct{}{}
+	}
+
+	prevBlocks, err := p.state.DB.GetAccountBlocks(ctx, requester.ID, nil)
+	if err != nil {
+		return gtserror.Newf("db error getting blocks: %w", err)
+	}
+
+	for _, prev := range prevBlocks {
+		username := prev.TargetAccount.Username
+		domain := prev.TargetAccount.Domain
+
+		if _, ok := wanted[username+"@"+domain]; ok {
+			// Leave this
+			// one alone.
+			continue
+		}
+
+		if _, errWithCode := p.BlockRemove(
+			ctx,
+			requester,
+			prev.TargetAccountID,
+		); errWithCode != nil {
+			log.Errorf(ctx, "could not unblock account: %v", errWithCode.Unwrap())
 		}
 	}
+
+	return nil
 }
 
-func (p *Processor) importBlocks(
+func (p *Processor) importMutes(
 	ctx context.Context,
 	requester *gtsmodel.Account,
-	blocksData *multipart.FileHeader,
-	overwrite bool,
-) gtserror.WithCode {
-	file, err := blocksData.Open()
+	data *importTask,
+	progress func(),
+) error {
+	// Convert the records into a slice of barebones mutes.
+	//
+	// Only TargetAccount.Username, TargetAccount.Domain,
+	// and Notifications will be set on each UserMute.
+	mutes, err := p.converter.CSVToMutes(ctx, data.Records)
 	if err != nil {
-		err := fmt.Errorf("error opening blocks data file: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+		return fmt.Errorf("error converting records to mutes: %w", err)
 	}
-	defer file.Close()
 
-	// Parse records out of the file.
-	records, err := csv.NewReader(file).ReadAll()
+	// Go through the mutes parsed from CSV
+	// file, and create / update each one.
+	for _, mute := range remaining(mutes, data) {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		// Get the target account, dereferencing it if necessary.
+		targetAcct, _, err := p.federator.Dereferencer.GetAccountByUsernameDomain(
+			ctx,
+			requester.Username,
+			mute.TargetAccount.Username,
+			mute.TargetAccount.Domain,
+		)
+		if err != nil {
+			log.Errorf(ctx, "could not retrieve account: %v", err)
+			progress()
+			continue
+		}
+
+		// Use the processor's MuteCreate function
+		// to create or update the mute. This takes
+		// account of existing mutes.
+		if _, errWithCode := p.MuteCreate(
+			ctx,
+			requester,
+			targetAcct.ID,
+			&apimodel.UserMuteCreateUpdateRequest{
+				Notifications: mute.Notifications,
+			},
+		); errWithCode != nil {
+			log.Errorf(ctx, "could not mute account: %v", errWithCode.Unwrap())
+		}
+
+		progress()
+	}
+
+	if !data.Overwrite {
+		// Merging,
+		// we're done.
+		return nil
+	}
+
+	// We're overwriting, so now we've created
+	// (or tried to create) the wanted mutes,
+	// go through existing mutes owned by
+	// requester, and remove unwanted ones.
+	wanted := make(map[string]struct{}, len(mutes))
+	for _, mute := range mutes {
+		wanted[mute.TargetAccount.Username+"@"+mute.TargetAccount.Domain] = struct{}{}
+	}
+
+	prevMutes, err := p.state.DB.GetAccountMutes(ctx, requester.ID, nil)
 	if err != nil {
-		err := fmt.Errorf("error reading blocks data file: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+		return gtserror.Newf("db error getting mutes: %w", err)
 	}
 
-	// Convert the records into a slice of barebones blocks.
+	for _, prev := range prevMutes {
+		username := prev.TargetAccount.Username
+		domain := prev.TargetAccount.Domain
+
+		if _, ok := wanted[username+"@"+domain]; ok {
+			// Leave this
+			// one alone.
+			continue
+		}
+
+		if _, errWithCode := p.MuteRemove(
+			ctx,
+			requester,
+			prev.TargetAccountID,
+		); errWithCode != nil {
+			log.Errorf(ctx, "could not unmute account: %v", errWithCode.Unwrap())
+		}
+	}
+
+	return nil
+}
+
+func (p *Processor) importBookmarks(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	data *importTask,
+	progress func(),
+) error {
+	// Convert the records into a slice of barebones bookmarks.
 	//
-	// Only TargetAccount.Username and TargetAccount.Domain,
-	// will be set on each Block.
-	blocks, err := p.converter.CSVToBlocks(ctx, records)
+	// Only Status.URI will be set on each StatusBookmark.
+	bookmarks, err := p.converter.CSVToBookmarks(ctx, data.Records)
 	if err != nil {
-		err := fmt.Errorf("error converting records to blocks: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+		return fmt.Errorf("error converting records to bookmarks: %w", err)
 	}
 
-	// Do remaining processing of this import asynchronously.
-	f := importBlocksAsyncF(p, requester, blocks, overwrite)
-	p.state.Workers.Processing.Queue.Push(f)
+	// Go through the bookmarks parsed
+	// from CSV file, and create each one.
+	for _, bookmark := range remaining(bookmarks, data) {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err := p.importBookmark(ctx, requester, bookmark.Status.URI); err != nil {
+			log.Errorf(ctx, "could not bookmark status: %v", err)
+		}
+
+		progress()
+	}
+
+	if !data.Overwrite {
+		// Merging,
+		// we're done.
+		return nil
+	}
+
+	// We're overwriting, so now we've created
+	// (or tried to create) the wanted bookmarks,
+	// go through existing bookmarks owned by
+	// requester, and remove unwanted ones.
+	wanted := make(map[string]struct{}, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		wanted[bookmark.Status.URI] = struct{}{}
+	}
+
+	prevBookmarks, err := p.state.DB.GetStatusBookmarks(ctx, requester.ID, 0, "", "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting bookmarks: %w", err)
+	}
+
+	for _, prev := range prevBookmarks {
+		if _, ok := wanted[prev.Status.URI]; ok {
+			// Leave this
+			// one alone.
+			continue
+		}
+
+		if err := p.state.DB.DeleteStatusBookmarkByID(ctx, prev.ID); err != nil {
+			log.Errorf(ctx, "db error removing bookmark: %v", err)
+			continue
+		}
+
+		if err := p.c.InvalidateTimelinedStatus(ctx, requester.ID, prev.StatusID); err != nil {
+			log.Errorf(ctx, "error invalidating status from timelines: %v", err)
+		}
+	}
 
 	return nil
 }
 
-func importBlocksAsyncF(
-	p *Processor,
+// importBookmark creates a bookmark by requester of the
+// status with the given URI, if it's visible to requester.
+func (p *Processor) importBookmark(
+	ctx context.Context,
 	requester *gtsmodel.Account,
-	blocks []*gtsmodel.Block,
-	overwrite bool,
-) func(context.Context) {
-	return func(ctx context.Context) {
-		// Map used to store wanted
-		// block targets (if overwriting).
-		var wantedBlocks map[string]struct{}
-
-		if overwrite {
-			// If we're overwriting, we need to get current
-			// blocks owned by requester *before* making any
-			// changes, so that we can remove unwanted blocks
-			// after we've created new ones.
-			var (
-				prevBlocks []*gtsmodel.Block
-				err        error
-			)
-
-			prevBlocks, err = p.state.DB.GetAccountBlocks(ctx, requester.ID, nil)
-			if err != nil {
-				log.Errorf(ctx, "db error getting blocks: %v", err)
-				return
+	uriStr string,
+) error {
+	uri, err := url.Parse(uriStr)
+	if err != nil {
+		return err
+	}
+
+	// Get the status, dereferencing it if necessary.
+	status, _, err := p.federator.Dereferencer.GetStatusByURI(
+		ctx,
+		requester.Username,
+		uri,
+	)
+	if err != nil {
+		return fmt.Errorf("could not retrieve status: %w", err)
+	}
+
+	visible, err := p.visFilter.StatusVisible(ctx, requester, status)
+	if err != nil {
+		return gtserror.Newf("error checking status visibility: %w", err)
+	}
+
+	if !visible {
+		return fmt.Errorf("status %s not visible", uriStr)
+	}
+
+	bookmarked, err := p.state.DB.IsStatusBookmarkedBy(ctx, requester.ID, status.ID)
+	if err != nil {
+		return gtserror.Newf("db error checking bookmark: %w", err)
+	}
+
+	if bookmarked {
+		// Nothing
+		// to do.
+		return nil
+	}
+
+	if err := p.state.DB.PutStatusBookmark(ctx, &gtsmodel.StatusBookmark{
+		ID:              id.NewULID(),
+		AccountID:       requester.ID,
+		Account:         requester,
+		TargetAccountID: status.AccountID,
+		TargetAccount:   status.Account,
+		StatusID:        status.ID,
+		Status:          status,
+	}); err != nil {
+		return gtserror.Newf("db error putting bookmark: %w", err)
+	}
+
+	if err := p.c.InvalidateTimelinedStatus(ctx, requester.ID, status.ID); err != nil {
+		return gtserror.Newf("error invalidating status from timelines: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Processor) importLists(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	data *importTask,
+	progress func(),
+) error {
+	// Convert the records into a slice of barebones list entries.
+	//
+	// Only List.Title, and Follow.TargetAccount.Username and
+	// Follow.TargetAccount.Domain will be set on each ListEntry.
+	entries, err := p.converter.CSVToLists(ctx, data.Records)
+	if err != nil {
+		return fmt.Errorf("error converting records to lists: %w", err)
+	}
+
+	// Get requester's existing lists,
+	// so we can look them up by title.
+	prevLists, err := p.state.DB.GetListsByAccountID(
+		gtscontext.SetBarebones(ctx),
+		requester.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting lists: %w", err)
+	}
+
+	lists := make(map[string]*gtsmodel.List, len(prevLists))
+	for _, list := range prevLists {
+		lists[list.Title] = list
+	}
+
+	// Go through the list entries parsed
+	// from CSV file, and create each one.
+	for _, entry := range remaining(entries, data) {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		title := entry.List.Title
+		list, ok := lists[title]
+		if !ok {
+			// List with this title doesn't
+			// exist yet, so create it, with
+			// the same defaults as the API.
+			list = &gtsmodel.List{
+				ID:            id.NewULID(),
+				Title:         title,
+				AccountID:     requester.ID,
+				RepliesPolicy: gtsmodel.RepliesPolicyFollowed,
+				Exclusive:     new(bool),
 			}
 
-			// Initialize new blocks map.
-			wantedBlocks = make(map[string]struct{}, len(blocks))
-
-			// Once we've created (or tried to create)
-			// the required blocks, go through previous
-			// blocks and remove unwanted ones.
-			defer func() {
-				for _, prev := range prevBlocks {
-					username := prev.TargetAccount.Username
-					domain := prev.TargetAccount.Domain
-
-					_, wanted := wantedBlocks[username+"@"+domain]
-					if wanted {
-						// Leave this
-						// one alone.
-						continue
-					}
-
-					if _, errWithCode := p.BlockRemove(
-						ctx,
-						requester,
-						prev.TargetAccountID,
-					); errWithCode != nil {
-						log.Errorf(ctx, "could not unblock account: %v", errWithCode.Unwrap())
-						continue
-					}
-				}
-			}()
-		}
-
-		// Go through the blocks parsed from CSV
-		// file, and create / update each one.
-		for _, block := range blocks {
-			var (
-				// Username of the target.
-				username = block.TargetAccount.Username
-
-				// Domain of the target.
-				// Empty for our domain.
-				domain = block.TargetAccount.Domain
-			)
-
-			if overwrite {
-				// We'll be overwriting, so store
-				// this new block in our handy map.
-				wantedBlocks[username+"@"+domain] = struct{}{}
+			if err := p.state.DB.PutList(ctx, list); err != nil {
+				return gtserror.Newf("db error putting list: %w", err)
 			}
 
-			// Get the target account, dereferencing it if necessary.
-			targetAcct, _, err := p.federator.Dereferencer.GetAccountByUsernameDomain(
-				ctx,
-				// Provide empty request user to use the
-				// instance account to deref the account.
-				//
-				// It's pointless to make lots of calls
-				// to a remote from an account that's about
-				// to block that account.
-				"",
-				username,
-				domain,
-			)
-			if err != nil {
-				log.Errorf(ctx, "could not retrieve account: %v", err)
-				continue
+			lists[title] = list
+		}
+
+		if err := p.importListEntry(ctx,
+			requester,
+			list,
+			entry.Follow.TargetAccount,
+		); err != nil {
+			log.Errorf(ctx, "could not add account to list: %v", err)
+		}
+
+		progress()
+	}
+
+	if !data.Overwrite {
+		// Merging,
+		// we're done.
+		return nil
+	}
+
+	// We're overwriting, so now we've created
+	// (or tried to create) the wanted lists and
+	// entries, go through requester's lists, and
+	// remove unwanted lists and list entries.
+	wanted := make(map[string]map[string]struct{}, len(lists))
+	for _, entry := range entries {
+		title := entry.List.Title
+		if wanted[title] == nil {
+			wanted[title] = make(map[string]struct{})
+		}
+
+		target := entry.Follow.TargetAccount
+		wanted[title][target.Username+"@"+target.Domain] = struct{}{}
+	}
+
+	for title, list := range lists {
+		wantedEntries, ok := wanted[title]
+		if !ok {
+			// This whole
+			// list is unwanted.
+			if err := p.state.DB.DeleteListByID(ctx, list.ID); err != nil {
+				log.Errorf(ctx, "db error deleting list: %v", err)
 			}
+			continue
+		}
+
+		follows, err := p.state.DB.GetFollowsInList(ctx, list.ID, nil)
+		if err != nil {
+			log.Errorf(ctx, "db error getting list follows: %v", err)
+			continue
+		}
+
+		for _, follow := range follows {
+			username := follow.TargetAccount.Username
+			domain := follow.TargetAccount.Domain
 
-			// Use the processor's BlockCreate function
-			// to create or update the block. This takes
-			// account of existing blocks, and also sends
-			// the block to the FromClientAPI processor.
-			if _, errWithCode := p.BlockCreate(
-				ctx,
-				requester,
-				targetAcct.ID,
-			); errWithCode != nil {
-				log.Errorf(ctx, "could not block account: %v", errWithCode.Unwrap())
+			if _, ok := wantedEntries[username+"@"+domain]; ok {
+				// Leave this
+				// one alone.
 				continue
 			}
+
+			if err := p.state.DB.DeleteListEntry(ctx, list.ID, follow.ID); err != nil {
+				log.Errorf(ctx, "db error deleting list entry: %v", err)
+			}
 		}
 	}
+
+	return nil
+}
+
+// importListEntry adds the given target account to the given list
+// owned by requester. Since list entries are follows, requester must
+// already follow the target account for this to succeed.
+func (p *Processor) importListEntry(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	list *gtsmodel.List,
+	target *gtsmodel.Account,
+) error {
+	// Get the target account, dereferencing it if necessary.
+	targetAcct, _, err := p.federator.Dereferencer.GetAccountByUsernameDomain(
+		ctx,
+		requester.Username,
+		target.Username,
+		target.Domain,
+	)
+	if err != nil {
+		return fmt.Errorf("could not retrieve account: %w", err)
+	}
+
+	follow, err := p.state.DB.GetFollow(
+		gtscontext.SetBarebones(ctx),
+		requester.ID,
+		targetAcct.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting follow: %w", err)
+	}
+
+	if follow == nil {
+		return fmt.Errorf("account %s not currently followed", targetAcct.URI)
+	}
+
+	err = p.state.DB.PutListEntries(ctx, []*gtsmodel.ListEntry{{
+		ID:       id.NewULID(),
+		ListID:   list.ID,
+		FollowID: follow.ID,
+	}})
+	if err != nil && !errors.Is(err, db.ErrAlreadyExists) {
+		return gtserror.Newf("db error putting list entry: %w", err)
+	}
+
+	return nil
 }
@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type ImportTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *ImportTestSuite) TestResumeImport() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+	)
+
+	// Persist an import of blocks
+	// that was interrupted after
+	// processing its first entry.
+	taskData, err := json.Marshal(map[string]any{
+		"account_id": testAccount.ID,
+		"type":       "blocks",
+		"overwrite":  false,
+		"records": [][]string{
+			{"admin@localhost:8080"},
+			{"1happyturtle@localhost:8080"},
+		},
+		"progress": 1,
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.state.DB.PutWorkerTasks(ctx, []*gtsmodel.WorkerTask{{
+		WorkerType: gtsmodel.ImportWorker,
+		TaskData:   taskData,
+		CreatedAt:  time.Now(),
+	}}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Resume the import, and run
+	// the queued processing fn.
+	if err := suite.accountProcessor.ResumeImports(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	fn, ok := suite.state.Workers.Processing.Queue.Pop()
+	if !ok {
+		suite.FailNow("expected import to be queued")
+	}
+	fn(ctx)
+
+	// Only the remaining
+	// entry should be blocked.
+	blocked, err := suite.state.DB.IsBlocked(ctx, testAccount.ID, suite.testAccounts["local_account_2"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(blocked)
+
+	blocked, err = suite.state.DB.IsBlocked(ctx, testAccount.ID, suite.testAccounts["admin_account"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(blocked)
+
+	// Finished import task
+	// should now be gone.
+	tasks, err := suite.state.DB.GetWorkerTasks(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(tasks)
+}
+
+func TestImportTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportTestSuite))
+}
@@ -0,0 +1,404 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"codeberg.org/gruf/go-kv"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// ContentLimitsGet returns all content limits, newest first.
+func (p *Processor) ContentLimitsGet(ctx context.Context) ([]*apimodel.ContentLimit, gtserror.WithCode) {
+	limits, err := p.state.DB.GetContentLimits(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting content limits: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiLimits := make([]*apimodel.ContentLimit, 0, len(limits))
+	for _, limit := range limits {
+		apiLimit, errWithCode := p.apiContentLimit(ctx, limit)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiLimits = append(apiLimits, apiLimit)
+	}
+
+	return apiLimits, nil
+}
+
+// ContentLimitGet returns the content limit with the given id.
+func (p *Processor) ContentLimitGet(ctx context.Context, id string) (*apimodel.ContentLimit, gtserror.WithCode) {
+	limit, errWithCode := p.getContentLimit(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiContentLimit(ctx, limit)
+}
+
+// ContentLimitCreate creates a content limit on either the
+// account or the domain given in the form, and processes its
+// side effects asynchronously as an admin action.
+func (p *Processor) ContentLimitCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.ContentLimitRequest,
+) (*apimodel.ContentLimit, gtserror.WithCode) {
+	limit := &gtsmodel.ContentLimit{
+		ID:                 id.NewULID(),
+		ForceSensitive:     util.Ptr(false),
+		RejectMedia:        util.Ptr(false),
+		CreatedByAccountID: adminAcct.ID,
+		CreatedByAccount:   adminAcct,
+	}
+
+	switch {
+	case form.AccountID != "" && form.Domain != "":
+		const text = "only one of account_id or domain should be provided"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+
+	case form.AccountID != "":
+		account, err := p.state.DB.GetAccountByID(ctx, form.AccountID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting account %s: %w", form.AccountID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if account == nil {
+			err := fmt.Errorf("account %s not found", form.AccountID)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		limit.AccountID = account.ID
+		limit.Account = account
+
+	case form.Domain != "":
+		domain, err := normalizeDomain(form.Domain)
+		if err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		if domain == config.GetHost() || domain == config.GetAccountDomain() {
+			const text = "cannot limit content of this instance's own domain"
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		limit.Domain = domain
+
+	default:
+		const text = "one of account_id or domain must be provided"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if errWithCode := applyContentLimitForm(limit, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.PutContentLimit(ctx, limit); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			const text = "a content limit already exists for this account or domain"
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+		}
+
+		err := gtserror.Newf("db error putting content limit: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if errWithCode := p.runContentLimitAction(ctx,
+		adminAcct,
+		limit,
+		gtsmodel.AdminActionLimitContent,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiContentLimit(ctx, limit)
+}
+
+// ContentLimitUpdate updates the content limit with the given id
+// using the given form, and processes its side effects asynchronously
+// as an admin action. The target of a content limit can't be changed.
+func (p *Processor) ContentLimitUpdate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+	form *apimodel.ContentLimitRequest,
+) (*apimodel.ContentLimit, gtserror.WithCode) {
+	limit, errWithCode := p.getContentLimit(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if (form.AccountID != "" && form.AccountID != limit.AccountID) ||
+		(form.Domain != "" && form.Domain != limit.Domain) {
+		const text = "account_id or domain of a content limit cannot be changed"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if errWithCode := applyContentLimitForm(limit, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.UpdateContentLimit(ctx, limit,
+		"force_sensitive",
+		"content_warning",
+		"reject_media",
+		"private_comment",
+	); err != nil {
+		err := gtserror.Newf("db error updating content limit: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if errWithCode := p.runContentLimitAction(ctx,
+		adminAcct,
+		limit,
+		gtsmodel.AdminActionLimitContent,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiContentLimit(ctx, limit)
+}
+
+// ContentLimitDelete removes the content limit with the given id,
+// and processes its side effects asynchronously as an admin action.
+func (p *Processor) ContentLimitDelete(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+) (*apimodel.ContentLimit, gtserror.WithCode) {
+	limit, errWithCode := p.getContentLimit(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Prepare the limit to return, *before* the deletion goes through.
+	apiLimit, errWithCode := p.apiContentLimit(ctx, limit)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteContentLimitByID(ctx, limit.ID); err != nil {
+		err := gtserror.Newf("db error deleting content limit: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if errWithCode := p.runContentLimitAction(ctx,
+		adminAcct,
+		limit,
+		gtsmodel.AdminActionUnlimitContent,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return apiLimit, nil
+}
+
+// applyContentLimitForm applies any set fields of the
+// given form to the given content limit, ensuring that
+// the resulting limit actually limits something.
+func applyContentLimitForm(
+	limit *gtsmodel.ContentLimit,
+	form *apimodel.ContentLimitRequest,
+) gtserror.WithCode {
+	if form.ForceSensitive != nil {
+		limit.ForceSensitive = form.ForceSensitive
+	}
+
+	if form.ContentWarning != nil {
+		cw := text.SanitizeToPlaintext(*form.ContentWarning)
+		limit.ContentWarning = strings.TrimSpace(cw)
+	}
+
+	if form.RejectMedia != nil {
+		limit.RejectMedia = form.RejectMedia
+	}
+
+	if form.PrivateComment != nil {
+		limit.PrivateComment = text.SanitizeToPlaintext(*form.PrivateComment)
+	}
+
+	if !*limit.ForceSensitive &&
+		limit.ContentWarning == "" &&
+		!*limit.RejectMedia {
+		const text = "content limit must set at least one of force_sensitive, content_warning, or reject_media"
+		return gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return nil
+}
+
+// runContentLimitAction records an admin action of the
+// given type for the given content limit, and processes
+// the side effects of the (changed) limit asynchronously.
+func (p *Processor) runContentLimitAction(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	limit *gtsmodel.ContentLimit,
+	actionType gtsmodel.AdminActionType,
+) gtserror.WithCode {
+	action := &gtsmodel.AdminAction{
+		ID:        id.NewULID(),
+		Type:      actionType,
+		AccountID: adminAcct.ID,
+		Text:      limit.PrivateComment,
+	}
+
+	if limit.AccountID != "" {
+		action.TargetCategory = gtsmodel.AdminActionCategoryAccount
+		action.TargetID = limit.AccountID
+		action.Target = limit.Account
+	} else {
+		action.TargetCategory = gtsmodel.AdminActionCategoryDomain
+		action.TargetID = limit.Domain
+		action.Target = limit.Domain
+	}
+
+	return p.actions.Run(
+		ctx,
+		action,
+		func(ctx context.Context) gtserror.MultiError {
+			// Log start + finish.
+			l := log.WithFields(kv.Fields{
+				{"target", action.Key()},
+				{"actionID", action.ID},
+			}...).WithContext(ctx)
+
+			l.Info("processing content limit side effects")
+			defer func() { l.Info("finished processing content limit side effects") }()
+
+			return p.contentLimitSideEffects(ctx, limit)
+		},
+	)
+}
+
+// contentLimitSideEffects unprepares the timelined statuses
+// of all accounts targeted by the given content limit, so
+// that they get prepared again with the current limits
+// applied next time they're shown.
+//
+// Note that for domain-level limits, only accounts on the
+// domain itself are ranged through, not its subdomains.
+func (p *Processor) contentLimitSideEffects(
+	ctx context.Context,
+	limit *gtsmodel.ContentLimit,
+) gtserror.MultiError {
+	var errs gtserror.MultiError
+
+	if limit.AccountID != "" {
+		if err := p.unprepareAccountStatuses(ctx, limit.AccountID); err != nil {
+			errs.Append(err)
+		}
+		return errs
+	}
+
+	if err := p.rangeDomainAccounts(ctx, limit.Domain, func(account *gtsmodel.Account) {
+		if err := p.unprepareAccountStatuses(ctx, account.ID); err != nil {
+			errs.Append(err)
+		}
+	}); err != nil {
+		errs.Appendf("db error ranging through accounts: %w", err)
+	}
+
+	return errs
+}
+
+// unprepareAccountStatuses unprepares all statuses
+// of the given account from home and list timelines.
+func (p *Processor) unprepareAccountStatuses(ctx context.Context, accountID string) error {
+	var (
+		limit = 50   // Limit selection to avoid spiking mem/cpu.
+		maxID string // Start with empty string to select from top.
+	)
+
+	for {
+		// Get (next) page of statuses.
+		statuses, err := p.state.DB.GetAccountStatuses(ctx,
+			accountID, limit, false, false, maxID, "", false, false,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting account statuses: %w", err)
+		}
+
+		if len(statuses) == 0 {
+			// No statuses left, we're done.
+			return nil
+		}
+
+		// Set next max ID for paging down.
+		maxID = statuses[len(statuses)-1].ID
+
+		for _, status := range statuses {
+			if err := p.state.Timelines.Home.UnprepareItemFromAllTimelines(ctx, status.ID); err != nil {
+				return gtserror.Newf("error unpreparing status from home timelines: %w", err)
+			}
+
+			if err := p.state.Timelines.List.UnprepareItemFromAllTimelines(ctx, status.ID); err != nil {
+				return gtserror.Newf("error unpreparing status from list timelines: %w", err)
+			}
+		}
+	}
+}
+
+// getContentLimit gets the content limit with
+// the given id, returning a 404 if not found.
+func (p *Processor) getContentLimit(ctx context.Context, id string) (*gtsmodel.ContentLimit, gtserror.WithCode) {
+	limit, err := p.state.DB.GetContentLimitByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting content limit %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if limit == nil {
+		err := fmt.Errorf("content limit %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return limit, nil
+}
+
+// apiContentLimit is a cheeky shortcut for returning the
+// API version of the given content limit, or an appropriate
+// error if something goes wrong.
+func (p *Processor) apiContentLimit(
+	ctx context.Context,
+	limit *gtsmodel.ContentLimit,
+) (*apimodel.ContentLimit, gtserror.WithCode) {
+	apiLimit, err := p.converter.ContentLimitToAPIContentLimit(ctx, limit)
+	if err != nil {
+		err := gtserror.Newf("error converting content limit to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiLimit, nil
+}
@@ -0,0 +1,155 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// DeliveryHealthGet returns the delivery health of all
+// domains that deliveries are currently failing to,
+// or that are marked as unavailable for deliveries.
+func (p *Processor) DeliveryHealthGet(ctx context.Context) ([]*apimodel.DeliveryHealth, gtserror.WithCode) {
+	hosts := p.state.Workers.Delivery.Health.Hosts()
+
+	apiHealths := make([]*apimodel.DeliveryHealth, 0, len(hosts))
+	for i := range hosts {
+		apiHealths = append(apiHealths, apiDeliveryHealth(&hosts[i]))
+	}
+
+	return apiHealths, nil
+}
+
+// DeliveryHealthReset resets the delivery health of the given
+// domain, marking it as available for deliveries again without
+// waiting for the next probe, and returns its previous health.
+func (p *Processor) DeliveryHealthReset(ctx context.Context, domain string) (*apimodel.DeliveryHealth, gtserror.WithCode) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if domain == "" {
+		const text = "domain must be provided"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	health := &p.state.Workers.Delivery.Health
+
+	prev, ok := health.Get(domain)
+	if !ok {
+		const text = "deliveries to domain are not failing"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	// Reset delivery health both
+	// in memory and in the database.
+	reset := delivery.HostHealth{Host: domain}
+	health.Set(reset)
+	p.StoreDeliveryHealth(ctx, reset)
+
+	return apiDeliveryHealth(&prev), nil
+}
+
+// FillDeliveryHealth loads the persisted delivery health of any instances
+// that deliveries were failing to from the database, into the delivery
+// worker pool's health tracker. This should be called on startup.
+func (p *Processor) FillDeliveryHealth(ctx context.Context) error {
+	instances, err := p.state.DB.GetInstancesFailingDelivery(ctx)
+	if err != nil {
+		return gtserror.Newf("error fetching instances from db: %w", err)
+	}
+
+	for _, instance := range instances {
+		p.state.Workers.Delivery.Health.Set(delivery.HostHealth{
+			Host:          instance.Domain,
+			FailingSince:  instance.DeliveryFailingSince,
+			UnavailableAt: instance.DeliveryUnavailableAt,
+		})
+	}
+
+	log.Infof(ctx, "loaded delivery health of %d failing instances", len(instances))
+	return nil
+}
+
+// StoreDeliveryHealth persists the given delivery health of a host to the
+// database entry of the instance on that host, if there is one. This is
+// intended to be set as the delivery worker pool's health change hook.
+func (p *Processor) StoreDeliveryHealth(ctx context.Context, health delivery.HostHealth) {
+	switch {
+	case health.Unavailable():
+		log.Warnf(ctx, "marking %s unavailable for deliveries, failing since %s: %s",
+			health.Host, util.FormatISO8601(health.FailingSince), health.LastError)
+	case health.FailingSince.IsZero():
+		log.Infof(ctx, "deliveries to %s succeeding again", health.Host)
+	}
+
+	instance, err := p.state.DB.GetInstance(
+		gtscontext.SetBarebones(ctx),
+		health.Host,
+	)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "error getting instance %s: %v", health.Host, err)
+		}
+		return
+	}
+
+	instance.DeliveryFailingSince = health.FailingSince
+	instance.DeliveryUnavailableAt = health.UnavailableAt
+
+	if err := p.state.DB.UpdateInstance(ctx, instance,
+		"delivery_failing_since",
+		"delivery_unavailable_at",
+	); err != nil {
+		log.Errorf(ctx, "error updating instance %s: %v", health.Host, err)
+	}
+}
+
+// apiDeliveryHealth converts the given
+// delivery health to its API representation.
+func apiDeliveryHealth(health *delivery.HostHealth) *apimodel.DeliveryHealth {
+	apiHealth := &apimodel.DeliveryHealth{
+		Domain:       health.Host,
+		Unavailable:  health.Unavailable(),
+		Failures:     health.Failures,
+		Skipped:      health.Skipped,
+		LastError:    health.LastError,
+		FailingSince: util.FormatISO8601(health.FailingSince),
+	}
+
+	if !health.LastFailure.IsZero() {
+		apiHealth.LastFailureAt = util.Ptr(util.FormatISO8601(health.LastFailure))
+	}
+
+	if health.Unavailable() {
+		apiHealth.UnavailableAt = util.Ptr(util.FormatISO8601(health.UnavailableAt))
+	}
+
+	if !health.LastProbe.IsZero() {
+		apiHealth.LastProbeAt = util.Ptr(util.FormatISO8601(health.LastProbe))
+	}
+
+	return apiHealth
+}
@@ -0,0 +1,99 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
+)
+
+type DeliveryHealthTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DeliveryHealthTestSuite) TestStoreFillDeliveryHealth() {
+	var (
+		ctx    = context.Background()
+		health = &suite.state.Workers.Delivery.Health
+		since  = time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+		at     = time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	)
+
+	// Mark a known instance as unavailable.
+	suite.adminProcessor.StoreDeliveryHealth(ctx, delivery.HostHealth{
+		Host:          "fossbros-anonymous.io",
+		FailingSince:  since,
+		UnavailableAt: at,
+	})
+
+	instance, err := suite.db.GetInstance(ctx, "fossbros-anonymous.io")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(instance.DeliveryFailingSince.Equal(since))
+	suite.True(instance.DeliveryUnavailableAt.Equal(at))
+
+	// Load persisted health into tracker.
+	if err := suite.adminProcessor.FillDeliveryHealth(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	hh, ok := health.Get("fossbros-anonymous.io")
+	suite.True(ok)
+	suite.True(hh.Unavailable())
+
+	// Probe delivery should be allowed straight
+	// away after loading, but not a second one.
+	suite.True(health.Allow("fossbros-anonymous.io"))
+	suite.False(health.Allow("fossbros-anonymous.io"))
+
+	apiHealth, errWithCode := suite.adminProcessor.DeliveryHealthGet(ctx)
+	suite.NoError(errWithCode)
+	suite.Len(apiHealth, 1)
+	suite.Equal("fossbros-anonymous.io", apiHealth[0].Domain)
+	suite.True(apiHealth[0].Unavailable)
+	suite.Equal(1, apiHealth[0].Skipped)
+
+	// Reset the instance's health.
+	prev, errWithCode := suite.adminProcessor.DeliveryHealthReset(ctx, "Fossbros-Anonymous.io")
+	suite.NoError(errWithCode)
+	suite.True(prev.Unavailable)
+
+	_, ok = health.Get("fossbros-anonymous.io")
+	suite.False(ok)
+
+	instance, err = suite.db.GetInstance(ctx, "fossbros-anonymous.io")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(instance.DeliveryFailingSince.IsZero())
+	suite.True(instance.DeliveryUnavailableAt.IsZero())
+
+	// Resetting again should 404.
+	_, errWithCode = suite.adminProcessor.DeliveryHealthReset(ctx, "fossbros-anonymous.io")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestDeliveryHealthTestSuite(t *testing.T) {
+	suite.Run(t, new(DeliveryHealthTestSuite))
+}
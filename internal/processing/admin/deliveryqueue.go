@@ -0,0 +1,221 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// DeliveryQueueGet returns a summary of the outgoing
+// deliveries currently pending to each domain, sorted
+// by the number of pending deliveries, most first.
+func (p *Processor) DeliveryQueueGet(ctx context.Context) ([]*apimodel.DeliveryQueue, gtserror.WithCode) {
+	queues := p.deliveryQueues(p.state.Workers.Delivery.Pending())
+
+	apiQueues := make([]*apimodel.DeliveryQueue, 0, len(queues))
+	for _, queue := range queues {
+		apiQueues = append(apiQueues, queue.toAPI())
+	}
+
+	return apiQueues, nil
+}
+
+// DeliveryQueuePurge drops all outgoing deliveries currently
+// pending to the given domain, returning the summary of
+// pending deliveries to that domain before they were dropped.
+func (p *Processor) DeliveryQueuePurge(ctx context.Context, domain string) (*apimodel.DeliveryQueue, gtserror.WithCode) {
+	queue, errWithCode := p.deliveryQueue(domain)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	n := p.state.Workers.Delivery.Purge(queue.domain)
+	log.Infof(ctx, "purged %d pending deliveries to %s", n, queue.domain)
+
+	return queue.toAPI(), nil
+}
+
+// DeliveryQueueRetry forces a retry of all failed outgoing deliveries
+// pending to the given domain, skipping their remaining backoff. The
+// domain's delivery health is also reset, so deliveries to it will not
+// be skipped if it was marked as unavailable. Returns the summary of
+// pending deliveries to that domain before they were retried.
+func (p *Processor) DeliveryQueueRetry(ctx context.Context, domain string) (*apimodel.DeliveryQueue, gtserror.WithCode) {
+	queue, errWithCode := p.deliveryQueue(domain)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Reset delivery health first, so
+	// retried deliveries aren't skipped.
+	health := &p.state.Workers.Delivery.Health
+	if _, ok := health.Get(queue.domain); ok {
+		reset := delivery.HostHealth{Host: queue.domain}
+		health.Set(reset)
+		p.StoreDeliveryHealth(ctx, reset)
+	}
+
+	n := p.state.Workers.Delivery.Retry(queue.domain)
+	log.Infof(ctx, "forced retry of %d pending deliveries to %s", n, queue.domain)
+
+	return queue.toAPI(), nil
+}
+
+// deliveryQueue returns the summary of pending
+// deliveries to domain, or an error if none.
+func (p *Processor) deliveryQueue(domain string) (*deliveryQueue, gtserror.WithCode) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if domain == "" {
+		const text = "domain must be provided"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Gather all pending deliveries to domain.
+	pending := p.state.Workers.Delivery.Pending()
+	pending = slices.DeleteFunc(pending, func(p delivery.Pending) bool {
+		return strings.ToLower(p.Host) != domain
+	})
+
+	queues := p.deliveryQueues(pending)
+	if len(queues) == 0 {
+		const text = "no deliveries pending to domain"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return queues[0], nil
+}
+
+// deliveryQueue is a summary of
+// deliveries pending to a domain.
+type deliveryQueue struct {
+	domain      string
+	queued      int
+	backlogged  int
+	attempts    int
+	maxAttempts int
+	oldest      time.Time
+	nextRetry   time.Time
+	lastError   string
+	lastErrorAt time.Time
+	unavailable bool
+}
+
+// deliveryQueues summarizes the given pending deliveries by
+// receiving domain, sorted by number pending, most first.
+func (p *Processor) deliveryQueues(pending []delivery.Pending) []*deliveryQueue {
+	byDomain := make(map[string]*deliveryQueue)
+
+	for _, dlv := range pending {
+		domain := strings.ToLower(dlv.Host)
+
+		queue, ok := byDomain[domain]
+		if !ok {
+			queue = &deliveryQueue{domain: domain}
+			byDomain[domain] = queue
+		}
+
+		if dlv.Backlogged {
+			queue.backlogged++
+			if queue.nextRetry.IsZero() ||
+				dlv.NextAttempt.Before(queue.nextRetry) {
+				queue.nextRetry = dlv.NextAttempt
+			}
+		} else {
+			queue.queued++
+		}
+
+		attempts := int(dlv.Attempts)
+		queue.attempts += attempts
+		queue.maxAttempts = max(queue.maxAttempts, attempts)
+
+		if !dlv.CreatedAt.IsZero() &&
+			(queue.oldest.IsZero() || dlv.CreatedAt.Before(queue.oldest)) {
+			queue.oldest = dlv.CreatedAt
+		}
+
+		if dlv.LastAttempt.After(queue.lastErrorAt) {
+			queue.lastError = dlv.LastError
+			queue.lastErrorAt = dlv.LastAttempt
+		}
+	}
+
+	queues := make([]*deliveryQueue, 0, len(byDomain))
+	for _, queue := range byDomain {
+		// Include any tracked delivery health of domain,
+		// which may have a later error than any pending.
+		if health, ok := p.state.Workers.Delivery.Health.Get(queue.domain); ok {
+			queue.unavailable = health.Unavailable()
+			if health.LastFailure.After(queue.lastErrorAt) {
+				queue.lastError = health.LastError
+				queue.lastErrorAt = health.LastFailure
+			}
+		}
+		queues = append(queues, queue)
+	}
+
+	slices.SortFunc(queues, func(a, b *deliveryQueue) int {
+		if c := cmp.Compare(
+			b.queued+b.backlogged,
+			a.queued+a.backlogged,
+		); c != 0 {
+			return c
+		}
+		return strings.Compare(a.domain, b.domain)
+	})
+
+	return queues
+}
+
+// toAPI converts the delivery queue
+// summary to its API representation.
+func (q *deliveryQueue) toAPI() *apimodel.DeliveryQueue {
+	apiQueue := &apimodel.DeliveryQueue{
+		Domain:      q.domain,
+		Queued:      q.queued,
+		Backlogged:  q.backlogged,
+		Attempts:    q.attempts,
+		MaxAttempts: q.maxAttempts,
+		LastError:   q.lastError,
+		Unavailable: q.unavailable,
+	}
+
+	if !q.oldest.IsZero() {
+		apiQueue.OldestCreatedAt = util.Ptr(util.FormatISO8601(q.oldest))
+	}
+
+	if !q.nextRetry.IsZero() {
+		apiQueue.NextRetryAt = util.Ptr(util.FormatISO8601(q.nextRetry))
+	}
+
+	if !q.lastErrorAt.IsZero() {
+		apiQueue.LastErrorAt = util.Ptr(util.FormatISO8601(q.lastErrorAt))
+	}
+
+	return apiQueue
+}
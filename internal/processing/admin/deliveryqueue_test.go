@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type DeliveryQueueTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DeliveryQueueTestSuite) queueDelivery(url string, createdAt time.Time) {
+	req, err := http.NewRequest("POST", url, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.state.Workers.Delivery.Queue.Push(&delivery.Delivery{
+		Request:   httpclient.WrapRequest(req),
+		CreatedAt: createdAt,
+	})
+}
+
+func (suite *DeliveryQueueTestSuite) TestDeliveryQueue() {
+	var (
+		ctx    = context.Background()
+		health = &suite.state.Workers.Delivery.Health
+		oldest = time.Now().Add(-time.Hour).Truncate(time.Second)
+	)
+
+	suite.queueDelivery("http://example.org/users/someone/inbox", time.Now())
+	suite.queueDelivery("http://fossbros-anonymous.io/users/foss_satan/inbox", time.Now())
+	suite.queueDelivery("http://fossbros-anonymous.io/inbox", oldest)
+
+	// Mark one of the domains as unavailable.
+	health.Set(delivery.HostHealth{
+		Host:          "fossbros-anonymous.io",
+		LastError:     "http response: 503 Service Unavailable",
+		LastFailure:   time.Now(),
+		FailingSince:  oldest,
+		UnavailableAt: oldest,
+	})
+
+	queues, errWithCode := suite.adminProcessor.DeliveryQueueGet(ctx)
+	suite.NoError(errWithCode)
+	suite.Len(queues, 2)
+
+	// Domain with most pending deliveries first.
+	suite.Equal("fossbros-anonymous.io", queues[0].Domain)
+	suite.Equal(2, queues[0].Queued)
+	suite.Equal(0, queues[0].Backlogged)
+	suite.Equal(util.FormatISO8601(oldest), *queues[0].OldestCreatedAt)
+	suite.Nil(queues[0].NextRetryAt)
+	suite.True(queues[0].Unavailable)
+	suite.Equal("http response: 503 Service Unavailable", queues[0].LastError)
+	suite.Equal("example.org", queues[1].Domain)
+	suite.Equal(1, queues[1].Queued)
+	suite.False(queues[1].Unavailable)
+	suite.Empty(queues[1].LastError)
+
+	// Forcing a retry resets the domain's health.
+	queue, errWithCode := suite.adminProcessor.DeliveryQueueRetry(ctx, "Fossbros-Anonymous.io")
+	suite.NoError(errWithCode)
+	suite.Equal(2, queue.Queued)
+	suite.True(queue.Unavailable)
+	_, ok := health.Get("fossbros-anonymous.io")
+	suite.False(ok)
+
+	// Purge the domain's deliveries.
+	queue, errWithCode = suite.adminProcessor.DeliveryQueuePurge(ctx, "fossbros-anonymous.io")
+	suite.NoError(errWithCode)
+	suite.Equal(2, queue.Queued)
+	suite.False(queue.Unavailable)
+
+	queues, errWithCode = suite.adminProcessor.DeliveryQueueGet(ctx)
+	suite.NoError(errWithCode)
+	suite.Len(queues, 1)
+	suite.Equal("example.org", queues[0].Domain)
+
+	// Purging again should 404.
+	_, errWithCode = suite.adminProcessor.DeliveryQueuePurge(ctx, "fossbros-anonymous.io")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Domain is required.
+	_, errWithCode = suite.adminProcessor.DeliveryQueueRetry(ctx, " ")
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestDeliveryQueueTestSuite(t *testing.T) {
+	suite.Run(t, new(DeliveryQueueTestSuite))
+}
@@ -333,3 +333,58 @@ func (p *Processor) DomainPermissionGet(
 
 	return p.apiDomainPerm(ctx, domainPerm, export)
 }
+
+// getDomainPermission returns the existing domain permission
+// of the given type for the given domain, or nil if it doesn't
+// exist. Returns an error only if something goes wrong in the db.
+func (p *Processor) getDomainPermission(
+	ctx context.Context,
+	permissionType gtsmodel.DomainPermissionType,
+	domain string,
+) (gtsmodel.DomainPermission, error) {
+	switch permissionType {
+	case gtsmodel.DomainPermissionBlock:
+		block, err := p.state.DB.GetDomainBlock(ctx, domain)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, err
+		}
+
+		if block == nil {
+			return nil, nil
+		}
+
+		return block, nil
+
+	case gtsmodel.DomainPermissionAllow:
+		allow, err := p.state.DB.GetDomainAllow(ctx, domain)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, err
+		}
+
+		if allow == nil {
+			return nil, nil
+		}
+
+		return allow, nil
+
+	default:
+		return nil, gtserror.Newf("unrecognized permission type %d", permissionType)
+	}
+}
+
+// updateDomainPermission updates the given domain permission
+// (*gtsmodel.DomainBlock or *gtsmodel.DomainAllow) in the db.
+func (p *Processor) updateDomainPermission(
+	ctx context.Context,
+	domainPermission gtsmodel.DomainPermission,
+	columns ...string,
+) error {
+	switch perm := domainPermission.(type) {
+	case *gtsmodel.DomainBlock:
+		return p.state.DB.UpdateDomainBlock(ctx, perm, columns...)
+	case *gtsmodel.DomainAllow:
+		return p.state.DB.UpdateDomainAllow(ctx, perm, columns...)
+	default:
+		return gtserror.Newf("unrecognized domain permission %T", domainPermission)
+	}
+}
@@ -0,0 +1,273 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// DomainPermissionDraftGet returns one
+// domain permission draft with the given id.
+func (p *Processor) DomainPermissionDraftGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.DomainPermission, gtserror.WithCode) {
+	draft, errWithCode := p.getDomainPermissionDraft(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiDomainPerm(ctx, draft, false)
+}
+
+// DomainPermissionDraftsGet returns domain permission drafts,
+// optionally filtered by permission type, subscription ID and
+// domain. Pass zero values to not filter on that field.
+func (p *Processor) DomainPermissionDraftsGet(
+	ctx context.Context,
+	permissionType gtsmodel.DomainPermissionType,
+	subscriptionID string,
+	domain string,
+) ([]*apimodel.DomainPermission, gtserror.WithCode) {
+	drafts, err := p.state.DB.GetDomainPermissionDrafts(ctx,
+		permissionType,
+		subscriptionID,
+		domain,
+	)
+	if err != nil {
+		err := gtserror.Newf("db error getting domain permission drafts: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiDrafts := make([]*apimodel.DomainPermission, len(drafts))
+	for i, draft := range drafts {
+		apiDraft, errWithCode := p.apiDomainPerm(ctx, draft, false)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiDrafts[i] = apiDraft
+	}
+
+	return apiDrafts, nil
+}
+
+// DomainPermissionDraftCreate creates a domain permission
+// draft of the given type for the given domain, which will
+// not take effect until accepted by an admin.
+func (p *Processor) DomainPermissionDraftCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	domain string,
+	permissionType gtsmodel.DomainPermissionType,
+	obfuscate bool,
+	publicComment string,
+	privateComment string,
+) (*apimodel.DomainPermission, gtserror.WithCode) {
+	if permissionType != gtsmodel.DomainPermissionBlock &&
+		permissionType != gtsmodel.DomainPermissionAllow {
+		const text = "permission_type must be one of: block, allow"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	domain, err := normalizeDomain(domain)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	draft := &gtsmodel.DomainPermissionDraft{
+		ID:                 id.NewULID(),
+		PermissionType:     permissionType,
+		Domain:             domain,
+		CreatedByAccountID: adminAcct.ID,
+		CreatedByAccount:   adminAcct,
+		PrivateComment:     text.SanitizeToPlaintext(privateComment),
+		PublicComment:      text.SanitizeToPlaintext(publicComment),
+		Obfuscate:          &obfuscate,
+	}
+
+	if err := p.state.DB.PutDomainPermissionDraft(ctx, draft); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err := fmt.Errorf("a domain %s draft already exists for %s", permissionType.String(), domain)
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+
+		err := gtserror.Newf("db error putting domain permission draft: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDomainPerm(ctx, draft, false)
+}
+
+// DomainPermissionDraftAccept converts the domain permission
+// draft with the given id into a domain permission of the
+// draft's type, and then removes the draft.
+//
+// If a permission of the same type already exists for the
+// draft's domain, then a conflict error is returned, unless
+// overwrite is true, in which case the existing permission
+// is updated with the values from the draft.
+//
+// Return values for this function are the new or updated
+// domain permission, the ID of the admin action resulting
+// from this call (if any), and/or an error.
+func (p *Processor) DomainPermissionDraftAccept(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+	overwrite bool,
+) (*apimodel.DomainPermission, string, gtserror.WithCode) {
+	draft, errWithCode := p.getDomainPermissionDraft(ctx, id)
+	if errWithCode != nil {
+		return nil, "", errWithCode
+	}
+
+	existing, err := p.getDomainPermission(ctx, draft.PermissionType, draft.Domain)
+	if err != nil {
+		err := gtserror.Newf("db error getting existing domain permission: %w", err)
+		return nil, "", gtserror.NewErrorInternalError(err)
+	}
+
+	var (
+		apiPerm  *apimodel.DomainPermission
+		actionID string
+	)
+
+	if existing == nil {
+		// No permission yet, create
+		// it and process side effects.
+		apiPerm, actionID, errWithCode = p.DomainPermissionCreate(
+			ctx,
+			draft.PermissionType,
+			adminAcct,
+			draft.Domain,
+			*draft.Obfuscate,
+			draft.PublicComment,
+			draft.PrivateComment,
+			draft.SubscriptionID,
+		)
+		if errWithCode != nil {
+			return nil, "", errWithCode
+		}
+	} else {
+		if !overwrite {
+			err := fmt.Errorf(
+				"a domain %s already exists for %s; set overwrite to true to replace it",
+				draft.PermissionType.String(), draft.Domain,
+			)
+			return nil, "", gtserror.NewErrorConflict(err, err.Error())
+		}
+
+		// Update the existing permission
+		// with the values from the draft.
+		switch perm := existing.(type) {
+		case *gtsmodel.DomainBlock:
+			perm.Obfuscate = draft.Obfuscate
+			perm.PublicComment = draft.PublicComment
+			perm.PrivateComment = draft.PrivateComment
+			perm.SubscriptionID = draft.SubscriptionID
+		case *gtsmodel.DomainAllow:
+			perm.Obfuscate = draft.Obfuscate
+			perm.PublicComment = draft.PublicComment
+			perm.PrivateComment = draft.PrivateComment
+			perm.SubscriptionID = draft.SubscriptionID
+		}
+
+		if err := p.updateDomainPermission(ctx, existing,
+			"obfuscate",
+			"public_comment",
+			"private_comment",
+			"subscription_id",
+		); err != nil {
+			err := gtserror.Newf("db error updating domain permission: %w", err)
+			return nil, "", gtserror.NewErrorInternalError(err)
+		}
+
+		apiPerm, errWithCode = p.apiDomainPerm(ctx, existing, false)
+		if errWithCode != nil {
+			return nil, "", errWithCode
+		}
+	}
+
+	// Permission is in place, the draft is no longer needed.
+	if err := p.state.DB.DeleteDomainPermissionDraft(ctx, draft.ID); err != nil {
+		err := gtserror.Newf("db error deleting domain permission draft: %w", err)
+		return nil, actionID, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPerm, actionID, nil
+}
+
+// DomainPermissionDraftRemove removes the domain permission
+// draft with the given id, without applying it, returning
+// the removed draft.
+//
+// Note that if the draft was created by a subscription, and
+// the domain is still present in the subscribed list, the
+// draft will be created again the next time it's fetched.
+func (p *Processor) DomainPermissionDraftRemove(
+	ctx context.Context,
+	id string,
+) (*apimodel.DomainPermission, gtserror.WithCode) {
+	draft, errWithCode := p.getDomainPermissionDraft(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Prepare the draft to return, *before* the deletion goes through.
+	apiDraft, errWithCode := p.apiDomainPerm(ctx, draft, false)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteDomainPermissionDraft(ctx, draft.ID); err != nil {
+		err := gtserror.Newf("db error deleting domain permission draft: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiDraft, nil
+}
+
+// getDomainPermissionDraft is a shortcut for getting
+// a draft by ID, returning an appropriate error code.
+func (p *Processor) getDomainPermissionDraft(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.DomainPermissionDraft, gtserror.WithCode) {
+	draft, err := p.state.DB.GetDomainPermissionDraftByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := fmt.Errorf("no domain permission draft exists with id %s", id)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		err := gtserror.Newf("db error getting domain permission draft %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return draft, nil
+}
@@ -0,0 +1,443 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// apiDomainPermSub is a cheeky shortcut for returning the
+// API version of the given domain permission subscription,
+// or an appropriate error if something goes wrong.
+func (p *Processor) apiDomainPermSub(
+	ctx context.Context,
+	sub *gtsmodel.DomainPermissionSubscription,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	apiSub, err := p.converter.DomainPermSubToAPIDomainPermSub(ctx, sub)
+	if err != nil {
+		err := gtserror.NewfAt(3, "error converting domain permission subscription to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiSub, nil
+}
+
+// DomainPermissionSubscriptionGet returns one
+// domain permission subscription with the given id.
+func (p *Processor) DomainPermissionSubscriptionGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	sub, errWithCode := p.getDomainPermissionSubscription(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiDomainPermSub(ctx, sub)
+}
+
+// DomainPermissionSubscriptionsGet returns all domain permission
+// subscriptions of the given type (Unknown for any type), in
+// descending order of priority.
+func (p *Processor) DomainPermissionSubscriptionsGet(
+	ctx context.Context,
+	permissionType gtsmodel.DomainPermissionType,
+) ([]*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	subs, err := p.state.DB.GetDomainPermissionSubscriptions(ctx, permissionType)
+	if err != nil {
+		err := gtserror.Newf("db error getting domain permission subscriptions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiSubs := make([]*apimodel.DomainPermissionSubscription, len(subs))
+	for i, sub := range subs {
+		apiSub, errWithCode := p.apiDomainPermSub(ctx, sub)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiSubs[i] = apiSub
+	}
+
+	return apiSubs, nil
+}
+
+// DomainPermissionSubscriptionCreate creates a new subscription
+// to the domain permission list at the given URI. The list will
+// be fetched and processed during the next scheduled run of
+// domain permission subscriptions processing.
+func (p *Processor) DomainPermissionSubscriptionCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.DomainPermissionSubscriptionRequest,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	sub := &gtsmodel.DomainPermissionSubscription{
+		ID:                 id.NewULID(),
+		CreatedByAccountID: adminAcct.ID,
+		CreatedByAccount:   adminAcct,
+	}
+
+	// Permission type must be set on creation.
+	if form.PermissionType == nil {
+		const text = "permission_type must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	sub.PermissionType = gtsmodel.NewDomainPermissionType(*form.PermissionType)
+	if sub.PermissionType == gtsmodel.DomainPermissionUnknown {
+		const text = "permission_type must be one of: block, allow"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// URI and content type must be set on creation.
+	if form.URI == nil || form.ContentType == nil {
+		const text = "uri and content_type must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Subscriptions are created
+	// as drafts unless specified.
+	asDraft := true
+	if form.AsDraft != nil {
+		asDraft = *form.AsDraft
+	}
+	sub.AsDraft = &asDraft
+
+	if errWithCode := applyDomainPermSubForm(sub, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.PutDomainPermissionSubscription(ctx, sub); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err := fmt.Errorf("a domain permission subscription already exists for uri %s", sub.URI)
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+
+		err := gtserror.Newf("db error putting domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDomainPermSub(ctx, sub)
+}
+
+// DomainPermissionSubscriptionUpdate updates the domain permission
+// subscription with the given id, using the set fields of the given
+// form. The permission type of a subscription cannot be changed.
+//
+// Any change resets stored ETag and Last-Modified values, so that
+// the list is fully fetched and re-processed on the next run.
+func (p *Processor) DomainPermissionSubscriptionUpdate(
+	ctx context.Context,
+	id string,
+	form *apimodel.DomainPermissionSubscriptionRequest,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	sub, errWithCode := p.getDomainPermissionSubscription(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.PermissionType != nil &&
+		gtsmodel.NewDomainPermissionType(*form.PermissionType) != sub.PermissionType {
+		const text = "permission_type of a subscription cannot be changed"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if form.AsDraft != nil {
+		sub.AsDraft = form.AsDraft
+	}
+
+	if errWithCode := applyDomainPermSubForm(sub, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	sub.ETag = ""
+	sub.LastModified = time.Time{}
+
+	if err := p.state.DB.UpdateDomainPermissionSubscription(ctx, sub,
+		"priority",
+		"title",
+		"as_draft",
+		"uri",
+		"content_type",
+		"fetch_username",
+		"fetch_password",
+		"etag",
+		"last_modified",
+	); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err := fmt.Errorf("a domain permission subscription already exists for uri %s", sub.URI)
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+
+		err := gtserror.Newf("db error updating domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDomainPermSub(ctx, sub)
+}
+
+// DomainPermissionSubscriptionRemove removes the domain permission
+// subscription with the given id, along with any drafts it created.
+//
+// If removeChildren is true, domain permissions created by the
+// subscription will also be removed (processing side effects).
+// Otherwise, they will be orphaned, ie., kept in place but no
+// longer associated with any subscription.
+func (p *Processor) DomainPermissionSubscriptionRemove(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+	removeChildren bool,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	sub, errWithCode := p.getDomainPermissionSubscription(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Prepare the subscription to return, *before* the deletion goes through.
+	apiSub, errWithCode := p.apiDomainPermSub(ctx, sub)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Remove any drafts created by the subscription.
+	drafts, err := p.state.DB.GetDomainPermissionDrafts(ctx, sub.PermissionType, sub.ID, "")
+	if err != nil {
+		err := gtserror.Newf("db error getting domain permission drafts: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, draft := range drafts {
+		if err := p.state.DB.DeleteDomainPermissionDraft(ctx, draft.ID); err != nil {
+			err := gtserror.Newf("db error deleting domain permission draft: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// Remove or orphan permissions created by the subscription.
+	perms, err := p.getSubscriptionDomainPermissions(ctx, sub)
+	if err != nil {
+		err := gtserror.Newf("db error getting subscription domain permissions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, perm := range perms {
+		if removeChildren {
+			if _, _, errWithCode := p.DomainPermissionDelete(ctx,
+				sub.PermissionType,
+				adminAcct,
+				perm.GetID(),
+			); errWithCode != nil {
+				return nil, errWithCode
+			}
+
+			continue
+		}
+
+		if err := p.orphanDomainPermission(ctx, perm); err != nil {
+			err := gtserror.Newf("db error orphaning domain permission: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if err := p.state.DB.DeleteDomainPermissionSubscription(ctx, sub.ID); err != nil {
+		err := gtserror.Newf("db error deleting domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiSub, nil
+}
+
+// DomainPermissionSubscriptionTest fetches and parses the list
+// of the domain permission subscription with the given id,
+// returning the resulting domain permissions *without* storing
+// or applying them, so that admins can check a list is parsed
+// as expected before it's processed for real.
+func (p *Processor) DomainPermissionSubscriptionTest(
+	ctx context.Context,
+	id string,
+) ([]*apimodel.DomainPermission, gtserror.WithCode) {
+	sub, errWithCode := p.getDomainPermissionSubscription(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Fetch without cache headers, so we always get the full list.
+	perms, _, err := p.fetchDomainPermissions(ctx, sub, true)
+	if err != nil {
+		err := gtserror.Newf("error fetching domain permission list: %w", err)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	apiPerms := make([]*apimodel.DomainPermission, len(perms))
+	for i, perm := range perms {
+		apiPerm, errWithCode := p.apiDomainPerm(ctx, perm, true)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiPerms[i] = apiPerm
+	}
+
+	return apiPerms, nil
+}
+
+// getDomainPermissionSubscription is a shortcut for getting
+// a subscription by ID, returning an appropriate error code.
+func (p *Processor) getDomainPermissionSubscription(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.DomainPermissionSubscription, gtserror.WithCode) {
+	sub, err := p.state.DB.GetDomainPermissionSubscriptionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := fmt.Errorf("no domain permission subscription exists with id %s", id)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		err := gtserror.Newf("db error getting domain permission subscription %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return sub, nil
+}
+
+// getSubscriptionDomainPermissions returns all domain
+// permissions that were created by the given subscription.
+func (p *Processor) getSubscriptionDomainPermissions(
+	ctx context.Context,
+	sub *gtsmodel.DomainPermissionSubscription,
+) ([]gtsmodel.DomainPermission, error) {
+	var perms []gtsmodel.DomainPermission
+
+	switch sub.PermissionType {
+	case gtsmodel.DomainPermissionBlock:
+		blocks, err := p.state.DB.GetDomainBlocks(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, block := range blocks {
+			if block.SubscriptionID == sub.ID {
+				perms = append(perms, block)
+			}
+		}
+
+	case gtsmodel.DomainPermissionAllow:
+		allows, err := p.state.DB.GetDomainAllows(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, allow := range allows {
+			if allow.SubscriptionID == sub.ID {
+				perms = append(perms, allow)
+			}
+		}
+	}
+
+	return perms, nil
+}
+
+// orphanDomainPermission unsets the subscription ID
+// of the given domain permission, so that it's no
+// longer managed by any subscription.
+func (p *Processor) orphanDomainPermission(
+	ctx context.Context,
+	perm gtsmodel.DomainPermission,
+) error {
+	return p.setDomainPermissionSubscriptionID(ctx, perm, "")
+}
+
+// setDomainPermissionSubscriptionID sets the
+// subscription ID of the given domain permission.
+func (p *Processor) setDomainPermissionSubscriptionID(
+	ctx context.Context,
+	perm gtsmodel.DomainPermission,
+	subscriptionID string,
+) error {
+	switch perm := perm.(type) {
+	case *gtsmodel.DomainBlock:
+		perm.SubscriptionID = subscriptionID
+	case *gtsmodel.DomainAllow:
+		perm.SubscriptionID = subscriptionID
+	}
+
+	return p.updateDomainPermission(ctx, perm, "subscription_id")
+}
+
+// applyDomainPermSubForm validates and sets the given
+// form's priority, title, uri, content type and fetch
+// credentials on the given subscription, where set.
+func applyDomainPermSubForm(
+	sub *gtsmodel.DomainPermissionSubscription,
+	form *apimodel.DomainPermissionSubscriptionRequest,
+) gtserror.WithCode {
+	if form.Priority != nil {
+		priority := *form.Priority
+		if priority < 0 || priority > 255 {
+			const text = "priority must be a number in the range 0 to 255"
+			return gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+		sub.Priority = uint8(priority)
+	}
+
+	if form.Title != nil {
+		sub.Title = text.SanitizeToPlaintext(*form.Title)
+	}
+
+	if form.URI != nil {
+		uri, err := url.Parse(*form.URI)
+		if err != nil ||
+			(uri.Scheme != "https" && uri.Scheme != "http") ||
+			uri.Host == "" {
+			const text = "uri must be a valid http or https url"
+			return gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+		sub.URI = uri.String()
+	}
+
+	if form.ContentType != nil {
+		sub.ContentType = gtsmodel.NewDomainPermSubContentType(*form.ContentType)
+		if sub.ContentType == gtsmodel.DomainPermSubContentTypeUnknown {
+			const text = "content_type must be one of: text/csv, application/json, text/plain"
+			return gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+	}
+
+	if form.FetchUsername != nil {
+		sub.FetchUsername = *form.FetchUsername
+	}
+
+	if form.FetchPassword != nil {
+		sub.FetchPassword = *form.FetchPassword
+	}
+
+	return nil
+}
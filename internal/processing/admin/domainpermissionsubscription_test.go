@@ -0,0 +1,330 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DomainPermissionSubscriptionTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) createSubscription(
+	permType string,
+	uri string,
+	contentType string,
+	priority int,
+	asDraft bool,
+) *apimodel.DomainPermissionSubscription {
+	sub, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionCreate(
+		context.Background(),
+		suite.testAccounts["admin_account"],
+		&apimodel.DomainPermissionSubscriptionRequest{
+			Priority:       &priority,
+			PermissionType: &permType,
+			AsDraft:        &asDraft,
+			URI:            &uri,
+			ContentType:    &contentType,
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	return sub
+}
+
+// processSubscriptions processes all subscriptions
+// and waits for any resulting admin actions to finish.
+func (suite *DomainPermissionSubscriptionTestSuite) processSubscriptions(ctx context.Context) {
+	suite.adminProcessor.ProcessDomainPermissionSubscriptions(ctx)
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin actions to finish")
+	}
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) subBlocks(subID string) map[string]*gtsmodel.DomainBlock {
+	blocks, err := suite.db.GetDomainBlocks(context.Background())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	subBlocks := make(map[string]*gtsmodel.DomainBlock)
+	for _, block := range blocks {
+		if block.SubscriptionID == subID {
+			subBlocks[block.Domain] = block
+		}
+	}
+	return subBlocks
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessCSV() {
+	var (
+		ctx = context.Background()
+		sub = testrig.NewTestDomainPermissionSubscriptions()["admin_sub_1"]
+	)
+
+	suite.processSubscriptions(ctx)
+
+	// Suspend-severity entries should now be
+	// blocked, but the silence entry ignored.
+	blocks := suite.subBlocks(sub.ID)
+	suite.Len(blocks, 3)
+	suite.Contains(blocks, "bumfaces.net")
+	suite.Contains(blocks, "peepee.poopoo")
+	suite.Contains(blocks, "nothanks.com")
+	suite.Equal("big jerks", blocks["bumfaces.net"].PublicComment)
+
+	// Manually created block should be untouched.
+	block, err := suite.db.GetDomainBlock(ctx, "replyguys.com")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(block.SubscriptionID)
+
+	// Fetch outcome should be stored.
+	dbSub, err := suite.db.GetDomainPermissionSubscriptionByID(ctx, sub.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(dbSub.Error)
+	suite.NotZero(dbSub.FetchedAt)
+	suite.Equal(dbSub.FetchedAt, dbSub.SuccessfullyFetchedAt)
+	suite.Equal(`"baddies-csv-v1"`, dbSub.ETag)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessNotModified() {
+	var (
+		ctx = context.Background()
+		sub = testrig.NewTestDomainPermissionSubscriptions()["admin_sub_1"]
+	)
+
+	suite.processSubscriptions(ctx)
+
+	// Remove one of the blocks behind the subscription's back.
+	block := suite.subBlocks(sub.ID)["nothanks.com"]
+	if err := suite.db.DeleteDomainBlock(ctx, block.Domain); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// List hasn't changed (ETag matches) so
+	// processing again shouldn't recreate it.
+	suite.processSubscriptions(ctx)
+	suite.Len(suite.subBlocks(sub.ID), 2)
+
+	// After an update the ETag is reset,
+	// so the list will be processed again.
+	title := "new title"
+	if _, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionUpdate(ctx,
+		sub.ID,
+		&apimodel.DomainPermissionSubscriptionRequest{Title: &title},
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.processSubscriptions(ctx)
+	suite.Len(suite.subBlocks(sub.ID), 3)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessPlainAsDrafts() {
+	ctx := context.Background()
+
+	sub := suite.createSubscription(
+		"block",
+		"https://lists.example.org/goodies.txt",
+		"text/plain",
+		0,
+		true,
+	)
+
+	suite.processSubscriptions(ctx)
+
+	drafts, errWithCode := suite.adminProcessor.DomainPermissionDraftsGet(ctx,
+		gtsmodel.DomainPermissionBlock,
+		sub.ID,
+		"",
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	domains := make([]string, 0, len(drafts))
+	for _, draft := range drafts {
+		domains = append(domains, draft.Domain.Domain)
+	}
+	suite.ElementsMatch([]string{"goodeggs.org", "rainbowfriends.net"}, domains)
+
+	// Accept one draft; it should turn into a
+	// block belonging to the subscription.
+	perm, _, errWithCode := suite.adminProcessor.DomainPermissionDraftAccept(ctx,
+		suite.testAccounts["admin_account"],
+		drafts[0].ID,
+		false,
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(sub.ID, perm.SubscriptionID)
+
+	_, errWithCode = suite.adminProcessor.DomainPermissionDraftGet(ctx, drafts[0].ID)
+	suite.NotNil(errWithCode)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessPriority() {
+	var (
+		ctx     = context.Background()
+		highSub = testrig.NewTestDomainPermissionSubscriptions()["admin_sub_1"]
+	)
+
+	// Lower priority sub listing the same domains.
+	lowSub := suite.createSubscription(
+		"block",
+		"https://lists.example.org/baddies.txt",
+		"text/plain",
+		10,
+		false,
+	)
+
+	suite.processSubscriptions(ctx)
+
+	// Higher priority sub should own everything.
+	suite.Len(suite.subBlocks(highSub.ID), 3)
+	suite.Empty(suite.subBlocks(lowSub.ID))
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestTest() {
+	ctx := context.Background()
+
+	sub := suite.createSubscription(
+		"block",
+		"https://lists.example.org/baddies.json",
+		"application/json",
+		0,
+		false,
+	)
+
+	perms, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionTest(ctx, sub.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(perms, 3)
+	suite.Equal("bumfaces.net", perms[0].Domain.Domain)
+	suite.Equal("big jerks", perms[0].PublicComment)
+
+	// Testing shouldn't create anything.
+	suite.Empty(suite.subBlocks(sub.ID))
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestTestTooLarge() {
+	ctx := context.Background()
+
+	sub := suite.createSubscription(
+		"block",
+		"https://lists.example.org/huge.txt",
+		"text/plain",
+		0,
+		false,
+	)
+
+	// An oversized list should error, not be truncated.
+	perms, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionTest(ctx, sub.ID)
+	suite.Nil(perms)
+	suite.ErrorContains(errWithCode, "list exceeds maximum size")
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestRemove() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		sub       = testrig.NewTestDomainPermissionSubscriptions()["admin_sub_1"]
+	)
+
+	suite.processSubscriptions(ctx)
+	blocks := suite.subBlocks(sub.ID)
+	suite.Len(blocks, 3)
+
+	// Remove without children; blocks should be orphaned.
+	if _, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionRemove(ctx,
+		adminAcct,
+		sub.ID,
+		false,
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(suite.subBlocks(""), 4)
+
+	_, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionGet(ctx, sub.ID)
+	suite.NotNil(errWithCode)
+
+	// Remove with children; blocks should be gone.
+	newSub := suite.createSubscription(
+		"block",
+		"https://lists.example.org/goodies.txt",
+		"text/plain",
+		0,
+		false,
+	)
+	suite.processSubscriptions(ctx)
+	suite.Len(suite.subBlocks(newSub.ID), 2)
+
+	if _, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionRemove(ctx,
+		adminAcct,
+		newSub.ID,
+		true,
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(suite.subBlocks(newSub.ID))
+
+	_, err := suite.db.GetDomainBlock(ctx, "goodeggs.org")
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestCreateInvalid() {
+	var (
+		ctx         = context.Background()
+		permType    = "block"
+		uri         = "ftp://lists.example.org/baddies.txt"
+		contentType = "text/plain"
+	)
+
+	_, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionCreate(ctx,
+		suite.testAccounts["admin_account"],
+		&apimodel.DomainPermissionSubscriptionRequest{
+			PermissionType: &permType,
+			URI:            &uri,
+			ContentType:    &contentType,
+			AsDraft:        util.Ptr(true),
+		},
+	)
+	suite.NotNil(errWithCode)
+}
+
+func TestDomainPermissionSubscriptionTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainPermissionSubscriptionTestSuite))
+}
@@ -0,0 +1,623 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"codeberg.org/gruf/go-kv"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// maxDomainPermListSize is the maximum number of bytes
+// we'll read from a domain permission subscription list.
+const maxDomainPermListSize = 16 << 20 // 16MiB
+
+// ScheduleDomainPermissionSubscriptions schedules processing
+// of domain permission subscriptions using configured parameters.
+//
+// Returns an error if `InstanceSubscriptionsProcessFrom`
+// is not a valid format (hh:mm).
+func (p *Processor) ScheduleDomainPermissionSubscriptions() error {
+	const hourMinute = "15:04"
+
+	var (
+		now            = time.Now()
+		processEvery   = config.GetInstanceSubscriptionsProcessEvery()
+		processFromStr = config.GetInstanceSubscriptionsProcessFrom()
+	)
+
+	// Parse processFromStr as hh:mm.
+	// Resulting time will be on 1 Jan year zero.
+	processFrom, err := time.Parse(hourMinute, processFromStr)
+	if err != nil {
+		return gtserror.Newf(
+			"error parsing '%s' in time format 'hh:mm': %w",
+			processFromStr, err,
+		)
+	}
+
+	// Time travel from
+	// year zero, groovy.
+	firstProcessAt := time.Date(
+		now.Year(),
+		now.Month(),
+		now.Day(),
+		processFrom.Hour(),
+		processFrom.Minute(),
+		0,
+		0,
+		now.Location(),
+	)
+
+	// Ensure first processing is in the future.
+	for firstProcessAt.Before(now) {
+		firstProcessAt = firstProcessAt.Add(processEvery)
+	}
+
+	fn := func(ctx context.Context, start time.Time) {
+		log.Info(ctx, "starting domain permission subscriptions processing")
+		p.ProcessDomainPermissionSubscriptions(ctx)
+		log.Infof(ctx, "finished domain permission subscriptions processing after %s", time.Since(start))
+	}
+
+	log.Infof(nil,
+		"scheduling domain permission subscriptions processing to run every %s, starting from %s; next run will be at %s",
+		processEvery, processFromStr, firstProcessAt,
+	)
+
+	// Schedule processing to execute according to schedule.
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@domainpermsubs",
+		firstProcessAt,
+		processEvery,
+		fn,
+	) {
+		panic("failed to schedule @domainpermsubs")
+	}
+
+	return nil
+}
+
+// ProcessDomainPermissionSubscriptions fetches and processes
+// all domain permission subscriptions, creating and removing
+// domain permissions (or drafts) accordingly.
+//
+// Allow subscriptions are processed before block subscriptions,
+// so that new allows can shield domains from block side effects.
+// Within each type, subscriptions are processed in descending
+// order of priority, so that higher priority subscriptions get
+// first claim on any domains that appear in multiple lists.
+func (p *Processor) ProcessDomainPermissionSubscriptions(ctx context.Context) {
+	for _, permType := range []gtsmodel.DomainPermissionType{
+		gtsmodel.DomainPermissionAllow,
+		gtsmodel.DomainPermissionBlock,
+	} {
+		subs, err := p.state.DB.GetDomainPermissionSubscriptions(ctx, permType)
+		if err != nil {
+			log.Errorf(ctx, "db error getting domain %s subscriptions: %v", permType.String(), err)
+			continue
+		}
+
+		// Gather priorities of all subs of this type
+		// so we know which subs may claim which perms.
+		priorities := make(map[string]uint8, len(subs))
+		for _, sub := range subs {
+			priorities[sub.ID] = sub.Priority
+		}
+
+		for _, sub := range subs {
+			if err := p.processDomainPermissionSubscription(ctx, sub, priorities); err != nil {
+				log.Errorf(ctx, "error processing domain permission subscription %s: %v", sub.URI, err)
+			}
+		}
+	}
+}
+
+// processDomainPermissionSubscription fetches the given subscription's
+// list and, if it has changed since the last successful fetch, creates
+// and removes domain permissions or drafts to match the list.
+//
+// Fetch outcome is stored on the subscription in the database. The
+// list's cache headers are only stored once every entry has been
+// applied, so that a partially applied list is fetched again in full.
+func (p *Processor) processDomainPermissionSubscription(
+	ctx context.Context,
+	sub *gtsmodel.DomainPermissionSubscription,
+	priorities map[string]uint8,
+) error {
+	perms, notModified, err := p.fetchDomainPermissions(ctx, sub, false)
+	sub.FetchedAt = time.Now()
+
+	if err == nil && !notModified && len(perms) == 0 {
+		// Don't trust an empty list, as that would cause us to
+		// remove all permissions created by this subscription.
+		err = errors.New("fetched list contained no valid entries")
+	}
+
+	if err != nil {
+		// Store the error on the subscription so admins can see it.
+		sub.Error = err.Error()
+		if err := p.state.DB.UpdateDomainPermissionSubscription(ctx, sub,
+			"fetched_at",
+			"error",
+		); err != nil {
+			log.Errorf(ctx, "db error updating domain permission subscription: %v", err)
+		}
+		return err
+	}
+
+	// Fetch was successful.
+	sub.SuccessfullyFetchedAt = sub.FetchedAt
+	sub.Error = ""
+	if err := p.state.DB.UpdateDomainPermissionSubscription(ctx, sub,
+		"fetched_at",
+		"successfully_fetched_at",
+		"error",
+	); err != nil {
+		return gtserror.Newf("db error updating domain permission subscription: %w", err)
+	}
+
+	if notModified {
+		// Nothing changed
+		// since last time.
+		return nil
+	}
+
+	// Permissions created by the subscription
+	// are created on behalf of its creator.
+	adminAcct, err := p.state.DB.GetAccountByID(ctx, sub.CreatedByAccountID)
+	if err != nil {
+		return gtserror.Newf("db error getting subscription creator account: %w", err)
+	}
+
+	l := log.WithContext(ctx).WithFields(kv.Fields{
+		{"subscription", sub.ID},
+		{"uri", sub.URI},
+	}...)
+
+	// Number of entries that
+	// couldn't be applied.
+	var failed int
+
+	// Create (or claim) permissions
+	// for each domain in the list.
+	domains := make(map[string]struct{}, len(perms))
+	for _, perm := range perms {
+		domains[perm.Domain] = struct{}{}
+		if err := p.applyDomainPermSubEntry(ctx, sub, adminAcct, perm, priorities); err != nil {
+			l.Errorf("error applying entry for %s: %v", perm.Domain, err)
+			failed++
+		}
+	}
+
+	// Remove permissions that were created by
+	// the subscription, but are no longer listed.
+	existing, err := p.getSubscriptionDomainPermissions(ctx, sub)
+	if err != nil {
+		return gtserror.Newf("db error getting subscription domain permissions: %w", err)
+	}
+
+	for _, perm := range existing {
+		if _, ok := domains[perm.GetDomain()]; ok {
+			continue
+		}
+
+		l.Infof("removing domain %s %s no longer present in list", sub.PermissionType.String(), perm.GetDomain())
+		if _, _, errWithCode := p.DomainPermissionDelete(ctx,
+			sub.PermissionType,
+			adminAcct,
+			perm.GetID(),
+		); errWithCode != nil {
+			l.Errorf("error removing domain %s %s: %v", sub.PermissionType.String(), perm.GetDomain(), errWithCode)
+			failed++
+		}
+	}
+
+	// Likewise remove drafts that are no longer listed.
+	drafts, err := p.state.DB.GetDomainPermissionDrafts(ctx, sub.PermissionType, sub.ID, "")
+	if err != nil {
+		return gtserror.Newf("db error getting domain permission drafts: %w", err)
+	}
+
+	for _, draft := range drafts {
+		if _, ok := domains[draft.Domain]; ok {
+			continue
+		}
+
+		if err := p.state.DB.DeleteDomainPermissionDraft(ctx, draft.ID); err != nil {
+			l.Errorf("db error removing domain permission draft for %s: %v", draft.Domain, err)
+			failed++
+		}
+	}
+
+	if failed != 0 {
+		// Leave the stored cache headers alone so
+		// that the list gets fully applied next time.
+		err := gtserror.Newf("failed applying %d list entries", failed)
+		sub.Error = err.Error()
+		if err := p.state.DB.UpdateDomainPermissionSubscription(ctx, sub, "error"); err != nil {
+			log.Errorf(ctx, "db error updating domain permission subscription: %v", err)
+		}
+		return err
+	}
+
+	// Whole list was applied, store
+	// cache headers for next time.
+	if err := p.state.DB.UpdateDomainPermissionSubscription(ctx, sub,
+		"etag",
+		"last_modified",
+	); err != nil {
+		return gtserror.Newf("db error updating domain permission subscription: %w", err)
+	}
+
+	return nil
+}
+
+// applyDomainPermSubEntry creates a domain permission (or draft
+// thereof) from one entry of the given subscription's list, if
+// it doesn't exist already.
+//
+// If a permission already exists for the domain which was created
+// by another subscription of lower priority, it is claimed by the
+// given subscription instead. Permissions created manually, or by
+// subscriptions of equal or higher priority, are left alone.
+func (p *Processor) applyDomainPermSubEntry(
+	ctx context.Context,
+	sub *gtsmodel.DomainPermissionSubscription,
+	adminAcct *gtsmodel.Account,
+	entry *gtsmodel.DomainPermissionDraft,
+	priorities map[string]uint8,
+) error {
+	existing, err := p.getDomainPermission(ctx, sub.PermissionType, entry.Domain)
+	if err != nil {
+		return gtserror.Newf("db error getting existing domain permission: %w", err)
+	}
+
+	if existing != nil {
+		ownerID := existing.GetSubscriptionID()
+		if ownerID == "" || ownerID == sub.ID {
+			// Manually created, or
+			// already ours; leave it.
+			return nil
+		}
+
+		if priority, ok := priorities[ownerID]; ok && priority >= sub.Priority {
+			// Owner takes precedence.
+			return nil
+		}
+
+		// Claim it for this subscription.
+		return p.setDomainPermissionSubscriptionID(ctx, existing, sub.ID)
+	}
+
+	draft, err := p.state.DB.GetDomainPermissionDraft(ctx, sub.PermissionType, entry.Domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting existing domain permission draft: %w", err)
+	}
+
+	if *sub.AsDraft {
+		if draft != nil {
+			// Already awaiting
+			// approval, nothing to do.
+			return nil
+		}
+
+		entry.ID = id.NewULID()
+		entry.PermissionType = sub.PermissionType
+		entry.CreatedByAccountID = adminAcct.ID
+		entry.SubscriptionID = sub.ID
+		return p.state.DB.PutDomainPermissionDraft(ctx, entry)
+	}
+
+	if _, _, errWithCode := p.DomainPermissionCreate(
+		ctx,
+		sub.PermissionType,
+		adminAcct,
+		entry.Domain,
+		*entry.Obfuscate,
+		entry.PublicComment,
+		entry.PrivateComment,
+		sub.ID,
+	); errWithCode != nil {
+		return errWithCode
+	}
+
+	if draft != nil && draft.SubscriptionID == sub.ID {
+		// Permission is now in place, so a leftover
+		// draft from this subscription isn't needed.
+		return p.state.DB.DeleteDomainPermissionDraft(ctx, draft.ID)
+	}
+
+	return nil
+}
+
+// fetchDomainPermissions fetches and parses the list of the given
+// subscription, returning its entries as (unstored) drafts.
+//
+// Unless skipCache is true, stored ETag and Last-Modified values
+// of the subscription are sent along with the request, and if the
+// remote indicates the list hasn't changed, notModified will be
+// true. On successful fetch the subscription's ETag and Last-Modified
+// fields are updated (but not stored) from the response headers.
+func (p *Processor) fetchDomainPermissions(
+	ctx context.Context,
+	sub *gtsmodel.DomainPermissionSubscription,
+	skipCache bool,
+) (perms []*gtsmodel.DomainPermissionDraft, notModified bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sub.URI, nil)
+	if err != nil {
+		return nil, false, gtserror.Newf("error creating request: %w", err)
+	}
+
+	req.Header.Set("Accept", sub.ContentType.String()+", */*;q=0.1")
+
+	if !skipCache {
+		if sub.ETag != "" {
+			req.Header.Set("If-None-Match", sub.ETag)
+		}
+
+		if !sub.LastModified.IsZero() {
+			req.Header.Set("If-Modified-Since", sub.LastModified.UTC().Format(http.TimeFormat))
+		}
+	}
+
+	if sub.FetchUsername != "" || sub.FetchPassword != "" {
+		req.SetBasicAuth(sub.FetchUsername, sub.FetchPassword)
+	}
+
+	// Fetch using the instance account transport.
+	tsport, err := p.transport.NewTransportForUsername(ctx, "")
+	if err != nil {
+		return nil, false, gtserror.Newf("error getting transport: %w", err)
+	}
+
+	rsp, err := tsport.GET(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode == http.StatusNotModified && !skipCache {
+		return nil, true, nil
+	}
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, false, gtserror.NewFromResponse(rsp)
+	}
+
+	// Read one byte beyond the limit so that an
+	// oversized list is an error, not truncated.
+	b, err := io.ReadAll(io.LimitReader(rsp.Body, maxDomainPermListSize+1))
+	if err != nil {
+		return nil, false, gtserror.Newf("error reading body: %w", err)
+	}
+
+	if len(b) > maxDomainPermListSize {
+		err := gtserror.Newf("list exceeds maximum size of %d bytes", maxDomainPermListSize)
+		return nil, false, gtserror.SetLimitReached(err)
+	}
+
+	body := bytes.NewReader(b)
+
+	switch sub.ContentType {
+	case gtsmodel.DomainPermSubContentTypeCSV:
+		perms, err = parseDomainPermsCSV(body, sub.PermissionType)
+	case gtsmodel.DomainPermSubContentTypeJSON:
+		perms, err = parseDomainPermsJSON(body)
+	case gtsmodel.DomainPermSubContentTypePlain:
+		perms, err = parseDomainPermsPlain(body)
+	default:
+		err = gtserror.Newf("unrecognized content type %d", sub.ContentType)
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !skipCache {
+		// Store cache headers for next time.
+		sub.ETag = rsp.Header.Get("ETag")
+		sub.LastModified, _ = http.ParseTime(rsp.Header.Get("Last-Modified"))
+	}
+
+	return perms, false, nil
+}
+
+// parseDomainPermsCSV parses a Mastodon-style CSV domain
+// permission list, eg., as exported from Mastodon:
+//
+//	#domain,#severity,#reject_media,#reject_reports,#public_comment,#obfuscate
+//	example.org,suspend,true,true,they smell,false
+//
+// When parsing for blocks, only entries with severity
+// "suspend" are included, since we don't (yet) support
+// silencing, and "noop" entries don't block anything.
+func parseDomainPermsCSV(
+	r io.Reader,
+	permType gtsmodel.DomainPermissionType,
+) ([]*gtsmodel.DomainPermissionDraft, error) {
+	csvReader := csv.NewReader(r)
+	csvReader.FieldsPerRecord = -1 // Allow variable fields.
+	csvReader.TrimLeadingSpace = true
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, gtserror.Newf("error reading csv: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil, gtserror.New("empty csv")
+	}
+
+	// Map column names to indices,
+	// so that order doesn't matter.
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		name = strings.TrimPrefix(strings.TrimSpace(name), "#")
+		columns[strings.ToLower(name)] = i
+	}
+
+	domainIdx, ok := columns["domain"]
+	if !ok {
+		return nil, gtserror.New("csv header does not contain domain column")
+	}
+
+	// field returns the value of
+	// the named column, if present.
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var (
+		entries = make([]*gtsmodel.DomainPermissionDraft, 0, len(records)-1)
+		seen    = make(map[string]struct{}, len(records)-1)
+	)
+
+	for _, record := range records[1:] {
+		if domainIdx >= len(record) {
+			continue
+		}
+
+		if permType == gtsmodel.DomainPermissionBlock {
+			severity := field(record, "severity")
+			if severity != "" && severity != "suspend" {
+				continue
+			}
+		}
+
+		obfuscate, _ := strconv.ParseBool(field(record, "obfuscate"))
+
+		entries = appendDomainPermEntry(entries, seen,
+			record[domainIdx],
+			obfuscate,
+			field(record, "public_comment"),
+			"",
+		)
+	}
+
+	return entries, nil
+}
+
+// parseDomainPermsJSON parses a JSON array of domain
+// permissions, as exported from GoToSocial, eg.,
+// `[{"domain":"example.org","public_comment":"they smell"}]`.
+func parseDomainPermsJSON(r io.Reader) ([]*gtsmodel.DomainPermissionDraft, error) {
+	var apiPerms []*apimodel.DomainPermission
+	if err := json.NewDecoder(r).Decode(&apiPerms); err != nil {
+		return nil, gtserror.Newf("error decoding json: %w", err)
+	}
+
+	var (
+		entries = make([]*gtsmodel.DomainPermissionDraft, 0, len(apiPerms))
+		seen    = make(map[string]struct{}, len(apiPerms))
+	)
+
+	for _, apiPerm := range apiPerms {
+		if apiPerm == nil {
+			continue
+		}
+
+		entries = appendDomainPermEntry(entries, seen,
+			apiPerm.Domain.Domain,
+			apiPerm.Obfuscate,
+			apiPerm.PublicComment,
+			apiPerm.PrivateComment,
+		)
+	}
+
+	return entries, nil
+}
+
+// parseDomainPermsPlain parses a plaintext list
+// of domains, one per line. Blank lines and lines
+// starting with '#' (comments) are ignored.
+func parseDomainPermsPlain(r io.Reader) ([]*gtsmodel.DomainPermissionDraft, error) {
+	var (
+		entries []*gtsmodel.DomainPermissionDraft
+		seen    = make(map[string]struct{})
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		entries = appendDomainPermEntry(entries, seen, line, false, "", "")
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, gtserror.Newf("error reading plaintext: %w", err)
+	}
+
+	return entries, nil
+}
+
+// appendDomainPermEntry appends a new entry for the given domain
+// to entries, if the domain is valid and not already in seen.
+func appendDomainPermEntry(
+	entries []*gtsmodel.DomainPermissionDraft,
+	seen map[string]struct{},
+	domain string,
+	obfuscate bool,
+	publicComment string,
+	privateComment string,
+) []*gtsmodel.DomainPermissionDraft {
+	domain, err := normalizeDomain(domain)
+	if err != nil {
+		log.Debugf(nil, "skipping domain permission list entry: %v", err)
+		return entries
+	}
+
+	if _, ok := seen[domain]; ok {
+		// Already have it.
+		return entries
+	}
+	seen[domain] = struct{}{}
+
+	return append(entries, &gtsmodel.DomainPermissionDraft{
+		Domain:         domain,
+		Obfuscate:      util.Ptr(obfuscate),
+		PublicComment:  text.SanitizeToPlaintext(publicComment),
+		PrivateComment: text.SanitizeToPlaintext(privateComment),
+	})
+}
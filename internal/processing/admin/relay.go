@@ -0,0 +1,290 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// apiRelay is a cheeky shortcut for returning the
+// API version of the given relay, or an appropriate
+// error if something goes wrong.
+func (p *Processor) apiRelay(
+	ctx context.Context,
+	relay *gtsmodel.Relay,
+) (*apimodel.Relay, gtserror.WithCode) {
+	apiRelay, err := p.converter.RelayToAPIRelay(ctx, relay)
+	if err != nil {
+		err := gtserror.NewfAt(3, "error converting relay to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiRelay, nil
+}
+
+// RelayGet returns one relay with the given id.
+func (p *Processor) RelayGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.Relay, gtserror.WithCode) {
+	relay, errWithCode := p.getRelay(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiRelay(ctx, relay)
+}
+
+// RelaysGet returns all relays, oldest first.
+func (p *Processor) RelaysGet(
+	ctx context.Context,
+) ([]*apimodel.Relay, gtserror.WithCode) {
+	relays, err := p.state.DB.GetRelays(ctx)
+	if err != nil {
+		err := gtserror.Newf("db error getting relays: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiRelays := make([]*apimodel.Relay, len(relays))
+	for i, relay := range relays {
+		apiRelay, errWithCode := p.apiRelay(ctx, relay)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiRelays[i] = apiRelay
+	}
+
+	return apiRelays, nil
+}
+
+// RelayCreate subscribes to a relay by sending a Follow to it from
+// the instance account. Exactly one of the form's inbox URL (for
+// Mastodon-style relays) or actor URL (for LitePub-style relays)
+// must be set. The relay remains pending until it Accepts the Follow.
+func (p *Processor) RelayCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.RelayCreateRequest,
+) (*apimodel.Relay, gtserror.WithCode) {
+	if (form.InboxURL == "") == (form.ActorURL == "") {
+		const text = "exactly one of inbox_url or actor_url must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	rawURL := form.InboxURL
+	if form.ActorURL != "" {
+		rawURL = form.ActorURL
+	}
+
+	relayURL, err := url.Parse(rawURL)
+	if err != nil ||
+		(relayURL.Scheme != "https" && relayURL.Scheme != "http") ||
+		relayURL.Host == "" {
+		const text = "relay url must be a valid http or https url"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	domain, err := util.Punify(relayURL.Host)
+	if err != nil {
+		err := fmt.Errorf("invalid relay domain %s: %w", relayURL.Host, err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Ensure relay domain not blocked.
+	blocked, err := p.state.DB.IsDomainBlocked(ctx, domain)
+	if err != nil {
+		err := gtserror.Newf("db error checking for domain block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if blocked {
+		err := fmt.Errorf("relay domain %s is blocked", domain)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	instanceAcct, err := p.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		err := gtserror.Newf("db error getting instance account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	relayID := id.NewULID()
+	relay := &gtsmodel.Relay{
+		ID:                 relayID,
+		InboxURI:           relayURL.String(),
+		Domain:             domain,
+		FollowURI:          uris.GenerateURIForFollow(instanceAcct.Username, relayID),
+		State:              gtsmodel.RelayStatePending,
+		Forward:            util.Ptr(util.PtrOrValue(form.Forward, true)),
+		CreatedByAccountID: adminAcct.ID,
+	}
+
+	if form.ActorURL != "" {
+		// LitePub-style relays are followed
+		// directly, so dereference the relay
+		// actor in order to find its inbox.
+		relayAcct, _, err := p.federator.GetAccountByURI(
+			// Caller will want a snappy
+			// response so don't retry.
+			gtscontext.SetFastFail(ctx),
+			instanceAcct.Username,
+			relayURL,
+		)
+		if err != nil {
+			err := fmt.Errorf("error dereferencing relay actor %s: %w", relayURL, err)
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+
+		relay.ActorURI = relayAcct.URI
+		relay.InboxURI = relayAcct.InboxURI
+	}
+
+	if err := p.state.DB.PutRelay(ctx, relay); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err := fmt.Errorf("a relay already exists with inbox %s", relay.InboxURI)
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+
+		err := gtserror.Newf("db error putting relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	follow, err := p.converter.RelayToASFollow(ctx, relay, instanceAcct)
+	if err != nil {
+		err := gtserror.Newf("error converting relay to Follow: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.deliverToRelay(ctx, relay, instanceAcct, follow); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiRelay(ctx, relay)
+}
+
+// RelayDelete unsubscribes from the relay with the
+// given id, by sending an Undo of the Follow to it
+// and removing it from the database.
+func (p *Processor) RelayDelete(
+	ctx context.Context,
+	id string,
+) (*apimodel.Relay, gtserror.WithCode) {
+	relay, errWithCode := p.getRelay(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Prepare the relay to return, *before* the deletion goes through.
+	apiRelay, errWithCode := p.apiRelay(ctx, relay)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	instanceAcct, err := p.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		err := gtserror.Newf("db error getting instance account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if relay.State != gtsmodel.RelayStateRejected {
+		// Relay may still consider us
+		// subscribed, so Undo our Follow.
+		undo, err := p.converter.RelayToASUndoFollow(ctx, relay, instanceAcct)
+		if err != nil {
+			err := gtserror.Newf("error converting relay to Undo: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if err := p.deliverToRelay(ctx, relay, instanceAcct, undo); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if err := p.state.DB.DeleteRelayByID(ctx, relay.ID); err != nil {
+		err := gtserror.Newf("db error deleting relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiRelay, nil
+}
+
+// getRelay is a shortcut for getting a relay
+// by ID, returning an appropriate error code.
+func (p *Processor) getRelay(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.Relay, gtserror.WithCode) {
+	relay, err := p.state.DB.GetRelayByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := fmt.Errorf("no relay exists with id %s", id)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		err := gtserror.Newf("db error getting relay %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return relay, nil
+}
+
+// deliverToRelay queues the given activity for
+// delivery to the inbox of the given relay, on
+// behalf of the instance account.
+func (p *Processor) deliverToRelay(
+	ctx context.Context,
+	relay *gtsmodel.Relay,
+	instanceAcct *gtsmodel.Account,
+	t vocab.Type,
+) error {
+	inboxIRI, err := url.Parse(relay.InboxURI)
+	if err != nil {
+		return gtserror.Newf("error parsing relay inbox uri: %w", err)
+	}
+
+	tsport, err := p.transport.NewTransportForUsername(ctx, instanceAcct.Username)
+	if err != nil {
+		return gtserror.Newf("error creating transport: %w", err)
+	}
+
+	m, err := ap.Serialize(t)
+	if err != nil {
+		return gtserror.Newf("error serializing %T: %w", t, err)
+	}
+
+	if err := tsport.Deliver(ctx, m, inboxIRI); err != nil {
+		return gtserror.Newf("error delivering %T to relay inbox %s: %w", t, relay.InboxURI, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RelayTestSuite struct {
+	AdminStandardTestSuite
+}
+
+// popDelivery pops the next queued delivery,
+// returning the request URL and body.
+func (suite *RelayTestSuite) popDelivery() (string, string) {
+	var url, body string
+	if !testrig.WaitFor(func() bool {
+		delivery, ok := suite.state.Workers.Delivery.Queue.Pop()
+		if !ok {
+			return false
+		}
+		b, err := io.ReadAll(delivery.Request.Body)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		url, body = delivery.Request.URL.String(), string(b)
+		return true
+	}) {
+		suite.FailNow("timed out waiting for delivery")
+	}
+	return url, body
+}
+
+func (suite *RelayTestSuite) TestRelayCreate() {
+	ctx := context.Background()
+	adminAcct := suite.testAccounts["admin_account"]
+
+	relay, errWithCode := suite.adminProcessor.RelayCreate(ctx, adminAcct, &apimodel.RelayCreateRequest{
+		InboxURL: "https://relay.example.net/inbox",
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("https://relay.example.net/inbox", relay.InboxURL)
+	suite.Equal("relay.example.net", relay.Domain)
+	suite.Equal("pending", relay.State)
+	suite.True(relay.Forward)
+
+	// A Follow of Public from the instance
+	// account should be sent to the relay inbox.
+	url, body := suite.popDelivery()
+	suite.Equal("https://relay.example.net/inbox", url)
+	suite.Contains(body, `"type":"Follow"`)
+	suite.Contains(body, `"actor":"http://localhost:8080/users/localhost:8080"`)
+	suite.Contains(body, `"object":"https://www.w3.org/ns/activitystreams#Public"`)
+}
+
+func (suite *RelayTestSuite) TestRelayCreateInvalid() {
+	ctx := context.Background()
+	adminAcct := suite.testAccounts["admin_account"]
+
+	for _, form := range []*apimodel.RelayCreateRequest{
+		{},
+		{
+			InboxURL: "https://relay.example.net/inbox",
+			ActorURL: "https://relay.example.net/actor",
+		},
+		{
+			InboxURL: "ftp://relay.example.net/inbox",
+		},
+	} {
+		_, errWithCode := suite.adminProcessor.RelayCreate(ctx, adminAcct, form)
+		suite.Error(errWithCode)
+		suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	}
+}
+
+func (suite *RelayTestSuite) TestRelayCreateConflict() {
+	ctx := context.Background()
+	adminAcct := suite.testAccounts["admin_account"]
+
+	_, errWithCode := suite.adminProcessor.RelayCreate(ctx, adminAcct, &apimodel.RelayCreateRequest{
+		InboxURL: "https://relay.example.org/inbox",
+	})
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+}
+
+func (suite *RelayTestSuite) TestRelayDelete() {
+	ctx := context.Background()
+	relay := testrig.NewTestRelays()["relay_accepted"]
+
+	apiRelay, errWithCode := suite.adminProcessor.RelayDelete(ctx, relay.ID)
+	suite.NoError(errWithCode)
+	suite.Equal(relay.ID, apiRelay.ID)
+
+	// An Undo of our Follow should
+	// be sent to the relay inbox.
+	url, body := suite.popDelivery()
+	suite.Equal(relay.InboxURI, url)
+	suite.Contains(body, `"type":"Undo"`)
+	suite.Contains(body, relay.FollowURI)
+
+	_, err := suite.state.DB.GetRelayByID(ctx, relay.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestRelayTestSuite(t *testing.T) {
+	suite.Run(t, new(RelayTestSuite))
+}
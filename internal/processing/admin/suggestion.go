@@ -0,0 +1,138 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// apiFeaturedAccount is a cheeky shortcut for returning
+// the API version of the given featured account, or an
+// appropriate error if something goes wrong.
+func (p *Processor) apiFeaturedAccount(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) (*apimodel.Account, gtserror.WithCode) {
+	apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, account)
+	if err != nil {
+		err := gtserror.NewfAt(3, "error converting account to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiAccount, nil
+}
+
+// FeaturedSuggestionsGet returns all local accounts
+// featured to be suggested to other accounts, in the
+// order in which they were featured.
+func (p *Processor) FeaturedSuggestionsGet(
+	ctx context.Context,
+) ([]*apimodel.Account, gtserror.WithCode) {
+	featured, err := p.state.DB.GetFeaturedSuggestions(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting featured suggestions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAccounts := make([]*apimodel.Account, 0, len(featured))
+	for _, f := range featured {
+		apiAccount, errWithCode := p.apiFeaturedAccount(ctx, f.Account)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiAccounts = append(apiAccounts, apiAccount)
+	}
+
+	return apiAccounts, nil
+}
+
+// FeaturedSuggestionCreate features the local account with
+// the given ID, to be suggested to other accounts as one
+// worth following, returning the featured account.
+func (p *Processor) FeaturedSuggestionCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	accountID string,
+) (*apimodel.Account, gtserror.WithCode) {
+	account, err := p.state.DB.GetAccountByID(gtscontext.SetBarebones(ctx), accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if account == nil {
+		err := gtserror.Newf("account %s not found", accountID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if !account.IsLocal() || account.IsInstance() || account.IsSuspended() {
+		const text = "only active local user accounts can be featured"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	if err := p.state.DB.PutFeaturedSuggestion(ctx, &gtsmodel.FeaturedSuggestion{
+		ID:                 id.NewULID(),
+		AccountID:          account.ID,
+		CreatedByAccountID: adminAcct.ID,
+	}); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			const text = "account is already featured"
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+		}
+
+		err := gtserror.Newf("db error putting featured suggestion: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiFeaturedAccount(ctx, account)
+}
+
+// FeaturedSuggestionDelete stops featuring the account
+// with the given ID, returning the previously featured
+// account.
+func (p *Processor) FeaturedSuggestionDelete(
+	ctx context.Context,
+	accountID string,
+) (*apimodel.Account, gtserror.WithCode) {
+	featured, err := p.state.DB.GetFeaturedSuggestionByAccountID(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting featured suggestion: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if featured == nil {
+		err := gtserror.Newf("account %s is not featured", accountID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if err := p.state.DB.DeleteFeaturedSuggestionByAccountID(ctx, accountID); err != nil {
+		err := gtserror.Newf("db error deleting featured suggestion: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiFeaturedAccount(ctx, featured.Account)
+}
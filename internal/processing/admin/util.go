@@ -20,11 +20,14 @@ package admin
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"golang.org/x/net/idna"
 )
 
 // stubbifyInstance renders the given instance as a stub,
@@ -97,3 +100,26 @@ func (p *Processor) rangeDomainAccounts(
 		}
 	}
 }
+
+// normalizeDomain trims the given domain and converts
+// it to lowercase punycode, returning an error if the
+// result is not something that looks like a hostname.
+//
+// Obfuscated domains (eg., "exa*ple.org"), which are
+// sometimes present in shared domain block lists, will
+// not pass this check, since we can't know what they are.
+func normalizeDomain(domain string) (string, error) {
+	domain = strings.TrimSpace(domain)
+	domain = strings.TrimSuffix(domain, ".")
+
+	punyDomain, err := idna.Lookup.ToASCII(strings.ToLower(domain))
+	if err != nil {
+		return "", fmt.Errorf("invalid domain %q: %w", domain, err)
+	}
+
+	if !strings.Contains(punyDomain, ".") {
+		return "", fmt.Errorf("invalid domain %q: not a fully qualified domain name", domain)
+	}
+
+	return punyDomain, nil
+}
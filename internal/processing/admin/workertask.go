@@ -56,6 +56,13 @@ func (p *Processor) FillWorkerQueues(ctx context.Context) error {
 		return gtserror.Newf("error fetching worker tasks from db: %w", err)
 	}
 
+	// Import tasks are persisted for the whole
+	// lifetime of an import, and get resumed by
+	// the account processor, so leave them be.
+	tasks = slices.DeleteFunc(tasks, func(task *gtsmodel.WorkerTask) bool {
+		return task.WorkerType == gtsmodel.ImportWorker
+	})
+
 	var (
 		// Counts of each task type
 		// successfully recovered.
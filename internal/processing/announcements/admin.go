@@ -0,0 +1,316 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// AdminGetAll returns all announcements on this instance,
+// including unpublished and ended announcements.
+func (p *Processor) AdminGetAll(ctx context.Context) ([]*apimodel.Announcement, gtserror.WithCode) {
+	announcements, err := p.state.DB.GetAnnouncements(ctx, false)
+	if err != nil {
+		err := gtserror.Newf("db error getting announcements: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAnnouncements := make([]*apimodel.Announcement, 0, len(announcements))
+	for _, announcement := range announcements {
+		apiAnnouncement, errWithCode := p.apiAnnouncement(ctx, announcement, nil)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiAnnouncements = append(apiAnnouncements, apiAnnouncement)
+	}
+
+	return apiAnnouncements, nil
+}
+
+// AdminGet returns one announcement with the given
+// ID, regardless of whether it's published or ended.
+func (p *Processor) AdminGet(ctx context.Context, id string) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement, errWithCode := p.getAnnouncement(ctx, id, false)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiAnnouncement(ctx, announcement, nil)
+}
+
+// Create creates a new announcement authored by the given
+// admin account. Unless the form specifies otherwise, the
+// announcement will be published (and streamed to users) immediately.
+func (p *Processor) Create(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.AnnouncementRequest,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	if form.Text == nil {
+		const errText = "text must be provided"
+		return nil, gtserror.NewErrorBadRequest(errors.New(errText), errText)
+	}
+
+	announcement := &gtsmodel.Announcement{
+		ID:        id.NewULID(),
+		AccountID: adminAcct.ID,
+		AllDay:    util.Ptr(util.PtrOrValue(form.AllDay, false)),
+		Published: util.Ptr(util.PtrOrValue(form.Published, true)),
+	}
+
+	if errWithCode := p.setText(ctx, announcement, *form.Text); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := p.setTimes(announcement, form.StartsAt, form.EndsAt); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if announcement.IsPublished() {
+		announcement.PublishedAt = time.Now()
+	}
+
+	if err := p.state.DB.PutAnnouncement(ctx, announcement); err != nil {
+		err := gtserror.Newf("db error putting announcement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAnnouncement, errWithCode := p.apiAnnouncement(ctx, announcement, nil)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if announcement.IsPublished() && !announcement.IsEnded() {
+		p.stream.Announcement(ctx, apiAnnouncement)
+	}
+
+	return apiAnnouncement, nil
+}
+
+// Update updates the announcement with the given ID using
+// the set fields of the given form, and streams the change
+// to users if the announcement is (or was) visible to them.
+func (p *Processor) Update(
+	ctx context.Context,
+	id string,
+	form *apimodel.AnnouncementRequest,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement, errWithCode := p.getAnnouncement(ctx, id, false)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	wasVisible := announcement.IsPublished() && !announcement.IsEnded()
+	columns := make([]string, 0, 8)
+
+	if form.Text != nil {
+		if errWithCode := p.setText(ctx, announcement, *form.Text); errWithCode != nil {
+			return nil, errWithCode
+		}
+		columns = append(columns, "text", "content", "emojis")
+	}
+
+	if form.StartsAt != nil || form.EndsAt != nil {
+		if errWithCode := p.setTimes(announcement, form.StartsAt, form.EndsAt); errWithCode != nil {
+			return nil, errWithCode
+		}
+		columns = append(columns, "starts_at", "ends_at")
+	}
+
+	if form.AllDay != nil {
+		announcement.AllDay = form.AllDay
+		columns = append(columns, "all_day")
+	}
+
+	if form.Published != nil {
+		announcement.Published = form.Published
+		columns = append(columns, "published")
+
+		if announcement.IsPublished() && announcement.PublishedAt.IsZero() {
+			// First time being published.
+			announcement.PublishedAt = time.Now()
+			columns = append(columns, "published_at")
+		}
+	}
+
+	if len(columns) == 0 {
+		const errText = "no fields set on update request"
+		return nil, gtserror.NewErrorBadRequest(errors.New(errText), errText)
+	}
+
+	if err := p.state.DB.UpdateAnnouncement(ctx, announcement, columns...); err != nil {
+		err := gtserror.Newf("db error updating announcement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAnnouncement, errWithCode := p.apiAnnouncement(ctx, announcement, nil)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	switch isVisible := announcement.IsPublished() && !announcement.IsEnded(); {
+	case isVisible:
+		// New or updated announcement.
+		p.stream.Announcement(ctx, apiAnnouncement)
+	case wasVisible:
+		// Announcement unpublished or ended early.
+		p.stream.AnnouncementDelete(ctx, announcement.ID)
+	}
+
+	return apiAnnouncement, nil
+}
+
+// Delete deletes the announcement with the given ID,
+// along with all read markers and reactions for it.
+func (p *Processor) Delete(ctx context.Context, id string) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement, errWithCode := p.getAnnouncement(ctx, id, false)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert before deleting, so
+	// we still have reactions etc.
+	apiAnnouncement, errWithCode := p.apiAnnouncement(ctx, announcement, nil)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteAnnouncementByID(ctx, announcement.ID); err != nil {
+		err := gtserror.Newf("db error deleting announcement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if announcement.IsPublished() && !announcement.IsEnded() {
+		p.stream.AnnouncementDelete(ctx, announcement.ID)
+	}
+
+	return apiAnnouncement, nil
+}
+
+// setText validates and formats the given markdown
+// text, and sets it on the given announcement.
+func (p *Processor) setText(
+	ctx context.Context,
+	announcement *gtsmodel.Announcement,
+	text string,
+) gtserror.WithCode {
+	if err := validate.AnnouncementText(text); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	res := p.formatter.FromMarkdown(ctx,
+		p.parseMentionFunc,
+		announcement.AccountID,
+		"",
+		text,
+	)
+
+	announcement.Text = text
+	announcement.Content = res.HTML
+	announcement.Emojis = res.Emojis
+	announcement.EmojiIDs = make([]string, len(res.Emojis))
+	for i, emoji := range res.Emojis {
+		announcement.EmojiIDs[i] = emoji.ID
+	}
+
+	return nil
+}
+
+// setTimes parses the given start and end times (if set), and sets
+// them on the given announcement. Empty strings unset the given time.
+func (p *Processor) setTimes(
+	announcement *gtsmodel.Announcement,
+	startsAt *string,
+	endsAt *string,
+) gtserror.WithCode {
+	for _, t := range []struct {
+		key   string
+		value *string
+		field *time.Time
+	}{
+		{"starts_at", startsAt, &announcement.StartsAt},
+		{"ends_at", endsAt, &announcement.EndsAt},
+	} {
+		if t.value == nil {
+			// Leave as-is.
+			continue
+		}
+
+		if *t.value == "" {
+			// Unset time.
+			*t.field = time.Time{}
+			continue
+		}
+
+		parsed, err := parseTime(*t.value)
+		if err != nil {
+			errText := t.key + " must be an ISO 8601 datetime or date: " + err.Error()
+			return gtserror.NewErrorBadRequest(err, errText)
+		}
+		*t.field = parsed
+	}
+
+	if !announcement.StartsAt.IsZero() &&
+		!announcement.EndsAt.IsZero() &&
+		announcement.EndsAt.Before(announcement.StartsAt) {
+		const errText = "ends_at must not be before starts_at"
+		return gtserror.NewErrorBadRequest(errors.New(errText), errText)
+	}
+
+	return nil
+}
+
+// parseTime parses the given string as either
+// an ISO 8601 datetime, or as a plain date.
+func parseTime(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return t, nil
+	}
+
+	if t, dateErr := time.Parse(time.DateOnly, value); dateErr == nil {
+		return t, nil
+	}
+
+	return time.Time{}, err
+}
+
+// apiAnnouncement converts the given announcement to
+// its API model, from the perspective of requester.
+func (p *Processor) apiAnnouncement(
+	ctx context.Context,
+	announcement *gtsmodel.Announcement,
+	requester *gtsmodel.Account,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	apiAnnouncement, err := p.converter.AnnouncementToAPIAnnouncement(ctx, announcement, requester)
+	if err != nil {
+		err := gtserror.Newf("error converting announcement %s: %w", announcement.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiAnnouncement, nil
+}
@@ -0,0 +1,81 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+// Processor wraps functionality for managing instance
+// announcements as an admin, and for reading, dismissing,
+// and reacting to published announcements as a user.
+type Processor struct {
+	state            *state.State
+	converter        *typeutils.Converter
+	formatter        *text.Formatter
+	parseMentionFunc gtsmodel.ParseMentionFunc
+	stream           *stream.Processor
+}
+
+// New returns a new announcements processor.
+func New(
+	state *state.State,
+	converter *typeutils.Converter,
+	parseMentionFunc gtsmodel.ParseMentionFunc,
+	stream *stream.Processor,
+) Processor {
+	return Processor{
+		state:            state,
+		converter:        converter,
+		formatter:        text.NewFormatter(state.DB),
+		parseMentionFunc: parseMentionFunc,
+		stream:           stream,
+	}
+}
+
+// getAnnouncement gets the announcement with the given ID. If
+// activeOnly is true, announcements which are unpublished or
+// have ended will be treated as though they don't exist.
+func (p *Processor) getAnnouncement(
+	ctx context.Context,
+	id string,
+	activeOnly bool,
+) (*gtsmodel.Announcement, gtserror.WithCode) {
+	announcement, err := p.state.DB.GetAnnouncementByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting announcement %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if announcement == nil ||
+		(activeOnly && (!announcement.IsPublished() || announcement.IsEnded())) {
+		err := gtserror.Newf("announcement %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return announcement, nil
+}
@@ -0,0 +1,57 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// GetAll returns all currently active announcements, from the
+// perspective of the requester. Announcements the requester has
+// dismissed are only included if withDismissed is true.
+func (p *Processor) GetAll(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	withDismissed bool,
+) ([]*apimodel.Announcement, gtserror.WithCode) {
+	announcements, err := p.state.DB.GetAnnouncements(ctx, true)
+	if err != nil {
+		err := gtserror.Newf("db error getting announcements: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAnnouncements := make([]*apimodel.Announcement, 0, len(announcements))
+	for _, announcement := range announcements {
+		apiAnnouncement, errWithCode := p.apiAnnouncement(ctx, announcement, requester)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		if apiAnnouncement.Read && !withDismissed {
+			continue
+		}
+
+		apiAnnouncements = append(apiAnnouncements, apiAnnouncement)
+	}
+
+	return apiAnnouncements, nil
+}
@@ -0,0 +1,144 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// ReactionAdd adds a reaction with the given name (unicode emoji or
+// local custom emoji shortcode) by the requester to the active
+// announcement with the given ID. Adding an existing reaction is a no-op.
+func (p *Processor) ReactionAdd(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	announcementID string,
+	name string,
+) gtserror.WithCode {
+	announcement, errWithCode := p.getAnnouncement(ctx, announcementID, true)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	emojiID, errWithCode := p.reactionEmojiID(ctx, name)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	err := p.state.DB.PutAnnouncementReaction(ctx, &gtsmodel.AnnouncementReaction{
+		ID:             id.NewULID(),
+		AnnouncementID: announcement.ID,
+		AccountID:      requester.ID,
+		Name:           name,
+		EmojiID:        emojiID,
+	})
+	switch {
+	case errors.Is(err, db.ErrAlreadyExists):
+		// Already reacted,
+		// nothing changes.
+		return nil
+	case err != nil:
+		err := gtserror.Newf("db error putting announcement reaction: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return p.streamReactionCount(ctx, announcement.ID, name)
+}
+
+// ReactionRemove removes the reaction with the given name by the requester
+// from the active announcement with the given ID, if such a reaction exists.
+func (p *Processor) ReactionRemove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	announcementID string,
+	name string,
+) gtserror.WithCode {
+	announcement, errWithCode := p.getAnnouncement(ctx, announcementID, true)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.DeleteAnnouncementReaction(ctx,
+		announcement.ID,
+		requester.ID,
+		name,
+	); err != nil {
+		err := gtserror.Newf("db error deleting announcement reaction: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return p.streamReactionCount(ctx, announcement.ID, name)
+}
+
+// reactionEmojiID checks that the given reaction name is either
+// a unicode emoji, or the shortcode of an enabled local custom
+// emoji. In the latter case, the ID of the custom emoji is returned.
+func (p *Processor) reactionEmojiID(ctx context.Context, name string) (string, gtserror.WithCode) {
+	if validate.UnicodeEmoji(name) == nil {
+		return "", nil
+	}
+
+	invalid := func() (string, gtserror.WithCode) {
+		err := fmt.Errorf("%s is not a recognized emoji", name)
+		return "", gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if validate.EmojiShortcode(name) != nil {
+		return invalid()
+	}
+
+	emoji, err := p.state.DB.GetEmojiByShortcodeDomain(ctx, name, "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting emoji %s: %w", name, err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	if emoji == nil || *emoji.Disabled {
+		return invalid()
+	}
+
+	return emoji.ID, nil
+}
+
+// streamReactionCount streams the current count of reactions
+// with the given name to the given announcement to all users.
+func (p *Processor) streamReactionCount(ctx context.Context, announcementID string, name string) gtserror.WithCode {
+	reactions, err := p.state.DB.GetAnnouncementReactions(ctx, announcementID)
+	if err != nil {
+		err := gtserror.Newf("db error getting announcement reactions: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	var count int
+	for _, reaction := range reactions {
+		if reaction.Name == name {
+			count++
+		}
+	}
+
+	p.stream.AnnouncementReaction(ctx, announcementID, name, count)
+	return nil
+}
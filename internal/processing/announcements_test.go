@@ -0,0 +1,161 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type AnnouncementsTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *AnnouncementsTestSuite) TestCreateAnnouncement() {
+	var (
+		ctx     = context.Background()
+		admin   = suite.testAccounts["admin_account"]
+		streams = suite.openStreams(ctx, suite.testAccounts["local_account_1"], nil)
+	)
+
+	apiAnnouncement, errWithCode := suite.processor.Announcements().Create(ctx, admin, &apimodel.AnnouncementRequest{
+		Text:     util.Ptr("Maintenance tonight! :rainbow:"),
+		StartsAt: util.Ptr("2030-10-20"),
+		EndsAt:   util.Ptr("2030-10-21"),
+		AllDay:   util.Ptr(true),
+	})
+	suite.NoError(errWithCode)
+	suite.True(apiAnnouncement.Published)
+	suite.NotEmpty(apiAnnouncement.PublishedAt)
+	suite.True(apiAnnouncement.AllDay)
+	suite.Equal("2030-10-20T00:00:00.000Z", apiAnnouncement.StartsAt)
+	suite.Equal("<p>Maintenance tonight! :rainbow:</p>", apiAnnouncement.Content)
+	suite.Len(apiAnnouncement.Emojis, 1)
+
+	msg, ok := streams[stream.TimelineHome].Recv(ctx)
+	suite.True(ok)
+	suite.Equal(stream.EventTypeAnnouncement, msg.Event)
+
+	// New announcement should now be visible to users.
+	apiAnnouncements, errWithCode := suite.processor.Announcements().GetAll(ctx, suite.testAccounts["local_account_1"], false)
+	suite.NoError(errWithCode)
+	suite.Len(apiAnnouncements, 2)
+	suite.Equal(apiAnnouncement.ID, apiAnnouncements[1].ID)
+}
+
+func (suite *AnnouncementsTestSuite) TestCreateAnnouncementEndBeforeStart() {
+	_, errWithCode := suite.processor.Announcements().Create(context.Background(), suite.testAccounts["admin_account"], &apimodel.AnnouncementRequest{
+		Text:     util.Ptr("oops"),
+		StartsAt: util.Ptr("2030-10-21T00:00:00Z"),
+		EndsAt:   util.Ptr("2030-10-20T00:00:00Z"),
+	})
+	suite.EqualError(errWithCode, "ends_at must not be before starts_at")
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *AnnouncementsTestSuite) TestUnpublishAnnouncement() {
+	var (
+		ctx     = context.Background()
+		streams = suite.openStreams(ctx, suite.testAccounts["local_account_1"], nil)
+	)
+
+	apiAnnouncement, errWithCode := suite.processor.Announcements().Update(ctx, "01JAD2W8X2A9ZG3C5NQF1S8V6P", &apimodel.AnnouncementRequest{
+		Published: util.Ptr(false),
+	})
+	suite.NoError(errWithCode)
+	suite.False(apiAnnouncement.Published)
+
+	msg, ok := streams[stream.TimelineHome].Recv(ctx)
+	suite.True(ok)
+	suite.Equal(stream.EventTypeAnnouncementDelete, msg.Event)
+	suite.Equal("01JAD2W8X2A9ZG3C5NQF1S8V6P", msg.Payload)
+
+	// Unpublished announcements can't be dismissed.
+	errWithCode = suite.processor.Announcements().Dismiss(ctx, suite.testAccounts["local_account_1"], "01JAD2W8X2A9ZG3C5NQF1S8V6P")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *AnnouncementsTestSuite) TestGetAnnouncementsDismissed() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_2"]
+	)
+
+	// local_account_2 already dismissed the only active announcement.
+	apiAnnouncements, errWithCode := suite.processor.Announcements().GetAll(ctx, requester, false)
+	suite.NoError(errWithCode)
+	suite.Empty(apiAnnouncements)
+
+	apiAnnouncements, errWithCode = suite.processor.Announcements().GetAll(ctx, requester, true)
+	suite.NoError(errWithCode)
+	suite.Len(apiAnnouncements, 1)
+
+	apiAnnouncement := apiAnnouncements[0]
+	suite.True(apiAnnouncement.Read)
+	suite.Equal([]apimodel.AnnouncementReaction{
+		{
+			Name:  "👍",
+			Count: 2,
+			Me:    true,
+		},
+		{
+			Name:      "rainbow",
+			Count:     1,
+			Me:        true,
+			URL:       "http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png",
+			StaticURL: "http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/static/01F8MH9H8E4VG3KDYJR9EGPXCQ.png",
+		},
+	}, apiAnnouncement.Reactions)
+}
+
+func (suite *AnnouncementsTestSuite) TestReactions() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+		streams   = suite.openStreams(ctx, requester, nil)
+	)
+
+	errWithCode := suite.processor.Announcements().ReactionAdd(ctx, requester, "01JAD2W8X2A9ZG3C5NQF1S8V6P", "👍")
+	suite.NoError(errWithCode)
+
+	msg, ok := streams[stream.TimelineHome].Recv(ctx)
+	suite.True(ok)
+	suite.Equal(stream.EventTypeAnnouncementReaction, msg.Event)
+	suite.Equal(`{"name":"👍","count":3,"announcement_id":"01JAD2W8X2A9ZG3C5NQF1S8V6P"}`, msg.Payload)
+
+	errWithCode = suite.processor.Announcements().ReactionRemove(ctx, requester, "01JAD2W8X2A9ZG3C5NQF1S8V6P", "👍")
+	suite.NoError(errWithCode)
+
+	msg, ok = streams[stream.TimelineHome].Recv(ctx)
+	suite.True(ok)
+	suite.Equal(`{"name":"👍","count":2,"announcement_id":"01JAD2W8X2A9ZG3C5NQF1S8V6P"}`, msg.Payload)
+
+	// Neither unicode emoji nor a known custom emoji.
+	errWithCode = suite.processor.Announcements().ReactionAdd(ctx, requester, "01JAD2W8X2A9ZG3C5NQF1S8V6P", "not_an_emoji")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func TestAnnouncementsTestSuite(t *testing.T) {
+	suite.Run(t, &AnnouncementsTestSuite{})
+}
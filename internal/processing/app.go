@@ -19,13 +19,17 @@ package processing
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 func (p *Processor) AppCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ApplicationCreateRequest) (*apimodel.Application, gtserror.WithCode) {
@@ -60,6 +64,12 @@ func (p *Processor) AppCreate(ctx context.Context, authed *oauth.Auth, form *api
 		Scopes:       scopes,
 	}
 
+	if authed.User != nil {
+		// App was created using a user-level token,
+		// so let that user manage (view, delete) it.
+		app.ManagedByUserID = authed.User.ID
+	}
+
 	// chuck it in the db
 	if err := p.state.DB.PutApplication(ctx, app); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
@@ -86,3 +96,139 @@ func (p *Processor) AppCreate(ctx context.Context, authed *oauth.Auth, form *api
 
 	return apiApp, nil
 }
+
+// AppsGet returns a page of applications
+// managed by (ie., created by) the given user.
+func (p *Processor) AppsGet(
+	ctx context.Context,
+	userID string,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	apps, err := p.state.DB.GetApplicationsManagedByUserID(ctx, userID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting apps: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(apps)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = apps[count-1].ID
+		hi = apps[0].ID
+
+		// Best-guess items length.
+		items = make([]interface{}, 0, count)
+	)
+
+	for _, app := range apps {
+		apiApp, err := p.converter.AppToAPIAppSensitive(ctx, app)
+		if err != nil {
+			log.Errorf(ctx, "error converting app to api app: %v", err)
+			continue
+		}
+
+		// Append app to return items.
+		items = append(items, apiApp)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/apps",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// AppGet returns one application managed
+// by the given user, by its ID.
+func (p *Processor) AppGet(
+	ctx context.Context,
+	userID string,
+	appID string,
+) (*apimodel.Application, gtserror.WithCode) {
+	app, errWithCode := p.getManagedApp(ctx, userID, appID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiApp, err := p.converter.AppToAPIAppSensitive(ctx, app)
+	if err != nil {
+		err := gtserror.Newf("error converting app to api app: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiApp, nil
+}
+
+// AppDelete deletes one application managed by the given
+// user, along with its oauth client, and all tokens issued
+// to it (for *any* user), returning the deleted app.
+func (p *Processor) AppDelete(
+	ctx context.Context,
+	userID string,
+	appID string,
+) (*apimodel.Application, gtserror.WithCode) {
+	app, errWithCode := p.getManagedApp(ctx, userID, appID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert before deleting, as
+	// we want to return the app.
+	apiApp, err := p.converter.AppToAPIAppSensitive(ctx, app)
+	if err != nil {
+		err := gtserror.Newf("error converting app to api app: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Delete tokens first so that nothing
+	// can use the app while it's being removed.
+	if err := p.state.DB.DeleteTokensByClientID(ctx, app.ClientID); err != nil {
+		err := gtserror.Newf("db error deleting tokens for app: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteClientByID(ctx, app.ClientID); err != nil {
+		err := gtserror.Newf("db error deleting client for app: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteApplicationByClientID(ctx, app.ClientID); err != nil {
+		err := gtserror.Newf("db error deleting app: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiApp, nil
+}
+
+// getManagedApp gets the app with the given ID, returning
+// 404 if it doesn't exist, or isn't managed by the given user.
+func (p *Processor) getManagedApp(
+	ctx context.Context,
+	userID string,
+	appID string,
+) (*gtsmodel.Application, gtserror.WithCode) {
+	app, err := p.state.DB.GetApplicationByID(ctx, appID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting app: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if app == nil {
+		err := gtserror.Newf("app %s not found", appID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if app.ManagedByUserID != userID {
+		// Don't reveal that the app exists.
+		err := gtserror.Newf("app %s not managed by user %s", appID, userID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return app, nil
+}
@@ -0,0 +1,120 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type AppTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *AppTestSuite) createApp(ctx context.Context) *apimodel.Application {
+	apiApp, errWithCode := suite.processor.AppCreate(
+		ctx,
+		suite.testAutheds["local_account_1"],
+		&apimodel.ApplicationCreateRequest{
+			ClientName:   "my cool bot",
+			RedirectURIs: "urn:ietf:wg:oauth:2.0:oob",
+			Scopes:       "read write",
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	return apiApp
+}
+
+func (suite *AppTestSuite) TestAppsGet() {
+	var (
+		ctx    = context.Background()
+		user   = suite.testUsers["local_account_1"]
+		apiApp = suite.createApp(ctx)
+	)
+	suite.Equal([]string{"read", "write"}, apiApp.Scopes)
+
+	resp, errWithCode := suite.processor.AppsGet(ctx, user.ID, nil)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if suite.Len(resp.Items, 1) {
+		suite.Equal(apiApp.ID, resp.Items[0].(*apimodel.Application).ID)
+	}
+
+	// Another user shouldn't see it.
+	resp, errWithCode = suite.processor.AppsGet(ctx, suite.testUsers["local_account_2"].ID, nil)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(resp.Items)
+}
+
+func (suite *AppTestSuite) TestAppDelete() {
+	var (
+		ctx    = context.Background()
+		user   = suite.testUsers["local_account_1"]
+		apiApp = suite.createApp(ctx)
+	)
+
+	// Issue a token to the new app.
+	token := &gtsmodel.Token{
+		ID:          id.NewULID(),
+		ClientID:    apiApp.ClientID,
+		UserID:      suite.testUsers["local_account_2"].ID,
+		RedirectURI: "urn:ietf:wg:oauth:2.0:oob",
+		Access:      "AZERTYUIOPQSDFGHJKLMWXCVBN0123456789AZERTYUIOPQSD",
+	}
+	if err := suite.db.PutToken(ctx, token); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Other users can't delete it.
+	_, errWithCode := suite.processor.AppDelete(ctx, suite.testUsers["local_account_2"].ID, apiApp.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	deleted, errWithCode := suite.processor.AppDelete(ctx, user.ID, apiApp.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(apiApp.ID, deleted.ID)
+
+	// App, client, and token should all be gone.
+	_, err := suite.db.GetApplicationByID(ctx, apiApp.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	_, err = suite.db.GetClientByID(ctx, apiApp.ClientID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	_, err = suite.db.GetTokenByID(ctx, token.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestAppTestSuite(t *testing.T) {
+	suite.Run(t, new(AppTestSuite))
+}
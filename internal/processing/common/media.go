@@ -71,6 +71,20 @@ func (p *Processor) StoreLocalMedia(
 	return attachment, nil
 }
 
+// RefocusMedia is a wrapper around media.Manager{}.RefocusMedia()
+// with appropriate error response, to be called on changing the
+// focus of media, before updating its "thumbnail_variants" column.
+func (p *Processor) RefocusMedia(
+	ctx context.Context,
+	attachment *gtsmodel.MediaAttachment,
+) gtserror.WithCode {
+	if err := p.media.RefocusMedia(ctx, attachment); err != nil {
+		err := gtserror.Newf("error refocusing media: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+	return nil
+}
+
 // StoreLocalMedia is a wrapper around CreateMedia() and
 // ProcessingMedia{}.Load() with appropriate error responses.
 func (p *Processor) StoreLocalEmoji(
@@ -95,6 +95,11 @@ func (p *Processor) Accept(
 			return nil, errWithCode
 		}
 
+	case gtsmodel.InteractionQuote:
+		if errWithCode := p.acceptQuote(ctx, req); errWithCode != nil {
+			return nil, errWithCode
+		}
+
 	default:
 		err := gtserror.Newf("unknown interaction type for interaction request %s", reqID)
 		return nil, gtserror.NewErrorInternalError(err)
@@ -197,6 +202,50 @@ func (p *Processor) acceptReply(
 	return nil
 }
 
+// Package-internal convenience
+// function to accept a quote.
+func (p *Processor) acceptQuote(
+	ctx context.Context,
+	req *gtsmodel.InteractionRequest,
+) gtserror.WithCode {
+	// If the Quote is missing, that means it's
+	// probably already been undone by someone,
+	// so there's nothing to actually accept.
+	if req.Quote == nil {
+		err := gtserror.Newf("no Quote found for interaction request %s", req.ID)
+		return gtserror.NewErrorNotFound(err)
+	}
+
+	// Update the Quote.
+	req.Quote.PendingApproval = util.Ptr(false)
+	req.Quote.PreApproved = false
+	req.Quote.QuotePendingApproval = false
+	req.Quote.ApprovedByURI = req.URI
+	if err := p.state.DB.UpdateStatus(
+		ctx,
+		req.Quote,
+		"pending_approval",
+		"approved_by_uri",
+	); err != nil {
+		err := gtserror.Newf("db error updating status quote: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Send the accepted request off through the
+	// client API processor to handle side effects.
+	// Quotes are Notes too, so these are handled
+	// in the same way as replies.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityAccept,
+		GTSModel:       req,
+		Origin:         req.TargetAccount,
+		Target:         req.InteractingAccount,
+	})
+
+	return nil
+}
+
 // Package-internal convenience
 // function to accept an announce.
 func (p *Processor) acceptAnnounce(
@@ -41,6 +41,7 @@ func (p *Processor) GetPage(
 	likes bool,
 	replies bool,
 	boosts bool,
+	quotes bool,
 	page *paging.Page,
 ) (*apimodel.PageableResponse, gtserror.WithCode) {
 	reqs, err := p.state.DB.GetInteractionsRequestsForAcct(
@@ -50,6 +51,7 @@ func (p *Processor) GetPage(
 		likes,
 		replies,
 		boosts,
+		quotes,
 		page,
 	)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
@@ -90,7 +90,7 @@ func (p *Processor) Reject(
 			Target:         req.InteractingAccount,
 		})
 
-	case gtsmodel.InteractionReply:
+	case gtsmodel.InteractionReply, gtsmodel.InteractionQuote:
 		// Send the rejected request off through the
 		// client API processor to handle side effects.
 		p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
@@ -40,6 +40,13 @@ func (p *Processor) Delete(ctx context.Context, mediaAttachmentID string) gtserr
 		return gtserror.NewErrorInternalError(err)
 	}
 
+	if attachment.File.Path != "" {
+		// Lock media file, which may be shared with
+		// other media, until the attachment is deleted.
+		unlock := p.state.StorageLocks.Lock(attachment.File.Path)
+		defer unlock()
+	}
+
 	errs := []string{}
 
 	// delete the thumbnail from storage
@@ -49,10 +56,22 @@ func (p *Processor) Delete(ctx context.Context, mediaAttachmentID string) gtserr
 		}
 	}
 
-	// delete the file from storage
+	// delete any thumbnail variants from storage
+	for _, variant := range attachment.ThumbnailVariants {
+		if err := p.state.Storage.Delete(ctx, variant.Path); err != nil && !storage.IsNotFound(err) {
+			errs = append(errs, fmt.Sprintf("remove thumbnail variant at path %s: %s", variant.Path, err))
+		}
+	}
+
+	// delete the file from storage, unless shared with other media
 	if attachment.File.Path != "" {
-		if err := p.state.Storage.Delete(ctx, attachment.File.Path); err != nil && !storage.IsNotFound(err) {
-			errs = append(errs, fmt.Sprintf("remove file at path %s: %s", attachment.File.Path, err))
+		refs, err := p.state.DB.CountAttachmentsByFilePath(ctx, attachment.File.Path, attachment.ID)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("count media with file at path %s: %s", attachment.File.Path, err))
+		} else if refs == 0 {
+			if err := p.state.Storage.Delete(ctx, attachment.File.Path); err != nil && !storage.IsNotFound(err) {
+				errs = append(errs, fmt.Sprintf("remove file at path %s: %s", attachment.File.Path, err))
+			}
 		}
 	}
 
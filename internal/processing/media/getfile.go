@@ -201,6 +201,25 @@ func (p *Processor) getAttachmentContent(
 			apiContent,
 		)
 
+	case media.SizeW320, media.SizeW640, media.SizeW1280:
+		// Look for thumbnail variant of requested size.
+		width := media.ThumbVariantWidth(sizeStr)
+		for _, variant := range attach.ThumbnailVariants {
+			if variant.Width != width {
+				continue
+			}
+
+			apiContent.ContentType = variant.ContentType
+			apiContent.ContentLength = int64(variant.FileSize)
+			return p.getContent(ctx,
+				variant.Path,
+				apiContent,
+			)
+		}
+
+		const text = "media thumbnail size not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+
 	default:
 		const text = "invalid media attachment size"
 		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
@@ -379,6 +398,12 @@ func parseSize(s string) (media.Size, error) {
 		return media.SizeOriginal, nil
 	case string(media.SizeStatic):
 		return media.SizeStatic, nil
+	case string(media.SizeW320):
+		return media.SizeW320, nil
+	case string(media.SizeW640):
+		return media.SizeW640, nil
+	case string(media.SizeW1280):
+		return media.SizeW1280, nil
 	}
 	return "", fmt.Errorf("%s not a recognized media.Size", s)
 }
@@ -63,6 +63,59 @@ func (suite *GetFileTestSuite) TestGetRemoteFileCached() {
 	suite.EqualValues(len(suite.testRemoteAttachments[testAttachment.RemoteURL].Data), content.ContentLength)
 }
 
+func (suite *GetFileTestSuite) TestGetFileThumbnailVariant() {
+	ctx := context.Background()
+
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+	fileName := path.Base(testAttachment.File.Path)
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	// Store a thumbnail variant for the attachment.
+	data := []byte("a very small thumbnail")
+	variant := &gtsmodel.ThumbnailVariant{
+		Width:       320,
+		Height:      180,
+		Path:        testAttachment.AccountID + "/attachment/w320/" + testAttachment.ID + ".jpeg",
+		ContentType: "image/jpeg",
+		FileSize:    len(data),
+	}
+	if _, err := suite.storage.Put(ctx, variant.Path, data); err != nil {
+		suite.FailNow(err.Error())
+	}
+	testAttachment.ThumbnailVariants = []*gtsmodel.ThumbnailVariant{variant}
+	if err := suite.db.UpdateAttachment(ctx, testAttachment, "thumbnail_variants"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	content, errWithCode := suite.mediaProcessor.GetFile(ctx, requestingAccount, &apimodel.GetContentRequestForm{
+		AccountID: testAttachment.AccountID,
+		MediaType: string(media.TypeAttachment),
+		MediaSize: string(media.SizeW320),
+		FileName:  fileName,
+	})
+	suite.NoError(errWithCode)
+	suite.NotNil(content)
+	b, err := io.ReadAll(content.Content)
+	suite.NoError(err)
+
+	if closer, ok := content.Content.(io.Closer); ok {
+		suite.NoError(closer.Close())
+	}
+
+	suite.Equal(data, b)
+	suite.Equal("image/jpeg", content.ContentType)
+	suite.EqualValues(len(data), content.ContentLength)
+
+	// A variant size that wasn't generated should not be found.
+	_, errWithCode = suite.mediaProcessor.GetFile(ctx, requestingAccount, &apimodel.GetContentRequestForm{
+		AccountID: testAttachment.AccountID,
+		MediaType: string(media.TypeAttachment),
+		MediaSize: string(media.SizeW640),
+		FileName:  fileName,
+	})
+	suite.Equal(404, errWithCode.Code())
+}
+
 func (suite *GetFileTestSuite) TestGetRemoteFileUncached() {
 	ctx := context.Background()
 
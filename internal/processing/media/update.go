@@ -52,13 +52,22 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, media
 	}
 
 	if form.Focus != nil {
-		focusx, focusy, err := parseFocus(*form.Focus)
+		focusx, focusy, err := ParseFocus(*form.Focus)
 		if err != nil {
 			return nil, gtserror.NewErrorBadRequest(err)
 		}
-		attachment.FileMeta.Focus.X = focusx
-		attachment.FileMeta.Focus.Y = focusy
-		updatingColumns = append(updatingColumns, "focus_x", "focus_y")
+		if focusx != attachment.FileMeta.Focus.X ||
+			focusy != attachment.FileMeta.Focus.Y {
+			attachment.FileMeta.Focus.X = focusx
+			attachment.FileMeta.Focus.Y = focusy
+			updatingColumns = append(updatingColumns, "focus_x", "focus_y")
+
+			// Regenerate any thumbnail variants cropped around the old focus.
+			if errWithCode := p.c.RefocusMedia(ctx, attachment); errWithCode != nil {
+				return nil, errWithCode
+			}
+			updatingColumns = append(updatingColumns, "thumbnail_variants")
+		}
 	}
 
 	if err := p.state.DB.UpdateAttachment(ctx, attachment, updatingColumns...); err != nil {
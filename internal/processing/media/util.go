@@ -23,7 +23,10 @@ import (
 	"strings"
 )
 
-func parseFocus(focus string) (focusx, focusy float32, err error) {
+// ParseFocus parses the given focus string, in the
+// form "x,y", into focus x and y coordinates. Both
+// values must be between -1 and 1.
+func ParseFocus(focus string) (focusx, focusy float32, err error) {
 	if focus == "" {
 		return
 	}
@@ -114,6 +114,7 @@ func GetParseMentionFunc(state *state.State, federator *federation.Federator) gt
 			TargetAccountURL: targetAcct.URL,
 			TargetAccount:    targetAcct,
 			NameString:       namestring,
+			IsNew:            true,
 		}, nil
 	}
 }
@@ -0,0 +1,144 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+)
+
+// CreateOrReplace creates a Web Push subscription for the
+// given access token, replacing any existing subscription.
+func (p *Processor) CreateOrReplace(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	accessToken string,
+	form *apimodel.PushSubscriptionCreateRequest,
+) (*apimodel.PushSubscription, gtserror.WithCode) {
+	tokenID, errWithCode := p.getTokenID(ctx, accessToken)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	endpoint := form.Subscription.Endpoint
+	if endpoint == "" {
+		const text = "subscription[endpoint] must be provided"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if endpointURL, err := url.Parse(endpoint); err != nil ||
+		endpointURL.Scheme != "https" || endpointURL.Host == "" {
+		const text = "subscription[endpoint] must be an https URL"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	keys := form.Subscription.Keys
+	if keys.Auth == "" || keys.P256dh == "" {
+		const text = "subscription[keys][auth] and subscription[keys][p256dh] must be provided"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if err := webpush.ValidateKeys(keys.P256dh, keys.Auth); err != nil {
+		const text = "subscription keys are invalid"
+		return nil, gtserror.NewErrorUnprocessableEntity(err, text)
+	}
+
+	subscription := &gtsmodel.WebPushSubscription{
+		ID:        id.NewULID(),
+		AccountID: account.ID,
+		TokenID:   tokenID,
+		Endpoint:  endpoint,
+		Auth:      keys.Auth,
+		P256dh:    keys.P256dh,
+		Policy:    gtsmodel.WebPushNotificationPolicyAll,
+	}
+
+	if errWithCode := applyData(subscription, form.Data); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Tokens can only have one subscription,
+	// so remove any that might already exist.
+	if err := p.state.DB.DeleteWebPushSubscriptionByTokenID(ctx, tokenID); err != nil {
+		err := gtserror.Newf("db error deleting existing subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.PutWebPushSubscription(ctx, subscription); err != nil {
+		err := gtserror.Newf("db error putting subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiSubscription, err := p.converter.WebPushSubscriptionToAPIWebPushSubscription(ctx, subscription)
+	if err != nil {
+		err := gtserror.Newf("error converting subscription to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiSubscription, nil
+}
+
+// applyData sets the alerts and policy from
+// the given request data on the subscription.
+func applyData(
+	subscription *gtsmodel.WebPushSubscription,
+	data *apimodel.PushSubscriptionRequestData,
+) gtserror.WithCode {
+	if data == nil {
+		return nil
+	}
+
+	if data.Policy != nil {
+		switch policy := gtsmodel.WebPushNotificationPolicy(*data.Policy); policy {
+		case gtsmodel.WebPushNotificationPolicyAll,
+			gtsmodel.WebPushNotificationPolicyFollowed,
+			gtsmodel.WebPushNotificationPolicyFollower,
+			gtsmodel.WebPushNotificationPolicyNone:
+			subscription.Policy = policy
+		default:
+			const text = "data[policy] must be one of all, followed, follower, none"
+			return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+	}
+
+	if alerts := data.Alerts; alerts != nil {
+		flags := &subscription.NotificationFlags
+		flags.Set(gtsmodel.NotificationFollow, alerts.Follow)
+		flags.Set(gtsmodel.NotificationFollowRequest, alerts.FollowRequest)
+		flags.Set(gtsmodel.NotificationFave, alerts.Favourite)
+		flags.Set(gtsmodel.NotificationMention, alerts.Mention)
+		flags.Set(gtsmodel.NotificationReblog, alerts.Reblog)
+		flags.Set(gtsmodel.NotificationPoll, alerts.Poll)
+		flags.Set(gtsmodel.NotificationStatus, alerts.Status)
+		flags.Set(gtsmodel.NotificationSignup, alerts.AdminSignup)
+		flags.Set(gtsmodel.NotificationPendingFave, alerts.PendingFavourite)
+		flags.Set(gtsmodel.NotificationPendingReply, alerts.PendingReply)
+		flags.Set(gtsmodel.NotificationPendingReblog, alerts.PendingReblog)
+		flags.Set(gtsmodel.NotificationPendingQuote, alerts.PendingQuote)
+		flags.Set(gtsmodel.NotificationReaction, alerts.Reaction)
+	}
+
+	return nil
+}
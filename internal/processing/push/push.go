@@ -0,0 +1,74 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+type Processor struct {
+	state     *state.State
+	converter *typeutils.Converter
+}
+
+func New(state *state.State, converter *typeutils.Converter) Processor {
+	return Processor{
+		state:     state,
+		converter: converter,
+	}
+}
+
+// getTokenID returns the ID of the token with the given
+// access token, which Web Push subscriptions are keyed by.
+func (p *Processor) getTokenID(ctx context.Context, accessToken string) (string, gtserror.WithCode) {
+	token, err := p.state.DB.GetTokenByAccess(ctx, accessToken)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			const text = "token not found"
+			return "", gtserror.NewErrorUnauthorized(errors.New(text), text)
+		}
+		err := gtserror.Newf("db error getting token: %w", err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	return token.ID, nil
+}
+
+// getSubscription returns the Web Push subscription
+// for the given token ID, or 404 if there isn't one.
+func (p *Processor) getSubscription(ctx context.Context, tokenID string) (*gtsmodel.WebPushSubscription, gtserror.WithCode) {
+	subscription, err := p.state.DB.GetWebPushSubscriptionByTokenID(ctx, tokenID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if subscription == nil {
+		const text = "push subscription not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return subscription, nil
+}
@@ -85,22 +85,16 @@ func (p *Processor) Accounts(
 		}...).
 		Debugf("beginning search")
 
-	// todo: Currently we don't support offset for paging;
-	// if caller supplied an offset greater than 0, return
-	// nothing as though there were no additional results.
-	if offset > 0 {
-		return p.packageAccounts(
-			ctx,
-			requestingAccount,
-			foundAccounts,
-			includeInstanceAccounts,
-			includeBlockedAccounts,
-		)
-	}
-
 	// See if we have something that looks like a namestring.
 	username, domain, err := util.ExtractNamestringParts(query)
-	if err == nil {
+	switch {
+	case err == nil && offset > 0:
+		// We don't support offset for paging namestring
+		// searches; if caller supplied an offset greater
+		// than 0, return nothing as though there were no
+		// additional results.
+
+	case err == nil:
 		if domain != "" {
 			// Search was an exact namestring;
 			// we can safely assume caller is
@@ -128,14 +122,16 @@ func (p *Processor) Accounts(
 			err = gtserror.Newf("error searching by namestring: %w", err)
 			return nil, gtserror.NewErrorInternalError(err)
 		}
-	} else {
+
+	default:
 		// Query Doesn't look like a
-		// namestring, use text search.
+		// namestring, use text search,
+		// ordered by relevance.
 		if err := p.accountsByText(
 			ctx,
 			requestingAccount.ID,
-			id.Highest,
-			id.Lowest,
+			"",
+			"",
 			limit,
 			offset,
 			query,
@@ -119,11 +119,13 @@ func (p *Processor) Get(
 		}...).
 		Debugf("beginning search")
 
-	// todo: Currently we don't support offset for paging;
-	// a caller can page using maxID or minID, but if they
-	// supply an offset greater than 0, return nothing as
-	// though there were no additional results.
-	if req.Offset > 0 {
+	// Offset is only supported for paging through full-text
+	// search results for accounts + statuses, which are
+	// ordered by relevance. For other searches, if caller
+	// supplies an offset greater than 0, return nothing as
+	// though there were no additional results; they can
+	// page using maxID or minID instead.
+	noResults := func() (*apimodel.SearchResult, gtserror.WithCode) {
 		return p.packageSearchResult(
 			ctx,
 			account,
@@ -175,6 +177,10 @@ func (p *Processor) Get(
 			includeInstanceAccounts = domainSet
 			includeBlockedAccounts = domainSet
 
+			if offset > 0 {
+				return noResults()
+			}
+
 			err = p.accountsByUsernameDomain(
 				ctx,
 				account,
@@ -221,6 +227,11 @@ func (p *Processor) Get(
 		// caller wants to include blocked accounts too.
 		includeBlockedAccounts = true
 
+		if offset > 0 {
+			// Only ever 1 result.
+			return noResults()
+		}
+
 		if err := p.byURI(
 			ctx,
 			account,
@@ -704,6 +715,12 @@ func (p *Processor) hashtag(
 		return false, nil
 	}
 
+	if offset > 0 {
+		// Tags can't be paged by
+		// offset, only maxID / minID.
+		return false, nil
+	}
+
 	// Search for tags starting with the normalized string.
 	tags, err := p.state.DB.SearchForTags(
 		ctx,
@@ -109,6 +109,15 @@ func (p *Processor) Create(
 		return nil, errWithCode
 	}
 
+	// Check + attach quoted status.
+	if errWithCode := p.processQuote(ctx,
+		requester,
+		status,
+		form.QuotedStatusID,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	if errWithCode := p.processThreadID(ctx, status); errWithCode != nil {
 		return nil, errWithCode
 	}
@@ -131,7 +140,13 @@ func (p *Processor) Create(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	if err := p.processContent(ctx, p.parseMention, form, status); err != nil {
+	if err := p.processContent(ctx,
+		p.parseMention,
+		form.ContentType,
+		form.Status,
+		form.SpoilerText,
+		status,
+	); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
@@ -267,6 +282,98 @@ func (p *Processor) processInReplyTo(ctx context.Context, requester *gtsmodel.Ac
 	return nil
 }
 
+func (p *Processor) processQuote(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status, quotedStatusID string) gtserror.WithCode {
+	if quotedStatusID == "" {
+		// Not a quote.
+		// Nothing to do.
+		return nil
+	}
+
+	// Fetch target quoted status (checking visibility).
+	quote, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		quotedStatusID,
+		nil,
+	)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// If this is a boost, unwrap it to get source status.
+	quote, errWithCode = p.c.UnwrapIfBoost(ctx,
+		requester,
+		quote,
+	)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// Ensure valid quote target for requester.
+	policyResult, err := p.intFilter.StatusQuoteable(ctx,
+		requester,
+		quote,
+	)
+	if err != nil {
+		err := gtserror.Newf("error seeing if status %s is quoteable: %w", status.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if policyResult.Forbidden() {
+		const errText = "you do not have permission to quote this status"
+		err := gtserror.New(errText)
+		return gtserror.NewErrorForbidden(err, errText)
+	}
+
+	// Derive pendingApproval status.
+	var pendingApproval bool
+	switch {
+	case policyResult.WithApproval():
+		// We're allowed to do
+		// this pending approval.
+		pendingApproval = true
+
+	case policyResult.MatchedOnCollection():
+		// We're permitted to do this, but since
+		// we matched due to presence in a followers
+		// or following collection, we should mark
+		// as pending approval and wait until we can
+		// prove it's been Accepted by the target.
+		pendingApproval = true
+
+		if *quote.Local {
+			// If the target is local we don't need
+			// to wait for an Accept from remote,
+			// we can just preapprove it and have
+			// the processor create the Accept.
+			status.PreApproved = true
+		}
+	}
+
+	if pendingApproval {
+		// A status can only be pending approval
+		// for one interaction at a time, so if this
+		// is also a reply that needs approval then
+		// it can't be posted as a quote too.
+		if util.PtrOrValue(status.PendingApproval, false) {
+			const errText = "cannot quote this status in a reply that is itself pending approval"
+			err := gtserror.New(errText)
+			return gtserror.NewErrorUnprocessableEntity(err, errText)
+		}
+
+		status.PendingApproval = util.Ptr(true)
+		status.QuotePendingApproval = true
+	}
+
+	// Set status fields from quote.
+	status.QuoteID = quote.ID
+	status.Quote = quote
+	status.QuoteURI = quote.URI
+	status.QuoteAccountID = quote.AccountID
+	status.QuoteAccount = quote.Account
+
+	return nil
+}
+
 func (p *Processor) processThreadID(ctx context.Context, status *gtsmodel.Status) gtserror.WithCode {
 	// Status takes the thread ID of
 	// whatever it replies to, if set.
@@ -312,45 +419,85 @@ func (p *Processor) processMediaIDs(ctx context.Context, form *apimodel.StatusCr
 		return nil
 	}
 
-	// Get minimum allowed char descriptions.
-	minChars := config.GetMediaDescriptionMinChars()
+	attachments, errWithCode := p.getAttachableMedia(ctx, form.MediaIDs, thisAccountID)
+	if errWithCode != nil {
+		return errWithCode
+	}
 
-	attachments := []*gtsmodel.MediaAttachment{}
-	attachmentIDs := []string{}
+	attachmentIDs := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		attachmentIDs = append(attachmentIDs, attachment.ID)
+	}
 
-	for _, mediaID := range form.MediaIDs {
-		attachment, err := p.state.DB.GetAttachmentByID(ctx, mediaID)
-		if err != nil && !errors.Is(err, db.ErrNoEntries) {
-			err := gtserror.Newf("error fetching media from db: %w", err)
-			return gtserror.NewErrorInternalError(err)
-		}
+	status.Attachments = attachments
+	status.AttachmentIDs = attachmentIDs
+	return nil
+}
 
-		if attachment == nil {
-			text := fmt.Sprintf("media %s not found", mediaID)
-			return gtserror.NewErrorBadRequest(errors.New(text), text)
-		}
+// getAttachableMedia fetches the media with given IDs, checking
+// that each belongs to the given account, is not yet attached to
+// a (scheduled) status, and has a long enough description.
+func (p *Processor) getAttachableMedia(ctx context.Context, mediaIDs []string, thisAccountID string) ([]*gtsmodel.MediaAttachment, gtserror.WithCode) {
+	attachments := make([]*gtsmodel.MediaAttachment, 0, len(mediaIDs))
 
-		if attachment.AccountID != thisAccountID {
-			text := fmt.Sprintf("media %s does not belong to account", mediaID)
-			return gtserror.NewErrorBadRequest(errors.New(text), text)
+	for _, mediaID := range mediaIDs {
+		attachment, errWithCode := p.getAttachableMediaByID(ctx, mediaID, thisAccountID, "")
+		if errWithCode != nil {
+			return nil, errWithCode
 		}
 
-		if attachment.StatusID != "" || attachment.ScheduledStatusID != "" {
-			text := fmt.Sprintf("media %s already attached to status", mediaID)
-			return gtserror.NewErrorBadRequest(errors.New(text), text)
-		}
+		attachments = append(attachments, attachment)
+	}
+
+	if errWithCode := checkMediaDescriptions(attachments); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return attachments, nil
+}
+
+// getAttachableMediaByID fetches the media with given ID, checking that it
+// belongs to the given account, and is not yet attached to a (scheduled) status
+// other than the one with given status ID, (i.e. when editing a status).
+func (p *Processor) getAttachableMediaByID(ctx context.Context, mediaID string, thisAccountID string, statusID string) (*gtsmodel.MediaAttachment, gtserror.WithCode) {
+	attachment, err := p.state.DB.GetAttachmentByID(ctx, mediaID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error fetching media from db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if attachment == nil {
+		text := fmt.Sprintf("media %s not found", mediaID)
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if attachment.AccountID != thisAccountID {
+		text := fmt.Sprintf("media %s does not belong to account", mediaID)
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if (attachment.StatusID != "" && attachment.StatusID != statusID) ||
+		attachment.ScheduledStatusID != "" {
+		text := fmt.Sprintf("media %s already attached to status", mediaID)
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return attachment, nil
+}
 
+// checkMediaDescriptions checks that all of the given
+// media have descriptions of the minimum allowed length.
+func checkMediaDescriptions(attachments []*gtsmodel.MediaAttachment) gtserror.WithCode {
+	// Get minimum allowed char descriptions.
+	minChars := config.GetMediaDescriptionMinChars()
+
+	for _, attachment := range attachments {
 		if length := len([]rune(attachment.Description)); length < minChars {
-			text := fmt.Sprintf("media %s description too short, at least %d required", mediaID, minChars)
+			text := fmt.Sprintf("media %s description too short, at least %d required", attachment.ID, minChars)
 			return gtserror.NewErrorBadRequest(errors.New(text), text)
 		}
-
-		attachments = append(attachments, attachment)
-		attachmentIDs = append(attachmentIDs, attachment.ID)
 	}
 
-	status.Attachments = attachments
-	status.AttachmentIDs = attachmentIDs
 	return nil
 }
 
@@ -462,11 +609,17 @@ func processLanguage(form *apimodel.StatusCreateRequest, accountDefaultLanguage
 	return nil
 }
 
-func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.ParseMentionFunc, form *apimodel.StatusCreateRequest, status *gtsmodel.Status) error {
-	if form.ContentType == "" {
+func (p *Processor) processContent(
+	ctx context.Context,
+	parseMention gtsmodel.ParseMentionFunc,
+	contentType apimodel.StatusContentType,
+	content string,
+	contentWarning string,
+	status *gtsmodel.Status,
+) error {
+	if contentType == "" {
 		// If content type wasn't specified, use the author's preferred content-type.
-		contentType := apimodel.StatusContentType(status.Account.Settings.StatusContentType)
-		form.ContentType = contentType
+		contentType = apimodel.StatusContentType(status.Account.Settings.StatusContentType)
 	}
 
 	// format is the currently set text formatting
@@ -479,7 +632,7 @@ func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.Pa
 		return formatFunc(ctx, parseMention, status.AccountID, status.ID, input)
 	}
 
-	switch form.ContentType {
+	switch contentType {
 	// None given / set,
 	// use default (plain).
 	case "":
@@ -495,11 +648,11 @@ func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.Pa
 
 	// Unknown.
 	default:
-		return fmt.Errorf("invalid status format: %q", form.ContentType)
+		return fmt.Errorf("invalid status format: %q", contentType)
 	}
 
 	// Sanitize status text and format.
-	contentRes := formatInput(format, form.Status)
+	contentRes := formatInput(format, content)
 
 	// Collect formatted results.
 	status.Content = contentRes.HTML
@@ -512,7 +665,7 @@ func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.Pa
 	format = p.formatter.FromPlainEmojiOnly
 
 	// Sanitize content warning and format.
-	spoiler := text.SanitizeToPlaintext(form.SpoilerText)
+	spoiler := text.SanitizeToPlaintext(contentWarning)
 	warningRes := formatInput(format, spoiler)
 
 	// Collect formatted results.
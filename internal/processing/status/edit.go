@@ -0,0 +1,399 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/media"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// Edit processes the given form to edit an existing status owned by requester,
+// storing the previous version of the status as a historical revision, and
+// returning the api model representation of the updated status if it's OK.
+//
+// Precondition: the form's fields should have already been validated and normalized by the caller.
+func (p *Processor) Edit(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	statusID string,
+	form *apimodel.StatusEditRequest,
+) (
+	*apimodel.Status,
+	gtserror.WithCode,
+) {
+	// Ensure account populated; we'll need settings.
+	if err := p.state.DB.PopulateAccount(ctx, requester); err != nil {
+		log.Errorf(ctx, "error(s) populating account, will continue: %s", err)
+	}
+
+	status, err := p.state.DB.GetStatusByID(ctx, statusID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting status %s: %w", statusID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if status == nil {
+		err := gtserror.Newf("status %s not found", statusID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if status.AccountID != requester.ID {
+		err := gtserror.New("status doesn't belong to requesting account")
+		return nil, gtserror.NewErrorForbidden(err)
+	}
+
+	if status.BoostOfID != "" {
+		const text = "boosts cannot be edited"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Use the populated requester
+	// as the status account model.
+	status.Account = requester
+
+	// Snapshot the current version of
+	// the status as a historical revision.
+	edit := &gtsmodel.StatusEdit{
+		ID:             id.NewULID(),
+		CreatedAt:      status.CreatedAt,
+		StatusID:       status.ID,
+		Content:        status.Content,
+		ContentWarning: status.ContentWarning,
+		Text:           status.Text,
+		Language:       status.Language,
+		Sensitive:      status.Sensitive,
+		AttachmentIDs:  status.AttachmentIDs,
+		Attachments:    status.Attachments,
+	}
+
+	if !status.EditedAt.IsZero() {
+		// Status was edited before, so
+		// current version dates from then.
+		edit.CreatedAt = status.EditedAt
+	}
+
+	if status.Poll != nil {
+		edit.PollOptions = status.Poll.Options
+		edit.PollVotes = status.Poll.Votes
+	}
+
+	// Get current time.
+	now := time.Now()
+
+	// Keep hold of the existing mentions and
+	// poll so we can compare them post-edit.
+	oldMentions := status.Mentions
+	oldPoll := status.Poll
+
+	// Reset formatting results, these
+	// are regenerated from new content.
+	status.Mentions = nil
+	status.Emojis = nil
+	status.Tags = nil
+
+	// Set the new status poll from the form
+	// (if any), we compare this to the old
+	// poll once the options are formatted.
+	status.Poll = nil
+	if form.Poll != nil {
+		secs := time.Duration(form.Poll.ExpiresIn)
+		status.Poll = &gtsmodel.Poll{
+			ID:         id.NewULID(),
+			Multiple:   &form.Poll.Multiple,
+			HideCounts: &form.Poll.HideTotals,
+			Options:    form.Poll.Options,
+			StatusID:   status.ID,
+			Status:     status,
+			ExpiresAt:  now.Add(secs * time.Second),
+		}
+	}
+
+	updatedMedia, refocusedMedia, errWithCode := p.processEditMediaIDs(ctx, form, requester.ID, status)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Update the plain fields.
+	status.Text = form.Status
+	status.Sensitive = &form.Sensitive
+	if form.Language != "" {
+		status.Language = form.Language
+	}
+
+	// Wrap the parse mention function so that mentions of
+	// accounts already mentioned by the status are reused,
+	// rather than being stored and notified a second time.
+	parseMention := func(
+		ctx context.Context,
+		namestring string,
+		originAccountID string,
+		statusID string,
+	) (*gtsmodel.Mention, error) {
+		mention, err := p.parseMention(ctx, namestring, originAccountID, statusID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, existing := range oldMentions {
+			if existing.TargetAccountURI == mention.TargetAccountURI {
+				return existing, nil
+			}
+		}
+
+		return mention, nil
+	}
+
+	if err := p.processContent(ctx,
+		parseMention,
+		form.ContentType,
+		form.Status,
+		form.SpoilerText,
+		status,
+	); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check whether the poll needs replacing. Only a change in options
+	// or multiple-choice resets a poll, otherwise we keep the old one,
+	// along with any votes that have already been cast in it.
+	var pollChanged bool
+	switch newPoll := status.Poll; {
+	case oldPoll == nil && newPoll == nil:
+		// No poll before or after.
+
+	case oldPoll != nil && newPoll != nil &&
+		slices.Equal(oldPoll.Options, newPoll.Options) &&
+		*oldPoll.Multiple == *newPoll.Multiple:
+		// Poll unchanged, keep the old one.
+		status.Poll = oldPoll
+
+	default:
+		pollChanged = true
+	}
+
+	if pollChanged {
+		if status.Poll != nil {
+			// Try to insert the new status poll in the database.
+			if err := p.state.DB.PutPoll(ctx, status.Poll); err != nil {
+				err := gtserror.Newf("error inserting poll in db: %w", err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+			status.PollID = status.Poll.ID
+			status.ActivityStreamsType = ap.ActivityQuestion
+		} else {
+			status.PollID = ""
+			status.ActivityStreamsType = ap.ObjectNote
+		}
+	}
+
+	// Insert the historical revision in the database.
+	if err := p.state.DB.PutStatusEdit(ctx, edit); err != nil {
+		err := gtserror.Newf("error inserting status edit in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Add the revision to the status.
+	status.EditIDs = append(status.EditIDs, edit.ID)
+	status.Edits = append(status.Edits, edit)
+	status.EditedAt = now
+
+	// Update the status in the database.
+	if err := p.state.DB.UpdateStatus(ctx, status,
+		"content",
+		"content_warning",
+		"text",
+		"language",
+		"sensitive",
+		"attachments",
+		"mentions",
+		"emojis",
+		"tags",
+		"poll_id",
+		"activity_streams_type",
+		"edits",
+		"edited_at",
+	); err != nil {
+		err := gtserror.Newf("error updating status in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Now the edit is stored, regenerate thumbnail
+	// variants cropped around any changed focus.
+	for _, attachment := range refocusedMedia {
+		if errWithCode := p.c.RefocusMedia(ctx, attachment); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	// Update any media attributes it changed.
+	for _, attachment := range updatedMedia {
+		if err := p.state.DB.UpdateAttachment(ctx, attachment,
+			"description",
+			"focus_x",
+			"focus_y",
+			"thumbnail_variants",
+		); err != nil {
+			err := gtserror.Newf("error updating media in db: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// Delete any mentions no longer
+	// included in the edited status.
+	for _, mention := range oldMentions {
+		if slices.Contains(status.MentionIDs, mention.ID) {
+			continue
+		}
+
+		if err := p.state.DB.DeleteMentionByID(ctx, mention.ID); err != nil {
+			log.Errorf(ctx, "error deleting stale mention: %v", err)
+		}
+	}
+
+	if pollChanged && oldPoll != nil {
+		// Delete the replaced poll from the database.
+		if err := p.state.DB.DeletePollByID(ctx, oldPoll.ID); err != nil {
+			log.Errorf(ctx, "error deleting replaced poll: %v", err)
+		}
+
+		// Cancel any scheduled expiry task for poll.
+		_ = p.state.Workers.Scheduler.Cancel(oldPoll.ID)
+	}
+
+	// Send it back to the client API worker for async side-effects.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       status,
+		Origin:         requester,
+	})
+
+	if pollChanged && status.Poll != nil {
+		// Now that the status is updated, and side effects queued,
+		// attempt to schedule an expiry handler for the status poll.
+		if err := p.polls.ScheduleExpiry(ctx, status.Poll); err != nil {
+			log.Errorf(ctx, "error scheduling poll expiry: %v", err)
+		}
+	}
+
+	return p.c.GetAPIStatus(ctx, requester, status)
+}
+
+// processEditMediaIDs sets the attachments of the given status being edited
+// according to form media IDs, applying any given media attributes. Media
+// already attached to the status can be reused, newly attached media must
+// belong to the requester and not yet be attached to another status. The
+// media with changed attributes are returned, to be updated in the database
+// only once the edit itself has been stored, along with the subset of those
+// with a changed focus, which need their thumbnail variants regenerating.
+func (p *Processor) processEditMediaIDs(
+	ctx context.Context,
+	form *apimodel.StatusEditRequest,
+	thisAccountID string,
+	status *gtsmodel.Status,
+) (
+	[]*gtsmodel.MediaAttachment,
+	[]*gtsmodel.MediaAttachment,
+	gtserror.WithCode,
+) {
+	attachments := make([]*gtsmodel.MediaAttachment, 0, len(form.MediaIDs))
+	attachmentIDs := make([]string, 0, len(form.MediaIDs))
+
+	for _, mediaID := range form.MediaIDs {
+		// Check if the status already has this attachment.
+		i := slices.IndexFunc(status.Attachments, func(a *gtsmodel.MediaAttachment) bool {
+			return a.ID == mediaID
+		})
+		if i >= 0 {
+			attachments = append(attachments, status.Attachments[i])
+			attachmentIDs = append(attachmentIDs, mediaID)
+			continue
+		}
+
+		attachment, errWithCode := p.getAttachableMediaByID(ctx, mediaID, thisAccountID, status.ID)
+		if errWithCode != nil {
+			return nil, nil, errWithCode
+		}
+
+		attachments = append(attachments, attachment)
+		attachmentIDs = append(attachmentIDs, attachment.ID)
+	}
+
+	// Media with changed
+	// attributes / focus.
+	var updated []*gtsmodel.MediaAttachment
+	var refocused []*gtsmodel.MediaAttachment
+
+	// Apply any media attributes.
+	for _, attrs := range form.MediaAttributes {
+		i := slices.Index(attachmentIDs, attrs.ID)
+		if i < 0 {
+			text := fmt.Sprintf("media %s not attached to status", attrs.ID)
+			return nil, nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		// Copy the attachment, so as not to
+		// modify the version of the status
+		// being kept as a historical revision.
+		attachment := new(gtsmodel.MediaAttachment)
+		*attachment = *attachments[i]
+		attachments[i] = attachment
+
+		attachment.Description = text.SanitizeToPlaintext(attrs.Description)
+
+		if attrs.Focus != "" {
+			focusx, focusy, err := media.ParseFocus(attrs.Focus)
+			if err != nil {
+				return nil, nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+			if focusx != attachment.FileMeta.Focus.X ||
+				focusy != attachment.FileMeta.Focus.Y {
+				attachment.FileMeta.Focus.X = focusx
+				attachment.FileMeta.Focus.Y = focusy
+				refocused = append(refocused, attachment)
+			}
+		}
+
+		updated = append(updated, attachment)
+	}
+
+	// Finally check all descriptions
+	// meet the minimum requirements.
+	if errWithCode := checkMediaDescriptions(attachments); errWithCode != nil {
+		return nil, nil, errWithCode
+	}
+
+	status.Attachments = attachments
+	status.AttachmentIDs = attachmentIDs
+	return updated, refocused, nil
+}
@@ -0,0 +1,96 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type StatusEditTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusEditTestSuite) TestEditMediaAttributes() {
+	ctx := context.Background()
+
+	editingAccount := suite.testAccounts["admin_account"]
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	form := &apimodel.StatusEditRequest{
+		Status:      "hello world! (edited)",
+		MediaIDs:    []string{testAttachment.ID},
+		ContentType: apimodel.StatusContentTypePlain,
+		MediaAttributes: []apimodel.AttachmentAttributesRequest{{
+			ID:          testAttachment.ID,
+			Description: "a new description",
+			Focus:       "-0.5,0.5",
+		}},
+	}
+
+	apiStatus, errWithCode := suite.status.Edit(ctx, editingAccount, testStatus.ID, form)
+	suite.NoError(errWithCode)
+	suite.NotNil(apiStatus)
+
+	// Media attributes should be updated.
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.NoError(err)
+	suite.Equal("a new description", dbAttachment.Description)
+	suite.Equal(float32(-0.5), dbAttachment.FileMeta.Focus.X)
+	suite.Equal(float32(0.5), dbAttachment.FileMeta.Focus.Y)
+}
+
+func (suite *StatusEditTestSuite) TestEditRejectedMediaUnchanged() {
+	ctx := context.Background()
+
+	config.SetMediaDescriptionMinChars(100)
+
+	editingAccount := suite.testAccounts["admin_account"]
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	form := &apimodel.StatusEditRequest{
+		Status:      "hello world! (edited)",
+		MediaIDs:    []string{testAttachment.ID},
+		ContentType: apimodel.StatusContentTypePlain,
+		MediaAttributes: []apimodel.AttachmentAttributesRequest{{
+			ID:          testAttachment.ID,
+			Description: "too short",
+			Focus:       "-0.5,0.5",
+		}},
+	}
+
+	apiStatus, errWithCode := suite.status.Edit(ctx, editingAccount, testStatus.ID, form)
+	suite.EqualError(errWithCode, "media "+testAttachment.ID+" description too short, at least 100 required")
+	suite.Nil(apiStatus)
+
+	// Media attributes should be unchanged.
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.NoError(err)
+	suite.Equal(testAttachment.Description, dbAttachment.Description)
+	suite.Equal(testAttachment.FileMeta.Focus, dbAttachment.FileMeta.Focus)
+}
+
+func TestStatusEditTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusEditTestSuite))
+}
@@ -0,0 +1,238 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// ReactionAdd adds an emoji reaction with the given emoji (unicode emoji,
+// or custom emoji shortcode) by the requester to the given status.
+// Adding a reaction that already exists is a no-op.
+func (p *Processor) ReactionAdd(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetStatusID string,
+	emoji string,
+) (*apimodel.Status, gtserror.WithCode) {
+	status, errWithCode := p.getReactableStatus(ctx, requester, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	name, customEmoji, errWithCode := p.reactionEmoji(ctx, emoji)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Ensure valid reaction target for requester.
+	policyResult, err := p.intFilter.StatusReactable(ctx,
+		requester,
+		status,
+	)
+	if err != nil {
+		err := gtserror.Newf("error seeing if status %s is reactable: %w", status.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !policyResult.Permitted() {
+		// There's no approval flow for
+		// reactions, so anything that's
+		// not permitted outright is out.
+		const errText = "you do not have permission to react to this status"
+		err := gtserror.New(errText)
+		return nil, gtserror.NewErrorForbidden(err, errText)
+	}
+
+	reactionID := id.NewULID()
+	reaction := &gtsmodel.StatusReaction{
+		ID:              reactionID,
+		AccountID:       requester.ID,
+		Account:         requester,
+		TargetAccountID: status.AccountID,
+		TargetAccount:   status.Account,
+		StatusID:        status.ID,
+		Status:          status,
+		Name:            name,
+		URI:             uris.GenerateURIForLike(requester.Username, reactionID),
+	}
+
+	if customEmoji != nil {
+		reaction.EmojiID = customEmoji.ID
+		reaction.Emoji = customEmoji
+	}
+
+	if err := p.state.DB.PutStatusReaction(ctx, reaction); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// Already reacted,
+			// nothing changes.
+			return p.c.GetAPIStatus(ctx, requester, status)
+		}
+
+		err := gtserror.Newf("db error putting reaction: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process new reaction side effects.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActivityEmojiReact,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       reaction,
+		Origin:         requester,
+		Target:         status.Account,
+	})
+
+	return p.c.GetAPIStatus(ctx, requester, status)
+}
+
+// ReactionRemove removes the emoji reaction with the given emoji by the
+// requester from the given status (no-op if reaction doesn't exist).
+func (p *Processor) ReactionRemove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetStatusID string,
+	emoji string,
+) (*apimodel.Status, gtserror.WithCode) {
+	status, errWithCode := p.getReactableStatus(ctx, requester, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Reactions are stored without surrounding
+	// colons or local domain, so trim them
+	// off the same as when adding.
+	name := strings.Trim(emoji, ":")
+	name = strings.TrimSuffix(name, "@"+config.GetHost())
+
+	reaction, err := p.state.DB.GetStatusReaction(ctx,
+		requester.ID,
+		status.ID,
+		name,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting reaction: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if reaction == nil {
+		// Status isn't reacted to
+		// with this emoji by requester.
+		return p.c.GetAPIStatus(ctx, requester, status)
+	}
+
+	if err := p.state.DB.DeleteStatusReactionByID(ctx, reaction.ID); err != nil {
+		err := gtserror.Newf("db error deleting reaction: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process reaction removal side effects.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActivityEmojiReact,
+		APActivityType: ap.ActivityUndo,
+		GTSModel:       reaction,
+		Origin:         requester,
+		Target:         status.Account,
+	})
+
+	return p.c.GetAPIStatus(ctx, requester, status)
+}
+
+// getReactableStatus gets the given status, checking that
+// it's visible to requester, and unwrapping it if it's a boost.
+func (p *Processor) getReactableStatus(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetID string,
+) (*gtsmodel.Status, gtserror.WithCode) {
+	target, errWithCode := p.c.GetVisibleTargetStatus(
+		ctx,
+		requester,
+		targetID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.c.UnwrapIfBoost(
+		ctx,
+		requester,
+		target,
+	)
+}
+
+// reactionEmoji parses the given emoji, which may be a unicode
+// emoji, the shortcode of an enabled local custom emoji, or
+// "shortcode@domain" for a remote custom emoji we have stored,
+// optionally wrapped in colons. It returns the reaction name
+// to store, and the custom emoji if this isn't a unicode emoji.
+func (p *Processor) reactionEmoji(
+	ctx context.Context,
+	emoji string,
+) (string, *gtsmodel.Emoji, gtserror.WithCode) {
+	name := strings.Trim(emoji, ":")
+	if validate.UnicodeEmoji(name) == nil {
+		return name, nil, nil
+	}
+
+	invalid := func() (string, *gtsmodel.Emoji, gtserror.WithCode) {
+		err := fmt.Errorf("%s is not a recognized emoji", emoji)
+		return "", nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	shortcode, domain, _ := strings.Cut(name, "@")
+	if domain == config.GetHost() {
+		// Local emoji are
+		// stored by shortcode.
+		domain = ""
+	}
+
+	if validate.EmojiShortcode(shortcode) != nil {
+		return invalid()
+	}
+
+	customEmoji, err := p.state.DB.GetEmojiByShortcodeDomain(ctx, shortcode, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting emoji %s: %w", name, err)
+		return "", nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if customEmoji == nil || *customEmoji.Disabled {
+		return invalid()
+	}
+
+	if domain == "" {
+		return shortcode, customEmoji, nil
+	}
+
+	return shortcode + "@" + domain, customEmoji, nil
+}
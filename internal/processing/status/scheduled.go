@@ -0,0 +1,632 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// Minimum amount of time in the future
+	// that a status may be scheduled for.
+	scheduledStatusMinOffset = 5 * time.Minute
+
+	// Maximum number of statuses an account may have scheduled at once.
+	scheduledStatusesMax = 300
+
+	// Maximum number of statuses an account may have scheduled for one day.
+	scheduledStatusesDailyMax = 25
+
+	// Delay before retrying a scheduled status that failed
+	// to publish, doubled on each further failed attempt.
+	scheduledStatusRetryMin = time.Minute
+
+	// Upper bound on the retry delay for
+	// a scheduled status failing to publish.
+	scheduledStatusRetryMax = 6 * time.Hour
+)
+
+// ScheduledStatusCreate processes the given form to schedule a new status
+// for publishing at form.ScheduledAt, returning the api model representation
+// of the scheduled status if it's OK.
+//
+// Precondition: the form's fields should have already been validated and normalized by the caller.
+func (p *Processor) ScheduledStatusCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	application *gtsmodel.Application,
+	form *apimodel.StatusCreateRequest,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	// Ensure account populated; we'll need settings.
+	if err := p.state.DB.PopulateAccount(ctx, requester); err != nil {
+		log.Errorf(ctx, "error(s) populating account, will continue: %s", err)
+	}
+
+	scheduledAt, errWithCode := p.validateScheduledAt(ctx,
+		requester.ID,
+		form.ScheduledAt,
+		nil, // new status
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.InReplyToID != "" {
+		// Ensure the requester can see the status they're replying
+		// to now, the full reply checks are performed on publish.
+		if _, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+			requester,
+			form.InReplyToID,
+			nil,
+		); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	if form.QuotedStatusID != "" {
+		// Likewise for the status they're quoting,
+		// quote checks are performed on publish.
+		if _, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+			requester,
+			form.QuotedStatusID,
+			nil,
+		); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	attachments, errWithCode := p.getAttachableMedia(ctx, form.MediaIDs, requester.ID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Scratch status to derive visibility, language and
+	// interaction policy in the same way status create would.
+	status := &gtsmodel.Status{}
+
+	if err := p.processVisibility(ctx, form, requester.Settings.Privacy, status); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if form.InteractionPolicy != nil {
+		if errWithCode := processInteractionPolicy(form, requester.Settings, status); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	if err := processLanguage(form, requester.Settings.Language, status); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	now := time.Now()
+	scheduled := &gtsmodel.ScheduledStatus{
+		ID:                id.NewULID(),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+		AccountID:         requester.ID,
+		Account:           requester,
+		ScheduledAt:       scheduledAt,
+		Text:              form.Status,
+		MediaIDs:          make([]string, 0, len(attachments)),
+		MediaAttachments:  attachments,
+		Sensitive:         &form.Sensitive,
+		SpoilerText:       form.SpoilerText,
+		Visibility:        status.Visibility,
+		LocalOnly:         util.Ptr(!*status.Federated),
+		InReplyToID:       form.InReplyToID,
+		QuotedStatusID:    form.QuotedStatusID,
+		Language:          status.Language,
+		ContentType:       string(form.ContentType),
+		InteractionPolicy: status.InteractionPolicy,
+		ApplicationID:     application.ID,
+		Application:       application,
+	}
+
+	for _, attachment := range attachments {
+		scheduled.MediaIDs = append(scheduled.MediaIDs, attachment.ID)
+	}
+
+	if form.Poll != nil {
+		scheduled.Poll = gtsmodel.ScheduledStatusPoll{
+			Options:    form.Poll.Options,
+			ExpiresIn:  form.Poll.ExpiresIn,
+			Multiple:   &form.Poll.Multiple,
+			HideTotals: &form.Poll.HideTotals,
+		}
+	}
+
+	// Insert this new scheduled status in the database.
+	if err := p.state.DB.PutScheduledStatus(ctx, scheduled); err != nil {
+		err := gtserror.Newf("error inserting scheduled status in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Mark attachments as belonging to the
+	// scheduled status, so they can't be used
+	// elsewhere and aren't pruned as unused.
+	for _, attachment := range attachments {
+		attachment.ScheduledStatusID = scheduled.ID
+		if err := p.state.DB.UpdateAttachment(ctx, attachment, "scheduled_status_id"); err != nil {
+			err := gtserror.Newf("error updating media %s: %w", attachment.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if err := p.ScheduleStatus(ctx, scheduled); err != nil {
+		log.Errorf(ctx, "error scheduling status: %v", err)
+	}
+
+	return p.apiScheduledStatus(ctx, scheduled)
+}
+
+// ScheduledStatusGet returns the scheduled status with given ID, if owned by requester.
+func (p *Processor) ScheduledStatusGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduled, errWithCode := p.getOwnScheduledStatus(ctx, requester, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiScheduledStatus(ctx, scheduled)
+}
+
+// ScheduledStatusesGetPage returns a page of scheduled statuses owned by requester.
+func (p *Processor) ScheduledStatusesGetPage(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	scheduled, err := p.state.DB.GetScheduledStatusesForAcct(ctx, requester.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error getting scheduled statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(scheduled)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := scheduled[count-1].ID
+	hi := scheduled[0].ID
+
+	// Convert each scheduled status to API model.
+	items := make([]interface{}, 0, count)
+	for _, s := range scheduled {
+		item, errWithCode := p.apiScheduledStatus(ctx, s)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		items = append(items, item)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/scheduled_statuses",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// ScheduledStatusUpdate reschedules the scheduled status
+// with given ID, if owned by requester, to the form time.
+func (p *Processor) ScheduledStatusUpdate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+	form *apimodel.ScheduledStatusUpdateRequest,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduled, errWithCode := p.getOwnScheduledStatus(ctx, requester, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	scheduledAt, errWithCode := p.validateScheduledAt(ctx,
+		requester.ID,
+		form.ScheduledAt,
+		scheduled,
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	scheduled.ScheduledAt = scheduledAt
+	if err := p.state.DB.UpdateScheduledStatus(ctx, scheduled, "scheduled_at"); err != nil {
+		err := gtserror.Newf("error updating scheduled status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Replace any existing publish task with a
+	// new one for the updated scheduled time.
+	p.state.Workers.Scheduler.Cancel(scheduled.ID)
+	if err := p.ScheduleStatus(ctx, scheduled); err != nil {
+		log.Errorf(ctx, "error scheduling status: %v", err)
+	}
+
+	return p.apiScheduledStatus(ctx, scheduled)
+}
+
+// ScheduledStatusDelete cancels and deletes the
+// scheduled status with given ID, if owned by requester.
+func (p *Processor) ScheduledStatusDelete(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) gtserror.WithCode {
+	scheduled, errWithCode := p.getOwnScheduledStatus(ctx, requester, id)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.deleteScheduledStatus(ctx, scheduled); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// ScheduleAll schedules publishing of all scheduled statuses
+// in the database, ie., those still pending after a restart.
+// Statuses scheduled for a time already passed are published immediately.
+func (p *Processor) ScheduleAll(ctx context.Context) error {
+	// Fetch all scheduled statuses from the database (barebones models are enough).
+	scheduled, err := p.state.DB.GetAllScheduledStatuses(gtscontext.SetBarebones(ctx))
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting scheduled statuses from db: %w", err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, s := range scheduled {
+		// Schedule each of the statuses and catch any errors.
+		if err := p.ScheduleStatus(ctx, s); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// ScheduleStatus adds a task to the scheduler to
+// publish the given scheduled status at its scheduled time.
+func (p *Processor) ScheduleStatus(ctx context.Context, scheduled *gtsmodel.ScheduledStatus) error {
+	return p.scheduleStatus(ctx, scheduled, 0)
+}
+
+// scheduleStatus adds a task to the scheduler to publish the given
+// scheduled status at its scheduled time, where attempt is the
+// number of times publishing it has failed already.
+func (p *Processor) scheduleStatus(ctx context.Context, scheduled *gtsmodel.ScheduledStatus, attempt int) error {
+	// Add the given status to the scheduler.
+	ok := p.state.Workers.Scheduler.AddOnce(
+		scheduled.ID,
+		scheduled.ScheduledAt,
+		p.onPublish(scheduled.ID, attempt),
+	)
+
+	if !ok {
+		// Failed to add the status to the scheduler, either it was
+		// starting / stopping or there already exists a task for status.
+		return gtserror.Newf("failed adding scheduled status %s to scheduler", scheduled.ID)
+	}
+
+	atStr := scheduled.ScheduledAt.Local().Format("Jan _2 2006 15:04:05")
+	log.Infof(ctx, "scheduled status %s for publishing at '%s'", scheduled.ID, atStr)
+	return nil
+}
+
+// onPublish returns a callback function to be used by
+// the scheduler when the given scheduled status is due.
+func (p *Processor) onPublish(scheduledID string, attempt int) func(context.Context, time.Time) {
+	return func(ctx context.Context, now time.Time) {
+		// Get the latest version of scheduled status from database.
+		scheduled, err := p.state.DB.GetScheduledStatusByID(ctx, scheduledID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "error getting scheduled status %s from db: %v", scheduledID, err)
+			return
+		}
+
+		if scheduled == nil {
+			// Deleted in
+			// the meantime.
+			return
+		}
+
+		if scheduled.ScheduledAt.After(now) {
+			// Rescheduled in the meantime; the
+			// newer scheduler task will publish it.
+			return
+		}
+
+		account := scheduled.Account
+		if account == nil || account.IsSuspended() {
+			// Nothing to publish,
+			// just remove it.
+			if err := p.deleteScheduledStatus(ctx, scheduled); err != nil {
+				log.Errorf(ctx, "error deleting scheduled status %s: %v", scheduledID, err)
+			}
+			return
+		}
+
+		application := scheduled.Application
+		if application == nil {
+			// Application may have since been
+			// deleted; publish without one.
+			application = new(gtsmodel.Application)
+		}
+
+		form, err := p.scheduledToCreateRequest(ctx, scheduled)
+		if err != nil {
+			log.Errorf(ctx, "error preparing scheduled status %s: %v", scheduledID, err)
+			return
+		}
+
+		// Unlink media from the scheduled status
+		// so it can be attached to the new status.
+		if err := p.setScheduledMedia(ctx, scheduled, scheduled.ID, ""); err != nil {
+			log.Errorf(ctx, "error unlinking scheduled status %s media: %v", scheduledID, err)
+			return
+		}
+
+		if _, errWithCode := p.Create(ctx,
+			account,
+			application,
+			form,
+		); errWithCode != nil {
+			log.Errorf(ctx, "error publishing scheduled status %s: %v", scheduledID, errWithCode)
+
+			// Keep the scheduled status so the user can
+			// still see it, link its media to it again,
+			// and retry publishing it a bit later on.
+			if err := p.setScheduledMedia(ctx, scheduled, "", scheduled.ID); err != nil {
+				log.Errorf(ctx, "error linking scheduled status %s media: %v", scheduledID, err)
+			}
+			p.retryPublish(ctx, scheduled, now, attempt+1)
+			return
+		}
+
+		// Only remove the scheduled
+		// status once it's published.
+		if err := p.deleteScheduledStatus(ctx, scheduled); err != nil {
+			log.Errorf(ctx, "error deleting scheduled status %s: %v", scheduledID, err)
+		}
+	}
+}
+
+// retryPublish reschedules the given scheduled status after it failed
+// to publish for the given number of attempts, backing off exponentially
+// up to scheduledStatusRetryMax between each attempt.
+func (p *Processor) retryPublish(
+	ctx context.Context,
+	scheduled *gtsmodel.ScheduledStatus,
+	now time.Time,
+	attempt int,
+) {
+	delay := scheduledStatusRetryMax
+	if shift := attempt - 1; shift < 16 {
+		delay = min(scheduledStatusRetryMin<<shift, delay)
+	}
+
+	// Move the scheduled time forward, so the
+	// scheduled status doesn't sit in the past.
+	scheduled.ScheduledAt = now.Add(delay)
+	if err := p.state.DB.UpdateScheduledStatus(ctx, scheduled, "scheduled_at"); err != nil {
+		log.Errorf(ctx, "error updating scheduled status %s: %v", scheduled.ID, err)
+		return
+	}
+
+	// Replace the spent publish task with a
+	// new one for the updated scheduled time.
+	p.state.Workers.Scheduler.Cancel(scheduled.ID)
+	if err := p.scheduleStatus(ctx, scheduled, attempt); err != nil {
+		log.Errorf(ctx, "error rescheduling status: %v", err)
+	}
+}
+
+// scheduledToCreateRequest converts the given scheduled status
+// to a status create form, as though it had just been submitted.
+func (p *Processor) scheduledToCreateRequest(
+	ctx context.Context,
+	scheduled *gtsmodel.ScheduledStatus,
+) (*apimodel.StatusCreateRequest, error) {
+	form := &apimodel.StatusCreateRequest{
+		Status:         scheduled.Text,
+		MediaIDs:       scheduled.MediaIDs,
+		InReplyToID:    scheduled.InReplyToID,
+		QuotedStatusID: scheduled.QuotedStatusID,
+		Sensitive:      util.PtrOrValue(scheduled.Sensitive, false),
+		SpoilerText:    scheduled.SpoilerText,
+		Visibility:     p.converter.VisToAPIVis(ctx, scheduled.Visibility),
+		LocalOnly:      util.Ptr(util.PtrOrValue(scheduled.LocalOnly, false)),
+		Language:       scheduled.Language,
+		ContentType:    apimodel.StatusContentType(scheduled.ContentType),
+	}
+
+	if len(scheduled.Poll.Options) != 0 {
+		form.Poll = &apimodel.PollRequest{
+			Options:    scheduled.Poll.Options,
+			ExpiresIn:  scheduled.Poll.ExpiresIn,
+			Multiple:   util.PtrOrValue(scheduled.Poll.Multiple, false),
+			HideTotals: util.PtrOrValue(scheduled.Poll.HideTotals, false),
+		}
+	}
+
+	if scheduled.InteractionPolicy != nil {
+		var err error
+		form.InteractionPolicy, err = p.converter.InteractionPolicyToAPIInteractionPolicy(ctx,
+			scheduled.InteractionPolicy,
+			nil,
+			nil,
+		)
+		if err != nil {
+			return nil, gtserror.Newf("error converting interaction policy: %w", err)
+		}
+	}
+
+	return form, nil
+}
+
+// deleteScheduledStatus cancels any publish task for the given scheduled
+// status, and deletes it from the database, detaching any of its media.
+func (p *Processor) deleteScheduledStatus(ctx context.Context, scheduled *gtsmodel.ScheduledStatus) error {
+	p.state.Workers.Scheduler.Cancel(scheduled.ID)
+
+	if err := p.state.DB.DeleteScheduledStatusByID(ctx, scheduled.ID); err != nil {
+		return gtserror.Newf("error deleting scheduled status: %w", err)
+	}
+
+	// Unset the scheduled status
+	// ID on any of its media.
+	return p.setScheduledMedia(ctx, scheduled, scheduled.ID, "")
+}
+
+// setScheduledMedia updates the scheduled status ID of any media of the given
+// scheduled status from old to new, skipping media attached to a status. i.e.
+// to unlink media from a scheduled status so it can be attached to a status.
+func (p *Processor) setScheduledMedia(
+	ctx context.Context,
+	scheduled *gtsmodel.ScheduledStatus,
+	old string,
+	new string,
+) error {
+	attachments, err := p.state.DB.GetAttachmentsByIDs(ctx, scheduled.MediaIDs)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting scheduled status media: %w", err)
+	}
+
+	for _, attachment := range attachments {
+		if attachment.ScheduledStatusID != old ||
+			attachment.StatusID != "" {
+			continue
+		}
+
+		attachment.ScheduledStatusID = new
+		if err := p.state.DB.UpdateAttachment(ctx, attachment, "scheduled_status_id"); err != nil {
+			return gtserror.Newf("error updating media %s: %w", attachment.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Processor) getOwnScheduledStatus(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) (*gtsmodel.ScheduledStatus, gtserror.WithCode) {
+	scheduled, err := p.state.DB.GetScheduledStatusByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error getting scheduled status %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if scheduled == nil || scheduled.AccountID != requester.ID {
+		err := fmt.Errorf("scheduled status %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return scheduled, nil
+}
+
+// validateScheduledAt parses the given ISO 8601 datetime string,
+// checking it's far enough in the future, and that the account
+// is still within the limits of statuses it may have scheduled.
+// If rescheduling, the existing scheduled status should be passed.
+func (p *Processor) validateScheduledAt(
+	ctx context.Context,
+	accountID string,
+	scheduledAtStr string,
+	existing *gtsmodel.ScheduledStatus,
+) (time.Time, gtserror.WithCode) {
+	scheduledAt, err := time.Parse(time.RFC3339, scheduledAtStr)
+	if err != nil {
+		const text = "scheduled_at must be a valid ISO 8601 datetime"
+		return time.Time{}, gtserror.NewErrorUnprocessableEntity(err, text)
+	}
+
+	if time.Until(scheduledAt) < scheduledStatusMinOffset {
+		const text = "scheduled_at must be at least 5 minutes in the future"
+		return time.Time{}, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	if existing == nil {
+		total, err := p.state.DB.CountScheduledStatusesForAcct(ctx, accountID, time.Time{}, time.Time{})
+		if err != nil {
+			err := gtserror.Newf("error counting scheduled statuses: %w", err)
+			return time.Time{}, gtserror.NewErrorInternalError(err)
+		}
+
+		if total >= scheduledStatusesMax {
+			text := fmt.Sprintf("you may not have more than %d statuses scheduled", scheduledStatusesMax)
+			return time.Time{}, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+	}
+
+	// Count statuses already scheduled for the same (UTC) day.
+	day := scheduledAt.UTC().Truncate(24 * time.Hour)
+	daily, err := p.state.DB.CountScheduledStatusesForAcct(ctx, accountID, day, day.Add(24*time.Hour))
+	if err != nil {
+		err := gtserror.Newf("error counting scheduled statuses: %w", err)
+		return time.Time{}, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil && !existing.ScheduledAt.Before(day) &&
+		existing.ScheduledAt.Before(day.Add(24*time.Hour)) {
+		// Don't count the status
+		// being rescheduled itself.
+		daily--
+	}
+
+	if daily >= scheduledStatusesDailyMax {
+		text := fmt.Sprintf("you may not have more than %d statuses scheduled for one day", scheduledStatusesDailyMax)
+		return time.Time{}, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	return scheduledAt, nil
+}
+
+func (p *Processor) apiScheduledStatus(
+	ctx context.Context,
+	scheduled *gtsmodel.ScheduledStatus,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	apiScheduled, err := p.converter.ScheduledStatusToAPIScheduledStatus(ctx, scheduled)
+	if err != nil {
+		err := gtserror.Newf("error converting scheduled status to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiScheduled, nil
+}
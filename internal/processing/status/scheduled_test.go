@@ -0,0 +1,251 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type ScheduledStatusTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *ScheduledStatusTestSuite) SetupTest() {
+	suite.StatusStandardTestSuite.SetupTest()
+
+	// The previous test's scheduler may have stopped
+	// the newly started one on its way out, make sure
+	// it's running as these tests rely on it.
+	_ = suite.state.Workers.Scheduler.Start()
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusCreate() {
+	ctx := context.Background()
+
+	requester := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+	attachment := suite.testAttachments["local_account_1_unattached_1"]
+	scheduledAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	form := &apimodel.StatusCreateRequest{
+		Status:      "this is a status from the future",
+		MediaIDs:    []string{attachment.ID},
+		SpoilerText: "time travel",
+		Visibility:  apimodel.VisibilityUnlisted,
+		ScheduledAt: scheduledAt,
+		ContentType: apimodel.StatusContentTypeMarkdown,
+	}
+
+	apiScheduled, errWithCode := suite.status.ScheduledStatusCreate(ctx, requester, application, form)
+	suite.NoError(errWithCode)
+	suite.NotNil(apiScheduled)
+
+	suite.Equal(scheduledAt, apiScheduled.ScheduledAt[:19]+"Z")
+	suite.Equal("this is a status from the future", apiScheduled.Params.Text)
+	suite.Equal("time travel", apiScheduled.Params.SpoilerText)
+	suite.Equal(apimodel.VisibilityUnlisted, apiScheduled.Params.Visibility)
+	suite.Equal(application.ID, apiScheduled.Params.ApplicationID)
+	suite.Equal([]string{attachment.ID}, apiScheduled.Params.MediaIDs)
+	suite.Len(apiScheduled.MediaAttachments, 1)
+
+	// Media should now be marked as belonging to the scheduled status.
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Equal(apiScheduled.ID, dbAttachment.ScheduledStatusID)
+
+	// Scheduled status should be gettable + pageable.
+	_, errWithCode = suite.status.ScheduledStatusGet(ctx, requester, apiScheduled.ID)
+	suite.NoError(errWithCode)
+
+	resp, errWithCode := suite.status.ScheduledStatusesGetPage(ctx, requester, &paging.Page{Limit: 20})
+	suite.NoError(errWithCode)
+	suite.Len(resp.Items, 1)
+
+	// But not by someone else.
+	_, errWithCode = suite.status.ScheduledStatusGet(ctx, suite.testAccounts["local_account_2"], apiScheduled.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusCreateTooSoon() {
+	ctx := context.Background()
+
+	requester := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+
+	form := &apimodel.StatusCreateRequest{
+		Status:      "not far enough in the future",
+		ScheduledAt: time.Now().Add(time.Minute).UTC().Format(time.RFC3339),
+	}
+
+	apiScheduled, errWithCode := suite.status.ScheduledStatusCreate(ctx, requester, application, form)
+	suite.Nil(apiScheduled)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal("Unprocessable Entity: scheduled_at must be at least 5 minutes in the future", errWithCode.Safe())
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusUpdateDelete() {
+	ctx := context.Background()
+
+	requester := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+	attachment := suite.testAttachments["local_account_1_unattached_1"]
+
+	form := &apimodel.StatusCreateRequest{
+		Status:      "to be rescheduled",
+		MediaIDs:    []string{attachment.ID},
+		ScheduledAt: time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+	}
+
+	apiScheduled, errWithCode := suite.status.ScheduledStatusCreate(ctx, requester, application, form)
+	suite.NoError(errWithCode)
+
+	// Reschedule for later.
+	later := time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339)
+	apiScheduled, errWithCode = suite.status.ScheduledStatusUpdate(ctx, requester, apiScheduled.ID,
+		&apimodel.ScheduledStatusUpdateRequest{ScheduledAt: later},
+	)
+	suite.NoError(errWithCode)
+	suite.Equal(later, apiScheduled.ScheduledAt[:19]+"Z")
+
+	// Now cancel it.
+	errWithCode = suite.status.ScheduledStatusDelete(ctx, requester, apiScheduled.ID)
+	suite.NoError(errWithCode)
+
+	_, err := suite.db.GetScheduledStatusByID(ctx, apiScheduled.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Media should be free to attach elsewhere again.
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Empty(dbAttachment.ScheduledStatusID)
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusPublish() {
+	ctx := context.Background()
+
+	requester := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+	attachment := suite.testAttachments["local_account_1_unattached_1"]
+
+	form := &apimodel.StatusCreateRequest{
+		Status:      "hello from the past!",
+		MediaIDs:    []string{attachment.ID},
+		ScheduledAt: time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+	}
+
+	apiScheduled, errWithCode := suite.status.ScheduledStatusCreate(ctx, requester, application, form)
+	suite.NoError(errWithCode)
+
+	// Move scheduled time to the past, as
+	// though we've been down for a while,
+	// and reload all scheduled statuses.
+	scheduled, err := suite.db.GetScheduledStatusByID(ctx, apiScheduled.ID)
+	suite.NoError(err)
+	scheduled.ScheduledAt = time.Now().Add(-time.Minute)
+	err = suite.db.UpdateScheduledStatus(ctx, scheduled, "scheduled_at")
+	suite.NoError(err)
+
+	suite.state.Workers.Scheduler.Cancel(scheduled.ID)
+	err = suite.status.ScheduleAll(ctx)
+	suite.NoError(err)
+
+	// Scheduled status should be published + removed.
+	if !suite.Eventually(func() bool {
+		_, err := suite.db.GetScheduledStatusByID(ctx, scheduled.ID)
+		return err == db.ErrNoEntries
+	}, 5*time.Second, 10*time.Millisecond) {
+		suite.FailNow("timed out waiting for scheduled status to be published")
+	}
+
+	var statusID string
+	if !suite.Eventually(func() bool {
+		dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+		if err != nil {
+			return false
+		}
+		statusID = dbAttachment.StatusID
+		return statusID != ""
+	}, 5*time.Second, 10*time.Millisecond) {
+		suite.FailNow("timed out waiting for media to be attached to published status")
+	}
+
+	status, err := suite.db.GetStatusByID(ctx, statusID)
+	suite.NoError(err)
+	suite.Equal(requester.ID, status.AccountID)
+	suite.Equal(application.ID, status.CreatedWithApplicationID)
+	suite.Equal("hello from the past!", status.Text)
+	suite.Equal([]string{attachment.ID}, status.AttachmentIDs)
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusPublishFailed() {
+	ctx := context.Background()
+
+	requester := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+	attachment := suite.testAttachments["local_account_1_unattached_1"]
+
+	form := &apimodel.StatusCreateRequest{
+		Status:      "hello from the past!",
+		MediaIDs:    []string{attachment.ID},
+		ScheduledAt: time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+	}
+
+	apiScheduled, errWithCode := suite.status.ScheduledStatusCreate(ctx, requester, application, form)
+	suite.NoError(errWithCode)
+
+	// Move scheduled time to the past, and set it
+	// to reply to a status that doesn't exist (i.e.
+	// deleted in the meantime) so publishing fails.
+	scheduled, err := suite.db.GetScheduledStatusByID(ctx, apiScheduled.ID)
+	suite.NoError(err)
+	scheduled.ScheduledAt = time.Now().Add(-time.Minute)
+	scheduled.InReplyToID = "01J9Z5QB0X5X9Y1FXZ4QFKJ0T8"
+	err = suite.db.UpdateScheduledStatus(ctx, scheduled, "scheduled_at", "in_reply_to_id")
+	suite.NoError(err)
+
+	suite.state.Workers.Scheduler.Cancel(scheduled.ID)
+	err = suite.status.ScheduleAll(ctx)
+	suite.NoError(err)
+
+	// Give the scheduler time to attempt publishing.
+	time.Sleep(time.Second)
+
+	// Scheduled status should be kept, with media still
+	// linked to it, and rescheduled to retry publishing.
+	scheduled, err = suite.db.GetScheduledStatusByID(ctx, scheduled.ID)
+	suite.NoError(err)
+	suite.Equal([]string{attachment.ID}, scheduled.MediaIDs)
+	suite.True(scheduled.ScheduledAt.After(time.Now()))
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Equal(scheduled.ID, dbAttachment.ScheduledStatusID)
+	suite.Empty(dbAttachment.StatusID)
+}
+
+func TestScheduledStatusTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledStatusTestSuite))
+}
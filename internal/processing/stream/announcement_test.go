@@ -0,0 +1,88 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+type AnnouncementTestSuite struct {
+	StreamTestSuite
+}
+
+func (suite *AnnouncementTestSuite) TestStreamAnnouncement() {
+	ctx := context.Background()
+
+	// Open streams for two different accounts;
+	// announcements should be sent to everyone.
+	stream1, errWithCode := suite.streamProcessor.Open(ctx, suite.testAccounts["local_account_1"], stream.TimelineHome)
+	suite.NoError(errWithCode)
+	stream2, errWithCode := suite.streamProcessor.Open(ctx, suite.testAccounts["local_account_2"], stream.TimelineHome)
+	suite.NoError(errWithCode)
+
+	suite.streamProcessor.Announcement(ctx, &apimodel.Announcement{
+		ID:        "01JAD2W8X2A9ZG3C5NQF1S8V6P",
+		Content:   "<p>hello</p>",
+		UpdatedAt: "2024-10-01T08:00:00.000Z",
+		Published: true,
+	})
+
+	for _, s := range []*stream.Stream{stream1, stream2} {
+		msg, ok := s.Recv(ctx)
+		suite.True(ok)
+		suite.Equal(stream.EventTypeAnnouncement, msg.Event)
+		suite.Contains(msg.Payload, `"id":"01JAD2W8X2A9ZG3C5NQF1S8V6P"`)
+	}
+}
+
+func (suite *AnnouncementTestSuite) TestStreamAnnouncementReaction() {
+	ctx := context.Background()
+
+	openStream, errWithCode := suite.streamProcessor.Open(ctx, suite.testAccounts["local_account_1"], stream.TimelineHome)
+	suite.NoError(errWithCode)
+
+	suite.streamProcessor.AnnouncementReaction(ctx, "01JAD2W8X2A9ZG3C5NQF1S8V6P", "👍", 3)
+
+	msg, ok := openStream.Recv(ctx)
+	suite.True(ok)
+	suite.Equal(stream.EventTypeAnnouncementReaction, msg.Event)
+	suite.Equal(`{"name":"👍","count":3,"announcement_id":"01JAD2W8X2A9ZG3C5NQF1S8V6P"}`, msg.Payload)
+}
+
+func (suite *AnnouncementTestSuite) TestStreamAnnouncementDelete() {
+	ctx := context.Background()
+
+	openStream, errWithCode := suite.streamProcessor.Open(ctx, suite.testAccounts["local_account_1"], stream.TimelineHome)
+	suite.NoError(errWithCode)
+
+	suite.streamProcessor.AnnouncementDelete(ctx, "01JAD2W8X2A9ZG3C5NQF1S8V6P")
+
+	msg, ok := openStream.Recv(ctx)
+	suite.True(ok)
+	suite.Equal(stream.EventTypeAnnouncementDelete, msg.Event)
+	suite.Equal("01JAD2W8X2A9ZG3C5NQF1S8V6P", msg.Payload)
+}
+
+func TestAnnouncementTestSuite(t *testing.T) {
+	suite.Run(t, &AnnouncementTestSuite{})
+}
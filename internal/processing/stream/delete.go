@@ -25,7 +25,7 @@ import (
 
 // Delete streams the delete of the given statusID to *ALL* open streams.
 func (p *Processor) Delete(ctx context.Context, statusID string) {
-	p.streams.PostAll(ctx, stream.Message{
+	p.streams.PostAllKeyed(ctx, stream.Message{
 		Payload: statusID,
 		Event:   stream.EventTypeDelete,
 		Stream:  stream.AllStatusTimelines,
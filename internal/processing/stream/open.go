@@ -19,12 +19,14 @@ package stream
 
 import (
 	"context"
+	"strings"
 
 	"codeberg.org/gruf/go-kv"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 )
 
 // Open returns a new Stream for the given account, which will contain a channel for passing messages back to the caller.
@@ -36,3 +38,30 @@ func (p *Processor) Open(ctx context.Context, account *gtsmodel.Account, streamT
 	l.Debug("received open stream request")
 	return p.streams.Open(account.ID, streamType), nil
 }
+
+// HashtagStreamType returns the stream type used to key
+// the given hashtag timeline + tag name internally, eg.,
+// `hashtag:example` or `hashtag:local:example`.
+//
+// The tag name will be normalized and lowercased,
+// to match the form in which tags are stored.
+func (p *Processor) HashtagStreamType(timeline string, tagName string) (string, gtserror.WithCode) {
+	if timeline != stream.TimelineHashtag &&
+		timeline != stream.TimelineHashtagLocal {
+		err := gtserror.Newf("%s is not a hashtag stream", timeline)
+		return "", gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if tagName == "" {
+		const errText = "tag must be provided for hashtag streams"
+		return "", gtserror.NewErrorBadRequest(gtserror.New(errText), errText)
+	}
+
+	tagNameNormal, ok := text.NormalizeHashtag(tagName)
+	if !ok {
+		err := gtserror.Newf("string '%s' could not be normalized to a valid hashtag", tagName)
+		return "", gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return timeline + ":" + strings.ToLower(tagNameNormal), nil
+}
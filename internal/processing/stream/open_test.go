@@ -19,6 +19,7 @@ package stream_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -35,6 +36,31 @@ func (suite *OpenStreamTestSuite) TestOpenStream() {
 	suite.NoError(errWithCode)
 }
 
+func (suite *OpenStreamTestSuite) TestHashtagStreamType() {
+	for _, test := range []struct {
+		timeline   string
+		tagName    string
+		expect     string
+		expectCode int
+	}{
+		{timeline: "hashtag", tagName: "Welcome", expect: "hashtag:welcome"},
+		{timeline: "hashtag:local", tagName: "#welcome", expect: "hashtag:local:welcome"},
+		{timeline: "hashtag", tagName: "local", expect: "hashtag:local"},
+		{timeline: "hashtag", tagName: "", expectCode: http.StatusBadRequest},
+		{timeline: "hashtag", tagName: "not a tag", expectCode: http.StatusBadRequest},
+		{timeline: "public", tagName: "welcome", expectCode: http.StatusBadRequest},
+	} {
+		streamType, errWithCode := suite.streamProcessor.HashtagStreamType(test.timeline, test.tagName)
+		if test.expectCode != 0 {
+			suite.Equal(test.expectCode, errWithCode.Code())
+			continue
+		}
+
+		suite.NoError(errWithCode)
+		suite.Equal(test.expect, streamType)
+	}
+}
+
 func TestOpenStreamTestSuite(t *testing.T) {
 	suite.Run(t, &OpenStreamTestSuite{})
 }
@@ -151,6 +151,20 @@ func (suite *StatusUpdateTestSuite) TestStreamNotification() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, dst.String())
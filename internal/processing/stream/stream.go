@@ -36,3 +36,9 @@ func New(state *state.State, oauthServer oauth.Server) Processor {
 		streams:     stream.Streams{},
 	}
 }
+
+// AccountIDs returns the IDs of all accounts
+// with an open stream of any of given types.
+func (p *Processor) AccountIDs(streamTypes ...string) []string {
+	return p.streams.AccountIDs(streamTypes...)
+}
@@ -0,0 +1,263 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package suggestions
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Reasons for suggesting an account,
+// as given in the sources of a suggestion.
+const (
+	sourceFeatured         = "featured"
+	sourceMostInteractions = "most_interactions"
+	sourceFriendsOfFriends = "friends_of_friends"
+	sourceMostFollowed     = "most_followed"
+)
+
+// candidatesLimit is the maximum number of
+// candidate accounts to fetch from each of
+// the sources of suggestions, before filtering.
+const candidatesLimit = 100
+
+// SuggestionsGet returns up to limit accounts worth following for
+// the requester: accounts featured by admins, accounts whose statuses
+// the requester often interacts with, accounts followed by accounts
+// the requester follows, and the most followed accounts on this
+// instance, in that order. Accounts which the requester already
+// follows, has dismissed, has blocked or muted, or which are not
+// discoverable are never suggested.
+func (p *Processor) SuggestionsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	limit int,
+) ([]*apimodel.Suggestion, gtserror.WithCode) {
+	dismissed, err := p.state.DB.GetSuggestionDismissedAccountIDs(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting dismissed suggestions for account %s: %w", requester.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	var (
+		suggestions = make([]*apimodel.Suggestion, 0, limit)
+		byID        = make(map[string]*apimodel.Suggestion)
+		checked     = make(map[string]struct{})
+	)
+
+	// Suggestions are rejected without
+	// further checks if already seen,
+	// or dismissed by the requester.
+	checked[requester.ID] = struct{}{}
+	for _, accountID := range dismissed {
+		checked[accountID] = struct{}{}
+	}
+
+	// add adds the account with given ID to
+	// the suggestions with the given source,
+	// if it's suitable to suggest to requester.
+	add := func(accountID string, source string) error {
+		if suggestion, ok := byID[accountID]; ok {
+			// Already suggested, add
+			// this as another source.
+			if !slices.Contains(suggestion.Sources, source) {
+				suggestion.Sources = append(suggestion.Sources, source)
+			}
+			return nil
+		}
+
+		if len(suggestions) == limit {
+			// Got enough already.
+			return nil
+		}
+
+		if _, ok := checked[accountID]; ok {
+			// Already rejected.
+			return nil
+		}
+		checked[accountID] = struct{}{}
+
+		account, err := p.suggestible(ctx, requester, accountID)
+		if err != nil {
+			return err
+		}
+
+		if account == nil {
+			// Not suitable.
+			return nil
+		}
+
+		apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, account)
+		if err != nil {
+			return gtserror.Newf("error converting account %s to frontend representation: %w", accountID, err)
+		}
+
+		suggestion := &apimodel.Suggestion{
+			Source:  deprecatedSource(source),
+			Sources: []string{source},
+			Account: apiAccount,
+		}
+		suggestions = append(suggestions, suggestion)
+		byID[accountID] = suggestion
+
+		return nil
+	}
+
+	// Gather candidates from each source,
+	// the most significant sources first.
+	for _, source := range []struct {
+		name          string
+		getAccountIDs func(context.Context) ([]string, error)
+	}{
+		{
+			name: sourceFeatured,
+			getAccountIDs: func(ctx context.Context) ([]string, error) {
+				featured, err := p.state.DB.GetFeaturedSuggestions(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				accountIDs := make([]string, 0, len(featured))
+				for _, f := range featured {
+					accountIDs = append(accountIDs, f.AccountID)
+				}
+				return accountIDs, nil
+			},
+		},
+		{
+			name: sourceMostInteractions,
+			getAccountIDs: func(ctx context.Context) ([]string, error) {
+				return p.state.DB.GetInteractedAccountIDs(ctx, requester.ID, candidatesLimit)
+			},
+		},
+		{
+			name: sourceFriendsOfFriends,
+			getAccountIDs: func(ctx context.Context) ([]string, error) {
+				return p.state.DB.GetFriendsOfFriendsIDs(ctx, requester.ID, candidatesLimit)
+			},
+		},
+		{
+			name: sourceMostFollowed,
+			getAccountIDs: func(ctx context.Context) ([]string, error) {
+				return p.state.DB.GetMostFollowedLocalAccountIDs(ctx, candidatesLimit)
+			},
+		},
+	} {
+		accountIDs, err := source.getAccountIDs(ctx)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting %s suggestions for account %s: %w", source.name, requester.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		for _, accountID := range accountIDs {
+			if err := add(accountID, source.name); err != nil {
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+		}
+	}
+
+	return suggestions, nil
+}
+
+// SuggestedAccountsGet is like SuggestionsGet, but returns
+// only the suggested accounts, for the deprecated v1 API.
+func (p *Processor) SuggestedAccountsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	limit int,
+) ([]*apimodel.Account, gtserror.WithCode) {
+	suggestions, errWithCode := p.SuggestionsGet(ctx, requester, limit)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	accounts := make([]*apimodel.Account, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		accounts = append(accounts, suggestion.Account)
+	}
+
+	return accounts, nil
+}
+
+// suggestible returns the account with given ID if it's
+// suitable to suggest to the requester, else nil.
+func (p *Processor) suggestible(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	accountID string,
+) (*gtsmodel.Account, error) {
+	account, err := p.state.DB.GetAccountByID(gtscontext.SetBarebones(ctx), accountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			log.Debugf(ctx, "suggested account %s not found", accountID)
+			return nil, nil
+		}
+		return nil, gtserror.Newf("db error getting account %s: %w", accountID, err)
+	}
+
+	if account.IsSuspended() ||
+		account.IsInstance() ||
+		account.IsMoving() ||
+		!util.PtrOrValue(account.Discoverable, false) {
+		// Not suitable for anyone.
+		return nil, nil
+	}
+
+	for _, check := range []func(context.Context, string, string) (bool, error){
+		p.state.DB.IsFollowing,
+		p.state.DB.IsFollowRequested,
+		p.state.DB.IsEitherBlocked,
+		p.state.DB.IsMuted,
+	} {
+		found, err := check(ctx, requester.ID, account.ID)
+		if err != nil {
+			return nil, gtserror.Newf("db error checking relationship with account %s: %w", account.ID, err)
+		}
+
+		if found {
+			// Already following or requested,
+			// or blocked / muted in some way.
+			return nil, nil
+		}
+	}
+
+	return account, nil
+}
+
+// deprecatedSource returns the deprecated
+// single source of a suggestion, for clients
+// that don't support the list of sources yet.
+func deprecatedSource(source string) string {
+	switch source {
+	case sourceFeatured:
+		return "staff"
+	case sourceMostInteractions:
+		return "past_interactions"
+	default:
+		return "global"
+	}
+}
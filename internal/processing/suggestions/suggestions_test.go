@@ -0,0 +1,136 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package suggestions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/suggestions"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SuggestionsTestSuite struct {
+	suite.Suite
+	state       state.State
+	suggestions suggestions.Processor
+
+	testAccounts map[string]*gtsmodel.Account
+}
+
+func (suite *SuggestionsTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+	suite.state.Caches.Init()
+	testrig.StartNoopWorkers(&suite.state)
+	testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.state.DB, nil)
+	suite.testAccounts = testrig.NewTestAccounts()
+	converter := typeutils.NewConverter(&suite.state)
+	suite.suggestions = suggestions.New(&suite.state, converter)
+}
+
+func (suite *SuggestionsTestSuite) TearDownTest() {
+	testrig.StopWorkers(&suite.state)
+	testrig.StandardDBTeardown(suite.state.DB)
+}
+
+func (suite *SuggestionsTestSuite) TestSuggestionsGet() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_2"]
+
+	// local_account_2 follows local_account_1, who is
+	// featured, and who follows the admin account.
+	// Only the admin account should be suggested.
+	suggestions, errWithCode := suite.suggestions.SuggestionsGet(ctx, requester, 40)
+	suite.NoError(errWithCode)
+	suite.Len(suggestions, 1)
+	suite.Equal(suite.testAccounts["admin_account"].ID, suggestions[0].Account.ID)
+	suite.Equal([]string{"friends_of_friends", "most_followed"}, suggestions[0].Sources)
+	suite.Equal("global", suggestions[0].Source)
+}
+
+func (suite *SuggestionsTestSuite) TestSuggestionsGetFeatured() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_2"]
+	admin := suite.testAccounts["admin_account"]
+
+	err := suite.state.DB.PutFeaturedSuggestion(ctx, &gtsmodel.FeaturedSuggestion{
+		ID:                 id.NewULID(),
+		AccountID:          admin.ID,
+		CreatedByAccountID: admin.ID,
+	})
+	suite.NoError(err)
+
+	suggestions, errWithCode := suite.suggestions.SuggestionsGet(ctx, requester, 40)
+	suite.NoError(errWithCode)
+	suite.Len(suggestions, 1)
+	suite.Equal([]string{"featured", "friends_of_friends", "most_followed"}, suggestions[0].Sources)
+	suite.Equal("staff", suggestions[0].Source)
+}
+
+func (suite *SuggestionsTestSuite) TestSuggestionsGetBlocked() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_2"]
+
+	err := suite.state.DB.PutBlock(ctx, &gtsmodel.Block{
+		ID:              id.NewULID(),
+		URI:             "http://localhost:8080/users/admin/blocks/" + id.NewULID(),
+		AccountID:       suite.testAccounts["admin_account"].ID,
+		TargetAccountID: requester.ID,
+	})
+	suite.NoError(err)
+
+	suggestions, errWithCode := suite.suggestions.SuggestionsGet(ctx, requester, 40)
+	suite.NoError(errWithCode)
+	suite.Empty(suggestions)
+}
+
+func (suite *SuggestionsTestSuite) TestSuggestionDismiss() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_2"]
+	targetAccountID := suite.testAccounts["admin_account"].ID
+
+	// Dismissing twice should be fine.
+	for i := 0; i < 2; i++ {
+		errWithCode := suite.suggestions.SuggestionDismiss(ctx, requester, targetAccountID)
+		suite.NoError(errWithCode)
+	}
+
+	suggestions, errWithCode := suite.suggestions.SuggestionsGet(ctx, requester, 40)
+	suite.NoError(errWithCode)
+	suite.Empty(suggestions)
+}
+
+func (suite *SuggestionsTestSuite) TestSuggestionDismissNotFound() {
+	errWithCode := suite.suggestions.SuggestionDismiss(
+		context.Background(),
+		suite.testAccounts["local_account_2"],
+		"01JATCZ8D7E5Q1V9M0R3YB2K4H",
+	)
+	suite.EqualError(errWithCode, "SuggestionDismiss: account 01JATCZ8D7E5Q1V9M0R3YB2K4H not found")
+}
+
+func TestSuggestionsTestSuite(t *testing.T) {
+	suite.Run(t, new(SuggestionsTestSuite))
+}
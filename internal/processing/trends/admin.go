@@ -0,0 +1,339 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// AdminTagsGet returns all trending tags, including those
+// not (yet) approved, most trending first, paged by limit + offset.
+func (p *Processor) AdminTagsGet(
+	ctx context.Context,
+	limit int,
+	offset int,
+) ([]*apimodel.AdminTrendsTag, gtserror.WithCode) {
+	items := page(p.get().tags, limit, offset)
+	apiTags := make([]*apimodel.AdminTrendsTag, 0, len(items))
+
+	for _, item := range items {
+		tag, err := p.state.DB.GetTag(ctx, item.targetID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "db error getting tag %s: %v", item.targetID, err)
+			}
+			continue
+		}
+
+		apiTag, errWithCode := p.adminTag(ctx, tag, item.history)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiTags = append(apiTags, apiTag)
+	}
+
+	return apiTags, nil
+}
+
+// AdminStatusesGet returns all trending statuses, including those
+// not (yet) approved, most trending first, paged by limit + offset.
+func (p *Processor) AdminStatusesGet(
+	ctx context.Context,
+	admin *gtsmodel.Account,
+	limit int,
+	offset int,
+) ([]*apimodel.AdminTrendsStatus, gtserror.WithCode) {
+	items := page(p.get().statuses, limit, offset)
+	apiStatuses := make([]*apimodel.AdminTrendsStatus, 0, len(items))
+
+	for _, item := range items {
+		status, err := p.state.DB.GetStatusByID(ctx, item.targetID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "db error getting status %s: %v", item.targetID, err)
+			}
+			continue
+		}
+
+		apiStatus, errWithCode := p.adminStatus(ctx, admin, status)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiStatuses = append(apiStatuses, apiStatus)
+	}
+
+	return apiStatuses, nil
+}
+
+// AdminLinksGet returns all trending links, including those
+// not (yet) approved, most trending first, paged by limit + offset.
+func (p *Processor) AdminLinksGet(
+	ctx context.Context,
+	limit int,
+	offset int,
+) ([]*apimodel.AdminTrendsLink, gtserror.WithCode) {
+	items := page(p.get().links, limit, offset)
+	apiLinks := make([]*apimodel.AdminTrendsLink, 0, len(items))
+
+	for _, item := range items {
+		trend, err := p.state.DB.GetTrendByTarget(ctx, gtsmodel.TrendTypeLink, item.targetID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "db error getting link trend %s: %v", item.targetID, err)
+			}
+			continue
+		}
+
+		apiLinks = append(apiLinks, adminLink(trend, item.history))
+	}
+
+	return apiLinks, nil
+}
+
+// AdminTagReview approves or rejects the tag with the given ID
+// as a trend, which may be done before the tag starts trending.
+func (p *Processor) AdminTagReview(
+	ctx context.Context,
+	admin *gtsmodel.Account,
+	tagID string,
+	approve bool,
+) (*apimodel.AdminTrendsTag, gtserror.WithCode) {
+	tag, err := p.state.DB.GetTag(ctx, tagID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tag %s: %w", tagID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if tag == nil {
+		err := gtserror.Newf("tag %s not found", tagID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if _, errWithCode := p.review(ctx, admin, gtsmodel.TrendTypeTag, tag.ID, approve); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.adminTag(ctx, tag, trendHistory(p.get().tags, tag.ID))
+}
+
+// AdminStatusReview approves or rejects the status with the given
+// ID as a trend, which may be done before the status starts trending.
+func (p *Processor) AdminStatusReview(
+	ctx context.Context,
+	admin *gtsmodel.Account,
+	statusID string,
+	approve bool,
+) (*apimodel.AdminTrendsStatus, gtserror.WithCode) {
+	status, err := p.state.DB.GetStatusByID(ctx, statusID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting status %s: %w", statusID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if status == nil {
+		err := gtserror.Newf("status %s not found", statusID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if status.Visibility != gtsmodel.VisibilityPublic || status.BoostOfID != "" {
+		const text = "only public, non-boost statuses can trend"
+		return nil, gtserror.NewErrorUnprocessableEntity(gtserror.New(text), text)
+	}
+
+	if _, errWithCode := p.review(ctx, admin, gtsmodel.TrendTypeStatus, status.ID, approve); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.adminStatus(ctx, admin, status)
+}
+
+// AdminLinkReview approves or rejects the trending link with the given
+// trend ID. Unlike tags and statuses, links only have an ID once trending.
+func (p *Processor) AdminLinkReview(
+	ctx context.Context,
+	admin *gtsmodel.Account,
+	trendID string,
+	approve bool,
+) (*apimodel.AdminTrendsLink, gtserror.WithCode) {
+	trend, err := p.state.DB.GetTrendByID(ctx, trendID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting trend %s: %w", trendID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if trend == nil || trend.Type != gtsmodel.TrendTypeLink {
+		err := gtserror.Newf("link trend %s not found", trendID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	trend, errWithCode := p.review(ctx, admin, trend.Type, trend.TargetID, approve)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return adminLink(trend, trendHistory(p.get().links, trend.TargetID)), nil
+}
+
+// review sets the trend with given type and target as approved
+// or rejected by the given admin, creating it if necessary.
+func (p *Processor) review(
+	ctx context.Context,
+	admin *gtsmodel.Account,
+	trendType gtsmodel.TrendType,
+	targetID string,
+	approve bool,
+) (*gtsmodel.Trend, gtserror.WithCode) {
+	state := gtsmodel.TrendStateRejected
+	if approve {
+		state = gtsmodel.TrendStateApproved
+	}
+
+	trend, err := p.state.DB.GetTrendByTarget(ctx, trendType, targetID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting %s trend %s: %w", trendType, targetID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if trend == nil {
+		// Not trending (yet),
+		// store review for later.
+		trend = &gtsmodel.Trend{
+			ID:                  id.NewULID(),
+			Type:                trendType,
+			TargetID:            targetID,
+			State:               state,
+			ReviewedAt:          time.Now(),
+			ReviewedByAccountID: admin.ID,
+		}
+
+		if err := p.state.DB.PutTrend(ctx, trend); err != nil {
+			err := gtserror.Newf("db error putting %s trend %s: %w", trendType, targetID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		return trend, nil
+	}
+
+	trend.State = state
+	trend.ReviewedAt = time.Now()
+	trend.ReviewedByAccountID = admin.ID
+
+	if err := p.state.DB.UpdateTrend(ctx, trend,
+		"state",
+		"reviewed_at",
+		"reviewed_by_account_id",
+	); err != nil {
+		err := gtserror.Newf("db error updating %s trend %s: %w", trendType, targetID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return trend, nil
+}
+
+// adminTag returns the admin API
+// version of the given trending tag.
+func (p *Processor) adminTag(
+	ctx context.Context,
+	tag *gtsmodel.Tag,
+	history []apimodel.History,
+) (*apimodel.AdminTrendsTag, gtserror.WithCode) {
+	state, err := p.getTrendState(ctx, gtsmodel.TrendTypeTag, tag.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTag, err := p.converter.TagToAPITag(ctx, tag, false, nil)
+	if err != nil {
+		err := gtserror.Newf("error converting tag %s to frontend representation: %w", tag.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	apiTag.History = &history
+
+	return &apimodel.AdminTrendsTag{
+		Tag:            apiTag,
+		ID:             tag.ID,
+		Trendable:      state == gtsmodel.TrendStateApproved,
+		Usable:         *tag.Useable,
+		RequiresReview: state == gtsmodel.TrendStatePending,
+	}, nil
+}
+
+// adminStatus returns the admin API
+// version of the given trending status.
+func (p *Processor) adminStatus(
+	ctx context.Context,
+	admin *gtsmodel.Account,
+	status *gtsmodel.Status,
+) (*apimodel.AdminTrendsStatus, gtserror.WithCode) {
+	state, err := p.getTrendState(ctx, gtsmodel.TrendTypeStatus, status.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiStatus, err := p.converter.StatusToAPIStatus(ctx,
+		status,
+		admin,
+		statusfilter.FilterContextNone,
+		nil,
+		nil,
+	)
+	if err != nil {
+		err := gtserror.Newf("error converting status %s to frontend representation: %w", status.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.AdminTrendsStatus{
+		Status:         apiStatus,
+		RequiresReview: state == gtsmodel.TrendStatePending,
+	}, nil
+}
+
+// adminLink returns the admin API
+// version of the given trending link.
+func adminLink(t *gtsmodel.Trend, history []apimodel.History) *apimodel.AdminTrendsLink {
+	return &apimodel.AdminTrendsLink{
+		TrendsLink:     *apiLink(&trend{targetID: t.TargetID, history: history}),
+		ID:             t.ID,
+		Trendable:      t.State == gtsmodel.TrendStateApproved,
+		RequiresReview: t.State == gtsmodel.TrendStatePending,
+	}
+}
+
+// trendHistory returns the usage history of the
+// trending item with given target ID, or an
+// empty history if it's not currently trending.
+func trendHistory(items []*trend, targetID string) []apimodel.History {
+	for _, item := range items {
+		if item.targetID == targetID {
+			return item.history
+		}
+	}
+	return []apimodel.History{}
+}
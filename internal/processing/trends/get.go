@@ -0,0 +1,216 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/usermute"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// TagsGet returns approved trending tags,
+// most trending first, paged by limit + offset.
+func (p *Processor) TagsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	limit int,
+	offset int,
+) ([]*apimodel.Tag, gtserror.WithCode) {
+	apiTags := make([]*apimodel.Tag, 0, limit)
+
+	for _, item := range p.get().tags {
+		if len(apiTags) == offset+limit {
+			break
+		}
+
+		state, err := p.getTrendState(ctx, gtsmodel.TrendTypeTag, item.targetID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if state != gtsmodel.TrendStateApproved {
+			continue
+		}
+
+		tag, err := p.state.DB.GetTag(ctx, item.targetID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "db error getting tag %s: %v", item.targetID, err)
+			}
+			continue
+		}
+
+		if !*tag.Useable || !*tag.Listable {
+			// Tag has since been disabled.
+			continue
+		}
+
+		following, err := p.state.DB.IsAccountFollowingTag(ctx, requester.ID, tag.ID)
+		if err != nil {
+			err := gtserror.Newf("db error checking whether account %s follows tag %s: %w", requester.ID, tag.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		apiTag, err := p.converter.TagToAPITag(ctx, tag, false, &following)
+		if err != nil {
+			err := gtserror.Newf("error converting tag %s to frontend representation: %w", tag.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiTag.History = &item.history
+
+		apiTags = append(apiTags, &apiTag)
+	}
+
+	return page(apiTags, limit, offset), nil
+}
+
+// StatusesGet returns approved trending statuses which are
+// visible to the requester, most trending first, paged by
+// limit + offset.
+func (p *Processor) StatusesGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	limit int,
+	offset int,
+) ([]*apimodel.Status, gtserror.WithCode) {
+	filters, err := p.state.DB.GetFiltersForAccountID(ctx, requester.ID)
+	if err != nil {
+		err = gtserror.Newf("couldn't retrieve filters for account %s: %w", requester.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mutes, err := p.state.DB.GetAccountMutes(gtscontext.SetBarebones(ctx), requester.ID, nil)
+	if err != nil {
+		err = gtserror.Newf("couldn't retrieve mutes for account %s: %w", requester.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	compiledMutes := usermute.NewCompiledUserMuteList(mutes)
+
+	apiStatuses := make([]*apimodel.Status, 0, limit)
+
+	for _, item := range p.get().statuses {
+		if len(apiStatuses) == offset+limit {
+			break
+		}
+
+		state, err := p.getTrendState(ctx, gtsmodel.TrendTypeStatus, item.targetID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if state != gtsmodel.TrendStateApproved {
+			continue
+		}
+
+		status, err := p.state.DB.GetStatusByID(ctx, item.targetID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "db error getting status %s: %v", item.targetID, err)
+			}
+			continue
+		}
+
+		visible, err := p.visFilter.StatusVisible(ctx, requester, status)
+		if err != nil {
+			log.Errorf(ctx, "error checking status visibility: %v", err)
+			continue
+		}
+
+		if !visible {
+			continue
+		}
+
+		apiStatus, err := p.converter.StatusToAPIStatus(ctx,
+			status,
+			requester,
+			statusfilter.FilterContextPublic,
+			filters,
+			compiledMutes,
+		)
+		if errors.Is(err, statusfilter.ErrHideStatus) {
+			continue
+		}
+		if err != nil {
+			log.Errorf(ctx, "error converting to api status: %v", err)
+			continue
+		}
+
+		apiStatuses = append(apiStatuses, apiStatus)
+	}
+
+	return page(apiStatuses, limit, offset), nil
+}
+
+// LinksGet returns approved trending links,
+// most trending first, paged by limit + offset.
+func (p *Processor) LinksGet(
+	ctx context.Context,
+	limit int,
+	offset int,
+) ([]*apimodel.TrendsLink, gtserror.WithCode) {
+	apiLinks := make([]*apimodel.TrendsLink, 0, limit)
+
+	for _, item := range p.get().links {
+		if len(apiLinks) == offset+limit {
+			break
+		}
+
+		state, err := p.getTrendState(ctx, gtsmodel.TrendTypeLink, item.targetID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if state != gtsmodel.TrendStateApproved {
+			continue
+		}
+
+		apiLinks = append(apiLinks, apiLink(item))
+	}
+
+	return page(apiLinks, limit, offset), nil
+}
+
+// apiLink returns the API version of the given trending
+// link. As link previews aren't fetched, the card contains
+// just the link itself and its host as the provider.
+func apiLink(item *trend) *apimodel.TrendsLink {
+	apiLink := &apimodel.TrendsLink{
+		Card: apimodel.Card{
+			URL:   item.targetID,
+			Title: item.targetID,
+			Type:  "link",
+		},
+		History: item.history,
+	}
+
+	if link, err := url.Parse(item.targetID); err == nil {
+		apiLink.ProviderName = link.Host
+		apiLink.ProviderURL = link.Scheme + "://" + link.Host
+	}
+
+	return apiLink
+}
@@ -0,0 +1,429 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	// refreshEvery is how
+	// often trends are recalculated.
+	refreshEvery = 15 * time.Minute
+
+	// historyDays is the number of rolling
+	// 24 hour windows of usage history used
+	// to calculate tag and link trends.
+	historyDays = 7
+
+	// minAccounts is the minimum number of distinct
+	// accounts that must have used a tag or link, or
+	// engaged with a status, within the most recent
+	// window for it to be considered as trending.
+	minAccounts = 2
+
+	// statusMaxAge is the maximum age of
+	// a status for it to be considered
+	// as trending. Engagement with older
+	// statuses is ignored.
+	statusMaxAge = 3 * 24 * time.Hour
+
+	// statusHalfLife is the age at which the
+	// score of a trending status is halved.
+	statusHalfLife = 24 * time.Hour
+
+	// maxTrends is the maximum number of
+	// each type of trend kept per refresh.
+	maxTrends = 50
+)
+
+// ScheduleRefresh schedules trends to be recalculated
+// every 15 minutes, starting now.
+func (p *Processor) ScheduleRefresh() error {
+	fn := func(ctx context.Context, start time.Time) {
+		log.Debug(ctx, "starting trends refresh")
+		if err := p.Refresh(ctx); err != nil {
+			log.Errorf(ctx, "error refreshing trends: %v", err)
+			return
+		}
+		log.Debugf(ctx, "finished trends refresh after %s", time.Since(start))
+	}
+
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@trendsrefresh",
+		time.Time{},
+		refreshEvery,
+		fn,
+	) {
+		return gtserror.New("failed to schedule @trendsrefresh")
+	}
+
+	return nil
+}
+
+// Refresh recalculates trending tags, statuses and links from
+// rolling windows of recent usage, storing a new pending trend
+// for admins to review for any trending item not seen before.
+func (p *Processor) Refresh(ctx context.Context) error {
+	now := time.Now()
+
+	tags, err := p.calculateTrends(ctx, now, p.state.DB.GetTagUsage)
+	if err != nil {
+		return gtserror.Newf("error calculating tag trends: %w", err)
+	}
+
+	links, err := p.calculateTrends(ctx, now, p.getLinkUsage)
+	if err != nil {
+		return gtserror.Newf("error calculating link trends: %w", err)
+	}
+
+	statuses, err := p.calculateStatusTrends(ctx, now)
+	if err != nil {
+		return gtserror.Newf("error calculating status trends: %w", err)
+	}
+
+	// Ensure a trend exists for each trending item,
+	// so that unseen items show up for admin review.
+	for trendType, items := range map[gtsmodel.TrendType][]*trend{
+		gtsmodel.TrendTypeTag:    tags,
+		gtsmodel.TrendTypeStatus: statuses,
+		gtsmodel.TrendTypeLink:   links,
+	} {
+		for _, item := range items {
+			if err := p.putPendingTrend(ctx, trendType, item.targetID); err != nil {
+				return err
+			}
+		}
+	}
+
+	p.trends.Store(&trends{
+		tags:     tags,
+		statuses: statuses,
+		links:    links,
+	})
+
+	return nil
+}
+
+// calculateTrends calculates trending items from the
+// daily usage returned by the given function, scoring
+// each by how much it was used by distinct accounts in
+// the most recent window, compared to the windows before.
+func (p *Processor) calculateTrends(
+	ctx context.Context,
+	now time.Time,
+	getUsage func(context.Context, time.Time, time.Time) ([]*gtsmodel.TrendUsage, error),
+) ([]*trend, error) {
+	var (
+		histories = make(map[string][]apimodel.History)
+		accounts  = make(map[string][]int)
+	)
+
+	for day := 0; day < historyDays; day++ {
+		until := now.Add(-time.Duration(day) * 24 * time.Hour)
+		since := until.Add(-24 * time.Hour)
+
+		usage, err := getUsage(ctx, since, until)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, u := range usage {
+			if _, ok := histories[u.TargetID]; !ok {
+				// First time seeing this target,
+				// prepare history with zero usage.
+				histories[u.TargetID] = zeroHistory(now)
+				accounts[u.TargetID] = make([]int, historyDays)
+			}
+
+			histories[u.TargetID][day].Uses = strconv.Itoa(u.Uses)
+			histories[u.TargetID][day].Accounts = strconv.Itoa(u.Accounts)
+			accounts[u.TargetID][day] = u.Accounts
+		}
+	}
+
+	items := make([]*trend, 0, len(histories))
+	for targetID, history := range histories {
+		score := usageScore(accounts[targetID])
+		if score <= 0 {
+			// Not trending.
+			continue
+		}
+
+		items = append(items, &trend{
+			targetID: targetID,
+			history:  history,
+			score:    score,
+		})
+	}
+
+	return topTrends(items), nil
+}
+
+// calculateStatusTrends calculates trending statuses, scoring each
+// by the number of distinct accounts that faved or boosted it in
+// the most recent window, decaying with the age of the status.
+func (p *Processor) calculateStatusTrends(ctx context.Context, now time.Time) ([]*trend, error) {
+	engagement, err := p.state.DB.GetStatusEngagement(ctx, now.Add(-24*time.Hour), now)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*trend, 0, len(engagement))
+	for _, e := range engagement {
+		if e.Accounts < minAccounts {
+			// Not enough engagement.
+			continue
+		}
+
+		status, err := p.state.DB.GetStatusByID(gtscontext.SetBarebones(ctx), e.TargetID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "db error getting status %s: %v", e.TargetID, err)
+			}
+			continue
+		}
+
+		if status.Visibility != gtsmodel.VisibilityPublic ||
+			status.BoostOfID != "" ||
+			util.PtrOrValue(status.PendingApproval, false) {
+			// Only public, non-boost, approved
+			// statuses are eligible to trend.
+			continue
+		}
+
+		age := now.Sub(status.CreatedAt)
+		if age > statusMaxAge {
+			// Too old.
+			continue
+		}
+
+		decay := math.Pow(0.5, float64(age)/float64(statusHalfLife))
+		items = append(items, &trend{
+			targetID: status.ID,
+			score:    float64(e.Accounts) * decay,
+		})
+	}
+
+	return topTrends(items), nil
+}
+
+// getLinkUsage returns the usage of each link
+// shared by public statuses created within
+// the given period of time.
+func (p *Processor) getLinkUsage(ctx context.Context, since time.Time, until time.Time) ([]*gtsmodel.TrendUsage, error) {
+	statuses, err := p.state.DB.GetPublicStatusContent(ctx, since, until)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	var (
+		usage    = make(map[string]*gtsmodel.TrendUsage)
+		accounts = make(map[string]map[string]struct{})
+	)
+
+	for _, status := range statuses {
+		for _, link := range extractLinks(status.Content) {
+			u, ok := usage[link]
+			if !ok {
+				u = &gtsmodel.TrendUsage{TargetID: link}
+				usage[link] = u
+				accounts[link] = make(map[string]struct{})
+			}
+
+			u.Uses++
+			accounts[link][status.AccountID] = struct{}{}
+			u.Accounts = len(accounts[link])
+		}
+	}
+
+	usages := make([]*gtsmodel.TrendUsage, 0, len(usage))
+	for _, u := range usage {
+		usages = append(usages, u)
+	}
+
+	return usages, nil
+}
+
+// putPendingTrend stores a new pending trend for
+// the given trending item, if one doesn't exist yet.
+func (p *Processor) putPendingTrend(
+	ctx context.Context,
+	trendType gtsmodel.TrendType,
+	targetID string,
+) error {
+	_, err := p.state.DB.GetTrendByTarget(ctx, trendType, targetID)
+	if err == nil {
+		// Already exists.
+		return nil
+	}
+
+	if !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting %s trend %s: %w", trendType, targetID, err)
+	}
+
+	if err := p.state.DB.PutTrend(ctx, &gtsmodel.Trend{
+		ID:       id.NewULID(),
+		Type:     trendType,
+		TargetID: targetID,
+		State:    gtsmodel.TrendStatePending,
+	}); err != nil && !errors.Is(err, db.ErrAlreadyExists) {
+		return gtserror.Newf("db error putting %s trend %s: %w", trendType, targetID, err)
+	}
+
+	return nil
+}
+
+// extractLinks returns the http(s) URLs of each
+// link in the given status HTML content, excluding
+// mention and hashtag links, without duplicates.
+func extractLinks(content string) []string {
+	var links []string
+
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			// End of content.
+			return links
+
+		case html.StartTagToken:
+			token := tokenizer.Token()
+			if token.DataAtom != atom.A {
+				continue
+			}
+
+			var (
+				href   string
+				ignore bool
+			)
+
+			for _, attr := range token.Attr {
+				switch attr.Key {
+				case "href":
+					href = attr.Val
+				case "class":
+					// Ignore mentions + hashtags,
+					// eg., `class="mention hashtag"`.
+					classes := strings.Fields(attr.Val)
+					ignore = ignore ||
+						slices.Contains(classes, "mention") ||
+						slices.Contains(classes, "hashtag")
+				case "rel":
+					// Ignore tags, eg., `rel="tag"`.
+					ignore = ignore ||
+						slices.Contains(strings.Fields(attr.Val), "tag")
+				}
+			}
+
+			if ignore || href == "" {
+				continue
+			}
+
+			link, err := url.Parse(href)
+			if err != nil ||
+				(link.Scheme != "http" && link.Scheme != "https") ||
+				link.Host == "" {
+				// Not a valid web link.
+				continue
+			}
+
+			// Fragments don't
+			// change the link.
+			link.Fragment = ""
+			link.RawFragment = ""
+
+			if str := link.String(); !slices.Contains(links, str) {
+				links = append(links, str)
+			}
+		}
+	}
+}
+
+// usageScore returns the trend score for given daily counts
+// of distinct accounts, most recent first. This is the number
+// of accounts in the most recent day, minus the mean number of
+// accounts in the days before, or zero if there weren't enough
+// accounts in the most recent day for the item to be trending.
+func usageScore(accounts []int) float64 {
+	if accounts[0] < minAccounts {
+		return 0
+	}
+
+	var expected float64
+	for _, n := range accounts[1:] {
+		expected += float64(n)
+	}
+	expected /= float64(len(accounts) - 1)
+
+	return float64(accounts[0]) - expected
+}
+
+// zeroHistory returns a usage history with zero usage
+// for each day of history, most recent day first.
+func zeroHistory(now time.Time) []apimodel.History {
+	history := make([]apimodel.History, historyDays)
+	for day := range history {
+		since := now.Add(-time.Duration(day+1) * 24 * time.Hour)
+		history[day] = apimodel.History{
+			Day:      strconv.FormatInt(since.Truncate(24*time.Hour).Unix(), 10),
+			Uses:     "0",
+			Accounts: "0",
+		}
+	}
+	return history
+}
+
+// topTrends sorts the given trending items by
+// score descending, returning at most maxTrends.
+func topTrends(items []*trend) []*trend {
+	slices.SortFunc(items, func(a, b *trend) int {
+		if c := cmp.Compare(b.score, a.score); c != 0 {
+			return c
+		}
+
+		// Sort ties by ID / URL for
+		// a stable, predictable order.
+		return cmp.Compare(a.targetID, b.targetID)
+	})
+
+	if len(items) > maxTrends {
+		items = items[:maxTrends]
+	}
+
+	return items
+}
@@ -0,0 +1,124 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+// Processor wraps functionality for calculating trending
+// tags, statuses and links, for showing approved trends
+// to users, and for reviewing trends as an admin.
+type Processor struct {
+	state     *state.State
+	converter *typeutils.Converter
+	visFilter *visibility.Filter
+
+	// Most recently calculated trends,
+	// or nil if not yet calculated.
+	trends *atomic.Pointer[trends]
+}
+
+// New returns a new trends processor.
+func New(
+	state *state.State,
+	converter *typeutils.Converter,
+	visFilter *visibility.Filter,
+) Processor {
+	return Processor{
+		state:     state,
+		converter: converter,
+		visFilter: visFilter,
+		trends:    new(atomic.Pointer[trends]),
+	}
+}
+
+// trends is a snapshot of calculated
+// trends, each sorted by score descending.
+type trends struct {
+	tags     []*trend
+	statuses []*trend
+	links    []*trend
+}
+
+// trend is one calculated trending item.
+type trend struct {
+	// ID of the tag or status,
+	// or URL of the link.
+	targetID string
+
+	// Daily usage history,
+	// most recent day first.
+	history []apimodel.History
+
+	// Score used to rank
+	// this trending item.
+	score float64
+}
+
+// get returns the most recently calculated trends,
+// or an empty snapshot if not yet calculated.
+func (p *Processor) get() *trends {
+	if t := p.trends.Load(); t != nil {
+		return t
+	}
+	return new(trends)
+}
+
+// getTrendState returns the review state of the
+// trend with given type and target, returning
+// pending if the trend hasn't been stored yet.
+func (p *Processor) getTrendState(
+	ctx context.Context,
+	trendType gtsmodel.TrendType,
+	targetID string,
+) (gtsmodel.TrendState, error) {
+	trend, err := p.state.DB.GetTrendByTarget(ctx, trendType, targetID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return 0, gtserror.Newf("db error getting %s trend %s: %w", trendType, targetID, err)
+	}
+
+	if trend == nil {
+		return gtsmodel.TrendStatePending, nil
+	}
+
+	return trend.State, nil
+}
+
+// page returns the page of given slice
+// described by given limit and offset.
+func page[T any](items []T, limit int, offset int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	items = items[offset:]
+	if limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
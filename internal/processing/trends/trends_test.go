@@ -0,0 +1,230 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/trends"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TrendsTestSuite struct {
+	suite.Suite
+	state  state.State
+	trends trends.Processor
+
+	testAccounts map[string]*gtsmodel.Account
+	testStatuses map[string]*gtsmodel.Status
+	testTags     map[string]*gtsmodel.Tag
+}
+
+func (suite *TrendsTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+	suite.state.Caches.Init()
+	testrig.StartNoopWorkers(&suite.state)
+	testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.state.DB, nil)
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testStatuses = testrig.NewTestStatuses()
+	suite.testTags = testrig.NewTestTags()
+	converter := typeutils.NewConverter(&suite.state)
+	suite.trends = trends.New(&suite.state, converter, visibility.NewFilter(&suite.state))
+}
+
+func (suite *TrendsTestSuite) TearDownTest() {
+	testrig.StopWorkers(&suite.state)
+	testrig.StandardDBTeardown(suite.state.DB)
+}
+
+// putStatus stores a new public status
+// by the given account, created now.
+func (suite *TrendsTestSuite) putStatus(account *gtsmodel.Account, content string, tagIDs ...string) *gtsmodel.Status {
+	statusID := id.NewULID()
+	status := &gtsmodel.Status{
+		ID:                  statusID,
+		URI:                 "http://localhost:8080/users/" + account.Username + "/statuses/" + statusID,
+		URL:                 "http://localhost:8080/@" + account.Username + "/statuses/" + statusID,
+		Content:             content,
+		TagIDs:              tagIDs,
+		Local:               util.Ptr(true),
+		AccountID:           account.ID,
+		AccountURI:          account.URI,
+		Visibility:          gtsmodel.VisibilityPublic,
+		ActivityStreamsType: ap.ObjectNote,
+		Federated:           util.Ptr(true),
+	}
+
+	if err := suite.state.DB.PutStatus(context.Background(), status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return status
+}
+
+// putFave stores a new fave of the
+// given status by the given account.
+func (suite *TrendsTestSuite) putFave(account *gtsmodel.Account, status *gtsmodel.Status) {
+	faveID := id.NewULID()
+	if err := suite.state.DB.PutStatusFave(context.Background(), &gtsmodel.StatusFave{
+		ID:              faveID,
+		AccountID:       account.ID,
+		TargetAccountID: status.AccountID,
+		StatusID:        status.ID,
+		URI:             "http://localhost:8080/users/" + account.Username + "/liked/" + faveID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *TrendsTestSuite) TestRefreshAndReview() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+		admin     = suite.testAccounts["admin_account"]
+		tagID     = suite.testTags["welcome"].ID
+		content   = `<p>read this <a href="https://example.org/article#comments" rel="nofollow noreferrer noopener">https://example.org/article</a> <a href="http://localhost:8080/tags/welcome" class="mention hashtag" rel="tag">#<span>welcome</span></a></p>`
+	)
+
+	status := suite.putStatus(requester, content, tagID)
+	suite.putStatus(suite.testAccounts["local_account_2"], content, tagID)
+	suite.putFave(suite.testAccounts["local_account_2"], status)
+	suite.putFave(admin, status)
+
+	// Nothing trends before
+	// trends are calculated.
+	tags, errWithCode := suite.trends.TagsGet(ctx, requester, 10, 0)
+	suite.Nil(errWithCode)
+	suite.Empty(tags)
+
+	if err := suite.trends.Refresh(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Welcome tag was already approved
+	// so it should be shown straight away.
+	tags, errWithCode = suite.trends.TagsGet(ctx, requester, 10, 0)
+	suite.Nil(errWithCode)
+	suite.Len(tags, 1)
+	suite.Equal("welcome", tags[0].Name)
+	suite.Len(*tags[0].History, 7)
+	suite.Equal("2", (*tags[0].History)[0].Uses)
+	suite.Equal("2", (*tags[0].History)[0].Accounts)
+	suite.Equal("0", (*tags[0].History)[1].Accounts)
+
+	// Link and status haven't been
+	// reviewed so shouldn't be shown.
+	links, errWithCode := suite.trends.LinksGet(ctx, 10, 0)
+	suite.Nil(errWithCode)
+	suite.Empty(links)
+
+	statuses, errWithCode := suite.trends.StatusesGet(ctx, requester, 10, 0)
+	suite.Nil(errWithCode)
+	suite.Empty(statuses)
+
+	// Admins should see them waiting for review.
+	adminLinks, errWithCode := suite.trends.AdminLinksGet(ctx, 10, 0)
+	suite.Nil(errWithCode)
+	suite.Len(adminLinks, 1)
+	suite.Equal("https://example.org/article", adminLinks[0].URL)
+	suite.Equal("example.org", adminLinks[0].ProviderName)
+	suite.True(adminLinks[0].RequiresReview)
+	suite.False(adminLinks[0].Trendable)
+
+	adminStatuses, errWithCode := suite.trends.AdminStatusesGet(ctx, admin, 10, 0)
+	suite.Nil(errWithCode)
+	suite.Len(adminStatuses, 1)
+	suite.Equal(status.ID, adminStatuses[0].ID)
+	suite.True(adminStatuses[0].RequiresReview)
+
+	// Approve link + status.
+	adminLink, errWithCode := suite.trends.AdminLinkReview(ctx, admin, adminLinks[0].ID, true)
+	suite.Nil(errWithCode)
+	suite.True(adminLink.Trendable)
+	suite.False(adminLink.RequiresReview)
+
+	adminStatus, errWithCode := suite.trends.AdminStatusReview(ctx, admin, status.ID, true)
+	suite.Nil(errWithCode)
+	suite.False(adminStatus.RequiresReview)
+
+	links, errWithCode = suite.trends.LinksGet(ctx, 10, 0)
+	suite.Nil(errWithCode)
+	suite.Len(links, 1)
+	suite.Equal("https://example.org/article", links[0].URL)
+
+	statuses, errWithCode = suite.trends.StatusesGet(ctx, requester, 10, 0)
+	suite.Nil(errWithCode)
+	suite.Len(statuses, 1)
+	suite.Equal(status.ID, statuses[0].ID)
+
+	// Paging past the end returns nothing.
+	statuses, errWithCode = suite.trends.StatusesGet(ctx, requester, 10, 1)
+	suite.Nil(errWithCode)
+	suite.Empty(statuses)
+
+	// Rejecting the tag hides it again.
+	adminTag, errWithCode := suite.trends.AdminTagReview(ctx, admin, tagID, false)
+	suite.Nil(errWithCode)
+	suite.False(adminTag.Trendable)
+	suite.False(adminTag.RequiresReview)
+
+	tags, errWithCode = suite.trends.TagsGet(ctx, requester, 10, 0)
+	suite.Nil(errWithCode)
+	suite.Empty(tags)
+}
+
+func (suite *TrendsTestSuite) TestReviewBeforeTrending() {
+	var (
+		ctx   = context.Background()
+		admin = suite.testAccounts["admin_account"]
+	)
+
+	// Tags can be reviewed before they're trending.
+	adminTag, errWithCode := suite.trends.AdminTagReview(ctx, admin, suite.testTags["Hashtag"].ID, true)
+	suite.Nil(errWithCode)
+	suite.Equal("hashtag", adminTag.Name)
+	suite.True(adminTag.Trendable)
+	suite.Empty(*adminTag.History)
+
+	// Unknown tag.
+	_, errWithCode = suite.trends.AdminTagReview(ctx, admin, "01JAQB6Z1W8C5K9N2R7T3X4M0Y", true)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Non-public statuses can't trend.
+	_, errWithCode = suite.trends.AdminStatusReview(ctx, admin, suite.testStatuses["local_account_1_status_5"].ID, true)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Links can only be reviewed once trending.
+	_, errWithCode = suite.trends.AdminLinkReview(ctx, admin, adminTag.ID, true)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestTrendsTestSuite(t *testing.T) {
+	suite.Run(t, new(TrendsTestSuite))
+}
@@ -0,0 +1,194 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// TokensGet returns a page of access tokens owned by the given user.
+func (p *Processor) TokensGet(
+	ctx context.Context,
+	userID string,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	tokens, err := p.state.DB.GetAccessTokens(ctx, userID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tokens: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(tokens)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = tokens[count-1].ID
+		hi = tokens[0].ID
+
+		// Best-guess items length.
+		items = make([]interface{}, 0, count)
+	)
+
+	for _, token := range tokens {
+		apiToken, err := p.converter.TokenToAPITokenInfo(ctx, token)
+		if err != nil {
+			log.Errorf(ctx, "error converting token to api token: %v", err)
+			continue
+		}
+
+		// Append token to return items.
+		items = append(items, apiToken)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/tokens",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// TokenGet returns one access token
+// owned by the given user, by its ID.
+func (p *Processor) TokenGet(
+	ctx context.Context,
+	userID string,
+	tokenID string,
+) (*apimodel.TokenInfo, gtserror.WithCode) {
+	token, errWithCode := p.getOwnToken(ctx, userID, tokenID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiToken, err := p.converter.TokenToAPITokenInfo(ctx, token)
+	if err != nil {
+		err := gtserror.Newf("error converting token to api token: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiToken, nil
+}
+
+// TokenInvalidate invalidates (deletes) one access
+// token owned by the given user, returning its info.
+func (p *Processor) TokenInvalidate(
+	ctx context.Context,
+	userID string,
+	tokenID string,
+) (*apimodel.TokenInfo, gtserror.WithCode) {
+	token, errWithCode := p.getOwnToken(ctx, userID, tokenID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert before deleting, as
+	// we want to return the info.
+	apiToken, err := p.converter.TokenToAPITokenInfo(ctx, token)
+	if err != nil {
+		err := gtserror.Newf("error converting token to api token: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteTokenByID(ctx, token.ID); err != nil {
+		err := gtserror.Newf("db error deleting token: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiToken, nil
+}
+
+// TokensInvalidateByApp invalidates (deletes) all access tokens
+// owned by the given user that were issued to the given app.
+// This signs the user out of every session they have in that app.
+func (p *Processor) TokensInvalidateByApp(
+	ctx context.Context,
+	userID string,
+	appID string,
+) gtserror.WithCode {
+	app, err := p.state.DB.GetApplicationByID(ctx, appID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting app: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if app == nil {
+		err := gtserror.Newf("app %s not found", appID)
+		return gtserror.NewErrorNotFound(err)
+	}
+
+	// Users rarely have more than a handful of
+	// tokens, so just fetch all of them and pick
+	// out the ones that belong to this app.
+	tokens, err := p.state.DB.GetAccessTokens(ctx, userID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tokens: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	for _, token := range tokens {
+		if token.ClientID != app.ClientID {
+			continue
+		}
+
+		if err := p.state.DB.DeleteTokenByID(ctx, token.ID); err != nil {
+			err := gtserror.Newf("db error deleting token: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
+
+// getOwnToken gets the token with the given ID, returning
+// 404 if it doesn't exist, or isn't owned by the given user.
+func (p *Processor) getOwnToken(
+	ctx context.Context,
+	userID string,
+	tokenID string,
+) (*gtsmodel.Token, gtserror.WithCode) {
+	token, err := p.state.DB.GetTokenByID(ctx, tokenID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting token: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if token == nil || token.Access == "" {
+		err := gtserror.Newf("token %s not found", tokenID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if token.UserID != userID {
+		// Don't reveal that the token exists.
+		err := gtserror.Newf("token %s not owned by user %s", tokenID, userID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return token, nil
+}
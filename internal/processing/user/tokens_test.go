@@ -0,0 +1,142 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+type TokensTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *TokensTestSuite) TestTokensGet() {
+	var (
+		ctx  = context.Background()
+		user = suite.testUsers["local_account_1"]
+	)
+
+	resp, errWithCode := suite.user.TokensGet(ctx, user.ID, nil)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	ids := make([]string, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		token := item.(*apimodel.TokenInfo)
+		suite.Equal("really cool gts application", token.Application.Name)
+		suite.Equal(suite.testApplications["application_1"].ID, token.Application.ID)
+		ids = append(ids, token.ID)
+	}
+
+	// Access token should be included, but the
+	// authorization code token should not be.
+	suite.Contains(ids, suite.testTokens["local_account_1"].ID)
+	suite.NotContains(ids, suite.testTokens["local_account_1_user_authorization_token"].ID)
+}
+
+func (suite *TokensTestSuite) TestTokenGetOtherUser() {
+	var (
+		ctx   = context.Background()
+		user  = suite.testUsers["local_account_1"]
+		token = suite.testTokens["local_account_2"]
+	)
+
+	_, errWithCode := suite.user.TokenGet(ctx, user.ID, token.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *TokensTestSuite) TestTokenInvalidate() {
+	var (
+		ctx   = context.Background()
+		user  = suite.testUsers["local_account_1"]
+		token = suite.testTokens["local_account_1"]
+	)
+
+	apiToken, errWithCode := suite.user.TokenInvalidate(ctx, user.ID, token.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(token.ID, apiToken.ID)
+	suite.Equal(token.Scope, apiToken.Scope)
+
+	// Token should be gone now.
+	_, err := suite.db.GetTokenByID(ctx, token.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *TokensTestSuite) TestTokenInvalidateOtherUser() {
+	var (
+		ctx   = context.Background()
+		user  = suite.testUsers["local_account_1"]
+		token = suite.testTokens["local_account_2"]
+	)
+
+	_, errWithCode := suite.user.TokenInvalidate(ctx, user.ID, token.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Token should still be there.
+	_, err := suite.db.GetTokenByID(ctx, token.ID)
+	suite.NoError(err)
+}
+
+func (suite *TokensTestSuite) TestTokensInvalidateByApp() {
+	var (
+		ctx  = context.Background()
+		user = suite.testUsers["local_account_1"]
+		app  = suite.testApplications["application_1"]
+	)
+
+	if errWithCode := suite.user.TokensInvalidateByApp(ctx, user.ID, app.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// User should have no tokens left for this app.
+	tokens, err := suite.db.GetAccessTokens(ctx, user.ID, nil)
+	if err != nil {
+		suite.ErrorIs(err, db.ErrNoEntries)
+	}
+	for _, token := range tokens {
+		suite.NotEqual(app.ClientID, token.ClientID)
+	}
+
+	// Client token (not owned by
+	// any user) should still be there.
+	_, err = suite.db.GetTokenByID(ctx, suite.testTokens["local_account_1_client_application_token"].ID)
+	suite.NoError(err)
+}
+
+func (suite *TokensTestSuite) TestTokensInvalidateByAppNotFound() {
+	var (
+		ctx  = context.Background()
+		user = suite.testUsers["local_account_1"]
+	)
+
+	errWithCode := suite.user.TokensInvalidateByApp(ctx, user.ID, "01J9ZZZZZZZZZZZZZZZZZZZZZZ")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestTokensTestSuite(t *testing.T) {
+	suite.Run(t, new(TokensTestSuite))
+}
@@ -0,0 +1,406 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // #nosec G505 -- SHA1 is what authenticator apps expect for TOTP.
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image/png"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/qr"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// TOTP parameters, as per RFC 6238. These are the
+	// defaults expected by pretty much every authenticator
+	// app, so don't change them without good reason.
+	totpPeriod = 30 * time.Second
+	totpDigits = 6
+	totpSkew   = 1 // Accept codes from one period either side of now.
+
+	// Length in bytes of generated TOTP secrets.
+	twoFactorSecretLen = 20
+
+	// Width and height in pixels
+	// of generated QR code images.
+	twoFactorQRCodeSize = 256
+
+	// Number and length (in characters)
+	// of generated 2FA recovery codes.
+	twoFactorBackupCount = 8
+	twoFactorBackupLen   = 10
+)
+
+// twoFactorEncoding is used to encode TOTP secrets,
+// as expected in the "secret" param of otpauth URIs.
+var twoFactorEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TwoFactorQRCodeURICreate generates and stores a new TOTP secret
+// for the given user, and returns an otpauth:// URI for it, which
+// can be added to an authenticator app in order to enrol in two
+// factor authentication. Any secret from a previous, unfinished
+// enrolment is replaced, so each enrolment starts with a fresh secret.
+//
+// Returns 409 Conflict if 2FA is already enabled for the user.
+func (p *Processor) TwoFactorQRCodeURICreate(
+	ctx context.Context,
+	user *gtsmodel.User,
+) (*url.URL, gtserror.WithCode) {
+	if user.TwoFactorEnabled() {
+		const text = "two factor authentication is already enabled for this user"
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	// Generate a new secret for this user.
+	secret := make([]byte, twoFactorSecretLen)
+	if _, err := rand.Read(secret); err != nil {
+		err := gtserror.Newf("error generating secret: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	user.TwoFactorSecret = twoFactorEncoding.EncodeToString(secret)
+	user.TwoFactorLastStep = 0
+	if err := p.state.DB.UpdateUser(ctx, user,
+		"two_factor_secret",
+		"two_factor_last_step",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.twoFactorURI(ctx, user)
+}
+
+// TwoFactorQRCodePngGet returns a PNG image of a QR code
+// encoding the otpauth:// URI for the TOTP secret generated
+// by the last call to TwoFactorQRCodeURICreate, for scanning
+// with an authenticator app.
+//
+// Returns 409 Conflict if 2FA is already enabled for the user.
+func (p *Processor) TwoFactorQRCodePngGet(
+	ctx context.Context,
+	user *gtsmodel.User,
+) ([]byte, gtserror.WithCode) {
+	if user.TwoFactorEnabled() {
+		const text = "two factor authentication is already enabled for this user"
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	if user.TwoFactorSecret == "" {
+		const text = "no two factor secret set for this user; request a qr code uri first"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	uri, errWithCode := p.twoFactorURI(ctx, user)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	code, err := qr.Encode(uri.String(), qr.M, qr.Auto)
+	if err != nil {
+		err := gtserror.Newf("error encoding qr code: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	code, err = barcode.Scale(code, twoFactorQRCodeSize, twoFactorQRCodeSize)
+	if err != nil {
+		err := gtserror.Newf("error scaling qr code: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, code); err != nil {
+		err := gtserror.Newf("error encoding png: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// twoFactorURI returns the otpauth:// URI
+// for the given user's current TOTP secret.
+func (p *Processor) twoFactorURI(
+	ctx context.Context,
+	user *gtsmodel.User,
+) (*url.URL, gtserror.WithCode) {
+	account := user.Account
+	if account == nil {
+		var err error
+		account, err = p.state.DB.GetAccountByID(ctx, user.AccountID)
+		if err != nil {
+			err := gtserror.Newf("db error getting account for user: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// See https://github.com/google/google-authenticator/wiki/Key-Uri-Format
+	issuer := config.GetHost()
+	return &url.URL{
+		Scheme: "otpauth",
+		Host:   "totp",
+		Path:   "/" + issuer + ":" + account.Username + "@" + config.GetAccountDomain(),
+		RawQuery: url.Values{
+			"secret":    {user.TwoFactorSecret},
+			"issuer":    {issuer},
+			"algorithm": {"SHA1"},
+			"digits":    {fmt.Sprint(totpDigits)},
+			"period":    {fmt.Sprint(int(totpPeriod.Seconds()))},
+		}.Encode(),
+	}, nil
+}
+
+// TwoFactorEnable enables two factor authentication for the
+// given user after verifying their password, if the provided
+// code is valid for the secret previously generated by
+// TwoFactorQRCodeURICreate.
+//
+// Returns a slice of one-time recovery codes, which may be used
+// in place of a TOTP code at sign-in if the user loses access to
+// their authenticator app. These are shown to the user only once.
+func (p *Processor) TwoFactorEnable(
+	ctx context.Context,
+	user *gtsmodel.User,
+	password string,
+	code string,
+) ([]string, gtserror.WithCode) {
+	if user.TwoFactorEnabled() {
+		const text = "two factor authentication is already enabled for this user"
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
+		err := gtserror.Newf("%w", err)
+		return nil, gtserror.NewErrorUnauthorized(err, "password was incorrect")
+	}
+
+	if user.TwoFactorSecret == "" {
+		const text = "no two factor secret set for this user; request a qr code uri first"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	step, ok := totpValid(user.TwoFactorSecret, code, time.Now(), user.TwoFactorLastStep)
+	if !ok {
+		const text = "invalid two factor code"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	backups := make([]string, twoFactorBackupCount)
+	hashes := make([]string, twoFactorBackupCount)
+	for i := range backups {
+		backup, err := newBackupCode()
+		if err != nil {
+			err := gtserror.Newf("error generating recovery code: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(backup), bcrypt.DefaultCost)
+		if err != nil {
+			err := gtserror.Newf("error hashing recovery code: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		backups[i] = backup
+		hashes[i] = string(hash)
+	}
+
+	user.TwoFactorBackups = hashes
+	user.TwoFactorEnabledAt = time.Now()
+	user.TwoFactorLastStep = step
+	if err := p.state.DB.UpdateUser(ctx, user,
+		"two_factor_backups",
+		"two_factor_enabled_at",
+		"two_factor_last_step",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return backups, nil
+}
+
+// TwoFactorDisable disables two factor authentication
+// for the given user, after verifying their password.
+func (p *Processor) TwoFactorDisable(
+	ctx context.Context,
+	user *gtsmodel.User,
+	password string,
+) gtserror.WithCode {
+	if !user.TwoFactorEnabled() {
+		const text = "two factor authentication is not enabled for this user"
+		return gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
+		err := gtserror.Newf("%w", err)
+		return gtserror.NewErrorUnauthorized(err, "password was incorrect")
+	}
+
+	if err := p.TwoFactorReset(ctx, user); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// TwoFactorReset unconditionally clears all two
+// factor authentication data for the given user.
+func (p *Processor) TwoFactorReset(ctx context.Context, user *gtsmodel.User) error {
+	user.TwoFactorSecret = ""
+	user.TwoFactorBackups = nil
+	user.TwoFactorEnabledAt = time.Time{}
+	user.TwoFactorLastStep = 0
+	if err := p.state.DB.UpdateUser(ctx, user,
+		"two_factor_secret",
+		"two_factor_backups",
+		"two_factor_enabled_at",
+		"two_factor_last_step",
+	); err != nil {
+		return gtserror.Newf("db error updating user: %w", err)
+	}
+
+	return nil
+}
+
+// TwoFactorCheck checks the given code for a user with 2FA
+// enabled, as the second step of signing in. The code may be
+// either a TOTP code, or one of the user's recovery codes, in
+// which case that recovery code is used up.
+func (p *Processor) TwoFactorCheck(
+	ctx context.Context,
+	user *gtsmodel.User,
+	code string,
+) gtserror.WithCode {
+	if !user.TwoFactorEnabled() {
+		const text = "two factor authentication is not enabled for this user"
+		return gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Be lenient about how people
+	// copy + paste or type codes.
+	code = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(code))
+
+	if len(code) == totpDigits {
+		step, ok := totpValid(user.TwoFactorSecret, code, time.Now(), user.TwoFactorLastStep)
+		if ok {
+			// Mark this step as used, so the
+			// same code can't be used again.
+			user.TwoFactorLastStep = step
+			if err := p.state.DB.UpdateUser(ctx, user, "two_factor_last_step"); err != nil {
+				err := gtserror.Newf("db error updating user: %w", err)
+				return gtserror.NewErrorInternalError(err)
+			}
+			return nil
+		}
+	} else {
+		for i, hash := range user.TwoFactorBackups {
+			if bcrypt.CompareHashAndPassword([]byte(hash), []byte(strings.ToLower(code))) != nil {
+				continue
+			}
+
+			// Valid recovery code, it can't be used again.
+			user.TwoFactorBackups = append(user.TwoFactorBackups[:i:i], user.TwoFactorBackups[i+1:]...)
+			if err := p.state.DB.UpdateUser(ctx, user, "two_factor_backups"); err != nil {
+				err := gtserror.Newf("db error updating user: %w", err)
+				return gtserror.NewErrorInternalError(err)
+			}
+			return nil
+		}
+	}
+
+	const text = "invalid two factor code"
+	return gtserror.NewErrorForbidden(errors.New(text), text)
+}
+
+// totpValid returns the matching time step and true if
+// code is a valid TOTP code for the base32 encoded secret
+// at time now, allowing for some clock skew either side.
+// Steps at or before lastStep are rejected, so that an
+// accepted code can't be replayed within the skew window.
+func totpValid(secret string, code string, now time.Time, lastStep int64) (int64, bool) {
+	key, err := twoFactorEncoding.DecodeString(secret)
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+
+	counter := now.Unix() / int64(totpPeriod.Seconds())
+	for i := -totpSkew; i <= totpSkew; i++ {
+		step := counter + int64(i)
+		if step <= lastStep {
+			continue
+		}
+
+		expect := totpCode(key, uint64(step))
+		if subtle.ConstantTimeCompare([]byte(expect), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// totpCode generates the TOTP code for the given
+// key and counter, as per RFC 4226 section 5.3.
+func totpCode(key []byte, counter uint64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	bin := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, bin%1000000)
+}
+
+// newBackupCode returns a new random
+// lowercase alphanumeric recovery code.
+func newBackupCode() (string, error) {
+	const alphabet = "abcdefghijkmnpqrstuvwxyz23456789" // No confusables.
+
+	b := make([]byte, twoFactorBackupLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	for i := range b {
+		b[i] = alphabet[int(b[i])%len(alphabet)]
+	}
+	return string(b), nil
+}
@@ -0,0 +1,217 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"image/png"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type TwoFactorTestSuite struct {
+	UserStandardTestSuite
+}
+
+// totp returns the TOTP code at the given time for
+// the given otpauth uri, as an authenticator app would.
+func (suite *TwoFactorTestSuite) totp(uri *url.URL, at time.Time) string {
+	secret := uri.Query().Get("secret")
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(at.Unix()/30))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	bin := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", bin%1000000)
+}
+
+// enable enables 2FA for the given user,
+// returning the uri and recovery codes.
+func (suite *TwoFactorTestSuite) enable(user *gtsmodel.User) (*url.URL, []string) {
+	ctx := context.Background()
+
+	uri, errWithCode := suite.user.TwoFactorQRCodeURICreate(ctx, user)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Enable with the previous code, leaving
+	// the current one free for signing in.
+	code := suite.totp(uri, time.Now().Add(-30*time.Second))
+	backups, errWithCode := suite.user.TwoFactorEnable(ctx, user, "password", code)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	return uri, backups
+}
+
+func (suite *TwoFactorTestSuite) TestQRCodeURI() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	// No secret yet, so no QR code.
+	_, errWithCode := suite.user.TwoFactorQRCodePngGet(ctx, user)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	uri, errWithCode := suite.user.TwoFactorQRCodeURICreate(ctx, user)
+	suite.NoError(errWithCode)
+	suite.Equal("otpauth", uri.Scheme)
+	suite.Equal("totp", uri.Host)
+	suite.Equal("/localhost:8080:the_mighty_zork@localhost:8080", uri.Path)
+	suite.Equal("localhost:8080", uri.Query().Get("issuer"))
+	suite.Len(uri.Query().Get("secret"), 32)
+
+	// Secret should now be stored on the user.
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	suite.NoError(err)
+	suite.Equal(uri.Query().Get("secret"), dbUser.TwoFactorSecret)
+	suite.False(dbUser.TwoFactorEnabled())
+
+	// QR code should be a png for the stored secret.
+	b, errWithCode := suite.user.TwoFactorQRCodePngGet(ctx, dbUser)
+	suite.NoError(errWithCode)
+	img, err := png.Decode(bytes.NewReader(b))
+	suite.NoError(err)
+	suite.Equal(256, img.Bounds().Dx())
+
+	// Asking again should give a new secret.
+	uri2, errWithCode := suite.user.TwoFactorQRCodeURICreate(ctx, dbUser)
+	suite.NoError(errWithCode)
+	suite.NotEqual(uri.Query().Get("secret"), uri2.Query().Get("secret"))
+}
+
+func (suite *TwoFactorTestSuite) TestEnableBadCode() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	// No secret yet.
+	_, errWithCode := suite.user.TwoFactorEnable(ctx, user, "password", "123456")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	uri, errWithCode := suite.user.TwoFactorQRCodeURICreate(ctx, user)
+	suite.NoError(errWithCode)
+
+	// Wrong code.
+	_, errWithCode = suite.user.TwoFactorEnable(ctx, user, "password", "not a code")
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+	suite.False(user.TwoFactorEnabled())
+
+	// Right code, wrong password.
+	code := suite.totp(uri, time.Now())
+	_, errWithCode = suite.user.TwoFactorEnable(ctx, user, "nope", code)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+	suite.False(user.TwoFactorEnabled())
+}
+
+func (suite *TwoFactorTestSuite) TestEnableCheckDisable() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	uri, backups := suite.enable(user)
+	suite.Len(backups, 8)
+
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	suite.NoError(err)
+	suite.True(dbUser.TwoFactorEnabled())
+	suite.Len(dbUser.TwoFactorBackups, 8)
+
+	// Can't get a new qr code now.
+	_, errWithCode := suite.user.TwoFactorQRCodeURICreate(ctx, dbUser)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// Current code should be accepted at sign in...
+	code := suite.totp(uri, time.Now())
+	suite.NoError(suite.user.TwoFactorCheck(ctx, dbUser, code))
+
+	// ...but not replayed, even from the db.
+	errWithCode = suite.user.TwoFactorCheck(ctx, dbUser, code)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	dbUser, err = suite.db.GetUserByID(ctx, user.ID)
+	suite.NoError(err)
+	errWithCode = suite.user.TwoFactorCheck(ctx, dbUser, code)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// Nor can an older code be used.
+	errWithCode = suite.user.TwoFactorCheck(ctx, dbUser, suite.totp(uri, time.Now().Add(-30*time.Second)))
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// Rubbish should not.
+	errWithCode = suite.user.TwoFactorCheck(ctx, dbUser, "000000000")
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// Disabling needs the right password.
+	errWithCode = suite.user.TwoFactorDisable(ctx, dbUser, "nope")
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	suite.NoError(suite.user.TwoFactorDisable(ctx, dbUser, "password"))
+
+	dbUser, err = suite.db.GetUserByID(ctx, user.ID)
+	suite.NoError(err)
+	suite.False(dbUser.TwoFactorEnabled())
+	suite.Empty(dbUser.TwoFactorSecret)
+	suite.Empty(dbUser.TwoFactorBackups)
+}
+
+func (suite *TwoFactorTestSuite) TestRecoveryCode() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	_, backups := suite.enable(user)
+
+	// Recovery code should work once,
+	// however the user formats it...
+	code := backups[3]
+	suite.NoError(suite.user.TwoFactorCheck(ctx, user, " "+code[:5]+"-"+code[5:]+" "))
+
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	suite.NoError(err)
+	suite.Len(dbUser.TwoFactorBackups, 7)
+
+	// ...but not twice.
+	errWithCode := suite.user.TwoFactorCheck(ctx, dbUser, code)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// Other codes are still fine.
+	suite.NoError(suite.user.TwoFactorCheck(ctx, dbUser, backups[0]))
+}
+
+func TestTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, &TwoFactorTestSuite{})
+}
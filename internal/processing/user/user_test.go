@@ -34,7 +34,9 @@ type UserStandardTestSuite struct {
 	db          db.DB
 	state       state.State
 
-	testUsers map[string]*gtsmodel.User
+	testUsers        map[string]*gtsmodel.User
+	testTokens       map[string]*gtsmodel.Token
+	testApplications map[string]*gtsmodel.Application
 
 	sentEmails map[string]string
 
@@ -53,6 +55,8 @@ func (suite *UserStandardTestSuite) SetupTest() {
 	suite.sentEmails = make(map[string]string)
 	suite.emailSender = testrig.NewEmailSender("../../../web/template/", suite.sentEmails)
 	suite.testUsers = testrig.NewTestUsers()
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testApplications = testrig.NewTestApplications()
 
 	suite.user = user.New(&suite.state, typeutils.NewConverter(&suite.state), testrig.NewTestOauthServer(suite.db), suite.emailSender)
 
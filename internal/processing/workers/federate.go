@@ -168,14 +168,19 @@ func (f *federate) CreateStatus(ctx context.Context, status *gtsmodel.Status) er
 	}
 
 	// If status is pending approval,
-	// it must be a reply. Deliver it
-	// **ONLY** to the account it replies
-	// to, on behalf of the replier.
+	// it must be a reply or quote. Deliver
+	// it **ONLY** to the account it replies
+	// to or quotes, on behalf of the author.
 	if util.PtrOrValue(status.PendingApproval, false) {
+		interactee := status.InReplyToAccount
+		if status.QuotePendingApproval {
+			interactee = status.QuoteAccount
+		}
+
 		return f.deliverToInboxOnly(
 			ctx,
 			status.Account,
-			status.InReplyToAccount,
+			interactee,
 			// Status has to be wrapped in Create activity.
 			typeutils.WrapStatusableInCreate(statusable, false),
 		)
@@ -192,7 +197,9 @@ func (f *federate) CreateStatus(ctx context.Context, status *gtsmodel.Status) er
 	if _, err := f.FederatingActor().Send(ctx, outboxIRI, create); err != nil {
 		return gtserror.Newf("error sending Create activity via outbox %s: %w", outboxIRI, err)
 	}
-	return nil
+
+	// Forward the Create to any relays.
+	return f.deliverToRelays(ctx, status, create)
 }
 
 func (f *federate) CreatePollVote(ctx context.Context, poll *gtsmodel.Poll, vote *gtsmodel.PollVote) error {
@@ -266,7 +273,9 @@ func (f *federate) DeleteStatus(ctx context.Context, status *gtsmodel.Status) er
 		)
 	}
 
-	return nil
+	// Forward the Delete to any relays
+	// the status may have been sent to.
+	return f.deliverToRelays(ctx, status, delete)
 }
 
 func (f *federate) UpdateStatus(ctx context.Context, status *gtsmodel.Status) error {
@@ -473,6 +482,65 @@ func (f *federate) UndoLike(ctx context.Context, fave *gtsmodel.StatusFave) erro
 	return nil
 }
 
+func (f *federate) UndoReaction(ctx context.Context, reaction *gtsmodel.StatusReaction) error {
+	// Populate model.
+	if err := f.state.DB.PopulateStatusReaction(ctx, reaction); err != nil {
+		return gtserror.Newf("error populating reaction: %w", err)
+	}
+
+	// Do nothing if both accounts are local.
+	if reaction.Account.IsLocal() &&
+		reaction.TargetAccount.IsLocal() {
+		return nil
+	}
+
+	// Parse relevant URI(s).
+	outboxIRI, err := parseURI(reaction.Account.OutboxURI)
+	if err != nil {
+		return err
+	}
+
+	targetAccountIRI, err := parseURI(reaction.TargetAccount.URI)
+	if err != nil {
+		return err
+	}
+
+	// Recreate the ActivityStreams Like.
+	like, err := f.converter.ReactionToAS(ctx, reaction)
+	if err != nil {
+		return gtserror.Newf("error converting reaction to AS: %w", err)
+	}
+
+	// Create a new Undo.
+	undo := streams.NewActivityStreamsUndo()
+
+	// Set the Actor for the Undo:
+	// same as the actor for the Like.
+	undo.SetActivityStreamsActor(like.GetActivityStreamsActor())
+
+	// Set recreated Like as the 'object' property.
+	undoObject := streams.NewActivityStreamsObjectProperty()
+	undoObject.AppendActivityStreamsLike(like)
+	undo.SetActivityStreamsObject(undoObject)
+
+	// Address the Undo To the target account.
+	undoTo := streams.NewActivityStreamsToProperty()
+	undoTo.AppendIRI(targetAccountIRI)
+	undo.SetActivityStreamsTo(undoTo)
+
+	// Send the Undo via the Actor's outbox.
+	if _, err := f.FederatingActor().Send(
+		ctx, outboxIRI, undo,
+	); err != nil {
+		return gtserror.Newf(
+			"error sending activity %T via outbox %s: %w",
+			undo, outboxIRI, err,
+		)
+	}
+
+	return nil
+}
+
 func (f *federate) UndoAnnounce(ctx context.Context, boost *gtsmodel.Status) error {
 	// Populate model.
 	if err := f.state.DB.PopulateStatus(ctx, boost); err != nil {
@@ -749,6 +817,45 @@ func (f *federate) Like(ctx context.Context, fave *gtsmodel.StatusFave) error {
 	return nil
 }
 
+// React sends the given emoji reaction out to relevant
+// recipients with the Outbox of the reacting account.
+func (f *federate) React(ctx context.Context, reaction *gtsmodel.StatusReaction) error {
+	// Populate model.
+	if err := f.state.DB.PopulateStatusReaction(ctx, reaction); err != nil {
+		return gtserror.Newf("error populating reaction: %w", err)
+	}
+
+	// Do nothing if both accounts are local.
+	if reaction.Account.IsLocal() &&
+		reaction.TargetAccount.IsLocal() {
+		return nil
+	}
+
+	// Create the ActivityStreams Like.
+	like, err := f.converter.ReactionToAS(ctx, reaction)
+	if err != nil {
+		return gtserror.Newf("error converting reaction to AS Like: %w", err)
+	}
+
+	// Parse relevant URI(s).
+	outboxIRI, err := parseURI(reaction.Account.OutboxURI)
+	if err != nil {
+		return err
+	}
+
+	// Send the Like via the Actor's outbox.
+	if _, err := f.FederatingActor().Send(
+		ctx, outboxIRI, like,
+	); err != nil {
+		return gtserror.Newf(
+			"error sending activity %T via outbox %s: %w",
+			like, outboxIRI, err,
+		)
+	}
+
+	return nil
+}
+
 // Announce sends the given boost out to relevant
 // recipients with the Outbox of the status creator.
 //
@@ -860,6 +967,73 @@ func (f *federate) deliverToInboxOnly(
 	return nil
 }
 
+// deliverToRelays delivers the given activity
+// **ONLY** to the inboxes of accepted relays which
+// we forward to, on behalf of the status author,
+// if the given status is public.
+func (f *federate) deliverToRelays(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	t vocab.Type,
+) error {
+	if status.Visibility != gtsmodel.VisibilityPublic {
+		// Only public
+		// posts are relayed.
+		return nil
+	}
+
+	relays, err := f.state.DB.GetRelays(ctx)
+	if err != nil {
+		return gtserror.Newf("db error getting relays: %w", err)
+	}
+
+	var inboxes []*url.URL
+	for _, relay := range relays {
+		if relay.State != gtsmodel.RelayStateAccepted ||
+			!util.PtrOrValue(relay.Forward, true) {
+			continue
+		}
+
+		inbox, err := parseURI(relay.InboxURI)
+		if err != nil {
+			return err
+		}
+
+		inboxes = append(inboxes, inbox)
+	}
+
+	if len(inboxes) == 0 {
+		// No relays
+		// to forward to.
+		return nil
+	}
+
+	tsport, err := f.TransportController().NewTransportForUsername(
+		ctx,
+		status.Account.Username,
+	)
+	if err != nil {
+		return gtserror.Newf(
+			"error getting transport to deliver activity %T to relays: %w",
+			t, err,
+		)
+	}
+
+	m, err := ap.Serialize(t)
+	if err != nil {
+		return err
+	}
+
+	if err := tsport.BatchDeliver(ctx, m, inboxes); err != nil {
+		return gtserror.Newf(
+			"error delivering activity %T to relays: %w",
+			t, err,
+		)
+	}
+
+	return nil
+}
+
 func (f *federate) UpdateAccount(ctx context.Context, account *gtsmodel.Account) error {
 	// Populate model.
 	if err := f.state.DB.PopulateAccount(ctx, account); err != nil {
@@ -99,6 +99,10 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		case ap.ActivityLike:
 			return p.clientAPI.CreateLike(ctx, cMsg)
 
+		// CREATE EMOJI REACTION
+		case ap.ActivityEmojiReact:
+			return p.clientAPI.CreateReaction(ctx, cMsg)
+
 		// CREATE ANNOUNCE/BOOST
 		case ap.ActivityAnnounce:
 			return p.clientAPI.CreateAnnounce(ctx, cMsg)
@@ -141,7 +145,7 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		case ap.ObjectProfile:
 			return p.clientAPI.AcceptUser(ctx, cMsg)
 
-		// ACCEPT NOTE/STATUS (ie., accept a reply)
+		// ACCEPT NOTE/STATUS (ie., accept a reply or quote)
 		case ap.ObjectNote:
 			return p.clientAPI.AcceptReply(ctx, cMsg)
 
@@ -166,7 +170,7 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		case ap.ObjectProfile:
 			return p.clientAPI.RejectUser(ctx, cMsg)
 
-		// REJECT NOTE/STATUS (ie., reject a reply)
+		// REJECT NOTE/STATUS (ie., reject a reply or quote)
 		case ap.ObjectNote:
 			return p.clientAPI.RejectReply(ctx, cMsg)
 
@@ -195,6 +199,10 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		case ap.ActivityLike:
 			return p.clientAPI.UndoFave(ctx, cMsg)
 
+		// UNDO EMOJI REACTION
+		case ap.ActivityEmojiReact:
+			return p.clientAPI.UndoReaction(ctx, cMsg)
+
 		// UNDO ANNOUNCE/BOOST
 		case ap.ActivityAnnounce:
 			return p.clientAPI.UndoAnnounce(ctx, cMsg)
@@ -266,8 +274,9 @@ func (p *clientAPI) CreateStatus(ctx context.Context, cMsg *messages.FromClientA
 	}
 
 	// If pending approval is true then status must
-	// reply to a status (either one of ours or a
-	// remote) that requires approval for the reply.
+	// reply to or quote a status (either one of ours
+	// or a remote) that requires approval for the
+	// reply or quote.
 	pendingApproval := util.PtrOrZero(status.PendingApproval)
 
 	switch {
@@ -278,8 +287,14 @@ func (p *clientAPI) CreateStatus(ctx context.Context, cMsg *messages.FromClientA
 		// and/or notify the account that's being
 		// interacted with (if it's local): they can
 		// approve or deny the interaction later.
-		if err := p.utils.requestReply(ctx, status); err != nil {
-			return gtserror.Newf("error pending reply: %w", err)
+		if status.QuotePendingApproval {
+			if err := p.utils.requestQuote(ctx, status); err != nil {
+				return gtserror.Newf("error pending quote: %w", err)
+			}
+		} else {
+			if err := p.utils.requestReply(ctx, status); err != nil {
+				return gtserror.Newf("error pending reply: %w", err)
+			}
 		}
 
 		// Send Create to *remote* account inbox ONLY.
@@ -315,6 +330,17 @@ func (p *clientAPI) CreateStatus(ctx context.Context, cMsg *messages.FromClientA
 			URI:                  uris.GenerateURIForAccept(status.InReplyToAccount.Username, id),
 			AcceptedAt:           time.Now(),
 		}
+		if status.QuotePendingApproval {
+			// Interaction is a quote
+			// of one of our statuses.
+			approval.StatusID = status.QuoteID
+			approval.TargetAccountID = status.QuoteAccountID
+			approval.TargetAccount = status.QuoteAccount
+			approval.InteractionType = gtsmodel.InteractionQuote
+			approval.Reply = nil
+			approval.Quote = status
+			approval.URI = uris.GenerateURIForAccept(status.QuoteAccount.Username, id)
+		}
 		if err := p.state.DB.PutInteractionRequest(ctx, approval); err != nil {
 			return gtserror.Newf("db error putting pre-approved interaction request: %w", err)
 		}
@@ -554,6 +580,32 @@ func (p *clientAPI) CreateLike(ctx context.Context, cMsg *messages.FromClientAPI
 	return nil
 }
 
+func (p *clientAPI) CreateReaction(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	reaction, ok := cMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.StatusReaction", cMsg.GTSModel)
+	}
+
+	// Ensure reaction populated.
+	if err := p.state.DB.PopulateStatusReaction(ctx, reaction); err != nil {
+		return gtserror.Newf("error populating status reaction: %w", err)
+	}
+
+	if err := p.surface.notifyReaction(ctx, reaction); err != nil {
+		log.Errorf(ctx, "error notifying reaction: %v", err)
+	}
+
+	if err := p.federate.React(ctx, reaction); err != nil {
+		log.Errorf(ctx, "error federating reaction: %v", err)
+	}
+
+	// Interaction counts changed on the reacted-to status;
+	// uncache the prepared version from all timelines.
+	p.surface.invalidateStatusFromTimelines(ctx, reaction.StatusID)
+
+	return nil
+}
+
 func (p *clientAPI) CreateAnnounce(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	boost, ok := cMsg.GTSModel.(*gtsmodel.Status)
 	if !ok {
@@ -715,6 +767,11 @@ func (p *clientAPI) UpdateStatus(ctx context.Context, cMsg *messages.FromClientA
 		}
 	}
 
+	// Notify any accounts newly mentioned by the edit.
+	if err := p.surface.notifyNewMentions(ctx, status); err != nil {
+		log.Errorf(ctx, "error notifying status mentions: %v", err)
+	}
+
 	// Push message that the status has been edited to streams.
 	if err := p.surface.timelineStatusUpdate(ctx, status); err != nil {
 		log.Errorf(ctx, "error streaming status edit: %v", err)
@@ -879,6 +936,23 @@ func (p *clientAPI) UndoFave(ctx context.Context, cMsg *messages.FromClientAPI)
 	return nil
 }
 
+func (p *clientAPI) UndoReaction(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	reaction, ok := cMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.StatusReaction", cMsg.GTSModel)
+	}
+
+	if err := p.federate.UndoReaction(ctx, reaction); err != nil {
+		log.Errorf(ctx, "error federating reaction undo: %v", err)
+	}
+
+	// Interaction counts changed on the reacted-to status;
+	// uncache the prepared version from all timelines.
+	p.surface.invalidateStatusFromTimelines(ctx, reaction.StatusID)
+
+	return nil
+}
+
 func (p *clientAPI) UndoAnnounce(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	status, ok := cMsg.GTSModel.(*gtsmodel.Status)
 	if !ok {
@@ -1166,9 +1240,20 @@ func (p *clientAPI) AcceptReply(ctx context.Context, cMsg *messages.FromClientAP
 
 	var (
 		interactingAcct = req.InteractingAccount
-		reply           = req.Reply
+		reply           *gtsmodel.Status
+		interactedID    string
 	)
 
+	if req.InteractionType == gtsmodel.InteractionQuote {
+		// Accepted status is
+		// a quote, not a reply.
+		reply = req.Quote
+		interactedID = reply.QuoteID
+	} else {
+		reply = req.Reply
+		interactedID = reply.InReplyToID
+	}
+
 	// Update stats for the reply author account.
 	if err := p.utils.incrementStatusesCount(ctx, interactingAcct, reply); err != nil {
 		log.Errorf(ctx, "error updating account stats: %v", err)
@@ -1184,9 +1269,10 @@ func (p *clientAPI) AcceptReply(ctx context.Context, cMsg *messages.FromClientAP
 		log.Errorf(ctx, "error federating approval of reply: %v", err)
 	}
 
-	// Interaction counts changed on the replied status;
-	// uncache the prepared version from all timelines.
-	p.surface.invalidateStatusFromTimelines(ctx, reply.InReplyToID)
+	// Interaction counts changed on the replied (or
+	// quoted) status; uncache the prepared version
+	// from all timelines.
+	p.surface.invalidateStatusFromTimelines(ctx, interactedID)
 
 	return nil
 }
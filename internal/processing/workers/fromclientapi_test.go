@@ -1206,6 +1206,148 @@ func (suite *FromClientAPITestSuite) TestProcessCreateStatusWithFollowedHashtagA
 	)
 }
 
+// A public local status with a hashtag should be streamed to open
+// public, local, and hashtag streams, but not to the remote stream.
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusPublicStreams() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_2"]
+		testTag          = suite.testTags["welcome"]
+		streams          = make(map[string]*stream.Stream)
+	)
+
+	for _, streamType := range []string{
+		stream.TimelinePublic,
+		stream.TimelineLocal,
+		stream.TimelineRemote,
+		stream.TimelineHashtag + ":" + testTag.Name,
+		stream.TimelineHashtagLocal + ":" + testTag.Name,
+	} {
+		str, errWithCode := testStructs.Processor.Stream().Open(ctx, receivingAccount, streamType)
+		if errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+		streams[streamType] = str
+	}
+
+	// postingAccount posts a new public status using testTag.
+	status := suite.newStatus(
+		ctx,
+		testStructs.State,
+		postingAccount,
+		gtsmodel.VisibilityPublic,
+		nil,
+		nil,
+		nil,
+		false,
+		[]string{testTag.ID},
+	)
+
+	// Process the new status.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			Origin:         postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Status should be in each
+	// stream except the remote one.
+	for streamType, str := range streams {
+		if streamType == stream.TimelineRemote {
+			suite.checkStreamed(str, false, "", "")
+			continue
+		}
+
+		suite.checkStreamed(str, true, "", stream.EventTypeUpdate)
+	}
+
+	// Deleting the status should
+	// stream the delete to hashtag
+	// streams too, not just public.
+	testStructs.Processor.Stream().Delete(ctx, status.ID)
+	suite.checkStreamed(
+		streams[stream.TimelineHashtagLocal+":"+testTag.Name],
+		true,
+		status.ID,
+		stream.EventTypeDelete,
+	)
+}
+
+// A public status with a hashtag should not be streamed
+// to the public or hashtag streams of an account that
+// has the author blocked.
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusPublicStreamsBlocked() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_2"]
+		testTag          = suite.testTags["welcome"]
+	)
+
+	publicStream, errWithCode := testStructs.Processor.Stream().Open(ctx, receivingAccount, stream.TimelinePublic)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	tagStream, errWithCode := testStructs.Processor.Stream().Open(ctx, receivingAccount, stream.TimelineHashtag+":"+testTag.Name)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Setup: receivingAccount blocks postingAccount.
+	if err := testStructs.State.DB.PutBlock(ctx, &gtsmodel.Block{
+		ID:              id.NewULID(),
+		URI:             "http://localhost:8080/users/1happyturtle/blocks/01HSCHZB0WCD7F1PDQ5ZZK9F5R",
+		AccountID:       receivingAccount.ID,
+		TargetAccountID: postingAccount.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// postingAccount posts a new public status using testTag.
+	status := suite.newStatus(
+		ctx,
+		testStructs.State,
+		postingAccount,
+		gtsmodel.VisibilityPublic,
+		nil,
+		nil,
+		nil,
+		false,
+		[]string{testTag.ID},
+	)
+
+	// Process the new status.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			Origin:         postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Status should not be in either stream.
+	suite.checkStreamed(publicStream, false, "", "")
+	suite.checkStreamed(tagStream, false, "", "")
+}
+
 // A boost of a public status with a hashtag followed by a local user
 // who does not otherwise follow the author or booster
 // should end up in the tag-following user's home timeline as the original status.
@@ -1912,6 +2054,58 @@ func (suite *FromClientAPITestSuite) TestProcessUpdateStatusWithFollowedHashtag(
 	)
 }
 
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusForwardedToRelays() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	var (
+		ctx          = context.Background()
+		postingAcct  = suite.testAccounts["local_account_1"]
+		relays       = testrig.NewTestRelays()
+		forwardRelay = relays["relay_accepted"]
+		pendingRelay = relays["relay_pending"]
+	)
+
+	status := suite.newStatus(
+		ctx,
+		testStructs.State,
+		postingAcct,
+		gtsmodel.VisibilityPublic,
+		nil,
+		nil,
+		nil,
+		false,
+		nil,
+	)
+
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			Origin:         postingAcct,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Collect the inboxes the Create was delivered to.
+	var inboxes []string
+	testrig.WaitFor(func() bool {
+		delivery, ok := testStructs.State.Workers.Delivery.Queue.Pop()
+		if ok {
+			inboxes = append(inboxes, delivery.Request.URL.String())
+		}
+		return !ok && len(inboxes) > 0
+	})
+
+	// Public status should be forwarded to the
+	// accepted relay, but not the pending one.
+	suite.Contains(inboxes, forwardRelay.InboxURI)
+	suite.NotContains(inboxes, pendingRelay.InboxURI)
+}
+
 func (suite *FromClientAPITestSuite) TestProcessStatusDelete() {
 	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
 	defer testrig.TearDownTestStructs(testStructs)
@@ -30,9 +30,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 
+	"github.com/superseriousbusiness/gotosocial/internal/filter/interaction"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
@@ -44,12 +46,13 @@ import (
 // specifically for messages originating
 // from the federation/ActivityPub API.
 type fediAPI struct {
-	state    *state.State
-	surface  *Surface
-	federate *federate
-	account  *account.Processor
-	common   *common.Processor
-	utils    *utils
+	state     *state.State
+	surface   *Surface
+	federate  *federate
+	intFilter *interaction.Filter
+	account   *account.Processor
+	common    *common.Processor
+	utils     *utils
 }
 
 func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg *messages.FromFediAPI) error {
@@ -95,6 +98,10 @@ func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg *messages.FromF
 		case ap.ActivityLike:
 			return p.fediAPI.CreateLike(ctx, fMsg)
 
+		// CREATE EMOJI REACTION
+		case ap.ActivityEmojiReact:
+			return p.fediAPI.CreateReaction(ctx, fMsg)
+
 		// CREATE ANNOUNCE/BOOST
 		case ap.ActivityAnnounce:
 			return p.fediAPI.CreateAnnounce(ctx, fMsg)
@@ -251,8 +258,9 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 	}
 
 	// If pending approval is true then
-	// status must reply to a LOCAL status
-	// that requires approval for the reply.
+	// status must reply to or quote a LOCAL
+	// status that requires approval for
+	// the reply or quote.
 	pendingApproval := util.PtrOrZero(status.PendingApproval)
 
 	switch {
@@ -261,8 +269,14 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		// preapproved, then just notify the account
 		// that's being interacted with: they can
 		// approve or deny the interaction later.
-		if err := p.utils.requestReply(ctx, status); err != nil {
-			return gtserror.Newf("error pending reply: %w", err)
+		if status.QuotePendingApproval {
+			if err := p.utils.requestQuote(ctx, status); err != nil {
+				return gtserror.Newf("error pending quote: %w", err)
+			}
+		} else {
+			if err := p.utils.requestReply(ctx, status); err != nil {
+				return gtserror.Newf("error pending reply: %w", err)
+			}
 		}
 
 		// Return early.
@@ -291,6 +305,17 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 			URI:                  uris.GenerateURIForAccept(status.InReplyToAccount.Username, id),
 			AcceptedAt:           time.Now(),
 		}
+		if status.QuotePendingApproval {
+			// Interaction is a quote
+			// of one of our statuses.
+			approval.StatusID = status.QuoteID
+			approval.TargetAccountID = status.QuoteAccountID
+			approval.TargetAccount = status.QuoteAccount
+			approval.InteractionType = gtsmodel.InteractionQuote
+			approval.Reply = nil
+			approval.Quote = status
+			approval.URI = uris.GenerateURIForAccept(status.QuoteAccount.Username, id)
+		}
 		if err := p.state.DB.PutInteractionRequest(ctx, approval); err != nil {
 			return gtserror.Newf("db error putting pre-approved interaction request: %w", err)
 		}
@@ -316,8 +341,10 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		// Don't return, just continue as normal.
 	}
 
-	// Update stats for the remote account.
-	if err := p.utils.incrementStatusesCount(ctx, fMsg.Requesting, status); err != nil {
+	// Update stats for the remote account. This is the
+	// status author rather than the requester, which
+	// may differ for forwarded and relayed statuses.
+	if err := p.utils.incrementStatusesCount(ctx, status.Account, status); err != nil {
 		log.Errorf(ctx, "error updating account stats: %v", err)
 	}
 
@@ -525,6 +552,77 @@ func (p *fediAPI) CreateLike(ctx context.Context, fMsg *messages.FromFediAPI) er
 	return nil
 }
 
+func (p *fediAPI) CreateReaction(ctx context.Context, fMsg *messages.FromFediAPI) error {
+	reaction, ok := fMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.StatusReaction", fMsg.GTSModel)
+	}
+
+	// Check the reaction is permitted by the
+	// interaction policy of the reacted-to status.
+	policyResult, err := p.intFilter.StatusReactable(ctx,
+		reaction.Account,
+		reaction.Status,
+	)
+	if err != nil {
+		return gtserror.Newf("error checking status reactable: %w", err)
+	}
+
+	if !policyResult.Permitted() {
+		// There's no approval flow for
+		// reactions (yet), so anything
+		// not permitted outright is dropped.
+		log.Debugf(ctx, "dropping reaction %s not permitted by interaction policy", reaction.URI)
+		return nil
+	}
+
+	if emoji := reaction.Emoji; emoji != nil && emoji.ID == "" {
+		// Remote custom emoji we
+		// don't have yet, fetch it.
+		emoji, err := p.federate.GetEmoji(ctx,
+			emoji.Shortcode,
+			emoji.Domain,
+			emoji.ImageRemoteURL,
+			media.AdditionalEmojiInfo{
+				URI:                  &emoji.URI,
+				ImageRemoteURL:       &emoji.ImageRemoteURL,
+				ImageStaticRemoteURL: &emoji.ImageStaticRemoteURL,
+			},
+			false,
+		)
+		if err != nil {
+			if emoji == nil {
+				return gtserror.Newf("error loading reaction emoji: %w", err)
+			}
+
+			// non-fatal error occurred during loading, still use it.
+			log.Warnf(ctx, "partially loaded emoji: %v", err)
+		}
+
+		reaction.EmojiID = emoji.ID
+		reaction.Emoji = emoji
+	}
+
+	if err := p.state.DB.PutStatusReaction(ctx, reaction); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// Already stored, meaning we've
+			// already handled side effects.
+			return nil
+		}
+		return gtserror.Newf("db error putting reaction: %w", err)
+	}
+
+	if err := p.surface.notifyReaction(ctx, reaction); err != nil {
+		log.Errorf(ctx, "error notifying reaction: %v", err)
+	}
+
+	// Interaction counts changed on the reacted-to status;
+	// uncache the prepared version from all timelines.
+	p.surface.invalidateStatusFromTimelines(ctx, reaction.StatusID)
+
+	return nil
+}
+
 func (p *fediAPI) CreateAnnounce(ctx context.Context, fMsg *messages.FromFediAPI) error {
 	boost, ok := fMsg.GTSModel.(*gtsmodel.Status)
 	if !ok {
@@ -820,6 +918,11 @@ func (p *fediAPI) AcceptReply(ctx context.Context, fMsg *messages.FromFediAPI) e
 	// uncache the prepared version from all timelines.
 	p.surface.invalidateStatusFromTimelines(ctx, status.InReplyToID)
 
+	if status.QuoteID != "" {
+		// Likewise for the quoted status, if any.
+		p.surface.invalidateStatusFromTimelines(ctx, status.QuoteID)
+	}
+
 	return nil
 }
 
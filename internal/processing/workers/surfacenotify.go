@@ -88,6 +88,45 @@ func (s *Surface) notifyPendingReply(
 	return nil
 }
 
+// notifyPendingQuote notifies the account quoted
+// by the given status that they have a new quote,
+// and that it requires approval by them.
+func (s *Surface) notifyPendingQuote(
+	ctx context.Context,
+	status *gtsmodel.Status,
+) error {
+	// Beforehand, ensure the passed status is fully populated.
+	if err := s.State.DB.PopulateStatus(ctx, status); err != nil {
+		return gtserror.Newf("error populating status %s: %w", status.ID, err)
+	}
+
+	if status.QuoteAccount == nil ||
+		status.QuoteAccount.IsRemote() {
+		// Don't notify
+		// remote accounts.
+		return nil
+	}
+
+	if status.AccountID == status.QuoteAccountID {
+		// Don't notify
+		// self-quotes.
+		return nil
+	}
+
+	// notify quoted account
+	// of quote by status author.
+	if err := s.Notify(ctx,
+		gtsmodel.NotificationPendingQuote,
+		status.QuoteAccount,
+		status.Account,
+		status.ID,
+	); err != nil {
+		return gtserror.Newf("error notifying quoted account %s: %w", status.QuoteAccountID, err)
+	}
+
+	return nil
+}
+
 // notifyMentions iterates through mentions on the
 // given status, and notifies each mentioned account
 // that they have a new mention.
@@ -98,57 +137,93 @@ func (s *Surface) notifyMentions(
 	var errs gtserror.MultiError
 
 	for _, mention := range status.Mentions {
-		// Set status on the mention (stops
-		// the below function populating it).
-		mention.Status = status
-
-		// Beforehand, ensure the passed mention is fully populated.
-		if err := s.State.DB.PopulateMention(ctx, mention); err != nil {
-			errs.Appendf("error populating mention %s: %w", mention.ID, err)
-			continue
+		if err := s.notifyMention(ctx, status, mention); err != nil {
+			errs.Append(err)
 		}
+	}
 
-		if mention.TargetAccount.IsRemote() {
-			// no need to notify
-			// remote accounts.
-			continue
-		}
+	return errs.Combine()
+}
 
-		// Ensure thread not muted
-		// by mentioned account.
-		muted, err := s.State.DB.IsThreadMutedByAccount(
-			ctx,
-			status.ThreadID,
-			mention.TargetAccountID,
-		)
-		if err != nil {
-			errs.Appendf("error checking status thread mute %s: %w", status.ThreadID, err)
-			continue
-		}
+// notifyNewMentions iterates through mentions on the
+// given edited status, and notifies each account that
+// has only just been mentioned by the latest edit.
+func (s *Surface) notifyNewMentions(
+	ctx context.Context,
+	status *gtsmodel.Status,
+) error {
+	var errs gtserror.MultiError
 
-		if muted {
-			// This mentioned account
-			// has muted the thread.
-			// Don't pester them.
+	for _, mention := range status.Mentions {
+		if !mention.IsNew {
+			// Already notified
+			// on a prior version.
 			continue
 		}
 
-		// notify mentioned
-		// by status author.
-		if err := s.Notify(ctx,
-			gtsmodel.NotificationMention,
-			mention.TargetAccount,
-			mention.OriginAccount,
-			mention.StatusID,
-		); err != nil {
-			errs.Appendf("error notifying mention target %s: %w", mention.TargetAccountID, err)
-			continue
+		if err := s.notifyMention(ctx, status, mention); err != nil {
+			errs.Append(err)
 		}
 	}
 
 	return errs.Combine()
 }
 
+// notifyMention notifies the target
+// of the given status mention, taking
+// account of thread mutes.
+func (s *Surface) notifyMention(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	mention *gtsmodel.Mention,
+) error {
+	// Set status on the mention (stops
+	// the below function populating it).
+	mention.Status = status
+
+	// Beforehand, ensure the passed mention is fully populated.
+	if err := s.State.DB.PopulateMention(ctx, mention); err != nil {
+		return gtserror.Newf("error populating mention %s: %w", mention.ID, err)
+	}
+
+	if mention.TargetAccount.IsRemote() {
+		// no need to notify
+		// remote accounts.
+		return nil
+	}
+
+	// Ensure thread not muted
+	// by mentioned account.
+	muted, err := s.State.DB.IsThreadMutedByAccount(
+		ctx,
+		status.ThreadID,
+		mention.TargetAccountID,
+	)
+	if err != nil {
+		return gtserror.Newf("error checking status thread mute %s: %w", status.ThreadID, err)
+	}
+
+	if muted {
+		// This mentioned account
+		// has muted the thread.
+		// Don't pester them.
+		return nil
+	}
+
+	// notify mentioned
+	// by status author.
+	if err := s.Notify(ctx,
+		gtsmodel.NotificationMention,
+		mention.TargetAccount,
+		mention.OriginAccount,
+		mention.StatusID,
+	); err != nil {
+		return gtserror.Newf("error notifying mention target %s: %w", mention.TargetAccountID, err)
+	}
+
+	return nil
+}
+
 // notifyFollowRequest notifies the target of the given
 // follow request that they have a new follow request.
 func (s *Surface) notifyFollowRequest(
@@ -335,6 +410,59 @@ func (s *Surface) notifyableFave(
 	return true, nil
 }
 
+// notifyReaction notifies the target of the given
+// reaction that their status has been emoji reacted to.
+func (s *Surface) notifyReaction(
+	ctx context.Context,
+	reaction *gtsmodel.StatusReaction,
+) error {
+	if reaction.TargetAccountID == reaction.AccountID {
+		// Self-reaction, nothing to do.
+		return nil
+	}
+
+	// Beforehand, ensure the passed status reaction is fully populated.
+	if err := s.State.DB.PopulateStatusReaction(ctx, reaction); err != nil {
+		return gtserror.Newf("error populating reaction %s: %w", reaction.ID, err)
+	}
+
+	if reaction.TargetAccount.IsRemote() {
+		// no need to notify
+		// remote accounts.
+		return nil
+	}
+
+	// Ensure reactee hasn't
+	// muted the thread.
+	muted, err := s.State.DB.IsThreadMutedByAccount(
+		ctx,
+		reaction.Status.ThreadID,
+		reaction.TargetAccountID,
+	)
+	if err != nil {
+		return gtserror.Newf("error checking status thread mute %s: %w", reaction.StatusID, err)
+	}
+
+	if muted {
+		// Reactee doesn't want
+		// notifs for this thread.
+		return nil
+	}
+
+	// notify status author
+	// of reaction by account.
+	if err := s.Notify(ctx,
+		gtsmodel.NotificationReaction,
+		reaction.TargetAccount,
+		reaction.Account,
+		reaction.StatusID,
+	); err != nil {
+		return gtserror.Newf("error notifying status author %s: %w", reaction.TargetAccountID, err)
+	}
+
+	return nil
+}
+
 // notifyAnnounce notifies the status boost target
 // account that their status has been boosted.
 func (s *Surface) notifyAnnounce(
@@ -647,5 +775,10 @@ func (s *Surface) Notify(
 	}
 	s.Stream.Notify(ctx, targetAccount, apiNotif)
 
+	// Send Web Push notification to the user.
+	if err := s.WebPushSender.Send(ctx, notif, apiNotif); err != nil {
+		return gtserror.Newf("error sending web push notification: %w", err)
+	}
+
 	return nil
 }
@@ -71,6 +71,11 @@ func (s *Surface) timelineAndNotifyStatus(ctx context.Context, status *gtsmodel.
 		return gtserror.Newf("error timelining status %s for tag followers: %w", status.ID, err)
 	}
 
+	// Stream the status to open public and hashtag streams it belongs in.
+	if err := s.streamStatusToPublicStreams(ctx, status, false); err != nil {
+		return gtserror.Newf("error streaming status %s to public streams: %w", status.ID, err)
+	}
+
 	// Notify each local account that's mentioned by this status.
 	if err := s.notifyMentions(ctx, status); err != nil {
 		return gtserror.Newf("error notifying status mentions for status %s: %w", status.ID, err)
@@ -550,6 +555,120 @@ func (s *Surface) tagFollowersForStatus(
 	return visibleTagFollowerAccounts, errs.Combine()
 }
 
+// streamStatusToPublicStreams pushes the given status into the
+// open public, local / remote and hashtag streams of each account
+// that it's timelineable for. Unlike home and list timelines, these
+// timelines aren't stored per account, so only streams need updating.
+//
+// If update is true, the status will be streamed as an edit.
+func (s *Surface) streamStatusToPublicStreams(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	update bool,
+) error {
+	if status.Visibility != gtsmodel.VisibilityPublic ||
+		status.BoostOfID != "" ||
+		util.PtrOrValue(status.PendingApproval, false) {
+		// Only public, non-boost, approved
+		// statuses appear in these timelines.
+		return nil
+	}
+
+	// Gather public stream types for status.
+	publicStreamTypes := []string{stream.TimelinePublic}
+	if status.IsLocal() {
+		publicStreamTypes = append(publicStreamTypes, stream.TimelineLocal)
+	} else {
+		publicStreamTypes = append(publicStreamTypes, stream.TimelineRemote)
+	}
+
+	// Gather hashtag stream types for
+	// each useable + listable tag of status.
+	var tagStreamTypes []string
+	for _, tag := range status.Tags {
+		if !*tag.Useable || !*tag.Listable {
+			continue
+		}
+
+		tagStreamTypes = append(tagStreamTypes, stream.TimelineHashtag+":"+tag.Name)
+		if status.IsLocal() {
+			tagStreamTypes = append(tagStreamTypes, stream.TimelineHashtagLocal+":"+tag.Name)
+		}
+	}
+
+	// Get IDs of all accounts with an open stream of any of these types.
+	accountIDs := s.Stream.AccountIDs(append(publicStreamTypes, tagStreamTypes...)...)
+	if len(accountIDs) == 0 {
+		return nil
+	}
+
+	accounts, err := s.State.DB.GetAccountsByIDs(ctx, accountIDs)
+	if err != nil {
+		return gtserror.Newf("db error getting streaming accounts: %w", err)
+	}
+
+	errs := gtserror.MultiError{}
+	for _, account := range accounts {
+		publicTimelineable, err := s.VisFilter.StatusPublicTimelineable(ctx, account, status)
+		if err != nil {
+			errs.Appendf("error checking public visibility of status %s to account %s: %w", status.ID, account.ID, err)
+			continue
+		}
+
+		tagTimelineable, err := s.VisFilter.StatusTagTimelineable(ctx, account, status)
+		if err != nil {
+			errs.Appendf("error checking tag visibility of status %s to account %s: %w", status.ID, account.ID, err)
+			continue
+		}
+
+		var streamTypes []string
+		if publicTimelineable {
+			streamTypes = append(streamTypes, publicStreamTypes...)
+		}
+		if tagTimelineable {
+			streamTypes = append(streamTypes, tagStreamTypes...)
+		}
+
+		if len(streamTypes) == 0 {
+			continue
+		}
+
+		filters, mutes, err := s.getFiltersAndMutes(ctx, account.ID)
+		if err != nil {
+			errs.Append(err)
+			continue
+		}
+
+		// Convert status to frontend model for this account.
+		apiStatus, err := s.Converter.StatusToAPIStatus(ctx,
+			status,
+			account,
+			statusfilter.FilterContextPublic,
+			filters,
+			mutes,
+		)
+		if errors.Is(err, statusfilter.ErrHideStatus) {
+			continue
+		}
+		if err != nil {
+			errs.Appendf("error converting status %s for account %s: %w", status.ID, account.ID, err)
+			continue
+		}
+
+		// Stream to each type; this is a no-op
+		// for types without an open stream.
+		for _, streamType := range streamTypes {
+			if update {
+				s.Stream.StatusUpdate(ctx, account, apiStatus, streamType)
+			} else {
+				s.Stream.Update(ctx, account, apiStatus, streamType)
+			}
+		}
+	}
+
+	return errs.Combine()
+}
+
 // deleteStatusFromTimelines completely removes the given status from all timelines.
 // It will also stream deletion of the status to all open streams.
 func (s *Surface) deleteStatusFromTimelines(ctx context.Context, statusID string) error {
@@ -620,6 +739,11 @@ func (s *Surface) timelineStatusUpdate(ctx context.Context, status *gtsmodel.Sta
 		return gtserror.Newf("error timelining status %s for tag followers: %w", status.ID, err)
 	}
 
+	// Push updated status to open public and hashtag streams, if applicable.
+	if err := s.streamStatusToPublicStreams(ctx, status, true); err != nil {
+		return gtserror.Newf("error streaming status %s to public streams: %w", status.ID, err)
+	}
+
 	return nil
 }
 
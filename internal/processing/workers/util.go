@@ -20,6 +20,7 @@ package workers
 import (
 	"context"
 	"errors"
+	"slices"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -75,6 +76,32 @@ func (u *utils) wipeStatus(
 		}
 	}
 
+	// Delete any attachments only referenced by previous
+	// revisions of this status; these won't be reattached
+	// to anything else, so there's no reason to keep them.
+	if len(status.EditIDs) > 0 {
+		edits, err := u.state.DB.GetStatusEditsByIDs(
+			gtscontext.SetBarebones(ctx),
+			status.EditIDs,
+		)
+		if err != nil {
+			errs.Appendf("error getting status edits: %w", err)
+		}
+
+		for _, edit := range edits {
+			for _, id := range edit.AttachmentIDs {
+				if slices.Contains(status.AttachmentIDs, id) {
+					// Handled below.
+					continue
+				}
+
+				if err := u.media.Delete(ctx, id); err != nil {
+					errs.Appendf("error deleting edit media: %w", err)
+				}
+			}
+		}
+	}
+
 	// Either delete all attachments for this status,
 	// or simply detach + clean them separately later.
 	//
@@ -120,6 +147,11 @@ func (u *utils) wipeStatus(
 		errs.Appendf("error deleting status faves: %w", err)
 	}
 
+	// Delete all reactions to this status.
+	if err := u.state.DB.DeleteStatusReactionsForStatus(ctx, status.ID); err != nil {
+		errs.Appendf("error deleting status reactions: %w", err)
+	}
+
 	if pollID := status.PollID; pollID != "" {
 		// Delete this poll by ID from the database.
 		if err := u.state.DB.DeletePollByID(ctx, pollID); err != nil {
@@ -601,6 +633,53 @@ func (u *utils) requestReply(
 	return nil
 }
 
+// requestQuote stores an interaction request
+// for the given quote, and notifies the interactee.
+func (u *utils) requestQuote(
+	ctx context.Context,
+	quote *gtsmodel.Status,
+) error {
+	// Only create interaction request if
+	// status quotes a local status.
+	if quote.Quote == nil ||
+		!quote.Quote.IsLocal() {
+		return nil
+	}
+
+	// Lock on the interaction URI.
+	unlock := u.state.ProcessingLocks.Lock(quote.URI)
+	defer unlock()
+
+	// Ensure no req with this URI exists already.
+	req, err := u.state.DB.GetInteractionRequestByInteractionURI(ctx, quote.URI)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error checking for existing interaction request: %w", err)
+	}
+
+	if req != nil {
+		// Interaction req already exists,
+		// no need to do anything else.
+		return nil
+	}
+
+	// Create + store interaction request.
+	req, err = typeutils.StatusToInteractionRequest(ctx, quote)
+	if err != nil {
+		return gtserror.Newf("error creating interaction request: %w", err)
+	}
+
+	if err := u.state.DB.PutInteractionRequest(ctx, req); err != nil {
+		return gtserror.Newf("db error storing interaction request: %w", err)
+	}
+
+	// Notify *local* account of pending quote.
+	if err := u.surface.notifyPendingQuote(ctx, quote); err != nil {
+		return gtserror.Newf("error notifying pending quote: %w", err)
+	}
+
+	return nil
+}
+
 // requestAnnounce stores an interaction request
 // for the given announce, and notifies the interactee.
 func (u *utils) requestAnnounce(
@@ -19,6 +19,7 @@ package queue
 
 import (
 	"context"
+	"slices"
 
 	"codeberg.org/gruf/go-structr"
 )
@@ -66,6 +67,56 @@ func (q *StructQueue[T]) Delete(index string, key ...any) {
 	_ = q.queue.Pop(i, i.Key(key...))
 }
 
+// Range pops all queued entries, passing each in order to fn, before
+// pushing them back onto the front of the queue in their original order.
+// This allows values to be safely inspected while they are not queued,
+// though note concurrent callers may briefly see an empty queue.
+func (q *StructQueue[T]) Range(fn func(T)) {
+	values := q.queue.PopFrontN(q.queue.Len())
+	if len(values) == 0 {
+		return
+	}
+	for _, value := range values {
+		fn(value)
+	}
+	q.pushFront(values)
+}
+
+// DeleteFunc pops (and drops!) all queued entries for which fn
+// returns true, pushing the remaining back onto the front of the
+// queue in their original order. Returns the deleted entries.
+func (q *StructQueue[T]) DeleteFunc(fn func(T) bool) []T {
+	values := q.queue.PopFrontN(q.queue.Len())
+	if len(values) == 0 {
+		return nil
+	}
+	var deleted []T
+	keep := values[:0]
+	for _, value := range values {
+		if fn(value) {
+			deleted = append(deleted, value)
+		} else {
+			keep = append(keep, value)
+		}
+	}
+	q.pushFront(keep)
+	return deleted
+}
+
+// pushFront pushes values onto the front of
+// the queue, maintaining their given order.
+func (q *StructQueue[T]) pushFront(values []T) {
+	if len(values) == 0 {
+		return
+	}
+
+	// structr.Queue{}.PushFront() pushes
+	// each value to the front in turn, so
+	// reverse values to maintain order.
+	slices.Reverse(values)
+	q.queue.PushFront(values...)
+}
+
 // Len: see structr.Queue{}.Len().
 func (q *StructQueue[T]) Len() int {
 	return q.queue.Len()
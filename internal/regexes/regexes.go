@@ -75,7 +75,7 @@ const (
 	acceptsPath       = userPathPrefix + `/` + accepts + `/(` + ulid + `)$`
 	blockPath         = userPathPrefix + `/` + blocks + `/(` + ulid + `)$`
 	reportPath        = `^/?` + reports + `/(` + ulid + `)$`
-	filePath          = `^/?(` + ulid + `)/([a-z]+)/([a-z]+)/(` + ulid + `)\.([a-z0-9]+)$`
+	filePath          = `^/?(` + ulid + `)/([a-z]+)/([a-z0-9]+)/(` + ulid + `)\.([a-z0-9]+)$`
 )
 
 var (
@@ -58,6 +58,16 @@ type State struct {
 	// pinned statuses, creating notifs, etc.
 	ProcessingLocks mutexes.MutexMap
 
+	// StorageLocks provides access to this state's
+	// mutex map of per storage path locks, intended
+	// for use around media files, which may be shared
+	// between media attachments with identical contents.
+	//
+	// Held while sharing, uncaching or deleting media
+	// files, until the change is stored in the database,
+	// so files aren't removed while others share them.
+	StorageLocks mutexes.MutexMap
+
 	// Storage provides access to the storage driver.
 	Storage *storage.Driver
 
@@ -21,6 +21,7 @@ import (
 	"context"
 	"maps"
 	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 )
@@ -50,6 +51,18 @@ const (
 	// EventTypeConversation -- a user
 	// should be shown an updated conversation.
 	EventTypeConversation = "conversation"
+
+	// EventTypeAnnouncement -- an instance
+	// announcement has been published or updated.
+	EventTypeAnnouncement = "announcement"
+
+	// EventTypeAnnouncementReaction -- the reactions
+	// to an instance announcement have changed.
+	EventTypeAnnouncementReaction = "announcement.reaction"
+
+	// EventTypeAnnouncementDelete -- an instance
+	// announcement has been unpublished or deleted.
+	EventTypeAnnouncementDelete = "announcement.delete"
 )
 
 const (
@@ -63,6 +76,12 @@ const (
 	// Analogous to the federated timeline.
 	TimelinePublic = "public"
 
+	// TimelineRemote:
+	// All public posts originating from other
+	// servers. Analogous to the federated
+	// timeline, minus local posts.
+	TimelineRemote = "public:remote"
+
 	// TimelineHome:
 	// Events related to the current user, such
 	// as home feed updates and notifications.
@@ -79,6 +98,16 @@ const (
 	// TimelineList:
 	// Updates to a specific list.
 	TimelineList = "list"
+
+	// TimelineHashtag:
+	// All public posts using a specific
+	// hashtag. Analogous to the tag timeline.
+	TimelineHashtag = "hashtag"
+
+	// TimelineHashtagLocal:
+	// All public posts originating from this
+	// server that use a specific hashtag.
+	TimelineHashtagLocal = "hashtag:local"
 )
 
 // AllStatusTimelines contains all Timelines
@@ -87,9 +116,12 @@ const (
 var AllStatusTimelines = []string{
 	TimelineLocal,
 	TimelinePublic,
+	TimelineRemote,
 	TimelineHome,
 	TimelineDirect,
 	TimelineList,
+	TimelineHashtag,
+	TimelineHashtagLocal,
 }
 
 type Streams struct {
@@ -198,6 +230,24 @@ func (s *Streams) Post(ctx context.Context, accountID string, msg Message) bool
 
 // PostAll will post the given message to all streams with matching types.
 func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
+	return s.postAll(ctx, msg, (*Stream).getStreamType)
+}
+
+// PostAllKeyed is like PostAll, but will additionally post to all streams
+// subscribed to a keyed variant of any of the message stream types, e.g.
+// `list:01H3YF48G8B7KTPQFS8D2QBVG8` for `list`, or `hashtag:example` for
+// `hashtag`. This is useful for sending out status deletes.
+func (s *Streams) PostAllKeyed(ctx context.Context, msg Message) bool {
+	return s.postAll(ctx, msg, (*Stream).getKeyedStreamType)
+}
+
+// postAll will post the given message to all streams for
+// which the given function returns a supported stream type.
+func (s *Streams) postAll(
+	ctx context.Context,
+	msg Message,
+	getStreamType func(*Stream, ...string) string,
+) bool {
 	var deferred []func() bool
 
 	// Acquire lock.
@@ -208,7 +258,7 @@ func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
 		for _, str := range strs {
 
 			// Check whether stream supports any of our message targets.
-			if stype := str.getStreamType(msg.Stream...); stype != "" {
+			if stype := getStreamType(str, msg.Stream...); stype != "" {
 
 				// Rescope var
 				// to prevent
@@ -248,6 +298,29 @@ func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
 	return ok
 }
 
+// AccountIDs returns the IDs of all accounts with
+// an open stream supporting any of given stream types.
+func (s *Streams) AccountIDs(streamTypes ...string) []string {
+	var accountIDs []string
+
+	// Acquire lock.
+	s.mutex.Lock()
+
+	for accountID, strs := range s.streams {
+		for _, str := range strs {
+			if str.getStreamType(streamTypes...) != "" {
+				accountIDs = append(accountIDs, accountID)
+				break
+			}
+		}
+	}
+
+	// Done with lock.
+	s.mutex.Unlock()
+
+	return accountIDs
+}
+
 // Stream represents one
 // open stream for a client.
 type Stream struct {
@@ -302,6 +375,23 @@ func (s *Stream) getStreamType(streamTypes ...string) string {
 	return ""
 }
 
+// getKeyedStreamType returns the first stream type that stream supports
+// which is either in given list, or is keyed beneath a type in given list,
+// e.g. `list:01H3YF48G8B7KTPQFS8D2QBVG8` beneath `list`.
+func (s *Stream) getKeyedStreamType(streamTypes ...string) string {
+	if ptr := s.types.Load(); ptr != nil {
+		for _, streamType := range streamTypes {
+			for supported := range *ptr {
+				if supported == streamType ||
+					strings.HasPrefix(supported, streamType+":") {
+					return supported
+				}
+			}
+		}
+	}
+	return ""
+}
+
 // send will block on posting a new Message{}, returning early with
 // a false value if provided context is canceled, or stream closed.
 func (s *Stream) send(ctx context.Context, msg Message) bool {
@@ -156,7 +156,7 @@ func (cr *customRenderer) handleMention(text string) string {
 		return text
 	}
 
-	if cr.statusID != "" {
+	if cr.statusID != "" && mention.IsNew {
 		if err := cr.db.PutMention(cr.ctx, mention); err != nil {
 			log.Errorf(cr.ctx, "error putting mention in db: %s", err)
 			return text
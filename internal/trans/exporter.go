@@ -21,22 +21,34 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 )
 
 // Exporter wraps functionality for exporting entries from the database to a file.
 type Exporter interface {
+	// ExportMinimal exports local accounts, their relationships,
+	// users, domain blocks and instances, in the (unversioned)
+	// minimal export format.
 	ExportMinimal(ctx context.Context, path string) error
+
+	// Export exports every entry in the database in the current versioned
+	// export format, and if media is true, all values in storage too.
+	Export(ctx context.Context, path string, media bool) error
 }
 
 type exporter struct {
 	db         db.DB
+	storage    *storage.Driver
 	writtenIDs map[string]bool
 }
 
-// NewExporter returns a new Exporter that will use the given db.
-func NewExporter(db db.DB) Exporter {
+// NewExporter returns a new Exporter that will use the given db, and
+// the given storage to export media. Storage may be nil if media is
+// not to be exported.
+func NewExporter(db db.DB, storage *storage.Driver) Exporter {
 	return &exporter{
 		db:         db,
+		storage:    storage,
 		writtenIDs: make(map[string]bool),
 	}
 }
@@ -0,0 +1,189 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
+)
+
+const (
+	// exportPageSize is the number of database
+	// entries to select at once when exporting.
+	exportPageSize = 1000
+
+	// blobChunkSize is the maximum size
+	// of one chunk of a storage blob.
+	blobChunkSize = 1024 * 1024
+)
+
+func (e *exporter) Export(ctx context.Context, path string, media bool) error {
+	if path == "" {
+		return errors.New("Export: path empty")
+	}
+
+	if media && e.storage == nil {
+		return errors.New("Export: no storage to export media from")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("Export: couldn't export to %s: %s", path, err)
+	}
+
+	// Buffer writes, as entries
+	// are encoded one by one.
+	buf := bufio.NewWriter(file)
+	encoder := json.NewEncoder(buf)
+
+	if err := e.export(ctx, encoder, media); err != nil {
+		file.Close()
+		return fmt.Errorf("Export: %s", err)
+	}
+
+	if err := buf.Flush(); err != nil {
+		file.Close()
+		return fmt.Errorf("Export: error writing to %s: %s", path, err)
+	}
+
+	return neatClose(file)
+}
+
+func (e *exporter) export(ctx context.Context, encoder *json.Encoder, media bool) error {
+	// Header first, so that the importer
+	// knows what format to expect.
+	if err := encoder.Encode(&transmodel.Header{
+		Type:      transmodel.TransHeader,
+		Version:   transmodel.Version,
+		CreatedAt: time.Now(),
+		Host:      config.GetHost(),
+		Media:     media,
+	}); err != nil {
+		return fmt.Errorf("error encoding header: %s", err)
+	}
+
+	for _, t := range tables {
+		if err := e.exportTable(ctx, encoder, t); err != nil {
+			return fmt.Errorf("error exporting %s entries: %s", t.typ, err)
+		}
+	}
+
+	if media {
+		if err := e.exportStorage(ctx, encoder); err != nil {
+			return fmt.Errorf("error exporting media: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// exportTable encodes all entries in the given table, page by page.
+func (e *exporter) exportTable(ctx context.Context, encoder *json.Encoder, t table) error {
+	var after any
+	for {
+		page, err := t.getPage(ctx, e.db, after, exportPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range page {
+			data, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+
+			if err := encoder.Encode(&transmodel.Record{
+				Type: t.typ,
+				Data: data,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if len(page) < exportPageSize {
+			// Last page.
+			return nil
+		}
+
+		// Next page starts
+		// after this one.
+		after = page[len(page)-1]
+	}
+}
+
+// exportStorage encodes every value in storage, in chunks.
+func (e *exporter) exportStorage(ctx context.Context, encoder *json.Encoder) error {
+	// Gather keys first, as storage
+	// may not support reading values
+	// while walking over its keys.
+	var keys []string
+	if err := e.storage.WalkKeys(ctx, func(key string) error {
+		keys = append(keys, key)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("error walking storage keys: %w", err)
+	}
+
+	chunk := make([]byte, blobChunkSize)
+	for _, key := range keys {
+		if err := e.exportBlob(ctx, encoder, key, chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// exportBlob encodes the value stored under key, reading it into chunk.
+func (e *exporter) exportBlob(ctx context.Context, encoder *json.Encoder, key string, chunk []byte) error {
+	rc, err := e.storage.GetStream(ctx, key)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", key, err)
+	}
+	defer rc.Close()
+
+	for {
+		n, err := io.ReadFull(rc, chunk)
+		final := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+		if err != nil && !final {
+			return fmt.Errorf("error reading %s: %w", key, err)
+		}
+
+		if err := encoder.Encode(&transmodel.StorageBlob{
+			Type:  transmodel.TransStorageBlob,
+			Key:   key,
+			Data:  chunk[:n],
+			Final: final,
+		}); err != nil {
+			return err
+		}
+
+		if final {
+			return nil
+		}
+	}
+}
@@ -18,6 +18,7 @@
 package trans
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -41,22 +42,84 @@ func (i *importer) Import(ctx context.Context, path string) error {
 	}
 
 	decoder := json.NewDecoder(file)
-	decoder.UseNumber()
 
-	for {
-		entry := transmodel.Entry{}
-		err := decoder.Decode(&entry)
+	// Files without a header are
+	// minimal, unversioned exports.
+	version := 1
+	blobs := &blobImport{storage: i.storage}
+	defer blobs.cleanup(ctx)
+
+	for first := true; ; first = false {
+		raw := json.RawMessage{}
+		err := decoder.Decode(&raw)
 		if err != nil {
 			if err == io.EOF {
 				log.Infof(ctx, "reached end of file")
-				return neatClose(file)
+				break
 			}
 			return fmt.Errorf("Import: error decoding in readLoop: %s", err)
 		}
-		if err := i.inputEntry(ctx, entry); err != nil {
-			return fmt.Errorf("Import: error inputting entry: %s", err)
+
+		var header transmodel.Header
+		if err := json.Unmarshal(raw, &header); err != nil {
+			return fmt.Errorf("Import: error decoding entry type: %s", err)
+		}
+
+		if first && header.Type == transmodel.TransHeader {
+			if header.Version > transmodel.Version {
+				return fmt.Errorf("Import: unsupported export version %d, latest supported is %d", header.Version, transmodel.Version)
+			}
+			log.Infof(ctx, "importing version %d export of %s created at %s", header.Version, header.Host, header.CreatedAt)
+			version = header.Version
+			continue
+		}
+
+		if version == 1 {
+			entry := transmodel.Entry{}
+			if err := decodeEntry(raw, &entry); err != nil {
+				return fmt.Errorf("Import: error decoding entry: %s", err)
+			}
+			if err := i.inputEntry(ctx, entry); err != nil {
+				return fmt.Errorf("Import: error inputting entry: %s", err)
+			}
+			continue
+		}
+
+		if header.Type == transmodel.TransStorageBlob {
+			var blob transmodel.StorageBlob
+			if err := json.Unmarshal(raw, &blob); err != nil {
+				return fmt.Errorf("Import: error decoding storage blob: %s", err)
+			}
+			if err := blobs.input(ctx, &blob); err != nil {
+				return fmt.Errorf("Import: error inputting storage blob: %s", err)
+			}
+			continue
+		}
+
+		var record transmodel.Record
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return fmt.Errorf("Import: error decoding record: %s", err)
+		}
+		if err := i.inputRecord(ctx, &record); err != nil {
+			return fmt.Errorf("Import: error inputting record: %s", err)
+		}
+	}
+
+	if version >= 2 {
+		if blobs.key != "" {
+			return fmt.Errorf("Import: file ended before end of storage blob %s", blobs.key)
+		}
+
+		// Search index isn't exported,
+		// so build it from the imported
+		// statuses and accounts.
+		log.Info(ctx, "rebuilding search index")
+		if err := i.db.RebuildSearchIndex(ctx); err != nil {
+			return fmt.Errorf("Import: error rebuilding search index: %s", err)
 		}
 	}
+
+	return neatClose(file)
 }
 
 func (i *importer) inputEntry(ctx context.Context, entry transmodel.Entry) error {
@@ -150,6 +213,35 @@ func (i *importer) inputEntry(ctx context.Context, entry transmodel.Entry) error
 	return nil
 }
 
+// inputRecord puts the database model wrapped
+// in the given versioned export record in the db.
+func (i *importer) inputRecord(ctx context.Context, record *transmodel.Record) error {
+	t, ok := tablesByType[record.Type]
+	if !ok {
+		log.Errorf(ctx, "didn't recognize transtype '%s', skipping it", record.Type)
+		return nil
+	}
+
+	entry, err := t.decode(record.Data)
+	if err != nil {
+		return fmt.Errorf("inputRecord: error decoding %s: %s", record.Type, err)
+	}
+
+	if err := i.putInDB(ctx, entry); err != nil {
+		return fmt.Errorf("inputRecord: error adding %s to database: %s", record.Type, err)
+	}
+
+	return nil
+}
+
 func (i *importer) putInDB(ctx context.Context, entry interface{}) error {
 	return i.db.Put(ctx, entry)
 }
+
+// decodeEntry decodes a minimal export entry, keeping
+// numbers as json.Number so they can be decoded later.
+func decodeEntry(raw json.RawMessage, entry *transmodel.Entry) error {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	return decoder.Decode(entry)
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/google/uuid"
@@ -47,7 +48,7 @@ func (suite *ImportMinimalTestSuite) TestImportMinimalOK() {
 	tempFilePath := fmt.Sprintf("%s/%s", suite.T().TempDir(), uuid.NewString())
 
 	// export to the tempFilePath
-	exporter := trans.NewExporter(suite.db)
+	exporter := trans.NewExporter(suite.db, nil)
 	err = exporter.ExportMinimal(ctx, tempFilePath)
 	suite.NoError(err)
 
@@ -63,7 +64,7 @@ func (suite *ImportMinimalTestSuite) TestImportMinimalOK() {
 	// create a new database with just the tables created, no entries
 	newDB := testrig.NewTestDB(&state)
 
-	importer := trans.NewImporter(newDB)
+	importer := trans.NewImporter(newDB, nil)
 	err = importer.Import(ctx, tempFilePath)
 	suite.NoError(err)
 
@@ -124,3 +125,100 @@ func (suite *ImportMinimalTestSuite) TestImportMinimalOK() {
 func TestImportMinimalTestSuite(t *testing.T) {
 	suite.Run(t, &ImportMinimalTestSuite{})
 }
+
+type ImportFullTestSuite struct {
+	TransTestSuite
+}
+
+func (suite *ImportFullTestSuite) TestImportFullOK() {
+	ctx := context.Background()
+
+	// use a temporary file path
+	tempFilePath := fmt.Sprintf("%s/%s", suite.T().TempDir(), uuid.NewString())
+
+	// export everything, including media, to the tempFilePath
+	storage := testrig.NewInMemoryStorage()
+	testrig.StandardStorageSetup(storage, "../../testrig/media")
+	defer testrig.StandardStorageTeardown(storage)
+
+	exporter := trans.NewExporter(suite.db, storage)
+	err := exporter.Export(ctx, tempFilePath, true)
+	suite.NoError(err)
+
+	var state state.State
+	state.Caches.Init()
+
+	// create a new database with just the tables created, no entries,
+	// and new storage with nothing in it, then import into them
+	newDB := testrig.NewTestDB(&state)
+	newStorage := testrig.NewInMemoryStorage()
+
+	importer := trans.NewImporter(newDB, newStorage)
+	err = importer.Import(ctx, tempFilePath)
+	suite.NoError(err)
+
+	// every entry of these (and all other) types should have been imported
+	for _, models := range []any{
+		&[]*gtsmodel.Account{},
+		&[]*gtsmodel.AccountSettings{},
+		&[]*gtsmodel.Application{},
+		&[]*gtsmodel.Emoji{},
+		&[]*gtsmodel.Filter{},
+		&[]*gtsmodel.List{},
+		&[]*gtsmodel.ListEntry{},
+		&[]*gtsmodel.MediaAttachment{},
+		&[]*gtsmodel.Poll{},
+		&[]*gtsmodel.Report{},
+		&[]*gtsmodel.Status{},
+		&[]*gtsmodel.StatusToTag{},
+		&[]*gtsmodel.Token{},
+		&[]*gtsmodel.User{},
+	} {
+		suite.NoError(suite.db.GetAll(ctx, models))
+		before := reflect.ValueOf(models).Elem().Len()
+
+		suite.NoError(newDB.GetAll(ctx, models))
+		after := reflect.ValueOf(models).Elem().Len()
+
+		suite.NotZero(before, "%T", models)
+		suite.Equal(before, after, "%T", models)
+	}
+
+	// compare a test status and its settings before + after
+	testStatus := suite.testStatuses["local_account_1_status_1"]
+	testStatusAfter, err := newDB.GetStatusByID(ctx, testStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(testStatus.Content, testStatusAfter.Content)
+	suite.Equal(testStatus.Visibility, testStatusAfter.Visibility)
+	suite.Equal(testStatus.InteractionPolicy, testStatusAfter.InteractionPolicy)
+	suite.True(testStatus.CreatedAt.Equal(testStatusAfter.CreatedAt))
+
+	// compare account keys before + after
+	testAccount := suite.testAccounts["local_account_1"]
+	testAccountAfter, err := newDB.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(testAccount.PrivateKey.D, testAccountAfter.PrivateKey.D)
+	suite.Equal(testAccount.PublicKey, testAccountAfter.PublicKey)
+
+	// media should have been imported into the new storage
+	attachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+	b, err := newStorage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+
+	original, err := storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+	suite.Equal(original, b)
+
+	// statuses should be searchable again
+	statuses, err := newDB.SearchForStatuses(ctx, testAccount.ID, "hello", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.NotEmpty(statuses)
+}
+
+func TestImportFullTestSuite(t *testing.T) {
+	suite.Run(t, &ImportFullTestSuite{})
+}
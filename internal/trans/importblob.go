@@ -0,0 +1,105 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"os"
+	"path"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
+)
+
+// blobImport reassembles storage blob chunks
+// in a temporary file, putting each value in
+// storage once its final chunk is input.
+type blobImport struct {
+	storage *storage.Driver
+
+	// key of the value currently
+	// being reassembled in file.
+	key  string
+	file *os.File
+}
+
+func (b *blobImport) input(ctx context.Context, blob *transmodel.StorageBlob) error {
+	if b.storage == nil {
+		return errors.New("no storage to import media into")
+	}
+
+	if blob.Key != b.key {
+		if b.key != "" {
+			return fmt.Errorf("storage blob %s ended before its final chunk", b.key)
+		}
+
+		file, err := os.CreateTemp("", "gotosocial-import-*")
+		if err != nil {
+			return fmt.Errorf("error creating temp file: %w", err)
+		}
+
+		b.key = blob.Key
+		b.file = file
+	}
+
+	if _, err := b.file.Write(blob.Data); err != nil {
+		return fmt.Errorf("error writing temp file: %w", err)
+	}
+
+	if !blob.Final {
+		// More to come.
+		return nil
+	}
+
+	if err := b.file.Close(); err != nil {
+		return fmt.Errorf("error closing temp file: %w", err)
+	}
+
+	// Content-type is only used by S3, to serve
+	// media directly; guess it from extension.
+	contentType := mime.TypeByExtension(path.Ext(b.key))
+	if _, err := b.storage.PutFile(ctx, b.key, b.file.Name(), contentType); err != nil {
+		return fmt.Errorf("error putting %s in storage: %w", b.key, err)
+	}
+
+	log.Infof(ctx, "added storage blob with key %s", b.key)
+	b.cleanup(ctx)
+	return nil
+}
+
+// cleanup removes the current temp file, if any.
+func (b *blobImport) cleanup(ctx context.Context) {
+	if b.file == nil {
+		return
+	}
+
+	// Close in case we're cleaning
+	// up after an error; no-op else.
+	_ = b.file.Close()
+
+	if err := os.Remove(b.file.Name()); err != nil {
+		log.Errorf(ctx, "error removing temp file: %v", err)
+	}
+
+	b.key = ""
+	b.file = nil
+}
@@ -21,20 +21,28 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 )
 
 // Importer wraps functionality for importing entries from a file into the database.
 type Importer interface {
+	// Import imports entries from an export file in either
+	// the minimal or versioned format into the database, and
+	// any media contained in the file into storage.
 	Import(ctx context.Context, path string) error
 }
 
 type importer struct {
-	db db.DB
+	db      db.DB
+	storage *storage.Driver
 }
 
-// NewImporter returns a new Importer interface that uses the given db.
-func NewImporter(db db.DB) Importer {
+// NewImporter returns a new Importer interface that uses the given db, and
+// the given storage to import media. Storage may be nil if the file to be
+// imported doesn't contain media.
+func NewImporter(db db.DB, storage *storage.Driver) Importer {
 	return &importer{
-		db: db,
+		db:      db,
+		storage: storage,
 	}
 }
@@ -0,0 +1,40 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"time"
+)
+
+// Version is the current version of the export file format.
+//
+// Export files without a Header are version 1 (minimal)
+// exports, containing only the entry types serialized
+// by their own models in this package. Version 2 exports
+// contain every database model wrapped in a Record, and
+// optionally storage blobs.
+const Version = 2
+
+// Header is the first entry of a versioned export file.
+type Header struct {
+	Type      Type      `json:"type"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	Host      string    `json:"host"`
+	Media     bool      `json:"media"`
+}
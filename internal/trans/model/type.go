@@ -35,6 +35,75 @@ const (
 	TransUser             Type = "user"
 )
 
+// Types of entries found only in versioned export files,
+// in addition to the above, which in versioned files are
+// also serialized as full database models (see Record).
+const (
+	TransHeader                       Type = "header"
+	TransStorageBlob                  Type = "storageBlob"
+	TransAccountArchive               Type = "accountArchive"
+	TransAccountNote                  Type = "accountNote"
+	TransAccountSettings              Type = "accountSettings"
+	TransAccountStats                 Type = "accountStats"
+	TransAccountToEmoji               Type = "accountToEmoji"
+	TransAdminAction                  Type = "adminAction"
+	TransAnnouncement                 Type = "announcement"
+	TransAnnouncementReaction         Type = "announcementReaction"
+	TransAnnouncementRead             Type = "announcementRead"
+	TransApplication                  Type = "application"
+	TransClient                       Type = "client"
+	TransContentLimit                 Type = "contentLimit"
+	TransConversation                 Type = "conversation"
+	TransConversationToStatus         Type = "conversationToStatus"
+	TransDeniedUser                   Type = "deniedUser"
+	TransDomainAllow                  Type = "domainAllow"
+	TransDomainPermissionDraft        Type = "domainPermissionDraft"
+	TransDomainPermissionSubscription Type = "domainPermissionSubscription"
+	TransEmoji                        Type = "emoji"
+	TransEmojiCategory                Type = "emojiCategory"
+	TransFeaturedSuggestion           Type = "featuredSuggestion"
+	TransFilter                       Type = "filter"
+	TransFilterKeyword                Type = "filterKeyword"
+	TransFilterStatus                 Type = "filterStatus"
+	TransFollowedTag                  Type = "followedTag"
+	TransHeaderFilterAllow            Type = "headerFilterAllow"
+	TransHeaderFilterBlock            Type = "headerFilterBlock"
+	TransInteractionRequest           Type = "interactionRequest"
+	TransList                         Type = "list"
+	TransListEntry                    Type = "listEntry"
+	TransMarker                       Type = "marker"
+	TransMediaAttachment              Type = "mediaAttachment"
+	TransMention                      Type = "mention"
+	TransMove                         Type = "move"
+	TransNotification                 Type = "notification"
+	TransPoll                         Type = "poll"
+	TransPollVote                     Type = "pollVote"
+	TransRelay                        Type = "relay"
+	TransReport                       Type = "report"
+	TransRouterSession                Type = "routerSession"
+	TransRule                         Type = "rule"
+	TransScheduledStatus              Type = "scheduledStatus"
+	TransSinBinStatus                 Type = "sinBinStatus"
+	TransStatus                       Type = "status"
+	TransStatusBookmark               Type = "statusBookmark"
+	TransStatusEdit                   Type = "statusEdit"
+	TransStatusFave                   Type = "statusFave"
+	TransStatusReaction               Type = "statusReaction"
+	TransStatusToEmoji                Type = "statusToEmoji"
+	TransStatusToTag                  Type = "statusToTag"
+	TransSuggestionDismissal          Type = "suggestionDismissal"
+	TransTag                          Type = "tag"
+	TransThread                       Type = "thread"
+	TransThreadMute                   Type = "threadMute"
+	TransThreadToStatus               Type = "threadToStatus"
+	TransToken                        Type = "token"
+	TransTombstone                    Type = "tombstone"
+	TransTrend                        Type = "trend"
+	TransUserMute                     Type = "userMute"
+	TransVAPIDKeyPair                 Type = "vapidKeyPair"
+	TransWebPushSubscription          Type = "webPushSubscription"
+)
+
 // Entry is used for deserializing trans entries into a rough interface so that
 // the TypeKey can be fetched, before continuing with full parsing.
 type Entry map[string]interface{}
@@ -0,0 +1,152 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
+)
+
+// table wraps functions for exporting and importing
+// entries of one database model in a versioned export.
+type table struct {
+	typ transmodel.Type
+
+	// getPage gets a page of entries from the
+	// database, after the given entry (if set).
+	getPage func(ctx context.Context, db db.DB, after any, limit int) ([]any, error)
+
+	// decode decodes an entry from export data.
+	decode func(data json.RawMessage) (any, error)
+}
+
+// tableOf returns the table for database model T.
+func tableOf[T any](typ transmodel.Type) table {
+	return table{
+		typ: typ,
+		getPage: func(ctx context.Context, db db.DB, after any, limit int) ([]any, error) {
+			var entries []*T
+			if err := db.GetAllPage(ctx, &entries, after, limit); err != nil {
+				return nil, err
+			}
+
+			page := make([]any, len(entries))
+			for i, entry := range entries {
+				page[i] = entry
+			}
+
+			return page, nil
+		},
+		decode: func(data json.RawMessage) (any, error) {
+			entry := new(T)
+			err := json.Unmarshal(data, entry)
+			return entry, err
+		},
+	}
+}
+
+// tables contains every database model included in a versioned
+// export. Worker tasks, advanced migration records, and the search
+// index are deliberately left out, as they're either transient or
+// specific to the database being exported from; the search index
+// is instead rebuilt after import.
+var tables = []table{
+	tableOf[gtsmodel.Account](transmodel.TransAccount),
+	tableOf[gtsmodel.AccountArchive](transmodel.TransAccountArchive),
+	tableOf[gtsmodel.AccountNote](transmodel.TransAccountNote),
+	tableOf[gtsmodel.AccountSettings](transmodel.TransAccountSettings),
+	tableOf[gtsmodel.AccountStats](transmodel.TransAccountStats),
+	tableOf[gtsmodel.AccountToEmoji](transmodel.TransAccountToEmoji),
+	tableOf[gtsmodel.AdminAction](transmodel.TransAdminAction),
+	tableOf[gtsmodel.Announcement](transmodel.TransAnnouncement),
+	tableOf[gtsmodel.AnnouncementReaction](transmodel.TransAnnouncementReaction),
+	tableOf[gtsmodel.AnnouncementRead](transmodel.TransAnnouncementRead),
+	tableOf[gtsmodel.Application](transmodel.TransApplication),
+	tableOf[gtsmodel.Block](transmodel.TransBlock),
+	tableOf[gtsmodel.Client](transmodel.TransClient),
+	tableOf[gtsmodel.ContentLimit](transmodel.TransContentLimit),
+	tableOf[gtsmodel.Conversation](transmodel.TransConversation),
+	tableOf[gtsmodel.ConversationToStatus](transmodel.TransConversationToStatus),
+	tableOf[gtsmodel.DeniedUser](transmodel.TransDeniedUser),
+	tableOf[gtsmodel.DomainAllow](transmodel.TransDomainAllow),
+	tableOf[gtsmodel.DomainBlock](transmodel.TransDomainBlock),
+	tableOf[gtsmodel.DomainPermissionDraft](transmodel.TransDomainPermissionDraft),
+	tableOf[gtsmodel.DomainPermissionSubscription](transmodel.TransDomainPermissionSubscription),
+	tableOf[gtsmodel.EmailDomainBlock](transmodel.TransEmailDomainBlock),
+	tableOf[gtsmodel.Emoji](transmodel.TransEmoji),
+	tableOf[gtsmodel.EmojiCategory](transmodel.TransEmojiCategory),
+	tableOf[gtsmodel.FeaturedSuggestion](transmodel.TransFeaturedSuggestion),
+	tableOf[gtsmodel.Filter](transmodel.TransFilter),
+	tableOf[gtsmodel.FilterKeyword](transmodel.TransFilterKeyword),
+	tableOf[gtsmodel.FilterStatus](transmodel.TransFilterStatus),
+	tableOf[gtsmodel.Follow](transmodel.TransFollow),
+	tableOf[gtsmodel.FollowRequest](transmodel.TransFollowRequest),
+	tableOf[gtsmodel.FollowedTag](transmodel.TransFollowedTag),
+	tableOf[gtsmodel.HeaderFilterAllow](transmodel.TransHeaderFilterAllow),
+	tableOf[gtsmodel.HeaderFilterBlock](transmodel.TransHeaderFilterBlock),
+	tableOf[gtsmodel.Instance](transmodel.TransInstance),
+	tableOf[gtsmodel.InteractionRequest](transmodel.TransInteractionRequest),
+	tableOf[gtsmodel.List](transmodel.TransList),
+	tableOf[gtsmodel.ListEntry](transmodel.TransListEntry),
+	tableOf[gtsmodel.Marker](transmodel.TransMarker),
+	tableOf[gtsmodel.MediaAttachment](transmodel.TransMediaAttachment),
+	tableOf[gtsmodel.Mention](transmodel.TransMention),
+	tableOf[gtsmodel.Move](transmodel.TransMove),
+	tableOf[gtsmodel.Notification](transmodel.TransNotification),
+	tableOf[gtsmodel.Poll](transmodel.TransPoll),
+	tableOf[gtsmodel.PollVote](transmodel.TransPollVote),
+	tableOf[gtsmodel.Relay](transmodel.TransRelay),
+	tableOf[gtsmodel.Report](transmodel.TransReport),
+	tableOf[gtsmodel.RouterSession](transmodel.TransRouterSession),
+	tableOf[gtsmodel.Rule](transmodel.TransRule),
+	tableOf[gtsmodel.ScheduledStatus](transmodel.TransScheduledStatus),
+	tableOf[gtsmodel.SinBinStatus](transmodel.TransSinBinStatus),
+	tableOf[gtsmodel.Status](transmodel.TransStatus),
+	tableOf[gtsmodel.StatusBookmark](transmodel.TransStatusBookmark),
+	tableOf[gtsmodel.StatusEdit](transmodel.TransStatusEdit),
+	tableOf[gtsmodel.StatusFave](transmodel.TransStatusFave),
+	tableOf[gtsmodel.StatusReaction](transmodel.TransStatusReaction),
+	tableOf[gtsmodel.StatusToEmoji](transmodel.TransStatusToEmoji),
+	tableOf[gtsmodel.StatusToTag](transmodel.TransStatusToTag),
+	tableOf[gtsmodel.SuggestionDismissal](transmodel.TransSuggestionDismissal),
+	tableOf[gtsmodel.Tag](transmodel.TransTag),
+	tableOf[gtsmodel.Thread](transmodel.TransThread),
+	tableOf[gtsmodel.ThreadMute](transmodel.TransThreadMute),
+	tableOf[gtsmodel.ThreadToStatus](transmodel.TransThreadToStatus),
+	tableOf[gtsmodel.Token](transmodel.TransToken),
+	tableOf[gtsmodel.Tombstone](transmodel.TransTombstone),
+	tableOf[gtsmodel.Trend](transmodel.TransTrend),
+	tableOf[gtsmodel.User](transmodel.TransUser),
+	tableOf[gtsmodel.UserMute](transmodel.TransUserMute),
+	tableOf[gtsmodel.VAPIDKeyPair](transmodel.TransVAPIDKeyPair),
+	tableOf[gtsmodel.WebPushSubscription](transmodel.TransWebPushSubscription),
+}
+
+// tablesByType maps each entry type
+// in a versioned export to its table.
+var tablesByType = func() map[transmodel.Type]table {
+	m := make(map[transmodel.Type]table, len(tables))
+	for _, t := range tables {
+		m[t.typ] = t
+	}
+	return m
+}()
@@ -27,8 +27,10 @@ import (
 
 type TransTestSuite struct {
 	suite.Suite
-	db           db.DB
-	testAccounts map[string]*gtsmodel.Account
+	db              db.DB
+	testAccounts    map[string]*gtsmodel.Account
+	testStatuses    map[string]*gtsmodel.Status
+	testAttachments map[string]*gtsmodel.MediaAttachment
 }
 
 func (suite *TransTestSuite) SetupTest() {
@@ -39,6 +41,8 @@ func (suite *TransTestSuite) SetupTest() {
 	testrig.InitTestLog()
 
 	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testStatuses = testrig.NewTestStatuses()
+	suite.testAttachments = testrig.NewTestAttachments()
 
 	suite.db = testrig.NewTestDB(&state)
 	testrig.StandardDBSetup(suite.db, nil)
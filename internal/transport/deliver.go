@@ -24,6 +24,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -163,10 +164,11 @@ func (t *transport) prepare(
 	}
 
 	return &delivery.Delivery{
-		ActorID:  actorID,
-		ObjectID: objectID,
-		TargetID: targetID,
-		Request:  httpclient.WrapRequest(r),
+		ActorID:   actorID,
+		ObjectID:  objectID,
+		TargetID:  targetID,
+		Request:   httpclient.WrapRequest(r),
+		CreatedAt: time.Now(),
 	}, nil
 }
 
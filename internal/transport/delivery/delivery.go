@@ -53,8 +53,14 @@ type Delivery struct {
 	// constitutes this ActivtyPub delivery.
 	Request *httpclient.Request
 
+	// CreatedAt is the time at which
+	// this delivery was first prepared.
+	CreatedAt time.Time
+
 	// internal fields.
-	next time.Time
+	next    time.Time
+	lastErr string
+	lastAt  time.Time
 }
 
 // delivery is an internal type
@@ -69,6 +75,7 @@ type delivery struct {
 	Header   map[string][]string `json:"header,omitempty"`
 	URL      string              `json:"url,omitempty"`
 	Body     []byte              `json:"body,omitempty"`
+	Created  *time.Time          `json:"created_at,omitempty"`
 }
 
 // Serialize will serialize the delivery data as data blob for storage,
@@ -94,6 +101,13 @@ func (dlv *Delivery) Serialize() ([]byte, error) {
 		}
 	}
 
+	var created *time.Time
+
+	if !dlv.CreatedAt.IsZero() {
+		// Only include creation time when set.
+		created = &dlv.CreatedAt
+	}
+
 	// Marshal as internal JSON type.
 	return json.Marshal(delivery{
 		ActorID:  dlv.ActorID,
@@ -103,6 +117,7 @@ func (dlv *Delivery) Serialize() ([]byte, error) {
 		Header:   dlv.Request.Header,
 		URL:      dlv.Request.URL.String(),
 		Body:     body,
+		Created:  created,
 	})
 }
 
@@ -123,6 +138,10 @@ func (dlv *Delivery) Deserialize(data []byte) error {
 	dlv.ObjectID = idlv.ObjectID
 	dlv.TargetID = idlv.TargetID
 
+	if idlv.Created != nil {
+		dlv.CreatedAt = *idlv.Created
+	}
+
 	var body io.Reader
 
 	if idlv.Body != nil {
@@ -0,0 +1,322 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+)
+
+// HostHealth contains the delivery health
+// state of a single host that deliveries
+// have recently been failing to.
+type HostHealth struct {
+	// Host is the receiving host
+	// this delivery health is for.
+	Host string
+
+	// Failures is the number of failed
+	// delivery attempts to host since
+	// the last successful delivery.
+	Failures int
+
+	// LastError is the error string
+	// of the last failed delivery.
+	LastError string
+
+	// LastFailure is the time of
+	// the last failed delivery.
+	LastFailure time.Time
+
+	// FailingSince is the time of the
+	// first failed delivery to host since
+	// the last successful delivery.
+	FailingSince time.Time
+
+	// UnavailableAt is the time host was
+	// marked unavailable, or zero if not.
+	UnavailableAt time.Time
+
+	// LastProbe is the time of the last
+	// delivery attempt permitted to host
+	// while it was marked unavailable.
+	LastProbe time.Time
+
+	// Skipped is the number of deliveries
+	// to host dropped without an attempt
+	// while it was marked unavailable.
+	Skipped int
+}
+
+// Unavailable returns whether host is
+// marked unavailable for deliveries.
+func (h *HostHealth) Unavailable() bool {
+	return !h.UnavailableAt.IsZero()
+}
+
+// Health tracks the successes and failures of deliveries
+// per receiving host. When deliveries to a host have been
+// continuously failing for longer than UnavailableAfter,
+// the host is marked unavailable, and only one delivery
+// attempt every ProbeEvery is permitted to it, until a
+// successful delivery marks it as available again.
+type Health struct {
+
+	// UnavailableAfter is the period of continuously
+	// failing deliveries to a host after which it is
+	// marked unavailable. Zero disables this.
+	UnavailableAfter time.Duration
+
+	// ProbeEvery is the period to elapse between
+	// permitted delivery attempts to a host
+	// while it is marked as unavailable.
+	ProbeEvery time.Duration
+
+	// OnChange is called, if set, with a copy of a
+	// host's health whenever it starts failing, is
+	// marked unavailable, or successfully receives
+	// a delivery after failing. This is called
+	// from within delivery workers, outside of
+	// the Health{} mutex lock.
+	OnChange func(context.Context, HostHealth)
+
+	// internal fields.
+	hosts map[string]*HostHealth
+	mutex sync.Mutex
+}
+
+// Allow returns whether a delivery to host should be
+// attempted. This is always true unless the host is
+// marked as unavailable, in which case it is true
+// at most once every ProbeEvery period.
+func (h *Health) Allow(host string) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	hh, ok := h.hosts[host]
+	if !ok || !hh.Unavailable() {
+		return true
+	}
+
+	// Check whether due a probe.
+	now := time.Now()
+	if now.Sub(hh.LastProbe) < h.ProbeEvery {
+		hh.Skipped++
+		return false
+	}
+
+	hh.LastProbe = now
+	return true
+}
+
+// Success marks a successful delivery to
+// host, marking it available if necessary.
+func (h *Health) Success(ctx context.Context, host string) {
+	h.mutex.Lock()
+
+	hh, ok := h.hosts[host]
+	if !ok {
+		// Host was already
+		// healthy, nothing
+		// more to do here.
+		h.mutex.Unlock()
+		return
+	}
+
+	// Drop the host entry, with the
+	// last copy passed to any hook
+	// as now healthy (zero times).
+	delete(h.hosts, host)
+	h.mutex.Unlock()
+
+	if h.OnChange != nil {
+		h.OnChange(ctx, HostHealth{Host: hh.Host})
+	}
+}
+
+// Failure marks a failed delivery to host with given error,
+// marking it unavailable if it has been continuously failing
+// for longer than the configured UnavailableAfter period.
+func (h *Health) Failure(ctx context.Context, host string, err error) {
+	h.mutex.Lock()
+
+	if h.hosts == nil {
+		// Allocate hosts map.
+		h.hosts = make(map[string]*HostHealth)
+	}
+
+	hh, ok := h.hosts[host]
+	if !ok {
+		// Allocate new entry for host.
+		hh = &HostHealth{Host: host}
+		h.hosts[host] = hh
+	}
+
+	// Update failure details.
+	now := time.Now()
+	hh.Failures++
+	hh.LastFailure = now
+	if err != nil {
+		hh.LastError = err.Error()
+	}
+
+	// Whether this failure
+	// changes host state.
+	var changed bool
+
+	switch {
+	case hh.FailingSince.IsZero():
+		// First failure since
+		// last successful one.
+		hh.FailingSince = now
+		changed = true
+
+	case !hh.Unavailable() &&
+		h.UnavailableAfter > 0 &&
+		now.Sub(hh.FailingSince) >= h.UnavailableAfter:
+		// Failing for too long,
+		// mark host unavailable.
+		hh.UnavailableAt = now
+		hh.LastProbe = now
+		changed = true
+	}
+
+	// Take copy of health
+	// for any change hook.
+	health := *hh
+	h.mutex.Unlock()
+
+	if changed && h.OnChange != nil {
+		h.OnChange(ctx, health)
+	}
+}
+
+// Set sets the delivery health of the host, e.g. after
+// loading persisted health state on startup. Passing
+// a zero FailingSince time will reset host as healthy.
+// Note that this does not call the OnChange hook.
+func (h *Health) Set(health HostHealth) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if health.FailingSince.IsZero() {
+		// Healthy host,
+		// drop entry.
+		delete(h.hosts, health.Host)
+		return
+	}
+
+	if h.hosts == nil {
+		// Allocate hosts map.
+		h.hosts = make(map[string]*HostHealth)
+	}
+
+	h.hosts[health.Host] = &health
+}
+
+// Get returns a copy of the delivery health of host, and
+// whether it was found. Hosts not found are considered healthy.
+func (h *Health) Get(host string) (HostHealth, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if hh, ok := h.hosts[host]; ok {
+		return *hh, true
+	}
+	return HostHealth{}, false
+}
+
+// Hosts returns copies of the delivery health of
+// all hosts currently failing or marked unavailable,
+// sorted alphabetically by host.
+func (h *Health) Hosts() []HostHealth {
+	h.mutex.Lock()
+	hosts := make([]HostHealth, 0, len(h.hosts))
+	for _, hh := range h.hosts {
+		hosts = append(hosts, *hh)
+	}
+	h.mutex.Unlock()
+
+	slices.SortFunc(hosts, func(a, b HostHealth) int {
+		return strings.Compare(a.Host, b.Host)
+	})
+
+	return hosts
+}
+
+// Counts returns the number of hosts deliveries are
+// currently failing to (but not yet marked unavailable),
+// and the number of hosts marked unavailable.
+func (h *Health) Counts() (failing int, unavailable int) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for _, hh := range h.hosts {
+		if hh.Unavailable() {
+			unavailable++
+		} else {
+			failing++
+		}
+	}
+	return
+}
+
+// IsHostFailure returns whether a delivery error
+// indicates a problem with the receiving host, and
+// so should count towards its delivery Health{}.
+// That is network errors, timeouts, and 5xx or 429
+// responses. Errors caused by our own configuration
+// or by the request itself (e.g. dialing a reserved
+// address, TLS failures, redirect loops, or having
+// reached max retries) do not count.
+func IsHostFailure(err error) bool {
+	if code := gtserror.StatusCode(err); code != 0 {
+		return code >= 500 ||
+			code == http.StatusTooManyRequests
+	}
+
+	if errors.Is(err, httpclient.ErrReservedAddr) {
+		// Blocked by our own
+		// dialer, not the host.
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	return errors.As(err, &opErr) ||
+		errors.As(err, &dnsErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
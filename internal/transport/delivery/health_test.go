@@ -0,0 +1,131 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
+)
+
+func TestHealth(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		changes []delivery.HostHealth
+	)
+
+	health := delivery.Health{
+		UnavailableAfter: time.Nanosecond,
+		ProbeEvery:       time.Hour,
+		OnChange: func(_ context.Context, hh delivery.HostHealth) {
+			changes = append(changes, hh)
+		},
+	}
+
+	// Unknown hosts are healthy.
+	if !health.Allow("example.org") {
+		t.Fatal("expected delivery to healthy host to be allowed")
+	}
+
+	// First failure marks host failing.
+	health.Failure(ctx, "example.org", errors.New("connection refused"))
+	if len(changes) != 1 || changes[0].FailingSince.IsZero() || changes[0].Unavailable() {
+		t.Fatalf("expected host to be failing, got %+v", changes)
+	}
+	if !health.Allow("example.org") {
+		t.Fatal("expected delivery to failing host to be allowed")
+	}
+
+	// Failure after the window marks host unavailable.
+	time.Sleep(time.Millisecond)
+	health.Failure(ctx, "example.org", errors.New("connection refused"))
+	if len(changes) != 2 || !changes[1].Unavailable() {
+		t.Fatalf("expected host to be unavailable, got %+v", changes)
+	}
+	if failing, unavailable := health.Counts(); failing != 0 || unavailable != 1 {
+		t.Fatalf("unexpected counts: failing=%d unavailable=%d", failing, unavailable)
+	}
+
+	// Deliveries to unavailable host are skipped until due a probe.
+	if health.Allow("example.org") {
+		t.Fatal("expected delivery to unavailable host to be skipped")
+	}
+	hh, ok := health.Get("example.org")
+	if !ok || hh.Failures != 2 || hh.Skipped != 1 || hh.LastError != "connection refused" {
+		t.Fatalf("unexpected host health: %+v", hh)
+	}
+
+	// Once due a probe, one delivery is allowed through.
+	hh.LastProbe = time.Now().Add(-2 * time.Hour)
+	health.Set(hh)
+	if !health.Allow("example.org") {
+		t.Fatal("expected probe delivery to unavailable host to be allowed")
+	}
+	if health.Allow("example.org") {
+		t.Fatal("expected only one probe delivery to be allowed")
+	}
+
+	// First success marks host healthy again.
+	health.Success(ctx, "example.org")
+	if len(changes) != 3 || !changes[2].FailingSince.IsZero() || changes[2].Unavailable() {
+		t.Fatalf("expected host to be healthy, got %+v", changes)
+	}
+	if _, ok := health.Get("example.org"); ok {
+		t.Fatal("expected healthy host to be dropped")
+	}
+	if !health.Allow("example.org") {
+		t.Fatal("expected delivery to recovered host to be allowed")
+	}
+
+	// Successes to healthy hosts don't trigger changes.
+	health.Success(ctx, "example.org")
+	if len(changes) != 3 {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+}
+
+func TestIsHostFailure(t *testing.T) {
+	for _, test := range []struct {
+		err    error
+		expect bool
+	}{
+		{gtserror.WithStatusCode(errors.New("http response: 500 Internal Server Error"), 500), true},
+		{gtserror.WithStatusCode(errors.New("http response: 503 Service Unavailable"), 503), true},
+		{gtserror.WithStatusCode(errors.New("http response: 429 Too Many Requests"), 429), true},
+		{gtserror.WithStatusCode(errors.New("http response: 404 Not Found"), 404), false},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, true},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: httpclient.ErrReservedAddr}, false},
+		{&net.DNSError{Err: "no such host", IsNotFound: true}, true},
+		{fmt.Errorf("error doing request: %w", context.DeadlineExceeded), true},
+		{io.ErrUnexpectedEOF, true},
+		{errors.New("tls: failed to verify certificate"), false},
+		{errors.New("stopped after 10 redirects"), false},
+	} {
+		if got := delivery.IsHostFailure(test.err); got != test.expect {
+			t.Errorf("IsHostFailure(%v): expected %v, got %v", test.err, test.expect, got)
+		}
+	}
+}
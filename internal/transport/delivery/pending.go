@@ -0,0 +1,174 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Pending contains details of a single
+// delivery pending in the WorkerPool{},
+// either queued awaiting a first attempt,
+// or backlogged by a worker awaiting retry.
+type Pending struct {
+	// Host is the receiving
+	// host of the delivery.
+	Host string
+
+	// URL is the receiving
+	// inbox URL of the delivery.
+	URL string
+
+	// ActorID, ObjectID and TargetID
+	// are the ActivityPub ID IRIs of
+	// the activity being delivered.
+	ActorID  string
+	ObjectID string
+	TargetID string
+
+	// CreatedAt is the time at which
+	// the delivery was first prepared.
+	CreatedAt time.Time
+
+	// Attempts is the number of
+	// delivery attempts made so far.
+	Attempts uint
+
+	// Backlogged indicates whether the
+	// delivery has previously failed and
+	// is awaiting retry in a worker backlog.
+	Backlogged bool
+
+	// NextAttempt is the earliest time at
+	// which a backlogged delivery will be
+	// retried, or zero if not backlogged.
+	NextAttempt time.Time
+
+	// LastError is the error string of the
+	// last failed attempt, if any.
+	LastError string
+
+	// LastAttempt is the time of the
+	// last failed attempt, if any.
+	LastAttempt time.Time
+}
+
+// pending returns Pending{} details for delivery.
+func (dlv *Delivery) pending(backlogged bool) Pending {
+	p := Pending{
+		ActorID:     dlv.ActorID,
+		ObjectID:    dlv.ObjectID,
+		TargetID:    dlv.TargetID,
+		CreatedAt:   dlv.CreatedAt,
+		Backlogged:  backlogged,
+		LastError:   dlv.lastErr,
+		LastAttempt: dlv.lastAt,
+	}
+	if backlogged {
+		p.NextAttempt = dlv.next
+	}
+	if dlv.Request != nil {
+		p.Host = dlv.Request.URL.Host
+		p.URL = dlv.Request.URL.String()
+		p.Attempts = dlv.Request.Attempts()
+	}
+	return p
+}
+
+// Pending returns details of all deliveries currently pending in
+// the pool, both queued and in worker backlogs. Note this excludes
+// any deliveries currently being attempted by a worker.
+func (p *WorkerPool) Pending() []Pending {
+	var pending []Pending
+
+	// Gather all queued deliveries.
+	p.Queue.Range(func(dlv *Delivery) {
+		pending = append(pending, dlv.pending(false))
+	})
+
+	// Gather all backlogged deliveries.
+	for _, w := range p.workers {
+		w.rangeBacklog(func(dlv *Delivery) {
+			pending = append(pending, dlv.pending(true))
+		})
+	}
+
+	return pending
+}
+
+// Purge drops all deliveries pending in the pool
+// to given host, both queued and in worker backlogs,
+// returning the number of dropped deliveries.
+func (p *WorkerPool) Purge(host string) int {
+	match := matchHost(host)
+
+	// Drop all matching queued deliveries.
+	n := len(p.Queue.DeleteFunc(match))
+
+	// Drop all matching backlogged deliveries.
+	for _, w := range p.workers {
+		n += len(w.deleteBacklog(match))
+	}
+
+	return n
+}
+
+// Retry moves all deliveries in worker backlogs to given
+// host back onto the queue with backoff reset, so they will
+// be retried as soon as a worker is available. Returns the
+// number of deliveries moved, queued deliveries being ignored.
+func (p *WorkerPool) Retry(host string) int {
+	var retry []*Delivery
+
+	// Drop all matching backlogged deliveries.
+	for _, w := range p.workers {
+		retry = append(retry,
+			w.deleteBacklog(matchHost(host))...,
+		)
+	}
+
+	if len(retry) == 0 {
+		return 0
+	}
+
+	// Sort so earliest expected retries go first,
+	// (note sortDeliveries() orders the opposite).
+	sortDeliveries(retry)
+	slices.Reverse(retry)
+
+	// Reset backoff.
+	for _, dlv := range retry {
+		dlv.next = time.Time{}
+	}
+
+	// Push back onto queue.
+	p.Queue.Push(retry...)
+
+	return len(retry)
+}
+
+// matchHost returns a func that checks whether
+// a delivery is directed at given receiving host.
+func matchHost(host string) func(*Delivery) bool {
+	return func(dlv *Delivery) bool {
+		return dlv.Request != nil &&
+			strings.EqualFold(dlv.Request.URL.Host, host)
+	}
+}
@@ -0,0 +1,156 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery_test
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
+)
+
+func TestPendingPurge(t *testing.T) {
+	wp := new(delivery.WorkerPool)
+	wp.Init(httpclient.New(httpclient.Config{}))
+
+	// Queue deliveries to two hosts,
+	// without starting any workers.
+	for _, url := range []string{
+		"https://example.org/users/a/inbox",
+		"https://example.org/users/b/inbox",
+		"https://example.com/inbox",
+	} {
+		req, err := http.NewRequest("POST", url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wp.Queue.Push(&delivery.Delivery{
+			Request:   httpclient.WrapRequest(req),
+			CreatedAt: time.Now(),
+		})
+	}
+
+	pending := wp.Pending()
+	if len(pending) != 3 {
+		t.Fatalf("expected 3 pending deliveries, got %d", len(pending))
+	}
+	if pending[0].URL != "https://example.org/users/a/inbox" ||
+		pending[2].Host != "example.com" ||
+		pending[0].Backlogged ||
+		pending[0].CreatedAt.IsZero() {
+		t.Fatalf("unexpected pending deliveries: %+v", pending)
+	}
+
+	// Inspecting must not modify queue.
+	if l := wp.Queue.Len(); l != 3 {
+		t.Fatalf("expected queue length 3, got %d", l)
+	}
+
+	// Queued deliveries aren't retried.
+	if n := wp.Retry("example.org"); n != 0 {
+		t.Fatalf("expected 0 retried deliveries, got %d", n)
+	}
+
+	if n := wp.Purge("example.org"); n != 2 {
+		t.Fatalf("expected 2 purged deliveries, got %d", n)
+	}
+	pending = wp.Pending()
+	if len(pending) != 1 || pending[0].Host != "example.com" {
+		t.Fatalf("unexpected pending deliveries: %+v", pending)
+	}
+}
+
+func TestPendingBacklogRetry(t *testing.T) {
+	attempts := make(chan struct{}, 10)
+
+	// Start an HTTP server that always errors.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	srv := new(http.Server)
+	srv.Handler = http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		attempts <- struct{}{}
+	})
+	go srv.Serve(l)
+	defer srv.Close()
+
+	wp := new(delivery.WorkerPool)
+	wp.Init(httpclient.New(httpclient.Config{
+		AllowRanges: config.MustParseIPPrefixes([]string{
+			"127.0.0.0/8",
+		}),
+	}))
+	wp.Start(1)
+	defer wp.Stop()
+
+	req, err := http.NewRequest("POST", "http://"+l.Addr().String()+"/inbox", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wp.Queue.Push(&delivery.Delivery{Request: httpclient.WrapRequest(req)})
+	host := req.URL.Host
+
+	// awaitBacklogged waits until the delivery
+	// is backlogged following given attempt.
+	awaitBacklogged := func(attempt uint) delivery.Pending {
+		select {
+		case <-attempts:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for attempt %d", attempt)
+		}
+		for i := 0; i < 100; i++ {
+			pending := wp.Pending()
+			if len(pending) == 1 &&
+				pending[0].Backlogged &&
+				pending[0].Attempts == attempt {
+				return pending[0]
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("delivery not backlogged after attempt %d", attempt)
+		return delivery.Pending{}
+	}
+
+	p := awaitBacklogged(1)
+	if p.Host != host ||
+		p.NextAttempt.Before(time.Now()) ||
+		p.LastError != "http response: 503 Service Unavailable" {
+		t.Fatalf("unexpected pending delivery: %+v", p)
+	}
+
+	// Force retry, skipping the backoff.
+	if n := wp.Retry(host); n != 1 {
+		t.Fatalf("expected 1 retried delivery, got %d", n)
+	}
+	awaitBacklogged(2)
+
+	// Purge the backlogged delivery.
+	if n := wp.Purge(host); n != 1 {
+		t.Fatalf("expected 1 purged delivery, got %d", n)
+	}
+	if pending := wp.Pending(); len(pending) != 0 {
+		t.Fatalf("unexpected pending deliveries: %+v", pending)
+	}
+}
@@ -21,10 +21,12 @@ import (
 	"context"
 	"errors"
 	"slices"
+	"sync"
 	"time"
 
 	"codeberg.org/gruf/go-runners"
 	"codeberg.org/gruf/go-structr"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -44,6 +46,10 @@ type WorkerPool struct {
 	// passed to each of delivery pool Worker{}s.
 	Queue queue.StructQueue[*Delivery]
 
+	// Health is the embedded Health{} tracker
+	// passed to each of delivery pool Worker{}s.
+	Health Health
+
 	// internal fields.
 	workers []*Worker
 }
@@ -53,6 +59,8 @@ type WorkerPool struct {
 // from and number of delivery workers to spawn.
 func (p *WorkerPool) Init(client *httpclient.Client) {
 	p.Client = client
+	p.Health.UnavailableAfter = config.GetAdvancedDeliveryUnavailableAfter()
+	p.Health.ProbeEvery = config.GetAdvancedDeliveryProbeEvery()
 	p.Queue.Init(structr.QueueConfig[*Delivery]{
 		Indices: []structr.IndexConfig{
 			{Fields: "ActorID", Multiple: true},
@@ -79,6 +87,7 @@ func (p *WorkerPool) Start(n int) {
 		p.workers[i] = new(Worker)
 		p.workers[i].Client = p.Client
 		p.workers[i].Queue = &p.Queue
+		p.workers[i].Health = &p.Health
 
 		// Attempt to start worker.
 		// Return bool not useful
@@ -124,8 +133,14 @@ type Worker struct {
 	// that delivery worker will feed from.
 	Queue *queue.StructQueue[*Delivery]
 
+	// Health is the delivery Health{} tracker
+	// that delivery worker will check and update
+	// for each delivery's receiving host.
+	Health *Health
+
 	// internal fields.
 	backlog []*Delivery
+	bmutex  sync.Mutex
 	service runners.Service
 }
 
@@ -141,7 +156,7 @@ func (w *Worker) Stop() bool {
 
 // run wraps process to restart on any panic.
 func (w *Worker) run(ctx context.Context) {
-	if w.Client == nil || w.Queue == nil {
+	if w.Client == nil || w.Queue == nil || w.Health == nil {
 		panic("not yet initialized")
 	}
 	log.Debugf(ctx, "%p: starting worker", w)
@@ -151,7 +166,7 @@ func (w *Worker) run(ctx context.Context) {
 
 // process is the main delivery worker processing routine.
 func (w *Worker) process(ctx context.Context) bool {
-	if w.Client == nil || w.Queue == nil {
+	if w.Client == nil || w.Queue == nil || w.Health == nil {
 		// we perform this check here just
 		// to ensure the compiler knows these
 		// variables aren't nil in the loop,
@@ -178,6 +193,11 @@ loop:
 		const min = 100 * time.Millisecond
 		if d := dlv.backoff(); d > min {
 
+			// Re-add to backlog while we wait,
+			// keeping it visible to the pool for
+			// inspection, purging or forced retry.
+			w.pushBacklog(dlv)
+
 			// Start backoff sleep timer.
 			backoff := time.NewTimer(d)
 
@@ -190,17 +210,26 @@ loop:
 
 			case <-w.Queue.Wait():
 				// A new message was
-				// queued, re-add this
-				// to backlog + retry.
-				w.pushBacklog(dlv)
+				// queued, retry.
 				backoff.Stop()
 				continue loop
 
 			case <-backoff.C:
-				// success!
+				// success! pop
+				// from backlog.
+				continue loop
 			}
 		}
 
+		// Get receiving host of delivery.
+		host := dlv.Request.URL.Host
+
+		// Drop deliveries to hosts marked as
+		// unavailable, unless due a probe.
+		if !w.Health.Allow(host) {
+			continue loop
+		}
+
 		// Attempt delivery of AP request.
 		rsp, retry, err := w.Client.DoOnce(
 			dlv.Request,
@@ -210,6 +239,9 @@ loop:
 		case err == nil:
 			// Ensure body closed.
 			_ = rsp.Body.Close()
+
+			// Mark host as healthy.
+			w.Health.Success(ctx, host)
 			continue loop
 
 		case errors.Is(err, context.Canceled) &&
@@ -225,8 +257,19 @@ loop:
 			// faster check in the if-clause.
 			w.Queue.Push(dlv)
 			continue loop
+		}
+
+		if IsHostFailure(err) {
+			// Mark failed delivery to host, only
+			// where the error is the host's fault.
+			w.Health.Failure(ctx, host, err)
+		}
+
+		// Store failure details on delivery.
+		dlv.lastErr = err.Error()
+		dlv.lastAt = time.Now()
 
-		case !retry:
+		if !retry {
 			// Drop deliveries when no
 			// retry requested, or they
 			// reached max (either).
@@ -250,14 +293,7 @@ func (w *Worker) next(ctx context.Context) (*Delivery, bool) {
 
 	if !ok {
 		// Check the backlog.
-		if len(w.backlog) > 0 {
-
-			// Sort by 'next' time.
-			sortDeliveries(w.backlog)
-
-			// Pop next delivery.
-			dlv := w.popBacklog()
-
+		if dlv := w.popBacklog(); dlv != nil {
 			return dlv, true
 		}
 
@@ -276,12 +312,19 @@ func (w *Worker) next(ctx context.Context) (*Delivery, bool) {
 	return dlv, true
 }
 
-// popBacklog pops next available from the backlog.
+// popBacklog sorts the backlog by 'next' time,
+// then pops next available from the backlog.
 func (w *Worker) popBacklog() *Delivery {
+	w.bmutex.Lock()
+	defer w.bmutex.Unlock()
+
 	if len(w.backlog) == 0 {
 		return nil
 	}
 
+	// Sort by 'next' time.
+	sortDeliveries(w.backlog)
+
 	// Pop from backlog.
 	dlv := w.backlog[0]
 
@@ -294,7 +337,34 @@ func (w *Worker) popBacklog() *Delivery {
 
 // pushBacklog pushes the given delivery to backlog.
 func (w *Worker) pushBacklog(dlv *Delivery) {
+	w.bmutex.Lock()
 	w.backlog = append(w.backlog, dlv)
+	w.bmutex.Unlock()
+}
+
+// rangeBacklog passes each backlogged delivery to fn.
+func (w *Worker) rangeBacklog(fn func(*Delivery)) {
+	w.bmutex.Lock()
+	defer w.bmutex.Unlock()
+	for _, dlv := range w.backlog {
+		fn(dlv)
+	}
+}
+
+// deleteBacklog drops all backlogged deliveries for
+// which fn returns true, returning the dropped entries.
+func (w *Worker) deleteBacklog(fn func(*Delivery) bool) []*Delivery {
+	w.bmutex.Lock()
+	defer w.bmutex.Unlock()
+	var deleted []*Delivery
+	w.backlog = slices.DeleteFunc(w.backlog, func(dlv *Delivery) bool {
+		if fn(dlv) {
+			deleted = append(deleted, dlv)
+			return true
+		}
+		return false
+	})
+	return deleted
 }
 
 // sortDeliveries sorts deliveries according
@@ -22,6 +22,7 @@ import (
 	"context"
 	"errors"
 	"net/url"
+	"strings"
 
 	"github.com/miekg/dns"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -32,6 +33,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
 // ASRepresentationToAccount converts a remote account / person
@@ -353,6 +355,15 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		log.Warnf(ctx, "unusable published property on %s", uri)
 	}
 
+	// status.EditedAt
+	//
+	// Extract updated time for the status,
+	// if set this indicates an edited status.
+	if upd := ap.GetUpdated(statusable); !upd.IsZero() &&
+		upd.After(status.CreatedAt) {
+		status.EditedAt = upd
+	}
+
 	// status.AccountURI
 	// status.AccountID
 	// status.Account
@@ -405,6 +416,36 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		}
 	}
 
+	// status.QuoteURI
+	// status.QuoteID
+	// status.Quote
+	// status.QuoteAccountID
+	// status.QuoteAccount
+	//
+	// Status that this status quotes, if applicable.
+	// As with inReplyTo, if we don't have this status
+	// in the database, we just set the URI and assume
+	// we can deref it later.
+	if quoteURI := ap.ExtractQuoteURI(statusable); quoteURI != nil {
+		status.QuoteURI = quoteURI.String()
+
+		// Check if we already have the quoted status.
+		quote, err := c.state.DB.GetStatusByURI(ctx, status.QuoteURI)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("error getting quote %s from db: %w", status.QuoteURI, err)
+			return nil, err
+		}
+
+		if quote != nil {
+			// We have it in the DB! Set
+			// appropriate fields here and now.
+			status.QuoteID = quote.ID
+			status.Quote = quote
+			status.QuoteAccountID = quote.AccountID
+			status.QuoteAccount = quote.Account
+		}
+	}
+
 	// Calculate intended visibility of the status.
 	status.Visibility, err = ap.ExtractVisibility(
 		statusable,
@@ -534,6 +575,119 @@ func (c *Converter) ASLikeToFave(ctx context.Context, likeable ap.Likeable) (*gt
 	}, nil
 }
 
+// ASLikeToReaction converts a remote activitystreams 'like' carrying an
+// emoji reaction into a gts model status reaction. If the reaction uses
+// a remote custom emoji that we don't have stored yet, the returned
+// model's Emoji will be a placeholder without an ID, which the caller
+// should dereference before storing the reaction.
+func (c *Converter) ASLikeToReaction(ctx context.Context, reactable ap.Reactable) (*gtsmodel.StatusReaction, error) {
+	uriObj := ap.GetJSONLDId(reactable)
+	if uriObj == nil {
+		err := gtserror.New("unusable iri property")
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	// Stringify uri obj.
+	uri := uriObj.String()
+
+	origin, err := c.getASActorAccount(ctx, uri, reactable)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := c.getASObjectStatus(ctx, uri, reactable)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction := &gtsmodel.StatusReaction{
+		AccountID:       origin.ID,
+		Account:         origin,
+		TargetAccountID: target.AccountID,
+		TargetAccount:   target.Account,
+		StatusID:        target.ID,
+		Status:          target,
+		URI:             uri,
+	}
+
+	content := ap.ExtractReaction(reactable)
+	if len(content) < 3 || content[0] != ':' || content[len(content)-1] != ':' {
+		// Not a custom emoji shortcode,
+		// so this should be a unicode emoji.
+		if err := validate.UnicodeEmoji(content); err != nil {
+			return nil, gtserror.SetMalformed(err)
+		}
+
+		reaction.Name = content
+		return reaction, nil
+	}
+
+	shortcode := strings.Trim(content, ":")
+	if err := validate.EmojiShortcode(shortcode); err != nil {
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	emojis, err := ap.ExtractEmojis(reactable)
+	if err != nil {
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	// Find the tagged emoji
+	// used for the reaction.
+	var emoji *gtsmodel.Emoji
+	for _, e := range emojis {
+		if e.Shortcode == shortcode {
+			emoji = e
+			break
+		}
+	}
+
+	if emoji == nil {
+		err := gtserror.Newf("no emoji tag for reaction %s", content)
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	reaction.Name = shortcode
+	if emoji.Domain == config.GetHost() {
+		// Reaction using one of our own
+		// emojis, these are stored with
+		// an empty domain in the database.
+		emoji.Domain = ""
+	} else {
+		// Remote custom emoji names are
+		// suffixed with their domain, to
+		// distinguish any with clashing
+		// shortcodes from one another.
+		reaction.Name += "@" + emoji.Domain
+	}
+
+	// Look for an existing stored emoji.
+	existing, err := c.state.DB.GetEmojiByShortcodeDomain(ctx,
+		emoji.Shortcode,
+		emoji.Domain,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting emoji %s: %w", content, err)
+	}
+
+	switch {
+	case existing != nil:
+		reaction.EmojiID = existing.ID
+		reaction.Emoji = existing
+
+	case emoji.Domain == "":
+		// We don't have this local emoji (anymore).
+		err := gtserror.Newf("local emoji %s not found", content)
+		return nil, gtserror.SetMalformed(err)
+
+	default:
+		// Placeholder to dereference.
+		reaction.Emoji = emoji
+	}
+
+	return reaction, nil
+}
+
 // ASBlockToBlock converts a remote activity streams 'block' representation into a gts model block.
 func (c *Converter) ASBlockToBlock(ctx context.Context, blockable ap.Blockable) (*gtsmodel.Block, error) {
 	uriObj := ap.GetJSONLDId(blockable)
@@ -20,6 +20,7 @@ package typeutils
 import (
 	"cmp"
 	"context"
+	"net/url"
 	"slices"
 	"strconv"
 
@@ -553,3 +554,195 @@ func (c *Converter) CSVToBlocks(
 
 	return blocks, nil
 }
+
+// CSVToMutes converts a slice of CSV records
+// to a slice of barebones *gtsmodel.UserMute's,
+// ready for further processing.
+//
+// Only TargetAccount.Username, TargetAccount.Domain,
+// and Notifications will be set on each UserMute.
+func (c *Converter) CSVToMutes(
+	ctx context.Context,
+	records [][]string,
+) ([]*gtsmodel.UserMute, error) {
+	mutes := make([]*gtsmodel.UserMute, 0, len(records))
+
+	for _, record := range records {
+		recordLen := len(record)
+
+		// Older versions of this Masto CSV
+		// schema may not include "Hide notifications",
+		// so be lenient here in what we accept.
+		if recordLen == 0 ||
+			recordLen > 2 {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// "Account address"
+		if record[0] == "Account address" {
+			// CSV header row,
+			// skip this one.
+			continue
+		}
+
+		username, domain, ok := csvNamestringParts(record[0])
+		if !ok {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// "Hide notifications",
+		// Mastodon defaults to true.
+		notifications := util.Ptr(true)
+		if recordLen > 1 {
+			b, err := strconv.ParseBool(record[1])
+			if err != nil {
+				// Badly formatted,
+				// skip this one.
+				continue
+			}
+			notifications = &b
+		}
+
+		// Looks good, whack it in the slice.
+		mutes = append(mutes, &gtsmodel.UserMute{
+			TargetAccount: &gtsmodel.Account{
+				Username: username,
+				Domain:   domain,
+			},
+			Notifications: notifications,
+		})
+	}
+
+	return mutes, nil
+}
+
+// CSVToBookmarks converts a slice of CSV records
+// to a slice of barebones *gtsmodel.StatusBookmark's,
+// ready for further processing.
+//
+// Only Status.URI will be set on each StatusBookmark.
+func (c *Converter) CSVToBookmarks(
+	ctx context.Context,
+	records [][]string,
+) ([]*gtsmodel.StatusBookmark, error) {
+	bookmarks := make([]*gtsmodel.StatusBookmark, 0, len(records))
+
+	for _, record := range records {
+		// NOTE: Mastodon-compatible bookmarks
+		// CSV doesn't use column headers, it's
+		// just one status URI per record.
+		if len(record) != 1 {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		uri, err := url.Parse(record[0])
+		if err != nil ||
+			(uri.Scheme != "https" && uri.Scheme != "http") ||
+			uri.Host == "" {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// Looks good, whack it in the slice.
+		bookmarks = append(bookmarks, &gtsmodel.StatusBookmark{
+			Status: &gtsmodel.Status{
+				URI: uri.String(),
+			},
+		})
+	}
+
+	return bookmarks, nil
+}
+
+// CSVToLists converts a slice of CSV records
+// to a slice of barebones *gtsmodel.ListEntry's,
+// ready for further processing.
+//
+// Only List.Title, and Follow.TargetAccount.Username
+// and Follow.TargetAccount.Domain will be set on
+// each ListEntry.
+func (c *Converter) CSVToLists(
+	ctx context.Context,
+	records [][]string,
+) ([]*gtsmodel.ListEntry, error) {
+	entries := make([]*gtsmodel.ListEntry, 0, len(records))
+
+	for _, record := range records {
+		// NOTE: Mastodon-compatible lists
+		// CSV doesn't use column headers.
+		if len(record) != 2 {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// "List name"
+		title := record[0]
+		if title == "" {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// "Account address"
+		username, domain, ok := csvNamestringParts(record[1])
+		if !ok {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// Looks good, whack it in the slice.
+		entries = append(entries, &gtsmodel.ListEntry{
+			List: &gtsmodel.List{
+				Title: title,
+			},
+			Follow: &gtsmodel.Follow{
+				TargetAccount: &gtsmodel.Account{
+					Username: username,
+					Domain:   domain,
+				},
+			},
+		})
+	}
+
+	return entries, nil
+}
+
+// csvNamestringParts extracts the username and domain
+// from the given CSV "Account address", with or without
+// the leading '@'. The returned domain will be empty if
+// the address is of a local account. If the address
+// is badly formatted, ok will be false.
+func csvNamestringParts(namestring string) (username string, domain string, ok bool) {
+	if namestring == "" {
+		return "", "", false
+	}
+
+	// Prepend with "@"
+	// if not included.
+	if namestring[0] != '@' {
+		namestring = "@" + namestring
+	}
+
+	username, domain, err := util.ExtractNamestringParts(namestring)
+	if err != nil {
+		return "", "", false
+	}
+
+	if domain == config.GetHost() ||
+		domain == config.GetAccountDomain() {
+		// Clear the domain,
+		// since it's ours.
+		domain = ""
+	}
+
+	return username, domain, true
+}
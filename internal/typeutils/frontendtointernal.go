@@ -166,10 +166,50 @@ func APIInteractionPolicyToInteractionPolicy(
 		return nil, err
 	}
 
+	// Clients that don't know about quotes
+	// won't set can_quote at all, so in that
+	// case just use the can_reblog rules.
+	canQuote := p.CanQuote
+	if canQuote.Always == nil && canQuote.WithApproval == nil {
+		canQuote = p.CanReblog
+	}
+
+	canQuoteAlways, err := convertURIs(canQuote.Always)
+	if err != nil {
+		err := fmt.Errorf("error converting %s.can_quote.always: %w", v, err)
+		return nil, err
+	}
+
+	canQuoteWithApproval, err := convertURIs(canQuote.WithApproval)
+	if err != nil {
+		err := fmt.Errorf("error converting %s.can_quote.with_approval: %w", v, err)
+		return nil, err
+	}
+
+	// Clients that don't know about reactions
+	// won't set can_react at all, so in that
+	// case just use the can_favourite rules.
+	canReact := p.CanReact
+	if canReact.Always == nil && canReact.WithApproval == nil {
+		canReact = p.CanFavourite
+	}
+
+	canReactAlways, err := convertURIs(canReact.Always)
+	if err != nil {
+		err := fmt.Errorf("error converting %s.can_react.always: %w", v, err)
+		return nil, err
+	}
+
+	canReactWithApproval, err := convertURIs(canReact.WithApproval)
+	if err != nil {
+		err := fmt.Errorf("error converting %s.can_react.with_approval: %w", v, err)
+		return nil, err
+	}
+
 	// Normalize URIs.
 	//
-	// 1. Ensure canLikeAlways, canReplyAlways,
-	//    and canAnnounceAlways include self
+	// 1. Ensure canLikeAlways, canReplyAlways, canAnnounceAlways,
+	//    canQuoteAlways, and canReactAlways include self
 	//    (either explicitly or within public).
 
 	// ensureIncludesSelf adds the "author" PolicyValue
@@ -200,6 +240,8 @@ func APIInteractionPolicyToInteractionPolicy(
 	canLikeAlways = ensureIncludesSelf(canLikeAlways)
 	canReplyAlways = ensureIncludesSelf(canReplyAlways)
 	canAnnounceAlways = ensureIncludesSelf(canAnnounceAlways)
+	canQuoteAlways = ensureIncludesSelf(canQuoteAlways)
+	canReactAlways = ensureIncludesSelf(canReactAlways)
 
 	// 2. Ensure canReplyAlways includes mentioned
 	//    accounts (either explicitly or within public).
@@ -229,5 +271,13 @@ func APIInteractionPolicyToInteractionPolicy(
 			Always:       canAnnounceAlways,
 			WithApproval: canAnnounceWithApproval,
 		},
+		CanQuote: gtsmodel.PolicyRules{
+			Always:       canQuoteAlways,
+			WithApproval: canQuoteWithApproval,
+		},
+		CanReact: gtsmodel.PolicyRules{
+			Always:       canReactAlways,
+			WithApproval: canReactWithApproval,
+		},
 	}, nil
 }
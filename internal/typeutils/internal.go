@@ -121,9 +121,20 @@ func StatusToInteractionRequest(
 		interactionType gtsmodel.InteractionType
 		reply           *gtsmodel.Status
 		announce        *gtsmodel.Status
+		quote           *gtsmodel.Status
 	)
 
-	if status.InReplyToID != "" {
+	switch {
+	case status.QuotePendingApproval:
+		// It's a quote.
+		targetID = status.QuoteID
+		target = status.Quote
+		targetAccountID = status.QuoteAccountID
+		targetAccount = status.QuoteAccount
+		interactionType = gtsmodel.InteractionQuote
+		quote = status
+
+	case status.InReplyToID != "":
 		// It's a reply.
 		targetID = status.InReplyToID
 		target = status.InReplyTo
@@ -131,7 +142,8 @@ func StatusToInteractionRequest(
 		targetAccount = status.InReplyToAccount
 		interactionType = gtsmodel.InteractionReply
 		reply = status
-	} else {
+
+	default:
 		// It's a boost.
 		targetID = status.BoostOfID
 		target = status.BoostOf
@@ -154,6 +166,7 @@ func StatusToInteractionRequest(
 		InteractionType:      interactionType,
 		Reply:                reply,
 		Announce:             announce,
+		Quote:                quote,
 	}, nil
 }
 
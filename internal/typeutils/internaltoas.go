@@ -484,11 +484,26 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 		status.SetActivityStreamsInReplyTo(inReplyToProp)
 	}
 
+	// quote -- not part of our vocabulary, so
+	// set it as an unknown property, using each
+	// of the property names in common use.
+	if s.QuoteURI != "" {
+		unknown := status.GetUnknownProperties()
+		unknown["quote"] = s.QuoteURI
+		unknown["quoteUrl"] = s.QuoteURI
+		unknown["_misskey_quote"] = s.QuoteURI
+	}
+
 	// published
 	publishedProp := streams.NewActivityStreamsPublishedProperty()
 	publishedProp.Set(s.CreatedAt)
 	status.SetActivityStreamsPublished(publishedProp)
 
+	// updated
+	if !s.EditedAt.IsZero() {
+		ap.SetUpdated(status, s.EditedAt)
+	}
+
 	// url
 	if s.URL != "" {
 		sURL, err := url.Parse(s.URL)
@@ -560,6 +575,15 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 		}
 		tagProp.AppendTootHashtag(asHashtag)
 	}
+
+	// tag -- quote object link (FEP-e232)
+	if s.QuoteURI != "" {
+		asLink, err := quoteToASLink(s.QuoteURI)
+		if err != nil {
+			return nil, gtserror.Newf("error converting quote to AS link: %w", err)
+		}
+		tagProp.AppendActivityStreamsLink(asLink)
+	}
 	status.SetActivityStreamsTag(tagProp)
 
 	// parse out some URIs we need here
@@ -777,6 +801,31 @@ func (c *Converter) addPollToAS(ctx context.Context, poll *gtsmodel.Poll, dst ap
 	return nil
 }
 
+// quoteToASLink returns an FEP-e232 object
+// link pointing to the given quoted status URI.
+func quoteToASLink(quoteURI string) (vocab.ActivityStreamsLink, error) {
+	href, err := url.Parse(quoteURI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing url %s: %w", quoteURI, err)
+	}
+
+	link := streams.NewActivityStreamsLink()
+
+	mediaTypeProp := streams.NewActivityStreamsMediaTypeProperty()
+	mediaTypeProp.Set(`application/ld+json; profile="https://www.w3.org/ns/activitystreams"`)
+	link.SetActivityStreamsMediaType(mediaTypeProp)
+
+	hrefProp := streams.NewActivityStreamsHrefProperty()
+	hrefProp.SetIRI(href)
+	link.SetActivityStreamsHref(hrefProp)
+
+	nameProp := streams.NewActivityStreamsNameProperty()
+	nameProp.AppendXMLSchemaString("RE: " + quoteURI)
+	link.SetActivityStreamsName(nameProp)
+
+	return link, nil
+}
+
 // StatusToASDelete converts a gts model status into a Delete of that status, using just the
 // URI of the status as object, and addressing the Delete appropriately.
 func (c *Converter) StatusToASDelete(ctx context.Context, s *gtsmodel.Status) (vocab.ActivityStreamsDelete, error) {
@@ -965,6 +1014,101 @@ func (c *Converter) FollowToAS(ctx context.Context, f *gtsmodel.Follow) (vocab.A
 	return follow, nil
 }
 
+// RelayToASFollow converts the given relay into an activity streams
+// Follow from the given instance account to the relay. LitePub-style
+// relays are followed directly, while Mastodon-style relays expect a
+// Follow of the Public collection.
+func (c *Converter) RelayToASFollow(
+	ctx context.Context,
+	r *gtsmodel.Relay,
+	instanceAcct *gtsmodel.Account,
+) (vocab.ActivityStreamsFollow, error) {
+	actorIRI, err := url.Parse(instanceAcct.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing instance account uri: %w", err)
+	}
+
+	followIRI, err := url.Parse(r.FollowURI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing follow uri: %w", err)
+	}
+
+	objectURI := pub.PublicActivityPubIRI
+	if r.IsLitePub() {
+		objectURI = r.ActorURI
+	}
+
+	objectIRI, err := url.Parse(objectURI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing object uri: %w", err)
+	}
+
+	follow := streams.NewActivityStreamsFollow()
+
+	// Set the Follow ID.
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(followIRI)
+	follow.SetJSONLDId(idProp)
+
+	// Set the instance account as Actor.
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(actorIRI)
+	follow.SetActivityStreamsActor(actorProp)
+
+	// Set the relay actor or Public as Object.
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(objectIRI)
+	follow.SetActivityStreamsObject(objectProp)
+
+	if r.IsLitePub() {
+		// Address LitePub Follow
+		// directly to the relay actor.
+		toProp := streams.NewActivityStreamsToProperty()
+		toProp.AppendIRI(objectIRI)
+		follow.SetActivityStreamsTo(toProp)
+	}
+
+	return follow, nil
+}
+
+// RelayToASUndoFollow converts the given relay into an activity
+// streams Undo of the Follow from the instance account to the relay.
+func (c *Converter) RelayToASUndoFollow(
+	ctx context.Context,
+	r *gtsmodel.Relay,
+	instanceAcct *gtsmodel.Account,
+) (vocab.ActivityStreamsUndo, error) {
+	follow, err := c.RelayToASFollow(ctx, r, instanceAcct)
+	if err != nil {
+		return nil, err
+	}
+
+	undo := streams.NewActivityStreamsUndo()
+
+	// Set the Undo ID.
+	undoIRI, err := url.Parse(r.FollowURI + "/undo")
+	if err != nil {
+		return nil, gtserror.Newf("error parsing undo uri: %w", err)
+	}
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(undoIRI)
+	undo.SetJSONLDId(idProp)
+
+	// Same Actor and To as the Follow.
+	undo.SetActivityStreamsActor(follow.GetActivityStreamsActor())
+	if to := follow.GetActivityStreamsTo(); to != nil {
+		undo.SetActivityStreamsTo(to)
+	}
+
+	// Relays expect the whole
+	// Follow as the Undo Object.
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendActivityStreamsFollow(follow)
+	undo.SetActivityStreamsObject(objectProp)
+
+	return undo, nil
+}
+
 // MentionToAS converts a gts model mention into an activity streams Mention, suitable for federation
 func (c *Converter) MentionToAS(ctx context.Context, m *gtsmodel.Mention) (vocab.ActivityStreamsMention, error) {
 	if m.TargetAccount == nil {
@@ -1217,6 +1361,104 @@ func (c *Converter) FaveToAS(ctx context.Context, f *gtsmodel.StatusFave) (vocab
 	return like, nil
 }
 
+// ReactionToAS converts a gts model status reaction into an activityStreams
+// LIKE, suitable for federation. The emoji is set as content of the Like, and
+// as "_misskey_reaction" for Misskey. Custom emojis are also included as tag.
+// We want to end up with something like this:
+//
+//	{
+//		"@context": "https://www.w3.org/ns/activitystreams",
+//		"_misskey_reaction": ":blobcat:",
+//		"actor": "http://localhost:8080/users/the_mighty_zork",
+//		"content": ":blobcat:",
+//		"id": "http://localhost:8080/users/the_mighty_zork/liked/01JAFYN4JPS9N5ZPPDJGEDAMDY",
+//		"object": "http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R",
+//		"tag": {
+//			"icon": {
+//				"mediaType": "image/png",
+//				"type": "Image",
+//				"url": "http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png"
+//			},
+//			"id": "http://localhost:8080/emoji/01F8MH9H8E4VG3KDYJR9EGPXCQ",
+//			"name": ":rainbow:",
+//			"type": "Emoji",
+//			"updated": "2021-09-20T10:40:37Z"
+//		},
+//		"to": "http://localhost:8080/users/admin",
+//		"type": "Like"
+//	}
+func (c *Converter) ReactionToAS(ctx context.Context, r *gtsmodel.StatusReaction) (vocab.ActivityStreamsLike, error) {
+	if err := c.state.DB.PopulateStatusReaction(ctx, r); err != nil {
+		return nil, gtserror.Newf("error populating reaction: %w", err)
+	}
+
+	// create the like
+	like := streams.NewActivityStreamsLike()
+
+	// set the actor property to the reacting account's URI
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorIRI, err := url.Parse(r.Account.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", r.Account.URI, err)
+	}
+	actorProp.AppendIRI(actorIRI)
+	like.SetActivityStreamsActor(actorProp)
+
+	// set the ID property to the reaction's URI
+	idProp := streams.NewJSONLDIdProperty()
+	idIRI, err := url.Parse(r.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", r.URI, err)
+	}
+	idProp.Set(idIRI)
+	like.SetJSONLDId(idProp)
+
+	// set the object property to the target status's URI
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	statusIRI, err := url.Parse(r.Status.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", r.Status.URI, err)
+	}
+	objectProp.AppendIRI(statusIRI)
+	like.SetActivityStreamsObject(objectProp)
+
+	// set the TO property to the target account's IRI
+	toProp := streams.NewActivityStreamsToProperty()
+	toIRI, err := url.Parse(r.TargetAccount.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", r.TargetAccount.URI, err)
+	}
+	toProp.AppendIRI(toIRI)
+	like.SetActivityStreamsTo(toProp)
+
+	// Unicode emojis are sent as-is,
+	// custom emojis as :shortcode:
+	// with the emoji itself as tag.
+	content := r.Name
+	if r.Emoji != nil {
+		content = ":" + r.Emoji.Shortcode + ":"
+
+		asEmoji, err := c.EmojiToAS(ctx, r.Emoji)
+		if err != nil {
+			return nil, gtserror.Newf("error converting emoji to AS: %w", err)
+		}
+
+		tagProp := streams.NewActivityStreamsTagProperty()
+		tagProp.AppendTootEmoji(asEmoji)
+		like.SetActivityStreamsTag(tagProp)
+	}
+
+	contentProp := streams.NewActivityStreamsContentProperty()
+	contentProp.AppendXMLSchemaString(content)
+	like.SetActivityStreamsContent(contentProp)
+
+	// There's no _misskey_reaction in our
+	// vocabulary, so set it as unknown prop.
+	like.GetUnknownProperties()["_misskey_reaction"] = content
+
+	return like, nil
+}
+
 // BoostToAS converts a gts model boost into an activityStreams ANNOUNCE, suitable for federation
 func (c *Converter) BoostToAS(ctx context.Context, boostWrapperStatus *gtsmodel.Status, boostingAccount *gtsmodel.Account, boostedAccount *gtsmodel.Account) (vocab.ActivityStreamsAnnounce, error) {
 	// the boosted status is probably pinned to the boostWrapperStatus but double check to make sure
@@ -1957,6 +2199,106 @@ func (c *Converter) InteractionPolicyToASInteractionPolicy(
 	canAnnounceProp.AppendGoToSocialCanAnnounce(canAnnounce)
 	policy.SetGoToSocialCanAnnounce(canAnnounceProp)
 
+	/*
+		CAN QUOTE
+	*/
+
+	// Policies created before quotes
+	// were supported have no canQuote
+	// rules, so fall back to canAnnounce.
+	canQuoteRules := interactionPolicy.CanQuote
+	if canQuoteRules.IsZero() {
+		canQuoteRules = interactionPolicy.CanAnnounce
+	}
+
+	// Build canQuote.always
+	canQuoteAlwaysProp := streams.NewGoToSocialAlwaysProperty()
+	if err := populateValuesForProp(
+		canQuoteAlwaysProp,
+		status,
+		canQuoteRules.Always,
+	); err != nil {
+		return nil, gtserror.Newf("error setting canQuote.always: %w", err)
+	}
+
+	canQuoteAlways, err := canQuoteAlwaysProp.Serialize()
+	if err != nil {
+		return nil, gtserror.Newf("error serializing canQuote.always: %w", err)
+	}
+
+	// Build canQuote.approvalRequired
+	canQuoteApprovalRequiredProp := streams.NewGoToSocialApprovalRequiredProperty()
+	if err := populateValuesForProp(
+		canQuoteApprovalRequiredProp,
+		status,
+		canQuoteRules.WithApproval,
+	); err != nil {
+		return nil, gtserror.Newf("error setting canQuote.approvalRequired: %w", err)
+	}
+
+	canQuoteApprovalRequired, err := canQuoteApprovalRequiredProp.Serialize()
+	if err != nil {
+		return nil, gtserror.Newf("error serializing canQuote.approvalRequired: %w", err)
+	}
+
+	// Set canQuote on the policy. There's no
+	// canQuote in our vocabulary (yet), so
+	// set it as an unknown property instead.
+	policy.GetUnknownProperties()["canQuote"] = map[string]interface{}{
+		"always":           canQuoteAlways,
+		"approvalRequired": canQuoteApprovalRequired,
+	}
+
+	/*
+		CAN REACT
+	*/
+
+	// Policies created before reactions
+	// were supported have no canReact
+	// rules, so fall back to canLike.
+	canReactRules := interactionPolicy.CanReact
+	if canReactRules.IsZero() {
+		canReactRules = interactionPolicy.CanLike
+	}
+
+	// Build canReact.always
+	canReactAlwaysProp := streams.NewGoToSocialAlwaysProperty()
+	if err := populateValuesForProp(
+		canReactAlwaysProp,
+		status,
+		canReactRules.Always,
+	); err != nil {
+		return nil, gtserror.Newf("error setting canReact.always: %w", err)
+	}
+
+	canReactAlways, err := canReactAlwaysProp.Serialize()
+	if err != nil {
+		return nil, gtserror.Newf("error serializing canReact.always: %w", err)
+	}
+
+	// Build canReact.approvalRequired
+	canReactApprovalRequiredProp := streams.NewGoToSocialApprovalRequiredProperty()
+	if err := populateValuesForProp(
+		canReactApprovalRequiredProp,
+		status,
+		canReactRules.WithApproval,
+	); err != nil {
+		return nil, gtserror.Newf("error setting canReact.approvalRequired: %w", err)
+	}
+
+	canReactApprovalRequired, err := canReactApprovalRequiredProp.Serialize()
+	if err != nil {
+		return nil, gtserror.Newf("error serializing canReact.approvalRequired: %w", err)
+	}
+
+	// Set canReact on the policy. There's no
+	// canReact in our vocabulary (yet), so
+	// set it as an unknown property instead.
+	policy.GetUnknownProperties()["canReact"] = map[string]interface{}{
+		"always":           canReactAlways,
+		"approvalRequired": canReactApprovalRequired,
+	}
+
 	return policy, nil
 }
 
@@ -476,6 +476,18 @@ func (suite *InternalToASTestSuite) TestStatusToAS() {
       ],
       "approvalRequired": []
     },
+    "canQuote": {
+      "always": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ],
+      "approvalRequired": []
+    },
+    "canReact": {
+      "always": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ],
+      "approvalRequired": []
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
@@ -558,6 +570,18 @@ func (suite *InternalToASTestSuite) TestStatusWithTagsToASWithIDs() {
       ],
       "approvalRequired": []
     },
+    "canQuote": {
+      "always": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ],
+      "approvalRequired": []
+    },
+    "canReact": {
+      "always": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ],
+      "approvalRequired": []
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
@@ -658,6 +682,18 @@ func (suite *InternalToASTestSuite) TestStatusWithTagsToASFromDB() {
       ],
       "approvalRequired": []
     },
+    "canQuote": {
+      "always": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ],
+      "approvalRequired": []
+    },
+    "canReact": {
+      "always": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ],
+      "approvalRequired": []
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
@@ -751,6 +787,18 @@ func (suite *InternalToASTestSuite) TestStatusToASWithMentions() {
       ],
       "approvalRequired": []
     },
+    "canQuote": {
+      "always": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ],
+      "approvalRequired": []
+    },
+    "canReact": {
+      "always": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ],
+      "approvalRequired": []
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
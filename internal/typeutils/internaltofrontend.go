@@ -32,6 +32,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/usermute"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/language"
@@ -39,6 +40,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 const (
@@ -98,6 +100,10 @@ func (c *Converter) UserToAPIUser(ctx context.Context, u *gtsmodel.User) *apimod
 		user.ResetPasswordSentAt = util.FormatISO8601(u.ResetPasswordSentAt)
 	}
 
+	if !u.TwoFactorEnabledAt.IsZero() {
+		user.TwoFactorEnabledAt = util.FormatISO8601(u.TwoFactorEnabledAt)
+	}
+
 	return user
 }
 
@@ -610,13 +616,21 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 }
 
 func (c *Converter) AppToAPIAppSensitive(ctx context.Context, a *gtsmodel.Application) (*apimodel.Application, error) {
+	vapidKeyPair, err := webpush.GetOrCreateVAPIDKeyPair(ctx, c.state.DB)
+	if err != nil {
+		return nil, gtserror.Newf("error getting vapid key pair: %w", err)
+	}
+
 	return &apimodel.Application{
 		ID:           a.ID,
+		CreatedAt:    util.FormatISO8601(a.CreatedAt),
 		Name:         a.Name,
 		Website:      a.Website,
 		RedirectURI:  a.RedirectURI,
 		ClientID:     a.ClientID,
 		ClientSecret: a.ClientSecret,
+		VapidKey:     vapidKeyPair.Public,
+		Scopes:       strings.Fields(a.Scopes),
 	}, nil
 }
 
@@ -630,6 +644,43 @@ func (c *Converter) AppToAPIAppPublic(ctx context.Context, a *gtsmodel.Applicati
 	}, nil
 }
 
+// TokenToAPITokenInfo converts a gts model token into its api representation
+// for serialization on the API. The token's application will be fetched from
+// the db, and only public details about the application (plus its ID) will be included.
+func (c *Converter) TokenToAPITokenInfo(ctx context.Context, token *gtsmodel.Token) (*apimodel.TokenInfo, error) {
+	app, err := c.state.DB.GetApplicationByClientID(ctx, token.ClientID)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting application for token %s: %w", token.ID, err)
+	}
+
+	apiApp, err := c.AppToAPIAppPublic(ctx, app)
+	if err != nil {
+		return nil, gtserror.Newf("error converting application %s: %w", app.ID, err)
+	}
+
+	// Include app ID so the token
+	// owner can manage tokens by app.
+	apiApp.ID = app.ID
+
+	createdAt := token.AccessCreateAt
+	if createdAt.IsZero() {
+		createdAt = token.CreatedAt
+	}
+
+	var lastUsed string
+	if !token.LastUsed.IsZero() {
+		lastUsed = util.FormatISO8601(token.LastUsed)
+	}
+
+	return &apimodel.TokenInfo{
+		ID:          token.ID,
+		CreatedAt:   util.FormatISO8601(createdAt),
+		LastUsed:    lastUsed,
+		Scope:       token.Scope,
+		Application: apiApp,
+	}, nil
+}
+
 // AttachmentToAPIAttachment converts a gts model media attacahment into its api representation for serialization on the API.
 func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, media *gtsmodel.MediaAttachment) (apimodel.Attachment, error) {
 	var api apimodel.Attachment
@@ -674,6 +725,15 @@ func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, media *gtsmod
 
 			// Copy over local thumbnail file URL.
 			api.PreviewURL = util.Ptr(media.Thumbnail.URL)
+
+			// Add any additional thumbnail sizes.
+			for _, variant := range media.ThumbnailVariants {
+				api.Meta.Thumbnails = append(api.Meta.Thumbnails, apimodel.MediaThumbnail{
+					URL:    variant.URL,
+					Width:  variant.Width,
+					Height: variant.Height,
+				})
+			}
 		}
 	}
 
@@ -788,12 +848,12 @@ func (c *Converter) TagToAPITag(ctx context.Context, t *gtsmodel.Tag, stubHistor
 	return apimodel.Tag{
 		Name: strings.ToLower(t.Name),
 		URL:  uris.URIForTag(t.Name),
-		History: func() *[]any {
+		History: func() *[]apimodel.History {
 			if !stubHistory {
 				return nil
 			}
 
-			h := make([]any, 0)
+			h := make([]apimodel.History, 0)
 			return &h
 		}(),
 		Following: following,
@@ -1205,6 +1265,7 @@ func (c *Converter) StatusToWebStatus(
 			Sensitive:       apiStatus.Sensitive,
 			MIMEType:        ogAttachment.File.ContentType,
 			PreviewMIMEType: ogAttachment.Thumbnail.ContentType,
+			PreviewVariants: previewVariants(apiAttachment),
 		}
 	}
 
@@ -1215,17 +1276,114 @@ func (c *Converter) StatusToWebStatus(
 // Callers should check beforehand whether a requester has permission to view the
 // source of the status, and ensure they're passing only a local status into this function.
 func (c *Converter) StatusToAPIStatusSource(ctx context.Context, s *gtsmodel.Status) (*apimodel.StatusSource, error) {
-	// TODO: remove this when edit support is added.
-	text := "**STATUS EDITS ARE NOT CURRENTLY SUPPORTED IN GOTOSOCIAL (coming in 2024)**\n" +
-		"You can review the original text of your status below, but you will not be able to submit this edit.\n\n---\n\n" + s.Text
-
 	return &apimodel.StatusSource{
 		ID:          s.ID,
-		Text:        text,
+		Text:        s.Text,
 		SpoilerText: s.ContentWarning,
 	}, nil
 }
 
+// StatusToAPIEdits converts a status and its historical revisions
+// into a slice of frontend API model status edits, ordered oldest
+// first, with the current version of the status as the final entry.
+func (c *Converter) StatusToAPIEdits(ctx context.Context, s *gtsmodel.Status) ([]*apimodel.StatusEdit, error) {
+	// Ensure full status is populated, we need
+	// account, edits, attachments, poll and emojis.
+	if err := c.state.DB.PopulateStatus(ctx, s); err != nil {
+		if s.Account == nil {
+			return nil, gtserror.Newf("error(s) populating status, required account not set: %w", err)
+		}
+		log.Errorf(ctx, "error(s) populating status, will continue: %v", err)
+	}
+
+	apiAccount, err := c.AccountToAPIAccountPublic(ctx, s.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting account: %w", err)
+	}
+
+	// Status edits don't store their own emojis,
+	// so use the current status emojis for each.
+	apiEmojis, err := c.convertEmojisToAPIEmojis(ctx, s.Emojis, s.EmojiIDs)
+	if err != nil {
+		log.Errorf(ctx, "error converting status emojis: %v", err)
+	}
+
+	apiEdits := make([]*apimodel.StatusEdit, 0, len(s.Edits)+1)
+
+	for _, edit := range s.Edits {
+		apiAttachments, err := c.convertAttachmentsToAPIAttachments(ctx,
+			edit.Attachments,
+			edit.AttachmentIDs,
+		)
+		if err != nil {
+			log.Errorf(ctx, "error converting edit attachments: %v", err)
+		}
+
+		var apiPoll *apimodel.Poll
+		if len(edit.PollOptions) > 0 {
+			apiPoll = &apimodel.Poll{
+				Options: make([]apimodel.PollOption, len(edit.PollOptions)),
+				Emojis:  apiEmojis,
+			}
+			for i, title := range edit.PollOptions {
+				apiPoll.Options[i].Title = title
+				if i < len(edit.PollVotes) {
+					votes := edit.PollVotes[i]
+					apiPoll.Options[i].VotesCount = &votes
+					apiPoll.VotesCount += votes
+				}
+			}
+		}
+
+		apiEdits = append(apiEdits, &apimodel.StatusEdit{
+			Content:          edit.Content,
+			SpoilerText:      edit.ContentWarning,
+			Sensitive:        util.PtrOrZero(edit.Sensitive),
+			CreatedAt:        util.FormatISO8601(edit.CreatedAt),
+			Account:          apiAccount,
+			Poll:             apiPoll,
+			MediaAttachments: apiAttachments,
+			Emojis:           apiEmojis,
+		})
+	}
+
+	// Finally, add the current version of the status.
+	apiAttachments, err := c.convertAttachmentsToAPIAttachments(ctx,
+		s.Attachments,
+		s.AttachmentIDs,
+	)
+	if err != nil {
+		log.Errorf(ctx, "error converting status attachments: %v", err)
+	}
+
+	var apiPoll *apimodel.Poll
+	if s.Poll != nil {
+		// Convert without requester so no own votes are set.
+		apiPoll, err = c.PollToAPIPoll(ctx, nil, s.Poll)
+		if err != nil {
+			return nil, gtserror.Newf("error converting poll: %w", err)
+		}
+	}
+
+	createdAt := s.CreatedAt
+	if !s.EditedAt.IsZero() {
+		createdAt = s.EditedAt
+	}
+
+	apiEdits = append(apiEdits, &apimodel.StatusEdit{
+		Content:          s.Content,
+		SpoilerText:      s.ContentWarning,
+		Sensitive:        util.PtrOrZero(s.Sensitive),
+		CreatedAt:        util.FormatISO8601(createdAt),
+		Account:          apiAccount,
+		Poll:             apiPoll,
+		MediaAttachments: apiAttachments,
+		Emojis:           apiEmojis,
+	})
+
+	return apiEdits, nil
+}
+
 // statusToFrontend is a package internal function for
 // parsing a status into its initial frontend representation.
 //
@@ -1280,9 +1438,124 @@ func (c *Converter) statusToFrontend(
 		apiStatus.Filtered = apiStatus.Reblog.Filtered
 	}
 
+	// Set quote of the status, or of
+	// the boosted status if this is a boost.
+	if status.BoostOf != nil {
+		apiStatus.Reblog.Quote, err = c.statusQuoteToFrontend(ctx,
+			status.BoostOf,
+			requestingAccount,
+			filterContext,
+			filters,
+			mutes,
+		)
+	} else {
+		apiStatus.Quote, err = c.statusQuoteToFrontend(ctx,
+			status,
+			requestingAccount,
+			filterContext,
+			filters,
+			mutes,
+		)
+	}
+	if err != nil {
+		return nil, gtserror.Newf("error converting quoted status: %w", err)
+	}
+
 	return apiStatus, nil
 }
 
+// statusQuoteToFrontend converts the quote (if any) of
+// the given status into a frontend quote model, taking
+// account of the quote's approval state, and visibility
+// of the quoted status to the requesting account.
+//
+// Quotes of quotes are not expanded, to prevent
+// possibly endless recursion, so this function
+// uses baseStatusToFrontend for the quoted status.
+func (c *Converter) statusQuoteToFrontend(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	requestingAccount *gtsmodel.Account,
+	filterContext statusfilter.FilterContext,
+	filters []*gtsmodel.Filter,
+	mutes *usermute.CompiledUserMuteList,
+) (*apimodel.StatusQuote, error) {
+	if status.QuoteURI == "" {
+		// Not a quote.
+		return nil, nil
+	}
+
+	if status.Quote == nil {
+		// Quoted status deleted, or
+		// not dereferenced (yet).
+		return &apimodel.StatusQuote{
+			State: apimodel.StatusQuoteStateDeleted,
+		}, nil
+	}
+
+	if util.PtrOrValue(status.PendingApproval, false) {
+		// Status is pending approval, check
+		// whether this is because of the quote.
+		req, err := c.state.DB.GetInteractionRequestByInteractionURI(
+			gtscontext.SetBarebones(ctx),
+			status.URI,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("db error getting interaction request: %w", err)
+		}
+
+		if req != nil &&
+			req.InteractionType == gtsmodel.InteractionQuote &&
+			!req.IsAccepted() {
+			return &apimodel.StatusQuote{
+				State: apimodel.StatusQuoteStatePending,
+			}, nil
+		}
+	}
+
+	visible, err := c.visFilter.StatusVisible(ctx,
+		requestingAccount,
+		status.Quote,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error checking quote visibility: %w", err)
+	}
+
+	if !visible {
+		return &apimodel.StatusQuote{
+			State: apimodel.StatusQuoteStateUnauthorized,
+		}, nil
+	}
+
+	quoted, err := c.baseStatusToFrontend(ctx,
+		status.Quote,
+		requestingAccount,
+		filterContext,
+		filters,
+		mutes,
+	)
+	if errors.Is(err, statusfilter.ErrHideStatus) {
+		// Quoted status is filtered or muted,
+		// so treat it as unauthorized (hidden).
+		return &apimodel.StatusQuote{
+			State: apimodel.StatusQuoteStateUnauthorized,
+		}, nil
+	} else if err != nil {
+		return nil, gtserror.Newf("error converting quoted status: %w", err)
+	}
+
+	// Convert author of quoted status to API model.
+	quoted.Account, err = c.AccountToAPIAccountPublic(ctx, status.Quote.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting quoted status acct: %w", err)
+	}
+
+	return &apimodel.StatusQuote{
+		State:        apimodel.StatusQuoteStateAccepted,
+		QuotedStatus: quoted,
+	}, nil
+}
+
 // baseStatusToFrontend performs the main logic
 // of statusToFrontend() without handling of boost
 // logic, to prevent *possible* recursion issues.
@@ -1331,6 +1604,16 @@ func (c *Converter) baseStatusToFrontend(
 		return nil, gtserror.Newf("error counting faves: %w", err)
 	}
 
+	reactions, err := c.state.DB.GetStatusReactions(ctx, s.ID)
+	if err != nil {
+		return nil, gtserror.Newf("error getting reactions: %w", err)
+	}
+
+	var requesterID string
+	if requestingAccount != nil {
+		requesterID = requestingAccount.ID
+	}
+
 	apiAttachments, err := c.convertAttachmentsToAPIAttachments(ctx, s.Attachments, s.AttachmentIDs)
 	if err != nil {
 		log.Errorf(ctx, "error converting status attachments: %v", err)
@@ -1380,6 +1663,7 @@ func (c *Converter) baseStatusToFrontend(
 		RepliesCount:       repliesCount,
 		ReblogsCount:       reblogsCount,
 		FavouritesCount:    favesCount,
+		EmojiReactions:     statusReactionsToAPIReactions(reactions, requesterID),
 		Content:            s.Content,
 		Reblog:             nil, // Set below.
 		Application:        nil, // Set below.
@@ -1406,6 +1690,19 @@ func (c *Converter) baseStatusToFrontend(
 		apiStatus.Language = util.Ptr(s.Language)
 	}
 
+	if !s.EditedAt.IsZero() {
+		apiStatus.EditedAt = util.Ptr(util.FormatISO8601(s.EditedAt))
+	}
+
+	// Apply any admin content limits on the
+	// author, so that statuses stored before
+	// the limit was put in place are covered.
+	limits, err := c.state.DB.GetContentLimitsForAccount(ctx, s.Account)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting content limits: %w", err)
+	}
+	applyContentLimits(limits, apiStatus)
+
 	if app := s.CreatedWithApplication; app != nil {
 		apiStatus.Application, err = c.AppToAPIAppPublic(ctx, app)
 		if err != nil {
@@ -1817,13 +2114,38 @@ func (c *Converter) NotificationToAPINotification(
 		apiStatus = apiStatus.Reblog.Status
 	}
 
-	return &apimodel.Notification{
+	apiNotif := &apimodel.Notification{
 		ID:        n.ID,
 		Type:      string(n.NotificationType),
 		CreatedAt: util.FormatISO8601(n.CreatedAt),
 		Account:   apiAccount,
 		Status:    apiStatus,
-	}, nil
+	}
+
+	if n.NotificationType == gtsmodel.NotificationReaction {
+		// Notifications don't store which emoji
+		// was used, so find the latest reaction
+		// from origin account to this status.
+		reactions, err := c.state.DB.GetStatusReactions(ctx, n.StatusID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, fmt.Errorf("NotificationToapi: error getting status reactions: %w", err)
+		}
+
+		for i := len(reactions) - 1; i >= 0; i-- {
+			reaction := reactions[i]
+			if reaction.AccountID != n.OriginAccountID {
+				continue
+			}
+
+			apiNotif.Emoji = reaction.Name
+			if reaction.Emoji != nil {
+				apiNotif.EmojiURL = reaction.Emoji.ImageURL
+			}
+			break
+		}
+	}
+
+	return apiNotif, nil
 }
 
 // ConversationToAPIConversation converts a conversation into its API representation.
@@ -1921,9 +2243,111 @@ func (c *Converter) DomainPermToAPIDomainPerm(
 	domainPerm.CreatedBy = d.GetCreatedByAccountID()
 	domainPerm.CreatedAt = util.FormatISO8601(d.GetCreatedAt())
 
+	// Drafts may be of either type, so
+	// indicate which type this one is.
+	if _, ok := d.(*gtsmodel.DomainPermissionDraft); ok {
+		domainPerm.PermissionType = d.GetType().String()
+	}
+
 	return domainPerm, nil
 }
 
+// DomainPermSubToAPIDomainPermSub converts the given
+// domain permission subscription to its API model.
+func (c *Converter) DomainPermSubToAPIDomainPermSub(
+	ctx context.Context,
+	d *gtsmodel.DomainPermissionSubscription,
+) (*apimodel.DomainPermissionSubscription, error) {
+	apiSub := &apimodel.DomainPermissionSubscription{
+		ID:             d.ID,
+		Priority:       d.Priority,
+		Title:          d.Title,
+		PermissionType: d.PermissionType.String(),
+		AsDraft:        *d.AsDraft,
+		CreatedBy:      d.CreatedByAccountID,
+		CreatedAt:      util.FormatISO8601(d.CreatedAt),
+		URI:            d.URI,
+		ContentType:    d.ContentType.String(),
+		FetchUsername:  d.FetchUsername,
+		FetchPassword:  d.FetchPassword,
+		Error:          d.Error,
+	}
+
+	if !d.FetchedAt.IsZero() {
+		apiSub.FetchedAt = util.FormatISO8601(d.FetchedAt)
+	}
+
+	if !d.SuccessfullyFetchedAt.IsZero() {
+		apiSub.SuccessfullyFetchedAt = util.FormatISO8601(d.SuccessfullyFetchedAt)
+	}
+
+	return apiSub, nil
+}
+
+// ContentLimitToAPIContentLimit converts the
+// given content limit to its API representation.
+func (c *Converter) ContentLimitToAPIContentLimit(
+	ctx context.Context,
+	l *gtsmodel.ContentLimit,
+) (*apimodel.ContentLimit, error) {
+	return &apimodel.ContentLimit{
+		ID:             l.ID,
+		AccountID:      l.AccountID,
+		Domain:         l.Domain,
+		ForceSensitive: *l.ForceSensitive,
+		ContentWarning: l.ContentWarning,
+		RejectMedia:    *l.RejectMedia,
+		PrivateComment: l.PrivateComment,
+		CreatedBy:      l.CreatedByAccountID,
+		CreatedAt:      util.FormatISO8601(l.CreatedAt),
+		UpdatedAt:      util.FormatISO8601(l.UpdatedAt),
+	}, nil
+}
+
+// AccountArchiveToAPIAccountArchive converts the
+// given account archive to its API representation.
+func (c *Converter) AccountArchiveToAPIAccountArchive(
+	ctx context.Context,
+	a *gtsmodel.AccountArchive,
+) (*apimodel.AccountArchive, error) {
+	apiArchive := &apimodel.AccountArchive{
+		ID:        a.ID,
+		CreatedAt: util.FormatISO8601(a.CreatedAt),
+		State:     a.State.String(),
+		Size:      a.Size,
+	}
+
+	if !a.ExpiresAt.IsZero() {
+		expiresAt := util.FormatISO8601(a.ExpiresAt)
+		apiArchive.ExpiresAt = &expiresAt
+	}
+
+	if a.State == gtsmodel.AccountArchiveStateReady {
+		url := config.GetProtocol() + "://" + config.GetHost() +
+			"/api/v1/exports/archives/" + a.ID + "/download"
+		apiArchive.URL = &url
+	}
+
+	return apiArchive, nil
+}
+
+// RelayToAPIRelay converts the given relay to its API representation.
+func (c *Converter) RelayToAPIRelay(
+	ctx context.Context,
+	r *gtsmodel.Relay,
+) (*apimodel.Relay, error) {
+	return &apimodel.Relay{
+		ID:        r.ID,
+		InboxURL:  r.InboxURI,
+		ActorURL:  r.ActorURI,
+		Domain:    r.Domain,
+		State:     r.State.String(),
+		Forward:   util.PtrOrValue(r.Forward, true),
+		CreatedBy: r.CreatedByAccountID,
+		CreatedAt: util.FormatISO8601(r.CreatedAt),
+	}, nil
+}
+
 // ReportToAPIReport converts a gts model report into an api model report, for serving at /api/v1/reports
 func (c *Converter) ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (*apimodel.Report, error) {
 	report := &apimodel.Report{
@@ -1963,6 +2387,79 @@ func (c *Converter) ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (
 	return report, nil
 }
 
+// ScheduledStatusToAPIScheduledStatus converts a gts model scheduled status into an api model scheduled status, for serving at /api/v1/scheduled_statuses
+func (c *Converter) ScheduledStatusToAPIScheduledStatus(ctx context.Context, s *gtsmodel.ScheduledStatus) (*apimodel.ScheduledStatus, error) {
+	if err := c.state.DB.PopulateScheduledStatus(ctx, s); err != nil {
+		log.Errorf(ctx, "error(s) populating scheduled status, will continue: %v", err)
+	}
+
+	apiAttachments, err := c.convertAttachmentsToAPIAttachments(ctx,
+		s.MediaAttachments,
+		s.MediaIDs,
+	)
+	if err != nil {
+		log.Errorf(ctx, "error converting scheduled status attachments: %v", err)
+	}
+
+	scheduledAt := util.FormatISO8601(s.ScheduledAt)
+	params := &apimodel.StatusParams{
+		Text:          s.Text,
+		MediaIDs:      s.MediaIDs,
+		Sensitive:     util.PtrOrValue(s.Sensitive, false),
+		SpoilerText:   s.SpoilerText,
+		Visibility:    c.VisToAPIVis(ctx, s.Visibility),
+		LocalOnly:     util.PtrOrValue(s.LocalOnly, false),
+		ContentType:   apimodel.StatusContentType(s.ContentType),
+		ApplicationID: s.ApplicationID,
+		ScheduledAt:   &scheduledAt,
+	}
+
+	if params.MediaIDs == nil {
+		// Serialize as
+		// empty array.
+		params.MediaIDs = []string{}
+	}
+
+	if s.InReplyToID != "" {
+		params.InReplyToID = &s.InReplyToID
+	}
+
+	if s.QuotedStatusID != "" {
+		params.QuotedStatusID = &s.QuotedStatusID
+	}
+
+	if s.Language != "" {
+		params.Language = &s.Language
+	}
+
+	if len(s.Poll.Options) != 0 {
+		params.Poll = &apimodel.StatusParamsPoll{
+			Options:    s.Poll.Options,
+			ExpiresIn:  s.Poll.ExpiresIn,
+			Multiple:   util.PtrOrValue(s.Poll.Multiple, false),
+			HideTotals: util.PtrOrValue(s.Poll.HideTotals, false),
+		}
+	}
+
+	if s.InteractionPolicy != nil {
+		params.InteractionPolicy, err = c.InteractionPolicyToAPIInteractionPolicy(ctx,
+			s.InteractionPolicy,
+			nil,
+			nil,
+		)
+		if err != nil {
+			return nil, gtserror.Newf("error converting interaction policy: %w", err)
+		}
+	}
+
+	return &apimodel.ScheduledStatus{
+		ID:               s.ID,
+		ScheduledAt:      scheduledAt,
+		Params:           params,
+		MediaAttachments: apiAttachments,
+	}, nil
+}
+
 // ReportToAdminAPIReport converts a gts model report into an admin view report, for serving at /api/v1/admin/reports
 func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Report, requestingAccount *gtsmodel.Account) (*apimodel.AdminReport, error) {
 	var (
@@ -2364,6 +2861,131 @@ func (c *Converter) FilterStatusToAPIFilterStatus(ctx context.Context, filterSta
 	}
 }
 
+// AnnouncementToAPIAnnouncement converts one GTS model announcement into an API announcement.
+// If requester is set, read state and reactions will be shown from the perspective of that account.
+func (c *Converter) AnnouncementToAPIAnnouncement(
+	ctx context.Context,
+	announcement *gtsmodel.Announcement,
+	requester *gtsmodel.Account,
+) (*apimodel.Announcement, error) {
+	apiAnnouncement := &apimodel.Announcement{
+		ID:        announcement.ID,
+		Content:   announcement.Content,
+		AllDay:    util.PtrOrValue(announcement.AllDay, false),
+		UpdatedAt: util.FormatISO8601(announcement.UpdatedAt),
+		Published: announcement.IsPublished(),
+		Mentions:  []apimodel.Mention{},
+		Statuses:  []apimodel.Status{},
+		Tags:      []apimodel.Tag{},
+	}
+
+	if !announcement.StartsAt.IsZero() {
+		apiAnnouncement.StartsAt = util.FormatISO8601(announcement.StartsAt)
+	}
+
+	if !announcement.EndsAt.IsZero() {
+		apiAnnouncement.EndsAt = util.FormatISO8601(announcement.EndsAt)
+	}
+
+	if !announcement.PublishedAt.IsZero() {
+		apiAnnouncement.PublishedAt = util.FormatISO8601(announcement.PublishedAt)
+	}
+
+	var err error
+	apiAnnouncement.Emojis, err = c.convertEmojisToAPIEmojis(ctx, announcement.Emojis, announcement.EmojiIDs)
+	if err != nil {
+		log.Errorf(ctx, "error converting announcement emojis: %v", err)
+	}
+
+	var requesterID string
+	if requester != nil {
+		requesterID = requester.ID
+
+		apiAnnouncement.Read, err = c.state.DB.IsAnnouncementRead(ctx, announcement.ID, requester.ID)
+		if err != nil {
+			return nil, gtserror.Newf("error checking announcement read: %w", err)
+		}
+	}
+
+	reactions, err := c.state.DB.GetAnnouncementReactions(ctx, announcement.ID)
+	if err != nil {
+		return nil, gtserror.Newf("error getting announcement reactions: %w", err)
+	}
+	apiAnnouncement.Reactions = announcementReactionsToAPIReactions(reactions, requesterID)
+
+	return apiAnnouncement, nil
+}
+
+// statusReactionsToAPIReactions groups the given reactions by name,
+// preserving the order in which each name was first used. If requesterID
+// is set, reactions made by that account will be marked as such.
+func statusReactionsToAPIReactions(
+	reactions []*gtsmodel.StatusReaction,
+	requesterID string,
+) []apimodel.StatusReaction {
+	if len(reactions) == 0 {
+		return nil
+	}
+
+	apiReactions := make([]apimodel.StatusReaction, 0, len(reactions))
+	indexes := make(map[string]int, len(reactions))
+
+	for _, reaction := range reactions {
+		i, ok := indexes[reaction.Name]
+		if !ok {
+			apiReaction := apimodel.StatusReaction{Name: reaction.Name}
+			if reaction.Emoji != nil {
+				apiReaction.URL = reaction.Emoji.ImageURL
+				apiReaction.StaticURL = reaction.Emoji.ImageStaticURL
+			}
+
+			i = len(apiReactions)
+			indexes[reaction.Name] = i
+			apiReactions = append(apiReactions, apiReaction)
+		}
+
+		apiReactions[i].Count++
+		if requesterID != "" && reaction.AccountID == requesterID {
+			apiReactions[i].Me = true
+		}
+	}
+
+	return apiReactions
+}
+
+// announcementReactionsToAPIReactions groups the given reactions by name,
+// preserving the order in which each name was first used. If requesterID
+// is set, reactions made by that account will be marked as such.
+func announcementReactionsToAPIReactions(
+	reactions []*gtsmodel.AnnouncementReaction,
+	requesterID string,
+) []apimodel.AnnouncementReaction {
+	apiReactions := make([]apimodel.AnnouncementReaction, 0, len(reactions))
+	indexes := make(map[string]int, len(reactions))
+
+	for _, reaction := range reactions {
+		i, ok := indexes[reaction.Name]
+		if !ok {
+			apiReaction := apimodel.AnnouncementReaction{Name: reaction.Name}
+			if reaction.Emoji != nil {
+				apiReaction.URL = reaction.Emoji.ImageURL
+				apiReaction.StaticURL = reaction.Emoji.ImageStaticURL
+			}
+
+			i = len(apiReactions)
+			indexes[reaction.Name] = i
+			apiReactions = append(apiReactions, apiReaction)
+		}
+
+		apiReactions[i].Count++
+		if requesterID != "" && reaction.AccountID == requesterID {
+			apiReactions[i].Me = true
+		}
+	}
+
+	return apiReactions
+}
+
 // convertEmojisToAPIEmojis will convert a slice of GTS model emojis to frontend API model emojis, falling back to IDs if no GTS models supplied.
 func (c *Converter) convertEmojisToAPIEmojis(ctx context.Context, emojis []*gtsmodel.Emoji, emojiIDs []string) ([]apimodel.Emoji, error) {
 	var errs gtserror.MultiError
@@ -2499,6 +3121,32 @@ func (c *Converter) InteractionPolicyToAPIInteractionPolicy(
 		},
 	}
 
+	// Policies created before quotes
+	// were supported have no canQuote
+	// rules, so fall back to canAnnounce.
+	canQuote := policy.CanQuote
+	if canQuote.IsZero() {
+		canQuote = policy.CanAnnounce
+	}
+
+	apiPolicy.CanQuote = apimodel.PolicyRules{
+		Always:       policyValsToAPIPolicyVals(canQuote.Always),
+		WithApproval: policyValsToAPIPolicyVals(canQuote.WithApproval),
+	}
+
+	// Likewise, policies created before
+	// reactions were supported have no
+	// canReact rules, so use canLike.
+	canReact := policy.CanReact
+	if canReact.IsZero() {
+		canReact = policy.CanLike
+	}
+
+	apiPolicy.CanReact = apimodel.PolicyRules{
+		Always:       policyValsToAPIPolicyVals(canReact.Always),
+		WithApproval: policyValsToAPIPolicyVals(canReact.WithApproval),
+	}
+
 	if status == nil || requester == nil {
 		// We're done here!
 		return apiPolicy, nil
@@ -2568,6 +3216,46 @@ func (c *Converter) InteractionPolicyToAPIInteractionPolicy(
 		)
 	}
 
+	quoteable, err := c.intFilter.StatusQuoteable(ctx, requester, status)
+	if err != nil {
+		err := gtserror.Newf("error checking status quoteable by requester: %w", err)
+		return nil, err
+	}
+
+	if quoteable.Permission == gtsmodel.PolicyPermissionPermitted {
+		// We can do this!
+		apiPolicy.CanQuote.Always = append(
+			apiPolicy.CanQuote.Always,
+			apimodel.PolicyValueMe,
+		)
+	} else if quoteable.Permission == gtsmodel.PolicyPermissionWithApproval {
+		// We can do this with approval.
+		apiPolicy.CanQuote.WithApproval = append(
+			apiPolicy.CanQuote.WithApproval,
+			apimodel.PolicyValueMe,
+		)
+	}
+
+	reactable, err := c.intFilter.StatusReactable(ctx, requester, status)
+	if err != nil {
+		err := gtserror.Newf("error checking status reactable by requester: %w", err)
+		return nil, err
+	}
+
+	if reactable.Permission == gtsmodel.PolicyPermissionPermitted {
+		// We can do this!
+		apiPolicy.CanReact.Always = append(
+			apiPolicy.CanReact.Always,
+			apimodel.PolicyValueMe,
+		)
+	} else if reactable.Permission == gtsmodel.PolicyPermissionWithApproval {
+		// We can do this with approval.
+		apiPolicy.CanReact.WithApproval = append(
+			apiPolicy.CanReact.WithApproval,
+			apimodel.PolicyValueMe,
+		)
+	}
+
 	return apiPolicy, nil
 }
 
@@ -2702,6 +3390,24 @@ func (c *Converter) InteractionReqToAPIInteractionReq(
 		}
 	}
 
+	var quote *apimodel.Status
+	if req.InteractionType == gtsmodel.InteractionQuote && req.Quote != nil {
+		quote, err = c.statusToAPIStatus(
+			ctx,
+			req.Quote,
+			requestingAcct,
+			statusfilter.FilterContextNone,
+			nil,   // No filters.
+			nil,   // No mutes.
+			true,  // Placehold unknown attachments.
+			false, // Don't add note about pending.
+		)
+		if err != nil {
+			err := gtserror.Newf("error converting quote: %w", err)
+			return nil, err
+		}
+	}
+
 	var acceptedAt string
 	if req.IsAccepted() {
 		acceptedAt = util.FormatISO8601(req.AcceptedAt)
@@ -2719,8 +3425,44 @@ func (c *Converter) InteractionReqToAPIInteractionReq(
 		Account:    interactingAcct,
 		Status:     interactedStatus,
 		Reply:      reply,
+		Quote:      quote,
 		AcceptedAt: acceptedAt,
 		RejectedAt: rejectedAt,
 		URI:        req.URI,
 	}, nil
 }
+
+// WebPushSubscriptionToAPIWebPushSubscription converts a
+// gtsmodel Web Push subscription to its API model.
+func (c *Converter) WebPushSubscriptionToAPIWebPushSubscription(
+	ctx context.Context,
+	subscription *gtsmodel.WebPushSubscription,
+) (*apimodel.PushSubscription, error) {
+	vapidKeyPair, err := webpush.GetOrCreateVAPIDKeyPair(ctx, c.state.DB)
+	if err != nil {
+		return nil, gtserror.Newf("error getting vapid key pair: %w", err)
+	}
+
+	flags := subscription.NotificationFlags
+	return &apimodel.PushSubscription{
+		ID:        subscription.ID,
+		Endpoint:  subscription.Endpoint,
+		ServerKey: vapidKeyPair.Public,
+		Alerts: &apimodel.PushSubscriptionAlerts{
+			Follow:           flags.Get(gtsmodel.NotificationFollow),
+			FollowRequest:    flags.Get(gtsmodel.NotificationFollowRequest),
+			Favourite:        flags.Get(gtsmodel.NotificationFave),
+			Mention:          flags.Get(gtsmodel.NotificationMention),
+			Reblog:           flags.Get(gtsmodel.NotificationReblog),
+			Poll:             flags.Get(gtsmodel.NotificationPoll),
+			Status:           flags.Get(gtsmodel.NotificationStatus),
+			AdminSignup:      flags.Get(gtsmodel.NotificationSignup),
+			PendingFavourite: flags.Get(gtsmodel.NotificationPendingFave),
+			PendingReply:     flags.Get(gtsmodel.NotificationPendingReply),
+			PendingReblog:    flags.Get(gtsmodel.NotificationPendingReblog),
+			PendingQuote:     flags.Get(gtsmodel.NotificationPendingQuote),
+			Reaction:         flags.Get(gtsmodel.NotificationReaction),
+		},
+		Policy: string(subscription.Policy),
+	}, nil
+}
@@ -572,6 +572,20 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -775,6 +789,20 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredStatusToFrontend() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -955,6 +983,20 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredBoostToFrontend() {
           "me"
         ],
         "with_approval": []
+      },
+      "can_quote": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
+      },
+      "can_react": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
       }
     }
   },
@@ -1045,6 +1087,20 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredBoostToFrontend() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -1303,6 +1359,20 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownAttachments
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -1378,6 +1448,18 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
         "public"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public"
+      ],
+      "with_approval": []
     }
   },
   "account": {
@@ -1433,7 +1515,8 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
       "blurhash": "LKE3VIw}0KD%a2o{M|t7NFWps:t7",
       "Sensitive": true,
       "MIMEType": "image/jpg",
-      "PreviewMIMEType": "image/webp"
+      "PreviewMIMEType": "image/webp",
+      "PreviewVariants": null
     },
     {
       "id": "01HE7ZFX9GKA5ZZVD4FACABSS9",
@@ -1448,7 +1531,8 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
       "blurhash": "L26*j+~qE1RP?wxut7ofRlM{R*of",
       "Sensitive": true,
       "MIMEType": "",
-      "PreviewMIMEType": ""
+      "PreviewMIMEType": "",
+      "PreviewVariants": null
     },
     {
       "id": "01HE88YG74PVAB81PX2XA9F3FG",
@@ -1463,7 +1547,8 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
       "blurhash": null,
       "Sensitive": true,
       "MIMEType": "",
-      "PreviewMIMEType": ""
+      "PreviewMIMEType": "",
+      "PreviewVariants": null
     }
   ],
   "LanguageTag": "en",
@@ -1476,6 +1561,55 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
 }`, string(b))
 }
 
+func (suite *InternalToFrontendTestSuite) TestStatusToFrontendContentLimits() {
+	var (
+		ctx               = context.Background()
+		testStatus        = suite.testStatuses["remote_account_1_status_1"]
+		requestingAccount = suite.testAccounts["admin_account"]
+	)
+
+	// Limit the whole domain, and
+	// the status author in particular.
+	for _, limit := range []*gtsmodel.ContentLimit{
+		{
+			ID:                 "01JB3V4TFMD1B8Z9M2XQ5G6W7E",
+			Domain:             "fossbros-anonymous.io",
+			ForceSensitive:     util.Ptr(false),
+			ContentWarning:     "fossbros",
+			RejectMedia:        util.Ptr(true),
+			CreatedByAccountID: requestingAccount.ID,
+		},
+		{
+			ID:                 "01JB3V5C1XJQ7T2R9S0H4N8K3A",
+			AccountID:          testStatus.AccountID,
+			ForceSensitive:     util.Ptr(true),
+			ContentWarning:     "nsfw art",
+			RejectMedia:        util.Ptr(false),
+			CreatedByAccountID: requestingAccount.ID,
+		},
+	} {
+		if err := suite.db.PutContentLimit(ctx, limit); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(ctx, testStatus, requestingAccount, statusfilter.FilterContextNone, nil, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Sensitive flag should be forced, and the
+	// more specific content warning should win.
+	suite.True(apiStatus.Sensitive)
+	suite.Equal("nsfw art", apiStatus.SpoilerText)
+
+	// Media from the domain is rejected, so the
+	// attachment should be placeheld with a link.
+	suite.Empty(apiStatus.MediaAttachments)
+	suite.Contains(apiStatus.Content, "1 attachment in this status was not downloaded")
+	suite.Contains(apiStatus.Content, "http://fossbros-anonymous.io/attachments/original/13bbc3f8-2b5e-46ea-9531-40b4974d9912.jpg")
+}
+
 func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage() {
 	testStatus := &gtsmodel.Status{}
 	*testStatus = *suite.testStatuses["admin_account_status_1"]
@@ -1613,6 +1747,20 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage()
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -1704,6 +1852,18 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendPartialInteraction
         "author"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "author"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "author"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -1827,6 +1987,20 @@ func (suite *InternalToFrontendTestSuite) TestStatusToAPIStatusPendingApproval()
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_react": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }
@@ -2769,6 +2943,20 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
             "me"
           ],
           "with_approval": []
+        },
+        "can_quote": {
+          "always": [
+            "public",
+            "me"
+          ],
+          "with_approval": []
+        },
+        "can_react": {
+          "always": [
+            "public",
+            "me"
+          ],
+          "with_approval": []
         }
       }
     }
@@ -3259,6 +3447,20 @@ func (suite *InternalToFrontendTestSuite) TestIntReqToAPI() {
           "me"
         ],
         "with_approval": []
+      },
+      "can_quote": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
+      },
+      "can_react": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
       }
     }
   },
@@ -3352,6 +3554,20 @@ func (suite *InternalToFrontendTestSuite) TestIntReqToAPI() {
           "me"
         ],
         "with_approval": []
+      },
+      "can_quote": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
+      },
+      "can_react": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
       }
     }
   }
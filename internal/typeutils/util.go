@@ -60,6 +60,32 @@ func toAPIFrameRate(framerate *float32) string {
 	return strconv.Itoa(int(round)) + "/1"
 }
 
+// previewVariants returns the thumbnail variants of an attachment
+// that are uncropped, i.e. with the same aspect ratio as the original
+// media and its preview, so they can be mixed with the preview in a
+// srcset without changing the shape of the shown image.
+func previewVariants(attachment *apimodel.Attachment) []apimodel.MediaThumbnail {
+	if attachment.Meta == nil {
+		return nil
+	}
+
+	width := attachment.Meta.Original.Width
+	height := attachment.Meta.Original.Height
+
+	var variants []apimodel.MediaThumbnail
+	for _, variant := range attachment.Meta.Thumbnails {
+		// Allow for variant height having been
+		// rounded to nearest pixel when scaling.
+		diff := variant.Height*width - variant.Width*height
+		if diff < -width || diff > width {
+			continue
+		}
+		variants = append(variants, variant)
+	}
+
+	return variants
+}
+
 type statusInteractions struct {
 	Favourited bool
 	Muted      bool
@@ -117,6 +143,46 @@ func misskeyReportInlineURLs(content string) []*url.URL {
 	return urls
 }
 
+// applyContentLimits applies the given admin content limits
+// on a status author to the given API status, forcing the
+// sensitive flag and content warning as necessary.
+//
+// If media is rejected, locally stored file details are
+// removed from attachments, so that remote attachments get
+// placeheld like any other media we didn't download. Local
+// attachments have nothing to link to, so they're dropped.
+func applyContentLimits(limits gtsmodel.ContentLimits, apiStatus *apimodel.Status) {
+	if len(limits) == 0 {
+		// Nothing
+		// to apply.
+		return
+	}
+
+	if limits.ForceSensitive() {
+		apiStatus.Sensitive = true
+	}
+
+	apiStatus.SpoilerText = limits.ApplyContentWarning(apiStatus.SpoilerText)
+
+	if limits.RejectMedia() {
+		apiStatus.MediaAttachments = slices.DeleteFunc(
+			apiStatus.MediaAttachments,
+			func(a *apimodel.Attachment) bool {
+				if a.RemoteURL == nil {
+					return true
+				}
+
+				a.Type = gtsmodel.FileTypeUnknown.String()
+				a.URL = nil
+				a.TextURL = nil
+				a.PreviewURL = nil
+				a.Meta = nil
+				return false
+			},
+		)
+	}
+}
+
 // placeholderAttachments separates any attachments with missing local URL
 // out of the given slice, and returns a piece of text containing links to
 // those attachments, as well as the slice of remaining "known" attachments.
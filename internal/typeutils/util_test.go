@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/language"
@@ -218,3 +219,27 @@ func TestFilterableText(t *testing.T) {
 		assert.Equal(t, testcase.expectedFields, fields)
 	}
 }
+
+func TestPreviewVariants(t *testing.T) {
+	thumbnails := []apimodel.MediaThumbnail{
+		{URL: "https://example.org/w320.jpeg", Width: 320, Height: 180},
+		{URL: "https://example.org/w640.jpeg", Width: 640, Height: 360},
+	}
+
+	// Uncropped variants of 16:9 media are all included.
+	uncropped := &apimodel.Attachment{Meta: &apimodel.MediaMeta{
+		Original:   apimodel.MediaDimensions{Width: 1920, Height: 1080},
+		Thumbnails: thumbnails,
+	}}
+	assert.Equal(t, thumbnails, previewVariants(uncropped))
+
+	// Variants of very wide media cropped to 16:9 are excluded.
+	cropped := &apimodel.Attachment{Meta: &apimodel.MediaMeta{
+		Original:   apimodel.MediaDimensions{Width: 3000, Height: 600},
+		Thumbnails: thumbnails,
+	}}
+	assert.Empty(t, previewVariants(cropped))
+
+	// No media meta.
+	assert.Empty(t, previewVariants(&apimodel.Attachment{}))
+}
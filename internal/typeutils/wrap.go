@@ -19,6 +19,7 @@ package typeutils
 
 import (
 	"net/url"
+	"strconv"
 
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
@@ -93,6 +94,19 @@ func WrapStatusableInCreate(status ap.Statusable, iriOnly bool) vocab.ActivitySt
 func WrapStatusableInUpdate(status ap.Statusable, iriOnly bool) vocab.ActivityStreamsUpdate {
 	update := streams.NewActivityStreamsUpdate()
 	wrapStatusableInActivity(update, status, iriOnly)
+
+	if updated := ap.GetUpdated(status); !updated.IsZero() {
+		// Status has been edited, make sure each Update
+		// has a unique ID so that remotes don't discard
+		// subsequent edits as duplicates, and mark the
+		// activity as published at time of the edit.
+		idIRI := ap.GetJSONLDId(status)
+		ap.MustSet(ap.SetJSONLDIdStr, ap.WithJSONLDId(update),
+			idIRI.String()+"#updates/"+strconv.FormatInt(updated.Unix(), 10),
+		)
+		ap.SetPublished(update, updated)
+	}
+
 	return update
 }
 
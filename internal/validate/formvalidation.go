@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"net/mail"
+	"strings"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -46,6 +47,8 @@ const (
 	maximumListTitleLength        = 200
 	maximumFilterKeywordLength    = 40
 	maximumFilterTitleLength      = 200
+	maximumAnnouncementLength     = 5000
+	maximumUnicodeEmojiLength     = 64 // Long enough for family emojis with skin tones (~35 bytes) and flag tag sequences.
 )
 
 // Password returns a helpful error if the given password
@@ -207,6 +210,60 @@ func EmojiCategory(category string) error {
 	return nil
 }
 
+// UnicodeEmoji checks that the given string looks like a
+// single unicode emoji, including modifiers and zero-width
+// joiner sequences. This is a heuristic based on unicode
+// ranges, so it may accept some sequences that don't render.
+func UnicodeEmoji(emoji string) error {
+	if emoji == "" {
+		return errors.New("no emoji provided")
+	}
+
+	if length := len(emoji); length > maximumUnicodeEmojiLength {
+		return fmt.Errorf("emoji must be less than %d bytes, provided emoji was %d bytes", maximumUnicodeEmojiLength, length)
+	}
+
+	var (
+		pictographic bool
+		keycap       = strings.ContainsRune(emoji, 0x20E3)
+	)
+
+	for _, r := range emoji {
+		switch {
+		case r == 0x200D, // zero width joiner
+			r == 0xFE0E || r == 0xFE0F,   // variation selectors
+			r == 0x20E3,                  // combining enclosing keycap
+			r >= 0x1F3FB && r <= 0x1F3FF, // skin tone modifiers
+			r >= 0xE0020 && r <= 0xE007F: // tag sequences (subdivision flags)
+			continue
+
+		case keycap && (r == '#' || r == '*' || (r >= '0' && r <= '9')):
+			// Keycap base, eg., 1️⃣.
+			pictographic = true
+
+		case r == 0x00A9 || r == 0x00AE, // © ®
+			r == 0x203C || r == 0x2049, // ‼ ⁉
+			r == 0x3030 || r == 0x303D || r == 0x3297 || r == 0x3299,
+			r >= 0x2100 && r <= 0x21FF,   // letterlike symbols, arrows
+			r >= 0x2300 && r <= 0x23FF,   // misc technical
+			r >= 0x24C2 && r <= 0x27BF,   // enclosed alphanumerics, geometric shapes, misc symbols, dingbats
+			r >= 0x2900 && r <= 0x297F,   // supplemental arrows
+			r >= 0x2B00 && r <= 0x2BFF,   // misc symbols and arrows
+			r >= 0x1F000 && r <= 0x1FAFF: // emoticons, pictographs, flags, etc
+			pictographic = true
+
+		default:
+			return fmt.Errorf("emoji %s did not pass validation, contains non-emoji character %U", emoji, r)
+		}
+	}
+
+	if !pictographic {
+		return fmt.Errorf("emoji %s did not pass validation, contains no emoji", emoji)
+	}
+
+	return nil
+}
+
 // SiteTitle ensures that the given site title is within spec.
 func SiteTitle(siteTitle string) error {
 	if length := len([]rune(siteTitle)); length > maximumSiteTitleLength {
@@ -279,6 +336,19 @@ func ProfileFields(fields []*gtsmodel.Field) error {
 	return nil
 }
 
+// AnnouncementText validates the text of an instance announcement.
+func AnnouncementText(text string) error {
+	if text == "" {
+		return errors.New("announcement text must be provided")
+	}
+
+	if length := len([]rune(text)); length > maximumAnnouncementLength {
+		return fmt.Errorf("announcement text must be less than %d characters, provided text was %d characters", maximumAnnouncementLength, length)
+	}
+
+	return nil
+}
+
 // ListTitle validates the title of a new or updated List.
 func ListTitle(title string) error {
 	if title == "" {
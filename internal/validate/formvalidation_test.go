@@ -381,6 +381,32 @@ func (suite *ValidationTestSuite) TestValidateEmojiShortcode() {
 	}
 }
 
+func (suite *ValidationTestSuite) TestValidateUnicodeEmoji() {
+	for _, test := range []struct {
+		emoji string
+		ok    bool
+	}{
+		{emoji: "👍", ok: true},
+		{emoji: "👍🏽", ok: true},
+		{emoji: "❤️", ok: true},
+		{emoji: "👨‍👩‍👧‍👦", ok: true},
+		{emoji: "🏳️‍🌈", ok: true},
+		{emoji: "🇳🇱", ok: true},
+		{emoji: "1️⃣", ok: true},
+		{emoji: "", ok: false},
+		{emoji: "1", ok: false},
+		{emoji: "blobcat", ok: false},
+		{emoji: "👍a", ok: false},
+		{emoji: "\u200d", ok: false},
+	} {
+		err := validate.UnicodeEmoji(test.emoji)
+		ok := err == nil
+		if !suite.Equal(test.ok, ok) {
+			suite.T().Logf("fail on %s", test.emoji)
+		}
+	}
+}
+
 func TestValidationTestSuite(t *testing.T) {
 	suite.Run(t, new(ValidationTestSuite))
 }
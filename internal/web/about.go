@@ -58,6 +58,7 @@ func (m *Module) aboutGETHandler(c *gin.Context) {
 		Extra: map[string]any{
 			"showStrap":        true,
 			"blocklistExposed": config.GetInstanceExposeSuspendedWeb(),
+			"directoryEnabled": config.GetInstanceDirectoryEnabled(),
 			"languages":        config.GetInstanceLanguages().DisplayStrs(),
 		},
 	}
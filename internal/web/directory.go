@@ -0,0 +1,135 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package web
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+const (
+	directoryPath = "/directory"
+
+	// Number of accounts to
+	// show per directory page.
+	directoryPageSize = 40
+)
+
+func (m *Module) directoryGETHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	instance, errWithCode := m.processor.InstanceGetV1(ctx)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Return instance we already got from the db,
+	// don't try to fetch it again when erroring.
+	instanceGet := func(ctx context.Context) (*apimodel.InstanceV1, gtserror.WithCode) {
+		return instance, nil
+	}
+
+	// We only serve text/html at this endpoint.
+	if _, err := apiutil.NegotiateAccept(c, apiutil.TextHTML); err != nil {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), instanceGet)
+		return
+	}
+
+	if !config.GetInstanceDirectoryEnabled() {
+		const text = "profile directory is disabled on this instance"
+		apiutil.WebErrorHandler(c, gtserror.NewErrorNotFound(errors.New(text), text), instanceGet)
+		return
+	}
+
+	order, errWithCode := apiutil.ParseDirectoryOrder(c.Query(apiutil.DirectoryOrderKey), apiutil.DirectoryOrderActive)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	offset, errWithCode := apiutil.ParseOffset(c.Query(apiutil.OffsetKey), 0, 10000, 0)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	// The web directory only shows local accounts, and is
+	// viewed logged-out, so there's no requester to filter by.
+	accounts, errWithCode := m.processor.Account().DirectoryGet(
+		ctx,
+		nil,
+		true,
+		order == apiutil.DirectoryOrderNew,
+		directoryPageSize,
+		offset,
+	)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	// pageLink returns a link to the
+	// directory at the given offset.
+	pageLink := func(offset int) string {
+		query := url.Values{}
+		if order != apiutil.DirectoryOrderActive {
+			query.Set(apiutil.DirectoryOrderKey, order)
+		}
+		if offset > 0 {
+			query.Set(apiutil.OffsetKey, strconv.Itoa(offset))
+		}
+
+		link := directoryPath
+		if len(query) > 0 {
+			link += "?" + query.Encode()
+		}
+		return link
+	}
+
+	var prevLink, nextLink string
+	if offset > 0 {
+		prevLink = pageLink(max(offset-directoryPageSize, 0))
+	}
+	if len(accounts) == directoryPageSize {
+		nextLink = pageLink(offset + directoryPageSize)
+	}
+
+	page := apiutil.WebPage{
+		Template:    "directory.tmpl",
+		Instance:    instance,
+		OGMeta:      apiutil.OGBase(instance),
+		Stylesheets: []string{cssDirectory},
+		Extra: map[string]any{
+			"accounts": accounts,
+			"order":    order,
+			"prevLink": prevLink,
+			"nextLink": nextLink,
+		},
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
@@ -0,0 +1,183 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// recordSize is the record size advertised in the
+	// aes128gcm header. We only ever send a single
+	// record, so the whole payload has to fit in it.
+	recordSize = 4096
+
+	// saltLen is the length of the random salt.
+	saltLen = 16
+
+	// tagLen is the length of the AES-GCM auth tag.
+	tagLen = 16
+
+	// headerLen is the length of the aes128gcm header
+	// with a 65 byte uncompressed P-256 key as key ID.
+	headerLen = saltLen + 4 + 1 + 65
+
+	// maxPlaintextLen is the largest plaintext that will fit
+	// in a single 4096 byte message (RFC 8291 section 4),
+	// leaving space for the header, the padding delimiter
+	// octet and the auth tag.
+	maxPlaintextLen = recordSize - headerLen - tagLen - 1
+)
+
+// encrypt encrypts the given plaintext for a user agent with
+// the given P-256 public key and auth secret, as described by
+// RFC 8291, using the aes128gcm content encoding from RFC 8188.
+func encrypt(plaintext []byte, uaPublicBytes []byte, authSecret []byte) ([]byte, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, gtserror.Newf("error generating salt: %w", err)
+	}
+
+	// Each message uses a fresh application server key pair.
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, gtserror.Newf("error generating key: %w", err)
+	}
+
+	return encryptWith(plaintext, uaPublicBytes, authSecret, salt, asPrivate)
+}
+
+// encryptWith is encrypt with the salt
+// and ephemeral key provided by the caller.
+func encryptWith(
+	plaintext []byte,
+	uaPublicBytes []byte,
+	authSecret []byte,
+	salt []byte,
+	asPrivate *ecdh.PrivateKey,
+) ([]byte, error) {
+	if len(plaintext) > maxPlaintextLen {
+		return nil, gtserror.Newf("plaintext too long (%d > %d)", len(plaintext), maxPlaintextLen)
+	}
+
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, gtserror.Newf("invalid user agent public key: %w", err)
+	}
+
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, gtserror.Newf("error deriving shared secret: %w", err)
+	}
+
+	asPublicBytes := asPrivate.PublicKey().Bytes()
+
+	// Combine the shared secret and the auth
+	// secret into the input keying material.
+	//
+	// key_info = "WebPush: info" || 0x00 || ua_public || as_public
+	keyInfo := make([]byte, 0, 14+len(uaPublicBytes)+len(asPublicBytes))
+	keyInfo = append(keyInfo, "WebPush: info\x00"...)
+	keyInfo = append(keyInfo, uaPublicBytes...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm, err := deriveKey(authSecret, ecdhSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	// Derive content encryption key and nonce.
+	cek, err := deriveKey(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := deriveKey(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, gtserror.Newf("error creating cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, gtserror.Newf("error creating gcm: %w", err)
+	}
+
+	// Header: salt || rs || idlen || keyid
+	out := make([]byte, headerLen, headerLen+len(plaintext)+1+tagLen)
+	copy(out, salt)
+	binary.BigEndian.PutUint32(out[saltLen:], recordSize)
+	out[saltLen+4] = byte(len(asPublicBytes))
+	copy(out[saltLen+5:], asPublicBytes)
+
+	// The single (and so last) record is
+	// delimited by 0x02, with no padding.
+	record := make([]byte, 0, len(plaintext)+1)
+	record = append(record, plaintext...)
+	record = append(record, 0x02)
+
+	return gcm.Seal(out, nonce, record, nil), nil
+}
+
+// deriveKey derives a key of given length
+// from the given salt, input keying material
+// and info, using HKDF with SHA-256.
+func deriveKey(salt, ikm, info []byte, length int) ([]byte, error) {
+	key := make([]byte, length)
+	r := hkdf.New(sha256.New, ikm, salt, info)
+	if _, err := io.ReadFull(r, key); err != nil {
+		return nil, gtserror.Newf("error deriving key: %w", err)
+	}
+	return key, nil
+}
+
+// ValidateKeys checks that the given base64-encoded
+// user agent keys can be used to encrypt messages.
+func ValidateKeys(p256dh string, auth string) error {
+	uaPublic, err := decodeBase64(p256dh)
+	if err != nil {
+		return gtserror.Newf("error decoding p256dh key: %w", err)
+	}
+
+	if _, err := ecdh.P256().NewPublicKey(uaPublic); err != nil {
+		return gtserror.Newf("invalid p256dh key: %w", err)
+	}
+
+	authSecret, err := decodeBase64(auth)
+	if err != nil {
+		return gtserror.Newf("error decoding auth secret: %w", err)
+	}
+
+	if len(authSecret) != 16 {
+		return gtserror.Newf("auth secret must be 16 bytes, was %d", len(authSecret))
+	}
+
+	return nil
+}
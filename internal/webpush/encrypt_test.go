@@ -0,0 +1,155 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type EncryptTestSuite struct {
+	suite.Suite
+}
+
+func (suite *EncryptTestSuite) decode(s string) []byte {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return b
+}
+
+// TestRFC8291Example checks encryption against
+// the worked example in RFC 8291 appendix A.
+func (suite *EncryptTestSuite) TestRFC8291Example() {
+	asPrivate, err := ecdh.P256().NewPrivateKey(suite.decode("yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	out, err := encryptWith(
+		[]byte("When I grow up, I want to be a watermelon"),
+		suite.decode("BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"),
+		suite.decode("BTBZMqHH6r4Tts7J_aSIgg"),
+		suite.decode("DGv6ra1nlYgDCS1FRnbzlw"),
+		asPrivate,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(
+		"DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN",
+		base64.RawURLEncoding.EncodeToString(out),
+	)
+}
+
+func (suite *EncryptTestSuite) TestRoundTrip() {
+	uaPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	authSecret := make([]byte, 16)
+	if _, err := rand.Read(authSecret); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	plaintext := []byte(`{"title":"hello"}`)
+	out, err := encrypt(plaintext, uaPrivate.PublicKey().Bytes(), authSecret)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Decrypt as the user agent would.
+	salt := out[:saltLen]
+	suite.EqualValues(recordSize, binary.BigEndian.Uint32(out[saltLen:]))
+	suite.EqualValues(65, out[saltLen+4])
+
+	asPublic, err := ecdh.P256().NewPublicKey(out[saltLen+5 : headerLen])
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ecdhSecret, err := uaPrivate.ECDH(asPublic)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	keyInfo := append([]byte("WebPush: info\x00"), uaPrivate.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, asPublic.Bytes()...)
+	ikm, err := deriveKey(authSecret, ecdhSecret, keyInfo, 32)
+	suite.NoError(err)
+	cek, err := deriveKey(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	suite.NoError(err)
+	nonce, err := deriveKey(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+	suite.NoError(err)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	record, err := gcm.Open(nil, nonce, out[headerLen:], nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(append(plaintext, 0x02), record)
+}
+
+func (suite *EncryptTestSuite) TestTooLong() {
+	uaPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = encrypt(make([]byte, maxPlaintextLen+1), uaPrivate.PublicKey().Bytes(), make([]byte, 16))
+	suite.ErrorContains(err, "plaintext too long")
+}
+
+func (suite *EncryptTestSuite) TestMaxLength() {
+	uaPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Header and all, the largest message
+	// must fit in 4096 bytes (RFC 8291 section 4).
+	out, err := encrypt(make([]byte, maxPlaintextLen), uaPrivate.PublicKey().Bytes(), make([]byte, 16))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(out, 4096)
+}
+
+func TestEncryptTestSuite(t *testing.T) {
+	suite.Run(t, new(EncryptTestSuite))
+}
@@ -0,0 +1,48 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// NewNoopSender returns a Sender that doesn't actually send any
+// notifications, but calls the given callback for each one, if set.
+func NewNoopSender(sendCallback func(*gtsmodel.Notification)) Sender {
+	return &noopSender{
+		sendCallback: sendCallback,
+	}
+}
+
+type noopSender struct {
+	sendCallback func(*gtsmodel.Notification)
+}
+
+func (n *noopSender) Send(
+	_ context.Context,
+	notification *gtsmodel.Notification,
+	_ *apimodel.Notification,
+) error {
+	if n.sendCallback != nil {
+		n.sendCallback(notification)
+	}
+	return nil
+}
@@ -0,0 +1,402 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/k3a/html2text"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+const (
+	// pushTTL is how long, in seconds, a push service
+	// should hold on to a notification for an offline
+	// user agent before giving up on it (48 hours).
+	pushTTL = 48 * 60 * 60
+
+	// maxBodyLen is the maximum length in
+	// characters of a notification's body.
+	maxBodyLen = 500
+)
+
+// Sender can send Web Push notifications.
+type Sender interface {
+	// Send queues delivery of the given notification to
+	// each of the target account's Web Push subscriptions
+	// whose alerts and policy allow for it.
+	Send(
+		ctx context.Context,
+		notification *gtsmodel.Notification,
+		apiNotification *apimodel.Notification,
+	) error
+}
+
+// HTTPClient is the subset of
+// the http client used by Sender.
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// NewSender returns a new Sender that delivers
+// notifications using the given HTTP client, on
+// the state's Web Push worker pool.
+func NewSender(httpClient HTTPClient, state *state.State) Sender {
+	return &realSender{
+		httpClient: httpClient,
+		state:      state,
+	}
+}
+
+type realSender struct {
+	httpClient HTTPClient
+	state      *state.State
+
+	// lazily loaded
+	// VAPID key pair.
+	vapidMu         sync.Mutex
+	vapidKeyPair    *gtsmodel.VAPIDKeyPair
+	vapidPrivateKey *ecdsa.PrivateKey
+}
+
+func (r *realSender) Send(
+	ctx context.Context,
+	notification *gtsmodel.Notification,
+	apiNotification *apimodel.Notification,
+) error {
+	// Fetch all of the target account's subscriptions.
+	subscriptions, err := r.state.DB.GetWebPushSubscriptionsByAccountID(
+		ctx,
+		notification.TargetAccountID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting subscriptions: %w", err)
+	}
+
+	for _, subscription := range subscriptions {
+		ok, err := r.shouldSend(ctx, subscription, notification)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			continue
+		}
+
+		// Queue the actual delivery, as this involves
+		// a potentially slow request to a push service.
+		subscription := subscription
+		r.state.Workers.WebPush.Queue.Push(func(ctx context.Context) {
+			if err := r.sendTo(ctx, subscription, apiNotification); err != nil {
+				log.Errorf(ctx, "error sending web push notification %s: %v", notification.ID, err)
+			}
+		})
+	}
+
+	return nil
+}
+
+// shouldSend returns whether the given subscription's
+// alerts and policy allow for the given notification.
+func (r *realSender) shouldSend(
+	ctx context.Context,
+	subscription *gtsmodel.WebPushSubscription,
+	notification *gtsmodel.Notification,
+) (bool, error) {
+	if !subscription.NotificationFlags.Get(notification.NotificationType) {
+		// Not subscribed
+		// to this type.
+		return false, nil
+	}
+
+	switch subscription.Policy {
+	case gtsmodel.WebPushNotificationPolicyAll:
+		return true, nil
+
+	case gtsmodel.WebPushNotificationPolicyFollowed:
+		// Only if subscriber follows the origin account.
+		following, err := r.state.DB.IsFollowing(ctx,
+			notification.TargetAccountID,
+			notification.OriginAccountID,
+		)
+		if err != nil {
+			return false, gtserror.Newf("db error checking follow: %w", err)
+		}
+		return following, nil
+
+	case gtsmodel.WebPushNotificationPolicyFollower:
+		// Only if origin account follows the subscriber.
+		followedBy, err := r.state.DB.IsFollowing(ctx,
+			notification.OriginAccountID,
+			notification.TargetAccountID,
+		)
+		if err != nil {
+			return false, gtserror.Newf("db error checking follow: %w", err)
+		}
+		return followedBy, nil
+
+	default: // incl. WebPushNotificationPolicyNone
+		return false, nil
+	}
+}
+
+// sendTo encrypts and delivers the given
+// notification to the given subscription.
+func (r *realSender) sendTo(
+	ctx context.Context,
+	subscription *gtsmodel.WebPushSubscription,
+	apiNotification *apimodel.Notification,
+) error {
+	// The payload includes the subscription's access
+	// token, so that clients can use it to fetch the
+	// full notification from the API when it arrives.
+	token, err := r.state.DB.GetTokenByID(ctx, subscription.TokenID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Token has since been revoked,
+			// so the subscription is defunct.
+			return r.deleteSubscription(ctx, subscription)
+		}
+		return gtserror.Newf("db error getting token: %w", err)
+	}
+
+	// Preferably send the notification in the user's locale.
+	user, err := r.state.DB.GetUserByAccountID(
+		gtscontext.SetBarebones(ctx),
+		subscription.AccountID,
+	)
+	if err != nil {
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	plaintext, err := json.Marshal(newPayload(token.Access, user.Locale, apiNotification))
+	if err != nil {
+		return gtserror.Newf("error marshaling payload: %w", err)
+	}
+
+	uaPublic, err := decodeBase64(subscription.P256dh)
+	if err != nil {
+		return gtserror.Newf("error decoding p256dh key: %w", err)
+	}
+
+	authSecret, err := decodeBase64(subscription.Auth)
+	if err != nil {
+		return gtserror.Newf("error decoding auth secret: %w", err)
+	}
+
+	body, err := encrypt(plaintext, uaPublic, authSecret)
+	if err != nil {
+		return gtserror.Newf("error encrypting payload: %w", err)
+	}
+
+	vapidKeyPair, privateKey, err := r.getVAPIDKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	subject := config.GetProtocol() + "://" + config.GetHost()
+	authorization, err := vapidAuthorization(vapidKeyPair, privateKey, subscription.Endpoint, subject, time.Now())
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return gtserror.Newf("error creating request: %w", err)
+	}
+
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(pushTTL))
+	req.Header.Set("Urgency", "normal")
+
+	rsp, err := r.httpClient.Do(req)
+	if err != nil {
+		return gtserror.Newf("error doing request: %w", err)
+	}
+	defer rsp.Body.Close()
+
+	switch {
+	case rsp.StatusCode == http.StatusNotFound ||
+		rsp.StatusCode == http.StatusGone:
+		// Push service says the subscription
+		// has expired or been unsubscribed.
+		return r.deleteSubscription(ctx, subscription)
+
+	case rsp.StatusCode < 200 || rsp.StatusCode > 299:
+		return gtserror.Newf("push service returned %s", rsp.Status)
+	}
+
+	return nil
+}
+
+// getVAPIDKeys returns the instance's VAPID key
+// pair and parsed private key, loading them on
+// first use, and generating them if necessary.
+func (r *realSender) getVAPIDKeys(ctx context.Context) (*gtsmodel.VAPIDKeyPair, *ecdsa.PrivateKey, error) {
+	r.vapidMu.Lock()
+	defer r.vapidMu.Unlock()
+
+	if r.vapidKeyPair != nil {
+		return r.vapidKeyPair, r.vapidPrivateKey, nil
+	}
+
+	vapidKeyPair, err := GetOrCreateVAPIDKeyPair(ctx, r.state.DB)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	privateKey, err := vapidPrivateKey(vapidKeyPair)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r.vapidKeyPair = vapidKeyPair
+	r.vapidPrivateKey = privateKey
+	return vapidKeyPair, privateKey, nil
+}
+
+func (r *realSender) deleteSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription) error {
+	log.Debugf(ctx, "deleting defunct web push subscription %s", subscription.ID)
+	if err := r.state.DB.DeleteWebPushSubscriptionByTokenID(ctx, subscription.TokenID); err != nil {
+		return gtserror.Newf("db error deleting subscription: %w", err)
+	}
+	return nil
+}
+
+// payload is the JSON plaintext of a Web
+// Push message, in the format used by Mastodon.
+type payload struct {
+	AccessToken      string `json:"access_token"`
+	PreferredLocale  string `json:"preferred_locale"`
+	NotificationID   string `json:"notification_id"`
+	NotificationType string `json:"notification_type"`
+	Icon             string `json:"icon"`
+	Title            string `json:"title"`
+	Body             string `json:"body"`
+}
+
+func newPayload(accessToken string, locale string, apiNotification *apimodel.Notification) *payload {
+	if locale == "" {
+		locale = "en"
+	}
+
+	p := &payload{
+		AccessToken:      accessToken,
+		PreferredLocale:  locale,
+		NotificationID:   apiNotification.ID,
+		NotificationType: apiNotification.Type,
+	}
+
+	name := ""
+	if account := apiNotification.Account; account != nil {
+		p.Icon = account.Avatar
+		name = account.DisplayName
+		if name == "" {
+			name = account.Username
+		}
+	}
+
+	switch apiNotification.Type {
+	case "follow":
+		p.Title = name + " followed you"
+	case "follow_request":
+		p.Title = name + " requested to follow you"
+	case "mention":
+		p.Title = name + " mentioned you"
+	case "reblog":
+		p.Title = name + " boosted your post"
+	case "favourite":
+		p.Title = name + " favourited your post"
+	case "poll":
+		p.Title = "A poll you voted in or created has ended"
+	case "status":
+		p.Title = name + " just posted"
+	case "admin.sign_up":
+		p.Title = name + " signed up"
+	case "pending.favourite":
+		p.Title = name + " wants to favourite your post"
+	case "pending.reply":
+		p.Title = name + " wants to reply to your post"
+	case "pending.reblog":
+		p.Title = name + " wants to boost your post"
+	case "pending.quote":
+		p.Title = name + " wants to quote your post"
+	case "reaction":
+		p.Title = name + " reacted to your post"
+	default:
+		p.Title = "New notification from " + name
+	}
+
+	if status := apiNotification.Status; status != nil {
+		if status.SpoilerText != "" {
+			// Don't leak content
+			// behind a content warning.
+			p.Body = status.SpoilerText
+		} else {
+			p.Body = html2text.HTML2TextWithOptions(
+				status.Content,
+				html2text.WithLinksInnerText(),
+				html2text.WithUnixLineBreaks(),
+			)
+		}
+	}
+
+	p.Body = truncate(p.Body, maxBodyLen)
+	return p
+}
+
+// truncate truncates the given string to at most n
+// characters, adding an ellipsis if it was truncated.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+
+	runes := []rune(s)
+	return string(runes[:n-1]) + "…"
+}
+
+// decodeBase64 decodes the given base64 string, which clients
+// may send either URL-safe or standard, padded or unpadded.
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	if strings.ContainsAny(s, "+/") {
+		return base64.RawStdEncoding.DecodeString(s)
+	}
+	return base64.RawURLEncoding.DecodeString(s)
+}
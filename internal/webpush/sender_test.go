@@ -0,0 +1,271 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SenderTestSuite struct {
+	suite.Suite
+	state         state.State
+	testAccounts  map[string]*gtsmodel.Account
+	testTokens    map[string]*gtsmodel.Token
+	requests      []*http.Request
+	requestBodies [][]byte
+	status        int
+	sender        webpush.Sender
+}
+
+func (suite *SenderTestSuite) SetupSuite() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+}
+
+func (suite *SenderTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+	testrig.StartNoopWorkers(&suite.state)
+
+	_ = testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.state.DB, nil)
+
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testTokens = testrig.NewTestTokens()
+
+	suite.requests = nil
+	suite.requestBodies = nil
+	suite.status = http.StatusCreated
+	httpClient := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		suite.requests = append(suite.requests, req)
+		suite.requestBodies = append(suite.requestBodies, body)
+		return &http.Response{
+			StatusCode: suite.status,
+			Status:     http.StatusText(suite.status),
+			Body:       io.NopCloser(bytes.NewReader(nil)),
+		}, nil
+	}, "")
+
+	suite.sender = webpush.NewSender(httpClient, &suite.state)
+}
+
+func (suite *SenderTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.state.DB)
+	testrig.StopWorkers(&suite.state)
+}
+
+// putSubscription creates a subscription for local_account_1's
+// token, subscribed to mentions only, with the given policy.
+func (suite *SenderTestSuite) putSubscription(policy gtsmodel.WebPushNotificationPolicy) *gtsmodel.WebPushSubscription {
+	uaPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	authSecret := make([]byte, 16)
+	if _, err := rand.Read(authSecret); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	subscription := &gtsmodel.WebPushSubscription{
+		ID:        id.NewULID(),
+		AccountID: suite.testAccounts["local_account_1"].ID,
+		TokenID:   suite.testTokens["local_account_1"].ID,
+		Endpoint:  "https://push.example.org/send/123",
+		Auth:      base64.RawURLEncoding.EncodeToString(authSecret),
+		P256dh:    base64.RawURLEncoding.EncodeToString(uaPrivate.PublicKey().Bytes()),
+		Policy:    policy,
+	}
+	subscription.NotificationFlags.Set(gtsmodel.NotificationMention, true)
+
+	// Replace any existing subscription for this token.
+	if err := suite.state.DB.DeleteWebPushSubscriptionByTokenID(context.Background(), subscription.TokenID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.state.DB.PutWebPushSubscription(context.Background(), subscription); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return subscription
+}
+
+// send sends a notification of the given type from
+// the given account to local_account_1, and runs any
+// queued deliveries, returning how many there were.
+func (suite *SenderTestSuite) send(notificationType gtsmodel.NotificationType, origin string) int {
+	ctx := context.Background()
+	notification := &gtsmodel.Notification{
+		ID:               id.NewULID(),
+		NotificationType: notificationType,
+		TargetAccountID:  suite.testAccounts["local_account_1"].ID,
+		OriginAccountID:  suite.testAccounts[origin].ID,
+	}
+	apiNotification := &apimodel.Notification{
+		ID:   notification.ID,
+		Type: string(notificationType),
+		Account: &apimodel.Account{
+			Username: suite.testAccounts[origin].Username,
+		},
+	}
+
+	if err := suite.sender.Send(ctx, notification, apiNotification); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var n int
+	for {
+		fn, ok := suite.state.Workers.WebPush.Queue.Pop()
+		if !ok {
+			return n
+		}
+		fn(ctx)
+		n++
+	}
+}
+
+func (suite *SenderTestSuite) TestSend() {
+	subscription := suite.putSubscription(gtsmodel.WebPushNotificationPolicyAll)
+
+	if !suite.Equal(1, suite.send(gtsmodel.NotificationMention, "local_account_2")) {
+		suite.FailNow("expected a delivery")
+	}
+
+	req := suite.requests[0]
+	body := suite.requestBodies[0]
+	suite.Equal(http.MethodPost, req.Method)
+	suite.Equal(subscription.Endpoint, req.URL.String())
+	suite.Equal("aes128gcm", req.Header.Get("Content-Encoding"))
+	suite.Equal("172800", req.Header.Get("TTL"))
+
+	// aes128gcm header: 16 byte salt, 4 byte
+	// record size, then a 65 byte key ID.
+	suite.Greater(len(body), 86)
+	suite.Equal([]byte{0, 0, 16, 0, 65}, body[16:21])
+
+	// Authorization header should be a JWT
+	// signed with the instance's VAPID key.
+	vapidKeyPair, err := suite.state.DB.GetVAPIDKeyPair(context.Background())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	jwt, key, ok := strings.Cut(strings.TrimPrefix(req.Header.Get("Authorization"), "vapid t="), ", k=")
+	suite.True(ok)
+	suite.Equal(vapidKeyPair.Public, key)
+
+	parts := strings.Split(jwt, ".")
+	if !suite.Len(parts, 3) {
+		suite.FailNow("invalid jwt")
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var claims map[string]any
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("https://push.example.org", claims["aud"])
+	suite.Equal("http://localhost:8080", claims["sub"])
+
+	publicKeyBytes, err := base64.RawURLEncoding.DecodeString(key)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	x, y := elliptic.Unmarshal(elliptic.P256(), publicKeyBytes) //nolint:staticcheck
+	publicKey := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	suite.True(ecdsa.Verify(
+		publicKey,
+		digest[:],
+		new(big.Int).SetBytes(signature[:32]),
+		new(big.Int).SetBytes(signature[32:]),
+	))
+}
+
+func (suite *SenderTestSuite) TestSendNotSubscribedToType() {
+	suite.putSubscription(gtsmodel.WebPushNotificationPolicyAll)
+	suite.Zero(suite.send(gtsmodel.NotificationFave, "local_account_2"))
+	suite.Empty(suite.requests)
+}
+
+func (suite *SenderTestSuite) TestSendPolicy() {
+	// local_account_1 and local_account_2 follow each other,
+	// while local_account_1 and remote_account_1 don't.
+	suite.putSubscription(gtsmodel.WebPushNotificationPolicyFollowed)
+	suite.Equal(1, suite.send(gtsmodel.NotificationMention, "local_account_2"))
+	suite.Zero(suite.send(gtsmodel.NotificationMention, "remote_account_1"))
+
+	suite.putSubscription(gtsmodel.WebPushNotificationPolicyFollower)
+	suite.Equal(1, suite.send(gtsmodel.NotificationMention, "local_account_2"))
+	suite.Zero(suite.send(gtsmodel.NotificationMention, "remote_account_1"))
+
+	suite.putSubscription(gtsmodel.WebPushNotificationPolicyNone)
+	suite.Zero(suite.send(gtsmodel.NotificationMention, "local_account_2"))
+}
+
+func (suite *SenderTestSuite) TestSendGone() {
+	subscription := suite.putSubscription(gtsmodel.WebPushNotificationPolicyAll)
+
+	// Push service says the subscription has
+	// gone, so it should have been deleted.
+	suite.status = http.StatusGone
+	suite.Equal(1, suite.send(gtsmodel.NotificationMention, "local_account_2"))
+
+	_, err := suite.state.DB.GetWebPushSubscriptionByTokenID(context.Background(), subscription.TokenID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestSenderTestSuite(t *testing.T) {
+	suite.Run(t, new(SenderTestSuite))
+}
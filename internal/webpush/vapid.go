@@ -0,0 +1,162 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// vapidJWTLifetime is how long a VAPID JWT
+// is valid for. RFC 8292 caps this at 24h.
+const vapidJWTLifetime = 12 * time.Hour
+
+// GenerateVAPIDKeyPair generates a new P-256 key
+// pair suitable for identifying this instance to
+// Web Push services, as described in RFC 8292.
+func GenerateVAPIDKeyPair() (*gtsmodel.VAPIDKeyPair, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, gtserror.Newf("error generating key: %w", err)
+	}
+
+	privateKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, gtserror.Newf("error marshaling private key: %w", err)
+	}
+
+	publicKey, err := privateKey.PublicKey.ECDH()
+	if err != nil {
+		return nil, gtserror.Newf("error converting public key: %w", err)
+	}
+
+	return &gtsmodel.VAPIDKeyPair{
+		Public:  base64.RawURLEncoding.EncodeToString(publicKey.Bytes()),
+		Private: base64.StdEncoding.EncodeToString(privateKeyBytes),
+	}, nil
+}
+
+// GetOrCreateVAPIDKeyPair fetches the instance's VAPID
+// key pair from the database, generating and storing
+// a new one if it doesn't exist yet.
+func GetOrCreateVAPIDKeyPair(ctx context.Context, webPushDB db.WebPush) (*gtsmodel.VAPIDKeyPair, error) {
+	vapidKeyPair, err := webPushDB.GetVAPIDKeyPair(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting vapid key pair: %w", err)
+	}
+
+	if vapidKeyPair != nil {
+		// Already
+		// generated.
+		return vapidKeyPair, nil
+	}
+
+	vapidKeyPair, err = GenerateVAPIDKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := webPushDB.PutVAPIDKeyPair(ctx, vapidKeyPair); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// Someone else got there
+			// first, just use theirs.
+			return webPushDB.GetVAPIDKeyPair(ctx)
+		}
+		return nil, gtserror.Newf("db error putting vapid key pair: %w", err)
+	}
+
+	return vapidKeyPair, nil
+}
+
+// vapidPrivateKey parses the private key of the given VAPID key pair.
+func vapidPrivateKey(vapidKeyPair *gtsmodel.VAPIDKeyPair) (*ecdsa.PrivateKey, error) {
+	der, err := base64.StdEncoding.DecodeString(vapidKeyPair.Private)
+	if err != nil {
+		return nil, gtserror.Newf("error decoding private key: %w", err)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing private key: %w", err)
+	}
+
+	privateKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok || privateKey.Curve != elliptic.P256() {
+		return nil, gtserror.New("private key is not a P-256 key")
+	}
+
+	return privateKey, nil
+}
+
+// vapidAuthorization returns an Authorization header value for a
+// push to the given endpoint, using the "vapid" scheme from RFC 8292.
+func vapidAuthorization(
+	vapidKeyPair *gtsmodel.VAPIDKeyPair,
+	privateKey *ecdsa.PrivateKey,
+	endpoint string,
+	subject string,
+	now time.Time,
+) (string, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return "", gtserror.Newf("error parsing endpoint: %w", err)
+	}
+
+	claims, err := json.Marshal(map[string]any{
+		// Audience is the origin of the push resource.
+		"aud": endpointURL.Scheme + "://" + endpointURL.Host,
+		"exp": now.Add(vapidJWTLifetime).Unix(),
+		"sub": subject,
+	})
+	if err != nil {
+		return "", gtserror.Newf("error marshaling claims: %w", err)
+	}
+
+	// ES256 JWT, see RFC 7515 and RFC 7518 section 3.4.
+	const header = `{"typ":"JWT","alg":"ES256"}`
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(header)) +
+		"." + base64.RawURLEncoding.EncodeToString(claims)
+
+	digest := sha256.Sum256([]byte(unsigned))
+	r, s, err := ecdsa.Sign(rand.Reader, privateKey, digest[:])
+	if err != nil {
+		return "", gtserror.Newf("error signing jwt: %w", err)
+	}
+
+	// Signature is R || S, each
+	// left-padded to 32 bytes.
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	jwt := unsigned + "." + base64.RawURLEncoding.EncodeToString(signature)
+	return "vapid t=" + jwt + ", k=" + vapidKeyPair.Public, nil
+}
@@ -6,11 +6,14 @@ EXPECT=$(cat << "EOF"
 {
     "account-domain": "peepee",
     "accounts-allow-custom-css": true,
+    "accounts-archive-import-max-json-size": 1048576,
     "accounts-custom-css-length": 5000,
     "accounts-reason-required": false,
     "accounts-registration-open": true,
     "advanced-cookies-samesite": "strict",
     "advanced-csp-extra-uris": [],
+    "advanced-delivery-probe-every": 1800000000000,
+    "advanced-delivery-unavailable-after": 172800000000000,
     "advanced-header-filter-mode": "block",
     "advanced-rate-limit-exceptions": [
         "192.0.2.0/24",
@@ -32,6 +35,7 @@ EXPECT=$(cat << "EOF"
         "block-mem-ratio": 2,
         "boost-of-ids-mem-ratio": 3,
         "client-mem-ratio": 0.1,
+        "content-limit-mem-ratio": 0.1,
         "conversation-last-status-ids-mem-ratio": 2,
         "conversation-mem-ratio": 1,
         "emoji-category-mem-ratio": 0.1,
@@ -60,12 +64,16 @@ EXPECT=$(cat << "EOF"
         "poll-vote-ids-mem-ratio": 2,
         "poll-vote-mem-ratio": 2,
         "report-mem-ratio": 1,
+        "scheduled-status-mem-ratio": 0.5,
         "sin-bin-status-mem-ratio": 0.5,
         "status-bookmark-ids-mem-ratio": 2,
         "status-bookmark-mem-ratio": 0.5,
+        "status-edit-mem-ratio": 2,
         "status-fave-ids-mem-ratio": 3,
         "status-fave-mem-ratio": 2,
         "status-mem-ratio": 5,
+        "status-reaction-ids-mem-ratio": 2,
+        "status-reaction-mem-ratio": 1,
         "tag-mem-ratio": 2,
         "thread-mute-mem-ratio": 0.2,
         "token-mem-ratio": 0.75,
@@ -74,6 +82,7 @@ EXPECT=$(cat << "EOF"
         "user-mute-ids-mem-ratio": 3,
         "user-mute-mem-ratio": 2,
         "visibility-mem-ratio": 2,
+        "web-push-subscription-mem-ratio": 1,
         "webfinger-mem-ratio": 0.1
     },
     "config-path": "internal/config/testdata/test.yaml",
@@ -100,7 +109,9 @@ EXPECT=$(cat << "EOF"
         "timeout": 30000000000,
         "tls-insecure-skip-verify": false
     },
+    "include-media": false,
     "instance-deliver-to-shared-inboxes": false,
+    "instance-directory-enabled": false,
     "instance-expose-peers": true,
     "instance-expose-public-timeline": true,
     "instance-expose-suspended": true,
@@ -112,6 +123,8 @@ EXPECT=$(cat << "EOF"
         "nl",
         "en-GB"
     ],
+    "instance-subscriptions-process-every": 86400000000000,
+    "instance-subscriptions-process-from": "23:00",
     "landing-page-user": "admin",
     "letsencrypt-cert-dir": "/gotosocial/storage/certs",
     "letsencrypt-email-address": "",
@@ -132,6 +145,10 @@ EXPECT=$(cat << "EOF"
     "media-local-max-size": 420,
     "media-remote-cache-days": 30,
     "media-remote-max-size": 420,
+    "media-transcode": false,
+    "media-transcode-audio-format": "opus",
+    "media-transcode-max-duration": 300000000000,
+    "media-transcode-max-size": 420,
     "metrics-auth-enabled": false,
     "metrics-auth-password": "",
     "metrics-auth-username": "",
@@ -233,6 +250,7 @@ GTS_INSTANCE_EXPOSE_PEERS=true \
 GTS_INSTANCE_EXPOSE_SUSPENDED=true \
 GTS_INSTANCE_EXPOSE_SUSPENDED_WEB=true \
 GTS_INSTANCE_EXPOSE_PUBLIC_TIMELINE=true \
+GTS_INSTANCE_DIRECTORY_ENABLED=false \
 GTS_INSTANCE_FEDERATION_MODE='allowlist' \
 GTS_INSTANCE_FEDERATION_SPAM_FILTER=true \
 GTS_INSTANCE_DELIVER_TO_SHARED_INBOXES=false \
@@ -240,6 +258,7 @@ GTS_INSTANCE_INJECT_MASTODON_VERSION=true \
 GTS_INSTANCE_LANGUAGES="nl,en-gb" \
 GTS_ACCOUNTS_ALLOW_CUSTOM_CSS=true \
 GTS_ACCOUNTS_CUSTOM_CSS_LENGTH=5000 \
+GTS_ACCOUNTS_ARCHIVE_IMPORT_MAX_JSON_SIZE=1MiB \
 GTS_ACCOUNTS_REGISTRATION_OPEN=true \
 GTS_ACCOUNTS_REASON_REQUIRED=false \
 GTS_MEDIA_DESCRIPTION_MIN_CHARS=69 \
@@ -250,6 +269,10 @@ GTS_MEDIA_REMOTE_CACHE_DAYS=30 \
 GTS_MEDIA_EMOJI_LOCAL_MAX_SIZE=420 \
 GTS_MEDIA_EMOJI_REMOTE_MAX_SIZE=420 \
 GTS_MEDIA_FFMPEG_POOL_SIZE=8 \
+GTS_MEDIA_TRANSCODE=false \
+GTS_MEDIA_TRANSCODE_MAX_SIZE=420 \
+GTS_MEDIA_TRANSCODE_MAX_DURATION='5m' \
+GTS_MEDIA_TRANSCODE_AUDIO_FORMAT='opus' \
 GTS_METRICS_AUTH_ENABLED=false \
 GTS_METRICS_ENABLED=false \
 GTS_STORAGE_BACKEND='local' \
@@ -298,6 +321,8 @@ GTS_ADVANCED_SENDER_MULTIPLIER=-1 \
 GTS_ADVANCED_THROTTLING_MULTIPLIER=-1 \
 GTS_ADVANCED_THROTTLING_RETRY_AFTER='10s' \
 GTS_ADVANCED_HEADER_FILTER_MODE='block' \
+GTS_ADVANCED_DELIVERY_UNAVAILABLE_AFTER='48h' \
+GTS_ADVANCED_DELIVERY_PROBE_EVERY='30m' \
 GTS_REQUEST_ID_HEADER='X-Trace-Id' \
 go run ./cmd/gotosocial/... --config-path internal/config/testdata/test.yaml debug config)
 
@@ -90,6 +90,7 @@ func testDefaults() config.Configuration {
 		InstanceExposePeers:            true,
 		InstanceExposeSuspended:        true,
 		InstanceExposeSuspendedWeb:     true,
+		InstanceDirectoryEnabled:       true,
 		InstanceDeliverToSharedInboxes: true,
 		InstanceLanguages: language.Languages{
 			{
@@ -105,6 +106,8 @@ func testDefaults() config.Configuration {
 		AccountsAllowCustomCSS:   true,
 		AccountsCustomCSSLength:  10000,
 
+		AccountsArchiveImportMaxJSONSize: 100 * bytesize.MiB,
+
 		MediaDescriptionMinChars: 0,
 		MediaDescriptionMaxChars: 500,
 		MediaRemoteCacheDays:     7,
@@ -115,6 +118,13 @@ func testDefaults() config.Configuration {
 		MediaCleanupFrom:         "00:00",        // midnight.
 		MediaCleanupEvery:        24 * time.Hour, // 1/day.
 
+		// transcoding disabled so that test
+		// media is stored in original formats.
+		MediaTranscode:            false,
+		MediaTranscodeMaxSize:     40 * bytesize.MiB,
+		MediaTranscodeMaxDuration: 10 * time.Minute,
+		MediaTranscodeAudioFormat: config.MediaTranscodeAudioFormatMP3,
+
 		// the testrig only uses in-memory storage, so we can
 		// safely set this value to 'test' to avoid running storage
 		// migrations, and other silly things like that
@@ -166,6 +176,9 @@ func testDefaults() config.Configuration {
 		AdvancedThrottlingMultiplier: 0, // disabled
 		AdvancedSenderMultiplier:     0, // 1 sender only, regardless of CPU
 
+		AdvancedDeliveryUnavailableAfter: 24 * time.Hour,
+		AdvancedDeliveryProbeEvery:       time.Hour,
+
 		SoftwareVersion: "0.0.0-testrig",
 
 		// simply use cache defaults.
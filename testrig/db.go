@@ -29,12 +29,20 @@ import (
 
 var testModels = []interface{}{
 	&gtsmodel.Account{},
+	&gtsmodel.AccountArchive{},
 	&gtsmodel.AccountNote{},
 	&gtsmodel.AccountSettings{},
 	&gtsmodel.AccountToEmoji{},
+	&gtsmodel.Announcement{},
+	&gtsmodel.AnnouncementRead{},
+	&gtsmodel.AnnouncementReaction{},
 	&gtsmodel.Application{},
 	&gtsmodel.Block{},
+	&gtsmodel.ContentLimit{},
+	&gtsmodel.DomainAllow{},
 	&gtsmodel.DomainBlock{},
+	&gtsmodel.DomainPermissionDraft{},
+	&gtsmodel.DomainPermissionSubscription{},
 	&gtsmodel.EmailDomainBlock{},
 	&gtsmodel.Filter{},
 	&gtsmodel.FilterKeyword{},
@@ -49,15 +57,24 @@ var testModels = []interface{}{
 	&gtsmodel.Mention{},
 	&gtsmodel.Poll{},
 	&gtsmodel.PollVote{},
+	&gtsmodel.Relay{},
 	&gtsmodel.Status{},
 	&gtsmodel.StatusToEmoji{},
 	&gtsmodel.StatusToTag{},
 	&gtsmodel.StatusFave{},
+	&gtsmodel.StatusReaction{},
+	&gtsmodel.FeaturedSuggestion{},
+	&gtsmodel.SuggestionDismissal{},
 	&gtsmodel.StatusBookmark{},
+	&gtsmodel.StatusEdit{},
+	&gtsmodel.ScheduledStatus{},
+	&gtsmodel.VAPIDKeyPair{},
+	&gtsmodel.WebPushSubscription{},
 	&gtsmodel.Tag{},
 	&gtsmodel.Thread{},
 	&gtsmodel.ThreadMute{},
 	&gtsmodel.ThreadToStatus{},
+	&gtsmodel.Trend{},
 	&gtsmodel.User{},
 	&gtsmodel.UserMute{},
 	&gtsmodel.Emoji{},
@@ -104,6 +121,11 @@ func CreateTestTables(db db.DB) {
 			log.Panicf(nil, "error creating table for %+v: %s", m, err)
 		}
 	}
+
+	// Create the (empty) search index.
+	if err := db.RebuildSearchIndex(ctx); err != nil {
+		log.Panicf(nil, "error creating search index: %s", err)
+	}
 }
 
 // StandardDBSetup populates a given db with all the necessary tables/models for perfoming tests.
@@ -159,12 +181,60 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		}
 	}
 
+	for _, v := range NewTestAnnouncements() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestAnnouncementReads() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestAnnouncementReactions() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestRelays() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestTrends() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestFeaturedSuggestions() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
 	for _, v := range NewTestDomainBlocks() {
 		if err := db.Put(ctx, v); err != nil {
 			log.Panic(nil, err)
 		}
 	}
 
+	for _, v := range NewTestDomainPermissionDrafts() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestDomainPermissionSubscriptions() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
 	for _, v := range NewTestInstances() {
 		if err := db.Put(ctx, v); err != nil {
 			log.Panic(nil, err)
@@ -361,6 +431,12 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		log.Panic(nil, err)
 	}
 
+	// Fixtures are inserted directly,
+	// so (re)build the search index.
+	if err := db.RebuildSearchIndex(ctx); err != nil {
+		log.Panic(nil, err)
+	}
+
 	log.Debug(nil, "testing db setup complete")
 }
 
@@ -27,6 +27,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 // NewTestProcessor returns a Processor suitable for testing purposes.
@@ -41,6 +42,7 @@ func NewTestProcessor(state *state.State, federator *federation.Federator, email
 		mediaManager,
 		state,
 		emailSender,
+		webpush.NewNoopSender(nil),
 		visibility.NewFilter(state),
 		interaction.NewFilter(state),
 	)
@@ -1333,6 +1333,61 @@ func NewTestInstances() map[string]*gtsmodel.Instance {
 	}
 }
 
+func NewTestRelays() map[string]*gtsmodel.Relay {
+	return map[string]*gtsmodel.Relay{
+		"relay_accepted": {
+			ID:                 "01JAKZ3YB0Q5C3N4T6XVPD8WKM",
+			CreatedAt:          TimeMustParse("2024-10-21T12:00:00+02:00"),
+			UpdatedAt:          TimeMustParse("2024-10-21T12:05:00+02:00"),
+			InboxURI:           "https://relay.example.org/inbox",
+			Domain:             "relay.example.org",
+			FollowURI:          "http://localhost:8080/users/localhost:8080/follow/01JAKZ3YB0Q5C3N4T6XVPD8WKM",
+			State:              gtsmodel.RelayStateAccepted,
+			AcceptedByURI:      "https://relay.example.org/actor",
+			Forward:            util.Ptr(true),
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+		},
+		"relay_pending": {
+			ID:                 "01JAKZ5E2N8RHQ0V9G7TCY3XBF",
+			CreatedAt:          TimeMustParse("2024-10-21T12:10:00+02:00"),
+			UpdatedAt:          TimeMustParse("2024-10-21T12:10:00+02:00"),
+			InboxURI:           "https://litepub.example.org/inbox",
+			ActorURI:           "https://litepub.example.org/relay",
+			Domain:             "litepub.example.org",
+			FollowURI:          "http://localhost:8080/users/localhost:8080/follow/01JAKZ5E2N8RHQ0V9G7TCY3XBF",
+			State:              gtsmodel.RelayStatePending,
+			Forward:            util.Ptr(false),
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+		},
+	}
+}
+
+func NewTestTrends() map[string]*gtsmodel.Trend {
+	return map[string]*gtsmodel.Trend{
+		"tag_welcome_approved": {
+			ID:                  "01JAQ7X4M2D8W5S3KCV0FRTN6B",
+			CreatedAt:           TimeMustParse("2024-10-23T12:00:00+02:00"),
+			UpdatedAt:           TimeMustParse("2024-10-23T12:30:00+02:00"),
+			Type:                gtsmodel.TrendTypeTag,
+			TargetID:            "01F8MHA1A2NF9MJ3WCCQ3K8BSZ",
+			State:               gtsmodel.TrendStateApproved,
+			ReviewedAt:          TimeMustParse("2024-10-23T12:30:00+02:00"),
+			ReviewedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+		},
+	}
+}
+
+func NewTestFeaturedSuggestions() map[string]*gtsmodel.FeaturedSuggestion {
+	return map[string]*gtsmodel.FeaturedSuggestion{
+		"local_account_1": {
+			ID:                 "01JATB4D9Q3Z7N2W8XKC6M5V1R",
+			CreatedAt:          TimeMustParse("2024-10-24T12:00:00+02:00"),
+			AccountID:          "01F8MH1H7YV1Z7D2C8K2730QBF",
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+		},
+	}
+}
+
 func NewTestDomainBlocks() map[string]*gtsmodel.DomainBlock {
 	return map[string]*gtsmodel.DomainBlock{
 		"replyguys.com": {
@@ -1348,6 +1403,49 @@ func NewTestDomainBlocks() map[string]*gtsmodel.DomainBlock {
 	}
 }
 
+func NewTestDomainPermissionDrafts() map[string]*gtsmodel.DomainPermissionDraft {
+	return map[string]*gtsmodel.DomainPermissionDraft{
+		"fossbros-anonymous.io": {
+			ID:                 "01JCZN614XG85GCGAMSV9ZZAEJ",
+			CreatedAt:          TimeMustParse("2024-11-01T11:00:00+02:00"),
+			UpdatedAt:          TimeMustParse("2024-11-01T11:00:00+02:00"),
+			PermissionType:     gtsmodel.DomainPermissionBlock,
+			Domain:             "fossbros-anonymous.io",
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			PrivateComment:     "this is a draft domain permission",
+			PublicComment:      "this is a public comment",
+			Obfuscate:          util.Ptr(false),
+		},
+		"infosec.example.org": {
+			ID:                 "01JDW7QK3CC6Q3S3PPEGGX9S0K",
+			CreatedAt:          TimeMustParse("2024-11-01T11:00:00+02:00"),
+			UpdatedAt:          TimeMustParse("2024-11-01T11:00:00+02:00"),
+			PermissionType:     gtsmodel.DomainPermissionAllow,
+			Domain:             "infosec.example.org",
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			PrivateComment:     "these people are nice",
+			Obfuscate:          util.Ptr(false),
+		},
+	}
+}
+
+func NewTestDomainPermissionSubscriptions() map[string]*gtsmodel.DomainPermissionSubscription {
+	return map[string]*gtsmodel.DomainPermissionSubscription{
+		"admin_sub_1": {
+			ID:                 "01JGE681TQSBPAV59GZXPKE62H",
+			CreatedAt:          TimeMustParse("2024-11-01T11:00:00+02:00"),
+			UpdatedAt:          TimeMustParse("2024-11-01T11:00:00+02:00"),
+			Priority:           255,
+			Title:              "whatever!",
+			PermissionType:     gtsmodel.DomainPermissionBlock,
+			AsDraft:            util.Ptr(false),
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			URI:                "https://lists.example.org/baddies.csv",
+			ContentType:        gtsmodel.DomainPermSubContentTypeCSV,
+		},
+	}
+}
+
 type filenames struct {
 	Original string
 	Small    string
@@ -2675,6 +2773,84 @@ func NewTestRules() map[string]*gtsmodel.Rule {
 	}
 }
 
+func NewTestAnnouncements() map[string]*gtsmodel.Announcement {
+	return map[string]*gtsmodel.Announcement{
+		"announcement1": {
+			ID:          "01JAD2W8X2A9ZG3C5NQF1S8V6P",
+			CreatedAt:   TimeMustParse("2024-10-01T10:00:00+02:00"),
+			UpdatedAt:   TimeMustParse("2024-10-01T10:00:00+02:00"),
+			PublishedAt: TimeMustParse("2024-10-01T10:00:00+02:00"),
+			AccountID:   "01F8MH17FWEB39HZJ76B6VXSKF",
+			Text:        "Welcome to the instance! :rainbow:",
+			Content:     "<p>Welcome to the instance! :rainbow:</p>",
+			EmojiIDs:    []string{"01F8MH9H8E4VG3KDYJR9EGPXCQ"},
+			AllDay:      util.Ptr(false),
+			Published:   util.Ptr(true),
+		},
+		"announcement_draft": {
+			ID:        "01JAD2XQ7K8M3TBFRW4H6ZN9CE",
+			CreatedAt: TimeMustParse("2024-10-02T10:00:00+02:00"),
+			UpdatedAt: TimeMustParse("2024-10-02T10:00:00+02:00"),
+			AccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			Text:      "Scheduled maintenance coming soon.",
+			Content:   "<p>Scheduled maintenance coming soon.</p>",
+			AllDay:    util.Ptr(false),
+			Published: util.Ptr(false),
+		},
+		"announcement_ended": {
+			ID:          "01HK2R0Y5G9V8D3SB7QW1XJ4MF",
+			CreatedAt:   TimeMustParse("2024-01-01T10:00:00+02:00"),
+			UpdatedAt:   TimeMustParse("2024-01-01T10:00:00+02:00"),
+			PublishedAt: TimeMustParse("2024-01-01T10:00:00+02:00"),
+			AccountID:   "01F8MH17FWEB39HZJ76B6VXSKF",
+			Text:        "Happy new year!",
+			Content:     "<p>Happy new year!</p>",
+			StartsAt:    TimeMustParse("2024-01-01T00:00:00Z"),
+			EndsAt:      TimeMustParse("2024-01-02T00:00:00Z"),
+			AllDay:      util.Ptr(true),
+			Published:   util.Ptr(true),
+		},
+	}
+}
+
+func NewTestAnnouncementReads() map[string]*gtsmodel.AnnouncementRead {
+	return map[string]*gtsmodel.AnnouncementRead{
+		"local_account_2_announcement1": {
+			ID:             "01JAD3A1QH5N7R2V6CWT8KD0XB",
+			CreatedAt:      TimeMustParse("2024-10-01T11:00:00+02:00"),
+			AnnouncementID: "01JAD2W8X2A9ZG3C5NQF1S8V6P",
+			AccountID:      "01F8MH5NBDF2MV7CTC4Q5128HF",
+		},
+	}
+}
+
+func NewTestAnnouncementReactions() map[string]*gtsmodel.AnnouncementReaction {
+	return map[string]*gtsmodel.AnnouncementReaction{
+		"admin_account_announcement1_thumbsup": {
+			ID:             "01JAD3B4M8T2E6Y9HFV1GQ5KNS",
+			CreatedAt:      TimeMustParse("2024-10-01T10:30:00+02:00"),
+			AnnouncementID: "01JAD2W8X2A9ZG3C5NQF1S8V6P",
+			AccountID:      "01F8MH17FWEB39HZJ76B6VXSKF",
+			Name:           "👍",
+		},
+		"local_account_2_announcement1_thumbsup": {
+			ID:             "01JAD3C7Z0R4W1N5BKX8PJ2DGH",
+			CreatedAt:      TimeMustParse("2024-10-01T10:45:00+02:00"),
+			AnnouncementID: "01JAD2W8X2A9ZG3C5NQF1S8V6P",
+			AccountID:      "01F8MH5NBDF2MV7CTC4Q5128HF",
+			Name:           "👍",
+		},
+		"local_account_2_announcement1_rainbow": {
+			ID:             "01JAD3D9F6V3B8S1MQZ4YT7WRA",
+			CreatedAt:      TimeMustParse("2024-10-01T10:46:00+02:00"),
+			AnnouncementID: "01JAD2W8X2A9ZG3C5NQF1S8V6P",
+			AccountID:      "01F8MH5NBDF2MV7CTC4Q5128HF",
+			Name:           "rainbow",
+			EmojiID:        "01F8MH9H8E4VG3KDYJR9EGPXCQ",
+		},
+	}
+}
+
 // ActivityWithSignature wraps a pub.Activity along with its signature headers, for testing.
 type ActivityWithSignature struct {
 	Activity        pub.Activity
@@ -41,6 +41,8 @@ import (
 const (
 	applicationJSON         = "application/json"
 	applicationActivityJSON = "application/activity+json"
+	textCSV                 = "text/csv"
+	textPlain               = "text/plain"
 )
 
 // NewTestTransportController returns a test transport controller with the given http client.
@@ -101,6 +103,7 @@ func NewMockHTTPClient(do func(req *http.Request) (*http.Response, error), relat
 			responseBytes         = []byte(`{"error":"404 not found"}`)
 			responseContentType   = applicationJSON
 			responseContentLength = len(responseBytes)
+			responseHeaders       = http.Header{}
 			reqURLString          = req.URL.String()
 		)
 
@@ -129,6 +132,8 @@ func NewMockHTTPClient(do func(req *http.Request) (*http.Response, error), relat
 			responseCode, responseBytes, responseContentType, responseContentLength = WebfingerResponse(req)
 		} else if strings.Contains(reqURLString, ".well-known/host-meta") {
 			responseCode, responseBytes, responseContentType, responseContentLength = HostMetaResponse(req)
+		} else if strings.HasPrefix(reqURLString, "https://lists.example.org/") {
+			responseCode, responseBytes, responseContentType, responseContentLength, responseHeaders = DomainPermissionSubscriptionResponse(req)
 		} else if note, ok := mockHTTPClient.TestRemoteStatuses[reqURLString]; ok {
 			// the request is for a note that we have stored
 			noteI, err := streams.Serialize(note)
@@ -241,12 +246,13 @@ func NewMockHTTPClient(do func(req *http.Request) (*http.Response, error), relat
 		log.Debugf(nil, "returning response %s", string(responseBytes))
 		reader := bytes.NewReader(responseBytes)
 		readCloser := io.NopCloser(reader)
+		responseHeaders.Set("Content-Type", responseContentType)
 		return &http.Response{
 			Request:       req,
 			StatusCode:    responseCode,
 			Body:          readCloser,
 			ContentLength: int64(responseContentLength),
-			Header:        http.Header{"Content-Type": {responseContentType}},
+			Header:        responseHeaders,
 		}, nil
 	}
 
@@ -297,6 +303,90 @@ func HostMetaResponse(req *http.Request) (responseCode int, responseBytes []byte
 	return
 }
 
+// DomainPermissionSubscriptionResponse serves domain permission
+// lists for subscriptions under https://lists.example.org/.
+// Each list has a fixed ETag, and a request sending that
+// ETag in If-None-Match gets a 304 Not Modified response.
+func DomainPermissionSubscriptionResponse(req *http.Request) (
+	responseCode int,
+	responseBytes []byte,
+	responseContentType string,
+	responseContentLength int,
+	responseHeaders http.Header,
+) {
+	var etag string
+	responseHeaders = http.Header{}
+
+	switch req.URL.String() {
+	case "https://lists.example.org/baddies.csv":
+		etag = `"baddies-csv-v1"`
+		responseContentType = textCSV
+		responseBytes = []byte(`#domain,#severity,#reject_media,#reject_reports,#public_comment,#obfuscate
+bumfaces.net,suspend,false,false,big jerks,false
+peepee.poopoo,suspend,false,false,harassment,false
+nothanks.com,suspend,false,false,,false
+silenced.example.org,silence,false,false,just noisy,false`)
+	case "https://lists.example.org/baddies.json":
+		etag = `"baddies-json-v1"`
+		responseContentType = applicationJSON
+		responseBytes = []byte(`[
+  {
+    "domain": "bumfaces.net",
+    "suspended_at": "2020-05-13T13:29:12.000Z",
+    "public_comment": "big jerks"
+  },
+  {
+    "domain": "peepee.poopoo",
+    "suspended_at": "2020-05-13T13:29:12.000Z",
+    "public_comment": "harassment"
+  },
+  {
+    "domain": "nothanks.com",
+    "suspended_at": "2020-05-13T13:29:12.000Z"
+  }
+]`)
+	case "https://lists.example.org/baddies.txt":
+		etag = `"baddies-txt-v1"`
+		responseContentType = textPlain
+		responseBytes = []byte(`# some baddies
+bumfaces.net
+
+peepee.poopoo
+nothanks.com`)
+	case "https://lists.example.org/goodies.txt":
+		etag = `"goodies-txt-v1"`
+		responseContentType = textPlain
+		responseBytes = []byte(`goodeggs.org
+rainbowfriends.net`)
+	case "https://lists.example.org/huge.txt":
+		// Just over the 16MiB list size limit.
+		etag = `"huge-txt-v1"`
+		responseContentType = textPlain
+		line := []byte("some.really.long.domain.example.org\n")
+		responseBytes = bytes.Repeat(line, (16<<20)/len(line)+1)
+	default:
+		responseCode = http.StatusNotFound
+		responseBytes = []byte(`{"error":"404 not found"}`)
+		responseContentType = applicationJSON
+		responseContentLength = len(responseBytes)
+		return
+	}
+
+	responseHeaders.Set("ETag", etag)
+	responseHeaders.Set("Last-Modified", "Wed, 02 Oct 2024 11:24:57 GMT")
+
+	if req.Header.Get("If-None-Match") == etag {
+		responseCode = http.StatusNotModified
+		responseBytes = []byte{}
+		responseContentLength = 0
+		return
+	}
+
+	responseCode = http.StatusOK
+	responseContentLength = len(responseBytes)
+	return
+}
+
 func WebfingerResponse(req *http.Request) (responseCode int, responseBytes []byte, responseContentType string, responseContentLength int) {
 	var wfr *apimodel.WellKnownResponse
 
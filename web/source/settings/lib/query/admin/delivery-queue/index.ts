@@ -0,0 +1,75 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+import { DeliveryQueue } from "../../../types/delivery-queue";
+import { gtsApi } from "../../gts-api";
+
+const extended = gtsApi.injectEndpoints({
+	endpoints: (build) => ({
+		getDeliveryQueue: build.query<DeliveryQueue[], void>({
+			query: () => ({
+				url: `/api/v1/admin/delivery_queue`,
+			}),
+			providesTags: [{ type: "DeliveryQueue", id: "LIST" }],
+		}),
+
+		purgeDeliveryQueue: build.mutation<DeliveryQueue, string>({
+			query: (domain) => ({
+				method: "POST",
+				url: `/api/v1/admin/delivery_queue/purge`,
+				asForm: true,
+				body: { domain: domain },
+			}),
+			invalidatesTags: [{ type: "DeliveryQueue", id: "LIST" }],
+		}),
+
+		retryDeliveryQueue: build.mutation<DeliveryQueue, string>({
+			query: (domain) => ({
+				method: "POST",
+				url: `/api/v1/admin/delivery_queue/retry`,
+				asForm: true,
+				body: { domain: domain },
+			}),
+			invalidatesTags: [{ type: "DeliveryQueue", id: "LIST" }],
+		}),
+	}),
+});
+
+/**
+ * GET /api/v1/admin/delivery_queue to view
+ * outgoing deliveries pending per domain.
+ */
+const useGetDeliveryQueueQuery = extended.useGetDeliveryQueueQuery;
+
+/**
+ * POST /api/v1/admin/delivery_queue/purge to drop
+ * all outgoing deliveries pending to a domain.
+ */
+const usePurgeDeliveryQueueMutation = extended.usePurgeDeliveryQueueMutation;
+
+/**
+ * POST /api/v1/admin/delivery_queue/retry to force a retry
+ * of failed outgoing deliveries pending to a domain.
+ */
+const useRetryDeliveryQueueMutation = extended.useRetryDeliveryQueueMutation;
+
+export {
+	useGetDeliveryQueueQuery,
+	usePurgeDeliveryQueueMutation,
+	useRetryDeliveryQueueMutation,
+};
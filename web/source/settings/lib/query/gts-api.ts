@@ -143,6 +143,12 @@ const gtsBaseQuery: BaseQueryFn<
 			return headers;
 		},
 		responseHandler: (response) => {
+			// Return binary data as a blob
+			// for caller to download.
+			if (accept === "application/zip") {
+				return response.blob();
+			}
+
 			// Return just text if caller has
 			// set a custom accept content-type.
 			if (accept !== "application/json") {
@@ -169,6 +175,11 @@ export const gtsApi = createApi({
 		"HTTPHeaderBlocks",
 		"DefaultInteractionPolicies",
 		"InteractionRequest",
+		"User",
+		"Token",
+		"Application",
+		"AccountArchive",
+		"DeliveryQueue",
 	],
 	endpoints: (build) => ({
 		instanceV1: build.query<InstanceV1, void>({
@@ -21,7 +21,7 @@ import fileDownload from "js-file-download";
 
 import { gtsApi } from "../gts-api";
 import { FetchBaseQueryError } from "@reduxjs/toolkit/query";
-import { AccountExportStats } from "../../types/account";
+import { AccountArchive, AccountExportStats } from "../../types/account";
 
 const extended = gtsApi.injectEndpoints({
 	endpoints: (build) => ({
@@ -126,6 +126,40 @@ const extended = gtsApi.injectEndpoints({
 			}
 		}),
 
+		accountArchives: build.query<AccountArchive[], void>({
+			query: () => ({
+				url: `/api/v1/exports/archives`
+			}),
+			providesTags: [{ type: "AccountArchive", id: "LIST" }],
+		}),
+
+		requestAccountArchive: build.mutation<AccountArchive, void>({
+			query: () => ({
+				method: "POST",
+				url: `/api/v1/exports/archives`,
+			}),
+			invalidatesTags: [{ type: "AccountArchive", id: "LIST" }],
+		}),
+
+		downloadAccountArchive: build.mutation<string | null, string>({
+			async queryFn(id, _api, _extraOpts, fetchWithBQ) {
+				const zipRes = await fetchWithBQ({
+					url: `/api/v1/exports/archives/${id}/download`,
+					acceptContentType: "application/zip",
+				});
+				if (zipRes.error) {
+					return { error: zipRes.error as FetchBaseQueryError };
+				}
+
+				if (zipRes.meta?.response?.status !== 200) {
+					return { error: zipRes.data };
+				}
+
+				fileDownload(zipRes.data as Blob, `archive-${id}.zip`, "application/zip");
+				return { data: null };
+			}
+		}),
+
 		importData: build.mutation({
 			query: (formData) => ({
 				method: "POST",
@@ -145,5 +179,8 @@ export const {
 	useExportListsMutation,
 	useExportBlocksMutation,
 	useExportMutesMutation,
+	useAccountArchivesQuery,
+	useRequestAccountArchiveMutation,
+	useDownloadAccountArchiveMutation,
 	useImportDataMutation,
 } = extended;
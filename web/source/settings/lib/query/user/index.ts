@@ -41,7 +41,8 @@ const extended = gtsApi.injectEndpoints({
 		}),
 		
 		user: build.query<User, void>({
-			query: () => ({url: `/api/v1/user`})
+			query: () => ({url: `/api/v1/user`}),
+			providesTags: ["User"]
 		}),
 		
 		passwordChange: build.mutation({
@@ -61,6 +62,54 @@ const extended = gtsApi.injectEndpoints({
 			...replaceCacheOnMutation("user")
 		}),
 		
+		twoFactorQRCodeURI: build.mutation<string, void>({
+			query: () => ({
+				method: "POST",
+				url: `/api/v1/user/2fa/qruri`,
+				acceptContentType: "text/plain",
+			}),
+		}),
+
+		// Arg is the uri returned by twoFactorQRCodeURI,
+		// used only to tie the cached image to that uri.
+		twoFactorQRCodePng: build.query<string, string>({
+			query: () => ({
+				url: `/api/v1/user/2fa/qr.png`,
+				acceptContentType: "image/png",
+				// Store the image as an object
+				// URL so it can be used as img src.
+				responseHandler: async (response) => {
+					if (!response.ok) {
+						return response.json();
+					}
+
+					const blob = await response.blob();
+					return URL.createObjectURL(blob);
+				},
+			}),
+			keepUnusedDataFor: 0,
+		}),
+
+		twoFactorEnable: build.mutation<string[], { password: string, code: string }>({
+			query: (data) => ({
+				method: "POST",
+				url: `/api/v1/user/2fa/enable`,
+				asForm: true,
+				body: data,
+			}),
+			invalidatesTags: ["User"]
+		}),
+
+		twoFactorDisable: build.mutation<void, { password: string }>({
+			query: (data) => ({
+				method: "POST",
+				url: `/api/v1/user/2fa/disable`,
+				asForm: true,
+				body: data,
+			}),
+			invalidatesTags: ["User"]
+		}),
+		
 		aliasAccount: build.mutation<any, UpdateAliasesFormData>({
 			async queryFn(formData, _api, _extraOpts, fetchWithBQ) {
 				// Pull entries out from the hooked form.
@@ -125,6 +174,10 @@ export const {
 	useUserQuery,
 	usePasswordChangeMutation,
 	useEmailChangeMutation,
+	useTwoFactorQRCodeURIMutation,
+	useTwoFactorQRCodePngQuery,
+	useTwoFactorEnableMutation,
+	useTwoFactorDisableMutation,
 	useAliasAccountMutation,
 	useMoveAccountMutation,
 	useAccountThemesQuery,
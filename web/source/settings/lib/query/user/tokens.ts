@@ -0,0 +1,113 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import {
+	Application,
+	ListApplicationsResp,
+	ListParams,
+	ListTokensResp,
+	TokenInfo,
+} from "../../types/token";
+import { gtsApi } from "../gts-api";
+import parse from "parse-link-header";
+
+function listQuery(path: string, form: ListParams): string {
+	const params = new(URLSearchParams);
+	Object.entries(form).forEach(([k, v]) => {
+		if (v !== undefined) {
+			params.append(k, v);
+		}
+	});
+
+	if (params.size === 0) {
+		return path;
+	}
+
+	return `${path}?${params.toString()}`;
+}
+
+const extended = gtsApi.injectEndpoints({
+	endpoints: (build) => ({
+		listTokens: build.query<ListTokensResp, ListParams>({
+			query: (form) => ({
+				url: listQuery(`/api/v1/tokens`, form),
+			}),
+			// Headers required for paging.
+			transformResponse: (apiResp: TokenInfo[], meta) => {
+				const tokens = apiResp;
+				const linksStr = meta?.response?.headers.get("Link");
+				const links = parse(linksStr);
+				return { tokens, links };
+			},
+			providesTags: [{ type: "Token", id: "TRANSFORMED" }]
+		}),
+
+		invalidateToken: build.mutation<TokenInfo, string>({
+			query: (id) => ({
+				method: "POST",
+				url: `/api/v1/tokens/${id}/invalidate`,
+			}),
+			invalidatesTags: [{ type: "Token", id: "TRANSFORMED" }]
+		}),
+
+		invalidateAppTokens: build.mutation<any, string>({
+			query: (applicationID) => ({
+				method: "POST",
+				url: `/api/v1/tokens/invalidate`,
+				asForm: true,
+				body: { application_id: applicationID },
+			}),
+			invalidatesTags: [{ type: "Token", id: "TRANSFORMED" }]
+		}),
+
+		listApplications: build.query<ListApplicationsResp, ListParams>({
+			query: (form) => ({
+				url: listQuery(`/api/v1/apps`, form),
+			}),
+			// Headers required for paging.
+			transformResponse: (apiResp: Application[], meta) => {
+				const applications = apiResp;
+				const linksStr = meta?.response?.headers.get("Link");
+				const links = parse(linksStr);
+				return { applications, links };
+			},
+			providesTags: [{ type: "Application", id: "TRANSFORMED" }]
+		}),
+
+		deleteApplication: build.mutation<Application, string>({
+			query: (id) => ({
+				method: "DELETE",
+				url: `/api/v1/apps/${id}`,
+			}),
+			// Deleting an app also revokes its tokens.
+			invalidatesTags: [
+				{ type: "Application", id: "TRANSFORMED" },
+				{ type: "Token", id: "TRANSFORMED" },
+			]
+		}),
+	})
+});
+
+export const {
+	useLazyListTokensQuery,
+	useInvalidateTokenMutation,
+	useInvalidateAppTokensMutation,
+	useLazyListApplicationsQuery,
+	useDeleteApplicationMutation,
+} = extended;
@@ -120,3 +120,12 @@ export interface AccountExportStats {
 	blocks_count: number;
 	mutes_count: number;
 }
+
+export interface AccountArchive {
+	id: string;
+	created_at: string;
+	state: "processing" | "ready" | "failed";
+	size: number;
+	expires_at: string | null;
+	url: string | null;
+}
@@ -0,0 +1,59 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import { Links } from "parse-link-header";
+
+export interface Application {
+	id: string;
+	name: string;
+	website?: string;
+	redirect_uri?: string;
+	client_id?: string;
+	created_at?: string;
+	scopes?: string[];
+}
+
+export interface TokenInfo {
+	id: string;
+	created_at: string;
+	last_used?: string;
+	scope: string;
+	application: Application;
+}
+
+/**
+ * Parameters for paging through
+ * tokens or applications.
+ */
+export interface ListParams {
+	max_id?: string;
+	since_id?: string;
+	min_id?: string;
+	limit?: string;
+}
+
+export interface ListTokensResp {
+	tokens: TokenInfo[];
+	links: Links | null;
+}
+
+export interface ListApplicationsResp {
+	applications: Application[];
+	links: Links | null;
+}
@@ -0,0 +1,154 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+import React from "react";
+import { PageableList } from "../../../components/pageable-list";
+import MutationButton from "../../../components/form/mutation-button";
+import {
+	useGetDeliveryQueueQuery,
+	usePurgeDeliveryQueueMutation,
+	useRetryDeliveryQueueMutation,
+} from "../../../lib/query/admin/delivery-queue";
+import { DeliveryQueue } from "../../../lib/types/delivery-queue";
+
+export default function DeliveryQueueView() {
+	const queueRes = useGetDeliveryQueueQuery();
+
+	return (
+		<div className="delivery-queue-view">
+			<div className="form-section-docs">
+				<h1>Delivery Queue</h1>
+				<p>
+					On this page you can see the outgoing federation deliveries that
+					are currently pending to each domain, either queued awaiting a
+					first attempt, or awaiting retry after a failed attempt.
+				</p>
+				<p>
+					If a domain is gone for good, you can purge its pending deliveries
+					to drop them without further attempts. If a domain has recovered,
+					you can force a retry of its failed deliveries, rather than waiting
+					for their backoff to expire. Forcing a retry also marks the domain
+					as available for deliveries again, if it was marked unavailable.
+				</p>
+				<p>
+					Note that pending deliveries are held in memory, so this page only
+					shows a snapshot of the queue as of when it was loaded.
+				</p>
+			</div>
+			<button
+				type="button"
+				title="Reload delivery queue"
+				onClick={() => queueRes.refetch()}
+				disabled={queueRes.isFetching}
+			>
+				<i className="fa fa-fw fa-refresh" aria-hidden="true" /> Reload
+			</button>
+			<PageableList
+				isLoading={queueRes.isLoading}
+				isFetching={queueRes.isFetching}
+				isSuccess={queueRes.isSuccess}
+				items={queueRes.data}
+				itemToEntry={(queue: DeliveryQueue) => <DeliveryQueueEntry key={queue.domain} queue={queue} />}
+				isError={queueRes.isError}
+				error={queueRes.error}
+				emptyMessage={<b>No deliveries pending.</b>}
+			/>
+		</div>
+	);
+}
+
+interface DeliveryQueueEntryProps {
+	queue: DeliveryQueue;
+}
+
+function DeliveryQueueEntry({ queue }: DeliveryQueueEntryProps) {
+	const [ purge, purgeResult ] = usePurgeDeliveryQueueMutation();
+	const [ retry, retryResult ] = useRetryDeliveryQueueMutation();
+
+	const oldest = queue.oldest_created_at ? new Date(queue.oldest_created_at).toLocaleString() : "unknown";
+	const nextRetry = queue.next_retry_at ? new Date(queue.next_retry_at).toLocaleString() : "none";
+	const lastErrorAt = queue.last_error_at ? new Date(queue.last_error_at).toLocaleString() : undefined;
+
+	return (
+		<div className="entry delivery-queue" aria-label={`Pending deliveries to ${queue.domain}`}>
+			<span className="text-cutoff">
+				<i className="fa fa-fw fa-paper-plane" aria-hidden="true" /> <strong>{queue.domain}</strong>
+				{ queue.unavailable && <> <span className="unavailable">(unavailable)</span></> }
+			</span>
+			<dl className="info-list">
+				<div className="info-list-entry">
+					<dt>Queued:</dt>
+					<dd>{queue.queued}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Awaiting retry:</dt>
+					<dd>{queue.backlogged}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Attempts:</dt>
+					<dd>{queue.attempts} total, {queue.max_attempts} at most for one delivery</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Oldest pending:</dt>
+					<dd>{oldest}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Next retry:</dt>
+					<dd>{nextRetry}</dd>
+				</div>
+				{ queue.last_error &&
+					<div className="info-list-entry">
+						<dt>Last error:</dt>
+						<dd>
+							<span className="monospace">{queue.last_error}</span>
+							{ lastErrorAt && <> ({lastErrorAt})</> }
+						</dd>
+					</div>
+				}
+			</dl>
+			<div className="action-buttons">
+				<MutationButton
+					label="Retry now"
+					title={`Retry failed deliveries to ${queue.domain} now`}
+					type="button"
+					className="button"
+					onClick={(e) => {
+						e.preventDefault();
+						retry(queue.domain);
+					}}
+					disabled={false}
+					showError={true}
+					result={retryResult}
+				/>
+				<MutationButton
+					label="Purge"
+					title={`Drop all pending deliveries to ${queue.domain}`}
+					type="button"
+					className="button danger"
+					onClick={(e) => {
+						e.preventDefault();
+						purge(queue.domain);
+					}}
+					disabled={false}
+					showError={true}
+					result={purgeResult}
+				/>
+			</div>
+		</div>
+	);
+}
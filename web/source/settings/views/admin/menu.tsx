@@ -37,6 +37,7 @@ import { useHasPermission, useInstanceDebug } from "../../lib/navigation/util";
  * - /settings/admin/actions/email
  * - /settings/admin/actions/media
  * - /settings/admin/actions/keys
+ * - /settings/admin/deliveries/queue
  * - /settings/admin/http-header-permissions/blocks
  * - /settings/admin/http-header-permissions/blocks/:blockId\
  * - /settings/admin/http-header-permissions/allows
@@ -59,6 +60,7 @@ export default function AdminMenu() {
 			<AdminInstanceMenu />
 			<AdminEmojisMenu />
 			<AdminActionsMenu />
+			<AdminDeliveriesMenu />
 			<AdminHTTPHeaderPermissionsMenu />
 			<AdminDebugMenu />
 		</MenuItem>
@@ -118,6 +120,23 @@ function AdminActionsMenu() {
 	);
 }
 
+function AdminDeliveriesMenu() {
+	return (
+		<MenuItem
+			name="Deliveries"
+			itemUrl="deliveries"
+			defaultChild="queue"
+			icon="fa-paper-plane"
+		>
+			<MenuItem
+				name="Queue"
+				itemUrl="queue"
+				icon="fa-list-ol"
+			/>
+		</MenuItem>
+	);
+}
+
 function AdminEmojisMenu() {
 	return (
 		<MenuItem
@@ -34,6 +34,7 @@ import HeaderPermDetail from "./http-header-permissions/detail";
 import Email from "./actions/email";
 import ApURL from "./debug/apurl";
 import Caches from "./debug/caches";
+import DeliveryQueueView from "./deliveries/queue";
 
 /*
 	EXPORTED COMPONENTS
@@ -51,6 +52,7 @@ import Caches from "./debug/caches";
  * - /settings/admin/actions/media
  * - /settings/admin/actions/keys
  * - /settings/admin/actions/email
+ * - /settings/admin/deliveries/queue
  * - /settings/admin/http-header-permissions/allows
  * - /settings/admin/http-header-permissions/allows/:allowId
  * - /settings/admin/http-header-permissions/blocks
@@ -68,6 +70,7 @@ export default function AdminRouter() {
 				<AdminInstanceRouter />
 				<AdminEmojisRouter />
 				<AdminActionsRouter />
+				<AdminDeliveriesRouter />
 				<AdminHTTPHeaderPermissionsRouter />
 				<AdminDebugRouter />
 			</Router>
@@ -139,6 +142,28 @@ function AdminActionsRouter() {
 	);
 }
 
+/**
+ * - /settings/admin/deliveries/queue
+ */
+function AdminDeliveriesRouter() {
+	const parentUrl = useBaseUrl();
+	const thisBase = "/deliveries";
+	const absBase = parentUrl + thisBase;
+
+	return (
+		<BaseUrlContext.Provider value={absBase}>
+			<Router base={thisBase}>
+				<ErrorBoundary>
+					<Switch>
+						<Route path="/queue" component={DeliveryQueueView} />
+						<Route><Redirect to="/queue" /></Route>
+					</Switch>
+				</ErrorBoundary>
+			</Router>
+		</BaseUrlContext.Provider>
+	);
+}
+
 /**
  * - /settings/admin/instance/settings
  * - /settings/admin/instance/rules
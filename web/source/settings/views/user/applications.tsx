@@ -0,0 +1,122 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React, { useEffect, useMemo } from "react";
+import { useSearch } from "wouter";
+import { PageableList } from "../../components/pageable-list";
+import MutationButton from "../../components/form/mutation-button";
+import {
+	useDeleteApplicationMutation,
+	useLazyListApplicationsQuery,
+} from "../../lib/query/user/tokens";
+import { Application } from "../../lib/types/token";
+
+export default function Applications() {
+	const search = useSearch();
+	const urlQueryParams = useMemo(() => new URLSearchParams(search), [search]);
+	const [ listApps, listRes ] = useLazyListApplicationsQuery();
+
+	// On mount, and when paging, list applications.
+	useEffect(() => {
+		listApps(Object.fromEntries(urlQueryParams), true);
+	}, [urlQueryParams, listApps]);
+
+	return (
+		<div className="applications-view">
+			<div className="form-section-docs">
+				<h1>Applications</h1>
+				<p>
+					On this page you can see applications that you created yourself
+					using the API, for example when setting up a bot or a script.
+				</p>
+				<p>
+					Deleting an application will also revoke all access tokens issued
+					to that application, for all users. This cannot be undone.
+				</p>
+			</div>
+			<PageableList
+				isLoading={listRes.isLoading}
+				isFetching={listRes.isFetching}
+				isSuccess={listRes.isSuccess}
+				items={listRes.data?.applications}
+				itemToEntry={(app: Application) => <ApplicationListEntry key={app.id} app={app} />}
+				isError={listRes.isError}
+				error={listRes.error}
+				emptyMessage={<b>You haven't created any applications.</b>}
+				prevNextLinks={listRes.data?.links}
+			/>
+		</div>
+	);
+}
+
+interface ApplicationListEntryProps {
+	app: Application;
+}
+
+function ApplicationListEntry({ app }: ApplicationListEntryProps) {
+	const [ deleteApp, deleteResult ] = useDeleteApplicationMutation();
+	const created = app.created_at ? new Date(app.created_at).toLocaleString() : "unknown";
+
+	return (
+		<div className="entry application" aria-label={`Application ${app.name}`}>
+			<span className="text-cutoff">
+				<i className="fa fa-fw fa-cube" aria-hidden="true" /> <strong>{app.name}</strong>
+			</span>
+			<dl className="info-list">
+				{ app.website &&
+					<div className="info-list-entry">
+						<dt>Website:</dt>
+						<dd>{app.website}</dd>
+					</div>
+				}
+				<div className="info-list-entry">
+					<dt>Client ID:</dt>
+					<dd className="monospace">{app.client_id}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Redirect URI:</dt>
+					<dd className="monospace">{app.redirect_uri}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Scopes:</dt>
+					<dd className="monospace">{app.scopes?.join(" ") || "read"}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Created:</dt>
+					<dd>{created}</dd>
+				</div>
+			</dl>
+			<div className="action-buttons">
+				<MutationButton
+					label="Delete"
+					title={`Delete application ${app.name}`}
+					type="button"
+					className="button danger"
+					onClick={(e) => {
+						e.preventDefault();
+						deleteApp(app.id);
+					}}
+					disabled={false}
+					showError={true}
+					result={deleteResult}
+				/>
+			</div>
+		</div>
+	);
+}
@@ -0,0 +1,131 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React from "react";
+import {
+	useAccountArchivesQuery,
+	useRequestAccountArchiveMutation,
+	useDownloadAccountArchiveMutation,
+} from "../../../lib/query/user/export-import";
+import MutationButton from "../../../components/form/mutation-button";
+import useFormSubmit from "../../../lib/form/submit";
+import { useValue } from "../../../lib/form";
+import Loading from "../../../components/loading";
+import { Error } from "../../../components/error";
+import { AccountArchive } from "../../../lib/types/account";
+
+export default function Archive() {
+	const {
+		data: archives,
+		isLoading,
+		isError,
+		error,
+	} = useAccountArchivesQuery();
+
+	const [requestArchive, requestArchiveResult] = useFormSubmit(
+		// Use a dummy value.
+		{ type: useValue("requestArchive", "requestArchive") },
+		// Mutation we're wrapping.
+		useRequestAccountArchiveMutation(),
+		// Form never changes but
+		// we want to always trigger.
+		{ changedOnly: false },
+	);
+
+	if (isLoading) {
+		return <Loading />;
+	}
+
+	if (isError) {
+		return <Error error={error} />;
+	}
+
+	const processing = archives?.some((archive) => archive.state === "processing");
+
+	return (
+		<form className="export-data">
+			<div className="form-section-docs">
+				<h3>Export Archive</h3>
+				<a
+					href="https://docs.gotosocial.org/en/latest/user_guide/settings/#export-archive"
+					target="_blank"
+					className="docslink"
+					rel="noreferrer"
+				>
+				Learn more about this section (opens in a new tab)
+				</a>
+			</div>
+			<p>
+				Request a zip archive of your profile, statuses, likes, bookmarks and media.
+				The archive is built in the background; once it's ready, you can download it
+				here for 7 days. You can request a new archive once every 24 hours.
+			</p>
+
+			<div className="export-buttons-wrapper">
+				{archives?.map((archive) => (
+					<ArchiveEntry key={archive.id} archive={archive} />
+				))}
+				<div className="stats-and-button">
+					<span className="text-cutoff">
+						{processing ? "Archive is being built" : "New archive"}
+					</span>
+					<MutationButton
+						className="text-cutoff"
+						label="Request archive"
+						type="button"
+						onClick={() => requestArchive()}
+						result={requestArchiveResult}
+						showError={true}
+						disabled={processing}
+					/>
+				</div>
+			</div>
+		</form>
+	);
+}
+
+function ArchiveEntry({ archive }: { archive: AccountArchive }) {
+	const [downloadArchive, downloadArchiveResult] = useDownloadAccountArchiveMutation();
+
+	const created = new Date(archive.created_at).toLocaleString();
+	let label = `Archive from ${created}`;
+	switch (archive.state) {
+		case "processing":
+			label += " (processing)";
+			break;
+		case "failed":
+			label += " (failed)";
+			break;
+	}
+
+	return (
+		<div className="stats-and-button">
+			<span className="text-cutoff" title={label}>{label}</span>
+			<MutationButton
+				className="text-cutoff"
+				label="Download archive"
+				type="button"
+				onClick={() => downloadArchive(archive.id)}
+				result={downloadArchiveResult}
+				showError={true}
+				disabled={archive.state !== "ready"}
+			/>
+		</div>
+	);
+}
@@ -55,9 +55,9 @@ export default function Import() {
 			</div>
 			
 			<FileInput
-				label="CSV data file"
+				label="CSV data file or archive"
 				field={form.data}
-				accept="text/csv"
+				accept="text/csv,application/zip"
 			/>
 
 			<Select
@@ -68,6 +68,10 @@ export default function Import() {
 						<option value="">- Select import type -</option>
 						<option value="following">Following list</option>
 						<option value="blocks">Blocked accounts list</option>
+						<option value="mutes">Muted accounts list</option>
+						<option value="bookmarks">Bookmarks</option>
+						<option value="lists">Lists</option>
+						<option value="archive">Statuses from account archive (merge only)</option>
 					</>
 				}>
 			</Select>
@@ -23,6 +23,7 @@ import Loading from "../../../components/loading";
 import { Error } from "../../../components/error";
 import { useExportStatsQuery } from "../../../lib/query/user/export-import";
 import Import from "./import";
+import Archive from "./archive";
 
 export default function ExportImport() {
 	const {
@@ -50,9 +51,11 @@ export default function ExportImport() {
 			<h1>Export & Import</h1>
 			<p>
 				On this page you can export data from your GoToSocial account, or import data into
-				your GoToSocial account. All exports and imports use Mastodon-compatible CSV files.
+				your GoToSocial account. All exports and imports use Mastodon-compatible CSV files
+				or archives.
 			</p>
 			<Export exportStats={exportStats} />
+			<Archive />
 			<Import />
 		</>
 	);
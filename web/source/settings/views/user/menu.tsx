@@ -24,6 +24,9 @@ import React from "react";
  * - /settings/user/profile
  * - /settings/user/posts
  * - /settings/user/emailpassword
+ * - /settings/user/twofactor
+ * - /settings/user/tokens
+ * - /settings/user/applications
  * - /settings/user/migration
  */
 export default function UserMenu() {	
@@ -53,6 +56,21 @@ export default function UserMenu() {
 				itemUrl="emailpassword"
 				icon="fa-user-secret"
 			/>
+			<MenuItem
+				name="Two Factor Auth"
+				itemUrl="twofactor"
+				icon="fa-lock"
+			/>
+			<MenuItem
+				name="Access Tokens"
+				itemUrl="tokens"
+				icon="fa-key"
+			/>
+			<MenuItem
+				name="Applications"
+				itemUrl="applications"
+				icon="fa-cubes"
+			/>
 			<MenuItem
 				name="Migration"
 				itemUrl="migration"
@@ -25,6 +25,9 @@ import UserProfile from "./profile";
 import UserMigration from "./migration";
 import PostSettings from "./posts";
 import EmailPassword from "./emailpassword";
+import TwoFactor from "./twofactor";
+import Tokens from "./tokens";
+import Applications from "./applications";
 import ExportImport from "./export-import";
 import InteractionRequests from "./interactions";
 import InteractionRequestDetail from "./interactions/detail";
@@ -33,6 +36,9 @@ import InteractionRequestDetail from "./interactions/detail";
  * - /settings/user/profile
  * - /settings/user/posts
  * - /settings/user/emailpassword
+ * - /settings/user/twofactor
+ * - /settings/user/tokens
+ * - /settings/user/applications
  * - /settings/user/migration
  * - /settings/user/export-import
  * - /settings/users/interaction_requests
@@ -50,6 +56,9 @@ export default function UserRouter() {
 						<Route path="/profile" component={UserProfile} />
 						<Route path="/posts" component={PostSettings} />
 						<Route path="/emailpassword" component={EmailPassword} />
+						<Route path="/twofactor" component={TwoFactor} />
+						<Route path="/tokens" component={Tokens} />
+						<Route path="/applications" component={Applications} />
 						<Route path="/migration" component={UserMigration} />
 						<Route path="/export-import" component={ExportImport} />
 						<InteractionRequestsRouter />
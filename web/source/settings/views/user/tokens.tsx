@@ -0,0 +1,144 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React, { ReactNode, useEffect, useMemo } from "react";
+import { useSearch } from "wouter";
+import { PageableList } from "../../components/pageable-list";
+import MutationButton from "../../components/form/mutation-button";
+import {
+	useInvalidateAppTokensMutation,
+	useInvalidateTokenMutation,
+	useLazyListTokensQuery,
+} from "../../lib/query/user/tokens";
+import { TokenInfo } from "../../lib/types/token";
+
+export default function Tokens() {
+	const search = useSearch();
+	const urlQueryParams = useMemo(() => new URLSearchParams(search), [search]);
+	const [ listTokens, listRes ] = useLazyListTokensQuery();
+
+	// On mount, and when paging, list tokens.
+	useEffect(() => {
+		listTokens(Object.fromEntries(urlQueryParams), true);
+	}, [urlQueryParams, listTokens]);
+
+	return (
+		<div className="tokens-view">
+			<div className="form-section-docs">
+				<h1>Access Tokens</h1>
+				<p>
+					On this page you can see the access tokens that have been issued
+					to applications you've signed in to with this account, such as
+					apps on your phone, or web clients in your browser.
+				</p>
+				<p>
+					If you no longer use an application, or you've lost a device that
+					was signed in, you can revoke its token here. Applications using a
+					revoked token will be signed out, and will have to sign in again.
+				</p>
+				<p>
+					The "last used" time is approximate, and may be up to an hour out.
+				</p>
+			</div>
+			<PageableList
+				isLoading={listRes.isLoading}
+				isFetching={listRes.isFetching}
+				isSuccess={listRes.isSuccess}
+				items={listRes.data?.tokens}
+				itemToEntry={(token: TokenInfo) => <TokenListEntry key={token.id} token={token} />}
+				isError={listRes.isError}
+				error={listRes.error}
+				emptyMessage={<b>No access tokens found.</b>}
+				prevNextLinks={listRes.data?.links}
+			/>
+		</div>
+	);
+}
+
+interface TokenListEntryProps {
+	token: TokenInfo;
+}
+
+function TokenListEntry({ token }: TokenListEntryProps) {
+	const [ invalidate, invalidateResult ] = useInvalidateTokenMutation();
+	const [ invalidateApp, invalidateAppResult ] = useInvalidateAppTokensMutation();
+
+	const app = token.application;
+	const created = new Date(token.created_at).toLocaleString();
+	const lastUsed = token.last_used ? new Date(token.last_used).toLocaleString() : "unknown";
+
+	let appName: ReactNode = app.name;
+	if (app.website) {
+		appName = (
+			<a href={app.website} target="_blank" rel="nofollow noreferrer noopener">
+				{app.name}
+			</a>
+		);
+	}
+
+	return (
+		<div className="entry token" aria-label={`Access token for ${app.name}`}>
+			<span className="text-cutoff">
+				<i className="fa fa-fw fa-key" aria-hidden="true" /> <strong>{appName}</strong>
+			</span>
+			<dl className="info-list">
+				<div className="info-list-entry">
+					<dt>Scopes:</dt>
+					<dd className="monospace">{token.scope || "read"}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Created:</dt>
+					<dd>{created}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Last used:</dt>
+					<dd>{lastUsed}</dd>
+				</div>
+			</dl>
+			<div className="action-buttons">
+				<MutationButton
+					label="Revoke"
+					title={`Revoke this token for ${app.name}`}
+					type="button"
+					className="button danger"
+					onClick={(e) => {
+						e.preventDefault();
+						invalidate(token.id);
+					}}
+					disabled={false}
+					showError={true}
+					result={invalidateResult}
+				/>
+				<MutationButton
+					label="Revoke all for this app"
+					title={`Revoke all of your tokens for ${app.name}`}
+					type="button"
+					className="button danger"
+					onClick={(e) => {
+						e.preventDefault();
+						invalidateApp(app.id);
+					}}
+					disabled={false}
+					showError={true}
+					result={invalidateAppResult}
+				/>
+			</div>
+		</div>
+	);
+}
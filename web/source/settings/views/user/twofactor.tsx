@@ -0,0 +1,325 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React, { useState } from "react";
+import { useTextInput } from "../../lib/form";
+import useFormSubmit from "../../lib/form/submit";
+import { TextInput } from "../../components/form/inputs";
+import MutationButton from "../../components/form/mutation-button";
+import Loading from "../../components/loading";
+import { Error } from "../../components/error";
+import { User } from "../../lib/types/user";
+import { useInstanceV1Query } from "../../lib/query/gts-api";
+import {
+	useTwoFactorDisableMutation,
+	useTwoFactorEnableMutation,
+	useTwoFactorQRCodePngQuery,
+	useTwoFactorQRCodeURIMutation,
+	useUserQuery,
+} from "../../lib/query/user";
+
+export default function TwoFactor() {
+	// Load instance data.
+	const {
+		data: instance,
+		isFetching: isFetchingInstance,
+		isLoading: isLoadingInstance
+	} = useInstanceV1Query();
+	
+	// Load user data.
+	const {
+		data: user,
+		isFetching: isFetchingUser,
+		isLoading: isLoadingUser
+	} = useUserQuery();
+
+	// Recovery codes are only returned once, when 2FA
+	// is first enabled, so keep hold of them up here
+	// to survive the user being refetched.
+	const [ recoveryCodes, setRecoveryCodes ] = useState<string[]>();
+
+	if (
+		(isFetchingInstance || isLoadingInstance) ||
+		(isFetchingUser || isLoadingUser)
+	) {
+		return <Loading />;
+	}
+
+	if (user === undefined) {
+		throw "could not fetch user";
+	}
+
+	if (instance === undefined) {
+		throw "could not fetch instance";
+	}
+
+	return (
+		<>
+			<h1>Two Factor Authentication</h1>
+			<div className="form-section-docs">
+				<p>
+					Two factor authentication (2FA) adds an extra step when signing in:
+					after entering your email address and password, you will be asked for
+					a 6 digit code from an authenticator app on your phone or computer.
+				</p>
+				<a
+					href="https://docs.gotosocial.org/en/latest/user_guide/settings/#two-factor-authentication"
+					target="_blank"
+					className="docslink"
+					rel="noreferrer"
+				>
+					Learn more about this (opens in a new tab)
+				</a>
+			</div>
+			{ instance.configuration.oidc_enabled
+				? <p>
+					This instance is running with OIDC as its authorization + identity provider.
+					<br/>
+					This means <strong>you cannot set up two factor authentication using this settings panel</strong>.
+					<br/>
+					To set up two factor authentication, you should instead contact your OIDC provider.
+				</p>
+				: <TwoFactorSettings
+					user={user}
+					recoveryCodes={recoveryCodes}
+					setRecoveryCodes={setRecoveryCodes}
+				/>
+			}
+		</>
+	);
+}
+
+interface TwoFactorSettingsProps {
+	user: User;
+	recoveryCodes?: string[];
+	setRecoveryCodes: (_codes?: string[]) => void;
+}
+
+function TwoFactorSettings({ user, recoveryCodes, setRecoveryCodes }: TwoFactorSettingsProps) {
+	if (recoveryCodes !== undefined) {
+		return (
+			<RecoveryCodes
+				codes={recoveryCodes}
+				onDone={() => setRecoveryCodes(undefined)}
+			/>
+		);
+	}
+
+	if (user.two_factor_enabled_at) {
+		return <DisableTwoFactor user={user} />;
+	}
+
+	return <EnableTwoFactor onEnabled={setRecoveryCodes} />;
+}
+
+function EnableTwoFactor({ onEnabled }: { onEnabled: (_codes: string[]) => void }) {
+	// Requesting the uri generates a new secret for
+	// the user, so only do it when they ask to start.
+	const [ getQRCodeURI, uri ] = useTwoFactorQRCodeURIMutation();
+
+	if (uri.data === undefined) {
+		return (
+			<div className="form-section-docs">
+				<h3>Enable 2FA</h3>
+				<p>Two factor authentication is currently <strong>not enabled</strong> for your account.</p>
+				{ uri.error && <Error error={uri.error} reset={uri.reset} /> }
+				<button
+					onClick={() => getQRCodeURI()}
+					disabled={uri.isLoading}
+				>
+					Set up two factor authentication
+				</button>
+			</div>
+		);
+	}
+
+	return <EnableTwoFactorForm uri={uri.data} onEnabled={onEnabled} />;
+}
+
+interface EnableTwoFactorFormProps {
+	uri: string;
+	onEnabled: (_codes: string[]) => void;
+}
+
+function EnableTwoFactorForm({ uri, onEnabled }: EnableTwoFactorFormProps) {
+	const png = useTwoFactorQRCodePngQuery(uri);
+
+	const form = {
+		password: useTextInput("password"),
+		code: useTextInput("code", {
+			validator(val) {
+				if (val && !/^[0-9]{6}$/.test(val)) {
+					return "Code should be 6 digits";
+				}
+				return "";
+			}
+		}),
+	};
+
+	const [submitForm, result] = useFormSubmit(
+		form,
+		useTwoFactorEnableMutation(),
+		{
+			changedOnly: false,
+			onFinish: (res) => {
+				if (res.data) {
+					onEnabled(res.data);
+				}
+			},
+		},
+	);
+
+	if (png.isLoading) {
+		return <Loading />;
+	}
+
+	if (png.error) {
+		return <Error error={png.error} />;
+	}
+
+	// Pull the secret out of the uri so the
+	// user can type it in if they can't scan.
+	let secret = "";
+	try {
+		secret = new URL(uri).searchParams.get("secret") ?? "";
+	} catch {
+		// Leave it empty.
+	}
+
+	return (
+		<form className="two-factor-enable" onSubmit={submitForm}>
+			<div className="form-section-docs">
+				<h3>Enable 2FA</h3>
+				<p>
+					Scan the QR code below with your authenticator app, then enter
+					the 6 digit code it shows and your password to confirm that
+					everything is working.
+				</p>
+			</div>
+
+			<img
+				className="two-factor-qr"
+				src={png.data}
+				alt="QR code for setting up two factor authentication."
+			/>
+
+			{ secret && <p>
+				Can't scan the QR code? <a href={uri}>Open it in your app</a>,
+				or enter this secret key into your app instead:
+				<br/>
+				<code>{secret}</code>
+			</p> }
+
+			<TextInput
+				name="code"
+				field={form.code}
+				label="Code from your authenticator app"
+				autoComplete="one-time-code"
+				inputMode="numeric"
+			/>
+
+			<TextInput
+				type="password"
+				name="password"
+				field={form.password}
+				label="Current password"
+				autoComplete="current-password"
+			/>
+
+			<MutationButton
+				label="Enable 2FA"
+				result={result}
+				disabled={
+					!form.code.value ||
+					!form.code.valid ||
+					!form.password.value
+				}
+			/>
+		</form>
+	);
+}
+
+function RecoveryCodes({ codes, onDone }: { codes: string[], onDone: () => void }) {
+	return (
+		<div className="form-section-docs">
+			<h3>Recovery codes</h3>
+			<div className="info">
+				<i className="fa fa-fw fa-info-circle" aria-hidden="true"></i>
+				<b>
+					Two factor authentication is now enabled! Save these recovery
+					codes somewhere safe, as <strong>they will not be shown again</strong>.
+				</b>
+			</div>
+			<p>
+				If you lose access to your authenticator app, you can sign in by
+				entering one of these codes instead. Each code can only be used once.
+			</p>
+			<ul className="two-factor-recovery-codes">
+				{ codes.map((code) => <li key={code}><code>{code}</code></li>) }
+			</ul>
+			<button onClick={onDone}>
+				I've saved my recovery codes
+			</button>
+		</div>
+	);
+}
+
+function DisableTwoFactor({ user }: { user: User }) {
+	const form = {
+		password: useTextInput("password"),
+	};
+
+	const [submitForm, result] = useFormSubmit(
+		form,
+		useTwoFactorDisableMutation(),
+		{ changedOnly: false },
+	);
+
+	const enabledAt = new Date(user.two_factor_enabled_at as string).toLocaleString();
+
+	return (
+		<form className="two-factor-disable" onSubmit={submitForm}>
+			<div className="form-section-docs">
+				<h3>Disable 2FA</h3>
+				<p>
+					Two factor authentication has been <strong>enabled</strong> for
+					your account since {enabledAt}.
+				</p>
+				<p>
+					Disabling it will remove your recovery codes. To enable it again,
+					you will need to scan a new QR code with your authenticator app.
+				</p>
+			</div>
+
+			<TextInput
+				type="password"
+				name="password"
+				field={form.password}
+				label="Current password"
+				autoComplete="current-password"
+			/>
+
+			<MutationButton
+				label="Disable 2FA"
+				result={result}
+				disabled={!form.password.value}
+			/>
+		</form>
+	);
+}